@@ -131,6 +131,7 @@ export interface RepositoryMatch {
     archived?: boolean
     private?: boolean
     branches?: string[]
+    dependencyPath?: string[]
 }
 
 /**
@@ -9,7 +9,9 @@ import GitIcon from 'mdi-react/GitIcon'
 import GitLabIcon from 'mdi-react/GitlabIcon'
 import LanguageGoIcon from 'mdi-react/LanguageGoIcon'
 import LanguageJavaIcon from 'mdi-react/LanguageJavaIcon'
+import LanguagePhpIcon from 'mdi-react/LanguagePhpIcon'
 import LanguagePythonIcon from 'mdi-react/LanguagePythonIcon'
+import LanguageRubyIcon from 'mdi-react/LanguageRubyIcon'
 import LanguageRustIcon from 'mdi-react/LanguageRustIcon'
 import NpmIcon from 'mdi-react/NpmIcon'
 
@@ -30,8 +32,11 @@ import otherExternalServiceSchemaJSON from '../../../../../schema/other_external
 import pagureSchemaJSON from '../../../../../schema/pagure.schema.json'
 import perforceSchemaJSON from '../../../../../schema/perforce.schema.json'
 import phabricatorSchemaJSON from '../../../../../schema/phabricator.schema.json'
+import phpPackagesJSON from '../../../../../schema/php-packages.schema.json'
 import pythonPackagesJSON from '../../../../../schema/python-packages.schema.json'
+import rubyPackagesJSON from '../../../../../schema/ruby-packages.schema.json'
 import rustPackagesJSON from '../../../../../schema/rust-packages.schema.json'
+import sourcegraphSchemaJSON from '../../../../../schema/sourcegraph.schema.json'
 import { ExternalServiceKind } from '../../graphql-operations'
 import { EditorAction } from '../../site-admin/configHelpers'
 import { PerforceIcon } from '../PerforceIcon'
@@ -1255,6 +1260,40 @@ const PAGURE: AddExternalServiceOptions = {
     editorActions: [],
 }
 
+const SOURCEGRAPH: AddExternalServiceOptions = {
+    kind: ExternalServiceKind.SOURCEGRAPH,
+    title: 'Sourcegraph',
+    icon: GitIcon,
+    jsonSchema: sourcegraphSchemaJSON,
+    defaultDisplayName: 'Sourcegraph',
+    defaultConfig: `{
+  "url": "https://sourcegraph.example.com",
+  "token": "<access token>",
+  "gitURL": "http://sourcegraph-frontend-internal:3090/.internal/git",
+  "repositoryQuery": []
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>url</Field> to the URL of the remote Sourcegraph instance
+                    and <Field>token</Field> to an access token of a user on it.
+                </li>
+                <li>
+                    Set <Field>gitURL</Field> to the internal git endpoint of the remote instance. It must be reachable
+                    from the gitservers of this instance.
+                </li>
+                <li>
+                    Optionally, set <Field>repositoryQuery</Field> to search queries in the remote's query syntax,
+                    such as <Code>"repo:^github\\.com/myorg/"</Code>, to select the repositories to mirror.
+                </li>
+            </ol>
+            <Text>⚠️ Repository permissions of the remote instance are not enforced on this instance.</Text>
+        </div>
+    ),
+    editorActions: [],
+}
+
 const GERRIT: AddExternalServiceOptions = {
     kind: ExternalServiceKind.GERRIT,
     title: 'Gerrit',
@@ -1398,6 +1437,54 @@ const RUST_PACKAGES = {
     editorActions: [],
 }
 
+const RUBY_PACKAGES = {
+    kind: ExternalServiceKind.RUBYPACKAGES,
+    title: 'Ruby Dependencies',
+    icon: LanguageRubyIcon,
+    jsonSchema: rubyPackagesJSON,
+    defaultDisplayName: 'Ruby Dependencies',
+    defaultConfig: `{
+  "dependencies": []
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>dependencies</Field> to the list of packages that you want to
+                    manually add. For example, <Code>"rails@7.0.3"</Code>.
+                </li>
+            </ol>
+            <Text>⚠️ Ruby package repositories are visible by all users of the Sourcegraph instance.</Text>
+            <Text>⚠️ It is only possible to register one Ruby packages code host per Sourcegraph instance.</Text>
+        </div>
+    ),
+    editorActions: [],
+}
+
+const PHP_PACKAGES = {
+    kind: ExternalServiceKind.PHPPACKAGES,
+    title: 'PHP Dependencies',
+    icon: LanguagePhpIcon,
+    jsonSchema: phpPackagesJSON,
+    defaultDisplayName: 'PHP Dependencies',
+    defaultConfig: `{
+  "dependencies": []
+}`,
+    instructions: (
+        <div>
+            <ol>
+                <li>
+                    In the configuration below, set <Field>dependencies</Field> to the list of packages that you want to
+                    manually add. For example, <Code>"monolog/monolog@2.7.0"</Code>.
+                </li>
+            </ol>
+            <Text>⚠️ PHP package repositories are visible by all users of the Sourcegraph instance.</Text>
+            <Text>⚠️ It is only possible to register one PHP packages code host per Sourcegraph instance.</Text>
+        </div>
+    ),
+    editorActions: [],
+}
+
 export const codeHostExternalServices: Record<string, AddExternalServiceOptions> = {
     github: GITHUB_DOTCOM,
     ghe: GITHUB_ENTERPRISE,
@@ -1412,10 +1499,13 @@ export const codeHostExternalServices: Record<string, AddExternalServiceOptions>
     goModules: GO_MODULES,
     pythonPackages: PYTHON_PACKAGES,
     rustPackages: RUST_PACKAGES,
+    rubyPackages: RUBY_PACKAGES,
+    phpPackages: PHP_PACKAGES,
     ...(window.context?.experimentalFeatures?.perforce === 'enabled' ? { perforce: PERFORCE } : {}),
     ...(window.context?.experimentalFeatures?.jvmPackages === 'disabled' ? {} : { jvmPackages: JVM_PACKAGES }),
     ...(window.context?.experimentalFeatures?.pagure === 'enabled' ? { pagure: PAGURE } : {}),
     ...(window.context?.experimentalFeatures?.gerrit === 'enabled' ? { gerrit: GERRIT } : {}),
+    ...(window.context?.experimentalFeatures?.sourcegraphFederation === 'enabled' ? { sourcegraph: SOURCEGRAPH } : {}),
     ...(window.context?.experimentalFeatures?.npmPackages === 'disabled' ? {} : { npmPackages: NPM_PACKAGES }),
 }
 
@@ -1445,4 +1535,7 @@ export const defaultExternalServices: Record<ExternalServiceKind, AddExternalSer
     [ExternalServiceKind.NPMPACKAGES]: NPM_PACKAGES,
     [ExternalServiceKind.PYTHONPACKAGES]: PYTHON_PACKAGES,
     [ExternalServiceKind.RUSTPACKAGES]: RUST_PACKAGES,
+    [ExternalServiceKind.RUBYPACKAGES]: RUBY_PACKAGES,
+    [ExternalServiceKind.PHPPACKAGES]: PHP_PACKAGES,
+    [ExternalServiceKind.SOURCEGRAPH]: SOURCEGRAPH,
 }
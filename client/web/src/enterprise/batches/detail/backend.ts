@@ -44,6 +44,8 @@ import {
     CloseChangesetsVariables,
     PublishChangesetsResult,
     PublishChangesetsVariables,
+    RetryChangesetChecksResult,
+    RetryChangesetChecksVariables,
     AvailableBulkOperationsVariables,
     AvailableBulkOperationsResult,
     BulkOperationType,
@@ -786,6 +788,20 @@ export async function publishChangesets(
     dataOrThrowErrors(result)
 }
 
+export async function retryChangesetChecks(batchChange: Scalars['ID'], changesets: Scalars['ID'][]): Promise<void> {
+    const result = await requestGraphQL<RetryChangesetChecksResult, RetryChangesetChecksVariables>(
+        gql`
+            mutation RetryChangesetChecks($batchChange: ID!, $changesets: [ID!]!) {
+                retryChangesetChecks(batchChange: $batchChange, changesets: $changesets) {
+                    id
+                }
+            }
+        `,
+        { batchChange, changesets }
+    ).toPromise()
+    dataOrThrowErrors(result)
+}
+
 export const BULK_OPERATIONS = gql`
     query BatchChangeBulkOperations($batchChange: ID!, $first: Int, $after: String) {
         node(id: $batchChange) {
@@ -4,6 +4,7 @@ import classNames from 'classnames'
 import CommentOutlineIcon from 'mdi-react/CommentOutlineIcon'
 import ExternalLinkIcon from 'mdi-react/ExternalLinkIcon'
 import LinkVariantRemoveIcon from 'mdi-react/LinkVariantRemoveIcon'
+import RefreshIcon from 'mdi-react/RefreshIcon'
 import SourceBranchIcon from 'mdi-react/SourceBranchIcon'
 import SyncIcon from 'mdi-react/SyncIcon'
 import UploadIcon from 'mdi-react/UploadIcon'
@@ -50,6 +51,11 @@ const OPERATION_TITLES: Record<BulkOperationType, JSX.Element> = {
             <Icon role="img" aria-hidden={true} className="text-muted" as={UploadIcon} /> Publish changesets
         </>
     ),
+    RETRY_CHECKS: (
+        <>
+            <Icon role="img" aria-hidden={true} className="text-muted" as={RefreshIcon} /> Retry failed checks
+        </>
+    ),
 }
 
 export interface BulkOperationNodeProps {
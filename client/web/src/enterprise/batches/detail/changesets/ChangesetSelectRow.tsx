@@ -21,6 +21,7 @@ import { DetachChangesetsModal } from './DetachChangesetsModal'
 import { MergeChangesetsModal } from './MergeChangesetsModal'
 import { PublishChangesetsModal } from './PublishChangesetsModal'
 import { ReenqueueChangesetsModal } from './ReenqueueChangesetsModal'
+import { RetryChangesetChecksModal } from './RetryChangesetChecksModal'
 
 /**
  * Describes a possible action on the changeset list.
@@ -130,6 +131,22 @@ const AVAILABLE_ACTIONS: Record<BulkOperationType, ChangesetListAction> = {
             />
         ),
     },
+    [BulkOperationType.RETRY_CHECKS]: {
+        type: 'retry-checks',
+        experimental: true,
+        buttonLabel: 'Retry failed checks',
+        dropdownTitle: 'Retry failed checks',
+        dropdownDescription:
+            'Re-run the failed CI checks of all selected changesets on the code hosts. Only GitHub check suites and GitLab pipelines can be re-run.',
+        onTrigger: (batchChangeID, changesetIDs, onDone, onCancel) => (
+            <RetryChangesetChecksModal
+                batchChangeID={batchChangeID}
+                changesetIDs={changesetIDs}
+                afterCreate={onDone}
+                onCancel={onCancel}
+            />
+        ),
+    },
 }
 
 export interface ChangesetSelectRowProps {
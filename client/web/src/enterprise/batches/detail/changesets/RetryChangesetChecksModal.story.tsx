@@ -0,0 +1,31 @@
+import { action } from '@storybook/addon-actions'
+import { storiesOf } from '@storybook/react'
+import { noop } from 'lodash'
+
+import { WebStory } from '../../../../components/WebStory'
+
+import { RetryChangesetChecksModal } from './RetryChangesetChecksModal'
+
+const { add } = storiesOf('web/batches/details/RetryChangesetChecksModal', module).addDecorator(story => (
+    <div className="p-3 container">{story()}</div>
+))
+
+const retryChangesetChecks = () => {
+    action('RetryChangesetChecks')
+    return Promise.resolve()
+}
+
+add('Confirmation', () => (
+    <WebStory>
+        {props => (
+            <RetryChangesetChecksModal
+                {...props}
+                afterCreate={noop}
+                batchChangeID="test-123"
+                changesetIDs={['test-123', 'test-234']}
+                onCancel={noop}
+                retryChangesetChecks={retryChangesetChecks}
+            />
+        )}
+    </WebStory>
+))
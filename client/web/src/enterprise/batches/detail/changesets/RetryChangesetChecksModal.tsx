@@ -0,0 +1,70 @@
+import React, { useCallback, useState } from 'react'
+
+import { ErrorAlert } from '@sourcegraph/branded/src/components/alerts'
+import { asError, isErrorLike } from '@sourcegraph/common'
+import { Button, Modal, H3, Text } from '@sourcegraph/wildcard'
+
+import { LoaderButton } from '../../../../components/LoaderButton'
+import { Scalars } from '../../../../graphql-operations'
+import { retryChangesetChecks as _retryChangesetChecks } from '../backend'
+
+export interface RetryChangesetChecksModalProps {
+    onCancel: () => void
+    afterCreate: () => void
+    batchChangeID: Scalars['ID']
+    changesetIDs: Scalars['ID'][]
+
+    /** For testing only. */
+    retryChangesetChecks?: typeof _retryChangesetChecks
+}
+
+export const RetryChangesetChecksModal: React.FunctionComponent<React.PropsWithChildren<RetryChangesetChecksModalProps>> = ({
+    onCancel,
+    afterCreate,
+    batchChangeID,
+    changesetIDs,
+    retryChangesetChecks = _retryChangesetChecks,
+}) => {
+    const [isLoading, setIsLoading] = useState<boolean | Error>(false)
+
+    const onSubmit = useCallback<React.FormEventHandler>(async () => {
+        setIsLoading(true)
+        try {
+            await retryChangesetChecks(batchChangeID, changesetIDs)
+            afterCreate()
+        } catch (error) {
+            setIsLoading(asError(error))
+        }
+    }, [changesetIDs, retryChangesetChecks, batchChangeID, afterCreate])
+
+    return (
+        <Modal onDismiss={onCancel} aria-labelledby={MODAL_LABEL_ID}>
+            <H3 id={MODAL_LABEL_ID}>Retry failed checks</H3>
+            <Text className="mb-4">
+                Are you sure you want to re-run the failed checks of all the selected changesets on the code hosts?
+            </Text>
+            {isErrorLike(isLoading) && <ErrorAlert error={isLoading} />}
+            <div className="d-flex justify-content-end">
+                <Button
+                    disabled={isLoading === true}
+                    className="mr-2"
+                    onClick={onCancel}
+                    outline={true}
+                    variant="secondary"
+                >
+                    Cancel
+                </Button>
+                <LoaderButton
+                    onClick={onSubmit}
+                    disabled={isLoading === true}
+                    variant="primary"
+                    loading={isLoading === true}
+                    alwaysShowLabel={true}
+                    label="Retry"
+                />
+            </div>
+        </Modal>
+    )
+}
+
+const MODAL_LABEL_ID = 'retry-changeset-checks-modal-title'
@@ -271,6 +271,22 @@ export const RepoContainer: React.FunctionComponent<React.PropsWithChildren<Repo
         }, [repoOrError, resolvedRevisionOrError, props.telemetryService])
     )
 
+    // Redirect to the new name of a repository that has been renamed on its code host.
+    const { history } = props
+    useEffect(() => {
+        if (!repoOrError || isErrorLike(repoOrError) || repoOrError.name === repoName) {
+            return
+        }
+        const oldPrefix = `/${encodeURIPathComponent(repoName)}`
+        if (location.pathname.startsWith(oldPrefix)) {
+            history.replace(
+                `/${encodeURIPathComponent(repoOrError.name)}${location.pathname.slice(oldPrefix.length)}${
+                    location.search
+                }${location.hash}`
+            )
+        }
+    }, [repoOrError, repoName, history])
+
     // Update the workspace roots service to reflect the current repo / resolved revision
     useEffect(() => {
         const workspaceRootUri =
@@ -39,6 +39,18 @@ func (e ErrRepoSeeOther) Error() string {
 	return fmt.Sprintf("repo not found at this location, but might exist at %s", e.RedirectURL)
 }
 
+// ErrRepoRenamed indicates that the repo does not exist on this server under
+// the requested name, because it has been renamed or transferred on its code
+// host. Callers should redirect users to the new name.
+type ErrRepoRenamed struct {
+	// NewName is the current name of the repository.
+	NewName api.RepoName
+}
+
+func (e ErrRepoRenamed) Error() string {
+	return fmt.Sprintf("repo has been renamed to %s", e.NewName)
+}
+
 // NewRepos uses the provided `database.RepoStore` to initialize a new repos
 // store for the backend.
 //
@@ -73,6 +85,7 @@ func (s *repos) Get(ctx context.Context, repo api.RepoID) (_ *types.Repo, err er
 
 // GetByName retrieves the repository with the given name. It will lazy sync a repo
 // not yet present in the database under certain conditions. See repos.Syncer.SyncRepo.
+// If the repo has been renamed, an ErrRepoRenamed is returned.
 func (s *repos) GetByName(ctx context.Context, name api.RepoName) (_ *types.Repo, err error) {
 	if Mocks.Repos.GetByName != nil {
 		return Mocks.Repos.GetByName(ctx, name)
@@ -90,6 +103,13 @@ func (s *repos) GetByName(ctx context.Context, name api.RepoName) (_ *types.Repo
 		return nil, err
 	}
 
+	// The repo may have been renamed or transferred on its code host.
+	if repo, err := s.store.GetByRedirect(ctx, name); err == nil {
+		return nil, ErrRepoRenamed{NewName: repo.Name}
+	} else if !errcode.IsNotFound(err) {
+		return nil, err
+	}
+
 	if errcode.IsNotFound(err) && !envvar.SourcegraphDotComMode() {
 		// The repo doesn't exist and we're not on sourcegraph.com, we should not lazy
 		// clone it.
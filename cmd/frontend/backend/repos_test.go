@@ -27,6 +27,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/util"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func TestReposService_Get(t *testing.T) {
@@ -44,6 +45,28 @@ func TestReposService_Get(t *testing.T) {
 	require.Equal(t, wantRepo, repo)
 }
 
+func TestReposService_GetByName_Redirect(t *testing.T) {
+	t.Parallel()
+
+	wantRepo := &types.Repo{ID: 1, Name: "github.com/new/r"}
+
+	repoStore := database.NewMockRepoStore()
+	repoStore.GetByNameFunc.SetDefaultReturn(nil, &database.RepoNotFoundErr{Name: "github.com/old/r"})
+	repoStore.GetByRedirectFunc.SetDefaultReturn(wantRepo, nil)
+	s := &repos{store: repoStore}
+
+	_, err := s.GetByName(context.Background(), "github.com/old/r")
+	mockrequire.CalledOnceWith(t, repoStore.GetByRedirectFunc, mockrequire.Values(mockrequire.Skip, api.RepoName("github.com/old/r")))
+	var renamed ErrRepoRenamed
+	require.True(t, errors.As(err, &renamed))
+	require.Equal(t, wantRepo.Name, renamed.NewName)
+
+	// Without a redirect, the original error is returned.
+	repoStore.GetByRedirectFunc.SetDefaultReturn(nil, &database.RepoNotFoundErr{Name: "github.com/old/r"})
+	_, err = s.GetByName(context.Background(), "github.com/old/r")
+	require.True(t, errcode.IsNotFound(err))
+}
+
 func TestReposService_List(t *testing.T) {
 	t.Parallel()
 
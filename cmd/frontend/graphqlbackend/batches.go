@@ -202,7 +202,8 @@ type ListBatchChangeBulkOperationArgs struct {
 
 type CreateChangesetCommentsArgs struct {
 	BulkOperationBaseArgs
-	Body string
+	Body      string
+	Templated bool
 }
 
 type ReenqueueChangesetsArgs struct {
@@ -223,6 +224,27 @@ type PublishChangesetsArgs struct {
 	Draft bool
 }
 
+type RetryChangesetChecksArgs struct {
+	BulkOperationBaseArgs
+}
+
+type CreateMergeTrainArgs struct {
+	BulkOperationBaseArgs
+	ChangesetsPerWave   int32
+	WaveIntervalMinutes int32
+	Squash              bool
+	HealthCheckQuery    *string
+	HealthCheckURL      *string
+}
+
+type ResumeMergeTrainArgs struct {
+	MergeTrain graphql.ID
+}
+
+type CancelMergeTrainArgs struct {
+	MergeTrain graphql.ID
+}
+
 type ResolveWorkspacesForBatchSpecArgs struct {
 	BatchSpec        string
 	AllowIgnored     bool
@@ -274,6 +296,10 @@ type BatchChangesResolver interface {
 	MergeChangesets(ctx context.Context, args *MergeChangesetsArgs) (BulkOperationResolver, error)
 	CloseChangesets(ctx context.Context, args *CloseChangesetsArgs) (BulkOperationResolver, error)
 	PublishChangesets(ctx context.Context, args *PublishChangesetsArgs) (BulkOperationResolver, error)
+	RetryChangesetChecks(ctx context.Context, args *RetryChangesetChecksArgs) (BulkOperationResolver, error)
+	CreateMergeTrain(ctx context.Context, args *CreateMergeTrainArgs) (MergeTrainResolver, error)
+	ResumeMergeTrain(ctx context.Context, args *ResumeMergeTrainArgs) (MergeTrainResolver, error)
+	CancelMergeTrain(ctx context.Context, args *CancelMergeTrainArgs) (MergeTrainResolver, error)
 
 	// Queries
 	BatchChange(ctx context.Context, args *BatchChangeArgs) (BatchChangeResolver, error)
@@ -309,6 +335,27 @@ type BulkOperationResolver interface {
 	FinishedAt() *DateTime
 }
 
+type MergeTrainResolver interface {
+	ID() graphql.ID
+	State() string
+	PausedReason() *string
+	ChangesetsPerWave() int32
+	WaveIntervalMinutes() int32
+	Squash() bool
+	HealthCheckQuery() *string
+	HealthCheckURL() *string
+	Waves(ctx context.Context) ([]MergeTrainWaveResolver, error)
+	PendingChangesets(ctx context.Context) (int32, error)
+	Creator(ctx context.Context) (*UserResolver, error)
+	CreatedAt() DateTime
+	LastWaveAt() *DateTime
+}
+
+type MergeTrainWaveResolver interface {
+	Number() int32
+	BulkOperation(ctx context.Context) (BulkOperationResolver, error)
+}
+
 type ChangesetJobErrorResolver interface {
 	Changeset() ChangesetResolver
 	Error() *string
@@ -418,6 +465,7 @@ type ChangesetApplyPreviewConnectionStatsResolver interface {
 	Push() int32
 	Update() int32
 	Undraft() int32
+	UpdateMetadata() int32
 	Publish() int32
 	PublishDraft() int32
 	Sync() int32
@@ -478,6 +526,10 @@ type ChangesetSpecDeltaResolver interface {
 	CommitMessageChanged() bool
 	AuthorNameChanged() bool
 	AuthorEmailChanged() bool
+	ReviewersChanged() bool
+	LabelsChanged() bool
+	AssigneesChanged() bool
+	AutoMergeChanged() bool
 }
 
 type ChangesetDescription interface {
@@ -581,6 +633,12 @@ type ListBatchSpecArgs struct {
 	After *string
 }
 
+type ListBatchChangeArtifactsArgs struct {
+	Name  *string
+	First int32
+	After *string
+}
+
 type AvailableBulkOperationsArgs struct {
 	BatchChange graphql.ID
 	Changesets  []graphql.ID
@@ -636,6 +694,42 @@ type BatchChangeResolver interface {
 	CurrentSpec(ctx context.Context) (BatchSpecResolver, error)
 	BulkOperations(ctx context.Context, args *ListBatchChangeBulkOperationArgs) (BulkOperationConnectionResolver, error)
 	BatchSpecs(ctx context.Context, args *ListBatchSpecArgs) (BatchSpecConnectionResolver, error)
+	ChangesetDependencies(ctx context.Context) ([]ChangesetDependencyResolver, error)
+	CheckStats(ctx context.Context) ([]ChangesetCheckStatsResolver, error)
+	MergeTrains(ctx context.Context) ([]MergeTrainResolver, error)
+	Artifacts(ctx context.Context, args *ListBatchChangeArtifactsArgs) (BatchChangeArtifactConnectionResolver, error)
+}
+
+type BatchChangeArtifactConnectionResolver interface {
+	Nodes(ctx context.Context) ([]BatchChangeArtifactResolver, error)
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+	Names(ctx context.Context) ([]string, error)
+	CSV(ctx context.Context) (string, error)
+}
+
+type BatchChangeArtifactResolver interface {
+	Repository() *RepositoryResolver
+	WorkspacePath() string
+	Name() string
+	Value() JSONValue
+}
+
+type ChangesetDependencyResolver interface {
+	Changeset() ChangesetResolver
+	DependsOn() ChangesetResolver
+	// Gate returns a value of type btypes.ChangesetDependencyGate, in upper
+	// case.
+	Gate() string
+}
+
+type ChangesetCheckStatsResolver interface {
+	Name() string
+	Total() int32
+	Passed() int32
+	Failed() int32
+	Pending() int32
+	AverageDurationSeconds() *int32
 }
 
 type BatchChangesConnectionResolver interface {
@@ -685,6 +779,18 @@ type ChangesetLabelResolver interface {
 	Description() *string
 }
 
+type ChangesetCheckResolver interface {
+	Name() string
+	URL() *string
+	// State returns a value of type btypes.ChangesetCheckState.
+	State() string
+	Conclusion() *string
+	StartedAt() *DateTime
+	CompletedAt() *DateTime
+	DurationSeconds() *int32
+	Retryable() bool
+}
+
 // ChangesetResolver is the "interface Changeset" in the GraphQL schema and is
 // implemented by ExternalChangesetResolver and HiddenExternalChangesetResolver.
 type ChangesetResolver interface {
@@ -732,16 +838,21 @@ type ExternalChangesetResolver interface {
 	ReviewState(context.Context) *string
 	// CheckState returns a value of type *btypes.ChangesetCheckState.
 	CheckState() *string
+	// ConflictState returns a value of type *btypes.ChangesetConflictState.
+	ConflictState() *string
 	Repository(ctx context.Context) *RepositoryResolver
 
 	Events(ctx context.Context, args *ChangesetEventsConnectionArgs) (ChangesetEventsConnectionResolver, error)
 	Diff(ctx context.Context) (RepositoryComparisonInterface, error)
 	DiffStat(ctx context.Context) (*DiffStat, error)
 	Labels(ctx context.Context) ([]ChangesetLabelResolver, error)
+	Checks() []ChangesetCheckResolver
 
 	Error() *string
 	SyncerError() *string
 	ScheduleEstimateAt(ctx context.Context) (*DateTime, error)
+	// DependencyState returns a value of type *btypes.ChangesetDependencyState.
+	DependencyState(ctx context.Context) (*string, error)
 
 	CurrentSpec(ctx context.Context) (VisibleChangesetSpecResolver, error)
 }
@@ -831,6 +942,7 @@ type VisibleBatchSpecWorkspaceResolver interface {
 	Step(args BatchSpecWorkspaceStepArgs) (BatchSpecWorkspaceStepResolver, error)
 	Steps() ([]BatchSpecWorkspaceStepResolver, error)
 	SearchResultPaths() []string
+	Owner() *string
 	ChangesetSpecs(ctx context.Context) (*[]VisibleChangesetSpecResolver, error)
 	Executor(ctx context.Context) (*gql.ExecutorResolver, error)
 }
@@ -27,6 +27,7 @@ type CodeIntelResolver interface {
 
 	RequestLanguageSupport(ctx context.Context, args *RequestLanguageSupportArgs) (*EmptyResponse, error)
 	RequestedLanguageSupport(ctx context.Context) ([]string, error)
+	CodeIntelligenceDiagnosticTrends(ctx context.Context, args *CodeIntelligenceDiagnosticTrendsArgs) ([]DiagnosticTrendSeriesResolver, error)
 
 	AutoindexingServiceResolver
 	ExecutorResolver
@@ -60,6 +61,7 @@ type PoliciesServiceResolver interface {
 	ConfigurationPolicyByID(ctx context.Context, id graphql.ID) (CodeIntelligenceConfigurationPolicyResolver, error)
 	CreateCodeIntelligenceConfigurationPolicy(ctx context.Context, args *CreateCodeIntelligenceConfigurationPolicyArgs) (CodeIntelligenceConfigurationPolicyResolver, error)
 	DeleteCodeIntelligenceConfigurationPolicy(ctx context.Context, args *DeleteCodeIntelligenceConfigurationPolicyArgs) (*EmptyResponse, error)
+	PreviewCodeIntelligenceConfigurationPolicy(ctx context.Context, id graphql.ID, args *PreviewCodeIntelligenceConfigurationPolicyArgs) (CodeIntelligenceConfigurationPolicyPreviewResolver, error)
 	PreviewGitObjectFilter(ctx context.Context, id graphql.ID, args *PreviewGitObjectFilterArgs) ([]GitObjectFilterPreviewResolver, error)
 	PreviewRepositoryFilter(ctx context.Context, args *PreviewRepositoryFilterArgs) (RepositoryFilterPreviewResolver, error)
 	UpdateCodeIntelligenceConfigurationPolicy(ctx context.Context, args *UpdateCodeIntelligenceConfigurationPolicyArgs) (*EmptyResponse, error)
@@ -200,20 +202,24 @@ type GitBlobLSIFDataResolver interface {
 	ToGitTreeLSIFData() (GitTreeLSIFDataResolver, bool)
 	ToGitBlobLSIFData() (GitBlobLSIFDataResolver, bool)
 
+	Precise() bool
 	Stencil(ctx context.Context) ([]RangeResolver, error)
 	Ranges(ctx context.Context, args *LSIFRangesArgs) (CodeIntelligenceRangeConnectionResolver, error)
 	Definitions(ctx context.Context, args *LSIFQueryPositionArgs) (LocationConnectionResolver, error)
 	References(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 	Implementations(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
+	Supertypes(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
+	Subtypes(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 	Hover(ctx context.Context, args *LSIFQueryPositionArgs) (HoverResolver, error)
 }
 
 type GitBlobLSIFDataArgs struct {
-	Repo      *types.Repo
-	Commit    api.CommitID
-	Path      string
-	ExactPath bool
-	ToolName  string
+	Repo                *types.Repo
+	Commit              api.CommitID
+	Path                string
+	ExactPath           bool
+	ToolName            string
+	SearchBasedFallback bool
 }
 
 type LSIFRangesArgs struct {
@@ -347,6 +353,29 @@ type PreviewRepositoryFilterArgs struct {
 	After    *string
 }
 
+type CodeIntelligenceDiagnosticTrendsArgs struct {
+	Repository *graphql.ID
+	Severity   *string
+	Source     *string
+	Code       *string
+	GroupBy    string
+	Interval   string
+	From       *DateTime
+	To         *DateTime
+}
+
+type DiagnosticTrendSeriesResolver interface {
+	Severity() (*string, error)
+	Source() *string
+	Code() *string
+	Points() []DiagnosticTrendPointResolver
+}
+
+type DiagnosticTrendPointResolver interface {
+	DateTime() DateTime
+	Count() int32
+}
+
 type RepositoryFilterPreviewResolver interface {
 	Nodes() []*RepositoryResolver
 	TotalCount() int32
@@ -365,6 +394,19 @@ type GitObjectFilterPreviewResolver interface {
 	Rev() string
 }
 
+type PreviewCodeIntelligenceConfigurationPolicyArgs struct {
+	Policy *graphql.ID
+	CodeIntelConfigurationPolicy
+}
+
+type CodeIntelligenceConfigurationPolicyPreviewResolver interface {
+	NewlyExpiredUploads() []LSIFUploadResolver
+	NewlyProtectedUploads() []LSIFUploadResolver
+	ReclaimedStorageBytes() BigInt
+	UploadsTruncated() bool
+	NewlyIndexedCommits() []GitObjectFilterPreviewResolver
+}
+
 type CodeIntelligenceConfigurationPolicyConnectionResolver interface {
 	Nodes(ctx context.Context) ([]CodeIntelligenceConfigurationPolicyResolver, error)
 	TotalCount(ctx context.Context) (*int32, error)
@@ -0,0 +1,78 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type metadataRulesDryRunArgs struct {
+	Config *string
+}
+
+func (r *externalServiceResolver) MetadataRulesDryRun(ctx context.Context, args *metadataRulesDryRunArgs) (*metadataRulesDryRunResolver, error) {
+	// 🚨 SECURITY: Only site admins may evaluate the configuration of an external service, since
+	// this lists all repositories of the code host connection.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	svc := r.externalService.Clone()
+	if args.Config != nil {
+		svc.Config = *args.Config
+		// The configuration may come from a redacted one shown to the user.
+		if err := svc.UnredactConfig(r.externalService); err != nil {
+			return nil, errors.Wrap(err, "unredacting config")
+		}
+	}
+
+	result, err := repoupdater.DefaultClient.MetadataRulesDryRun(ctx, svc.ToAPIService())
+	if err != nil {
+		return nil, err
+	}
+	return &metadataRulesDryRunResolver{result: result}, nil
+}
+
+type metadataRulesDryRunResolver struct {
+	result *protocol.MetadataRulesDryRunResult
+}
+
+func (r *metadataRulesDryRunResolver) Rules() []*metadataRuleResolver {
+	rules := make([]*metadataRuleResolver, 0, len(r.result.Rules))
+	for _, rule := range r.result.Rules {
+		rules = append(rules, &metadataRuleResolver{rule: rule})
+	}
+	return rules
+}
+
+func (r *metadataRulesDryRunResolver) Added() []string {
+	return repoNamesToStrings(r.result.Added)
+}
+
+func (r *metadataRulesDryRunResolver) Removed() []string {
+	return repoNamesToStrings(r.result.Removed)
+}
+
+type metadataRuleResolver struct {
+	rule *protocol.MetadataRuleDryRun
+}
+
+func (r *metadataRuleResolver) Kind() string { return r.rule.Kind }
+
+func (r *metadataRuleResolver) Rule() string { return r.rule.Rule }
+
+func (r *metadataRuleResolver) Matched() []string {
+	return repoNamesToStrings(r.rule.Matched)
+}
+
+func repoNamesToStrings(names []api.RepoName) []string {
+	strs := make([]string, 0, len(names))
+	for _, name := range names {
+		strs = append(strs, string(name))
+	}
+	return strs
+}
@@ -239,7 +239,10 @@ func (r *GitTreeEntryResolver) IsSingleChild(ctx context.Context, args *gitTreeE
 	return len(entries) == 1, nil
 }
 
-func (r *GitTreeEntryResolver) LSIF(ctx context.Context, args *struct{ ToolName *string }) (GitBlobLSIFDataResolver, error) {
+func (r *GitTreeEntryResolver) LSIF(ctx context.Context, args *struct {
+	ToolName            *string
+	SearchBasedFallback bool
+}) (GitBlobLSIFDataResolver, error) {
 	codeIntelRequests.WithLabelValues(trace.RequestOrigin(ctx)).Inc()
 
 	var toolName string
@@ -253,11 +256,12 @@ func (r *GitTreeEntryResolver) LSIF(ctx context.Context, args *struct{ ToolName
 	}
 
 	return EnterpriseResolvers.codeIntelResolver.GitBlobLSIFData(ctx, &GitBlobLSIFDataArgs{
-		Repo:      repo,
-		Commit:    api.CommitID(r.Commit().OID()),
-		Path:      r.Path(),
-		ExactPath: !r.stat.IsDir(),
-		ToolName:  toolName,
+		Repo:                repo,
+		Commit:              api.CommitID(r.Commit().OID()),
+		Path:                r.Path(),
+		ExactPath:           !r.stat.IsDir(),
+		ToolName:            toolName,
+		SearchBasedFallback: args.SearchBasedFallback,
 	})
 }
 
@@ -650,6 +650,16 @@ func (r *schemaResolver) RepositoryRedirect(ctx context.Context, args *repositor
 		if errors.As(err, &e) {
 			return &repositoryRedirect{redirect: &RedirectResolver{url: e.RedirectURL}}, nil
 		}
+		var renamed backend.ErrRepoRenamed
+		if errors.As(err, &renamed) {
+			// The repository has been renamed. Its name differs from the
+			// requested one, which clients use to redirect to the new name.
+			repo, err := r.db.Repos().GetByName(ctx, renamed.NewName)
+			if err != nil {
+				return nil, err
+			}
+			return &repositoryRedirect{repo: NewRepositoryResolver(r.db, repo)}, nil
+		}
 		if errcode.IsNotFound(err) {
 			return nil, nil
 		}
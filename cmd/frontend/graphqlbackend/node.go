@@ -243,6 +243,11 @@ func (r *NodeResolver) ToBulkOperation() (BulkOperationResolver, bool) {
 	return n, ok
 }
 
+func (r *NodeResolver) ToMergeTrain() (MergeTrainResolver, bool) {
+	n, ok := r.Node.(MergeTrainResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToHiddenBatchSpecWorkspace() (HiddenBatchSpecWorkspaceResolver, bool) {
 	n, ok := r.Node.(BatchSpecWorkspaceResolver)
 	if !ok {
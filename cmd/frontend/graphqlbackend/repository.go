@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
@@ -281,9 +282,19 @@ func (r *RepositoryResolver) Label() (Markdown, error) {
 }
 
 func (r *RepositoryResolver) Detail() Markdown {
+	if len(r.RepoMatch.DependencyPath) > 0 {
+		return Markdown("Repository match via " + strings.Join(r.RepoMatch.DependencyPath, " → "))
+	}
 	return Markdown("Repository match")
 }
 
+func (r *RepositoryResolver) DependencyPath() *[]string {
+	if r.RepoMatch.DependencyPath == nil {
+		return nil
+	}
+	return &r.RepoMatch.DependencyPath
+}
+
 func (r *RepositoryResolver) Matches() []*searchResultMatchResolver {
 	return nil
 }
@@ -356,6 +367,10 @@ func (r *RepositoryResolver) PreviewGitObjectFilter(ctx context.Context, args *P
 	return EnterpriseResolvers.codeIntelResolver.PreviewGitObjectFilter(ctx, r.ID(), args)
 }
 
+func (r *RepositoryResolver) PreviewCodeIntelligenceConfigurationPolicy(ctx context.Context, args *PreviewCodeIntelligenceConfigurationPolicyArgs) (CodeIntelligenceConfigurationPolicyPreviewResolver, error) {
+	return EnterpriseResolvers.codeIntelResolver.PreviewCodeIntelligenceConfigurationPolicy(ctx, r.ID(), args)
+}
+
 type AuthorizedUserArgs struct {
 	RepositoryID graphql.ID
 	Permission   string
@@ -160,7 +160,11 @@ func matchesToResolvers(db database.DB, matches []result.Match) []SearchResultRe
 				RepoResolver: getRepoResolver(v.Repo, ""),
 			})
 		case *result.RepoMatch:
-			resolvers = append(resolvers, getRepoResolver(v.RepoName(), v.Rev))
+			resolver := getRepoResolver(v.RepoName(), v.Rev)
+			if v.DependencyPath != nil {
+				resolver.RepoMatch.DependencyPath = v.DependencyPath
+			}
+			resolvers = append(resolvers, resolver)
 		case *result.CommitMatch:
 			resolvers = append(resolvers, &CommitSearchResultResolver{
 				db:          db,
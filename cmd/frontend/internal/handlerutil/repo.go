@@ -11,6 +11,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // GetRepo gets the repo (from the reposSvc) specified in the URL's
@@ -21,6 +22,10 @@ func GetRepo(ctx context.Context, db database.DB, vars map[string]string) (*type
 
 	repo, err := backend.NewRepos(db).GetByName(ctx, origRepo)
 	if err != nil {
+		var e backend.ErrRepoRenamed
+		if errors.As(err, &e) {
+			return nil, &URLMovedError{e.NewName}
+		}
 		return nil, err
 	}
 
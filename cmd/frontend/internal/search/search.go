@@ -531,10 +531,11 @@ func fromRepository(rm *result.RepoMatch, repoCache map[api.RepoID]*types.Search
 	}
 
 	repoEvent := &streamhttp.EventRepoMatch{
-		Type:         streamhttp.RepoMatchType,
-		RepositoryID: int32(rm.ID),
-		Repository:   string(rm.Name),
-		Branches:     branches,
+		Type:           streamhttp.RepoMatchType,
+		RepositoryID:   int32(rm.ID),
+		Repository:     string(rm.Name),
+		Branches:       branches,
+		DependencyPath: rm.DependencyPath,
 	}
 
 	if r, ok := repoCache[rm.ID]; ok {
@@ -179,6 +179,7 @@ func (s *Server) createCommitFromPatch(ctx context.Context, req protocol.CreateC
 
 	if out, err := run(cmd, "applying patch"); err != nil {
 		s.Logger.Error("Failed to apply patch.", log.String("ref", ref), log.String("output", string(out)))
+		resp.Error.PatchDoesNotApply = true
 		return http.StatusInternalServerError, resp
 	}
 
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/packagist"
+	"github.com/sourcegraph/sourcegraph/internal/unpack"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/log"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func assertPHPParsesPlaceholder() *reposource.PHPDependency {
+	placeholder, err := reposource.ParsePHPDependency("sourcegraph.com/placeholder@0.0.0")
+	if err != nil {
+		panic(fmt.Sprintf("expected placeholder dependency to parse but got %v", err))
+	}
+
+	return placeholder
+}
+
+func NewPHPPackagesSyncer(
+	connection *schema.PHPPackagesConnection,
+	svc *dependencies.Service,
+	client *packagist.Client,
+) VCSSyncer {
+	placeholder := assertPHPParsesPlaceholder()
+
+	return &vcsDependenciesSyncer{
+		logger:      log.Scoped("vcs syncer", "vcsDependenciesSyncer implements the VCSSyncer interface for dependency repos"),
+		typ:         "php_packages",
+		scheme:      dependencies.PHPPackagesScheme,
+		placeholder: placeholder,
+		svc:         svc,
+		configDeps:  connection.Dependencies,
+		source:      &phpDependencySource{client: client},
+	}
+}
+
+// phpDependencySource implements dependenciesSource
+type phpDependencySource struct {
+	client *packagist.Client
+}
+
+func (phpDependencySource) ParseDependency(dep string) (reposource.PackageDependency, error) {
+	return reposource.ParsePHPDependency(dep)
+}
+
+func (phpDependencySource) ParseDependencyFromRepoName(repoName string) (reposource.PackageDependency, error) {
+	return reposource.ParsePHPDependencyFromRepoName(repoName)
+}
+
+func (s *phpDependencySource) Get(ctx context.Context, name, version string) (reposource.PackageDependency, error) {
+	v, err := s.client.Version(ctx, name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	dep := reposource.NewPHPDependency(name, version)
+	dep.PackageURL = v.Dist.URL
+	return dep, nil
+}
+
+func (s *phpDependencySource) Download(ctx context.Context, dir string, dep reposource.PackageDependency) error {
+	packageURL := dep.(*reposource.PHPDependency).PackageURL
+	if packageURL == "" {
+		return errors.Newf("no archive to download for %s", dep.PackageManagerSyntax())
+	}
+
+	pkg, err := s.client.Download(ctx, packageURL)
+	if err != nil {
+		return errors.Wrapf(err, "error downloading PHP package with URL '%s'", packageURL)
+	}
+
+	if err = unpackPHPPackage(pkg, dir); err != nil {
+		return errors.Wrap(err, "failed to unzip PHP package")
+	}
+
+	return nil
+}
+
+// unpackPHPPackage unpacks the given zip archive of a Composer package into workDir,
+// skipping any files that aren't valid or that are potentially malicious.
+func unpackPHPPackage(pkg []byte, workDir string) error {
+	opts := unpack.Opts{
+		SkipInvalid: true,
+		Filter: func(path string, file fs.FileInfo) bool {
+			size := file.Size()
+
+			const sizeLimit = 15 * 1024 * 1024
+			if size >= sizeLimit {
+				return false
+			}
+
+			_, malicious := isPotentiallyMaliciousFilepathInArchive(path, workDir)
+			return !malicious
+		},
+	}
+
+	if err := unpack.Zip(bytes.NewReader(pkg), int64(len(pkg)), workDir, opts); err != nil {
+		return err
+	}
+
+	// Archives of GitHub hosted packages have a single top-level directory
+	return stripSingleOutermostDirectory(workDir)
+}
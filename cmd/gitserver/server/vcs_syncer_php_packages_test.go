@@ -0,0 +1,58 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUnpackPHPPackage(t *testing.T) {
+	var zipBuf bytes.Buffer
+	zw := zip.NewWriter(&zipBuf)
+	for _, f := range []fileInfo{
+		{path: "monolog-monolog-247918a/src/Monolog/Logger.php", contents: []byte("<?php")},
+		{path: "monolog-monolog-247918a/composer.json", contents: []byte("{}")},
+		{path: "monolog-monolog-247918a/.git/index", contents: []byte("filter me")},
+	} {
+		fw, err := zw.Create(f.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write(f.contents); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tmp := t.TempDir()
+	if err := unpackPHPPackage(zipBuf.Bytes(), tmp); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	if err := filepath.Walk(tmp, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			got = append(got, strings.TrimPrefix(path, tmp))
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(got)
+
+	// The single top-level directory of GitHub archives is stripped
+	if d := cmp.Diff([]string{"/composer.json", "/src/Monolog/Logger.php"}, got); d != "" {
+		t.Fatalf("-want,+got\n%s", d)
+	}
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/rubygems"
+	"github.com/sourcegraph/sourcegraph/internal/unpack"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/log"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func assertRubyParsesPlaceholder() *reposource.RubyDependency {
+	placeholder, err := reposource.ParseRubyDependency("sourcegraph.com/placeholder@0.0.0")
+	if err != nil {
+		panic(fmt.Sprintf("expected placeholder dependency to parse but got %v", err))
+	}
+
+	return placeholder
+}
+
+func NewRubyPackagesSyncer(
+	connection *schema.RubyPackagesConnection,
+	svc *dependencies.Service,
+	client *rubygems.Client,
+) VCSSyncer {
+	placeholder := assertRubyParsesPlaceholder()
+
+	return &vcsDependenciesSyncer{
+		logger:      log.Scoped("vcs syncer", "vcsDependenciesSyncer implements the VCSSyncer interface for dependency repos"),
+		typ:         "ruby_packages",
+		scheme:      dependencies.RubyPackagesScheme,
+		placeholder: placeholder,
+		svc:         svc,
+		configDeps:  connection.Dependencies,
+		source:      &rubyDependencySource{client: client},
+	}
+}
+
+// rubyDependencySource implements dependenciesSource
+type rubyDependencySource struct {
+	client *rubygems.Client
+}
+
+func (rubyDependencySource) ParseDependency(dep string) (reposource.PackageDependency, error) {
+	return reposource.ParseRubyDependency(dep)
+}
+
+func (rubyDependencySource) ParseDependencyFromRepoName(repoName string) (reposource.PackageDependency, error) {
+	return reposource.ParseRubyDependencyFromRepoName(repoName)
+}
+
+func (s *rubyDependencySource) Get(ctx context.Context, name, version string) (reposource.PackageDependency, error) {
+	dep := reposource.NewRubyDependency(name, version)
+	if _, err := s.client.GetVersion(ctx, name, version); err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch gem metadata for %s", dep.PackageManagerSyntax())
+	}
+
+	return dep, nil
+}
+
+func (s *rubyDependencySource) Download(ctx context.Context, dir string, dep reposource.PackageDependency) error {
+	gem, err := s.client.Download(ctx, dep.PackageSyntax(), dep.PackageVersion())
+	if err != nil {
+		return errors.Wrapf(err, "error downloading gem %s", dep.PackageManagerSyntax())
+	}
+
+	if err = unpackRubyGem(gem, dir); err != nil {
+		return errors.Wrap(err, "failed to unpack gem")
+	}
+
+	return nil
+}
+
+// unpackRubyGem unpacks the sources of the given .gem archive into workDir, skipping any
+// files that aren't valid or that are potentially malicious. A .gem file is an uncompressed
+// tar archive whose "data.tar.gz" entry holds the files of the gem.
+func unpackRubyGem(gem []byte, workDir string) error {
+	tr := tar.NewReader(bytes.NewReader(gem))
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			return errors.New("gem does not contain data.tar.gz")
+		}
+		if err != nil {
+			return err
+		}
+		if h.Name != "data.tar.gz" {
+			continue
+		}
+
+		opts := unpack.Opts{
+			SkipInvalid: true,
+			Filter: func(path string, file fs.FileInfo) bool {
+				size := file.Size()
+
+				const sizeLimit = 15 * 1024 * 1024
+				if size >= sizeLimit {
+					return false
+				}
+
+				_, malicious := isPotentiallyMaliciousFilepathInArchive(path, workDir)
+				return !malicious
+			},
+		}
+
+		return unpack.Tgz(tr, workDir, opts)
+	}
+}
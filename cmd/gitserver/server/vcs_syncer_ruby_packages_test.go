@@ -0,0 +1,64 @@
+package server
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackRubyGem(t *testing.T) {
+	data := createTgz(t, []fileInfo{
+		{path: "lib/rails.rb", contents: []byte("module Rails; end")},
+		{path: "README.md", contents: []byte("rails")},
+		{path: ".git/index", contents: []byte("filter me")},
+		{path: "../escape.rb", contents: []byte("filter me")},
+	})
+
+	var gem bytes.Buffer
+	tw := tar.NewWriter(&gem)
+	require.NoError(t, addFileToTarball(t, tw, fileInfo{path: "metadata.gz", contents: []byte("ignored")}))
+	require.NoError(t, addFileToTarball(t, tw, fileInfo{path: "data.tar.gz", contents: data}))
+	require.NoError(t, addFileToTarball(t, tw, fileInfo{path: "checksums.yaml.gz", contents: []byte("ignored")}))
+	require.NoError(t, tw.Close())
+
+	tmp := t.TempDir()
+	if err := unpackRubyGem(gem.Bytes(), tmp); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	if err := filepath.Walk(tmp, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			got = append(got, strings.TrimPrefix(path, tmp))
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(got)
+
+	if d := cmp.Diff([]string{"/README.md", "/lib/rails.rb"}, got); d != "" {
+		t.Fatalf("-want,+got\n%s", d)
+	}
+}
+
+func TestUnpackRubyGem_MissingData(t *testing.T) {
+	var gem bytes.Buffer
+	tw := tar.NewWriter(&gem)
+	require.NoError(t, addFileToTarball(t, tw, fileInfo{path: "metadata.gz", contents: []byte("ignored")}))
+	require.NoError(t, tw.Close())
+
+	if err := unpackRubyGem(gem.Bytes(), t.TempDir()); err == nil {
+		t.Fatal("expected error for gem without data.tar.gz")
+	}
+}
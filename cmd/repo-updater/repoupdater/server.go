@@ -65,6 +65,7 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("/repo-lookup", s.handleRepoLookup)
 	mux.HandleFunc("/enqueue-repo-update", s.handleEnqueueRepoUpdate)
 	mux.HandleFunc("/sync-external-service", s.handleExternalServiceSync)
+	mux.HandleFunc("/metadata-rules-dry-run", s.handleMetadataRulesDryRun)
 	mux.HandleFunc("/enqueue-changeset-sync", s.handleEnqueueChangesetSync)
 	mux.HandleFunc("/schedule-perms-sync", s.handleSchedulePermsSync)
 	return mux
@@ -263,6 +264,60 @@ func (s *Server) handleExternalServiceSync(w http.ResponseWriter, r *http.Reques
 	})
 }
 
+func (s *Server) handleMetadataRulesDryRun(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req protocol.MetadataRulesDryRunRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respond(w, http.StatusBadRequest, err)
+		return
+	}
+	logger := s.Logger.With(log.Object("ExternalService",
+		log.Int64("id", req.ExternalService.ID), log.String("kind", req.ExternalService.Kind)),
+	)
+
+	var sourcer repos.Sourcer
+	if sourcer = s.Sourcer; sourcer == nil {
+		db := database.NewDB(s.Handle().DB())
+		sourcer = repos.NewSourcer(db, httpcli.ExternalClientFactory)
+	}
+	src, err := sourcer(ctx, &types.ExternalService{
+		ID:              req.ExternalService.ID,
+		Kind:            req.ExternalService.Kind,
+		DisplayName:     req.ExternalService.DisplayName,
+		Config:          req.ExternalService.Config,
+		NamespaceUserID: req.ExternalService.NamespaceUserID,
+		NamespaceOrgID:  req.ExternalService.NamespaceOrgID,
+	})
+	if err != nil {
+		s.respond(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var synced []api.RepoName
+	if req.ExternalService.ID != 0 {
+		rs, err := s.Store.RepoStore().ListMinimalRepos(ctx, database.ReposListOptions{
+			ExternalServiceIDs: []int64{req.ExternalService.ID},
+		})
+		if err != nil {
+			s.respond(w, http.StatusInternalServerError, errors.Wrap(err, "store.list-repos"))
+			return
+		}
+		for _, repo := range rs {
+			synced = append(synced, repo.Name)
+		}
+	}
+
+	result, err := repos.MetadataRulesDryRun(ctx, src, synced)
+	if err != nil {
+		logger.Warn("server.metadata-rules-dry-run", log.Error(err))
+		s.respond(w, http.StatusOK, &protocol.MetadataRulesDryRunResult{Error: err.Error()})
+		return
+	}
+
+	s.respond(w, http.StatusOK, result)
+}
+
 func externalServiceValidate(ctx context.Context, req protocol.ExternalServiceSyncRequest, src repos.Source) error {
 	if !req.ExternalService.DeletedAt.IsZero() {
 		// We don't need to check deleted services.
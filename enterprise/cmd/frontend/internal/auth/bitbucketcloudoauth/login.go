@@ -0,0 +1,84 @@
+package bitbucketcloudoauth
+
+import (
+	"net/http"
+
+	"github.com/dghubble/gologin/v2"
+	oauth2Login "github.com/dghubble/gologin/v2/oauth2"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func LoginHandler(config *oauth2.Config, failure http.Handler) http.Handler {
+	return oauth2Login.LoginHandler(config, failure)
+}
+
+func CallbackHandler(config *oauth2.Config, success, failure http.Handler) http.Handler {
+	success = bitbucketCloudHandler(success, failure)
+	return oauth2Login.CallbackHandler(config, success, failure)
+}
+
+// newClient returns the client used to look up the user that just
+// authenticated. It's a variable so that it can be replaced in tests.
+var newClient = func(token string) (bitbucketcloud.Client, error) {
+	client, err := bitbucketcloud.NewClient(extsvc.URNBitbucketCloudOAuth, &schema.BitbucketCloudConnection{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return client.WithAuthenticator(&auth.OAuthBearerToken{Token: token}), nil
+}
+
+func bitbucketCloudHandler(success, failure http.Handler) http.Handler {
+	if failure == nil {
+		failure = gologin.DefaultFailureHandler
+	}
+	fn := func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		token, err := oauth2Login.TokenFromContext(ctx)
+		if err != nil {
+			ctx = gologin.WithError(ctx, err)
+			failure.ServeHTTP(w, req.WithContext(ctx))
+			return
+		}
+
+		client, err := newClient(token.AccessToken)
+		if err != nil {
+			ctx = gologin.WithError(ctx, err)
+			failure.ServeHTTP(w, req.WithContext(ctx))
+			return
+		}
+		user, err := client.CurrentUser(ctx)
+		err = validateResponse(user, err)
+		if err != nil {
+			ctx = gologin.WithError(ctx, err)
+			failure.ServeHTTP(w, req.WithContext(ctx))
+			return
+		}
+		emails, err := client.CurrentUserEmails(ctx)
+		if err != nil {
+			ctx = gologin.WithError(ctx, errors.Wrap(err, "unable to get Bitbucket Cloud user emails"))
+			failure.ServeHTTP(w, req.WithContext(ctx))
+			return
+		}
+		ctx = WithUser(ctx, user, emails)
+		success.ServeHTTP(w, req.WithContext(ctx))
+	}
+	return http.HandlerFunc(fn)
+}
+
+// validateResponse returns an error if the given Bitbucket Cloud user or error
+// are unexpected. Returns nil if they are valid.
+func validateResponse(user *bitbucketcloud.User, err error) error {
+	if err != nil {
+		return errors.Wrap(err, "unable to get Bitbucket Cloud user")
+	}
+	if user == nil || user.UUID == "" {
+		return errors.Errorf("unable to get Bitbucket Cloud user: bad user info %#+v", user)
+	}
+	return nil
+}
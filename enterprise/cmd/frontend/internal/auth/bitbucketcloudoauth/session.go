@@ -0,0 +1,149 @@
+package bitbucketcloudoauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/hubspot"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/hubspot/hubspotutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/auth/oauth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type sessionIssuerHelper struct {
+	*extsvc.CodeHost
+	db          database.DB
+	clientKey   string
+	allowSignup bool
+}
+
+func (s *sessionIssuerHelper) GetOrCreateUser(ctx context.Context, token *oauth2.Token, anonymousUserID, firstSourceURL, lastSourceURL string) (actr *actor.Actor, safeErrMsg string, err error) {
+	bbUser, emails, err := UserFromContext(ctx)
+	if err != nil {
+		return nil, "Could not read Bitbucket Cloud user from callback request.", errors.Wrap(err, "could not read user from context")
+	}
+
+	username := bbUser.Username
+	if username == "" {
+		username = bbUser.Nickname
+	}
+	login, err := auth.NormalizeUsername(username)
+	if err != nil {
+		return nil, fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", login), err
+	}
+
+	// 🚨 SECURITY: Ensure that the user email is verified
+	verifiedEmails := getVerifiedEmails(emails)
+	if len(verifiedEmails) == 0 {
+		return nil, "Could not get verified email for Bitbucket Cloud user. Check that your Bitbucket Cloud account has a confirmed email that matches one of your Sourcegraph verified emails.", errors.New("no verified email")
+	}
+
+	var data extsvc.AccountData
+	bitbucketcloud.SetExternalAccountData(&data, bbUser, token)
+
+	// We will first attempt to connect one of the verified emails with an existing
+	// account in Sourcegraph. If allowSignup is true, we will then create an
+	// account using the primary email address, which comes first.
+	type attemptConfig struct {
+		email            string
+		createIfNotExist bool
+	}
+	var attempts []attemptConfig
+	for _, email := range verifiedEmails {
+		attempts = append(attempts, attemptConfig{email: email})
+	}
+	if s.allowSignup {
+		attempts = append(attempts, attemptConfig{
+			email:            verifiedEmails[0],
+			createIfNotExist: true,
+		})
+	}
+
+	var (
+		firstSafeErrMsg string
+		firstErr        error
+	)
+	for i, attempt := range attempts {
+		userID, safeErrMsg, err := auth.GetAndSaveUser(ctx, s.db, auth.GetAndSaveUserOp{
+			UserProps: database.NewUser{
+				Username:        login,
+				Email:           attempt.email,
+				EmailIsVerified: true,
+				DisplayName:     bbUser.DisplayName,
+				AvatarURL:       bbUser.Links["avatar"].Href,
+			},
+			// The authz provider identifies accounts by their UUID, so that's
+			// what is stored as the account ID.
+			ExternalAccount: extsvc.AccountSpec{
+				ServiceType: s.ServiceType,
+				ServiceID:   s.ServiceID,
+				ClientID:    s.clientKey,
+				AccountID:   bbUser.UUID,
+			},
+			ExternalAccountData: data,
+			CreateIfNotExist:    attempt.createIfNotExist,
+		})
+		if err == nil {
+			go hubspotutil.SyncUser(attempt.email, hubspotutil.SignupEventID, &hubspot.ContactProperties{
+				AnonymousUserID: anonymousUserID,
+				FirstSourceURL:  firstSourceURL,
+				LastSourceURL:   lastSourceURL,
+			})
+			return actor.FromUser(userID), "", nil // success
+		}
+		if i == 0 {
+			firstSafeErrMsg, firstErr = safeErrMsg, err
+		}
+	}
+
+	// On failure, return the first error
+	return nil, fmt.Sprintf("No user exists matching any of the verified emails: %s.\n\nFirst error was: %s", strings.Join(verifiedEmails, ", "), firstSafeErrMsg), firstErr
+}
+
+// getVerifiedEmails returns the confirmed email addresses of the user, with the
+// primary address first.
+func getVerifiedEmails(emails []*bitbucketcloud.UserEmail) (verifiedEmails []string) {
+	for _, email := range emails {
+		if !email.IsConfirmed {
+			continue
+		}
+		if email.IsPrimary {
+			verifiedEmails = append([]string{email.Email}, verifiedEmails...)
+		} else {
+			verifiedEmails = append(verifiedEmails, email.Email)
+		}
+	}
+	return verifiedEmails
+}
+
+func (s *sessionIssuerHelper) CreateCodeHostConnection(ctx context.Context, token *oauth2.Token, providerID string) (*types.ExternalService, string, error) {
+	return nil, "Creating Bitbucket Cloud code host connections through OAuth is not supported.", errors.New("unsupported code host connection")
+}
+
+func (s *sessionIssuerHelper) DeleteStateCookie(w http.ResponseWriter) {
+	stateConfig := getStateConfig()
+	stateConfig.MaxAge = -1
+	http.SetCookie(w, oauth.NewCookie(stateConfig, ""))
+}
+
+func (s *sessionIssuerHelper) SessionData(token *oauth2.Token) oauth.SessionData {
+	return oauth.SessionData{
+		ID: providers.ConfigID{
+			ID:   s.ServiceID,
+			Type: s.ServiceType,
+		},
+		AccessToken: token.AccessToken,
+		TokenType:   token.Type(),
+	}
+}
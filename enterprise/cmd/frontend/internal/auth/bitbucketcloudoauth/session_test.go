@@ -0,0 +1,122 @@
+package bitbucketcloudoauth
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestSessionIssuerHelper_GetOrCreateUser(t *testing.T) {
+	bbURL, _ := url.Parse("https://bitbucket.org")
+	codeHost := extsvc.NewCodeHost(bbURL, extsvc.TypeBitbucketCloud)
+
+	// Only bob@example.com belongs to an existing Sourcegraph user.
+	existingUsers := map[string]int32{"bob@example.com": 2}
+	var ops []auth.GetAndSaveUserOp
+	auth.MockGetAndSaveUser = func(ctx context.Context, op auth.GetAndSaveUserOp) (int32, string, error) {
+		ops = append(ops, op)
+		if userID, ok := existingUsers[op.UserProps.Email]; ok {
+			return userID, "", nil
+		}
+		if op.CreateIfNotExist {
+			return 3, "", nil
+		}
+		return 0, "no such user", errors.New("no such user")
+	}
+	t.Cleanup(func() { auth.MockGetAndSaveUser = nil })
+
+	user := &bitbucketcloud.User{Account: bitbucketcloud.Account{
+		Nickname:    "alice",
+		DisplayName: "Alice",
+		UUID:        "{alice-uuid}",
+	}}
+
+	tests := []struct {
+		name        string
+		emails      []*bitbucketcloud.UserEmail
+		allowSignup bool
+		wantActor   *actor.Actor
+		wantEmails  []string
+	}{
+		{
+			name: "unconfirmed emails are ignored",
+			emails: []*bitbucketcloud.UserEmail{
+				{Email: "bob@example.com", IsPrimary: true},
+			},
+			allowSignup: true,
+		},
+		{
+			name: "existing user matched by secondary email",
+			emails: []*bitbucketcloud.UserEmail{
+				{Email: "alice@example.com", IsConfirmed: true},
+				{Email: "bob@example.com", IsConfirmed: true, IsPrimary: true},
+			},
+			wantActor:  actor.FromUser(2),
+			wantEmails: []string{"bob@example.com"},
+		},
+		{
+			name: "signup not allowed",
+			emails: []*bitbucketcloud.UserEmail{
+				{Email: "alice@example.com", IsConfirmed: true, IsPrimary: true},
+			},
+			wantEmails: []string{"alice@example.com"},
+		},
+		{
+			name: "signup with primary email",
+			emails: []*bitbucketcloud.UserEmail{
+				{Email: "other@example.com", IsConfirmed: true},
+				{Email: "alice@example.com", IsConfirmed: true, IsPrimary: true},
+			},
+			allowSignup: true,
+			wantActor:   actor.FromUser(3),
+			wantEmails:  []string{"alice@example.com", "other@example.com", "alice@example.com"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ops = nil
+			s := &sessionIssuerHelper{
+				CodeHost:    codeHost,
+				db:          database.NewMockDB(),
+				clientKey:   "client-key",
+				allowSignup: tc.allowSignup,
+			}
+
+			ctx := WithUser(context.Background(), user, tc.emails)
+			have, _, err := s.GetOrCreateUser(ctx, &oauth2.Token{AccessToken: "token"}, "", "", "")
+			if tc.wantActor == nil {
+				if err == nil {
+					t.Fatalf("expected error, got actor %v", have)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			} else if have.UID != tc.wantActor.UID {
+				t.Fatalf("wrong actor: have=%d want=%d", have.UID, tc.wantActor.UID)
+			}
+
+			var haveEmails []string
+			for _, op := range ops {
+				haveEmails = append(haveEmails, op.UserProps.Email)
+				if op.ExternalAccount.AccountID != user.UUID {
+					t.Errorf("wrong account ID: %q", op.ExternalAccount.AccountID)
+				}
+				if op.UserProps.Username != "alice" {
+					t.Errorf("wrong username: %q", op.UserProps.Username)
+				}
+			}
+			if diff := cmp.Diff(tc.wantEmails, haveEmails); diff != "" {
+				t.Fatalf("wrong emails tried (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package bitbucketcloudoauth
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// unexported key type prevents collisions
+type key int
+
+const (
+	userKey key = iota
+	emailsKey
+)
+
+// WithUser returns a copy of ctx that stores the Bitbucket Cloud User and
+// their email addresses.
+func WithUser(ctx context.Context, user *bitbucketcloud.User, emails []*bitbucketcloud.UserEmail) context.Context {
+	ctx = context.WithValue(ctx, userKey, user)
+	return context.WithValue(ctx, emailsKey, emails)
+}
+
+// UserFromContext returns the Bitbucket Cloud User and their email addresses
+// from the ctx.
+func UserFromContext(ctx context.Context) (*bitbucketcloud.User, []*bitbucketcloud.UserEmail, error) {
+	user, ok := ctx.Value(userKey).(*bitbucketcloud.User)
+	if !ok {
+		return nil, nil, errors.Errorf("bitbucketcloud: Context missing Bitbucket Cloud User")
+	}
+	emails, _ := ctx.Value(emailsKey).([]*bitbucketcloud.UserEmail)
+	return user, emails, nil
+}
@@ -256,6 +256,10 @@ type ChangesetSpecDelta struct {
 	CommitMessageChanged bool
 	AuthorNameChanged    bool
 	AuthorEmailChanged   bool
+	ReviewersChanged     bool
+	LabelsChanged        bool
+	AssigneesChanged     bool
+	AutoMergeChanged     bool
 }
 
 type ChangesetSpec struct {
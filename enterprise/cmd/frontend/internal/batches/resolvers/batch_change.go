@@ -291,6 +291,108 @@ func (r *batchChangeResolver) CurrentSpec(ctx context.Context) (graphqlbackend.B
 	return &batchSpecResolver{store: r.store, batchSpec: batchSpec}, nil
 }
 
+func (r *batchChangeResolver) ChangesetDependencies(ctx context.Context) ([]graphqlbackend.ChangesetDependencyResolver, error) {
+	deps, err := r.store.ListChangesetDependencies(ctx, store.ListChangesetDependenciesOpts{BatchChangeID: r.batchChange.ID})
+	if err != nil {
+		return nil, err
+	}
+	if len(deps) == 0 {
+		return []graphqlbackend.ChangesetDependencyResolver{}, nil
+	}
+
+	ids := make([]int64, 0, 2*len(deps))
+	for _, d := range deps {
+		ids = append(ids, d.ChangesetID, d.DependsOnChangesetID)
+	}
+	cs, _, err := r.store.ListChangesets(ctx, store.ListChangesetsOpts{IDs: ids, IncludeArchived: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: database.Repos.GetRepoIDsSet uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	reposByID, err := r.store.Repos().GetReposSetByIDs(ctx, cs.RepoIDs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	resolversByID := make(map[int64]graphqlbackend.ChangesetResolver, len(cs))
+	for _, c := range cs {
+		resolversByID[c.ID] = NewChangesetResolver(r.store, c, reposByID[c.RepoID])
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetDependencyResolver, 0, len(deps))
+	for _, d := range deps {
+		changeset, ok := resolversByID[d.ChangesetID]
+		if !ok {
+			continue
+		}
+		dependsOn, ok := resolversByID[d.DependsOnChangesetID]
+		if !ok {
+			continue
+		}
+		resolvers = append(resolvers, &changesetDependencyResolver{changeset: changeset, dependsOn: dependsOn, gate: d.Gate})
+	}
+	return resolvers, nil
+}
+
+func (r *batchChangeResolver) CheckStats(ctx context.Context) ([]graphqlbackend.ChangesetCheckStatsResolver, error) {
+	stats, err := r.store.GetBatchChangeCheckStats(ctx, r.batchChange.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetCheckStatsResolver, 0, len(stats))
+	for _, s := range stats {
+		resolvers = append(resolvers, &changesetCheckStatsResolver{stats: s})
+	}
+	return resolvers, nil
+}
+
+func (r *batchChangeResolver) MergeTrains(ctx context.Context) ([]graphqlbackend.MergeTrainResolver, error) {
+	trains, err := r.store.ListMergeTrains(ctx, store.ListMergeTrainsOpts{BatchChangeID: r.batchChange.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.MergeTrainResolver, 0, len(trains))
+	for _, t := range trains {
+		resolvers = append(resolvers, &mergeTrainResolver{store: r.store, mergeTrain: t})
+	}
+	return resolvers, nil
+}
+
+func (r *batchChangeResolver) Artifacts(
+	ctx context.Context,
+	args *graphqlbackend.ListBatchChangeArtifactsArgs,
+) (graphqlbackend.BatchChangeArtifactConnectionResolver, error) {
+	if err := validateFirstParamDefaults(args.First); err != nil {
+		return nil, err
+	}
+	opts := store.ListBatchChangeArtifactsOpts{
+		BatchChangeID: r.batchChange.ID,
+		LimitOpts: store.LimitOpts{
+			Limit: int(args.First),
+		},
+	}
+	if args.Name != nil {
+		opts.Name = *args.Name
+	}
+	if args.After != nil {
+		id, err := strconv.Atoi(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = int64(id)
+	}
+
+	return &batchChangeArtifactConnectionResolver{
+		store:       r.store,
+		batchChange: r.batchChange,
+		opts:        opts,
+	}, nil
+}
+
 func (r *batchChangeResolver) BulkOperations(
 	ctx context.Context,
 	args *graphqlbackend.ListBatchChangeBulkOperationArgs,
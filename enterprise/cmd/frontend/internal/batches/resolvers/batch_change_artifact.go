@@ -0,0 +1,178 @@
+package resolvers
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+var _ graphqlbackend.BatchChangeArtifactConnectionResolver = &batchChangeArtifactConnectionResolver{}
+
+type batchChangeArtifactConnectionResolver struct {
+	store       *store.Store
+	batchChange *btypes.BatchChange
+	opts        store.ListBatchChangeArtifactsOpts
+
+	// Cache results because they are used by multiple fields.
+	once      sync.Once
+	artifacts []*btypes.BatchChangeArtifact
+	reposByID map[api.RepoID]*types.Repo
+	next      int64
+	err       error
+}
+
+func (r *batchChangeArtifactConnectionResolver) Nodes(ctx context.Context) ([]graphqlbackend.BatchChangeArtifactResolver, error) {
+	artifacts, reposByID, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.BatchChangeArtifactResolver, 0, len(artifacts))
+	for _, a := range artifacts {
+		repo, ok := reposByID[a.RepoID]
+		if !ok {
+			// The repository was deleted or became inaccessible in the meantime.
+			continue
+		}
+		resolvers = append(resolvers, &batchChangeArtifactResolver{
+			artifact:     a,
+			repoResolver: graphqlbackend.NewRepositoryResolver(r.store.DatabaseDB(), repo),
+		})
+	}
+	return resolvers, nil
+}
+
+func (r *batchChangeArtifactConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := r.store.CountBatchChangeArtifacts(ctx, r.opts)
+	return int32(count), err
+}
+
+func (r *batchChangeArtifactConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	_, _, next, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if next != 0 {
+		return graphqlutil.NextPageCursor(strconv.Itoa(int(next))), nil
+	}
+	return graphqlutil.HasNextPage(false), nil
+}
+
+func (r *batchChangeArtifactConnectionResolver) Names(ctx context.Context) ([]string, error) {
+	spec, err := r.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: r.batchChange.BatchSpecID})
+	if err != nil {
+		return nil, err
+	}
+	return spec.Spec.ArtifactNames(), nil
+}
+
+func (r *batchChangeArtifactConnectionResolver) CSV(ctx context.Context) (string, error) {
+	opts := r.opts
+	opts.LimitOpts = store.LimitOpts{}
+	opts.Cursor = 0
+
+	artifacts, _, err := r.store.ListBatchChangeArtifacts(ctx, opts)
+	if err != nil {
+		return "", err
+	}
+	reposByID, err := r.store.Repos().GetReposSetByIDs(ctx, artifactRepoIDs(artifacts)...)
+	if err != nil {
+		return "", err
+	}
+	return artifactsCSV(artifacts, reposByID)
+}
+
+func (r *batchChangeArtifactConnectionResolver) compute(ctx context.Context) ([]*btypes.BatchChangeArtifact, map[api.RepoID]*types.Repo, int64, error) {
+	r.once.Do(func() {
+		r.artifacts, r.next, r.err = r.store.ListBatchChangeArtifacts(ctx, r.opts)
+		if r.err != nil {
+			return
+		}
+
+		// 🚨 SECURITY: database.Repos.GetReposSetByIDs uses the authzFilter
+		// under the hood and filters out repositories that the user doesn't
+		// have access to.
+		r.reposByID, r.err = r.store.Repos().GetReposSetByIDs(ctx, artifactRepoIDs(r.artifacts)...)
+	})
+	return r.artifacts, r.reposByID, r.next, r.err
+}
+
+func artifactRepoIDs(artifacts []*btypes.BatchChangeArtifact) []api.RepoID {
+	seen := make(map[api.RepoID]struct{}, len(artifacts))
+	ids := make([]api.RepoID, 0, len(artifacts))
+	for _, a := range artifacts {
+		if _, ok := seen[a.RepoID]; ok {
+			continue
+		}
+		seen[a.RepoID] = struct{}{}
+		ids = append(ids, a.RepoID)
+	}
+	return ids
+}
+
+// artifactsCSV renders the given artifacts as a CSV report. Text artifacts are
+// written as-is, all other values are encoded as JSON. Artifacts in
+// repositories missing from reposByID are skipped.
+func artifactsCSV(artifacts []*btypes.BatchChangeArtifact, reposByID map[api.RepoID]*types.Repo) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"repository", "path", "name", "value"}); err != nil {
+		return "", err
+	}
+
+	for _, a := range artifacts {
+		repo, ok := reposByID[a.RepoID]
+		if !ok {
+			continue
+		}
+
+		value, ok := a.Value.(string)
+		if !ok {
+			raw, err := json.Marshal(a.Value)
+			if err != nil {
+				return "", err
+			}
+			value = string(raw)
+		}
+
+		if err := w.Write([]string{string(repo.Name), a.Path, a.Name, value}); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	return buf.String(), w.Error()
+}
+
+var _ graphqlbackend.BatchChangeArtifactResolver = &batchChangeArtifactResolver{}
+
+type batchChangeArtifactResolver struct {
+	artifact     *btypes.BatchChangeArtifact
+	repoResolver *graphqlbackend.RepositoryResolver
+}
+
+func (r *batchChangeArtifactResolver) Repository() *graphqlbackend.RepositoryResolver {
+	return r.repoResolver
+}
+
+func (r *batchChangeArtifactResolver) WorkspacePath() string {
+	return r.artifact.Path
+}
+
+func (r *batchChangeArtifactResolver) Name() string {
+	return r.artifact.Name
+}
+
+func (r *batchChangeArtifactResolver) Value() graphqlbackend.JSONValue {
+	return graphqlbackend.JSONValue{Value: r.artifact.Value}
+}
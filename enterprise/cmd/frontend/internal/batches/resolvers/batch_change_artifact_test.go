@@ -0,0 +1,43 @@
+package resolvers
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestArtifactsCSV(t *testing.T) {
+	reposByID := map[api.RepoID]*types.Repo{
+		1: {ID: 1, Name: "github.com/sourcegraph/a"},
+		2: {ID: 2, Name: "github.com/sourcegraph/b"},
+	}
+	artifact := func(repoID api.RepoID, path, name string, value any) *btypes.BatchChangeArtifact {
+		return &btypes.BatchChangeArtifact{
+			BatchSpecWorkspaceArtifact: btypes.BatchSpecWorkspaceArtifact{Name: name, Value: value},
+			RepoID:                     repoID,
+			Path:                       path,
+		}
+	}
+
+	have, err := artifactsCSV([]*btypes.BatchChangeArtifact{
+		artifact(1, "", "warnings", "deprecated API, used twice"),
+		artifact(2, "web", "audit", map[string]any{"count": float64(2)}),
+		// Repository 3 isn't accessible, so it must be skipped.
+		artifact(3, "", "warnings", "secret"),
+	}, reposByID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `repository,path,name,value
+github.com/sourcegraph/a,,warnings,"deprecated API, used twice"
+github.com/sourcegraph/b,web,audit,"{""count"":2}"
+`
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("wrong CSV (-want +have):\n%s", diff)
+	}
+}
@@ -115,6 +115,13 @@ func (r *batchSpecWorkspaceResolver) SearchResultPaths() []string {
 	return r.workspace.FileMatches
 }
 
+func (r *batchSpecWorkspaceResolver) Owner() *string {
+	if r.workspace.Owner == "" {
+		return nil
+	}
+	return &r.workspace.Owner
+}
+
 func (r *batchSpecWorkspaceResolver) computeStepResolvers() ([]graphqlbackend.BatchSpecWorkspaceStepResolver, error) {
 	if _, ok := r.ToHiddenBatchSpecWorkspace(); ok {
 		return nil, nil
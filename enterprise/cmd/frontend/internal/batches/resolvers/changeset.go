@@ -397,6 +397,19 @@ func (r *changesetResolver) CheckState() *string {
 	return &state
 }
 
+func (r *changesetResolver) ConflictState() *string {
+	if !r.changeset.Published() {
+		return nil
+	}
+
+	state := string(r.changeset.ConflictState)
+	if state == "" || state == string(btypes.ChangesetConflictStateUnknown) {
+		return nil
+	}
+
+	return &state
+}
+
 func (r *changesetResolver) Error() *string { return r.changeset.FailureMessage }
 
 func (r *changesetResolver) SyncerError() *string { return r.changeset.SyncErrorMessage }
@@ -415,6 +428,20 @@ func (r *changesetResolver) ScheduleEstimateAt(ctx context.Context) (*graphqlbac
 	return graphqlbackend.DateTimeOrNil(config.ActiveWindow().Estimate(r.store.Clock()(), place)), nil
 }
 
+func (r *changesetResolver) DependencyState(ctx context.Context) (*string, error) {
+	statuses, err := r.store.ListChangesetDependencyStatuses(ctx, r.changeset.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	state := btypes.ComputeChangesetDependencyState(statuses)
+	if state == btypes.ChangesetDependencyStateNone {
+		return nil, nil
+	}
+	s := string(state)
+	return &s, nil
+}
+
 func (r *changesetResolver) CurrentSpec(ctx context.Context) (graphqlbackend.VisibleChangesetSpecResolver, error) {
 	if r.changeset.CurrentSpecID == 0 {
 		return nil, nil
@@ -461,6 +488,18 @@ func (r *changesetResolver) Labels(ctx context.Context) ([]graphqlbackend.Change
 	return resolvers, nil
 }
 
+func (r *changesetResolver) Checks() []graphqlbackend.ChangesetCheckResolver {
+	if !r.changeset.Published() {
+		return []graphqlbackend.ChangesetCheckResolver{}
+	}
+
+	resolvers := make([]graphqlbackend.ChangesetCheckResolver, 0, len(r.changeset.ExternalChecks))
+	for _, c := range r.changeset.ExternalChecks {
+		resolvers = append(resolvers, &changesetCheckResolver{check: c})
+	}
+	return resolvers
+}
+
 func (r *changesetResolver) Events(ctx context.Context, args *graphqlbackend.ChangesetEventsConnectionArgs) (graphqlbackend.ChangesetEventsConnectionResolver, error) {
 	if err := validateFirstParamDefaults(args.First); err != nil {
 		return nil, err
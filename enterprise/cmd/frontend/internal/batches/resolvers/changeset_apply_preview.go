@@ -367,3 +367,15 @@ func (c *changesetSpecDeltaResolver) AuthorNameChanged() bool {
 func (c *changesetSpecDeltaResolver) AuthorEmailChanged() bool {
 	return c.delta.AuthorEmailChanged
 }
+func (c *changesetSpecDeltaResolver) ReviewersChanged() bool {
+	return c.delta.ReviewersChanged
+}
+func (c *changesetSpecDeltaResolver) LabelsChanged() bool {
+	return c.delta.LabelsChanged
+}
+func (c *changesetSpecDeltaResolver) AssigneesChanged() bool {
+	return c.delta.AssigneesChanged
+}
+func (c *changesetSpecDeltaResolver) AutoMergeChanged() bool {
+	return c.delta.AutoMergeChanged
+}
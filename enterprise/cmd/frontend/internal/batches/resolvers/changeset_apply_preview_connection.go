@@ -98,18 +98,19 @@ func (r *changesetApplyPreviewConnectionResolver) Nodes(ctx context.Context) ([]
 }
 
 type changesetApplyPreviewConnectionStatsResolver struct {
-	push         int32
-	update       int32
-	undraft      int32
-	publish      int32
-	publishDraft int32
-	sync         int32
-	_import      int32
-	close        int32
-	reopen       int32
-	sleep        int32
-	detach       int32
-	archive      int32
+	push           int32
+	update         int32
+	undraft        int32
+	updateMetadata int32
+	publish        int32
+	publishDraft   int32
+	sync           int32
+	_import        int32
+	close          int32
+	reopen         int32
+	sleep          int32
+	detach         int32
+	archive        int32
 
 	added    int32
 	modified int32
@@ -125,6 +126,9 @@ func (r *changesetApplyPreviewConnectionStatsResolver) Update() int32 {
 func (r *changesetApplyPreviewConnectionStatsResolver) Undraft() int32 {
 	return r.undraft
 }
+func (r *changesetApplyPreviewConnectionStatsResolver) UpdateMetadata() int32 {
+	return r.updateMetadata
+}
 func (r *changesetApplyPreviewConnectionStatsResolver) Publish() int32 {
 	return r.publish
 }
@@ -207,6 +211,8 @@ func (r *changesetApplyPreviewConnectionResolver) Stats(ctx context.Context) (gr
 				stats.update++
 			case string(btypes.ReconcilerOperationUndraft):
 				stats.undraft++
+			case string(btypes.ReconcilerOperationUpdateMetadata):
+				stats.updateMetadata++
 			case string(btypes.ReconcilerOperationPublish):
 				stats.publish++
 			case string(btypes.ReconcilerOperationPublishDraft):
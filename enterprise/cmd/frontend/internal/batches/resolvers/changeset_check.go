@@ -0,0 +1,83 @@
+package resolvers
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+var _ graphqlbackend.ChangesetCheckResolver = &changesetCheckResolver{}
+
+type changesetCheckResolver struct {
+	check btypes.ChangesetCheck
+}
+
+func (r *changesetCheckResolver) Name() string {
+	return r.check.Name
+}
+
+func (r *changesetCheckResolver) URL() *string {
+	if r.check.URL == "" {
+		return nil
+	}
+	return &r.check.URL
+}
+
+func (r *changesetCheckResolver) State() string {
+	return string(r.check.State)
+}
+
+func (r *changesetCheckResolver) Conclusion() *string {
+	if r.check.Conclusion == "" {
+		return nil
+	}
+	return &r.check.Conclusion
+}
+
+func (r *changesetCheckResolver) StartedAt() *graphqlbackend.DateTime {
+	if r.check.StartedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.check.StartedAt}
+}
+
+func (r *changesetCheckResolver) CompletedAt() *graphqlbackend.DateTime {
+	if r.check.CompletedAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.check.CompletedAt}
+}
+
+func (r *changesetCheckResolver) DurationSeconds() *int32 {
+	return durationSeconds(r.check.Duration())
+}
+
+func (r *changesetCheckResolver) Retryable() bool {
+	return r.check.RetryID != ""
+}
+
+var _ graphqlbackend.ChangesetCheckStatsResolver = &changesetCheckStatsResolver{}
+
+type changesetCheckStatsResolver struct {
+	stats *btypes.ChangesetCheckStats
+}
+
+func (r *changesetCheckStatsResolver) Name() string   { return r.stats.Name }
+func (r *changesetCheckStatsResolver) Total() int32   { return r.stats.Total }
+func (r *changesetCheckStatsResolver) Passed() int32  { return r.stats.Passed }
+func (r *changesetCheckStatsResolver) Failed() int32  { return r.stats.Failed }
+func (r *changesetCheckStatsResolver) Pending() int32 { return r.stats.Pending }
+
+func (r *changesetCheckStatsResolver) AverageDurationSeconds() *int32 {
+	return durationSeconds(r.stats.AverageDuration)
+}
+
+// durationSeconds returns d in whole seconds, or nil if d is not positive.
+func durationSeconds(d time.Duration) *int32 {
+	if d <= 0 {
+		return nil
+	}
+	s := int32(d.Round(time.Second) / time.Second)
+	return &s
+}
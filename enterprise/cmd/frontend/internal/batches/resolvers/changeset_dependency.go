@@ -0,0 +1,28 @@
+package resolvers
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+var _ graphqlbackend.ChangesetDependencyResolver = &changesetDependencyResolver{}
+
+type changesetDependencyResolver struct {
+	changeset graphqlbackend.ChangesetResolver
+	dependsOn graphqlbackend.ChangesetResolver
+	gate      btypes.ChangesetDependencyGate
+}
+
+func (r *changesetDependencyResolver) Changeset() graphqlbackend.ChangesetResolver {
+	return r.changeset
+}
+
+func (r *changesetDependencyResolver) DependsOn() graphqlbackend.ChangesetResolver {
+	return r.dependsOn
+}
+
+func (r *changesetDependencyResolver) Gate() string {
+	return strings.ToUpper(string(r.gate))
+}
@@ -0,0 +1,129 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+const mergeTrainIDKind = "MergeTrain"
+
+func marshalMergeTrainID(id int64) graphql.ID {
+	return relay.MarshalID(mergeTrainIDKind, id)
+}
+
+func unmarshalMergeTrainID(id graphql.ID) (mergeTrainID int64, err error) {
+	err = relay.UnmarshalSpec(id, &mergeTrainID)
+	return
+}
+
+type mergeTrainResolver struct {
+	store      *store.Store
+	mergeTrain *btypes.MergeTrain
+}
+
+var _ graphqlbackend.MergeTrainResolver = &mergeTrainResolver{}
+
+func (r *mergeTrainResolver) ID() graphql.ID {
+	return marshalMergeTrainID(r.mergeTrain.ID)
+}
+
+func (r *mergeTrainResolver) State() string {
+	return string(r.mergeTrain.State)
+}
+
+func (r *mergeTrainResolver) PausedReason() *string {
+	if r.mergeTrain.State != btypes.MergeTrainStatePaused || r.mergeTrain.PausedReason == "" {
+		return nil
+	}
+	return &r.mergeTrain.PausedReason
+}
+
+func (r *mergeTrainResolver) ChangesetsPerWave() int32 {
+	return r.mergeTrain.ChangesetsPerWave
+}
+
+func (r *mergeTrainResolver) WaveIntervalMinutes() int32 {
+	return int32(r.mergeTrain.WaveInterval / time.Minute)
+}
+
+func (r *mergeTrainResolver) Squash() bool {
+	return r.mergeTrain.Squash
+}
+
+func (r *mergeTrainResolver) HealthCheckQuery() *string {
+	if r.mergeTrain.HealthCheckQuery == "" {
+		return nil
+	}
+	return &r.mergeTrain.HealthCheckQuery
+}
+
+func (r *mergeTrainResolver) HealthCheckURL() *string {
+	if r.mergeTrain.HealthCheckURL == "" {
+		return nil
+	}
+	return &r.mergeTrain.HealthCheckURL
+}
+
+func (r *mergeTrainResolver) Waves(ctx context.Context) ([]graphqlbackend.MergeTrainWaveResolver, error) {
+	waves, err := r.store.ListMergeTrainWaves(ctx, r.mergeTrain.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]graphqlbackend.MergeTrainWaveResolver, 0, len(waves))
+	for _, w := range waves {
+		resolvers = append(resolvers, &mergeTrainWaveResolver{store: r.store, wave: w})
+	}
+	return resolvers, nil
+}
+
+func (r *mergeTrainResolver) PendingChangesets(ctx context.Context) (int32, error) {
+	count, _, err := r.store.CountPendingMergeTrainChangesets(ctx, r.mergeTrain.ID)
+	return int32(count), err
+}
+
+func (r *mergeTrainResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.store.DatabaseDB(), r.mergeTrain.UserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *mergeTrainResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.mergeTrain.CreatedAt}
+}
+
+func (r *mergeTrainResolver) LastWaveAt() *graphqlbackend.DateTime {
+	if r.mergeTrain.LastWaveAt.IsZero() {
+		return nil
+	}
+	return &graphqlbackend.DateTime{Time: r.mergeTrain.LastWaveAt}
+}
+
+type mergeTrainWaveResolver struct {
+	store *store.Store
+	wave  *btypes.MergeTrainWave
+}
+
+var _ graphqlbackend.MergeTrainWaveResolver = &mergeTrainWaveResolver{}
+
+func (r *mergeTrainWaveResolver) Number() int32 {
+	return r.wave.Number
+}
+
+func (r *mergeTrainWaveResolver) BulkOperation(ctx context.Context) (graphqlbackend.BulkOperationResolver, error) {
+	bulkOperation, err := r.store.GetBulkOperation(ctx, store.GetBulkOperationOpts{ID: r.wave.BulkGroup})
+	if err != nil {
+		return nil, err
+	}
+	return &bulkOperationResolver{store: r.store, bulkOperation: bulkOperation}, nil
+}
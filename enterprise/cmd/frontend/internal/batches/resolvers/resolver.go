@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/graph-gophers/graphql-go"
 
@@ -27,6 +28,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/usagestats"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -125,6 +127,9 @@ func (r *Resolver) NodeResolvers() map[string]graphqlbackend.NodeByIDFunc {
 		batchSpecWorkspaceIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
 			return r.batchSpecWorkspaceByID(ctx, id)
 		},
+		mergeTrainIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
+			return r.mergeTrainByID(ctx, id)
+		},
 	}
 }
 
@@ -347,6 +352,30 @@ func (r *Resolver) bulkOperationByIDString(ctx context.Context, id string) (grap
 	return &bulkOperationResolver{store: r.store, bulkOperation: bulkOperation}, nil
 }
 
+func (r *Resolver) mergeTrainByID(ctx context.Context, gqlID graphql.ID) (graphqlbackend.MergeTrainResolver, error) {
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalMergeTrainID(gqlID)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == 0 {
+		return nil, nil
+	}
+
+	train, err := r.store.GetMergeTrain(ctx, id)
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &mergeTrainResolver{store: r.store, mergeTrain: train}, nil
+}
+
 func (r *Resolver) batchSpecWorkspaceByID(ctx context.Context, gqlID graphql.ID) (graphqlbackend.BatchSpecWorkspaceResolver, error) {
 	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
 		return nil, err
@@ -1160,7 +1189,7 @@ func (r *Resolver) generateAuthenticatorForCredential(ctx context.Context, exter
 			PublicKey:  keypair.PublicKey,
 			Passphrase: keypair.Passphrase,
 		}
-	} else if externalServiceType == extsvc.TypeBitbucketCloud {
+	} else if externalServiceType == extsvc.TypeBitbucketCloud || externalServiceType == extsvc.TypeAWSCodeCommit {
 		a = &auth.BasicAuthWithSSH{
 			BasicAuth:  auth.BasicAuth{Username: *username, Password: credential},
 			PrivateKey: keypair.PrivateKey,
@@ -1291,6 +1320,11 @@ func (r *Resolver) CreateChangesetComments(ctx context.Context, args *graphqlbac
 	if args.Body == "" {
 		return nil, errors.New("empty comment body is not allowed")
 	}
+	if args.Templated {
+		if err := template.ParseChangesetCommentTemplate(args.Body); err != nil {
+			return nil, errors.Wrap(err, "parsing comment body")
+		}
+	}
 
 	batchChangeID, changesetIDs, err := unmarshalBulkOperationBaseArgs(args.BulkOperationBaseArgs)
 	if err != nil {
@@ -1306,7 +1340,8 @@ func (r *Resolver) CreateChangesetComments(ctx context.Context, args *graphqlbac
 		changesetIDs,
 		btypes.ChangesetJobTypeComment,
 		&btypes.ChangesetJobCommentPayload{
-			Message: args.Body,
+			Message:   args.Body,
+			Templated: args.Templated,
 		},
 		store.ListChangesetsOpts{
 			// Also include archived changesets, we allow commenting on them as well.
@@ -1464,6 +1499,144 @@ func (r *Resolver) PublishChangesets(ctx context.Context, args *graphqlbackend.P
 	return r.bulkOperationByIDString(ctx, bulkGroupID)
 }
 
+func (r *Resolver) RetryChangesetChecks(ctx context.Context, args *graphqlbackend.RetryChangesetChecksArgs) (_ graphqlbackend.BulkOperationResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.RetryChangesetChecks", fmt.Sprintf("BatchChange: %q, len(Changesets): %d", args.BatchChange, len(args.Changesets)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, changesetIDs, err := unmarshalBulkOperationBaseArgs(args.BulkOperationBaseArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: CreateChangesetJobs checks whether current user is authorized.
+	svc := service.New(r.store)
+	published := btypes.ChangesetPublicationStatePublished
+	failed := btypes.ChangesetCheckStateFailed
+	bulkGroupID, err := svc.CreateChangesetJobs(
+		ctx,
+		batchChangeID,
+		changesetIDs,
+		btypes.ChangesetJobTypeRetryChecks,
+		&btypes.ChangesetJobRetryChecksPayload{},
+		store.ListChangesetsOpts{
+			PublicationState:   &published,
+			ReconcilerStates:   []btypes.ReconcilerState{btypes.ReconcilerStateCompleted},
+			ExternalStates:     []btypes.ChangesetExternalState{btypes.ChangesetExternalStateOpen, btypes.ChangesetExternalStateDraft},
+			ExternalCheckState: &failed,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.bulkOperationByIDString(ctx, bulkGroupID)
+}
+
+func (r *Resolver) CreateMergeTrain(ctx context.Context, args *graphqlbackend.CreateMergeTrainArgs) (_ graphqlbackend.MergeTrainResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateMergeTrain", fmt.Sprintf("BatchChange: %q, len(Changesets): %d", args.BatchChange, len(args.Changesets)))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	batchChangeID, changesetIDs, err := unmarshalBulkOperationBaseArgs(args.BulkOperationBaseArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.WaveIntervalMinutes < 0 {
+		return nil, errors.New("waveIntervalMinutes must not be negative")
+	}
+
+	opts := service.CreateMergeTrainOpts{
+		BatchChangeID:     batchChangeID,
+		ChangesetIDs:      changesetIDs,
+		ChangesetsPerWave: args.ChangesetsPerWave,
+		WaveInterval:      time.Duration(args.WaveIntervalMinutes) * time.Minute,
+		Squash:            args.Squash,
+	}
+	if args.HealthCheckQuery != nil {
+		opts.HealthCheckQuery = *args.HealthCheckQuery
+	}
+	if args.HealthCheckURL != nil {
+		opts.HealthCheckURL = *args.HealthCheckURL
+	}
+
+	// 🚨 SECURITY: CreateMergeTrain checks whether current user is authorized.
+	svc := service.New(r.store)
+	train, err := svc.CreateMergeTrain(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mergeTrainResolver{store: r.store, mergeTrain: train}, nil
+}
+
+func (r *Resolver) ResumeMergeTrain(ctx context.Context, args *graphqlbackend.ResumeMergeTrainArgs) (_ graphqlbackend.MergeTrainResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.ResumeMergeTrain", fmt.Sprintf("MergeTrain: %q", args.MergeTrain))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalMergeTrainID(args.MergeTrain)
+	if err != nil {
+		return nil, err
+	}
+	if id == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	// 🚨 SECURITY: ResumeMergeTrain checks whether current user is authorized.
+	svc := service.New(r.store)
+	train, err := svc.ResumeMergeTrain(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mergeTrainResolver{store: r.store, mergeTrain: train}, nil
+}
+
+func (r *Resolver) CancelMergeTrain(ctx context.Context, args *graphqlbackend.CancelMergeTrainArgs) (_ graphqlbackend.MergeTrainResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CancelMergeTrain", fmt.Sprintf("MergeTrain: %q", args.MergeTrain))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+	if err := enterprise.BatchChangesEnabledForUser(ctx, r.store.DatabaseDB()); err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalMergeTrainID(args.MergeTrain)
+	if err != nil {
+		return nil, err
+	}
+	if id == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	// 🚨 SECURITY: CancelMergeTrain checks whether current user is authorized.
+	svc := service.New(r.store)
+	train, err := svc.CancelMergeTrain(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mergeTrainResolver{store: r.store, mergeTrain: train}, nil
+}
+
 func (r *Resolver) BatchSpecs(ctx context.Context, args *graphqlbackend.ListBatchSpecArgs) (_ graphqlbackend.BatchSpecConnectionResolver, err error) {
 	tr, ctx := trace.New(ctx, "Resolver.BatchSpecs", fmt.Sprintf("First: %d, After: %v", args.First, args.After))
 	defer func() {
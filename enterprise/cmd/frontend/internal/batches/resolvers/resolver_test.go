@@ -59,6 +59,7 @@ func TestNullIDResilience(t *testing.T) {
 		marshalBatchChangesCredentialID(0, true),
 		marshalBulkOperationID(""),
 		marshalBatchSpecWorkspaceID(0),
+		marshalMergeTrainID(0),
 	}
 
 	for _, id := range ids {
@@ -95,6 +96,10 @@ func TestNullIDResilience(t *testing.T) {
 		fmt.Sprintf(`mutation { closeChangesets(batchChange: %q, changesets: [%q]) { id } }`, marshalBatchChangeID(1), marshalChangesetID(0)),
 		fmt.Sprintf(`mutation { publishChangesets(batchChange: %q, changesets: []) { id } }`, marshalBatchChangeID(0)),
 		fmt.Sprintf(`mutation { publishChangesets(batchChange: %q, changesets: [%q]) { id } }`, marshalBatchChangeID(1), marshalChangesetID(0)),
+		fmt.Sprintf(`mutation { createMergeTrain(batchChange: %q, changesets: [], changesetsPerWave: 1) { id } }`, marshalBatchChangeID(0)),
+		fmt.Sprintf(`mutation { createMergeTrain(batchChange: %q, changesets: [%q], changesetsPerWave: 1) { id } }`, marshalBatchChangeID(1), marshalChangesetID(0)),
+		fmt.Sprintf(`mutation { resumeMergeTrain(mergeTrain: %q) { id } }`, marshalMergeTrainID(0)),
+		fmt.Sprintf(`mutation { cancelMergeTrain(mergeTrain: %q) { id } }`, marshalMergeTrainID(0)),
 		fmt.Sprintf(`mutation { executeBatchSpec(batchSpec: %q) { id } }`, marshalBatchSpecRandID("")),
 		fmt.Sprintf(`mutation { cancelBatchSpecExecution(batchSpec: %q) { id } }`, marshalBatchSpecRandID("")),
 		fmt.Sprintf(`mutation { replaceBatchSpecInput(previousSpec: %q, batchSpec: "name: testing") { id } }`, marshalBatchSpecRandID("")),
@@ -1421,6 +1426,7 @@ func TestCreateChangesetComments(t *testing.T) {
 			"batchChange": marshalBatchChangeID(batchChange.ID),
 			"changesets":  []string{string(marshalChangesetID(changeset.ID))},
 			"body":        "test-body",
+			"templated":   false,
 		}
 	}
 
@@ -1442,6 +1448,20 @@ func TestCreateChangesetComments(t *testing.T) {
 		}
 	})
 
+	t.Run("invalid body template fails", func(t *testing.T) {
+		input := generateInput()
+		input["body"] = "${{ unknown }}"
+		input["templated"] = true
+		errs := apitest.Exec(actorCtx, t, s, input, &response, mutationCreateChangesetComments)
+
+		if len(errs) != 1 {
+			t.Fatalf("expected single errors, but got none")
+		}
+		if have, want := errs[0].Message, "parsing comment body"; !strings.HasPrefix(have, want) {
+			t.Fatalf("wrong error. want prefix=%q, have=%q", want, have)
+		}
+	})
+
 	t.Run("0 changesets fails", func(t *testing.T) {
 		input := generateInput()
 		input["changesets"] = []string{}
@@ -1479,8 +1499,8 @@ func TestCreateChangesetComments(t *testing.T) {
 }
 
 const mutationCreateChangesetComments = `
-mutation($batchChange: ID!, $changesets: [ID!]!, $body: String!) {
-    createChangesetComments(batchChange: $batchChange, changesets: $changesets, body: $body) { id }
+mutation($batchChange: ID!, $changesets: [ID!]!, $body: String!, $templated: Boolean) {
+    createChangesetComments(batchChange: $batchChange, changesets: $changesets, body: $body, templated: $templated) { id }
 }
 `
 
@@ -587,6 +587,7 @@ func (h *GitHubWebhook) commitStatusEvent(e *gh.StatusEvent) *github.CommitStatu
 		SHA:        e.GetSHA(),
 		State:      e.GetState(),
 		Context:    e.GetContext(),
+		TargetURL:  e.GetTargetURL(),
 		ReceivedAt: h.Store.Clock()(),
 	}
 }
@@ -602,9 +603,14 @@ func (h *GitHubWebhook) checkSuiteEvent(cs *gh.CheckSuite) *github.CheckSuite {
 
 func (h *GitHubWebhook) checkRunEvent(cr *gh.CheckRun) *github.CheckRun {
 	return &github.CheckRun{
-		ID:         cr.GetNodeID(),
-		Status:     cr.GetStatus(),
-		Conclusion: cr.GetConclusion(),
-		ReceivedAt: h.Store.Clock()(),
+		ID:           cr.GetNodeID(),
+		Name:         cr.GetName(),
+		DetailsURL:   cr.GetDetailsURL(),
+		Status:       cr.GetStatus(),
+		Conclusion:   cr.GetConclusion(),
+		StartedAt:    cr.GetStartedAt().Time,
+		CompletedAt:  cr.GetCompletedAt().Time,
+		CheckSuiteID: cr.GetCheckSuite().GetNodeID(),
+		ReceivedAt:   h.Store.Clock()(),
 	}
 }
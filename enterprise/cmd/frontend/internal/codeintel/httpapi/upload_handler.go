@@ -67,6 +67,10 @@ var errUnprocessableRequest = errors.New("unprocessable request: missing expecte
 //   - POST `/upload?uploadId={id},index={i}`
 //   - POST `/upload?uploadId={id},done=true`
 //
+// Incremental uploads, which contain only the documents that have changed since a previous upload, must use
+// the multipart sequence. The setup request supplies the additional `baseUploadId={id}` query arg, and its
+// body is a JSON object of the form `{"changedPaths": [...]}` listing each changed repository-relative path.
+//
 // See the functions the following functions for details on how each request is handled:
 //
 //   - handleEnqueueSinglePayload
@@ -98,6 +102,7 @@ func (h *UploadHandler) handleEnqueue(w http.ResponseWriter, r *http.Request) {
 			log.String("indexer", uploadState.indexer),
 			log.String("indexerVersion", uploadState.indexerVersion),
 			log.Int("associatedIndexID", uploadState.associatedIndexID),
+			log.Int("numChangedPaths", len(uploadState.changedPaths)),
 			log.Int("numParts", uploadState.numParts),
 			log.Int("numUploadedParts", len(uploadState.uploadedParts)),
 			log.Bool("multipart", uploadState.multipart),
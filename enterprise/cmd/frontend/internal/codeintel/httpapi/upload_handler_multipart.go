@@ -39,6 +39,8 @@ func (h *UploadHandler) handleEnqueueMultipartSetup(ctx context.Context, uploadS
 		Indexer:           uploadState.indexer,
 		IndexerVersion:    uploadState.indexerVersion,
 		AssociatedIndexID: &uploadState.associatedIndexID,
+		BaseUploadID:      uploadState.baseUploadID,
+		ChangedPaths:      uploadState.changedPaths,
 		State:             "uploading",
 		NumParts:          uploadState.numParts,
 		UploadedParts:     nil,
@@ -37,6 +37,8 @@ func (h *UploadHandler) handleEnqueueSinglePayload(ctx context.Context, uploadSt
 		Indexer:           uploadState.indexer,
 		IndexerVersion:    uploadState.indexerVersion,
 		AssociatedIndexID: &uploadState.associatedIndexID,
+		BaseUploadID:      uploadState.baseUploadID,
+		ChangedPaths:      uploadState.changedPaths,
 		State:             "uploading",
 		NumParts:          1,
 		UploadedParts:     []int{0},
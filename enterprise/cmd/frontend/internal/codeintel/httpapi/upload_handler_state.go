@@ -2,7 +2,10 @@ package httpapi
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 	"net/http"
+	"path"
 	"strings"
 
 	"github.com/inconshreveable/log15"
@@ -26,6 +29,8 @@ type uploadState struct {
 	indexer           string
 	indexerVersion    string
 	associatedIndexID int
+	baseUploadID      *int
+	changedPaths      []string
 	numParts          int
 	uploadedParts     []int
 	multipart         bool
@@ -71,6 +76,27 @@ func (h *UploadHandler) constructUploadState(ctx context.Context, r *http.Reques
 
 		// Stash repository id (user only gives us the name)
 		uploadState.repositoryID = repositoryID
+
+		if baseUploadID := getQueryInt(r, "baseUploadId"); baseUploadID != 0 {
+			// This is an incremental upload. Ensure that the base upload describes the same
+			// project so that the unchanged documents can be carried forward from it.
+			if statusCode, err := h.ensureBaseUploadMatches(ctx, uploadState, baseUploadID); err != nil {
+				return uploadState, statusCode, err
+			}
+
+			// The changed paths are the body of the setup request, so incremental uploads
+			// must be multipart uploads.
+			if !uploadState.multipart {
+				return uploadState, http.StatusBadRequest, errors.Errorf("incremental uploads must be multipart uploads")
+			}
+			changedPaths, err := readChangedPaths(r.Body)
+			if err != nil {
+				return uploadState, http.StatusBadRequest, err
+			}
+
+			uploadState.baseUploadID = &baseUploadID
+			uploadState.changedPaths = changedPaths
+		}
 	} else {
 		// An upload identifier was supplied; this is a subsequent request of a multi-part
 		// upload. Fetch the upload record to ensure that it hasn't since been deleted by
@@ -94,6 +120,30 @@ func (h *UploadHandler) constructUploadState(ctx context.Context, r *http.Reques
 	return uploadState, 0, nil
 }
 
+// ensureBaseUploadMatches ensures that the given base upload exists and was uploaded for the
+// same repository, root, and indexer as the incremental upload described by the given state.
+func (h *UploadHandler) ensureBaseUploadMatches(ctx context.Context, uploadState uploadState, baseUploadID int) (int, error) {
+	baseUpload, exists, err := h.dbStore.GetUploadByID(ctx, baseUploadID)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !exists {
+		return http.StatusNotFound, errors.Errorf("base upload %d not found", baseUploadID)
+	}
+
+	if baseUpload.RepositoryID != uploadState.repositoryID {
+		return http.StatusBadRequest, errors.Errorf("base upload %d belongs to a different repository", baseUploadID)
+	}
+	if baseUpload.Root != uploadState.root {
+		return http.StatusBadRequest, errors.Errorf("base upload %d has a different root (%q)", baseUploadID, baseUpload.Root)
+	}
+	if uploadState.indexer != "" && baseUpload.Indexer != uploadState.indexer {
+		return http.StatusBadRequest, errors.Errorf("base upload %d was produced by a different indexer (%q)", baseUploadID, baseUpload.Indexer)
+	}
+
+	return 0, nil
+}
+
 func ensureRepoAndCommitExist(ctx context.Context, db database.DB, repoName, commit string) (int, int, error) {
 	// 🚨 SECURITY: Bypass authz here; we've already determined that the current request is
 	// authorized to view the target repository; they are either a site admin or the code
@@ -108,6 +158,10 @@ func ensureRepoAndCommitExist(ctx context.Context, db database.DB, repoName, com
 		if errcode.IsNotFound(err) {
 			return 0, http.StatusNotFound, errors.Errorf("unknown repository %q", repoName)
 		}
+		var renamed backend.ErrRepoRenamed
+		if errors.As(err, &renamed) {
+			return 0, http.StatusNotFound, errors.Errorf("repository %q has been renamed to %q", repoName, renamed.NewName)
+		}
 
 		return 0, http.StatusInternalServerError, err
 	}
@@ -140,3 +194,42 @@ func sanitizeRoot(s string) string {
 	}
 	return s
 }
+
+// maxChangedPathsPayloadSize is the maximum size of the changed paths payload of an incremental upload.
+const maxChangedPathsPayloadSize = 32 * 1024 * 1024
+
+// changedPathsPayload is the body of the setup request of an incremental multipart upload.
+type changedPathsPayload struct {
+	ChangedPaths []string `json:"changedPaths"`
+}
+
+// readChangedPaths reads the repository-relative paths that were added, modified, or deleted
+// since the base upload of an incremental upload from the given request body.
+func readChangedPaths(body io.Reader) ([]string, error) {
+	var payload changedPathsPayload
+	if err := json.NewDecoder(io.LimitReader(body, maxChangedPathsPayloadSize)).Decode(&payload); err != nil {
+		return nil, errors.Wrap(err, "malformed changed paths payload")
+	}
+
+	return sanitizeChangedPaths(payload.ChangedPaths), nil
+}
+
+// sanitizeChangedPaths normalizes the given repository-relative paths, dropping empty and
+// duplicate values.
+func sanitizeChangedPaths(paths []string) []string {
+	seen := make(map[string]struct{}, len(paths))
+	sanitized := make([]string, 0, len(paths))
+	for _, p := range paths {
+		if p = strings.TrimPrefix(path.Clean("/"+p), "/"); p == "" {
+			continue
+		}
+		if _, ok := seen[p]; ok {
+			continue
+		}
+
+		seen[p] = struct{}{}
+		sanitized = append(sanitized, p)
+	}
+
+	return sanitized
+}
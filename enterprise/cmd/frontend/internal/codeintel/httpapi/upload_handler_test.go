@@ -198,6 +198,139 @@ func TestHandleEnqueueSinglePayloadNoIndexerName(t *testing.T) {
 	}
 }
 
+func TestHandleEnqueueMultipartSetupIncremental(t *testing.T) {
+	setupRepoMocks(t)
+
+	mockDBStore := NewMockDBStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+
+	mockDBStore.TransactFunc.SetDefaultReturn(mockDBStore, nil)
+	mockDBStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+	mockDBStore.InsertUploadFunc.SetDefaultReturn(42, nil)
+	mockDBStore.GetUploadByIDFunc.SetDefaultReturn(store.Upload{ID: 41, RepositoryID: 50, Root: "proj/", Indexer: "lsif-go"}, true, nil)
+
+	testURL, err := url.Parse("http://test.com/upload")
+	if err != nil {
+		t.Fatalf("unexpected error constructing url: %s", err)
+	}
+	testURL.RawQuery = (url.Values{
+		"commit":       []string{testCommit},
+		"root":         []string{"proj/"},
+		"repository":   []string{"github.com/test/test"},
+		"indexerName":  []string{"lsif-go"},
+		"multiPart":    []string{"true"},
+		"numParts":     []string{"3"},
+		"baseUploadId": []string{"41"},
+	}).Encode()
+
+	w := httptest.NewRecorder()
+	r, err := http.NewRequest("POST", testURL.String(), strings.NewReader(`{"changedPaths": ["proj/a.go", "/proj/b.go", "proj/a.go", ""]}`))
+	if err != nil {
+		t.Fatalf("unexpected error constructing request: %s", err)
+	}
+
+	NewUploadHandler(
+		database.NewDB(nil),
+		mockDBStore,
+		mockUploadStore,
+		true,
+		nil,
+		NewOperations(&observation.TestContext),
+		nil,
+	).ServeHTTP(w, r)
+
+	if w.Code != http.StatusAccepted {
+		t.Errorf("unexpected status code. want=%d have=%d", http.StatusAccepted, w.Code)
+	}
+
+	if len(mockDBStore.InsertUploadFunc.History()) != 1 {
+		t.Errorf("unexpected number of InsertUpload calls. want=%d have=%d", 1, len(mockDBStore.InsertUploadFunc.History()))
+	} else {
+		call := mockDBStore.InsertUploadFunc.History()[0]
+		if call.Arg1.BaseUploadID == nil || *call.Arg1.BaseUploadID != 41 {
+			t.Errorf("unexpected base upload id. want=%d have=%v", 41, call.Arg1.BaseUploadID)
+		}
+		if diff := cmp.Diff([]string{"proj/a.go", "proj/b.go"}, call.Arg1.ChangedPaths); diff != "" {
+			t.Errorf("unexpected changed paths (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestHandleEnqueueIncrementalInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		baseUpload store.Upload
+		multipart  bool
+		body       string
+	}{
+		{
+			name:       "mismatched base upload",
+			baseUpload: store.Upload{ID: 41, RepositoryID: 50, Root: "other/", Indexer: "lsif-go"},
+			multipart:  true,
+			body:       `{"changedPaths": ["proj/a.go"]}`,
+		},
+		{
+			name:       "single payload",
+			baseUpload: store.Upload{ID: 41, RepositoryID: 50, Root: "proj/", Indexer: "lsif-go"},
+			body:       "payload",
+		},
+		{
+			name:       "malformed changed paths",
+			baseUpload: store.Upload{ID: 41, RepositoryID: 50, Root: "proj/", Indexer: "lsif-go"},
+			multipart:  true,
+			body:       "proj/a.go",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			setupRepoMocks(t)
+
+			mockDBStore := NewMockDBStore()
+			mockUploadStore := uploadstoremocks.NewMockStore()
+			mockDBStore.GetUploadByIDFunc.SetDefaultReturn(tc.baseUpload, true, nil)
+
+			testURL, err := url.Parse("http://test.com/upload")
+			if err != nil {
+				t.Fatalf("unexpected error constructing url: %s", err)
+			}
+			query := url.Values{
+				"commit":       []string{testCommit},
+				"root":         []string{"proj/"},
+				"repository":   []string{"github.com/test/test"},
+				"indexerName":  []string{"lsif-go"},
+				"baseUploadId": []string{"41"},
+			}
+			if tc.multipart {
+				query.Set("multiPart", "true")
+				query.Set("numParts", "3")
+			}
+			testURL.RawQuery = query.Encode()
+
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest("POST", testURL.String(), strings.NewReader(tc.body))
+			if err != nil {
+				t.Fatalf("unexpected error constructing request: %s", err)
+			}
+
+			NewUploadHandler(
+				database.NewDB(nil),
+				mockDBStore,
+				mockUploadStore,
+				true,
+				nil,
+				NewOperations(&observation.TestContext),
+				nil,
+			).ServeHTTP(w, r)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("unexpected status code. want=%d have=%d", http.StatusBadRequest, w.Code)
+			}
+			if len(mockDBStore.InsertUploadFunc.History()) != 0 {
+				t.Errorf("unexpected number of InsertUpload calls. want=%d have=%d", 0, len(mockDBStore.InsertUploadFunc.History()))
+			}
+		})
+	}
+}
+
 func TestHandleEnqueueMultipartSetup(t *testing.T) {
 	setupRepoMocks(t)
 
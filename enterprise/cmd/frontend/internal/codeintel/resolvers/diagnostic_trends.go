@@ -0,0 +1,11 @@
+package resolvers
+
+import (
+	"context"
+
+	store "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+)
+
+func (r *resolver) GetDiagnosticTrends(ctx context.Context, opts store.GetDiagnosticTrendsOptions) ([]store.DiagnosticTrendPoint, error) {
+	return r.dbStore.GetDiagnosticTrends(ctx, opts)
+}
@@ -0,0 +1,86 @@
+package graphql
+
+import (
+	"sort"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type configurationPolicyPreviewResolver struct {
+	db               database.DB
+	resolver         resolvers.Resolver
+	gitserver        GitserverClient
+	preview          resolvers.ConfigurationPolicyPreview
+	prefetcher       *Prefetcher
+	locationResolver *CachedLocationResolver
+	errTracer        *observation.ErrCollector
+}
+
+var _ gql.CodeIntelligenceConfigurationPolicyPreviewResolver = &configurationPolicyPreviewResolver{}
+
+func NewConfigurationPolicyPreviewResolver(
+	db database.DB,
+	resolver resolvers.Resolver,
+	gitserver GitserverClient,
+	preview resolvers.ConfigurationPolicyPreview,
+	prefetcher *Prefetcher,
+	locationResolver *CachedLocationResolver,
+	errTracer *observation.ErrCollector,
+) gql.CodeIntelligenceConfigurationPolicyPreviewResolver {
+	return &configurationPolicyPreviewResolver{
+		db:               db,
+		resolver:         resolver,
+		gitserver:        gitserver,
+		preview:          preview,
+		prefetcher:       prefetcher,
+		locationResolver: locationResolver,
+		errTracer:        errTracer,
+	}
+}
+
+func (r *configurationPolicyPreviewResolver) NewlyExpiredUploads() []gql.LSIFUploadResolver {
+	return r.uploadResolvers(r.preview.NewlyExpiredUploads)
+}
+
+func (r *configurationPolicyPreviewResolver) NewlyProtectedUploads() []gql.LSIFUploadResolver {
+	return r.uploadResolvers(r.preview.NewlyProtectedUploads)
+}
+
+func (r *configurationPolicyPreviewResolver) ReclaimedStorageBytes() gql.BigInt {
+	return gql.BigInt{Int: r.preview.ReclaimedStorageBytes}
+}
+
+func (r *configurationPolicyPreviewResolver) UploadsTruncated() bool {
+	return r.preview.UploadsTruncated
+}
+
+func (r *configurationPolicyPreviewResolver) NewlyIndexedCommits() []gql.GitObjectFilterPreviewResolver {
+	var previews []gql.GitObjectFilterPreviewResolver
+	for rev, names := range r.preview.NewlyIndexedCommits {
+		for _, name := range names {
+			previews = append(previews, &gitObjectFilterPreviewResolver{
+				name: name,
+				rev:  rev,
+			})
+		}
+	}
+
+	sort.Slice(previews, func(i, j int) bool {
+		return previews[i].Name() < previews[j].Name() || (previews[i].Name() == previews[j].Name() && previews[i].Rev() < previews[j].Rev())
+	})
+
+	return previews
+}
+
+func (r *configurationPolicyPreviewResolver) uploadResolvers(uploads []dbstore.Upload) []gql.LSIFUploadResolver {
+	resolvers := make([]gql.LSIFUploadResolver, 0, len(uploads))
+	for _, upload := range uploads {
+		resolvers = append(resolvers, NewUploadResolver(r.db, r.gitserver, r.resolver, upload, r.prefetcher, r.locationResolver, r.errTracer))
+	}
+
+	return resolvers
+}
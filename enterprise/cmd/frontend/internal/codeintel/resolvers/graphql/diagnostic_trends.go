@@ -0,0 +1,154 @@
+package graphql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// 🚨 SECURITY: Only site admins may view organization-wide diagnostic trends
+func (r *Resolver) CodeIntelligenceDiagnosticTrends(ctx context.Context, args *gql.CodeIntelligenceDiagnosticTrendsArgs) (_ []gql.DiagnosticTrendSeriesResolver, err error) {
+	ctx, _, endObservation := r.observationContext.codeIntelDiagnosticTrends.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("groupBy", args.GroupBy),
+		log.String("interval", args.Interval),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	opts, err := makeGetDiagnosticTrendsOptions(args)
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := r.resolver.GetDiagnosticTrends(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDiagnosticTrendSeriesResolvers(points), nil
+}
+
+func makeGetDiagnosticTrendsOptions(args *gql.CodeIntelligenceDiagnosticTrendsArgs) (dbstore.GetDiagnosticTrendsOptions, error) {
+	opts := dbstore.GetDiagnosticTrendsOptions{
+		GroupBy:  dbstore.DiagnosticTrendGrouping(strings.ToLower(args.GroupBy)),
+		Interval: dbstore.DiagnosticTrendInterval(strings.ToLower(args.Interval)),
+	}
+
+	if args.Repository != nil {
+		repositoryID, err := gql.UnmarshalRepositoryID(*args.Repository)
+		if err != nil {
+			return opts, err
+		}
+		opts.RepositoryID = int(repositoryID)
+	}
+	if args.Severity != nil {
+		severity, err := fromSeverity(*args.Severity)
+		if err != nil {
+			return opts, err
+		}
+		opts.Severity = severity
+	}
+	if args.Source != nil {
+		opts.Source = *args.Source
+	}
+	if args.Code != nil {
+		opts.Code = *args.Code
+	}
+	if args.From != nil {
+		opts.From = &args.From.Time
+	}
+	if args.To != nil {
+		opts.To = &args.To.Time
+	}
+
+	return opts, nil
+}
+
+func fromSeverity(severity string) (int, error) {
+	for value, name := range severities {
+		if name == severity {
+			return value, nil
+		}
+	}
+
+	return 0, errors.Errorf("unknown diagnostic severity %q", severity)
+}
+
+type diagnosticTrendSeriesResolver struct {
+	severity int
+	source   string
+	code     string
+	points   []gql.DiagnosticTrendPointResolver
+}
+
+// newDiagnosticTrendSeriesResolvers splits the given points, ordered by time then series, into
+// series ordered by their first appearance.
+func newDiagnosticTrendSeriesResolvers(points []dbstore.DiagnosticTrendPoint) []gql.DiagnosticTrendSeriesResolver {
+	type key struct {
+		severity     int
+		source, code string
+	}
+
+	indexes := map[key]int{}
+	series := []*diagnosticTrendSeriesResolver{}
+	for _, point := range points {
+		k := key{point.Severity, point.Source, point.Code}
+
+		i, ok := indexes[k]
+		if !ok {
+			i = len(series)
+			indexes[k] = i
+			series = append(series, &diagnosticTrendSeriesResolver{
+				severity: point.Severity,
+				source:   point.Source,
+				code:     point.Code,
+			})
+		}
+
+		series[i].points = append(series[i].points, &diagnosticTrendPointResolver{point: point})
+	}
+
+	resolvers := make([]gql.DiagnosticTrendSeriesResolver, 0, len(series))
+	for _, s := range series {
+		resolvers = append(resolvers, s)
+	}
+
+	return resolvers
+}
+
+func (r *diagnosticTrendSeriesResolver) Severity() (*string, error) {
+	if r.severity == 0 {
+		return nil, nil
+	}
+
+	return toSeverity(r.severity)
+}
+
+func (r *diagnosticTrendSeriesResolver) Source() *string { return strPtr(r.source) }
+func (r *diagnosticTrendSeriesResolver) Code() *string   { return strPtr(r.code) }
+
+func (r *diagnosticTrendSeriesResolver) Points() []gql.DiagnosticTrendPointResolver {
+	return r.points
+}
+
+type diagnosticTrendPointResolver struct {
+	point dbstore.DiagnosticTrendPoint
+}
+
+func (r *diagnosticTrendPointResolver) DateTime() gql.DateTime {
+	return gql.DateTime{Time: r.point.Time}
+}
+
+func (r *diagnosticTrendPointResolver) Count() int32 {
+	return int32(r.point.Count)
+}
@@ -0,0 +1,102 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	resolvermocks "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers/mocks"
+	store "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCodeIntelligenceDiagnosticTrends(t *testing.T) {
+	users := database.NewStrictMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true}, nil)
+
+	db := database.NewStrictMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+
+	day1 := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.Add(time.Hour * 24)
+
+	mockResolver := resolvermocks.NewMockResolver()
+	mockResolver.GetDiagnosticTrendsFunc.SetDefaultReturn([]store.DiagnosticTrendPoint{
+		{Time: day1, Severity: 1, Count: 8},
+		{Time: day1, Severity: 2, Count: 9},
+		{Time: day2, Severity: 2, Count: 4},
+	}, nil)
+
+	repository := relay.MarshalID("Repository", 50)
+	severity := "WARNING"
+	args := &gql.CodeIntelligenceDiagnosticTrendsArgs{
+		Repository: &repository,
+		Severity:   &severity,
+		GroupBy:    "SEVERITY",
+		Interval:   "WEEK",
+		From:       &gql.DateTime{Time: day1},
+	}
+
+	series, err := NewResolver(db, nil, mockResolver, &observation.TestContext).CodeIntelligenceDiagnosticTrends(context.Background(), args)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if calls := mockResolver.GetDiagnosticTrendsFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(calls))
+	} else {
+		expectedOpts := store.GetDiagnosticTrendsOptions{
+			RepositoryID: 50,
+			Severity:     2,
+			GroupBy:      store.DiagnosticTrendGroupingSeverity,
+			Interval:     store.DiagnosticTrendIntervalWeek,
+			From:         &day1,
+		}
+		if diff := cmp.Diff(expectedOpts, calls[0].Arg1); diff != "" {
+			t.Errorf("unexpected options (-want +got):\n%s", diff)
+		}
+	}
+
+	type seriesCounts struct {
+		Severity string
+		Counts   []int32
+	}
+	var actual []seriesCounts
+	for _, s := range series {
+		severity, err := s.Severity()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		var counts []int32
+		for _, point := range s.Points() {
+			counts = append(counts, point.Count())
+		}
+		actual = append(actual, seriesCounts{Severity: *severity, Counts: counts})
+	}
+
+	expected := []seriesCounts{
+		{Severity: "ERROR", Counts: []int32{8}},
+		{Severity: "WARNING", Counts: []int32{9, 4}},
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("unexpected series (-want +got):\n%s", diff)
+	}
+}
+
+func TestCodeIntelligenceDiagnosticTrendsUnauthenticated(t *testing.T) {
+	db := database.NewDB(nil)
+	mockResolver := resolvermocks.NewMockResolver()
+
+	args := &gql.CodeIntelligenceDiagnosticTrendsArgs{GroupBy: "SEVERITY", Interval: "DAY"}
+	if _, err := NewResolver(db, nil, mockResolver, &observation.TestContext).CodeIntelligenceDiagnosticTrends(context.Background(), args); err != backend.ErrNotAuthenticated {
+		t.Errorf("unexpected error. want=%q have=%q", backend.ErrNotAuthenticated, err)
+	}
+}
@@ -109,6 +109,10 @@ func (r *frankenResolver) PreviewRepositoryFilter(ctx context.Context, args *gql
 	return r.getPoliciesServiceResolver().PreviewRepositoryFilter(ctx, args)
 }
 
+func (r *frankenResolver) PreviewCodeIntelligenceConfigurationPolicy(ctx context.Context, id graphql.ID, args *gql.PreviewCodeIntelligenceConfigurationPolicyArgs) (_ gql.CodeIntelligenceConfigurationPolicyPreviewResolver, err error) {
+	return r.getPoliciesServiceResolver().PreviewCodeIntelligenceConfigurationPolicy(ctx, id, args)
+}
+
 func (r *frankenResolver) PreviewGitObjectFilter(ctx context.Context, id graphql.ID, args *gql.PreviewGitObjectFilterArgs) (_ []gql.GitObjectFilterPreviewResolver, err error) {
 	return r.getPoliciesServiceResolver().PreviewGitObjectFilter(ctx, id, args)
 }
@@ -76,6 +76,14 @@ var (
 		name: "scip-python",
 		urn:  "github.com/sourcegraph/scip-python",
 	}
+	scipRuby = codeIntelIndexerResolver{
+		name: "scip-ruby",
+		urn:  "github.com/sourcegraph/scip-ruby",
+	}
+	scipDotnet = codeIntelIndexerResolver{
+		name: "scip-dotnet",
+		urn:  "github.com/sourcegraph/scip-dotnet",
+	}
 	rustAnalyzer = codeIntelIndexerResolver{
 		name: "rust-analyzer",
 		urn:  "github.com/rust-analyzer/rust-analyzer",
@@ -109,6 +117,8 @@ var allIndexers = []gql.CodeIntelIndexerResolver{
 	&lsifJsonnet,
 	&lsifOcaml,
 	&scipPython,
+	&scipRuby,
+	&scipDotnet,
 	&rustAnalyzer,
 	&lsifPHP,
 	&lsifTerraform,
@@ -140,7 +150,8 @@ var languageToIndexer = map[string][]gql.CodeIntelIndexerResolver{
 	".rs":      {&rustAnalyzer},
 	".php":     {&lsifPHP},
 	".tf":      {&lsifTerraform},
-	".cs":      {&lsifDotnet},
+	".rb":      {&scipRuby},
+	".cs":      {&scipDotnet, &lsifDotnet},
 }
 
 var imageToIndexer = map[string]gql.CodeIntelIndexerResolver{
@@ -151,4 +162,7 @@ var imageToIndexer = map[string]gql.CodeIntelIndexerResolver{
 	"sourcegraph/lsif-clang":      &lsifClang,
 	"davidrjenni/lsif-php":        &lsifPHP,
 	"sourcegraph/lsif-rust":       &rustAnalyzer,
+	"sourcegraph/scip-python":     &scipPython,
+	"sourcegraph/scip-ruby":       &scipRuby,
+	"sourcegraph/scip-dotnet":     &scipDotnet,
 }
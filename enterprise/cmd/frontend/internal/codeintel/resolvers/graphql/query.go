@@ -56,6 +56,10 @@ func NewQueryResolver(gitserver GitserverClient, queryResolver resolvers.QueryRe
 func (r *QueryResolver) ToGitTreeLSIFData() (gql.GitTreeLSIFDataResolver, bool) { return r, true }
 func (r *QueryResolver) ToGitBlobLSIFData() (gql.GitBlobLSIFDataResolver, bool) { return r, true }
 
+func (r *QueryResolver) Precise() bool {
+	return r.queryResolver.Precise()
+}
+
 func (r *QueryResolver) Stencil(ctx context.Context) (_ []gql.RangeResolver, err error) {
 	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "stencil"))
 
@@ -173,6 +177,47 @@ func (r *QueryResolver) Implementations(ctx context.Context, args *gql.LSIFPaged
 	return NewLocationConnectionResolver(locations, strPtr(cursor), r.locationResolver), nil
 }
 
+func (r *QueryResolver) Supertypes(ctx context.Context, args *gql.LSIFPagedQueryPositionArgs) (_ gql.LocationConnectionResolver, err error) {
+	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "supertypes"))
+	return r.typeHierarchy(ctx, args, r.queryResolver.Supertypes)
+}
+
+func (r *QueryResolver) Subtypes(ctx context.Context, args *gql.LSIFPagedQueryPositionArgs) (_ gql.LocationConnectionResolver, err error) {
+	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "subtypes"))
+	return r.typeHierarchy(ctx, args, r.queryResolver.Subtypes)
+}
+
+type typeHierarchyFunc func(ctx context.Context, line, character, limit int, rawCursor string) ([]resolvers.AdjustedLocation, string, error)
+
+func (r *QueryResolver) typeHierarchy(ctx context.Context, args *gql.LSIFPagedQueryPositionArgs, getLocations typeHierarchyFunc) (gql.LocationConnectionResolver, error) {
+	limit := derefInt32(args.First, DefaultImplementationsPageSize)
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	cursor, err := graphqlutil.DecodeCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	locations, cursor, err := getLocations(ctx, int(args.Line), int(args.Character), limit, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.Filter != nil && *args.Filter != "" {
+		filtered := locations[:0]
+		for _, loc := range locations {
+			if strings.Contains(loc.Path, *args.Filter) {
+				filtered = append(filtered, loc)
+			}
+		}
+		locations = filtered
+	}
+
+	return NewLocationConnectionResolver(locations, strPtr(cursor), r.locationResolver), nil
+}
+
 func (r *QueryResolver) Hover(ctx context.Context, args *gql.LSIFQueryPositionArgs) (_ gql.HoverResolver, err error) {
 	defer r.errTracer.Collect(&err, log.String("queryResolver.field", "hover"))
 
@@ -679,6 +679,65 @@ func (r *Resolver) PreviewGitObjectFilter(ctx context.Context, id graphql.ID, ar
 	return previews, nil
 }
 
+// 🚨 SECURITY: Only site admins may preview code intelligence configuration policies
+func (r *Resolver) PreviewCodeIntelligenceConfigurationPolicy(ctx context.Context, id graphql.ID, args *gql.PreviewCodeIntelligenceConfigurationPolicyArgs) (_ gql.CodeIntelligenceConfigurationPolicyPreviewResolver, err error) {
+	ctx, errTracer, endObservation := r.observationContext.previewConfigurationPolicy.WithErrors(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("repoID", string(id)),
+	}})
+	endObservation.OnCancel(ctx, 1, observation.Args{})
+
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	if err := validateConfigurationPolicy(args.CodeIntelConfigurationPolicy); err != nil {
+		return nil, err
+	}
+
+	repositoryID, err := gql.UnmarshalRepositoryID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var policyID int64
+	if args.Policy != nil {
+		policyID, err = unmarshalConfigurationPolicyGQLID(*args.Policy)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	preview, err := r.resolver.PreviewConfigurationPolicy(ctx, int(repositoryID), store.ConfigurationPolicy{
+		ID:                        int(policyID),
+		Name:                      args.Name,
+		Type:                      store.GitObjectType(args.Type),
+		Pattern:                   args.Pattern,
+		RetentionEnabled:          args.RetentionEnabled,
+		RetentionDuration:         toDuration(args.RetentionDurationHours),
+		RetainIntermediateCommits: args.RetainIntermediateCommits,
+		IndexingEnabled:           args.IndexingEnabled,
+		IndexCommitMaxAge:         toDuration(args.IndexCommitMaxAgeHours),
+		IndexIntermediateCommits:  args.IndexIntermediateCommits,
+	}, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a new prefetcher here as we only want to cache upload and index records in
+	// the same graphQL request, not across different request.
+	prefetcher := NewPrefetcher(r.resolver)
+
+	return NewConfigurationPolicyPreviewResolver(
+		r.db,
+		r.resolver,
+		r.gitserver,
+		preview,
+		prefetcher,
+		r.locationResolver,
+		errTracer,
+	), nil
+}
+
 // makeGetUploadsOptions translates the given GraphQL arguments into options defined by the
 // store.GetUploads operations.
 func makeGetUploadsOptions(args *gql.LSIFRepositoryUploadsQueryArgs) (store.GetUploadsOptions, error) {
@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
 	gs "github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 )
@@ -62,6 +63,7 @@ type DBStore interface {
 	LastIndexScanForRepository(ctx context.Context, repositoryID int) (*time.Time, error)
 	RequestLanguageSupport(ctx context.Context, userID int, language string) error
 	LanguagesRequestedBy(ctx context.Context, userID int) ([]string, error)
+	GetDiagnosticTrends(ctx context.Context, opts dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error)
 }
 
 type LSIFStore interface {
@@ -79,6 +81,12 @@ type LSIFStore interface {
 	PackageInformation(ctx context.Context, bundleID int, path string, packageInformationID string) (precise.PackageInformationData, bool, error)
 }
 
+type SearchBasedSearcher interface {
+	ReadFile(ctx context.Context, repo types.MinimalRepo, commit api.CommitID, path string) ([]byte, error)
+	SymbolDefinitions(ctx context.Context, repo types.MinimalRepo, commit api.CommitID, name string, limit int) ([]SearchBasedCandidate, error)
+	TextOccurrences(ctx context.Context, repo types.MinimalRepo, commit api.CommitID, name string, limit int) ([]SearchBasedCandidate, error)
+}
+
 type IndexEnqueuer interface {
 	QueueIndexes(ctx context.Context, repositoryID int, rev, configuration string, force bool) ([]dbstore.Index, error)
 	InferIndexConfiguration(ctx context.Context, repositoryID int, commit string) (*config.IndexConfiguration, []config.IndexJobHint, error)
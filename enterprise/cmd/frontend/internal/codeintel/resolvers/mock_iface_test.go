@@ -67,6 +67,9 @@ type MockDBStore struct {
 	// object controlling the behavior of the method
 	// GetConfigurationPolicyByID.
 	GetConfigurationPolicyByIDFunc *DBStoreGetConfigurationPolicyByIDFunc
+	// GetDiagnosticTrendsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDiagnosticTrends.
+	GetDiagnosticTrendsFunc *DBStoreGetDiagnosticTrendsFunc
 	// GetDumpsByIDsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetDumpsByIDs.
 	GetDumpsByIDsFunc *DBStoreGetDumpsByIDsFunc
@@ -202,6 +205,11 @@ func NewMockDBStore() *MockDBStore {
 				return
 			},
 		},
+		GetDiagnosticTrendsFunc: &DBStoreGetDiagnosticTrendsFunc{
+			defaultHook: func(context.Context, dbstore.GetDiagnosticTrendsOptions) (r0 []dbstore.DiagnosticTrendPoint, r1 error) {
+				return
+			},
+		},
 		GetDumpsByIDsFunc: &DBStoreGetDumpsByIDsFunc{
 			defaultHook: func(context.Context, []int) (r0 []dbstore.Dump, r1 error) {
 				return
@@ -379,6 +387,11 @@ func NewStrictMockDBStore() *MockDBStore {
 				panic("unexpected invocation of MockDBStore.GetConfigurationPolicyByID")
 			},
 		},
+		GetDiagnosticTrendsFunc: &DBStoreGetDiagnosticTrendsFunc{
+			defaultHook: func(context.Context, dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error) {
+				panic("unexpected invocation of MockDBStore.GetDiagnosticTrends")
+			},
+		},
 		GetDumpsByIDsFunc: &DBStoreGetDumpsByIDsFunc{
 			defaultHook: func(context.Context, []int) ([]dbstore.Dump, error) {
 				panic("unexpected invocation of MockDBStore.GetDumpsByIDs")
@@ -534,6 +547,9 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		GetConfigurationPolicyByIDFunc: &DBStoreGetConfigurationPolicyByIDFunc{
 			defaultHook: i.GetConfigurationPolicyByID,
 		},
+		GetDiagnosticTrendsFunc: &DBStoreGetDiagnosticTrendsFunc{
+			defaultHook: i.GetDiagnosticTrends,
+		},
 		GetDumpsByIDsFunc: &DBStoreGetDumpsByIDsFunc{
 			defaultHook: i.GetDumpsByIDs,
 		},
@@ -1855,6 +1871,114 @@ func (c DBStoreGetConfigurationPolicyByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// DBStoreGetDiagnosticTrendsFunc describes the behavior when the
+// GetDiagnosticTrends method of the parent MockDBStore instance is invoked.
+type DBStoreGetDiagnosticTrendsFunc struct {
+	defaultHook func(context.Context, dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error)
+	hooks       []func(context.Context, dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error)
+	history     []DBStoreGetDiagnosticTrendsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDiagnosticTrends delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) GetDiagnosticTrends(v0 context.Context, v1 dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error) {
+	r0, r1 := m.GetDiagnosticTrendsFunc.nextHook()(v0, v1)
+	m.GetDiagnosticTrendsFunc.appendCall(DBStoreGetDiagnosticTrendsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetDiagnosticTrends
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreGetDiagnosticTrendsFunc) SetDefaultHook(hook func(context.Context, dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDiagnosticTrends method of the parent MockDBStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *DBStoreGetDiagnosticTrendsFunc) PushHook(hook func(context.Context, dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBStoreGetDiagnosticTrendsFunc) SetDefaultReturn(r0 []dbstore.DiagnosticTrendPoint, r1 error) {
+	f.SetDefaultHook(func(context.Context, dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBStoreGetDiagnosticTrendsFunc) PushReturn(r0 []dbstore.DiagnosticTrendPoint, r1 error) {
+	f.PushHook(func(context.Context, dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreGetDiagnosticTrendsFunc) nextHook() func(context.Context, dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreGetDiagnosticTrendsFunc) appendCall(r0 DBStoreGetDiagnosticTrendsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreGetDiagnosticTrendsFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreGetDiagnosticTrendsFunc) History() []DBStoreGetDiagnosticTrendsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreGetDiagnosticTrendsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreGetDiagnosticTrendsFuncCall is an object that describes an
+// invocation of method GetDiagnosticTrends on an instance of MockDBStore.
+type DBStoreGetDiagnosticTrendsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 dbstore.GetDiagnosticTrendsOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []dbstore.DiagnosticTrendPoint
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreGetDiagnosticTrendsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreGetDiagnosticTrendsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // DBStoreGetDumpsByIDsFunc describes the behavior when the GetDumpsByIDs
 // method of the parent MockDBStore instance is invoked.
 type DBStoreGetDumpsByIDsFunc struct {
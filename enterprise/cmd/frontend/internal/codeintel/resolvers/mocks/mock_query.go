@@ -35,6 +35,9 @@ type MockQueryResolver struct {
 	// LSIFUploadsFunc is an instance of a mock function object controlling
 	// the behavior of the method LSIFUploads.
 	LSIFUploadsFunc *QueryResolverLSIFUploadsFunc
+	// PreciseFunc is an instance of a mock function object controlling the
+	// behavior of the method Precise.
+	PreciseFunc *QueryResolverPreciseFunc
 	// RangesFunc is an instance of a mock function object controlling the
 	// behavior of the method Ranges.
 	RangesFunc *QueryResolverRangesFunc
@@ -44,6 +47,12 @@ type MockQueryResolver struct {
 	// StencilFunc is an instance of a mock function object controlling the
 	// behavior of the method Stencil.
 	StencilFunc *QueryResolverStencilFunc
+	// SubtypesFunc is an instance of a mock function object controlling the
+	// behavior of the method Subtypes.
+	SubtypesFunc *QueryResolverSubtypesFunc
+	// SupertypesFunc is an instance of a mock function object controlling
+	// the behavior of the method Supertypes.
+	SupertypesFunc *QueryResolverSupertypesFunc
 }
 
 // NewMockQueryResolver creates a new mock of the QueryResolver interface.
@@ -75,6 +84,11 @@ func NewMockQueryResolver() *MockQueryResolver {
 				return
 			},
 		},
+		PreciseFunc: &QueryResolverPreciseFunc{
+			defaultHook: func() (r0 bool) {
+				return
+			},
+		},
 		RangesFunc: &QueryResolverRangesFunc{
 			defaultHook: func(context.Context, int, int) (r0 []resolvers.AdjustedCodeIntelligenceRange, r1 error) {
 				return
@@ -90,6 +104,16 @@ func NewMockQueryResolver() *MockQueryResolver {
 				return
 			},
 		},
+		SubtypesFunc: &QueryResolverSubtypesFunc{
+			defaultHook: func(context.Context, int, int, int, string) (r0 []resolvers.AdjustedLocation, r1 string, r2 error) {
+				return
+			},
+		},
+		SupertypesFunc: &QueryResolverSupertypesFunc{
+			defaultHook: func(context.Context, int, int, int, string) (r0 []resolvers.AdjustedLocation, r1 string, r2 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -122,6 +146,11 @@ func NewStrictMockQueryResolver() *MockQueryResolver {
 				panic("unexpected invocation of MockQueryResolver.LSIFUploads")
 			},
 		},
+		PreciseFunc: &QueryResolverPreciseFunc{
+			defaultHook: func() bool {
+				panic("unexpected invocation of MockQueryResolver.Precise")
+			},
+		},
 		RangesFunc: &QueryResolverRangesFunc{
 			defaultHook: func(context.Context, int, int) ([]resolvers.AdjustedCodeIntelligenceRange, error) {
 				panic("unexpected invocation of MockQueryResolver.Ranges")
@@ -137,6 +166,16 @@ func NewStrictMockQueryResolver() *MockQueryResolver {
 				panic("unexpected invocation of MockQueryResolver.Stencil")
 			},
 		},
+		SubtypesFunc: &QueryResolverSubtypesFunc{
+			defaultHook: func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error) {
+				panic("unexpected invocation of MockQueryResolver.Subtypes")
+			},
+		},
+		SupertypesFunc: &QueryResolverSupertypesFunc{
+			defaultHook: func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error) {
+				panic("unexpected invocation of MockQueryResolver.Supertypes")
+			},
+		},
 	}
 }
 
@@ -160,6 +199,9 @@ func NewMockQueryResolverFrom(i resolvers.QueryResolver) *MockQueryResolver {
 		LSIFUploadsFunc: &QueryResolverLSIFUploadsFunc{
 			defaultHook: i.LSIFUploads,
 		},
+		PreciseFunc: &QueryResolverPreciseFunc{
+			defaultHook: i.Precise,
+		},
 		RangesFunc: &QueryResolverRangesFunc{
 			defaultHook: i.Ranges,
 		},
@@ -169,6 +211,12 @@ func NewMockQueryResolverFrom(i resolvers.QueryResolver) *MockQueryResolver {
 		StencilFunc: &QueryResolverStencilFunc{
 			defaultHook: i.Stencil,
 		},
+		SubtypesFunc: &QueryResolverSubtypesFunc{
+			defaultHook: i.Subtypes,
+		},
+		SupertypesFunc: &QueryResolverSupertypesFunc{
+			defaultHook: i.Supertypes,
+		},
 	}
 }
 
@@ -736,6 +784,105 @@ func (c QueryResolverLSIFUploadsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// QueryResolverPreciseFunc describes the behavior when the Precise method
+// of the parent MockQueryResolver instance is invoked.
+type QueryResolverPreciseFunc struct {
+	defaultHook func() bool
+	hooks       []func() bool
+	history     []QueryResolverPreciseFuncCall
+	mutex       sync.Mutex
+}
+
+// Precise delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockQueryResolver) Precise() bool {
+	r0 := m.PreciseFunc.nextHook()()
+	m.PreciseFunc.appendCall(QueryResolverPreciseFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Precise method of
+// the parent MockQueryResolver instance is invoked and the hook queue is
+// empty.
+func (f *QueryResolverPreciseFunc) SetDefaultHook(hook func() bool) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Precise method of the parent MockQueryResolver instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *QueryResolverPreciseFunc) PushHook(hook func() bool) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *QueryResolverPreciseFunc) SetDefaultReturn(r0 bool) {
+	f.SetDefaultHook(func() bool {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *QueryResolverPreciseFunc) PushReturn(r0 bool) {
+	f.PushHook(func() bool {
+		return r0
+	})
+}
+
+func (f *QueryResolverPreciseFunc) nextHook() func() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *QueryResolverPreciseFunc) appendCall(r0 QueryResolverPreciseFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of QueryResolverPreciseFuncCall objects
+// describing the invocations of this function.
+func (f *QueryResolverPreciseFunc) History() []QueryResolverPreciseFuncCall {
+	f.mutex.Lock()
+	history := make([]QueryResolverPreciseFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// QueryResolverPreciseFuncCall is an object that describes an invocation of
+// method Precise on an instance of MockQueryResolver.
+type QueryResolverPreciseFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c QueryResolverPreciseFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c QueryResolverPreciseFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // QueryResolverRangesFunc describes the behavior when the Ranges method of
 // the parent MockQueryResolver instance is invoked.
 type QueryResolverRangesFunc struct {
@@ -1070,3 +1217,243 @@ func (c QueryResolverStencilFuncCall) Args() []interface{} {
 func (c QueryResolverStencilFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
+
+// QueryResolverSubtypesFunc describes the behavior when the Subtypes method
+// of the parent MockQueryResolver instance is invoked.
+type QueryResolverSubtypesFunc struct {
+	defaultHook func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error)
+	hooks       []func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error)
+	history     []QueryResolverSubtypesFuncCall
+	mutex       sync.Mutex
+}
+
+// Subtypes delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockQueryResolver) Subtypes(v0 context.Context, v1 int, v2 int, v3 int, v4 string) ([]resolvers.AdjustedLocation, string, error) {
+	r0, r1, r2 := m.SubtypesFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.SubtypesFunc.appendCall(QueryResolverSubtypesFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the Subtypes method of
+// the parent MockQueryResolver instance is invoked and the hook queue is
+// empty.
+func (f *QueryResolverSubtypesFunc) SetDefaultHook(hook func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Subtypes method of the parent MockQueryResolver instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *QueryResolverSubtypesFunc) PushHook(hook func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *QueryResolverSubtypesFunc) SetDefaultReturn(r0 []resolvers.AdjustedLocation, r1 string, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *QueryResolverSubtypesFunc) PushReturn(r0 []resolvers.AdjustedLocation, r1 string, r2 error) {
+	f.PushHook(func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *QueryResolverSubtypesFunc) nextHook() func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *QueryResolverSubtypesFunc) appendCall(r0 QueryResolverSubtypesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of QueryResolverSubtypesFuncCall objects
+// describing the invocations of this function.
+func (f *QueryResolverSubtypesFunc) History() []QueryResolverSubtypesFuncCall {
+	f.mutex.Lock()
+	history := make([]QueryResolverSubtypesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// QueryResolverSubtypesFuncCall is an object that describes an invocation
+// of method Subtypes on an instance of MockQueryResolver.
+type QueryResolverSubtypesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []resolvers.AdjustedLocation
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 string
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c QueryResolverSubtypesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c QueryResolverSubtypesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// QueryResolverSupertypesFunc describes the behavior when the Supertypes
+// method of the parent MockQueryResolver instance is invoked.
+type QueryResolverSupertypesFunc struct {
+	defaultHook func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error)
+	hooks       []func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error)
+	history     []QueryResolverSupertypesFuncCall
+	mutex       sync.Mutex
+}
+
+// Supertypes delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockQueryResolver) Supertypes(v0 context.Context, v1 int, v2 int, v3 int, v4 string) ([]resolvers.AdjustedLocation, string, error) {
+	r0, r1, r2 := m.SupertypesFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.SupertypesFunc.appendCall(QueryResolverSupertypesFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the Supertypes method of
+// the parent MockQueryResolver instance is invoked and the hook queue is
+// empty.
+func (f *QueryResolverSupertypesFunc) SetDefaultHook(hook func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Supertypes method of the parent MockQueryResolver instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *QueryResolverSupertypesFunc) PushHook(hook func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *QueryResolverSupertypesFunc) SetDefaultReturn(r0 []resolvers.AdjustedLocation, r1 string, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *QueryResolverSupertypesFunc) PushReturn(r0 []resolvers.AdjustedLocation, r1 string, r2 error) {
+	f.PushHook(func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *QueryResolverSupertypesFunc) nextHook() func(context.Context, int, int, int, string) ([]resolvers.AdjustedLocation, string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *QueryResolverSupertypesFunc) appendCall(r0 QueryResolverSupertypesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of QueryResolverSupertypesFuncCall objects
+// describing the invocations of this function.
+func (f *QueryResolverSupertypesFunc) History() []QueryResolverSupertypesFuncCall {
+	f.mutex.Lock()
+	history := make([]QueryResolverSupertypesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// QueryResolverSupertypesFuncCall is an object that describes an invocation
+// of method Supertypes on an instance of MockQueryResolver.
+type QueryResolverSupertypesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []resolvers.AdjustedLocation
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 string
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c QueryResolverSupertypesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c QueryResolverSupertypesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
@@ -51,6 +51,9 @@ type MockResolver struct {
 	// object controlling the behavior of the method
 	// GetConfigurationPolicyByID.
 	GetConfigurationPolicyByIDFunc *ResolverGetConfigurationPolicyByIDFunc
+	// GetDiagnosticTrendsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDiagnosticTrends.
+	GetDiagnosticTrendsFunc *ResolverGetDiagnosticTrendsFunc
 	// GetIndexByIDFunc is an instance of a mock function object controlling
 	// the behavior of the method GetIndexByID.
 	GetIndexByIDFunc *ResolverGetIndexByIDFunc
@@ -81,6 +84,10 @@ type MockResolver struct {
 	// object controlling the behavior of the method
 	// InferedIndexConfigurationHints.
 	InferedIndexConfigurationHintsFunc *ResolverInferedIndexConfigurationHintsFunc
+	// PreviewConfigurationPolicyFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// PreviewConfigurationPolicy.
+	PreviewConfigurationPolicyFunc *ResolverPreviewConfigurationPolicyFunc
 	// PreviewGitObjectFilterFunc is an instance of a mock function object
 	// controlling the behavior of the method PreviewGitObjectFilter.
 	PreviewGitObjectFilterFunc *ResolverPreviewGitObjectFilterFunc
@@ -166,6 +173,11 @@ func NewMockResolver() *MockResolver {
 				return
 			},
 		},
+		GetDiagnosticTrendsFunc: &ResolverGetDiagnosticTrendsFunc{
+			defaultHook: func(context.Context, dbstore.GetDiagnosticTrendsOptions) (r0 []dbstore.DiagnosticTrendPoint, r1 error) {
+				return
+			},
+		},
 		GetIndexByIDFunc: &ResolverGetIndexByIDFunc{
 			defaultHook: func(context.Context, int) (r0 dbstore.Index, r1 bool, r2 error) {
 				return
@@ -211,6 +223,11 @@ func NewMockResolver() *MockResolver {
 				return
 			},
 		},
+		PreviewConfigurationPolicyFunc: &ResolverPreviewConfigurationPolicyFunc{
+			defaultHook: func(context.Context, int, dbstore.ConfigurationPolicy, time.Time) (r0 resolvers.ConfigurationPolicyPreview, r1 error) {
+				return
+			},
+		},
 		PreviewGitObjectFilterFunc: &ResolverPreviewGitObjectFilterFunc{
 			defaultHook: func(context.Context, int, dbstore.GitObjectType, string) (r0 map[string][]string, r1 error) {
 				return
@@ -318,6 +335,11 @@ func NewStrictMockResolver() *MockResolver {
 				panic("unexpected invocation of MockResolver.GetConfigurationPolicyByID")
 			},
 		},
+		GetDiagnosticTrendsFunc: &ResolverGetDiagnosticTrendsFunc{
+			defaultHook: func(context.Context, dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error) {
+				panic("unexpected invocation of MockResolver.GetDiagnosticTrends")
+			},
+		},
 		GetIndexByIDFunc: &ResolverGetIndexByIDFunc{
 			defaultHook: func(context.Context, int) (dbstore.Index, bool, error) {
 				panic("unexpected invocation of MockResolver.GetIndexByID")
@@ -363,6 +385,11 @@ func NewStrictMockResolver() *MockResolver {
 				panic("unexpected invocation of MockResolver.InferedIndexConfigurationHints")
 			},
 		},
+		PreviewConfigurationPolicyFunc: &ResolverPreviewConfigurationPolicyFunc{
+			defaultHook: func(context.Context, int, dbstore.ConfigurationPolicy, time.Time) (resolvers.ConfigurationPolicyPreview, error) {
+				panic("unexpected invocation of MockResolver.PreviewConfigurationPolicy")
+			},
+		},
 		PreviewGitObjectFilterFunc: &ResolverPreviewGitObjectFilterFunc{
 			defaultHook: func(context.Context, int, dbstore.GitObjectType, string) (map[string][]string, error) {
 				panic("unexpected invocation of MockResolver.PreviewGitObjectFilter")
@@ -454,6 +481,9 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		GetConfigurationPolicyByIDFunc: &ResolverGetConfigurationPolicyByIDFunc{
 			defaultHook: i.GetConfigurationPolicyByID,
 		},
+		GetDiagnosticTrendsFunc: &ResolverGetDiagnosticTrendsFunc{
+			defaultHook: i.GetDiagnosticTrends,
+		},
 		GetIndexByIDFunc: &ResolverGetIndexByIDFunc{
 			defaultHook: i.GetIndexByID,
 		},
@@ -481,6 +511,9 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		InferedIndexConfigurationHintsFunc: &ResolverInferedIndexConfigurationHintsFunc{
 			defaultHook: i.InferedIndexConfigurationHints,
 		},
+		PreviewConfigurationPolicyFunc: &ResolverPreviewConfigurationPolicyFunc{
+			defaultHook: i.PreviewConfigurationPolicy,
+		},
 		PreviewGitObjectFilterFunc: &ResolverPreviewGitObjectFilterFunc{
 			defaultHook: i.PreviewGitObjectFilter,
 		},
@@ -1385,6 +1418,115 @@ func (c ResolverGetConfigurationPolicyByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// ResolverGetDiagnosticTrendsFunc describes the behavior when the
+// GetDiagnosticTrends method of the parent MockResolver instance is
+// invoked.
+type ResolverGetDiagnosticTrendsFunc struct {
+	defaultHook func(context.Context, dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error)
+	hooks       []func(context.Context, dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error)
+	history     []ResolverGetDiagnosticTrendsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDiagnosticTrends delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockResolver) GetDiagnosticTrends(v0 context.Context, v1 dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error) {
+	r0, r1 := m.GetDiagnosticTrendsFunc.nextHook()(v0, v1)
+	m.GetDiagnosticTrendsFunc.appendCall(ResolverGetDiagnosticTrendsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetDiagnosticTrends
+// method of the parent MockResolver instance is invoked and the hook queue
+// is empty.
+func (f *ResolverGetDiagnosticTrendsFunc) SetDefaultHook(hook func(context.Context, dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDiagnosticTrends method of the parent MockResolver instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *ResolverGetDiagnosticTrendsFunc) PushHook(hook func(context.Context, dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ResolverGetDiagnosticTrendsFunc) SetDefaultReturn(r0 []dbstore.DiagnosticTrendPoint, r1 error) {
+	f.SetDefaultHook(func(context.Context, dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ResolverGetDiagnosticTrendsFunc) PushReturn(r0 []dbstore.DiagnosticTrendPoint, r1 error) {
+	f.PushHook(func(context.Context, dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error) {
+		return r0, r1
+	})
+}
+
+func (f *ResolverGetDiagnosticTrendsFunc) nextHook() func(context.Context, dbstore.GetDiagnosticTrendsOptions) ([]dbstore.DiagnosticTrendPoint, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverGetDiagnosticTrendsFunc) appendCall(r0 ResolverGetDiagnosticTrendsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverGetDiagnosticTrendsFuncCall objects
+// describing the invocations of this function.
+func (f *ResolverGetDiagnosticTrendsFunc) History() []ResolverGetDiagnosticTrendsFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverGetDiagnosticTrendsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverGetDiagnosticTrendsFuncCall is an object that describes an
+// invocation of method GetDiagnosticTrends on an instance of MockResolver.
+type ResolverGetDiagnosticTrendsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 dbstore.GetDiagnosticTrendsOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []dbstore.DiagnosticTrendPoint
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverGetDiagnosticTrendsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverGetDiagnosticTrendsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ResolverGetIndexByIDFunc describes the behavior when the GetIndexByID
 // method of the parent MockResolver instance is invoked.
 type ResolverGetIndexByIDFunc struct {
@@ -2402,6 +2544,123 @@ func (c ResolverInferedIndexConfigurationHintsFuncCall) Results() []interface{}
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ResolverPreviewConfigurationPolicyFunc describes the behavior when the
+// PreviewConfigurationPolicy method of the parent MockResolver instance is
+// invoked.
+type ResolverPreviewConfigurationPolicyFunc struct {
+	defaultHook func(context.Context, int, dbstore.ConfigurationPolicy, time.Time) (resolvers.ConfigurationPolicyPreview, error)
+	hooks       []func(context.Context, int, dbstore.ConfigurationPolicy, time.Time) (resolvers.ConfigurationPolicyPreview, error)
+	history     []ResolverPreviewConfigurationPolicyFuncCall
+	mutex       sync.Mutex
+}
+
+// PreviewConfigurationPolicy delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockResolver) PreviewConfigurationPolicy(v0 context.Context, v1 int, v2 dbstore.ConfigurationPolicy, v3 time.Time) (resolvers.ConfigurationPolicyPreview, error) {
+	r0, r1 := m.PreviewConfigurationPolicyFunc.nextHook()(v0, v1, v2, v3)
+	m.PreviewConfigurationPolicyFunc.appendCall(ResolverPreviewConfigurationPolicyFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// PreviewConfigurationPolicy method of the parent MockResolver instance is
+// invoked and the hook queue is empty.
+func (f *ResolverPreviewConfigurationPolicyFunc) SetDefaultHook(hook func(context.Context, int, dbstore.ConfigurationPolicy, time.Time) (resolvers.ConfigurationPolicyPreview, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// PreviewConfigurationPolicy method of the parent MockResolver instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ResolverPreviewConfigurationPolicyFunc) PushHook(hook func(context.Context, int, dbstore.ConfigurationPolicy, time.Time) (resolvers.ConfigurationPolicyPreview, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ResolverPreviewConfigurationPolicyFunc) SetDefaultReturn(r0 resolvers.ConfigurationPolicyPreview, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, dbstore.ConfigurationPolicy, time.Time) (resolvers.ConfigurationPolicyPreview, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ResolverPreviewConfigurationPolicyFunc) PushReturn(r0 resolvers.ConfigurationPolicyPreview, r1 error) {
+	f.PushHook(func(context.Context, int, dbstore.ConfigurationPolicy, time.Time) (resolvers.ConfigurationPolicyPreview, error) {
+		return r0, r1
+	})
+}
+
+func (f *ResolverPreviewConfigurationPolicyFunc) nextHook() func(context.Context, int, dbstore.ConfigurationPolicy, time.Time) (resolvers.ConfigurationPolicyPreview, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverPreviewConfigurationPolicyFunc) appendCall(r0 ResolverPreviewConfigurationPolicyFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverPreviewConfigurationPolicyFuncCall
+// objects describing the invocations of this function.
+func (f *ResolverPreviewConfigurationPolicyFunc) History() []ResolverPreviewConfigurationPolicyFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverPreviewConfigurationPolicyFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverPreviewConfigurationPolicyFuncCall is an object that describes an
+// invocation of method PreviewConfigurationPolicy on an instance of
+// MockResolver.
+type ResolverPreviewConfigurationPolicyFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 dbstore.ConfigurationPolicy
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 resolvers.ConfigurationPolicyPreview
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverPreviewConfigurationPolicyFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverPreviewConfigurationPolicyFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ResolverPreviewGitObjectFilterFunc describes the behavior when the
 // PreviewGitObjectFilter method of the parent MockResolver instance is
 // invoked.
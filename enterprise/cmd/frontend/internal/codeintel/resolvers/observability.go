@@ -20,8 +20,13 @@ type operations struct {
 	ranges          *observation.Operation
 	references      *observation.Operation
 	implementations *observation.Operation
+	supertypes      *observation.Operation
+	subtypes        *observation.Operation
 	stencil         *observation.Operation
 
+	searchBasedDefinitions *observation.Operation
+	searchBasedReferences  *observation.Operation
+
 	findClosestDumps *observation.Operation
 }
 
@@ -58,11 +63,16 @@ func newOperations(observationContext *observation.Context) *operations {
 		diagnostics:     op("Diagnostics"),
 		hover:           op("Hover"),
 		implementations: op("Implementations"),
+		supertypes:      op("Supertypes"),
+		subtypes:        op("Subtypes"),
 		ranges:          op("Ranges"),
 		references:      op("References"),
 		stencil:         op("Stencil"),
 		queryResolver:   op("QueryResolver"),
 
+		searchBasedDefinitions: op("SearchBasedDefinitions"),
+		searchBasedReferences:  op("SearchBasedReferences"),
+
 		findClosestDumps: subOp("findClosestDumps"),
 	}
 }
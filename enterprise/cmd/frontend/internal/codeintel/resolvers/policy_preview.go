@@ -0,0 +1,262 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	policies "github.com/sourcegraph/sourcegraph/internal/codeintel/policies/enterprise"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	previewPolicyBatchSize = 100
+	previewUploadBatchSize = 100
+	previewCommitBatchSize = 50
+
+	// previewMaxUploads is the maximum number of uploads of a repository evaluated by a preview.
+	previewMaxUploads = 1000
+)
+
+// ConfigurationPolicyPreview describes how the data retention and auto-indexing behavior of a
+// single repository would change if a proposed configuration policy were saved.
+type ConfigurationPolicyPreview struct {
+	// NewlyExpiredUploads are the uploads that are protected by the current set of policies but
+	// would no longer be protected once the proposed policy is saved.
+	NewlyExpiredUploads []dbstore.Upload
+
+	// NewlyProtectedUploads are the uploads that are not protected by the current set of policies
+	// but would be protected once the proposed policy is saved.
+	NewlyProtectedUploads []dbstore.Upload
+
+	// ReclaimedStorageBytes is the total size of all newly expired uploads.
+	ReclaimedStorageBytes int64
+
+	// UploadsTruncated is true if the repository has more uploads than a preview evaluates. In
+	// this case, only the oldest uploads were evaluated.
+	UploadsTruncated bool
+
+	// NewlyIndexedCommits is a map from commits that would newly be selected for auto-indexing
+	// to the names of the branches and tags that match the proposed set of indexing policies.
+	NewlyIndexedCommits map[string][]string
+}
+
+// PreviewConfigurationPolicy evaluates the given proposed configuration policy against the uploads
+// and the commit graph of the given repository without persisting anything. If the identifier of
+// the proposed policy is non-zero, the proposed policy replaces the existing policy with the same
+// identifier; otherwise, it is evaluated as an additional policy.
+//
+// Note that the proposed policy is always evaluated as if it applies to the given repository, and
+// any repository patterns of the proposed policy are ignored.
+func (r *resolver) PreviewConfigurationPolicy(ctx context.Context, repositoryID int, policy dbstore.ConfigurationPolicy, now time.Time) (ConfigurationPolicyPreview, error) {
+	newlyExpiredUploads, newlyProtectedUploads, uploadsTruncated, err := r.previewRetention(ctx, repositoryID, policy, now)
+	if err != nil {
+		return ConfigurationPolicyPreview{}, err
+	}
+
+	newlyIndexedCommits, err := r.previewIndexing(ctx, repositoryID, policy, now)
+	if err != nil {
+		return ConfigurationPolicyPreview{}, err
+	}
+
+	var reclaimedStorageBytes int64
+	for _, upload := range newlyExpiredUploads {
+		if upload.UploadSize != nil {
+			reclaimedStorageBytes += *upload.UploadSize
+		}
+	}
+
+	return ConfigurationPolicyPreview{
+		NewlyExpiredUploads:   newlyExpiredUploads,
+		NewlyProtectedUploads: newlyProtectedUploads,
+		ReclaimedStorageBytes: reclaimedStorageBytes,
+		UploadsTruncated:      uploadsTruncated,
+		NewlyIndexedCommits:   newlyIndexedCommits,
+	}, nil
+}
+
+// previewRetention returns the set of completed and unexpired uploads of the given repository whose
+// protection status would change under the proposed policy. Protection is determined the same way as
+// in the upload expirer: an upload is protected if any commit visible to it matches a data retention
+// policy whose duration has not yet elapsed since the upload date. At most previewMaxUploads uploads
+// are evaluated, and truncated is true if the repository has more.
+func (r *resolver) previewRetention(ctx context.Context, repositoryID int, policy dbstore.ConfigurationPolicy, now time.Time) (newlyExpiredUploads, newlyProtectedUploads []dbstore.Upload, truncated bool, _ error) {
+	currentPolicies, err := r.allConfigurationPolicies(ctx, dbstore.GetConfigurationPoliciesOptions{
+		RepositoryID:     repositoryID,
+		ForDataRetention: true,
+	})
+	if err != nil {
+		return nil, nil, false, err
+	}
+	proposedPolicies := replaceConfigurationPolicy(currentPolicies, policy, policy.RetentionEnabled)
+
+	policyMatcher := policies.NewMatcher(r.gitserverClient, policies.RetentionExtractor, true, false)
+
+	currentCommitMap, err := policyMatcher.CommitsDescribedByPolicy(ctx, repositoryID, currentPolicies, now)
+	if err != nil {
+		return nil, nil, false, errors.Wrap(err, "policies.CommitsDescribedByPolicy")
+	}
+	proposedCommitMap, err := policyMatcher.CommitsDescribedByPolicy(ctx, repositoryID, proposedPolicies, now)
+	if err != nil {
+		return nil, nil, false, errors.Wrap(err, "policies.CommitsDescribedByPolicy")
+	}
+
+	for offset := 0; ; {
+		limit := previewUploadBatchSize
+		if remaining := previewMaxUploads - offset; remaining < limit {
+			limit = remaining
+		}
+
+		uploads, totalCount, err := r.dbStore.GetUploads(ctx, dbstore.GetUploadsOptions{
+			RepositoryID: repositoryID,
+			State:        "completed",
+			AllowExpired: false,
+			OldestFirst:  true,
+			Limit:        limit,
+			Offset:       offset,
+		})
+		if err != nil {
+			return nil, nil, false, errors.Wrap(err, "dbstore.GetUploads")
+		}
+		offset += len(uploads)
+
+		for _, upload := range uploads {
+			currentlyProtected, proposedProtected, err := r.previewUploadProtection(ctx, upload, currentCommitMap, proposedCommitMap, now)
+			if err != nil {
+				return nil, nil, false, err
+			}
+
+			if currentlyProtected && !proposedProtected {
+				newlyExpiredUploads = append(newlyExpiredUploads, upload)
+			} else if !currentlyProtected && proposedProtected {
+				newlyProtectedUploads = append(newlyProtectedUploads, upload)
+			}
+		}
+
+		if len(uploads) == 0 || offset >= totalCount {
+			break
+		}
+		if offset >= previewMaxUploads {
+			truncated = true
+			break
+		}
+	}
+
+	return newlyExpiredUploads, newlyProtectedUploads, truncated, nil
+}
+
+// previewUploadProtection returns whether the given upload is protected by the current and by the
+// proposed set of retention policies. The commits visible to the upload are paged through only until
+// the upload is known to be protected by both.
+func (r *resolver) previewUploadProtection(ctx context.Context, upload dbstore.Upload, currentCommitMap, proposedCommitMap map[string][]policies.PolicyMatch, now time.Time) (currentlyProtected, proposedProtected bool, _ error) {
+	var token *string
+	for first := true; first || token != nil; first = false {
+		commits, nextToken, err := r.dbStore.CommitsVisibleToUpload(ctx, upload.ID, previewCommitBatchSize, token)
+		if err != nil {
+			return false, false, errors.Wrap(err, "dbstore.CommitsVisibleToUpload")
+		}
+		token = nextToken
+
+		currentlyProtected = currentlyProtected || isUploadProtected(currentCommitMap, upload, commits, now)
+		proposedProtected = proposedProtected || isUploadProtected(proposedCommitMap, upload, commits, now)
+		if currentlyProtected && proposedProtected {
+			break
+		}
+	}
+
+	return currentlyProtected, proposedProtected, nil
+}
+
+// previewIndexing returns a map from commits of the given repository that match the proposed set of
+// indexing policies but do not match the current set of indexing policies to the names of the branches
+// and tags by which they are matched.
+func (r *resolver) previewIndexing(ctx context.Context, repositoryID int, policy dbstore.ConfigurationPolicy, now time.Time) (map[string][]string, error) {
+	currentPolicies, err := r.allConfigurationPolicies(ctx, dbstore.GetConfigurationPoliciesOptions{
+		RepositoryID: repositoryID,
+		ForIndexing:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	proposedPolicies := replaceConfigurationPolicy(currentPolicies, policy, policy.IndexingEnabled)
+
+	policyMatcher := policies.NewMatcher(r.gitserverClient, policies.IndexingExtractor, false, true)
+
+	currentCommitMap, err := policyMatcher.CommitsDescribedByPolicy(ctx, repositoryID, currentPolicies, now)
+	if err != nil {
+		return nil, errors.Wrap(err, "policies.CommitsDescribedByPolicy")
+	}
+	proposedCommitMap, err := policyMatcher.CommitsDescribedByPolicy(ctx, repositoryID, proposedPolicies, now)
+	if err != nil {
+		return nil, errors.Wrap(err, "policies.CommitsDescribedByPolicy")
+	}
+
+	namesByCommit := map[string][]string{}
+	for commit, policyMatches := range proposedCommitMap {
+		if len(policyMatches) == 0 || len(currentCommitMap[commit]) != 0 {
+			continue
+		}
+
+		names := make([]string, 0, len(policyMatches))
+		for _, policyMatch := range policyMatches {
+			names = append(names, policyMatch.Name)
+		}
+
+		namesByCommit[commit] = names
+	}
+
+	return namesByCommit, nil
+}
+
+// allConfigurationPolicies pages through the complete set of configuration policies matching the
+// given options.
+func (r *resolver) allConfigurationPolicies(ctx context.Context, opts dbstore.GetConfigurationPoliciesOptions) (policies []dbstore.ConfigurationPolicy, _ error) {
+	for {
+		opts.Limit = previewPolicyBatchSize
+		opts.Offset = len(policies)
+
+		policyBatch, totalCount, err := r.dbStore.GetConfigurationPolicies(ctx, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "dbstore.GetConfigurationPolicies")
+		}
+		policies = append(policies, policyBatch...)
+
+		if len(policyBatch) == 0 || len(policies) >= totalCount {
+			return policies, nil
+		}
+	}
+}
+
+// replaceConfigurationPolicy returns a copy of the given policies in which the policy with the same
+// identifier as the proposed policy is removed. The proposed policy is added to the result if enabled
+// is true.
+func replaceConfigurationPolicy(policies []dbstore.ConfigurationPolicy, proposed dbstore.ConfigurationPolicy, enabled bool) []dbstore.ConfigurationPolicy {
+	replaced := make([]dbstore.ConfigurationPolicy, 0, len(policies)+1)
+	for _, policy := range policies {
+		if proposed.ID != 0 && policy.ID == proposed.ID {
+			continue
+		}
+
+		replaced = append(replaced, policy)
+	}
+
+	if enabled {
+		replaced = append(replaced, proposed)
+	}
+
+	return replaced
+}
+
+// isUploadProtected returns true if any of the given commits visible to the given upload is matched
+// by a policy whose duration has not yet elapsed since the upload was uploaded.
+func isUploadProtected(commitMap map[string][]policies.PolicyMatch, upload dbstore.Upload, visibleCommits []string, now time.Time) bool {
+	for _, commit := range visibleCommits {
+		for _, policyMatch := range commitMap[commit] {
+			if policyMatch.PolicyDuration == nil || now.Sub(upload.UploadedAt) < *policyMatch.PolicyDuration {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,156 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestPreviewConfigurationPolicy(t *testing.T) {
+	now := time.Unix(1587396557, 0).UTC()
+
+	retentionPolicies := []dbstore.ConfigurationPolicy{
+		{ID: 1, Type: dbstore.GitObjectTypeTag, Pattern: "*", RetentionEnabled: true, RetentionDuration: timePtr(time.Hour * 24)},
+	}
+	indexingPolicies := []dbstore.ConfigurationPolicy{
+		{ID: 2, Type: dbstore.GitObjectTypeTree, Pattern: "main", IndexingEnabled: true},
+	}
+	uploads := []dbstore.Upload{
+		{ID: 50, Commit: "deadbeef1", UploadedAt: now.Add(-time.Hour * 2), UploadSize: int64Ptr(100)},
+		{ID: 51, Commit: "deadbeef2", UploadedAt: now.Add(-time.Minute), UploadSize: int64Ptr(200)},
+		{ID: 52, Commit: "deadbeef3", UploadedAt: now.Add(-time.Hour * 72), UploadSize: int64Ptr(400)},
+		{ID: 53, Commit: "deadbeef4", UploadedAt: now.Add(-time.Minute)},
+	}
+	visibleCommits := map[int][]string{
+		50: {"deadbeef1"},
+		51: {"deadbeef2"},
+		52: {"deadbeef3"},
+		53: {"deadbeef4", "deadbeef5"},
+	}
+	refDescriptions := map[string][]gitdomain.RefDescription{
+		"deadbeef1": {{Name: "v1.0.0", Type: gitdomain.RefTypeTag}},
+		"deadbeef2": {{Name: "v1.1.0", Type: gitdomain.RefTypeTag}},
+		"deadbeef3": {{Name: "feat/old", Type: gitdomain.RefTypeBranch}},
+		"deadbeef5": {{Name: "main", Type: gitdomain.RefTypeBranch, IsDefaultBranch: true}},
+	}
+
+	mockDBStore := NewMockDBStore()
+	mockDBStore.GetConfigurationPoliciesFunc.SetDefaultHook(func(ctx context.Context, opts dbstore.GetConfigurationPoliciesOptions) ([]dbstore.ConfigurationPolicy, int, error) {
+		if opts.ForIndexing {
+			return indexingPolicies, len(indexingPolicies), nil
+		}
+		return retentionPolicies, len(retentionPolicies), nil
+	})
+	mockDBStore.GetUploadsFunc.SetDefaultReturn(uploads, len(uploads), nil)
+	mockDBStore.CommitsVisibleToUploadFunc.SetDefaultHook(func(ctx context.Context, uploadID, limit int, token *string) ([]string, *string, error) {
+		return visibleCommits[uploadID], nil, nil
+	})
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.RefDescriptionsFunc.SetDefaultReturn(refDescriptions, nil)
+
+	resolver := NewResolver(mockDBStore, NewMockLSIFStore(), mockGitserverClient, nil, nil, nil, nil, nil, 50, &observation.TestContext, nil)
+
+	t.Run("replace policy", func(t *testing.T) {
+		// Shorten the retention duration of tags from a day to an hour and index all tags
+		preview, err := resolver.PreviewConfigurationPolicy(context.Background(), 42, dbstore.ConfigurationPolicy{
+			ID:                1,
+			Type:              dbstore.GitObjectTypeTag,
+			Pattern:           "*",
+			RetentionEnabled:  true,
+			RetentionDuration: timePtr(time.Hour),
+			IndexingEnabled:   true,
+		}, now)
+		if err != nil {
+			t.Fatalf("unexpected error previewing configuration policy: %s", err)
+		}
+
+		expectedPreview := ConfigurationPolicyPreview{
+			NewlyExpiredUploads:   []dbstore.Upload{uploads[0]},
+			ReclaimedStorageBytes: 100,
+			NewlyIndexedCommits: map[string][]string{
+				"deadbeef1": {"v1.0.0"},
+				"deadbeef2": {"v1.1.0"},
+			},
+		}
+		if diff := cmp.Diff(expectedPreview, preview); diff != "" {
+			t.Errorf("unexpected preview (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("additional policy", func(t *testing.T) {
+		// Retain all feature branches indefinitely without changing auto-indexing
+		preview, err := resolver.PreviewConfigurationPolicy(context.Background(), 42, dbstore.ConfigurationPolicy{
+			Type:             dbstore.GitObjectTypeTree,
+			Pattern:          "feat/*",
+			RetentionEnabled: true,
+		}, now)
+		if err != nil {
+			t.Fatalf("unexpected error previewing configuration policy: %s", err)
+		}
+
+		expectedPreview := ConfigurationPolicyPreview{
+			NewlyProtectedUploads: []dbstore.Upload{uploads[2]},
+			NewlyIndexedCommits:   map[string][]string{},
+		}
+		if diff := cmp.Diff(expectedPreview, preview); diff != "" {
+			t.Errorf("unexpected preview (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestPreviewConfigurationPolicyTruncated(t *testing.T) {
+	now := time.Unix(1587396557, 0).UTC()
+
+	mockDBStore := NewMockDBStore()
+	mockDBStore.GetUploadsFunc.SetDefaultHook(func(ctx context.Context, opts dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
+		uploads := make([]dbstore.Upload, 0, opts.Limit)
+		for i := 0; i < opts.Limit; i++ {
+			uploads = append(uploads, dbstore.Upload{ID: opts.Offset + i + 1, UploadedAt: now})
+		}
+		return uploads, previewMaxUploads * 2, nil
+	})
+	mockDBStore.CommitsVisibleToUploadFunc.SetDefaultHook(func(ctx context.Context, uploadID, limit int, token *string) ([]string, *string, error) {
+		if token == nil {
+			// A second page is only requested if the first didn't protect the upload
+			next := "next"
+			return []string{"deadbeef1"}, &next, nil
+		}
+		return []string{"deadbeef2"}, nil, nil
+	})
+	mockGitserverClient := NewMockGitserverClient()
+	mockGitserverClient.RefDescriptionsFunc.SetDefaultReturn(map[string][]gitdomain.RefDescription{
+		"deadbeef1": {{Name: "v1.0.0", Type: gitdomain.RefTypeTag}},
+	}, nil)
+
+	resolver := NewResolver(mockDBStore, NewMockLSIFStore(), mockGitserverClient, nil, nil, nil, nil, nil, 50, &observation.TestContext, nil)
+
+	preview, err := resolver.PreviewConfigurationPolicy(context.Background(), 42, dbstore.ConfigurationPolicy{
+		Type:             dbstore.GitObjectTypeTag,
+		Pattern:          "*",
+		RetentionEnabled: true,
+	}, now)
+	if err != nil {
+		t.Fatalf("unexpected error previewing configuration policy: %s", err)
+	}
+
+	if !preview.UploadsTruncated {
+		t.Error("expected preview to be truncated")
+	}
+	if len(preview.NewlyProtectedUploads) != previewMaxUploads {
+		t.Errorf("unexpected number of newly protected uploads. want=%d have=%d", previewMaxUploads, len(preview.NewlyProtectedUploads))
+	}
+	if calls := len(mockDBStore.CommitsVisibleToUploadFunc.History()); calls != previewMaxUploads*2 {
+		// The current policies protect no upload, so both pages are read
+		t.Errorf("unexpected number of calls to CommitsVisibleToUpload. want=%d have=%d", previewMaxUploads*2, calls)
+	}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
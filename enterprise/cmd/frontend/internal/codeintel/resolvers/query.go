@@ -43,12 +43,18 @@ type AdjustedCodeIntelligenceRange struct {
 // specifics (auth, validation, marshaling, etc.). This resolver is wrapped by a symmetrics resolver
 // in this package's graphql subpackage, which is exposed directly by the API.
 type QueryResolver interface {
+	// Precise returns true if results are derived from precise code intel indexes and false if
+	// results are derived from search heuristics.
+	Precise() bool
+
 	LSIFUploads(ctx context.Context) ([]store.Upload, error)
 	Stencil(ctx context.Context) ([]lsifstore.Range, error)
 	Ranges(ctx context.Context, startLine, endLine int) ([]AdjustedCodeIntelligenceRange, error)
 	Definitions(ctx context.Context, line, character int) ([]AdjustedLocation, error)
 	References(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error)
 	Implementations(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error)
+	Supertypes(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error)
+	Subtypes(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error)
 	Hover(ctx context.Context, line, character int) (string, lsifstore.Range, bool, error)
 	Diagnostics(ctx context.Context, limit int) ([]AdjustedDiagnostic, int, error)
 }
@@ -76,6 +82,8 @@ type queryResolver struct {
 	maximumIndexesPerMonikerSearch int
 }
 
+func (r *queryResolver) Precise() bool { return true }
+
 // NewQueryResolver create a new query resolver with the given services. The methods of this
 // struct return queries for the given repository, commit, and path, and will query only the
 // bundles associated with the given dump objects.
@@ -17,9 +17,37 @@ const slowImplementationsRequestThreshold = time.Second
 // ImplementationsLimit is maximum the number of locations returned from Implementations.
 const ImplementationsLimit = 100
 
-// Implementations returns the list of source locations that define the symbol at the given position.
+// Implementations returns the list of source locations that implement the symbol at the given position,
+// followed by the definitions of the symbols that the symbol at the given position implements.
 func (r *queryResolver) Implementations(ctx context.Context, line, character int, limit int, rawCursor string) (_ []AdjustedLocation, _ string, err error) {
-	ctx, trace, endObservation := observeResolver(ctx, &err, r.operations.implementations, slowImplementationsRequestThreshold, observation.Args{
+	return r.typeHierarchy(ctx, r.operations.implementations, line, character, limit, rawCursor, true, true)
+}
+
+// Supertypes returns the definitions of the symbols (e.g., interfaces and interface methods) that the
+// symbol at the given position implements, including symbols defined in dependencies.
+func (r *queryResolver) Supertypes(ctx context.Context, line, character int, limit int, rawCursor string) (_ []AdjustedLocation, _ string, err error) {
+	return r.typeHierarchy(ctx, r.operations.supertypes, line, character, limit, rawCursor, true, false)
+}
+
+// Subtypes returns the list of source locations that implement the symbol at the given position,
+// including implementations in dependent repositories.
+func (r *queryResolver) Subtypes(ctx context.Context, line, character int, limit int, rawCursor string) (_ []AdjustedLocation, _ string, err error) {
+	return r.typeHierarchy(ctx, r.operations.subtypes, line, character, limit, rawCursor, false, true)
+}
+
+// typeHierarchy pages through the supertypes and/or subtypes of the symbol at the given position.
+// Subtypes are gathered in the "local" and "dependents" phases, and supertypes are gathered in the
+// "dependencies" phase.
+func (r *queryResolver) typeHierarchy(
+	ctx context.Context,
+	operation *observation.Operation,
+	line, character int,
+	limit int,
+	rawCursor string,
+	includeSupertypes bool,
+	includeSubtypes bool,
+) (_ []AdjustedLocation, _ string, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, operation, slowImplementationsRequestThreshold, observation.Args{
 		LogFields: []log.Field{
 			log.Int("repositoryID", r.repositoryID),
 			log.String("commit", r.commit),
@@ -28,15 +56,22 @@ func (r *queryResolver) Implementations(ctx context.Context, line, character int
 			log.String("uploads", uploadIDsToString(r.uploads)),
 			log.Int("line", line),
 			log.Int("character", character),
+			log.Bool("includeSupertypes", includeSupertypes),
+			log.Bool("includeSubtypes", includeSubtypes),
 		},
 	})
 	defer endObservation()
 
+	initialPhase := "local"
+	if !includeSubtypes {
+		initialPhase = "dependencies"
+	}
+
 	// Decode cursor given from previous response or create a new one with default values.
 	// We use the cursor state track offsets with the result set and cache initial data that
 	// is used to resolve each page. This cursor will be modified in-place to become the
 	// cursor used to fetch the subsequent page of results in this result set.
-	cursor, err := decodeImplementationsCursor(rawCursor)
+	cursor, err := decodeImplementationsCursor(rawCursor, initialPhase)
 	if err != nil {
 		return nil, "", errors.Wrap(err, fmt.Sprintf("invalid cursor: %q", rawCursor))
 	}
@@ -54,7 +89,7 @@ func (r *queryResolver) Implementations(ctx context.Context, line, character int
 	// may already be stashed in the cursor decoded above, in which case we don't need to hit
 	// the database.
 
-	if cursor.OrderedImplementationMonikers == nil {
+	if includeSupertypes && cursor.OrderedImplementationMonikers == nil {
 		if cursor.OrderedImplementationMonikers, err = r.orderedMonikers(ctx, adjustedUploads, "implementation"); err != nil {
 			return nil, "", err
 		}
@@ -64,7 +99,7 @@ func (r *queryResolver) Implementations(ctx context.Context, line, character int
 		log.String("implementationMonikers", monikersToString(cursor.OrderedImplementationMonikers)),
 	)
 
-	if cursor.OrderedExportMonikers == nil {
+	if includeSubtypes && cursor.OrderedExportMonikers == nil {
 		if cursor.OrderedExportMonikers, err = r.orderedMonikers(ctx, adjustedUploads, "export"); err != nil {
 			return nil, "", err
 		}
@@ -87,7 +122,11 @@ func (r *queryResolver) Implementations(ctx context.Context, line, character int
 			locations = append(locations, localLocations...)
 
 			if !hasMore {
-				cursor.Phase = "dependencies"
+				if includeSupertypes {
+					cursor.Phase = "dependencies"
+				} else {
+					cursor.Phase = "dependents"
+				}
 				break
 			}
 		}
@@ -112,7 +151,11 @@ func (r *queryResolver) Implementations(ctx context.Context, line, character int
 		}
 		locations = append(locations, definitionLocations...)
 
-		cursor.Phase = "dependents"
+		if includeSubtypes {
+			cursor.Phase = "dependents"
+		} else {
+			cursor.Phase = "done"
+		}
 	}
 
 	// Phase 3: Gather all "remote" locations in dependents via moniker search.
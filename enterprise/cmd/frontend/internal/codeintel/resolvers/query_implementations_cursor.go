@@ -19,10 +19,10 @@ type implementationsCursor struct {
 }
 
 // decodeCursor is the inverse of encodeCursor. If the given encoded string is empty, then
-// a fresh cursor is returned.
-func decodeImplementationsCursor(rawEncoded string) (implementationsCursor, error) {
+// a fresh cursor starting at the given phase is returned.
+func decodeImplementationsCursor(rawEncoded, initialPhase string) (implementationsCursor, error) {
 	if rawEncoded == "" {
-		return implementationsCursor{Phase: "local"}, nil
+		return implementationsCursor{Phase: initialPhase}, nil
 	}
 
 	raw, err := base64.RawURLEncoding.DecodeString(rawEncoded)
@@ -471,3 +471,190 @@ func TestImplementationsRemoteWithSubRepoPermissions(t *testing.T) {
 		}
 	}
 }
+
+func TestSupertypes(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockPositionAdjuster := noopPositionAdjuster()
+
+	definitionUploads := []dbstore.Dump{
+		{ID: 150, Commit: "deadbeef1", Root: "sub1/"},
+		{ID: 151, Commit: "deadbeef2", Root: "sub2/"},
+	}
+	mockDBStore.DefinitionDumpsFunc.PushReturn(definitionUploads, nil)
+
+	// upload #150's commit no longer exists; all others do
+	mockGitserverClient.CommitsExistFunc.SetDefaultHook(func(ctx context.Context, rcs []gitserver.RepositoryCommit) (exists []bool, _ error) {
+		for _, rc := range rcs {
+			exists = append(exists, rc.Commit != "deadbeef1")
+		}
+		return
+	})
+
+	monikers := []precise.MonikerData{
+		{Kind: "implementation", Scheme: "tsc", Identifier: "padLeft", PackageInformationID: "51"},
+		{Kind: "export", Scheme: "tsc", Identifier: "pad_left", PackageInformationID: "52"},
+	}
+	mockLSIFStore.MonikersByPositionFunc.PushReturn([][]precise.MonikerData{{monikers[0], monikers[1]}}, nil)
+	mockLSIFStore.MonikersByPositionFunc.SetDefaultReturn([][]precise.MonikerData{{}}, nil)
+
+	packageInformation := precise.PackageInformationData{Name: "leftpad", Version: "0.1.0"}
+	mockLSIFStore.PackageInformationFunc.PushReturn(packageInformation, true, nil)
+
+	monikerLocations := []lsifstore.Location{
+		{DumpID: 151, Path: "a.go", Range: testRange1},
+		{DumpID: 151, Path: "b.go", Range: testRange2},
+	}
+	mockLSIFStore.BulkMonikerResultsFunc.PushReturn(monikerLocations, 2, nil)
+
+	uploads := []dbstore.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+	}
+	resolver := newQueryResolver(
+		database.NewMockDB(),
+		mockDBStore,
+		mockLSIFStore,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+		authz.NewMockSubRepoPermissionChecker(),
+		50,
+	)
+	adjustedLocations, cursor, err := resolver.Supertypes(context.Background(), 10, 20, 50, "")
+	if err != nil {
+		t.Fatalf("unexpected error querying supertypes: %s", err)
+	}
+	if cursor != "" {
+		t.Errorf("unexpected cursor %q", cursor)
+	}
+
+	expectedLocations := []AdjustedLocation{
+		{Dump: definitionUploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef2", AdjustedRange: testRange1},
+		{Dump: definitionUploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef2", AdjustedRange: testRange2},
+	}
+	if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+
+	if history := mockDBStore.DefinitionDumpsFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected call count for dbstore.DefinitionDump. want=%d have=%d", 1, len(history))
+	} else {
+		expectedMonikers := []precise.QualifiedMonikerData{
+			{MonikerData: monikers[0], PackageInformationData: packageInformation},
+		}
+		if diff := cmp.Diff(expectedMonikers, history[0].Arg1); diff != "" {
+			t.Errorf("unexpected monikers (-want +got):\n%s", diff)
+		}
+	}
+
+	if history := mockLSIFStore.BulkMonikerResultsFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected call count for lsifstore.BulkMonikerResults. want=%d have=%d", 1, len(history))
+	} else {
+		if history[0].Arg1 != "definitions" {
+			t.Errorf("unexpected table. want=%q have=%q", "definitions", history[0].Arg1)
+		}
+		if diff := cmp.Diff([]int{151}, history[0].Arg2); diff != "" {
+			t.Errorf("unexpected ids (-want +got):\n%s", diff)
+		}
+	}
+
+	if history := mockLSIFStore.ImplementationsFunc.History(); len(history) != 0 {
+		t.Errorf("unexpected call count for lsifstore.Implementations. want=%d have=%d", 0, len(history))
+	}
+}
+
+func TestSubtypes(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockPositionAdjuster := noopPositionAdjuster()
+
+	referenceUploads := []dbstore.Dump{
+		{ID: 250, Commit: "deadbeef1", Root: "sub1/"},
+		{ID: 251, Commit: "deadbeef2", Root: "sub2/"},
+	}
+	mockDBStore.GetDumpsByIDsFunc.PushReturn(referenceUploads, nil)
+
+	scanner := dbstore.PackageReferenceScannerFromSlice(
+		shared.PackageReference{Package: shared.Package{DumpID: 250}},
+		shared.PackageReference{Package: shared.Package{DumpID: 251}},
+	)
+	mockDBStore.ReferenceIDsFunc.PushReturn(scanner, 2, nil)
+
+	mockGitserverClient.CommitsExistFunc.SetDefaultHook(func(ctx context.Context, rcs []gitserver.RepositoryCommit) (exists []bool, _ error) {
+		for range rcs {
+			exists = append(exists, true)
+		}
+		return
+	})
+
+	monikers := []precise.MonikerData{
+		{Kind: "implementation", Scheme: "tsc", Identifier: "padLeft", PackageInformationID: "51"},
+		{Kind: "export", Scheme: "tsc", Identifier: "pad_left", PackageInformationID: "52"},
+	}
+	mockLSIFStore.MonikersByPositionFunc.PushReturn([][]precise.MonikerData{{monikers[0], monikers[1]}}, nil)
+
+	packageInformation := precise.PackageInformationData{Name: "leftpad", Version: "0.2.0"}
+	mockLSIFStore.PackageInformationFunc.PushReturn(packageInformation, true, nil)
+
+	locations := []lsifstore.Location{
+		{DumpID: 50, Path: "a.go", Range: testRange1},
+	}
+	mockLSIFStore.ImplementationsFunc.PushReturn(locations, 1, nil)
+
+	monikerLocations := []lsifstore.Location{
+		{DumpID: 251, Path: "b.go", Range: testRange2},
+	}
+	mockLSIFStore.BulkMonikerResultsFunc.PushReturn(monikerLocations, 1, nil)
+
+	uploads := []dbstore.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+	}
+	resolver := newQueryResolver(
+		database.NewMockDB(),
+		mockDBStore,
+		mockLSIFStore,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+		authz.NewMockSubRepoPermissionChecker(),
+		50,
+	)
+	adjustedLocations, _, err := resolver.Subtypes(context.Background(), 10, 20, 50, "")
+	if err != nil {
+		t.Fatalf("unexpected error querying subtypes: %s", err)
+	}
+
+	expectedLocations := []AdjustedLocation{
+		{Dump: uploads[0], Path: "sub1/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1},
+		{Dump: referenceUploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef2", AdjustedRange: testRange2},
+	}
+	if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+
+	if history := mockDBStore.DefinitionDumpsFunc.History(); len(history) != 0 {
+		t.Errorf("unexpected call count for dbstore.DefinitionDumps. want=%d have=%d", 0, len(history))
+	}
+
+	if history := mockLSIFStore.BulkMonikerResultsFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected call count for lsifstore.BulkMonikerResults. want=%d have=%d", 1, len(history))
+	} else {
+		if history[0].Arg1 != "implementations" {
+			t.Errorf("unexpected table. want=%q have=%q", "implementations", history[0].Arg1)
+		}
+		if diff := cmp.Diff([]precise.MonikerData{monikers[1]}, history[0].Arg3); diff != "" {
+			t.Errorf("unexpected monikers (-want +got):\n%s", diff)
+		}
+	}
+}
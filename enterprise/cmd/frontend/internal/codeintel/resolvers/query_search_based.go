@@ -0,0 +1,291 @@
+package resolvers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	store "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// SearchBasedReferencesLimit is the maximum number of files searched for references to a
+// symbol when answering imprecise references queries.
+const SearchBasedReferencesLimit = 500
+
+const slowSearchBasedRequestThreshold = 2 * time.Second
+
+// searchBasedQueryResolver answers code intel queries for a path in a repository that has no
+// precise index by searching for symbols and text occurrences of the identifier under the cursor.
+// Only definitions and references are supported; all other queries return empty results.
+type searchBasedQueryResolver struct {
+	searcher   SearchBasedSearcher
+	operations *operations
+	checker    authz.SubRepoPermissionChecker
+	repo       types.MinimalRepo
+	commit     string
+	path       string
+}
+
+// NewSearchBasedQueryResolver creates a new query resolver that returns imprecise results for
+// the given repository, commit, and path.
+func NewSearchBasedQueryResolver(
+	searcher SearchBasedSearcher,
+	operations *operations,
+	checker authz.SubRepoPermissionChecker,
+	repo types.MinimalRepo,
+	commit string,
+	path string,
+) QueryResolver {
+	return &searchBasedQueryResolver{
+		searcher:   searcher,
+		operations: operations,
+		checker:    checker,
+		repo:       repo,
+		commit:     commit,
+		path:       path,
+	}
+}
+
+var _ QueryResolver = &searchBasedQueryResolver{}
+
+func (r *searchBasedQueryResolver) Precise() bool { return false }
+
+func (r *searchBasedQueryResolver) LSIFUploads(ctx context.Context) ([]store.Upload, error) {
+	return nil, nil
+}
+
+func (r *searchBasedQueryResolver) Stencil(ctx context.Context) ([]lsifstore.Range, error) {
+	return nil, nil
+}
+
+func (r *searchBasedQueryResolver) Ranges(ctx context.Context, startLine, endLine int) ([]AdjustedCodeIntelligenceRange, error) {
+	return nil, nil
+}
+
+// Definitions returns the ranked set of symbols whose name matches the identifier at the given position.
+func (r *searchBasedQueryResolver) Definitions(ctx context.Context, line, character int) (_ []AdjustedLocation, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, r.operations.searchBasedDefinitions, slowSearchBasedRequestThreshold, r.observationArgs(line, character))
+	defer endObservation()
+
+	content, identifier, err := r.identifierAt(ctx, line, character)
+	if err != nil || identifier == "" {
+		return nil, err
+	}
+	trace.Log(log.String("identifier", identifier))
+
+	candidates, err := r.searcher.SymbolDefinitions(ctx, r.repo, api.CommitID(r.commit), identifier, DefinitionsLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, "searcher.SymbolDefinitions")
+	}
+	if candidates, err = r.filterCandidates(ctx, candidates); err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numCandidates", len(candidates)))
+
+	return r.adjustCandidates(newSearchBasedRanker(r.path, content).rank(candidates)), nil
+}
+
+// References returns the ranked set of text occurrences of the identifier at the given position.
+func (r *searchBasedQueryResolver) References(ctx context.Context, line, character, limit int, rawCursor string) (_ []AdjustedLocation, _ string, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, r.operations.searchBasedReferences, slowSearchBasedRequestThreshold, r.observationArgs(line, character))
+	defer endObservation()
+
+	cursor, err := decodeSearchBasedCursor(rawCursor)
+	if err != nil {
+		return nil, "", errors.Wrap(err, fmt.Sprintf("invalid cursor: %q", rawCursor))
+	}
+	if cursor.Offset < 0 {
+		return nil, "", errors.Newf("invalid cursor: %q", rawCursor)
+	}
+
+	content, identifier, err := r.identifierAt(ctx, line, character)
+	if err != nil || identifier == "" {
+		return nil, "", err
+	}
+	trace.Log(log.String("identifier", identifier))
+
+	// The search is re-run for each page. The ranking is deterministic for a given set of
+	// candidates, so the offset into the ranked list is stable across requests.
+	candidates, err := r.searcher.TextOccurrences(ctx, r.repo, api.CommitID(r.commit), identifier, SearchBasedReferencesLimit)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "searcher.TextOccurrences")
+	}
+	if candidates, err = r.filterCandidates(ctx, candidates); err != nil {
+		return nil, "", err
+	}
+	candidates = newSearchBasedRanker(r.path, content).rank(candidates)
+	trace.Log(log.Int("numCandidates", len(candidates)))
+
+	if cursor.Offset >= len(candidates) {
+		return nil, "", nil
+	}
+
+	page := candidates[cursor.Offset:]
+	if len(page) > limit {
+		page = page[:limit]
+	}
+
+	nextCursor := ""
+	if next := cursor.Offset + len(page); next < len(candidates) {
+		nextCursor = encodeSearchBasedCursor(searchBasedCursor{Offset: next})
+	}
+
+	return r.adjustCandidates(page), nextCursor, nil
+}
+
+func (r *searchBasedQueryResolver) Implementations(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error) {
+	return nil, "", nil
+}
+
+func (r *searchBasedQueryResolver) Supertypes(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error) {
+	return nil, "", nil
+}
+
+func (r *searchBasedQueryResolver) Subtypes(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error) {
+	return nil, "", nil
+}
+
+func (r *searchBasedQueryResolver) Hover(ctx context.Context, line, character int) (string, lsifstore.Range, bool, error) {
+	return "", lsifstore.Range{}, false, nil
+}
+
+func (r *searchBasedQueryResolver) Diagnostics(ctx context.Context, limit int) ([]AdjustedDiagnostic, int, error) {
+	return nil, 0, nil
+}
+
+func (r *searchBasedQueryResolver) observationArgs(line, character int) observation.Args {
+	return observation.Args{
+		LogFields: []log.Field{
+			log.Int("repositoryID", int(r.repo.ID)),
+			log.String("commit", r.commit),
+			log.String("path", r.path),
+			log.Int("line", line),
+			log.Int("character", character),
+		},
+	}
+}
+
+// identifierAt returns the content of the target file and the identifier that encloses the
+// given position. An empty identifier is returned if there is no identifier at that position.
+func (r *searchBasedQueryResolver) identifierAt(ctx context.Context, line, character int) ([]byte, string, error) {
+	content, err := r.searcher.ReadFile(ctx, r.repo, api.CommitID(r.commit), r.path)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "searcher.ReadFile")
+	}
+
+	lines := strings.Split(string(content), "\n")
+	if line < 0 || line >= len(lines) {
+		return content, "", nil
+	}
+
+	return content, identifierAtCharacter([]rune(lines[line]), character), nil
+}
+
+// identifierAtCharacter returns the identifier in the given line that contains the given character
+// offset. A cursor placed directly after the last character of an identifier also selects it.
+func identifierAtCharacter(line []rune, character int) string {
+	if character < 0 || character > len(line) {
+		return ""
+	}
+	if character == len(line) || !isIdentifierRune(line[character]) {
+		if character == 0 || !isIdentifierRune(line[character-1]) {
+			return ""
+		}
+		character--
+	}
+
+	start, end := character, character+1
+	for start > 0 && isIdentifierRune(line[start-1]) {
+		start--
+	}
+	for end < len(line) && isIdentifierRune(line[end]) {
+		end++
+	}
+
+	if unicode.IsDigit(line[start]) {
+		// Numeric literals are not identifiers
+		return ""
+	}
+
+	return string(line[start:end])
+}
+
+func isIdentifierRune(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// filterCandidates removes the candidates in paths the current actor cannot read under the
+// sub-repo permissions of the target repository. Candidates are filtered before ranking so
+// that the offsets encoded in reference cursors remain stable.
+func (r *searchBasedQueryResolver) filterCandidates(ctx context.Context, candidates []SearchBasedCandidate) ([]SearchBasedCandidate, error) {
+	if !authz.SubRepoEnabled(r.checker) {
+		return candidates, nil
+	}
+
+	a := actor.FromContext(ctx)
+	filtered := candidates[:0]
+	for _, candidate := range candidates {
+		if include, err := authz.FilterActorPath(ctx, r.checker, a, r.repo.Name, candidate.Path); err != nil {
+			return nil, err
+		} else if include {
+			filtered = append(filtered, candidate)
+		}
+	}
+
+	return filtered, nil
+}
+
+// adjustCandidates converts search candidates into locations. Search results are computed at
+// the requested commit, so no position adjustment is necessary.
+func (r *searchBasedQueryResolver) adjustCandidates(candidates []SearchBasedCandidate) []AdjustedLocation {
+	locations := make([]AdjustedLocation, 0, len(candidates))
+	for _, candidate := range candidates {
+		locations = append(locations, AdjustedLocation{
+			Dump:           store.Dump{RepositoryID: int(r.repo.ID), RepositoryName: string(r.repo.Name), Commit: r.commit},
+			Path:           candidate.Path,
+			AdjustedCommit: r.commit,
+			AdjustedRange:  candidate.Range,
+		})
+	}
+
+	return locations
+}
+
+// searchBasedCursor is the offset into the ranked list of text occurrences of the previous
+// page of imprecise references.
+type searchBasedCursor struct {
+	Offset int `json:"offset"`
+}
+
+func decodeSearchBasedCursor(rawEncoded string) (searchBasedCursor, error) {
+	if rawEncoded == "" {
+		return searchBasedCursor{}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(rawEncoded)
+	if err != nil {
+		return searchBasedCursor{}, err
+	}
+
+	var cursor searchBasedCursor
+	err = json.Unmarshal(raw, &cursor)
+	return cursor, err
+}
+
+func encodeSearchBasedCursor(cursor searchBasedCursor) string {
+	rawEncoded, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(rawEncoded)
+}
@@ -0,0 +1,204 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+const testSearchBasedContent = `package server
+
+func handle() {
+	result := computeResult(42)
+}
+`
+
+func TestSearchBasedDefinitions(t *testing.T) {
+	mockSearcher := NewMockSearchBasedSearcher()
+	mockSearcher.ReadFileFunc.SetDefaultReturn([]byte(testSearchBasedContent), nil)
+	mockSearcher.SymbolDefinitionsFunc.SetDefaultReturn([]SearchBasedCandidate{
+		testCandidate("cmd/other/compute.go", 5),
+		testCandidate("internal/server/compute.go", 12),
+	}, nil)
+
+	resolver := testSearchBasedQueryResolver(mockSearcher, authz.NewMockSubRepoPermissionChecker())
+	locations, err := resolver.Definitions(context.Background(), 3, 15)
+	if err != nil {
+		t.Fatalf("unexpected error querying definitions: %s", err)
+	}
+
+	if calls := mockSearcher.SymbolDefinitionsFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of SymbolDefinitions calls. want=%d have=%d", 1, len(calls))
+	} else if calls[0].Arg3 != "computeResult" {
+		t.Errorf("unexpected identifier. want=%q have=%q", "computeResult", calls[0].Arg3)
+	}
+
+	expected := []AdjustedLocation{
+		testSearchBasedLocation("internal/server/compute.go", 12),
+		testSearchBasedLocation("cmd/other/compute.go", 5),
+	}
+	if diff := cmp.Diff(expected, locations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+}
+
+func TestSearchBasedDefinitionsNoIdentifier(t *testing.T) {
+	mockSearcher := NewMockSearchBasedSearcher()
+	mockSearcher.ReadFileFunc.SetDefaultReturn([]byte(testSearchBasedContent), nil)
+
+	resolver := testSearchBasedQueryResolver(mockSearcher, authz.NewMockSubRepoPermissionChecker())
+	locations, err := resolver.Definitions(context.Background(), 3, 28)
+	if err != nil {
+		t.Fatalf("unexpected error querying definitions: %s", err)
+	}
+	if len(locations) != 0 {
+		t.Errorf("unexpected locations. want=%d have=%d", 0, len(locations))
+	}
+	if calls := mockSearcher.SymbolDefinitionsFunc.History(); len(calls) != 0 {
+		t.Errorf("unexpected number of SymbolDefinitions calls. want=%d have=%d", 0, len(calls))
+	}
+}
+
+func TestSearchBasedReferences(t *testing.T) {
+	mockSearcher := NewMockSearchBasedSearcher()
+	mockSearcher.ReadFileFunc.SetDefaultReturn([]byte(testSearchBasedContent), nil)
+	mockSearcher.TextOccurrencesFunc.SetDefaultReturn([]SearchBasedCandidate{
+		testCandidate("cmd/other/main.go", 7),
+		testCandidate("internal/server/compute.go", 12),
+		testCandidate("internal/server/server.go", 3),
+	}, nil)
+
+	resolver := testSearchBasedQueryResolver(mockSearcher, authz.NewMockSubRepoPermissionChecker())
+
+	locations, cursor, err := resolver.References(context.Background(), 3, 15, 2, "")
+	if err != nil {
+		t.Fatalf("unexpected error querying references: %s", err)
+	}
+	expected := []AdjustedLocation{
+		testSearchBasedLocation("internal/server/server.go", 3),
+		testSearchBasedLocation("internal/server/compute.go", 12),
+	}
+	if diff := cmp.Diff(expected, locations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+	if cursor == "" {
+		t.Fatalf("expected a next page cursor")
+	}
+
+	locations, cursor, err = resolver.References(context.Background(), 3, 15, 2, cursor)
+	if err != nil {
+		t.Fatalf("unexpected error querying references: %s", err)
+	}
+	expected = []AdjustedLocation{
+		testSearchBasedLocation("cmd/other/main.go", 7),
+	}
+	if diff := cmp.Diff(expected, locations); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+	if cursor != "" {
+		t.Errorf("unexpected next page cursor. want=%q have=%q", "", cursor)
+	}
+}
+
+func TestSearchBasedReferencesWithSubRepoPermissions(t *testing.T) {
+	mockSearcher := NewMockSearchBasedSearcher()
+	mockSearcher.ReadFileFunc.SetDefaultReturn([]byte(testSearchBasedContent), nil)
+	mockSearcher.SymbolDefinitionsFunc.SetDefaultReturn([]SearchBasedCandidate{
+		testCandidate("internal/secret/compute.go", 12),
+		testCandidate("internal/server/compute.go", 4),
+	}, nil)
+	mockSearcher.TextOccurrencesFunc.SetDefaultReturn([]SearchBasedCandidate{
+		testCandidate("internal/secret/compute.go", 12),
+		testCandidate("internal/server/compute.go", 4),
+		testCandidate("internal/server/server.go", 3),
+	}, nil)
+
+	// Applying sub-repo permissions
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultReturn(true)
+	checker.PermissionsFunc.SetDefaultHook(func(ctx context.Context, i int32, content authz.RepoContent) (authz.Perms, error) {
+		if content.Path == "internal/secret/compute.go" {
+			return authz.None, nil
+		}
+		return authz.Read, nil
+	})
+
+	resolver := testSearchBasedQueryResolver(mockSearcher, checker)
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	definitions, err := resolver.Definitions(ctx, 3, 15)
+	if err != nil {
+		t.Fatalf("unexpected error querying definitions: %s", err)
+	}
+	expected := []AdjustedLocation{
+		testSearchBasedLocation("internal/server/compute.go", 4),
+	}
+	if diff := cmp.Diff(expected, definitions); diff != "" {
+		t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+	}
+
+	references, cursor, err := resolver.References(ctx, 3, 15, 2, "")
+	if err != nil {
+		t.Fatalf("unexpected error querying references: %s", err)
+	}
+	expected = []AdjustedLocation{
+		testSearchBasedLocation("internal/server/server.go", 3),
+		testSearchBasedLocation("internal/server/compute.go", 4),
+	}
+	if diff := cmp.Diff(expected, references); diff != "" {
+		t.Errorf("unexpected references (-want +got):\n%s", diff)
+	}
+	if cursor != "" {
+		t.Errorf("unexpected next page cursor. want=%q have=%q", "", cursor)
+	}
+}
+
+func TestIdentifierAtCharacter(t *testing.T) {
+	testCases := []struct {
+		line      string
+		character int
+		expected  string
+	}{
+		{"\tresult := computeResult(42)", 1, "result"},
+		{"\tresult := computeResult(42)", 7, "result"}, // directly after identifier
+		{"\tresult := computeResult(42)", 8, ""},
+		{"\tresult := computeResult(42)", 20, "computeResult"},
+		{"\tresult := computeResult(42)", 26, ""}, // numeric literal
+		{"\tresult := computeResult(42)", 40, ""},
+		{"const $élan = 1", 8, "$élan"},
+	}
+
+	for _, testCase := range testCases {
+		if identifier := identifierAtCharacter([]rune(testCase.line), testCase.character); identifier != testCase.expected {
+			t.Errorf("unexpected identifier at %q:%d. want=%q have=%q", testCase.line, testCase.character, testCase.expected, identifier)
+		}
+	}
+}
+
+func testSearchBasedQueryResolver(searcher SearchBasedSearcher, checker authz.SubRepoPermissionChecker) QueryResolver {
+	return NewSearchBasedQueryResolver(
+		searcher,
+		newOperations(&observation.TestContext),
+		checker,
+		types.MinimalRepo{ID: 42, Name: api.RepoName("github.com/test/test")},
+		"deadbeef",
+		"internal/server/server.go",
+	)
+}
+
+func testSearchBasedLocation(path string, line int) AdjustedLocation {
+	return AdjustedLocation{
+		Dump:           dbstore.Dump{RepositoryID: 42, RepositoryName: "github.com/test/test", Commit: "deadbeef"},
+		Path:           path,
+		AdjustedCommit: "deadbeef",
+		AdjustedRange:  testCandidate(path, line).Range,
+	}
+}
@@ -19,6 +19,7 @@ import (
 	executor "github.com/sourcegraph/sourcegraph/internal/services/executors/transport/graphql"
 	"github.com/sourcegraph/sourcegraph/internal/symbols"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 )
 
@@ -52,6 +53,7 @@ type Resolver interface {
 	QueueAutoIndexJobsForRepo(ctx context.Context, repositoryID int, rev, configuration string) ([]store.Index, error)
 	PreviewRepositoryFilter(ctx context.Context, patterns []string, limit, offset int) (_ []int, totalCount int, repositoryMatchLimit *int, _ error)
 	PreviewGitObjectFilter(ctx context.Context, repositoryID int, gitObjectType store.GitObjectType, pattern string) (map[string][]string, error)
+	PreviewConfigurationPolicy(ctx context.Context, repositoryID int, policy store.ConfigurationPolicy, now time.Time) (ConfigurationPolicyPreview, error)
 	SupportedByCtags(ctx context.Context, filepath string, repo api.RepoName) (bool, string, error)
 	RetentionPolicyOverview(ctx context.Context, upload store.Upload, matchesOnly bool, first int, after int64, query string, now time.Time) (matches []RetentionPolicyMatchCandidate, totalCount int, err error)
 
@@ -63,6 +65,8 @@ type Resolver interface {
 	RequestLanguageSupport(ctx context.Context, userID int, language string) error
 	RequestedLanguageSupport(ctx context.Context, userID int) ([]string, error)
 
+	GetDiagnosticTrends(ctx context.Context, opts store.GetDiagnosticTrendsOptions) ([]store.DiagnosticTrendPoint, error)
+
 	ExecutorResolver() executor.Resolver
 }
 
@@ -84,6 +88,7 @@ type resolver struct {
 	operations       *operations
 	executorResolver executor.Resolver
 	symbolsClient    *symbols.Client
+	searchBased      SearchBasedSearcher
 
 	// See the same field on the QueryResolver struct
 	maximumIndexesPerMonikerSearch int
@@ -98,11 +103,12 @@ func NewResolver(
 	indexEnqueuer IndexEnqueuer,
 	hunkCache HunkCache,
 	symbolsClient *symbols.Client,
+	searchBasedSearcher SearchBasedSearcher,
 	maximumIndexesPerMonikerSearch int,
 	observationContext *observation.Context,
 	dbConn database.DB,
 ) Resolver {
-	return newResolver(dbStore, lsifStore, gitserverClient, policyMatcher, indexEnqueuer, hunkCache, symbolsClient, maximumIndexesPerMonikerSearch, observationContext, dbConn)
+	return newResolver(dbStore, lsifStore, gitserverClient, policyMatcher, indexEnqueuer, hunkCache, symbolsClient, searchBasedSearcher, maximumIndexesPerMonikerSearch, observationContext, dbConn)
 }
 
 func newResolver(
@@ -113,6 +119,7 @@ func newResolver(
 	indexEnqueuer IndexEnqueuer,
 	hunkCache HunkCache,
 	symbolsClient *symbols.Client,
+	searchBasedSearcher SearchBasedSearcher,
 	maximumIndexesPerMonikerSearch int,
 	observationContext *observation.Context,
 	dbConn database.DB,
@@ -126,6 +133,7 @@ func newResolver(
 		indexEnqueuer:                  indexEnqueuer,
 		hunkCache:                      hunkCache,
 		symbolsClient:                  symbolsClient,
+		searchBased:                    searchBasedSearcher,
 		maximumIndexesPerMonikerSearch: maximumIndexesPerMonikerSearch,
 		operations:                     newOperations(observationContext),
 		executorResolver:               executor.New(dbConn),
@@ -191,7 +199,9 @@ const slowQueryResolverRequestThreshold = time.Second
 
 // QueryResolver determines the set of dumps that can answer code intel queries for the
 // given repository, commit, and path, then constructs a new query resolver instance which
-// can be used to answer subsequent queries.
+// can be used to answer subsequent queries. If no dump can answer queries for the path and
+// a search-based fallback is requested, the returned query resolver answers queries with
+// imprecise results.
 func (r *resolver) QueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataArgs) (_ QueryResolver, err error) {
 	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.queryResolver, slowQueryResolverRequestThreshold, observation.Args{
 		LogFields: []log.Field{
@@ -200,6 +210,7 @@ func (r *resolver) QueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataA
 			log.String("path", args.Path),
 			log.Bool("exactPath", args.ExactPath),
 			log.String("indexer", args.ToolName),
+			log.Bool("searchBasedFallback", args.SearchBasedFallback),
 		},
 	})
 	defer endObservation()
@@ -216,9 +227,23 @@ func (r *resolver) QueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataA
 		args.ExactPath,
 		args.ToolName,
 	)
-	if err != nil || len(dumps) == 0 {
+	if err != nil {
 		return nil, err
 	}
+	if len(dumps) == 0 {
+		if !args.SearchBasedFallback || !args.ExactPath || r.searchBased == nil {
+			return nil, nil
+		}
+
+		return NewSearchBasedQueryResolver(
+			r.searchBased,
+			r.operations,
+			authz.DefaultSubRepoPermsChecker,
+			types.MinimalRepo{ID: args.Repo.ID, Name: args.Repo.Name, Stars: args.Repo.Stars},
+			string(args.Commit),
+			args.Path,
+		), nil
+	}
 
 	return NewQueryResolver(
 		r.db,
@@ -15,7 +15,7 @@ func TestQueryResolver(t *testing.T) {
 	mockLSIFStore := NewMockLSIFStore()
 	mockGitserverClient := NewMockGitserverClient()
 
-	resolver := NewResolver(mockDBStore, mockLSIFStore, mockGitserverClient, nil, nil, nil, nil, 50, &observation.TestContext, nil)
+	resolver := NewResolver(mockDBStore, mockLSIFStore, mockGitserverClient, nil, nil, nil, nil, nil, 50, &observation.TestContext, nil)
 	queryResolver, err := resolver.QueryResolver(context.Background(), &gql.GitBlobLSIFDataArgs{
 		Repo:      &types.Repo{ID: 50},
 		Commit:    api.CommitID("deadbeef"),
@@ -31,3 +31,29 @@ func TestQueryResolver(t *testing.T) {
 		t.Errorf("expected nil-valued resolver")
 	}
 }
+
+func TestQueryResolverSearchBasedFallback(t *testing.T) {
+	mockDBStore := NewMockDBStore() // returns no dumps
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockSearcher := NewMockSearchBasedSearcher()
+
+	resolver := NewResolver(mockDBStore, mockLSIFStore, mockGitserverClient, nil, nil, nil, nil, mockSearcher, 50, &observation.TestContext, nil)
+	queryResolver, err := resolver.QueryResolver(context.Background(), &gql.GitBlobLSIFDataArgs{
+		Repo:                &types.Repo{ID: 50},
+		Commit:              api.CommitID("deadbeef"),
+		Path:                "/foo/bar.go",
+		ExactPath:           true,
+		SearchBasedFallback: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if queryResolver == nil {
+		t.Fatalf("expected search-based resolver")
+	}
+	if queryResolver.Precise() {
+		t.Errorf("expected imprecise resolver")
+	}
+}
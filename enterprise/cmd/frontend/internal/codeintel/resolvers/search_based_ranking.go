@@ -0,0 +1,271 @@
+package resolvers
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/grafana/regexp"
+)
+
+// searchBasedRanker orders imprecise definition and reference candidates by their likely
+// relevance to the identifier in a particular source file.
+//
+// Candidates are scored, in decreasing order of importance, by whether they occur in the source
+// file, in the same directory (the same package for most languages), or in a file that the source
+// file imports. Ties are broken by the length of the directory prefix shared with the source file.
+// Test files are deprioritized unless the source file is itself a test file, and candidates in a
+// file of a different language than the source file are discarded.
+type searchBasedRanker struct {
+	path     string
+	dir      string
+	language string
+	isTest   bool
+	imports  []string
+	imported func(importPath, candidatePath string) bool
+}
+
+const (
+	searchBasedSameFileScore     = 1000
+	searchBasedSameDirScore      = 500
+	searchBasedImportedScore     = 250
+	searchBasedTestFilePenalty   = 100
+	searchBasedMaxSharedDirScore = 50
+)
+
+func newSearchBasedRanker(sourcePath string, content []byte) *searchBasedRanker {
+	language := searchBasedLanguage(sourcePath)
+
+	ranker := &searchBasedRanker{
+		path:     sourcePath,
+		dir:      path.Dir(sourcePath),
+		language: language,
+		isTest:   isTestFile(sourcePath),
+	}
+
+	if rules, ok := importRules[language]; ok {
+		ranker.imports = rules.extract(ranker.dir, content)
+		ranker.imported = rules.matches
+	}
+
+	return ranker
+}
+
+// rank filters and sorts the given candidates in-place and returns the resulting slice.
+func (r *searchBasedRanker) rank(candidates []SearchBasedCandidate) []SearchBasedCandidate {
+	filtered := candidates[:0]
+	for _, candidate := range candidates {
+		if r.language != "" {
+			if language := searchBasedLanguage(candidate.Path); language != "" && language != r.language {
+				continue
+			}
+		}
+
+		filtered = append(filtered, candidate)
+	}
+
+	scores := make(map[string]int, len(filtered))
+	for _, candidate := range filtered {
+		if _, ok := scores[candidate.Path]; !ok {
+			scores[candidate.Path] = r.score(candidate.Path)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		if si, sj := scores[filtered[i].Path], scores[filtered[j].Path]; si != sj {
+			return si > sj
+		}
+		if filtered[i].Path != filtered[j].Path {
+			return filtered[i].Path < filtered[j].Path
+		}
+		if filtered[i].Range.Start.Line != filtered[j].Range.Start.Line {
+			return filtered[i].Range.Start.Line < filtered[j].Range.Start.Line
+		}
+
+		return filtered[i].Range.Start.Character < filtered[j].Range.Start.Character
+	})
+
+	return filtered
+}
+
+// score returns the relevance of the given candidate path to the source file.
+func (r *searchBasedRanker) score(candidatePath string) int {
+	score := 0
+
+	if candidatePath == r.path {
+		score += searchBasedSameFileScore
+	}
+	if path.Dir(candidatePath) == r.dir {
+		score += searchBasedSameDirScore
+	} else if r.isImported(candidatePath) {
+		score += searchBasedImportedScore
+	}
+	if !r.isTest && isTestFile(candidatePath) {
+		score -= searchBasedTestFilePenalty
+	}
+
+	sharedDirs := sharedDirectoryDepth(r.dir, path.Dir(candidatePath))
+	if sharedDirs > searchBasedMaxSharedDirScore {
+		sharedDirs = searchBasedMaxSharedDirScore
+	}
+
+	return score + sharedDirs
+}
+
+func (r *searchBasedRanker) isImported(candidatePath string) bool {
+	for _, importPath := range r.imports {
+		if r.imported(importPath, candidatePath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// searchBasedLanguage returns the language family of the given path, or an empty string if the
+// language cannot be determined from the file's extension. Languages that can import each other
+// directly (e.g., TypeScript and JavaScript, or C and C++ through shared headers) belong to the
+// same family.
+func searchBasedLanguage(filepath string) string {
+	language, _ := enry.GetLanguageByExtension(filepath)
+
+	switch language {
+	case "JavaScript", "JSX", "TSX":
+		return "TypeScript"
+	case "C++", "Objective-C", "Objective-C++":
+		return "C"
+	}
+
+	return language
+}
+
+var testFilePattern = regexp.MustCompile(`(^|/)(test|tests|__tests__|testdata)/|(_test\.go|\.test\.[jt]sx?|\.spec\.[jt]sx?|_test\.py)$|(^|/)test_[^/]+\.py$`)
+
+// isTestFile returns true if the given path looks like it belongs to a test.
+func isTestFile(filepath string) bool {
+	return testFilePattern.MatchString(filepath)
+}
+
+// sharedDirectoryDepth returns the number of leading directory components shared by both paths.
+func sharedDirectoryDepth(a, b string) int {
+	if a == "." || b == "." {
+		return 0
+	}
+
+	as := strings.Split(a, "/")
+	bs := strings.Split(b, "/")
+
+	n := 0
+	for n < len(as) && n < len(bs) && as[n] == bs[n] {
+		n++
+	}
+
+	return n
+}
+
+// importRule describes how to determine which files are imported by a source file of a
+// particular language.
+type importRule struct {
+	// extract returns the import paths of the given file content, normalized as well as
+	// possible to repository-relative paths.
+	extract func(dir string, content []byte) []string
+
+	// matches returns true if the given candidate path belongs to the given import path.
+	matches func(importPath, candidatePath string) bool
+}
+
+var importRules = map[string]importRule{
+	"Go":         {extract: extractGoImports, matches: matchesGoImport},
+	"TypeScript": {extract: extractTypeScriptImports, matches: matchesModuleImport},
+	"Python":     {extract: extractPythonImports, matches: matchesModuleImport},
+}
+
+var (
+	goImportBlockPattern  = regexp.MustCompile(`(?s)\bimport\s*\((.*?)\)`)
+	goImportSinglePattern = regexp.MustCompile(`\bimport\s+(?:[\w.]+\s+)?"([^"]+)"`)
+	goImportSpecPattern   = regexp.MustCompile(`"([^"]+)"`)
+)
+
+func extractGoImports(dir string, content []byte) []string {
+	var imports []string
+	for _, match := range goImportBlockPattern.FindAllSubmatch(content, -1) {
+		for _, spec := range goImportSpecPattern.FindAllSubmatch(match[1], -1) {
+			imports = append(imports, string(spec[1]))
+		}
+	}
+	for _, match := range goImportSinglePattern.FindAllSubmatch(content, -1) {
+		imports = append(imports, string(match[1]))
+	}
+
+	return imports
+}
+
+// matchesGoImport returns true if the candidate is in the package with the given import path. The
+// module path of the repository is unknown, so the import path only needs to end with the directory
+// of the candidate.
+func matchesGoImport(importPath, candidatePath string) bool {
+	dir := path.Dir(candidatePath)
+	if dir == "." {
+		return false
+	}
+
+	return importPath == dir || strings.HasSuffix(importPath, "/"+dir)
+}
+
+var typeScriptImportPattern = regexp.MustCompile(`(?:\bfrom\s*|\bimport\s*\(?\s*|\brequire\s*\(\s*)['"]([^'"]+)['"]`)
+
+func extractTypeScriptImports(dir string, content []byte) []string {
+	var imports []string
+	for _, match := range typeScriptImportPattern.FindAllSubmatch(content, -1) {
+		// Only relative imports can be resolved to a path within the repository
+		if specifier := string(match[1]); strings.HasPrefix(specifier, ".") {
+			imports = append(imports, path.Join(dir, specifier))
+		}
+	}
+
+	return imports
+}
+
+var (
+	pythonFromImportPattern = regexp.MustCompile(`(?m)^\s*from\s+(\.*)([\w.]*)\s+import\b`)
+	pythonImportPattern     = regexp.MustCompile(`(?m)^\s*import\s+([\w.]+(?:\s*,\s*[\w.]+)*)`)
+)
+
+func extractPythonImports(dir string, content []byte) []string {
+	var imports []string
+	for _, match := range pythonFromImportPattern.FindAllSubmatch(content, -1) {
+		module := strings.ReplaceAll(string(match[2]), ".", "/")
+
+		if dots := len(match[1]); dots > 0 {
+			// Each leading dot past the first refers to a parent package
+			base := dir
+			for i := 1; i < dots; i++ {
+				base = path.Dir(base)
+			}
+
+			imports = append(imports, path.Join(base, module))
+		} else if module != "" {
+			imports = append(imports, module)
+		}
+	}
+	for _, match := range pythonImportPattern.FindAllSubmatch(content, -1) {
+		for _, module := range strings.Split(string(match[1]), ",") {
+			imports = append(imports, strings.ReplaceAll(strings.TrimSpace(module), ".", "/"))
+		}
+	}
+
+	return imports
+}
+
+// matchesModuleImport returns true if the candidate is the module with the given import path, or
+// the index of the package with the given import path. Import paths that could not be resolved
+// relative to the source file only need to match a suffix of the candidate path.
+func matchesModuleImport(importPath, candidatePath string) bool {
+	module := strings.TrimSuffix(candidatePath, path.Ext(candidatePath))
+	for _, index := range []string{"/index", "/__init__"} {
+		module = strings.TrimSuffix(module, index)
+	}
+
+	return module == importPath || strings.HasSuffix(module, "/"+importPath)
+}
@@ -0,0 +1,144 @@
+package resolvers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
+)
+
+func TestSearchBasedRankerGo(t *testing.T) {
+	content := []byte(`package server
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	store "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+)
+
+func handle(ctx context.Context, id api.RepoID) {}
+`)
+
+	candidates := []SearchBasedCandidate{
+		testCandidate("cmd/other/util.go", 1),
+		testCandidate("internal/codeintel/stores/dbstore/dumps.go", 1),
+		testCandidate("internal/server/handler_test.go", 1),
+		testCandidate("internal/server/handler.go", 3),
+		testCandidate("internal/server/server.go", 10),
+		testCandidate("internal/server/handler.go", 1),
+		testCandidate("client/web/src/handler.ts", 1),
+		testCandidate("internal/search/api.go", 1),
+		testCandidate("README.md", 1),
+		testCandidate("Makefile", 1),
+	}
+
+	ranked := newSearchBasedRanker("internal/server/server.go", content).rank(candidates)
+
+	expected := []string{
+		"internal/server/server.go:10",                 // same file
+		"internal/server/handler.go:1",                 // same package
+		"internal/server/handler.go:3",                 // same package
+		"internal/server/handler_test.go:1",            // same package, test file
+		"internal/codeintel/stores/dbstore/dumps.go:1", // imported package
+		"internal/search/api.go:1",                     // shared directory prefix
+		"Makefile:1",                                   // unknown language
+		"cmd/other/util.go:1",
+	}
+	if diff := cmp.Diff(expected, candidateKeys(ranked)); diff != "" {
+		t.Errorf("unexpected ranking (-want +got):\n%s", diff)
+	}
+}
+
+func TestSearchBasedRankerTypeScript(t *testing.T) {
+	content := []byte(`import { fetchBlob } from '../backend/blob'
+import React from 'react'
+const util = require('./util')
+`)
+
+	candidates := []SearchBasedCandidate{
+		testCandidate("client/web/src/backend/blob.ts", 1),
+		testCandidate("client/web/src/backend/tree.ts", 1),
+		testCandidate("client/web/src/repo/util/index.js", 1),
+		testCandidate("client/web/src/repo/Blob.test.tsx", 1),
+		testCandidate("client/web/src/repo/Blob.tsx", 1),
+		testCandidate("cmd/frontend/blob.go", 1),
+	}
+
+	ranked := newSearchBasedRanker("client/web/src/repo/RepoContainer.tsx", content).rank(candidates)
+
+	expected := []string{
+		"client/web/src/repo/Blob.tsx:1",      // same directory
+		"client/web/src/repo/Blob.test.tsx:1", // same directory, test file
+		"client/web/src/repo/util/index.js:1", // relative import of package index
+		"client/web/src/backend/blob.ts:1",    // relative import of module
+		"client/web/src/backend/tree.ts:1",    // shared directory prefix
+	}
+	if diff := cmp.Diff(expected, candidateKeys(ranked)); diff != "" {
+		t.Errorf("unexpected ranking (-want +got):\n%s", diff)
+	}
+}
+
+func TestSearchBasedRankerPython(t *testing.T) {
+	content := []byte(`import os, app.models
+from .views import render
+from ..lib.helpers import slugify
+`)
+
+	candidates := []SearchBasedCandidate{
+		testCandidate("src/app/lib/helpers.py", 1),
+		testCandidate("src/app/models/__init__.py", 1),
+		testCandidate("src/app/web/views.py", 1),
+		testCandidate("tests/test_views.py", 1),
+		testCandidate("src/other/views.py", 1),
+	}
+
+	ranked := newSearchBasedRanker("src/app/web/handlers.py", content).rank(candidates)
+
+	expected := []string{
+		"src/app/web/views.py:1",       // same package
+		"src/app/lib/helpers.py:1",     // relative import from parent package
+		"src/app/models/__init__.py:1", // absolute import of package
+		"src/other/views.py:1",         // shared directory prefix
+		"tests/test_views.py:1",        // test file
+	}
+	if diff := cmp.Diff(expected, candidateKeys(ranked)); diff != "" {
+		t.Errorf("unexpected ranking (-want +got):\n%s", diff)
+	}
+}
+
+func TestSearchBasedRankerTestSource(t *testing.T) {
+	candidates := []SearchBasedCandidate{
+		testCandidate("pkg/b/b.go", 1),
+		testCandidate("pkg/b/b_test.go", 1),
+	}
+
+	ranked := newSearchBasedRanker("pkg/a/a_test.go", nil).rank(candidates)
+
+	// Test files are not deprioritized when the source file is itself a test
+	expected := []string{"pkg/b/b.go:1", "pkg/b/b_test.go:1"}
+	if diff := cmp.Diff(expected, candidateKeys(ranked)); diff != "" {
+		t.Errorf("unexpected ranking (-want +got):\n%s", diff)
+	}
+}
+
+func testCandidate(path string, line int) SearchBasedCandidate {
+	return SearchBasedCandidate{
+		Path: path,
+		Range: lsifstore.Range{
+			Start: lsifstore.Position{Line: line, Character: 0},
+			End:   lsifstore.Position{Line: line, Character: 5},
+		},
+	}
+}
+
+func candidateKeys(candidates []SearchBasedCandidate) []string {
+	keys := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		keys = append(keys, fmt.Sprintf("%s:%d", candidate.Path, candidate.Range.Start.Line))
+	}
+
+	return keys
+}
@@ -0,0 +1,121 @@
+package resolvers
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/symbol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// SearchBasedCandidate is a location produced by a symbol or text search that may define or
+// reference the identifier under a user's cursor.
+type SearchBasedCandidate struct {
+	Path  string
+	Range lsifstore.Range
+}
+
+// searchBasedFetchTimeout is the maximum amount of time searcher will wait to fetch the archive
+// of the target repository when searching for text occurrences.
+const searchBasedFetchTimeout = 5 * time.Second
+
+type searchBasedSearcher struct {
+	db database.DB
+
+	// computeSymbols returns the symbols with exactly the given name.
+	computeSymbols func(ctx context.Context, repo types.MinimalRepo, commit api.CommitID, name string, first int32) ([]*result.SymbolMatch, error)
+}
+
+var _ SearchBasedSearcher = &searchBasedSearcher{}
+
+// NewSearchBasedSearcher creates a SearchBasedSearcher that reads symbols from Zoekt (when the
+// target commit is indexed) or the symbols service, and text occurrences from searcher.
+func NewSearchBasedSearcher(db database.DB) SearchBasedSearcher {
+	return &searchBasedSearcher{db: db, computeSymbols: symbol.ComputeExact}
+}
+
+func (s *searchBasedSearcher) ReadFile(ctx context.Context, repo types.MinimalRepo, commit api.CommitID, path string) ([]byte, error) {
+	return git.ReadFile(ctx, s.db, repo.Name, commit, path, authz.DefaultSubRepoPermsChecker)
+}
+
+// SymbolDefinitions returns the location of every symbol with exactly the given name.
+func (s *searchBasedSearcher) SymbolDefinitions(ctx context.Context, repo types.MinimalRepo, commit api.CommitID, name string, limit int) ([]SearchBasedCandidate, error) {
+	// The query is anchored, so that the limit isn't used up by symbols that only contain
+	// the name, such as Get in GetUser.
+	matches, err := s.computeSymbols(ctx, repo, commit, name, int32(limit))
+	if err != nil {
+		return nil, errors.Wrap(err, "symbol.ComputeExact")
+	}
+
+	candidates := make([]SearchBasedCandidate, 0, len(matches))
+	for _, match := range matches {
+		r := match.Symbol.Range()
+		candidates = append(candidates, SearchBasedCandidate{
+			Path: match.File.Path,
+			Range: lsifstore.Range{
+				Start: lsifstore.Position{Line: r.Start.Line, Character: r.Start.Character},
+				End:   lsifstore.Position{Line: r.End.Line, Character: r.End.Character},
+			},
+		})
+	}
+
+	return candidates, nil
+}
+
+// TextOccurrences returns the location of every case-sensitive whole-word occurrence of the
+// given name. At most limit files are searched for matches.
+func (s *searchBasedSearcher) TextOccurrences(ctx context.Context, repo types.MinimalRepo, commit api.CommitID, name string, limit int) ([]SearchBasedCandidate, error) {
+	patternInfo := &search.TextPatternInfo{
+		Pattern:               name,
+		IsWordMatch:           true,
+		IsCaseSensitive:       true,
+		FileMatchLimit:        int32(limit),
+		PatternMatchesContent: true,
+	}
+
+	var candidates []SearchBasedCandidate
+	onMatches := func(fileMatches []*protocol.FileMatch) {
+		for _, fileMatch := range fileMatches {
+			for _, chunkMatch := range fileMatch.ChunkMatches {
+				for _, r := range chunkMatch.Ranges {
+					candidates = append(candidates, SearchBasedCandidate{
+						Path: fileMatch.Path,
+						Range: lsifstore.Range{
+							Start: lsifstore.Position{Line: int(r.Start.Line), Character: int(r.Start.Column)},
+							End:   lsifstore.Position{Line: int(r.End.Line), Character: int(r.End.Column)},
+						},
+					})
+				}
+			}
+		}
+	}
+
+	if _, err := searcher.Search(
+		ctx,
+		search.SearcherURLs(),
+		repo.Name,
+		repo.ID,
+		"",
+		commit,
+		false,
+		patternInfo,
+		searchBasedFetchTimeout,
+		nil,
+		search.Features{},
+		onMatches,
+	); err != nil {
+		return nil, errors.Wrap(err, "searcher.Search")
+	}
+
+	return candidates, nil
+}
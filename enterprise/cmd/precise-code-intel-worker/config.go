@@ -16,6 +16,8 @@ type Config struct {
 	WorkerBudget          int64
 	MaximumRuntimePerJob  time.Duration
 	LSIFUploadStoreConfig *lsifuploadstore.Config
+
+	UploadStoreExpirationInterval time.Duration
 }
 
 func (c *Config) Load() {
@@ -26,6 +28,7 @@ func (c *Config) Load() {
 	c.WorkerConcurrency = c.GetInt("PRECISE_CODE_INTEL_WORKER_CONCURRENCY", "1", "The maximum number of indexes that can be processed concurrently.")
 	c.WorkerBudget = int64(c.GetInt("PRECISE_CODE_INTEL_WORKER_BUDGET", "0", "The amount of compressed input data (in bytes) a worker can process concurrently. Zero acts as an infinite budget."))
 	c.MaximumRuntimePerJob = c.GetInterval("PRECISE_CODE_INTEL_WORKER_MAXIMUM_RUNTIME_PER_JOB", "25m", "The maximum time a single LSIF processing job can take.")
+	c.UploadStoreExpirationInterval = c.GetInterval("PRECISE_CODE_INTEL_UPLOAD_EXPIRATION_INTERVAL", "1h", "Interval between removals of expired uploads from Azure and Filesystem upload stores.")
 }
 
 func (c *Config) Validate() error {
@@ -1,6 +1,7 @@
 package worker
 
 import (
+	"bufio"
 	"compress/gzip"
 	"context"
 	"fmt"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	scipconversion "github.com/sourcegraph/sourcegraph/internal/codeintel/scip/conversion"
 	store "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
@@ -23,6 +25,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/pathexistence"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/lib/log"
@@ -110,6 +113,19 @@ func (h *handler) handle(ctx context.Context, logger log.Logger, upload store.Up
 		return requeued, err
 	}
 
+	// Incremental uploads are processed only once their base upload has been processed, as we need
+	// to carry the data of the unchanged documents forward from the base upload.
+	var baseUpload *store.Upload
+	if upload.BaseUploadID != nil {
+		base, requeued, err := resolveBaseUpload(ctx, logger, h.dbStore, h.workerStore, upload)
+		if err != nil || requeued {
+			return requeued, err
+		}
+
+		baseUpload = &base
+		trace.Log(otlog.Int("baseUploadID", base.ID), otlog.Int("numChangedPaths", len(upload.ChangedPaths)))
+	}
+
 	// Determine if the upload is for the default Git branch.
 	isDefaultBranch, err := h.gitserverClient.DefaultBranchContains(ctx, upload.RepositoryID, upload.Commit)
 	if err != nil {
@@ -127,14 +143,23 @@ func (h *handler) handle(ctx context.Context, logger log.Logger, upload store.Up
 	}
 
 	return false, withUploadData(ctx, logger, h.uploadStore, upload.ID, trace, func(r io.Reader) (err error) {
-		groupedBundleData, err := conversion.Correlate(ctx, r, upload.Root, getChildren)
+		groupedBundleData, err := correlate(ctx, r, upload.Root, getChildren)
 		if err != nil {
-			return errors.Wrap(err, "conversion.Correlate")
+			return err
 		}
 
 		// Note: this is writing to a different database than the block below, so we need to use a
-		// different transaction context (managed by the writeData function).
-		if err := writeData(ctx, h.lsifStore, upload, repo, isDefaultBranch, groupedBundleData, trace); err != nil {
+		// different transaction context (managed by the writeData and writeIncrementalData functions).
+		write := func() error {
+			return writeData(ctx, h.lsifStore, upload, repo, isDefaultBranch, groupedBundleData, trace)
+		}
+		if baseUpload != nil {
+			write = func() error {
+				return writeIncrementalData(ctx, h.lsifStore, upload, *baseUpload, groupedBundleData, trace)
+			}
+		}
+
+		if err := write(); err != nil {
 			if isUniqueConstraintViolation(err) {
 				// If this is a unique constraint violation, then we've previously processed this same
 				// upload record up to this point, but failed to perform the transaction below. We can
@@ -184,6 +209,13 @@ func (h *handler) handle(ctx context.Context, logger log.Logger, upload store.Up
 			if err := tx.UpdatePackageReferences(ctx, upload.ID, groupedBundleData.PackageReferences); err != nil {
 				return errors.Wrap(err, "store.UpdatePackageReferences")
 			}
+			if baseUpload != nil {
+				// The unchanged documents carried forward from the base upload may provide or refer to
+				// packages that are not mentioned by any of the changed documents.
+				if err := tx.CopyPackagesAndReferences(ctx, baseUpload.ID, upload.ID); err != nil {
+					return errors.Wrap(err, "store.CopyPackagesAndReferences")
+				}
+			}
 
 			// When inserting a new completed upload record, update the reference counts both to it from
 			// existing uploads, as well as the reference counts to all of this new upload's dependencies.
@@ -264,8 +296,8 @@ func requeueIfCloningOrCommitUnknown(ctx context.Context, logger log.Logger, db
 }
 
 // withUploadData will invoke the given function with a reader of the upload's raw data. The
-// consumer should expect raw newline-delimited JSON content. If the function returns without
-// an error, the upload file will be deleted.
+// consumer should expect raw newline-delimited LSIF JSON or SCIP protobuf content. If the
+// function returns without an error, the upload file will be deleted.
 func withUploadData(ctx context.Context, logger log.Logger, uploadStore uploadstore.Store, id int, trace observation.TraceLogger, fn func(r io.Reader) error) error {
 	uploadFilename := fmt.Sprintf("upload-%d.lsif.gz", id)
 
@@ -297,6 +329,51 @@ func withUploadData(ctx context.Context, logger log.Logger, uploadStore uploadst
 	return nil
 }
 
+// correlate converts the given raw upload data into the format we write to the codeintel
+// database. Uploads may be either newline-delimited LSIF JSON or a SCIP protobuf index. An
+// LSIF upload always begins with a JSON object, whereas the first byte of a SCIP index is
+// the tag of one of its fields.
+func correlate(ctx context.Context, r io.Reader, root string, getChildren pathexistence.GetChildrenFunc) (*precise.GroupedBundleDataChans, error) {
+	br := bufio.NewReader(r)
+
+	if isSCIP(br) {
+		groupedBundleData, err := scipconversion.Correlate(ctx, br, root, getChildren)
+		if err != nil {
+			return nil, errors.Wrap(err, "scipconversion.Correlate")
+		}
+
+		return groupedBundleData, nil
+	}
+
+	groupedBundleData, err := conversion.Correlate(ctx, br, root, getChildren)
+	if err != nil {
+		return nil, errors.Wrap(err, "conversion.Correlate")
+	}
+
+	return groupedBundleData, nil
+}
+
+// isSCIP returns true if the given reader does not begin with a JSON object. Newlines are
+// not skipped as the tag of the SCIP metadata field is the newline byte.
+func isSCIP(r *bufio.Reader) bool {
+	for i := 1; ; i++ {
+		peeked, err := r.Peek(i)
+		if err != nil || len(peeked) < i {
+			// Empty uploads are handled (and rejected) by LSIF correlation
+			return false
+		}
+
+		switch peeked[i-1] {
+		case ' ', '\t', '\r':
+			continue
+		case '{':
+			return false
+		default:
+			return true
+		}
+	}
+}
+
 // writeData transactionally writes the given grouped bundle data into the given LSIF store.
 func writeData(ctx context.Context, lsifStore LSIFStore, upload store.Upload, repo *types.Repo, isDefaultBranch bool, groupedBundleData *precise.GroupedBundleDataChans, trace observation.TraceLogger) (err error) {
 	tx, err := lsifStore.Transact(ctx)
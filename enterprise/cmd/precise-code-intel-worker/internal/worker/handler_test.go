@@ -1,6 +1,9 @@
 package worker
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"io"
 	"os"
@@ -9,6 +12,8 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -153,6 +158,89 @@ func TestHandle(t *testing.T) {
 	}
 }
 
+func TestHandleSCIP(t *testing.T) {
+	setupRepoMocks(t)
+
+	upload := dbstore.Upload{
+		ID:           42,
+		Root:         "root/",
+		Commit:       "deadbeef",
+		RepositoryID: 50,
+		Indexer:      "scip-go",
+	}
+
+	mockWorkerStore := NewMockWorkerStore()
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+	gitserverClient := NewMockGitserverClient()
+
+	// Set default transaction behavior
+	mockDBStore.TransactFunc.SetDefaultReturn(mockDBStore, nil)
+	mockDBStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+
+	// Set default transaction behavior
+	mockLSIFStore.TransactFunc.SetDefaultReturn(mockLSIFStore, nil)
+	mockLSIFStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+
+	// Give correlation package a valid SCIP index
+	mockUploadStore.GetFunc.SetDefaultHook(func(ctx context.Context, key string) (io.ReadCloser, error) {
+		return gzipTestSCIPIndex(t), nil
+	})
+
+	// Allowlist all files in the index
+	gitserverClient.DirectoryChildrenFunc.SetDefaultReturn(map[string][]string{
+		"":     {"root/"},
+		"root": {"root/foo.go"},
+	}, nil)
+
+	gitserverClient.CommitDateFunc.SetDefaultReturn("deadbeef", time.Unix(1587396557, 0).UTC(), true, nil)
+
+	var paths []string
+	mockLSIFStore.WriteDocumentsFunc.SetDefaultHook(func(ctx context.Context, bundleID int, documents chan precise.KeyedDocumentData) (uint32, error) {
+		for document := range documents {
+			paths = append(paths, document.Path)
+		}
+		return uint32(len(paths)), nil
+	})
+
+	handler := &handler{
+		dbStore:         mockDBStore,
+		workerStore:     mockWorkerStore,
+		lsifStore:       mockLSIFStore,
+		uploadStore:     mockUploadStore,
+		gitserverClient: gitserverClient,
+	}
+
+	requeued, err := handler.handle(context.Background(), logtest.Scoped(t), upload, observation.TestTraceLogger(logtest.Scoped(t)))
+	if err != nil {
+		t.Fatalf("unexpected error handling upload: %s", err)
+	} else if requeued {
+		t.Errorf("unexpected requeue")
+	}
+
+	if diff := cmp.Diff([]string{"foo.go"}, paths); diff != "" {
+		t.Errorf("unexpected documents (-want +got):\n%s", diff)
+	}
+
+	expectedPackages := []precise.Package{
+		{
+			Scheme:  "scip-go",
+			Name:    "github.com/test/foo",
+			Version: "v1.0.0",
+		},
+	}
+	if len(mockDBStore.UpdatePackagesFunc.History()) != 1 {
+		t.Errorf("unexpected number of UpdatePackages calls. want=%d have=%d", 1, len(mockDBStore.UpdatePackagesFunc.History()))
+	} else if diff := cmp.Diff(expectedPackages, mockDBStore.UpdatePackagesFunc.History()[0].Arg2); diff != "" {
+		t.Errorf("unexpected UpdatePackagesFunc args (-want +got):\n%s", diff)
+	}
+
+	if len(mockUploadStore.DeleteFunc.History()) != 1 {
+		t.Errorf("unexpected number of Delete calls. want=%d have=%d", 1, len(mockUploadStore.DeleteFunc.History()))
+	}
+}
+
 func TestHandleError(t *testing.T) {
 	setupRepoMocks(t)
 
@@ -269,6 +357,54 @@ func copyTestDump(ctx context.Context, key string) (io.ReadCloser, error) {
 	return os.Open("../../testdata/dump1.lsif.gz")
 }
 
+func gzipTestSCIPIndex(t *testing.T) io.ReadCloser {
+	payload, err := proto.Marshal(&scip.Index{
+		Metadata: &scip.Metadata{ToolInfo: &scip.ToolInfo{Name: "scip-go"}},
+		Documents: []*scip.Document{
+			{
+				RelativePath: "foo.go",
+				Occurrences: []*scip.Occurrence{
+					{
+						Range:       []int32{1, 5, 8},
+						Symbol:      "scip-go gomod github.com/test/foo v1.0.0 `github.com/test/foo`/Foo().",
+						SymbolRoles: int32(scip.SymbolRole_Definition),
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	if _, err := gzipWriter.Write(payload); err != nil {
+		t.Fatalf("unexpected error compressing index: %s", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatalf("unexpected error compressing index: %s", err)
+	}
+
+	return io.NopCloser(&buf)
+}
+
+func TestIsSCIP(t *testing.T) {
+	testCases := map[string]bool{
+		"":                 false,
+		"{\"id\":1}\n":     false,
+		"  \t{\"id\":1}\n": false,
+		"\n\x02\x08\x01":   true,
+		"\x12\x00":         true,
+	}
+
+	for input, expected := range testCases {
+		if actual := isSCIP(bufio.NewReader(strings.NewReader(input))); actual != expected {
+			t.Errorf("unexpected result for %q. want=%v have=%v", input, expected, actual)
+		}
+	}
+}
+
 func setupRepoMocks(t *testing.T) {
 	t.Cleanup(func() {
 		backend.Mocks.Repos.Get = nil
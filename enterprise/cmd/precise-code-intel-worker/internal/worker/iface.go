@@ -21,8 +21,10 @@ type DBStore interface {
 	Done(err error) error
 
 	RepoName(ctx context.Context, id int) (string, error)
+	GetUploadByID(ctx context.Context, id int) (dbstore.Upload, bool, error)
 	UpdatePackages(ctx context.Context, dumpID int, packages []precise.Package) error
 	UpdatePackageReferences(ctx context.Context, dumpID int, packageReferences []precise.PackageReference) error
+	CopyPackagesAndReferences(ctx context.Context, sourceDumpID, targetDumpID int) error
 	UpdateReferenceCounts(ctx context.Context, ids []int, dependencyUpdateType dbstore.DependencyReferenceCountUpdateType) (updatedUploads int, err error)
 	MarkRepositoryAsDirty(ctx context.Context, repositoryID int) error
 	DeleteOverlappingDumps(ctx context.Context, repositoryID int, commit, root, indexer string) error
@@ -57,6 +59,13 @@ type LSIFStore interface {
 	WriteDefinitions(ctx context.Context, bundleID int, monikerLocations chan precise.MonikerLocations) (count uint32, err error)
 	WriteReferences(ctx context.Context, bundleID int, monikerLocations chan precise.MonikerLocations) (count uint32, err error)
 	WriteImplementations(ctx context.Context, bundleID int, monikerLocations chan precise.MonikerLocations) (count uint32, err error)
+
+	ReadMeta(ctx context.Context, bundleID int) (precise.MetaData, bool, error)
+	CopyDocuments(ctx context.Context, sourceBundleID, targetBundleID int, excludedPaths []string) (count uint32, err error)
+	ScanResultChunks(ctx context.Context, bundleID int, f func(index int, resultChunk precise.ResultChunkData)) error
+	ScanDefinitions(ctx context.Context, bundleID int, f func(monikerLocations precise.MonikerLocations)) error
+	ScanReferences(ctx context.Context, bundleID int, f func(monikerLocations precise.MonikerLocations)) error
+	ScanImplementations(ctx context.Context, bundleID int, f func(monikerLocations precise.MonikerLocations)) error
 }
 
 type LSIFStoreShim struct {
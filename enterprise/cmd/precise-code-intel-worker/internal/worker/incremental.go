@@ -0,0 +1,446 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	store "github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/log"
+)
+
+// An incremental upload contains only the documents of a project that have changed since a
+// previously processed base upload of the same repository, root, and indexer. When processing
+// an incremental upload, we correlate only the documents contained in the upload and carry all
+// remaining documents of the base upload forward. The resulting dump is a self-contained copy
+// of the base data in which the changed documents have been replaced, and it is added to the
+// commit graph like any other completed upload.
+//
+// The dump is NOT equivalent to one created from a complete upload. Result sets (definition,
+// reference, and implementation results) are the only data that may refer to more than one
+// document. The result sets of the base upload are carried forward with all locations within
+// replaced documents removed, and the result sets of the incremental upload are assigned
+// identifiers that cannot collide with those of the base upload. A result set that spans
+// replaced and unchanged documents is not merged with its counterpart in the other upload.
+// For example, an unchanged document that references a symbol defined in a replaced document
+// has no definition for that reference. Only relationships through monikers, which are merged
+// by scheme and identifier, are resolved across that boundary.
+
+// resolveBaseUpload returns the completed base upload of the given incremental upload. If the base
+// upload has not yet been processed, the given upload is requeued and a true-valued flag is returned.
+func resolveBaseUpload(ctx context.Context, logger log.Logger, dbStore DBStore, workerStore dbworkerstore.Store, upload store.Upload) (_ store.Upload, requeued bool, _ error) {
+	baseUpload, exists, err := dbStore.GetUploadByID(ctx, *upload.BaseUploadID)
+	if err != nil {
+		return store.Upload{}, false, errors.Wrap(err, "store.GetUploadByID")
+	}
+	if !exists {
+		return store.Upload{}, false, errors.Newf("base upload %d does not exist", *upload.BaseUploadID)
+	}
+
+	switch baseUpload.State {
+	case "completed":
+	case "uploading", "queued", "processing":
+		after := time.Now().UTC().Add(requeueDelay)
+
+		if err := workerStore.Requeue(ctx, upload.ID, after); err != nil {
+			return store.Upload{}, false, errors.Wrap(err, "store.Requeue")
+		}
+		logger.Warn("Requeued LSIF upload record",
+			log.Int("id", upload.ID),
+			log.String("reason", "base upload not yet processed"))
+		return store.Upload{}, true, nil
+
+	default:
+		return store.Upload{}, false, errors.Newf("base upload %d is not available (state=%s)", baseUpload.ID, baseUpload.State)
+	}
+
+	if baseUpload.RepositoryID != upload.RepositoryID || baseUpload.Root != upload.Root || baseUpload.Indexer != upload.Indexer {
+		return store.Upload{}, false, errors.Newf("base upload %d does not describe the same repository, root, and indexer", baseUpload.ID)
+	}
+
+	return baseUpload, false, nil
+}
+
+// writeIncrementalData transactionally writes the given grouped bundle data into the given LSIF
+// store, carrying forward all data of the given base upload that is not replaced by the changed
+// documents of the given incremental upload. The data of the base upload is read outside of the
+// write transaction.
+func writeIncrementalData(ctx context.Context, lsifStore LSIFStore, upload store.Upload, baseUpload store.Upload, groupedBundleData *precise.GroupedBundleDataChans, trace observation.TraceLogger) (err error) {
+	baseMeta, exists, err := lsifStore.ReadMeta(ctx, baseUpload.ID)
+	if err != nil {
+		return errors.Wrap(err, "store.ReadMeta")
+	}
+	if !exists {
+		return errors.Newf("no data exists for base upload %d", baseUpload.ID)
+	}
+
+	numResultChunks := baseMeta.NumResultChunks
+	if numResultChunks <= 0 {
+		numResultChunks = groupedBundleData.Meta.NumResultChunks
+	}
+
+	changes := collectIncrementalChanges(groupedBundleData, upload, numResultChunks)
+	trace.Log(
+		otlog.Int("numReplacedPaths", len(changes.replacedPaths)),
+		otlog.Int("numResultChunks", numResultChunks),
+	)
+
+	tx, err := lsifStore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.WriteMeta(ctx, upload.ID, precise.MetaData{NumResultChunks: numResultChunks}); err != nil {
+		return errors.Wrap(err, "store.WriteMeta")
+	}
+
+	count, err := tx.CopyDocuments(ctx, baseUpload.ID, upload.ID, changes.sortedReplacedPaths())
+	if err != nil {
+		return errors.Wrap(err, "store.CopyDocuments")
+	}
+	trace.Log(otlog.Uint32("numCopiedDocuments", count))
+
+	count, err = tx.WriteDocuments(ctx, upload.ID, documentChannel(changes.documents))
+	if err != nil {
+		return errors.Wrap(err, "store.WriteDocuments")
+	}
+	trace.Log(otlog.Uint32("numDocuments", count))
+
+	count, err = writeMerged(ctx, tx.WriteResultChunks, upload.ID, func(ctx context.Context, ch chan<- precise.IndexedResultChunkData) error {
+		return mergeResultChunks(ctx, lsifStore, baseUpload.ID, changes, ch)
+	})
+	if err != nil {
+		return errors.Wrap(err, "store.WriteResultChunks")
+	}
+	trace.Log(otlog.Uint32("numResultChunks", count))
+
+	for _, monikers := range []struct {
+		name   string
+		scan   func(ctx context.Context, bundleID int, f func(precise.MonikerLocations)) error
+		write  func(ctx context.Context, bundleID int, monikerLocations chan precise.MonikerLocations) (uint32, error)
+		values map[monikerKey]precise.MonikerLocations
+	}{
+		{"Definitions", lsifStore.ScanDefinitions, tx.WriteDefinitions, changes.definitions},
+		{"References", lsifStore.ScanReferences, tx.WriteReferences, changes.references},
+		{"Implementations", lsifStore.ScanImplementations, tx.WriteImplementations, changes.implementations},
+	} {
+		monikers := monikers
+
+		count, err = writeMerged(ctx, monikers.write, upload.ID, func(ctx context.Context, ch chan<- precise.MonikerLocations) error {
+			return mergeMonikerLocations(ctx, monikers.scan, baseUpload.ID, changes.replacedPaths, monikers.values, ch)
+		})
+		if err != nil {
+			return errors.Wrap(err, "store.Write"+monikers.name)
+		}
+		trace.Log(otlog.Uint32("num"+monikers.name, count))
+	}
+
+	return nil
+}
+
+// writeMerged invokes the given write function with a channel populated by the given merge function.
+// The merge function is invoked in a separate goroutine and is canceled if the write fails.
+func writeMerged[T any](
+	ctx context.Context,
+	write func(ctx context.Context, bundleID int, ch chan T) (uint32, error),
+	bundleID int,
+	merge func(ctx context.Context, ch chan<- T) error,
+) (uint32, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ch := make(chan T)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		errs <- merge(ctx, ch)
+	}()
+
+	count, err := write(ctx, bundleID, ch)
+
+	// Unblock the merge function if the write returned without consuming the entire channel
+	cancel()
+	for range ch {
+	}
+
+	if mergeErr := <-errs; err == nil {
+		err = mergeErr
+	}
+
+	return count, err
+}
+
+// incrementalChanges is the fully-read grouped bundle data of an incremental upload.
+type incrementalChanges struct {
+	// replacedPaths is the set of paths (relative to the upload root) of documents of the base
+	// upload that are replaced (or deleted) by the incremental upload.
+	replacedPaths map[string]struct{}
+
+	// documents are the documents of the incremental upload. The result set identifiers of all
+	// ranges are namespaced to the incremental upload.
+	documents []precise.KeyedDocumentData
+
+	// resultsByIndex maps result chunk indexes to the (namespaced) result sets of the incremental
+	// upload that hash to that result chunk index.
+	resultsByIndex map[int]map[precise.ID][]precise.DocumentPathRangeID
+
+	definitions     map[monikerKey]precise.MonikerLocations
+	references      map[monikerKey]precise.MonikerLocations
+	implementations map[monikerKey]precise.MonikerLocations
+}
+
+type monikerKey struct {
+	scheme     string
+	identifier string
+}
+
+// collectIncrementalChanges reads the given grouped bundle data of an incremental upload into memory.
+// Incremental uploads are expected to contain few documents, so this is cheap compared to reading the
+// data of the base upload into memory.
+func collectIncrementalChanges(groupedBundleData *precise.GroupedBundleDataChans, upload store.Upload, numResultChunks int) incrementalChanges {
+	namespace := fmt.Sprintf("u%d:", upload.ID)
+	namespaced := func(id precise.ID) precise.ID {
+		if id == "" {
+			return ""
+		}
+		return precise.ID(namespace) + id
+	}
+
+	changes := incrementalChanges{
+		replacedPaths:   map[string]struct{}{},
+		resultsByIndex:  map[int]map[precise.ID][]precise.DocumentPathRangeID{},
+		definitions:     map[monikerKey]precise.MonikerLocations{},
+		references:      map[monikerKey]precise.MonikerLocations{},
+		implementations: map[monikerKey]precise.MonikerLocations{},
+	}
+
+	for _, path := range upload.ChangedPaths {
+		// Changed paths are relative to the repository root; documents are relative to the upload root
+		if strings.HasPrefix(path, upload.Root) {
+			changes.replacedPaths[strings.TrimPrefix(path, upload.Root)] = struct{}{}
+		}
+	}
+
+	for document := range groupedBundleData.Documents {
+		for id, r := range document.Document.Ranges {
+			r.DefinitionResultID = namespaced(r.DefinitionResultID)
+			r.ReferenceResultID = namespaced(r.ReferenceResultID)
+			r.ImplementationResultID = namespaced(r.ImplementationResultID)
+			document.Document.Ranges[id] = r
+		}
+
+		changes.documents = append(changes.documents, document)
+		changes.replacedPaths[document.Path] = struct{}{}
+	}
+
+	for resultChunk := range groupedBundleData.ResultChunks {
+		for id, documentIDRangeIDs := range resultChunk.ResultChunk.DocumentIDRangeIDs {
+			resultID := namespaced(id)
+			index := precise.HashKey(resultID, numResultChunks)
+
+			if _, ok := changes.resultsByIndex[index]; !ok {
+				changes.resultsByIndex[index] = map[precise.ID][]precise.DocumentPathRangeID{}
+			}
+
+			for _, documentIDRangeID := range documentIDRangeIDs {
+				changes.resultsByIndex[index][resultID] = append(changes.resultsByIndex[index][resultID], precise.DocumentPathRangeID{
+					Path:    resultChunk.ResultChunk.DocumentPaths[documentIDRangeID.DocumentID],
+					RangeID: documentIDRangeID.RangeID,
+				})
+			}
+		}
+	}
+
+	for _, c := range []struct {
+		ch     chan precise.MonikerLocations
+		values map[monikerKey]precise.MonikerLocations
+	}{
+		{groupedBundleData.Definitions, changes.definitions},
+		{groupedBundleData.References, changes.references},
+		{groupedBundleData.Implementations, changes.implementations},
+	} {
+		for monikerLocations := range c.ch {
+			key := monikerKey{monikerLocations.Scheme, monikerLocations.Identifier}
+			value := c.values[key]
+			value.Kind, value.Scheme, value.Identifier = monikerLocations.Kind, monikerLocations.Scheme, monikerLocations.Identifier
+			value.Locations = append(value.Locations, monikerLocations.Locations...)
+			c.values[key] = value
+		}
+	}
+
+	return changes
+}
+
+// sortedReplacedPaths returns the replaced paths in lexicographic order.
+func (c incrementalChanges) sortedReplacedPaths() []string {
+	paths := make([]string, 0, len(c.replacedPaths))
+	for path := range c.replacedPaths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+// documentChannel returns a closed, buffered channel containing the given documents.
+func documentChannel(documents []precise.KeyedDocumentData) chan precise.KeyedDocumentData {
+	ch := make(chan precise.KeyedDocumentData, len(documents))
+	for _, document := range documents {
+		ch <- document
+	}
+	close(ch)
+
+	return ch
+}
+
+// mergeResultChunks sends each result chunk of the given base upload to the given channel with all
+// locations within replaced documents removed and the result sets of the incremental upload added.
+// Result chunks for indexes not present in the base upload are created as necessary.
+func mergeResultChunks(ctx context.Context, lsifStore LSIFStore, baseUploadID int, changes incrementalChanges, ch chan<- precise.IndexedResultChunkData) error {
+	seen := map[int]struct{}{}
+	send := func(index int, resultChunk precise.ResultChunkData) {
+		seen[index] = struct{}{}
+
+		select {
+		case ch <- precise.IndexedResultChunkData{Index: index, ResultChunk: resultChunk}:
+		case <-ctx.Done():
+		}
+	}
+
+	if err := lsifStore.ScanResultChunks(ctx, baseUploadID, func(index int, resultChunk precise.ResultChunkData) {
+		send(index, mergeResultChunk(resultChunk, changes.replacedPaths, changes.resultsByIndex[index]))
+	}); err != nil {
+		return err
+	}
+
+	indexes := make([]int, 0, len(changes.resultsByIndex))
+	for index := range changes.resultsByIndex {
+		if _, ok := seen[index]; !ok {
+			indexes = append(indexes, index)
+		}
+	}
+	sort.Ints(indexes)
+
+	for _, index := range indexes {
+		send(index, mergeResultChunk(precise.ResultChunkData{}, nil, changes.resultsByIndex[index]))
+	}
+
+	return ctx.Err()
+}
+
+// mergeResultChunk returns a copy of the given result chunk without locations in any of the given
+// replaced paths and with the given additional result sets. Document identifiers of the additional
+// result sets are derived from their paths, which cannot collide with the integer identifiers used
+// by the base result chunk.
+func mergeResultChunk(resultChunk precise.ResultChunkData, replacedPaths map[string]struct{}, results map[precise.ID][]precise.DocumentPathRangeID) precise.ResultChunkData {
+	merged := precise.ResultChunkData{
+		DocumentPaths:      map[precise.ID]string{},
+		DocumentIDRangeIDs: map[precise.ID][]precise.DocumentIDRangeID{},
+	}
+
+	for id, documentIDRangeIDs := range resultChunk.DocumentIDRangeIDs {
+		filtered := make([]precise.DocumentIDRangeID, 0, len(documentIDRangeIDs))
+		for _, documentIDRangeID := range documentIDRangeIDs {
+			path, ok := resultChunk.DocumentPaths[documentIDRangeID.DocumentID]
+			if !ok {
+				continue
+			}
+			if _, ok := replacedPaths[path]; ok {
+				continue
+			}
+
+			merged.DocumentPaths[documentIDRangeID.DocumentID] = path
+			filtered = append(filtered, documentIDRangeID)
+		}
+
+		if len(filtered) > 0 {
+			merged.DocumentIDRangeIDs[id] = filtered
+		}
+	}
+
+	for id, documentPathRangeIDs := range results {
+		documentIDRangeIDs := make([]precise.DocumentIDRangeID, 0, len(documentPathRangeIDs))
+		for _, documentPathRangeID := range documentPathRangeIDs {
+			documentID := precise.ID("path:" + documentPathRangeID.Path)
+			merged.DocumentPaths[documentID] = documentPathRangeID.Path
+			documentIDRangeIDs = append(documentIDRangeIDs, precise.DocumentIDRangeID{
+				DocumentID: documentID,
+				RangeID:    documentPathRangeID.RangeID,
+			})
+		}
+
+		merged.DocumentIDRangeIDs[id] = documentIDRangeIDs
+	}
+
+	return merged
+}
+
+// mergeMonikerLocations sends the moniker locations of the given base upload to the given channel with
+// all locations within replaced documents removed and the locations of the incremental upload with the
+// same scheme and identifier added. The remaining moniker locations of the incremental upload are sent
+// afterwards. Monikers without any remaining locations are dropped.
+func mergeMonikerLocations(
+	ctx context.Context,
+	scan func(ctx context.Context, bundleID int, f func(precise.MonikerLocations)) error,
+	baseUploadID int,
+	replacedPaths map[string]struct{},
+	values map[monikerKey]precise.MonikerLocations,
+	ch chan<- precise.MonikerLocations,
+) error {
+	seen := map[monikerKey]struct{}{}
+	send := func(monikerLocations precise.MonikerLocations) {
+		if len(monikerLocations.Locations) == 0 {
+			return
+		}
+
+		select {
+		case ch <- monikerLocations:
+		case <-ctx.Done():
+		}
+	}
+
+	if err := scan(ctx, baseUploadID, func(monikerLocations precise.MonikerLocations) {
+		key := monikerKey{monikerLocations.Scheme, monikerLocations.Identifier}
+		seen[key] = struct{}{}
+
+		filtered := make([]precise.LocationData, 0, len(monikerLocations.Locations))
+		for _, location := range monikerLocations.Locations {
+			if _, ok := replacedPaths[location.URI]; !ok {
+				filtered = append(filtered, location)
+			}
+		}
+		monikerLocations.Locations = append(filtered, values[key].Locations...)
+
+		send(monikerLocations)
+	}); err != nil {
+		return err
+	}
+
+	keys := make([]monikerKey, 0, len(values))
+	for key := range values {
+		if _, ok := seen[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].scheme != keys[j].scheme {
+			return keys[i].scheme < keys[j].scheme
+		}
+		return keys[i].identifier < keys[j].identifier
+	})
+
+	for _, key := range keys {
+		send(values[key])
+	}
+
+	return ctx.Err()
+}
@@ -0,0 +1,254 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/log/logtest"
+)
+
+func TestHandleIncremental(t *testing.T) {
+	setupRepoMocks(t)
+
+	baseUploadID := 41
+	upload := dbstore.Upload{
+		ID:           42,
+		Root:         "root/",
+		Commit:       "deadbeef",
+		RepositoryID: 50,
+		Indexer:      "scip-go",
+		BaseUploadID: &baseUploadID,
+		ChangedPaths: []string{"root/foo.go", "root/deleted.go", "other/baz.go"},
+	}
+
+	mockWorkerStore := NewMockWorkerStore()
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+	gitserverClient := NewMockGitserverClient()
+
+	// Set default transaction behavior
+	mockDBStore.TransactFunc.SetDefaultReturn(mockDBStore, nil)
+	mockDBStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+
+	// Set default transaction behavior
+	mockLSIFStore.TransactFunc.SetDefaultReturn(mockLSIFStore, nil)
+	mockLSIFStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+
+	// Give correlation package a valid SCIP index containing only foo.go
+	mockUploadStore.GetFunc.SetDefaultHook(func(ctx context.Context, key string) (io.ReadCloser, error) {
+		return gzipTestSCIPIndex(t), nil
+	})
+
+	// Allowlist all files in the index
+	gitserverClient.DirectoryChildrenFunc.SetDefaultReturn(map[string][]string{
+		"":     {"root/"},
+		"root": {"root/foo.go"},
+	}, nil)
+
+	gitserverClient.CommitDateFunc.SetDefaultReturn("deadbeef", time.Unix(1587396557, 0).UTC(), true, nil)
+
+	// Base upload contains foo.go and bar.go
+	mockDBStore.GetUploadByIDFunc.SetDefaultReturn(dbstore.Upload{
+		ID:           41,
+		Root:         "root/",
+		RepositoryID: 50,
+		Indexer:      "scip-go",
+		State:        "completed",
+	}, true, nil)
+	mockLSIFStore.ReadMetaFunc.SetDefaultReturn(precise.MetaData{NumResultChunks: 1}, true, nil)
+	mockLSIFStore.ScanResultChunksFunc.SetDefaultHook(func(ctx context.Context, bundleID int, f func(int, precise.ResultChunkData)) error {
+		f(0, precise.ResultChunkData{
+			DocumentPaths: map[precise.ID]string{"1": "foo.go", "2": "bar.go"},
+			DocumentIDRangeIDs: map[precise.ID][]precise.DocumentIDRangeID{
+				"10": {{DocumentID: "1", RangeID: "100"}, {DocumentID: "2", RangeID: "200"}},
+				"11": {{DocumentID: "1", RangeID: "101"}},
+			},
+		})
+		return nil
+	})
+	mockLSIFStore.ScanDefinitionsFunc.SetDefaultHook(func(ctx context.Context, bundleID int, f func(precise.MonikerLocations)) error {
+		f(precise.MonikerLocations{Scheme: "test", Identifier: "bar", Locations: []precise.LocationData{{URI: "bar.go"}, {URI: "foo.go"}}})
+		f(precise.MonikerLocations{Scheme: "test", Identifier: "foo", Locations: []precise.LocationData{{URI: "foo.go"}}})
+		return nil
+	})
+
+	var paths []string
+	mockLSIFStore.WriteDocumentsFunc.SetDefaultHook(func(ctx context.Context, bundleID int, documents chan precise.KeyedDocumentData) (uint32, error) {
+		for document := range documents {
+			paths = append(paths, document.Path)
+		}
+		return uint32(len(paths)), nil
+	})
+	var resultChunks []precise.IndexedResultChunkData
+	mockLSIFStore.WriteResultChunksFunc.SetDefaultHook(func(ctx context.Context, bundleID int, ch chan precise.IndexedResultChunkData) (uint32, error) {
+		for resultChunk := range ch {
+			resultChunks = append(resultChunks, resultChunk)
+		}
+		return uint32(len(resultChunks)), nil
+	})
+	var definitions []precise.MonikerLocations
+	mockLSIFStore.WriteDefinitionsFunc.SetDefaultHook(func(ctx context.Context, bundleID int, ch chan precise.MonikerLocations) (uint32, error) {
+		for monikerLocations := range ch {
+			definitions = append(definitions, monikerLocations)
+		}
+		return uint32(len(definitions)), nil
+	})
+
+	drainMonikerLocations := func(ctx context.Context, bundleID int, ch chan precise.MonikerLocations) (count uint32, _ error) {
+		for range ch {
+			count++
+		}
+		return count, nil
+	}
+	mockLSIFStore.WriteReferencesFunc.SetDefaultHook(drainMonikerLocations)
+	mockLSIFStore.WriteImplementationsFunc.SetDefaultHook(drainMonikerLocations)
+
+	handler := &handler{
+		dbStore:         mockDBStore,
+		workerStore:     mockWorkerStore,
+		lsifStore:       mockLSIFStore,
+		uploadStore:     mockUploadStore,
+		gitserverClient: gitserverClient,
+	}
+
+	requeued, err := handler.handle(context.Background(), logtest.Scoped(t), upload, observation.TestTraceLogger(logtest.Scoped(t)))
+	if err != nil {
+		t.Fatalf("unexpected error handling upload: %s", err)
+	} else if requeued {
+		t.Errorf("unexpected requeue")
+	}
+
+	if diff := cmp.Diff([]string{"foo.go"}, paths); diff != "" {
+		t.Errorf("unexpected documents (-want +got):\n%s", diff)
+	}
+
+	if calls := mockLSIFStore.WriteMetaFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of WriteMeta calls. want=%d have=%d", 1, len(calls))
+	} else if diff := cmp.Diff(precise.MetaData{NumResultChunks: 1}, calls[0].Arg2); diff != "" {
+		t.Errorf("unexpected metadata (-want +got):\n%s", diff)
+	}
+
+	if calls := mockLSIFStore.CopyDocumentsFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of CopyDocuments calls. want=%d have=%d", 1, len(calls))
+	} else if calls[0].Arg1 != 41 || calls[0].Arg2 != 42 {
+		t.Errorf("unexpected CopyDocuments bundle ids. want=%d,%d have=%d,%d", 41, 42, calls[0].Arg1, calls[0].Arg2)
+	} else if diff := cmp.Diff([]string{"deleted.go", "foo.go"}, calls[0].Arg3); diff != "" {
+		t.Errorf("unexpected excluded paths (-want +got):\n%s", diff)
+	}
+
+	if len(resultChunks) != 1 {
+		t.Fatalf("unexpected number of result chunks. want=%d have=%d", 1, len(resultChunks))
+	}
+	for id, documentIDRangeIDs := range resultChunks[0].ResultChunk.DocumentIDRangeIDs {
+		for _, documentIDRangeID := range documentIDRangeIDs {
+			path := resultChunks[0].ResultChunk.DocumentPaths[documentIDRangeID.DocumentID]
+
+			if id == "10" || id == "11" {
+				if path != "bar.go" {
+					t.Errorf("unexpected location of base result %s in %s", id, path)
+				}
+			} else if path != "foo.go" {
+				t.Errorf("unexpected location of new result %s in %s", id, path)
+			}
+		}
+	}
+	if _, ok := resultChunks[0].ResultChunk.DocumentIDRangeIDs["11"]; ok {
+		t.Errorf("expected base result without remaining locations to be removed")
+	}
+
+	expectedDefinitions := []precise.MonikerLocations{
+		{Scheme: "test", Identifier: "bar", Locations: []precise.LocationData{{URI: "bar.go"}}},
+	}
+	if diff := cmp.Diff(expectedDefinitions, definitions[:1]); diff != "" {
+		t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+	}
+	for _, monikerLocations := range definitions {
+		if monikerLocations.Scheme == "test" && monikerLocations.Identifier == "foo" {
+			t.Errorf("expected moniker without remaining locations to be removed")
+		}
+	}
+
+	if calls := mockDBStore.CopyPackagesAndReferencesFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of CopyPackagesAndReferences calls. want=%d have=%d", 1, len(calls))
+	} else if calls[0].Arg1 != 41 || calls[0].Arg2 != 42 {
+		t.Errorf("unexpected CopyPackagesAndReferences dump ids. want=%d,%d have=%d,%d", 41, 42, calls[0].Arg1, calls[0].Arg2)
+	}
+}
+
+func TestHandleIncrementalBaseNotProcessed(t *testing.T) {
+	setupRepoMocks(t)
+
+	baseUploadID := 41
+	upload := dbstore.Upload{
+		ID:           42,
+		Root:         "root/",
+		Commit:       "deadbeef",
+		RepositoryID: 50,
+		Indexer:      "scip-go",
+		BaseUploadID: &baseUploadID,
+	}
+
+	mockWorkerStore := NewMockWorkerStore()
+	mockDBStore := NewMockDBStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+	gitserverClient := NewMockGitserverClient()
+
+	mockDBStore.GetUploadByIDFunc.SetDefaultReturn(dbstore.Upload{ID: 41, State: "processing"}, true, nil)
+
+	handler := &handler{
+		dbStore:         mockDBStore,
+		workerStore:     mockWorkerStore,
+		uploadStore:     mockUploadStore,
+		gitserverClient: gitserverClient,
+	}
+
+	requeued, err := handler.handle(context.Background(), logtest.Scoped(t), upload, observation.TestTraceLogger(logtest.Scoped(t)))
+	if err != nil {
+		t.Fatalf("unexpected error handling upload: %s", err)
+	} else if !requeued {
+		t.Errorf("expected upload to be requeued")
+	}
+
+	if len(mockWorkerStore.RequeueFunc.History()) != 1 {
+		t.Errorf("unexpected number of Requeue calls. want=%d have=%d", 1, len(mockWorkerStore.RequeueFunc.History()))
+	}
+	if len(mockUploadStore.GetFunc.History()) != 0 {
+		t.Errorf("unexpected number of Get calls. want=%d have=%d", 0, len(mockUploadStore.GetFunc.History()))
+	}
+}
+
+func TestMergeResultChunk(t *testing.T) {
+	resultChunk := precise.ResultChunkData{
+		DocumentPaths: map[precise.ID]string{"1": "a.go", "2": "b.go", "3": "c.go"},
+		DocumentIDRangeIDs: map[precise.ID][]precise.DocumentIDRangeID{
+			"10": {{DocumentID: "1", RangeID: "100"}, {DocumentID: "2", RangeID: "200"}},
+			"11": {{DocumentID: "2", RangeID: "201"}},
+			"12": {{DocumentID: "3", RangeID: "300"}},
+		},
+	}
+	replacedPaths := map[string]struct{}{"b.go": {}}
+	results := map[precise.ID][]precise.DocumentPathRangeID{
+		"u42:10": {{Path: "b.go", RangeID: "5"}},
+	}
+
+	expected := precise.ResultChunkData{
+		DocumentPaths: map[precise.ID]string{"1": "a.go", "3": "c.go", "path:b.go": "b.go"},
+		DocumentIDRangeIDs: map[precise.ID][]precise.DocumentIDRangeID{
+			"10":     {{DocumentID: "1", RangeID: "100"}},
+			"12":     {{DocumentID: "3", RangeID: "300"}},
+			"u42:10": {{DocumentID: "path:b.go", RangeID: "5"}},
+		},
+	}
+	if diff := cmp.Diff(expected, mergeResultChunk(resultChunk, replacedPaths, results)); diff != "" {
+		t.Errorf("unexpected result chunk (-want +got):\n%s", diff)
+	}
+}
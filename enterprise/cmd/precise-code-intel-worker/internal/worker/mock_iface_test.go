@@ -22,12 +22,19 @@ import (
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/worker)
 // used for unit testing.
 type MockDBStore struct {
+	// CopyPackagesAndReferencesFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// CopyPackagesAndReferences.
+	CopyPackagesAndReferencesFunc *DBStoreCopyPackagesAndReferencesFunc
 	// DeleteOverlappingDumpsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteOverlappingDumps.
 	DeleteOverlappingDumpsFunc *DBStoreDeleteOverlappingDumpsFunc
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *DBStoreDoneFunc
+	// GetUploadByIDFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUploadByID.
+	GetUploadByIDFunc *DBStoreGetUploadByIDFunc
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *DBStoreHandleFunc
@@ -68,6 +75,11 @@ type MockDBStore struct {
 // return zero values for all results, unless overwritten.
 func NewMockDBStore() *MockDBStore {
 	return &MockDBStore{
+		CopyPackagesAndReferencesFunc: &DBStoreCopyPackagesAndReferencesFunc{
+			defaultHook: func(context.Context, int, int) (r0 error) {
+				return
+			},
+		},
 		DeleteOverlappingDumpsFunc: &DBStoreDeleteOverlappingDumpsFunc{
 			defaultHook: func(context.Context, int, string, string, string) (r0 error) {
 				return
@@ -78,6 +90,11 @@ func NewMockDBStore() *MockDBStore {
 				return
 			},
 		},
+		GetUploadByIDFunc: &DBStoreGetUploadByIDFunc{
+			defaultHook: func(context.Context, int) (r0 dbstore.Upload, r1 bool, r2 error) {
+				return
+			},
+		},
 		HandleFunc: &DBStoreHandleFunc{
 			defaultHook: func() (r0 *basestore.TransactableHandle) {
 				return
@@ -140,6 +157,11 @@ func NewMockDBStore() *MockDBStore {
 // methods panic on invocation, unless overwritten.
 func NewStrictMockDBStore() *MockDBStore {
 	return &MockDBStore{
+		CopyPackagesAndReferencesFunc: &DBStoreCopyPackagesAndReferencesFunc{
+			defaultHook: func(context.Context, int, int) error {
+				panic("unexpected invocation of MockDBStore.CopyPackagesAndReferences")
+			},
+		},
 		DeleteOverlappingDumpsFunc: &DBStoreDeleteOverlappingDumpsFunc{
 			defaultHook: func(context.Context, int, string, string, string) error {
 				panic("unexpected invocation of MockDBStore.DeleteOverlappingDumps")
@@ -150,6 +172,11 @@ func NewStrictMockDBStore() *MockDBStore {
 				panic("unexpected invocation of MockDBStore.Done")
 			},
 		},
+		GetUploadByIDFunc: &DBStoreGetUploadByIDFunc{
+			defaultHook: func(context.Context, int) (dbstore.Upload, bool, error) {
+				panic("unexpected invocation of MockDBStore.GetUploadByID")
+			},
+		},
 		HandleFunc: &DBStoreHandleFunc{
 			defaultHook: func() *basestore.TransactableHandle {
 				panic("unexpected invocation of MockDBStore.Handle")
@@ -212,12 +239,18 @@ func NewStrictMockDBStore() *MockDBStore {
 // methods delegate to the given implementation, unless overwritten.
 func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 	return &MockDBStore{
+		CopyPackagesAndReferencesFunc: &DBStoreCopyPackagesAndReferencesFunc{
+			defaultHook: i.CopyPackagesAndReferences,
+		},
 		DeleteOverlappingDumpsFunc: &DBStoreDeleteOverlappingDumpsFunc{
 			defaultHook: i.DeleteOverlappingDumps,
 		},
 		DoneFunc: &DBStoreDoneFunc{
 			defaultHook: i.Done,
 		},
+		GetUploadByIDFunc: &DBStoreGetUploadByIDFunc{
+			defaultHook: i.GetUploadByID,
+		},
 		HandleFunc: &DBStoreHandleFunc{
 			defaultHook: i.Handle,
 		},
@@ -254,6 +287,117 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 	}
 }
 
+// DBStoreCopyPackagesAndReferencesFunc describes the behavior when the
+// CopyPackagesAndReferences method of the parent MockDBStore instance is
+// invoked.
+type DBStoreCopyPackagesAndReferencesFunc struct {
+	defaultHook func(context.Context, int, int) error
+	hooks       []func(context.Context, int, int) error
+	history     []DBStoreCopyPackagesAndReferencesFuncCall
+	mutex       sync.Mutex
+}
+
+// CopyPackagesAndReferences delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockDBStore) CopyPackagesAndReferences(v0 context.Context, v1 int, v2 int) error {
+	r0 := m.CopyPackagesAndReferencesFunc.nextHook()(v0, v1, v2)
+	m.CopyPackagesAndReferencesFunc.appendCall(DBStoreCopyPackagesAndReferencesFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// CopyPackagesAndReferences method of the parent MockDBStore instance is
+// invoked and the hook queue is empty.
+func (f *DBStoreCopyPackagesAndReferencesFunc) SetDefaultHook(hook func(context.Context, int, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CopyPackagesAndReferences method of the parent MockDBStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *DBStoreCopyPackagesAndReferencesFunc) PushHook(hook func(context.Context, int, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBStoreCopyPackagesAndReferencesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBStoreCopyPackagesAndReferencesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, int) error {
+		return r0
+	})
+}
+
+func (f *DBStoreCopyPackagesAndReferencesFunc) nextHook() func(context.Context, int, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreCopyPackagesAndReferencesFunc) appendCall(r0 DBStoreCopyPackagesAndReferencesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreCopyPackagesAndReferencesFuncCall
+// objects describing the invocations of this function.
+func (f *DBStoreCopyPackagesAndReferencesFunc) History() []DBStoreCopyPackagesAndReferencesFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreCopyPackagesAndReferencesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreCopyPackagesAndReferencesFuncCall is an object that describes an
+// invocation of method CopyPackagesAndReferences on an instance of
+// MockDBStore.
+type DBStoreCopyPackagesAndReferencesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreCopyPackagesAndReferencesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreCopyPackagesAndReferencesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBStoreDeleteOverlappingDumpsFunc describes the behavior when the
 // DeleteOverlappingDumps method of the parent MockDBStore instance is
 // invoked.
@@ -471,6 +615,117 @@ func (c DBStoreDoneFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBStoreGetUploadByIDFunc describes the behavior when the GetUploadByID
+// method of the parent MockDBStore instance is invoked.
+type DBStoreGetUploadByIDFunc struct {
+	defaultHook func(context.Context, int) (dbstore.Upload, bool, error)
+	hooks       []func(context.Context, int) (dbstore.Upload, bool, error)
+	history     []DBStoreGetUploadByIDFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadByID delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDBStore) GetUploadByID(v0 context.Context, v1 int) (dbstore.Upload, bool, error) {
+	r0, r1, r2 := m.GetUploadByIDFunc.nextHook()(v0, v1)
+	m.GetUploadByIDFunc.appendCall(DBStoreGetUploadByIDFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetUploadByID method
+// of the parent MockDBStore instance is invoked and the hook queue is
+// empty.
+func (f *DBStoreGetUploadByIDFunc) SetDefaultHook(hook func(context.Context, int) (dbstore.Upload, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadByID method of the parent MockDBStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DBStoreGetUploadByIDFunc) PushHook(hook func(context.Context, int) (dbstore.Upload, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBStoreGetUploadByIDFunc) SetDefaultReturn(r0 dbstore.Upload, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (dbstore.Upload, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBStoreGetUploadByIDFunc) PushReturn(r0 dbstore.Upload, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (dbstore.Upload, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *DBStoreGetUploadByIDFunc) nextHook() func(context.Context, int) (dbstore.Upload, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreGetUploadByIDFunc) appendCall(r0 DBStoreGetUploadByIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreGetUploadByIDFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreGetUploadByIDFunc) History() []DBStoreGetUploadByIDFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreGetUploadByIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreGetUploadByIDFuncCall is an object that describes an invocation of
+// method GetUploadByID on an instance of MockDBStore.
+type DBStoreGetUploadByIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 dbstore.Upload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreGetUploadByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreGetUploadByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // DBStoreHandleFunc describes the behavior when the Handle method of the
 // parent MockDBStore instance is invoked.
 type DBStoreHandleFunc struct {
@@ -2211,9 +2466,27 @@ func (c GitserverClientResolveRevisionFuncCall) Results() []interface{} {
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/precise-code-intel-worker/internal/worker)
 // used for unit testing.
 type MockLSIFStore struct {
+	// CopyDocumentsFunc is an instance of a mock function object
+	// controlling the behavior of the method CopyDocuments.
+	CopyDocumentsFunc *LSIFStoreCopyDocumentsFunc
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *LSIFStoreDoneFunc
+	// ReadMetaFunc is an instance of a mock function object controlling the
+	// behavior of the method ReadMeta.
+	ReadMetaFunc *LSIFStoreReadMetaFunc
+	// ScanDefinitionsFunc is an instance of a mock function object
+	// controlling the behavior of the method ScanDefinitions.
+	ScanDefinitionsFunc *LSIFStoreScanDefinitionsFunc
+	// ScanImplementationsFunc is an instance of a mock function object
+	// controlling the behavior of the method ScanImplementations.
+	ScanImplementationsFunc *LSIFStoreScanImplementationsFunc
+	// ScanReferencesFunc is an instance of a mock function object
+	// controlling the behavior of the method ScanReferences.
+	ScanReferencesFunc *LSIFStoreScanReferencesFunc
+	// ScanResultChunksFunc is an instance of a mock function object
+	// controlling the behavior of the method ScanResultChunks.
+	ScanResultChunksFunc *LSIFStoreScanResultChunksFunc
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *LSIFStoreTransactFunc
@@ -2241,11 +2514,41 @@ type MockLSIFStore struct {
 // methods return zero values for all results, unless overwritten.
 func NewMockLSIFStore() *MockLSIFStore {
 	return &MockLSIFStore{
+		CopyDocumentsFunc: &LSIFStoreCopyDocumentsFunc{
+			defaultHook: func(context.Context, int, int, []string) (r0 uint32, r1 error) {
+				return
+			},
+		},
 		DoneFunc: &LSIFStoreDoneFunc{
 			defaultHook: func(error) (r0 error) {
 				return
 			},
 		},
+		ReadMetaFunc: &LSIFStoreReadMetaFunc{
+			defaultHook: func(context.Context, int) (r0 precise.MetaData, r1 bool, r2 error) {
+				return
+			},
+		},
+		ScanDefinitionsFunc: &LSIFStoreScanDefinitionsFunc{
+			defaultHook: func(context.Context, int, func(monikerLocations precise.MonikerLocations)) (r0 error) {
+				return
+			},
+		},
+		ScanImplementationsFunc: &LSIFStoreScanImplementationsFunc{
+			defaultHook: func(context.Context, int, func(monikerLocations precise.MonikerLocations)) (r0 error) {
+				return
+			},
+		},
+		ScanReferencesFunc: &LSIFStoreScanReferencesFunc{
+			defaultHook: func(context.Context, int, func(monikerLocations precise.MonikerLocations)) (r0 error) {
+				return
+			},
+		},
+		ScanResultChunksFunc: &LSIFStoreScanResultChunksFunc{
+			defaultHook: func(context.Context, int, func(index int, resultChunk precise.ResultChunkData)) (r0 error) {
+				return
+			},
+		},
 		TransactFunc: &LSIFStoreTransactFunc{
 			defaultHook: func(context.Context) (r0 LSIFStore, r1 error) {
 				return
@@ -2288,11 +2591,41 @@ func NewMockLSIFStore() *MockLSIFStore {
 // methods panic on invocation, unless overwritten.
 func NewStrictMockLSIFStore() *MockLSIFStore {
 	return &MockLSIFStore{
+		CopyDocumentsFunc: &LSIFStoreCopyDocumentsFunc{
+			defaultHook: func(context.Context, int, int, []string) (uint32, error) {
+				panic("unexpected invocation of MockLSIFStore.CopyDocuments")
+			},
+		},
 		DoneFunc: &LSIFStoreDoneFunc{
 			defaultHook: func(error) error {
 				panic("unexpected invocation of MockLSIFStore.Done")
 			},
 		},
+		ReadMetaFunc: &LSIFStoreReadMetaFunc{
+			defaultHook: func(context.Context, int) (precise.MetaData, bool, error) {
+				panic("unexpected invocation of MockLSIFStore.ReadMeta")
+			},
+		},
+		ScanDefinitionsFunc: &LSIFStoreScanDefinitionsFunc{
+			defaultHook: func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error {
+				panic("unexpected invocation of MockLSIFStore.ScanDefinitions")
+			},
+		},
+		ScanImplementationsFunc: &LSIFStoreScanImplementationsFunc{
+			defaultHook: func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error {
+				panic("unexpected invocation of MockLSIFStore.ScanImplementations")
+			},
+		},
+		ScanReferencesFunc: &LSIFStoreScanReferencesFunc{
+			defaultHook: func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error {
+				panic("unexpected invocation of MockLSIFStore.ScanReferences")
+			},
+		},
+		ScanResultChunksFunc: &LSIFStoreScanResultChunksFunc{
+			defaultHook: func(context.Context, int, func(index int, resultChunk precise.ResultChunkData)) error {
+				panic("unexpected invocation of MockLSIFStore.ScanResultChunks")
+			},
+		},
 		TransactFunc: &LSIFStoreTransactFunc{
 			defaultHook: func(context.Context) (LSIFStore, error) {
 				panic("unexpected invocation of MockLSIFStore.Transact")
@@ -2335,9 +2668,27 @@ func NewStrictMockLSIFStore() *MockLSIFStore {
 // All methods delegate to the given implementation, unless overwritten.
 func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
 	return &MockLSIFStore{
+		CopyDocumentsFunc: &LSIFStoreCopyDocumentsFunc{
+			defaultHook: i.CopyDocuments,
+		},
 		DoneFunc: &LSIFStoreDoneFunc{
 			defaultHook: i.Done,
 		},
+		ReadMetaFunc: &LSIFStoreReadMetaFunc{
+			defaultHook: i.ReadMeta,
+		},
+		ScanDefinitionsFunc: &LSIFStoreScanDefinitionsFunc{
+			defaultHook: i.ScanDefinitions,
+		},
+		ScanImplementationsFunc: &LSIFStoreScanImplementationsFunc{
+			defaultHook: i.ScanImplementations,
+		},
+		ScanReferencesFunc: &LSIFStoreScanReferencesFunc{
+			defaultHook: i.ScanReferences,
+		},
+		ScanResultChunksFunc: &LSIFStoreScanResultChunksFunc{
+			defaultHook: i.ScanResultChunks,
+		},
 		TransactFunc: &LSIFStoreTransactFunc{
 			defaultHook: i.Transact,
 		},
@@ -2362,34 +2713,35 @@ func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
 	}
 }
 
-// LSIFStoreDoneFunc describes the behavior when the Done method of the
-// parent MockLSIFStore instance is invoked.
-type LSIFStoreDoneFunc struct {
-	defaultHook func(error) error
-	hooks       []func(error) error
-	history     []LSIFStoreDoneFuncCall
+// LSIFStoreCopyDocumentsFunc describes the behavior when the CopyDocuments
+// method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreCopyDocumentsFunc struct {
+	defaultHook func(context.Context, int, int, []string) (uint32, error)
+	hooks       []func(context.Context, int, int, []string) (uint32, error)
+	history     []LSIFStoreCopyDocumentsFuncCall
 	mutex       sync.Mutex
 }
 
-// Done delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockLSIFStore) Done(v0 error) error {
-	r0 := m.DoneFunc.nextHook()(v0)
-	m.DoneFunc.appendCall(LSIFStoreDoneFuncCall{v0, r0})
-	return r0
+// CopyDocuments delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockLSIFStore) CopyDocuments(v0 context.Context, v1 int, v2 int, v3 []string) (uint32, error) {
+	r0, r1 := m.CopyDocumentsFunc.nextHook()(v0, v1, v2, v3)
+	m.CopyDocumentsFunc.appendCall(LSIFStoreCopyDocumentsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the Done method of the
-// parent MockLSIFStore instance is invoked and the hook queue is empty.
-func (f *LSIFStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+// SetDefaultHook sets function that is called when the CopyDocuments method
+// of the parent MockLSIFStore instance is invoked and the hook queue is
+// empty.
+func (f *LSIFStoreCopyDocumentsFunc) SetDefaultHook(hook func(context.Context, int, int, []string) (uint32, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// Done method of the parent MockLSIFStore instance invokes the hook at the
-// front of the queue and discards it. After the queue is empty, the default
-// hook function is invoked for any future action.
-func (f *LSIFStoreDoneFunc) PushHook(hook func(error) error) {
+// CopyDocuments method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreCopyDocumentsFunc) PushHook(hook func(context.Context, int, int, []string) (uint32, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2397,20 +2749,20 @@ func (f *LSIFStoreDoneFunc) PushHook(hook func(error) error) {
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *LSIFStoreDoneFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(error) error {
-		return r0
+func (f *LSIFStoreCopyDocumentsFunc) SetDefaultReturn(r0 uint32, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, int, []string) (uint32, error) {
+		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *LSIFStoreDoneFunc) PushReturn(r0 error) {
-	f.PushHook(func(error) error {
-		return r0
+func (f *LSIFStoreCopyDocumentsFunc) PushReturn(r0 uint32, r1 error) {
+	f.PushHook(func(context.Context, int, int, []string) (uint32, error) {
+		return r0, r1
 	})
 }
 
-func (f *LSIFStoreDoneFunc) nextHook() func(error) error {
+func (f *LSIFStoreCopyDocumentsFunc) nextHook() func(context.Context, int, int, []string) (uint32, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2423,34 +2775,147 @@ func (f *LSIFStoreDoneFunc) nextHook() func(error) error {
 	return hook
 }
 
-func (f *LSIFStoreDoneFunc) appendCall(r0 LSIFStoreDoneFuncCall) {
+func (f *LSIFStoreCopyDocumentsFunc) appendCall(r0 LSIFStoreCopyDocumentsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of LSIFStoreDoneFuncCall objects describing
-// the invocations of this function.
-func (f *LSIFStoreDoneFunc) History() []LSIFStoreDoneFuncCall {
+// History returns a sequence of LSIFStoreCopyDocumentsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreCopyDocumentsFunc) History() []LSIFStoreCopyDocumentsFuncCall {
 	f.mutex.Lock()
-	history := make([]LSIFStoreDoneFuncCall, len(f.history))
+	history := make([]LSIFStoreCopyDocumentsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// LSIFStoreDoneFuncCall is an object that describes an invocation of method
-// Done on an instance of MockLSIFStore.
-type LSIFStoreDoneFuncCall struct {
+// LSIFStoreCopyDocumentsFuncCall is an object that describes an invocation
+// of method CopyDocuments on an instance of MockLSIFStore.
+type LSIFStoreCopyDocumentsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
-	Arg0 error
-	// Result0 is the value of the 1st result returned from this method
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Result0 error
-}
-
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 uint32
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreCopyDocumentsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreCopyDocumentsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// LSIFStoreDoneFunc describes the behavior when the Done method of the
+// parent MockLSIFStore instance is invoked.
+type LSIFStoreDoneFunc struct {
+	defaultHook func(error) error
+	hooks       []func(error) error
+	history     []LSIFStoreDoneFuncCall
+	mutex       sync.Mutex
+}
+
+// Done delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockLSIFStore) Done(v0 error) error {
+	r0 := m.DoneFunc.nextHook()(v0)
+	m.DoneFunc.appendCall(LSIFStoreDoneFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Done method of the
+// parent MockLSIFStore instance is invoked and the hook queue is empty.
+func (f *LSIFStoreDoneFunc) SetDefaultHook(hook func(error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Done method of the parent MockLSIFStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *LSIFStoreDoneFunc) PushHook(hook func(error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreDoneFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreDoneFunc) PushReturn(r0 error) {
+	f.PushHook(func(error) error {
+		return r0
+	})
+}
+
+func (f *LSIFStoreDoneFunc) nextHook() func(error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreDoneFunc) appendCall(r0 LSIFStoreDoneFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreDoneFuncCall objects describing
+// the invocations of this function.
+func (f *LSIFStoreDoneFunc) History() []LSIFStoreDoneFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreDoneFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreDoneFuncCall is an object that describes an invocation of method
+// Done on an instance of MockLSIFStore.
+type LSIFStoreDoneFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c LSIFStoreDoneFuncCall) Args() []interface{} {
@@ -2463,6 +2928,549 @@ func (c LSIFStoreDoneFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// LSIFStoreReadMetaFunc describes the behavior when the ReadMeta method of
+// the parent MockLSIFStore instance is invoked.
+type LSIFStoreReadMetaFunc struct {
+	defaultHook func(context.Context, int) (precise.MetaData, bool, error)
+	hooks       []func(context.Context, int) (precise.MetaData, bool, error)
+	history     []LSIFStoreReadMetaFuncCall
+	mutex       sync.Mutex
+}
+
+// ReadMeta delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockLSIFStore) ReadMeta(v0 context.Context, v1 int) (precise.MetaData, bool, error) {
+	r0, r1, r2 := m.ReadMetaFunc.nextHook()(v0, v1)
+	m.ReadMetaFunc.appendCall(LSIFStoreReadMetaFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the ReadMeta method of
+// the parent MockLSIFStore instance is invoked and the hook queue is empty.
+func (f *LSIFStoreReadMetaFunc) SetDefaultHook(hook func(context.Context, int) (precise.MetaData, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReadMeta method of the parent MockLSIFStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *LSIFStoreReadMetaFunc) PushHook(hook func(context.Context, int) (precise.MetaData, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreReadMetaFunc) SetDefaultReturn(r0 precise.MetaData, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (precise.MetaData, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreReadMetaFunc) PushReturn(r0 precise.MetaData, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (precise.MetaData, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *LSIFStoreReadMetaFunc) nextHook() func(context.Context, int) (precise.MetaData, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreReadMetaFunc) appendCall(r0 LSIFStoreReadMetaFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreReadMetaFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreReadMetaFunc) History() []LSIFStoreReadMetaFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreReadMetaFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreReadMetaFuncCall is an object that describes an invocation of
+// method ReadMeta on an instance of MockLSIFStore.
+type LSIFStoreReadMetaFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 precise.MetaData
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreReadMetaFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreReadMetaFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// LSIFStoreScanDefinitionsFunc describes the behavior when the
+// ScanDefinitions method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreScanDefinitionsFunc struct {
+	defaultHook func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error
+	hooks       []func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error
+	history     []LSIFStoreScanDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// ScanDefinitions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) ScanDefinitions(v0 context.Context, v1 int, v2 func(monikerLocations precise.MonikerLocations)) error {
+	r0 := m.ScanDefinitionsFunc.nextHook()(v0, v1, v2)
+	m.ScanDefinitionsFunc.appendCall(LSIFStoreScanDefinitionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ScanDefinitions
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreScanDefinitionsFunc) SetDefaultHook(hook func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ScanDefinitions method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreScanDefinitionsFunc) PushHook(hook func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreScanDefinitionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreScanDefinitionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error {
+		return r0
+	})
+}
+
+func (f *LSIFStoreScanDefinitionsFunc) nextHook() func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreScanDefinitionsFunc) appendCall(r0 LSIFStoreScanDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreScanDefinitionsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreScanDefinitionsFunc) History() []LSIFStoreScanDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreScanDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreScanDefinitionsFuncCall is an object that describes an
+// invocation of method ScanDefinitions on an instance of MockLSIFStore.
+type LSIFStoreScanDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 func(monikerLocations precise.MonikerLocations)
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreScanDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreScanDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// LSIFStoreScanImplementationsFunc describes the behavior when the
+// ScanImplementations method of the parent MockLSIFStore instance is
+// invoked.
+type LSIFStoreScanImplementationsFunc struct {
+	defaultHook func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error
+	hooks       []func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error
+	history     []LSIFStoreScanImplementationsFuncCall
+	mutex       sync.Mutex
+}
+
+// ScanImplementations delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) ScanImplementations(v0 context.Context, v1 int, v2 func(monikerLocations precise.MonikerLocations)) error {
+	r0 := m.ScanImplementationsFunc.nextHook()(v0, v1, v2)
+	m.ScanImplementationsFunc.appendCall(LSIFStoreScanImplementationsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ScanImplementations
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreScanImplementationsFunc) SetDefaultHook(hook func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ScanImplementations method of the parent MockLSIFStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *LSIFStoreScanImplementationsFunc) PushHook(hook func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreScanImplementationsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreScanImplementationsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error {
+		return r0
+	})
+}
+
+func (f *LSIFStoreScanImplementationsFunc) nextHook() func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreScanImplementationsFunc) appendCall(r0 LSIFStoreScanImplementationsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreScanImplementationsFuncCall
+// objects describing the invocations of this function.
+func (f *LSIFStoreScanImplementationsFunc) History() []LSIFStoreScanImplementationsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreScanImplementationsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreScanImplementationsFuncCall is an object that describes an
+// invocation of method ScanImplementations on an instance of MockLSIFStore.
+type LSIFStoreScanImplementationsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 func(monikerLocations precise.MonikerLocations)
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreScanImplementationsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreScanImplementationsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// LSIFStoreScanReferencesFunc describes the behavior when the
+// ScanReferences method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreScanReferencesFunc struct {
+	defaultHook func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error
+	hooks       []func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error
+	history     []LSIFStoreScanReferencesFuncCall
+	mutex       sync.Mutex
+}
+
+// ScanReferences delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) ScanReferences(v0 context.Context, v1 int, v2 func(monikerLocations precise.MonikerLocations)) error {
+	r0 := m.ScanReferencesFunc.nextHook()(v0, v1, v2)
+	m.ScanReferencesFunc.appendCall(LSIFStoreScanReferencesFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ScanReferences
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreScanReferencesFunc) SetDefaultHook(hook func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ScanReferences method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreScanReferencesFunc) PushHook(hook func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreScanReferencesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreScanReferencesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error {
+		return r0
+	})
+}
+
+func (f *LSIFStoreScanReferencesFunc) nextHook() func(context.Context, int, func(monikerLocations precise.MonikerLocations)) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreScanReferencesFunc) appendCall(r0 LSIFStoreScanReferencesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreScanReferencesFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreScanReferencesFunc) History() []LSIFStoreScanReferencesFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreScanReferencesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreScanReferencesFuncCall is an object that describes an invocation
+// of method ScanReferences on an instance of MockLSIFStore.
+type LSIFStoreScanReferencesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 func(monikerLocations precise.MonikerLocations)
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreScanReferencesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreScanReferencesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// LSIFStoreScanResultChunksFunc describes the behavior when the
+// ScanResultChunks method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreScanResultChunksFunc struct {
+	defaultHook func(context.Context, int, func(index int, resultChunk precise.ResultChunkData)) error
+	hooks       []func(context.Context, int, func(index int, resultChunk precise.ResultChunkData)) error
+	history     []LSIFStoreScanResultChunksFuncCall
+	mutex       sync.Mutex
+}
+
+// ScanResultChunks delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) ScanResultChunks(v0 context.Context, v1 int, v2 func(index int, resultChunk precise.ResultChunkData)) error {
+	r0 := m.ScanResultChunksFunc.nextHook()(v0, v1, v2)
+	m.ScanResultChunksFunc.appendCall(LSIFStoreScanResultChunksFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ScanResultChunks
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreScanResultChunksFunc) SetDefaultHook(hook func(context.Context, int, func(index int, resultChunk precise.ResultChunkData)) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ScanResultChunks method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreScanResultChunksFunc) PushHook(hook func(context.Context, int, func(index int, resultChunk precise.ResultChunkData)) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreScanResultChunksFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, func(index int, resultChunk precise.ResultChunkData)) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreScanResultChunksFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, func(index int, resultChunk precise.ResultChunkData)) error {
+		return r0
+	})
+}
+
+func (f *LSIFStoreScanResultChunksFunc) nextHook() func(context.Context, int, func(index int, resultChunk precise.ResultChunkData)) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreScanResultChunksFunc) appendCall(r0 LSIFStoreScanResultChunksFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreScanResultChunksFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreScanResultChunksFunc) History() []LSIFStoreScanResultChunksFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreScanResultChunksFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreScanResultChunksFuncCall is an object that describes an
+// invocation of method ScanResultChunks on an instance of MockLSIFStore.
+type LSIFStoreScanResultChunksFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 func(index int, resultChunk precise.ResultChunkData)
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreScanResultChunksFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreScanResultChunksFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // LSIFStoreTransactFunc describes the behavior when the Transact method of
 // the parent MockLSIFStore instance is invoked.
 type LSIFStoreTransactFunc struct {
@@ -140,8 +140,18 @@ func main() {
 		Handler:      httpserver.NewHandler(nil),
 	})
 
+	routines := []goroutine.BackgroundRoutine{worker, server}
+	if config.LSIFUploadStoreConfig.RequiresExpiration() {
+		expirer, ok := uploadStore.(uploadstore.Expirer)
+		if !ok {
+			logger.Fatal("Upload store does not support expiring objects")
+		}
+
+		routines = append(routines, newUploadStoreExpirer(expirer, config.LSIFUploadStoreConfig.TTL, config.UploadStoreExpirationInterval))
+	}
+
 	// Go!
-	goroutine.MonitorBackgroundRoutines(context.Background(), worker, server)
+	goroutine.MonitorBackgroundRoutines(context.Background(), routines...)
 }
 
 func mustInitializeDB() *sql.DB {
@@ -212,6 +222,14 @@ func initializeUploadStore(ctx context.Context, uploadStore uploadstore.Store) e
 	}
 }
 
+// newUploadStoreExpirer returns a background routine that periodically removes uploads older
+// than the given TTL from upload stores that cannot be configured to expire objects natively.
+func newUploadStoreExpirer(expirer uploadstore.Expirer, ttl, interval time.Duration) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), interval, goroutine.NewHandlerWithErrorMessage("expire uploads", func(ctx context.Context) error {
+		return expirer.ExpireObjects(ctx, "", ttl)
+	}))
+}
+
 func isRequestError(err error) bool {
 	return errors.HasType(err, &smithyhttp.RequestSendError{})
 }
@@ -4,6 +4,7 @@ import (
 	"container/heap"
 	"context"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -25,6 +26,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
@@ -294,6 +296,68 @@ func (s *PermsSyncer) maybeRefreshGitLabOAuthTokenFromAccount(ctx context.Contex
 	return nil
 }
 
+func oauth2ConfigFromBitbucketCloudProvider(p *schema.BitbucketCloudAuthProvider, baseURL *url.URL) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.ClientKey,
+		ClientSecret: p.ClientSecret,
+		Endpoint:     bitbucketcloud.OAuthEndpoint(baseURL),
+		Scopes:       bitbucketcloud.RequestedOAuthScopes,
+	}
+}
+
+// maybeRefreshBitbucketCloudOAuthTokenFromAccount refreshes the OAuth token of
+// a Bitbucket Cloud external account, which expires after two hours, so that
+// the permissions of the user can be fetched with it.
+func (s *PermsSyncer) maybeRefreshBitbucketCloudOAuthTokenFromAccount(ctx context.Context, acct *extsvc.Account) (err error) {
+	if acct.ServiceType != extsvc.TypeBitbucketCloud {
+		return nil
+	}
+
+	var oauthConfig *oauth2.Config
+	for _, authProvider := range conf.SiteConfig().AuthProviders {
+		if authProvider.Bitbucketcloud == nil {
+			continue
+		}
+		rawURL := authProvider.Bitbucketcloud.Url
+		if rawURL == "" {
+			rawURL = "https://bitbucket.org/"
+		}
+		baseURL, err := url.Parse(rawURL)
+		if err != nil || extsvc.NormalizeBaseURL(baseURL).String() != acct.ServiceID {
+			continue
+		}
+		oauthConfig = oauth2ConfigFromBitbucketCloudProvider(authProvider.Bitbucketcloud, baseURL)
+		break
+	}
+	if oauthConfig == nil {
+		log15.Warn("PermsSyncer.maybeRefreshBitbucketCloudOAuthTokenFromAccount, external account has no auth.provider",
+			"externalAccountID", acct.ID,
+		)
+		return nil
+	}
+
+	_, tok, err := bitbucketcloud.GetExternalAccountData(&acct.AccountData)
+	if err != nil {
+		return errors.Wrap(err, "get external account data")
+	} else if tok == nil {
+		return errors.New("no token found in the external account data")
+	}
+
+	refreshedToken, err := oauthConfig.TokenSource(ctx, tok).Token()
+	if err != nil {
+		return errors.Wrap(err, "refresh token")
+	}
+
+	if refreshedToken.AccessToken != tok.AccessToken {
+		acct.AccountData.SetAuthData(refreshedToken)
+		_, err := s.db.UserExternalAccounts().LookupUserAndSave(ctx, acct.AccountSpec, acct.AccountData)
+		if err != nil {
+			return errors.Wrap(err, "save refreshed token")
+		}
+	}
+	return nil
+}
+
 // fetchUserPermsViaExternalAccounts uses external accounts (aka. login
 // connections) to list all accessible private repositories on code hosts for
 // the given user.
@@ -684,6 +748,9 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 		if err := s.maybeRefreshGitLabOAuthTokenFromAccount(ctx, acct); err != nil {
 			return errors.Wrap(err, "refreshing GitLab OAuth token for account")
 		}
+		if err := s.maybeRefreshBitbucketCloudOAuthTokenFromAccount(ctx, acct); err != nil {
+			return errors.Wrap(err, "refreshing Bitbucket Cloud OAuth token for account")
+		}
 	}
 
 	// NOTE: If a <repo_id, user_id> pair is present in the external_service_repos
@@ -937,3 +937,117 @@ func TestPermsSyncer_maybeRefreshGitLabOAuthTokenFromAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestPermsSyncer_maybeRefreshBitbucketCloudOAuthTokenFromAccount(t *testing.T) {
+	tests := []struct {
+		name    string
+		expired bool
+	}{
+		{
+			name:    "Expired token should be updated",
+			expired: true,
+		},
+		{
+			name:    "Not expired token should not be updated",
+			expired: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var databaseHit bool
+			var httpServerHit bool
+
+			// perms syncer mocking
+			db := database.NewMockDB()
+			externalAccounts := database.NewMockUserExternalAccountsStore()
+			externalAccounts.LookupUserAndSaveFunc.SetDefaultHook(func(ctx context.Context, spec extsvc.AccountSpec, data extsvc.AccountData) (int32, error) {
+				databaseHit = true
+				return 0, nil
+			})
+			db.UserExternalAccountsFunc.SetDefaultReturn(externalAccounts)
+
+			s := NewPermsSyncer(db, nil, nil, timeutil.Now, nil)
+
+			// http mocking
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/site/oauth2/access_token" {
+					t.Errorf("Expected to request '/site/oauth2/access_token', got: %s", r.URL.Path)
+				}
+				httpServerHit = true
+				w.Header().Set("Content-Type", "application/json")
+				refreshedToken := json.RawMessage(fmt.Sprintf(`
+		{
+			"access_token":"cafebabea66306277915a6919a90ac7972853317d9df385a828b17d9200b7d4c",
+			"token_type":"Bearer",
+			"refresh_token":"cafebabe251f4c2295494ee29b6b66f7011dad92251ab988a376a23ef12ad041",
+			"expiry":"%s"
+		}`,
+					time.Now().Add(2*time.Hour).Format(time.RFC3339)))
+				w.Write(refreshedToken)
+			}))
+			t.Cleanup(func() { server.Close() })
+
+			// conf mocking
+			conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				AuthProviders: []schema.AuthProviders{
+					{
+						Bitbucketcloud: &schema.BitbucketCloudAuthProvider{
+							ClientKey:    "clientId",
+							ClientSecret: "clientSecret",
+							Url:          fmt.Sprintf("%s/", server.URL),
+						},
+					},
+				},
+			}})
+			t.Cleanup(func() { conf.Mock(nil) })
+
+			// test data mocking
+			var expiry string
+			if test.expired {
+				expiry = time.Now().Add(-time.Hour).Format(time.RFC3339)
+			} else {
+				expiry = time.Now().Add(time.Hour).Format(time.RFC3339)
+			}
+			authData := json.RawMessage(fmt.Sprintf(`
+				{
+					"access_token":"9cc46dcda66306277915a6919a90ac7972853317d9df385a828b17d9200b7d4c",
+					"token_type":"Bearer",
+					"refresh_token":"5fa56e21251f4c2295494ee29b6b66f7011dad92251ab988a376a23ef12ad041",
+					"expiry":"%s"
+				}`,
+				expiry))
+			data := json.RawMessage(`{}`)
+			accountData := extsvc.AccountData{
+				AuthData: &authData,
+				Data:     &data,
+			}
+
+			extAccount := &extsvc.Account{
+				ID:     0,
+				UserID: 0,
+				AccountSpec: extsvc.AccountSpec{
+					ServiceType: extsvc.TypeBitbucketCloud,
+					ServiceID:   fmt.Sprintf("%s/", server.URL),
+					ClientID:    "clientId",
+					AccountID:   "accountId",
+				},
+				AccountData: accountData,
+			}
+
+			err := s.maybeRefreshBitbucketCloudOAuthTokenFromAccount(context.Background(), extAccount)
+			if err != nil {
+				t.Error(err)
+			}
+
+			// When token is expired, DB and HTTP server should be hit (for token update)
+			want := test.expired
+			if want != databaseHit {
+				t.Errorf("Database hit:\ngot: %v\nwant: %v", databaseHit, want)
+			}
+			if want != httpServerHit {
+				t.Errorf("HTTP Server hit:\ngot: %v\nwant: %v", httpServerHit, want)
+			}
+		})
+	}
+}
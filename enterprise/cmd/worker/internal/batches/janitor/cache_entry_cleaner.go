@@ -15,6 +15,12 @@ var maxCacheEntriesSize = env.MustGetInt(
 	"Maximum size of the batch_spec_execution_cache_entries.value column. Value is megabytes.",
 )
 
+var maxStepCacheEntriesSize = env.MustGetInt(
+	"SRC_BATCH_CHANGES_MAX_STEP_CACHE_SIZE_MB",
+	5000,
+	"Maximum size of the batch_step_cache_entries.value column. Value is megabytes.",
+)
+
 const cacheCleanInterval = 1 * time.Hour
 
 func NewCacheEntryCleaner(ctx context.Context, s *store.Store) goroutine.BackgroundRoutine {
@@ -28,3 +34,15 @@ func NewCacheEntryCleaner(ctx context.Context, s *store.Store) goroutine.Backgro
 		}),
 	)
 }
+
+func NewStepCacheEntryCleaner(ctx context.Context, s *store.Store) goroutine.BackgroundRoutine {
+	maxSizeByte := int64(maxStepCacheEntriesSize * 1024 * 1024)
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		cacheCleanInterval,
+		goroutine.NewHandlerWithErrorMessage("cleaning up LRU batch step cache entries", func(ctx context.Context) error {
+			return s.CleanBatchStepCacheEntries(ctx, maxSizeByte)
+		}),
+	)
+}
@@ -90,6 +90,7 @@ func (j *janitorJob) Routines(_ context.Context, logger log.Logger) ([]goroutine
 
 		janitor.NewSpecExpirer(workCtx, bstore),
 		janitor.NewCacheEntryCleaner(workCtx, bstore),
+		janitor.NewStepCacheEntryCleaner(workCtx, bstore),
 	}
 
 	return routines, nil
@@ -4,10 +4,14 @@ import (
 	"context"
 
 	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/reconciler"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/scheduler"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/lib/log"
 )
 
@@ -35,6 +39,11 @@ func (j *schedulerJob) Routines(_ context.Context, logger log.Logger) ([]gorouti
 
 	routines := []goroutine.BackgroundRoutine{
 		scheduler.NewScheduler(workCtx, bstore),
+		reconciler.NewDependencyGate(workCtx, bstore),
+		reconciler.NewRebaser(workCtx, bstore, gitserver.NewClient(bstore.DatabaseDB())),
+		scheduler.NewMergeTrainScheduler(workCtx, bstore),
+		scheduler.NewChangesetQueryImporter(workCtx, bstore, sources.NewSourcer(httpcli.NewExternalClientFactory())),
+		scheduler.NewChangesetReviewReminder(workCtx, bstore),
 	}
 
 	return routines, nil
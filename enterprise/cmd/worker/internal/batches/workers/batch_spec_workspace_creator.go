@@ -12,9 +12,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	codeownerslib "github.com/sourcegraph/sourcegraph/lib/batches/codeowners"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution/cache"
 	"github.com/sourcegraph/sourcegraph/lib/batches/template"
@@ -47,7 +50,14 @@ type workspaceCacheKey struct {
 	dbWorkspace   *btypes.BatchSpecWorkspace
 	repo          batcheslib.Repository
 	stepCacheKeys []string
-	skippedSteps  map[int32]struct{}
+	// globalStepCacheKeys holds the key in the step cache that is shared across
+	// batch changes for each entry in stepCacheKeys, or an empty string if the
+	// step result can't be shared.
+	globalStepCacheKeys []string
+	skippedSteps        map[int32]struct{}
+	// codeOwners are the CODEOWNERS rules of the repository, if the workspace
+	// was split off for a code owner.
+	codeOwners *codeownerslib.Ruleset
 }
 
 func (r *batchSpecWorkspaceCreator) process(
@@ -64,6 +74,7 @@ func (r *batchSpecWorkspaceCreator) process(
 	evaluatableSpec, err := batcheslib.ParseBatchSpec([]byte(spec.RawSpec), batcheslib.ParseBatchSpecOptions{
 		AllowTransformChanges: true,
 		AllowConditionalExec:  true,
+		AllowArtifacts:        conf.Get().BatchChangesEnableArtifacts,
 		// We don't allow forwarding of environment variables in server-side
 		// batch changes, since we'd then leak the executor/Firecracker
 		// internal environment.
@@ -99,6 +110,7 @@ func (r *batchSpecWorkspaceCreator) process(
 			Path:               w.Path,
 			FileMatches:        w.FileMatches,
 			OnlyFetchWorkspace: w.OnlyFetchWorkspace,
+			Owner:              w.Owner,
 
 			Unsupported: w.Unsupported,
 			Ignored:     w.Ignored,
@@ -146,34 +158,55 @@ func (r *batchSpecWorkspaceCreator) process(
 		}
 
 		stepCacheKeys := make([]string, 0, len(spec.Spec.Steps))
+		globalStepCacheKeys := make([]string, 0, len(spec.Spec.Steps))
 		// Generate cache keys for all the step results as well.
 		for i := 0; i < len(spec.Spec.Steps)-1; i++ {
 			if _, ok := skippedSteps[int32(i)]; ok {
 				continue
 			}
-			key := cache.StepsCacheKey{ExecutionKey: &key, StepIndex: i}
-			rawStepKey, err := key.Key()
+			stepKey := cache.StepsCacheKey{ExecutionKey: &key, StepIndex: i}
+			rawStepKey, err := stepKey.Key()
 			if err != nil {
 				return nil
 			}
 			stepCacheKeys = append(stepCacheKeys, rawStepKey)
+
+			var rawGlobalStepKey string
+			if globalStepKey := (cache.GlobalStepsCacheKey{ExecutionKey: &key, StepIndex: i}); globalStepKey.Cacheable() {
+				rawGlobalStepKey, err = globalStepKey.Key()
+				if err != nil {
+					return err
+				}
+			}
+			globalStepCacheKeys = append(globalStepCacheKeys, rawGlobalStepKey)
 		}
 
 		cacheKeyWorkspaces[rawKey] = workspaceCacheKey{
-			dbWorkspace:   workspace,
-			repo:          r,
-			stepCacheKeys: stepCacheKeys,
-			skippedSteps:  skippedSteps,
+			dbWorkspace:         workspace,
+			repo:                r,
+			stepCacheKeys:       stepCacheKeys,
+			globalStepCacheKeys: globalStepCacheKeys,
+			skippedSteps:        skippedSteps,
+			codeOwners:          w.CodeOwners,
 		}
 	}
 
 	// Fetch all cache entries by their keys.
 	cacheKeys := make([]string, 0, len(cacheKeyWorkspaces))
 	stepCacheKeys := make([]string, 0, len(cacheKeyWorkspaces))
+	globalStepCacheKeys := make([]string, 0, len(cacheKeyWorkspaces))
+	globalStepCacheRepoIDs := make([]api.RepoID, 0, len(cacheKeyWorkspaces))
 	for key, w := range cacheKeyWorkspaces {
 		cacheKeys = append(cacheKeys, key)
 
 		stepCacheKeys = append(stepCacheKeys, w.stepCacheKeys...)
+
+		for _, k := range w.globalStepCacheKeys {
+			if k != "" {
+				globalStepCacheKeys = append(globalStepCacheKeys, k)
+			}
+		}
+		globalStepCacheRepoIDs = append(globalStepCacheRepoIDs, w.dbWorkspace.RepoID)
 	}
 	entriesByCacheKey := make(map[string]*btypes.BatchSpecExecutionCacheEntry)
 	if len(cacheKeys) > 0 {
@@ -202,11 +235,30 @@ func (r *batchSpecWorkspaceCreator) process(
 		}
 	}
 
+	globalStepEntriesByCacheKey := make(map[string]*btypes.BatchStepCacheEntry)
+	if len(globalStepCacheKeys) > 0 {
+		// 🚨 SECURITY: Step cache entries are shared across users, so we look
+		// them up as the user to only get entries in repositories they can
+		// access.
+		entries, err := tx.ListBatchStepCacheEntries(userCtx, store.ListBatchStepCacheEntriesOpts{
+			Keys:    globalStepCacheKeys,
+			RepoIDs: globalStepCacheRepoIDs,
+		})
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			globalStepEntriesByCacheKey[entry.Key] = entry
+		}
+	}
+
 	// All changeset specs to be created.
 	cs := make([]*btypes.ChangesetSpec, 0)
 	// Collect all IDs of used cache entries to mark them as recently used later.
 	usedCacheEntries := make([]int64, 0)
+	usedGlobalStepCacheEntries := make([]int64, 0)
 	changesetsByWorkspace := make(map[*btypes.BatchSpecWorkspace][]*btypes.ChangesetSpec)
+	artifactsByWorkspace := make(map[*btypes.BatchSpecWorkspace]map[string]any)
 
 	// Check for an existing cache entry for each of the workspaces.
 	for rawKey, workspace := range cacheKeyWorkspaces {
@@ -216,9 +268,20 @@ func (r *batchSpecWorkspaceCreator) process(
 				continue
 			}
 
+			var value string
 			if c, ok := stepEntriesByCacheKey[key]; ok {
+				value = c.Value
+			} else if c, ok := globalStepEntriesByCacheKey[workspace.globalStepCacheKeys[idx]]; ok && c.RepoID == workspace.dbWorkspace.RepoID {
+				// The step result was produced by another batch change. We
+				// still store it under the key of this batch spec, since
+				// that's the key the executor will look for.
+				value = c.Value
+				usedGlobalStepCacheEntries = append(usedGlobalStepCacheEntries, c.ID)
+			}
+
+			if value != "" {
 				var res execution.AfterStepResult
-				if err := json.Unmarshal([]byte(c.Value), &res); err != nil {
+				if err := json.Unmarshal([]byte(value), &res); err != nil {
 					return err
 				}
 				workspace.dbWorkspace.SetStepCacheResult(idx+1, btypes.StepCacheResult{Key: key, Value: &res})
@@ -245,7 +308,7 @@ func (r *batchSpecWorkspaceCreator) process(
 			return err
 		}
 
-		rawSpecs, err := cache.ChangesetSpecsFromCache(spec.Spec, workspace.repo, executionResult)
+		rawSpecs, err := cache.ChangesetSpecsFromCache(spec.Spec, workspace.repo, executionResult, workspace.dbWorkspace.Owner, workspace.codeOwners)
 		if err != nil {
 			return err
 		}
@@ -265,12 +328,20 @@ func (r *batchSpecWorkspaceCreator) process(
 
 		cs = append(cs, specs...)
 		changesetsByWorkspace[workspace.dbWorkspace] = specs
+		if len(executionResult.Artifacts) > 0 {
+			artifactsByWorkspace[workspace.dbWorkspace] = executionResult.Artifacts
+		}
 	}
 
 	// Mark all used cache entries as recently used for cache eviction purposes.
 	if err := tx.MarkUsedBatchSpecExecutionCacheEntries(ctx, usedCacheEntries); err != nil {
 		return err
 	}
+	if len(usedGlobalStepCacheEntries) > 0 {
+		if err := tx.MarkUsedBatchStepCacheEntries(ctx, usedGlobalStepCacheEntries); err != nil {
+			return err
+		}
+	}
 
 	// If there are "importChangesets" statements in the spec we evaluate
 	// them now and create ChangesetSpecs for them.
@@ -321,5 +392,18 @@ func (r *batchSpecWorkspaceCreator) process(
 		}
 	}
 
-	return tx.CreateBatchSpecWorkspace(ctx, ws...)
+	if err := tx.CreateBatchSpecWorkspace(ctx, ws...); err != nil {
+		return err
+	}
+
+	// Copy the artifacts of cached results over, now that the workspaces have
+	// IDs.
+	var artifacts []*btypes.BatchSpecWorkspaceArtifact
+	for workspace, values := range artifactsByWorkspace {
+		artifacts = append(artifacts, btypes.NewBatchSpecWorkspaceArtifacts(workspace.ID, values)...)
+	}
+	if len(artifacts) == 0 {
+		return nil
+	}
+	return tx.CreateBatchSpecWorkspaceArtifacts(ctx, artifacts...)
 }
@@ -0,0 +1,56 @@
+package codeintel
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	"github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/codeintel"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/background/diagnostics"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/log"
+)
+
+type diagnosticsAggregatorJob struct{}
+
+func NewDiagnosticsAggregatorJob() job.Job {
+	return &diagnosticsAggregatorJob{}
+}
+
+func (j *diagnosticsAggregatorJob) Description() string {
+	return ""
+}
+
+func (j *diagnosticsAggregatorJob) Config() []env.Config {
+	return []env.Config{
+		diagnostics.ConfigInst,
+	}
+}
+
+func (j *diagnosticsAggregatorJob) Routines(ctx context.Context, logger log.Logger) ([]goroutine.BackgroundRoutine, error) {
+	observationContext := &observation.Context{
+		Logger:     logger.Scoped("routines", "codeintel job routines"),
+		Tracer:     &trace.Tracer{Tracer: opentracing.GlobalTracer()},
+		Registerer: prometheus.DefaultRegisterer,
+	}
+	metrics := diagnostics.NewMetrics(observationContext)
+
+	dbStore, err := codeintel.InitDBStore()
+	if err != nil {
+		return nil, err
+	}
+
+	lsifStore, err := codeintel.InitLSIFStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return []goroutine.BackgroundRoutine{
+		diagnostics.NewAggregator(dbStore, lsifStore, metrics),
+	}, nil
+}
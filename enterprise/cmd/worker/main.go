@@ -55,6 +55,7 @@ func main() {
 		"codeintel-upload-expirer":         freshcodeintel.NewUploadExpirerJob(),
 		"codeintel-commitgraph-updater":    freshcodeintel.NewCommitGraphUpdaterJob(),
 		"codeintel-autoindexing-scheduler": freshcodeintel.NewAutoindexingSchedulerJob(),
+		"codeintel-diagnostics-aggregator": freshcodeintel.NewDiagnosticsAggregatorJob(),
 
 		// temporary
 		"codeintel-janitor":       codeintel.NewJanitorJob(),
@@ -9,6 +9,7 @@ import (
 
 	"github.com/inconshreveable/log15"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/github"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/authz/gitlab"
@@ -58,6 +59,7 @@ func ProvidersFromConfig(
 			extsvc.KindGitHub,
 			extsvc.KindGitLab,
 			extsvc.KindBitbucketServer,
+			extsvc.KindBitbucketCloud,
 			extsvc.KindPerforce,
 		},
 		LimitOffset: &database.LimitOffset{
@@ -69,6 +71,7 @@ func ProvidersFromConfig(
 		gitHubConns          []*github.ExternalConnection
 		gitLabConns          []*types.GitLabConnection
 		bitbucketServerConns []*types.BitbucketServerConnection
+		bitbucketCloudConns  []*types.BitbucketCloudConnection
 		perforceConns        []*types.PerforceConnection
 	)
 	for {
@@ -114,6 +117,11 @@ func ProvidersFromConfig(
 					URN:                       svc.URN(),
 					BitbucketServerConnection: c,
 				})
+			case *schema.BitbucketCloudConnection:
+				bitbucketCloudConns = append(bitbucketCloudConns, &types.BitbucketCloudConnection{
+					URN:                      svc.URN(),
+					BitbucketCloudConnection: c,
+				})
 			case *schema.PerforceConnection:
 				perforceConns = append(perforceConns, &types.PerforceConnection{
 					URN:                svc.URN(),
@@ -157,6 +165,13 @@ func ProvidersFromConfig(
 		warnings = append(warnings, bbsWarnings...)
 	}
 
+	if len(bitbucketCloudConns) > 0 {
+		bbcloudProviders, bbcloudProblems, bbcloudWarnings := bitbucketcloud.NewAuthzProviders(bitbucketCloudConns)
+		providers = append(providers, bbcloudProviders...)
+		seriousProblems = append(seriousProblems, bbcloudProblems...)
+		warnings = append(warnings, bbcloudWarnings...)
+	}
+
 	if len(perforceConns) > 0 {
 		pfProviders, pfProblems, pfWarnings := perforce.NewAuthzProviders(perforceConns, db)
 		providers = append(providers, pfProviders...)
@@ -253,6 +268,15 @@ func ProviderFromExternalService(
 				},
 			},
 		)
+	case *schema.BitbucketCloudConnection:
+		providers, problems, _ = bitbucketcloud.NewAuthzProviders(
+			[]*types.BitbucketCloudConnection{
+				{
+					URN:                      svc.URN(),
+					BitbucketCloudConnection: c,
+				},
+			},
+		)
 	case *schema.PerforceConnection:
 		providers, problems, _ = perforce.NewAuthzProviders(
 			[]*types.PerforceConnection{
@@ -0,0 +1,183 @@
+// Package bitbucketcloud contains an authorization provider for Bitbucket Cloud.
+package bitbucketcloud
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Provider is an implementation of authz.Provider that provides repository
+// permissions as determined from the Bitbucket Cloud API.
+//
+// User permissions are fetched with the OAuth token stored on the Bitbucket
+// Cloud external account a user has connected, while repository permissions
+// are fetched with the credentials of the code host connection. Accounts are
+// identified by their Bitbucket Cloud UUID.
+type Provider struct {
+	urn      string
+	client   bitbucketcloud.Client
+	codeHost *extsvc.CodeHost
+}
+
+var _ authz.Provider = (*Provider)(nil)
+
+// NewProvider returns a new Bitbucket Cloud authorization provider for the
+// given connection. If a nil httpClient is provided, the default external
+// doer will be used.
+func NewProvider(conn *types.BitbucketCloudConnection, httpClient httpcli.Doer) (*Provider, error) {
+	baseURL, err := url.Parse(conn.Url)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse url")
+	}
+
+	client, err := bitbucketcloud.NewClient(conn.URN, conn.BitbucketCloudConnection, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Provider{
+		urn:      conn.URN,
+		client:   client,
+		codeHost: extsvc.NewCodeHost(baseURL, extsvc.TypeBitbucketCloud),
+	}, nil
+}
+
+// ValidateConnection validates that the Provider has access to the Bitbucket
+// Cloud API with the credentials it was configured with.
+func (p *Provider) ValidateConnection(ctx context.Context) []string {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := p.client.Ping(ctx); err != nil {
+		return []string{err.Error()}
+	}
+	return nil
+}
+
+func (p *Provider) URN() string {
+	return p.urn
+}
+
+// ServiceID returns the absolute URL that identifies the Bitbucket Cloud
+// instance this provider is configured with.
+func (p *Provider) ServiceID() string { return p.codeHost.ServiceID }
+
+// ServiceType returns the type of this Provider, namely, "bitbucketCloud".
+func (p *Provider) ServiceType() string { return p.codeHost.ServiceType }
+
+// FetchAccount satisfies the authz.Provider interface. Bitbucket Cloud
+// accounts are only ever linked through OAuth, so this always returns nil.
+func (p *Provider) FetchAccount(context.Context, *types.User, []*extsvc.Account, []string) (*extsvc.Account, error) {
+	return nil, nil
+}
+
+// FetchUserPerms returns a list of repository UUIDs (on code host) that the
+// given account has read access to. The repository UUID has the same value as
+// it would be used as api.ExternalRepoSpec.ID.
+//
+// This method may return partial but valid results in case of error, and it is
+// up to callers to decide whether to discard.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-repositories/#api-user-permissions-repositories-get
+func (p *Provider) FetchUserPerms(ctx context.Context, account *extsvc.Account, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	if account == nil {
+		return nil, errors.New("no account provided")
+	} else if !extsvc.IsHostOfAccount(p.codeHost, account) {
+		return nil, errors.Errorf("not a code host of the account: want %q but have %q",
+			account.AccountSpec.ServiceID, p.codeHost.ServiceID)
+	}
+
+	_, tok, err := bitbucketcloud.GetExternalAccountData(&account.AccountData)
+	if err != nil {
+		return nil, errors.Wrap(err, "get external account data")
+	} else if tok == nil {
+		return nil, errors.New("no token found in the external account data")
+	}
+
+	return p.FetchUserPermsByToken(ctx, tok.AccessToken, opts)
+}
+
+// FetchUserPermsByToken is the same as FetchUserPerms, but it only requires a
+// token.
+func (p *Provider) FetchUserPermsByToken(ctx context.Context, token string, opts authz.FetchPermsOptions) (*authz.ExternalUserPermissions, error) {
+	client := p.client.WithAuthenticator(&auth.OAuthBearerToken{Token: token})
+
+	perms := &authz.ExternalUserPermissions{}
+	var page *bitbucketcloud.PageToken
+	for {
+		repoPerms, next, err := client.CurrentUserRepoPermissions(ctx, page)
+		if err != nil {
+			return perms, err
+		}
+
+		for _, rp := range repoPerms {
+			if rp.Repo == nil || rp.Repo.UUID == "" {
+				continue
+			}
+			perms.Exacts = append(perms.Exacts, extsvc.RepoID(rp.Repo.UUID))
+		}
+
+		if !next.HasMore() {
+			return perms, nil
+		}
+		page = next
+	}
+}
+
+// FetchRepoPerms returns a list of user UUIDs (on code host) who have been
+// granted explicit access to the given repository on the code host. The user
+// UUID has the same value as it would be used as extsvc.Account.AccountID.
+//
+// Users who only gain access through workspace groups are not included here,
+// their access is picked up when their own permissions are synced.
+//
+// This method may return partial but valid results in case of error, and it is
+// up to callers to decide whether to discard.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-repositories/#api-repositories-workspace-repo-slug-permissions-config-users-get
+func (p *Provider) FetchRepoPerms(ctx context.Context, repo *extsvc.Repository, opts authz.FetchPermsOptions) ([]extsvc.AccountID, error) {
+	if repo == nil {
+		return nil, errors.New("no repository provided")
+	} else if !extsvc.IsHostOfRepo(p.codeHost, &repo.ExternalRepoSpec) {
+		return nil, errors.Errorf("not a code host of the repository: want %q but have %q",
+			repo.ServiceID, p.codeHost.ServiceID)
+	}
+
+	// The URI is of the form "bitbucket.org/<workspace>/<slug>".
+	parts := strings.Split(repo.URI, "/")
+	if len(parts) < 3 {
+		return nil, errors.Errorf("malformed repository URI: %q", repo.URI)
+	}
+	namespace, slug := parts[len(parts)-2], parts[len(parts)-1]
+
+	var accountIDs []extsvc.AccountID
+	var page *bitbucketcloud.PageToken
+	for {
+		userPerms, next, err := p.client.ListExplicitUserPermsForRepo(ctx, page, namespace, slug)
+		if err != nil {
+			return accountIDs, err
+		}
+
+		for _, up := range userPerms {
+			if up.User == nil || up.User.UUID == "" {
+				continue
+			}
+			accountIDs = append(accountIDs, extsvc.AccountID(up.User.UUID))
+		}
+
+		if !next.HasMore() {
+			return accountIDs, nil
+		}
+		page = next
+	}
+}
@@ -0,0 +1,197 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func newTestProvider(t *testing.T, handler http.Handler) *Provider {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	p, err := NewProvider(&types.BitbucketCloudConnection{
+		URN: "extsvc:bitbucketcloud:1",
+		BitbucketCloudConnection: &schema.BitbucketCloudConnection{
+			Url:         "https://bitbucket.org",
+			ApiURL:      srv.URL,
+			Username:    "admin",
+			AppPassword: "password",
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// writePage writes a paginated Bitbucket Cloud response. If next is non-empty,
+// it is resolved against the request host.
+func writePage(t *testing.T, w http.ResponseWriter, r *http.Request, next string, values any) {
+	t.Helper()
+
+	page := map[string]any{"values": values}
+	if next != "" {
+		page["next"] = fmt.Sprintf("http://%s%s", r.Host, next)
+	}
+	if err := json.NewEncoder(w).Encode(page); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProvider_FetchUserPerms(t *testing.T) {
+	t.Run("nil account", func(t *testing.T) {
+		p := newTestProvider(t, http.NotFoundHandler())
+		_, err := p.FetchUserPerms(context.Background(), nil, authz.FetchPermsOptions{})
+		want := "no account provided"
+		if err == nil || err.Error() != want {
+			t.Fatalf("err: want %q but got %v", want, err)
+		}
+	})
+
+	t.Run("not the code host of the account", func(t *testing.T) {
+		p := newTestProvider(t, http.NotFoundHandler())
+		_, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeGitLab,
+				ServiceID:   "https://gitlab.com/",
+			},
+		}, authz.FetchPermsOptions{})
+		want := `not a code host of the account: want "https://gitlab.com/" but have "https://bitbucket.org/"`
+		if err == nil || err.Error() != want {
+			t.Fatalf("err: want %q but got %v", want, err)
+		}
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		p := newTestProvider(t, http.NotFoundHandler())
+		_, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeBitbucketCloud,
+				ServiceID:   "https://bitbucket.org/",
+			},
+		}, authz.FetchPermsOptions{})
+		want := "no token found in the external account data"
+		if err == nil || err.Error() != want {
+			t.Fatalf("err: want %q but got %v", want, err)
+		}
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		p := newTestProvider(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if have, want := r.Header.Get("Authorization"), "Bearer user-token"; have != want {
+				t.Errorf("authorization header: want %q but have %q", want, have)
+			}
+			if r.URL.Path != "/2.0/user/permissions/repositories" {
+				t.Errorf("unexpected path %q", r.URL.Path)
+			}
+
+			if r.URL.Query().Get("page") == "" {
+				writePage(t, w, r, "/2.0/user/permissions/repositories?page=2", []bitbucketcloud.RepoPermission{
+					{Permission: bitbucketcloud.PermissionRead, Repo: &bitbucketcloud.Repo{UUID: "{repo-1}"}},
+					{Permission: bitbucketcloud.PermissionWrite, Repo: &bitbucketcloud.Repo{UUID: "{repo-2}"}},
+				})
+				return
+			}
+			writePage(t, w, r, "", []bitbucketcloud.RepoPermission{
+				{Permission: bitbucketcloud.PermissionAdmin, Repo: &bitbucketcloud.Repo{UUID: "{repo-3}"}},
+			})
+		}))
+
+		var data extsvc.AccountData
+		bitbucketcloud.SetExternalAccountData(&data, &bitbucketcloud.User{}, &oauth2.Token{AccessToken: "user-token"})
+
+		perms, err := p.FetchUserPerms(context.Background(), &extsvc.Account{
+			AccountSpec: extsvc.AccountSpec{
+				ServiceType: extsvc.TypeBitbucketCloud,
+				ServiceID:   "https://bitbucket.org/",
+				AccountID:   "{user-1}",
+			},
+			AccountData: data,
+		}, authz.FetchPermsOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []extsvc.RepoID{"{repo-1}", "{repo-2}", "{repo-3}"}
+		if diff := cmp.Diff(want, perms.Exacts); diff != "" {
+			t.Fatalf("Exacts mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestProvider_FetchRepoPerms(t *testing.T) {
+	t.Run("nil repository", func(t *testing.T) {
+		p := newTestProvider(t, http.NotFoundHandler())
+		_, err := p.FetchRepoPerms(context.Background(), nil, authz.FetchPermsOptions{})
+		want := "no repository provided"
+		if err == nil || err.Error() != want {
+			t.Fatalf("err: want %q but got %v", want, err)
+		}
+	})
+
+	t.Run("not the code host of the repository", func(t *testing.T) {
+		p := newTestProvider(t, http.NotFoundHandler())
+		_, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{
+			URI: "gitlab.com/user/repo",
+			ExternalRepoSpec: api.ExternalRepoSpec{
+				ServiceType: extsvc.TypeGitLab,
+				ServiceID:   "https://gitlab.com/",
+			},
+		}, authz.FetchPermsOptions{})
+		want := `not a code host of the repository: want "https://gitlab.com/" but have "https://bitbucket.org/"`
+		if err == nil || err.Error() != want {
+			t.Fatalf("err: want %q but got %v", want, err)
+		}
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		p := newTestProvider(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/2.0/repositories/myworkspace/myrepo/permissions-config/users" {
+				t.Errorf("unexpected path %q", r.URL.Path)
+			}
+
+			if r.URL.Query().Get("page") == "" {
+				writePage(t, w, r, "/2.0/repositories/myworkspace/myrepo/permissions-config/users?page=2", []bitbucketcloud.UserPermission{
+					{Permission: bitbucketcloud.PermissionRead, User: &bitbucketcloud.Account{UUID: "{user-1}"}},
+				})
+				return
+			}
+			writePage(t, w, r, "", []bitbucketcloud.UserPermission{
+				{Permission: bitbucketcloud.PermissionAdmin, User: &bitbucketcloud.Account{UUID: "{user-2}"}},
+			})
+		}))
+
+		accountIDs, err := p.FetchRepoPerms(context.Background(), &extsvc.Repository{
+			URI: "bitbucket.org/myworkspace/myrepo",
+			ExternalRepoSpec: api.ExternalRepoSpec{
+				ID:          "{repo-1}",
+				ServiceType: extsvc.TypeBitbucketCloud,
+				ServiceID:   "https://bitbucket.org/",
+			},
+		}, authz.FetchPermsOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []extsvc.AccountID{"{user-1}", "{user-2}"}
+		if diff := cmp.Diff(want, accountIDs); diff != "" {
+			t.Fatalf("AccountIDs mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
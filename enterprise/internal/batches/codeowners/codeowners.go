@@ -0,0 +1,37 @@
+// Package codeowners loads the CODEOWNERS files of repositories, which are used
+// to split up batch spec workspaces by code owner.
+package codeowners
+
+import (
+	"context"
+	"os"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	codeownerslib "github.com/sourcegraph/sourcegraph/lib/batches/codeowners"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Load returns the rules of the CODEOWNERS file of the repository at the given
+// commit. If the repository doesn't have a CODEOWNERS file, nil is returned.
+func Load(ctx context.Context, db database.DB, repo api.RepoName, commit api.CommitID) (*codeownerslib.Ruleset, error) {
+	for _, path := range codeownerslib.Paths {
+		content, err := git.ReadFile(ctx, db, repo, commit, path, authz.DefaultSubRepoPermsChecker)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "reading %s", path)
+		}
+
+		rs, err := codeownerslib.Parse(string(content))
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing %s", path)
+		}
+		return rs, nil
+	}
+
+	return nil, nil
+}
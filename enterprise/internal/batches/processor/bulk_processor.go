@@ -3,6 +3,8 @@ package processor
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/inconshreveable/log15"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -101,6 +104,8 @@ func (b *bulkProcessor) Process(ctx context.Context, job *btypes.ChangesetJob) (
 		return b.closeChangeset(ctx)
 	case btypes.ChangesetJobTypePublish:
 		return b.publishChangeset(ctx, job)
+	case btypes.ChangesetJobTypeRetryChecks:
+		return b.retryChecks(ctx)
 
 	default:
 		return &unknownJobTypeErr{jobType: string(job.JobType)}
@@ -112,11 +117,81 @@ func (b *bulkProcessor) comment(ctx context.Context, job *btypes.ChangesetJob) e
 	if !ok {
 		return errors.Errorf("invalid payload type for changeset_job, want=%T have=%T", &btypes.ChangesetJobCommentPayload{}, job.Payload)
 	}
+
+	body := typedPayload.Message
+	if typedPayload.Templated {
+		tmplCtx, err := b.commentContext(ctx, job)
+		if err != nil {
+			return errors.Wrap(err, "loading comment template variables")
+		}
+		body, err = template.RenderChangesetComment(typedPayload.Message, tmplCtx)
+		if err != nil {
+			// Rendering the comment again won't fix the template.
+			return errcode.MakeNonRetryable(errors.Wrap(err, "rendering comment"))
+		}
+	}
+
 	cs := &sources.Changeset{
 		Changeset:  b.ch,
 		TargetRepo: b.repo,
 	}
-	return b.css.CreateComment(ctx, cs, typedPayload.Message)
+	return b.css.CreateComment(ctx, cs, body)
+}
+
+// commentContext returns the variables that are available when rendering the
+// body of a comment on the changeset.
+func (b *bulkProcessor) commentContext(ctx context.Context, job *btypes.ChangesetJob) (*template.ChangesetCommentContext, error) {
+	title, err := b.ch.Title()
+	if err != nil {
+		return nil, err
+	}
+	url, err := b.ch.URL()
+	if err != nil {
+		return nil, err
+	}
+	baseRef, err := b.ch.BaseRef()
+	if err != nil {
+		return nil, err
+	}
+
+	tmplCtx := &template.ChangesetCommentContext{
+		Repository: template.Repository{
+			Name:   string(b.repo.Name),
+			Branch: strings.TrimPrefix(baseRef, "refs/heads/"),
+		},
+		Changeset: template.ChangesetAttributes{
+			Title:       title,
+			URL:         url,
+			Branch:      strings.TrimPrefix(b.ch.ExternalBranch, "refs/heads/"),
+			State:       string(b.ch.ExternalState),
+			ReviewState: string(b.ch.ExternalReviewState),
+			CheckState:  string(b.ch.ExternalCheckState),
+		},
+	}
+	if createdAt := b.ch.ExternalCreatedAt(); !createdAt.IsZero() {
+		tmplCtx.Changeset.DaysOpen = int(b.tx.Clock()().Sub(createdAt) / (24 * time.Hour))
+	}
+
+	if job.BatchChangeID != 0 {
+		batchChange, err := b.tx.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: job.BatchChangeID})
+		if err != nil {
+			return nil, errors.Wrap(err, "loading batch change")
+		}
+		tmplCtx.BatchChangeAttributes = template.BatchChangeAttributes{
+			Name:        batchChange.Name,
+			Description: batchChange.Description,
+		}
+	}
+
+	if b.ch.CurrentSpecID != 0 {
+		spec, err := b.tx.GetChangesetSpecByID(ctx, b.ch.CurrentSpecID)
+		if err != nil {
+			return nil, errors.Wrap(err, "loading changeset spec")
+		}
+		tmplCtx.Outputs = spec.Spec.Outputs
+	}
+
+	return tmplCtx, nil
 }
 
 func (b *bulkProcessor) detach(ctx context.Context, job *btypes.ChangesetJob) error {
@@ -218,6 +293,40 @@ func (b *bulkProcessor) closeChangeset(ctx context.Context) (err error) {
 	return nil
 }
 
+func (b *bulkProcessor) retryChecks(ctx context.Context) (err error) {
+	retryCss, err := sources.ToRetryChecksChangesetSource(b.css)
+	if err != nil {
+		return errcode.MakeNonRetryable(err)
+	}
+
+	cs := &sources.Changeset{
+		Changeset:  b.ch,
+		TargetRepo: b.repo,
+	}
+	if err := retryCss.RetryFailedChecks(ctx, cs); err != nil {
+		return err
+	}
+
+	events, err := cs.Changeset.Events()
+	if err != nil {
+		log15.Error("Events", "err", err)
+		return errcode.MakeNonRetryable(err)
+	}
+	state.SetDerivedState(ctx, b.tx.Repos(), cs.Changeset, events)
+
+	if err := b.tx.UpsertChangesetEvents(ctx, events...); err != nil {
+		log15.Error("UpsertChangesetEvents", "err", err)
+		return errcode.MakeNonRetryable(err)
+	}
+
+	if err := b.tx.UpdateChangesetCodeHostState(ctx, cs.Changeset); err != nil {
+		log15.Error("UpdateChangeset", "err", err)
+		return errcode.MakeNonRetryable(err)
+	}
+
+	return nil
+}
+
 func (b *bulkProcessor) publishChangeset(ctx context.Context, job *btypes.ChangesetJob) (err error) {
 	typedPayload, ok := job.Payload.(*btypes.ChangesetJobPublishPayload)
 	if !ok {
@@ -92,10 +92,14 @@ func TestBulkProcessor(t *testing.T) {
 			sourcer: sources.NewFakeSourcer(nil, fake),
 		}
 		job := &types.ChangesetJob{
-			JobType:     types.ChangesetJobTypeComment,
-			ChangesetID: changeset.ID,
-			UserID:      user.ID,
-			Payload:     &btypes.ChangesetJobCommentPayload{},
+			JobType:       types.ChangesetJobTypeComment,
+			ChangesetID:   changeset.ID,
+			BatchChangeID: batchChange.ID,
+			UserID:        user.ID,
+			Payload: &btypes.ChangesetJobCommentPayload{
+				Message:   "Please review ${{ repository.name }} for ${{ batch_change.name }}",
+				Templated: true,
+			},
 		}
 		if err := bstore.CreateChangesetJob(ctx, job); err != nil {
 			t.Fatal(err)
@@ -107,6 +111,56 @@ func TestBulkProcessor(t *testing.T) {
 		if !fake.CreateCommentCalled {
 			t.Fatal("expected CreateComment to be called but wasn't")
 		}
+		if want := "Please review " + string(repo.Name) + " for test-bulk"; fake.CommentBody != want {
+			t.Fatalf("wrong comment body. want=%q, have=%q", want, fake.CommentBody)
+		}
+	})
+
+	t.Run("Comment job without template", func(t *testing.T) {
+		fake := &sources.FakeChangesetSource{}
+		bp := &bulkProcessor{
+			tx:      bstore,
+			sourcer: sources.NewFakeSourcer(nil, fake),
+		}
+		job := &types.ChangesetJob{
+			JobType:     types.ChangesetJobTypeComment,
+			ChangesetID: changeset.ID,
+			UserID:      user.ID,
+			Payload:     &btypes.ChangesetJobCommentPayload{Message: "Use ${{ inputs.version }} in your workflow"},
+		}
+		if err := bstore.CreateChangesetJob(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+		if err := bp.Process(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+		if want := "Use ${{ inputs.version }} in your workflow"; fake.CommentBody != want {
+			t.Fatalf("wrong comment body. want=%q, have=%q", want, fake.CommentBody)
+		}
+	})
+
+	t.Run("Comment job with invalid template", func(t *testing.T) {
+		fake := &sources.FakeChangesetSource{}
+		bp := &bulkProcessor{
+			tx:      bstore,
+			sourcer: sources.NewFakeSourcer(nil, fake),
+		}
+		job := &types.ChangesetJob{
+			JobType:     types.ChangesetJobTypeComment,
+			ChangesetID: changeset.ID,
+			UserID:      user.ID,
+			Payload:     &btypes.ChangesetJobCommentPayload{Message: "${{ unknown }}", Templated: true},
+		}
+		if err := bstore.CreateChangesetJob(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+		err := bp.Process(ctx, job)
+		if err == nil || !errcode.IsNonRetryable(err) {
+			t.Fatalf("expected non-retryable error, got %v", err)
+		}
+		if fake.CreateCommentCalled {
+			t.Fatal("expected CreateComment not to be called but was")
+		}
 	})
 
 	t.Run("Detach job", func(t *testing.T) {
@@ -213,6 +267,27 @@ func TestBulkProcessor(t *testing.T) {
 		}
 	})
 
+	t.Run("Retry checks job", func(t *testing.T) {
+		fake := &sources.FakeChangesetSource{FakeMetadata: &github.PullRequest{}}
+		bp := &bulkProcessor{
+			tx:      bstore,
+			sourcer: sources.NewFakeSourcer(nil, fake),
+		}
+		job := &types.ChangesetJob{
+			JobType:     types.ChangesetJobTypeRetryChecks,
+			ChangesetID: changeset.ID,
+			UserID:      user.ID,
+			Payload:     &btypes.ChangesetJobRetryChecksPayload{},
+		}
+		err := bp.Process(ctx, job)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !fake.RetryFailedChecksCalled {
+			t.Fatal("expected RetryFailedChecks to be called but wasn't")
+		}
+	})
+
 	t.Run("Publish job", func(t *testing.T) {
 		fake := &sources.FakeChangesetSource{FakeMetadata: &github.PullRequest{}}
 		bp := &bulkProcessor{
@@ -0,0 +1,120 @@
+package reconciler
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const dependencyGateInterval = 1 * time.Minute
+
+// NewDependencyGate returns a background routine that periodically looks for
+// changesets that were held back by the reconciler because they depend on
+// other changesets, and re-enqueues them once all of their dependencies have
+// been merged, or once one of them was closed without being merged, so that
+// the reconciler can mark them as failed.
+func NewDependencyGate(ctx context.Context, bstore *store.Store) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		dependencyGateInterval,
+		goroutine.NewHandlerWithErrorMessage("batch changes dependency gate", func(ctx context.Context) error {
+			return enqueueChangesetsWithSettledDependencies(ctx, bstore)
+		}),
+	)
+}
+
+func enqueueChangesetsWithSettledDependencies(ctx context.Context, bstore *store.Store) error {
+	cs, err := bstore.ListChangesetsWithSettledDependencies(ctx)
+	if err != nil {
+		return errors.Wrap(err, "listing changesets with settled dependencies")
+	}
+
+	var errs error
+	for _, ch := range cs {
+		prev, curr, err := loadChangesetSpecs(ctx, bstore, ch)
+		if err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "loading changeset specs for changeset %d", ch.ID))
+			continue
+		}
+
+		plan, err := DeterminePlan(prev, curr, ch)
+		if err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "determining plan for changeset %d", ch.ID))
+			continue
+		}
+
+		// Only changesets that are still meant to be published or undrafted
+		// were held back by the reconciler.
+		if !isGatedByDependencies(plan.Ops) {
+			continue
+		}
+
+		log15.Debug("enqueueing changeset with settled dependencies", "changeset", ch.ID)
+		if err := bstore.EnqueueChangeset(ctx, ch, global.DefaultReconcilerEnqueueState(), btypes.ReconcilerStateCompleted); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "enqueueing changeset %d", ch.ID))
+		}
+	}
+
+	return errs
+}
+
+// isGatedByDependencies returns true if the operations are held back by
+// unmerged dependencies of the changeset.
+func isGatedByDependencies(ops Operations) bool {
+	return ops.Publishes() || ops.Contains(btypes.ReconcilerOperationUndraft)
+}
+
+// applyDependencyGates adjusts the plan to the dependencies of its changeset
+// that haven't been merged yet. With a publish gate the changeset isn't
+// published; with a merge gate it's published as a draft and not undrafted.
+// It returns false if the changeset has to be held back entirely, and an
+// error if a dependency was closed without being merged.
+func applyDependencyGates(ctx context.Context, tx *store.Store, plan *Plan) (proceed bool, err error) {
+	if !isGatedByDependencies(plan.Ops) {
+		return true, nil
+	}
+
+	statuses, err := tx.ListChangesetDependencyStatuses(ctx, plan.Changeset.ID)
+	if err != nil {
+		return false, err
+	}
+
+	switch btypes.ComputeChangesetDependencyState(statuses) {
+	case btypes.ChangesetDependencyStateBlocked:
+		for _, s := range statuses {
+			if s.Abandoned() {
+				return false, errcode.MakeNonRetryable(errors.Newf(
+					"changeset depends on changeset %d, which was %s without being merged",
+					s.DependsOnChangesetID,
+					strings.ToLower(string(s.DependsOnExternalState)),
+				))
+			}
+		}
+
+	case btypes.ChangesetDependencyStateWaitingToPublish:
+		if plan.Ops.Publishes() {
+			return false, nil
+		}
+		plan.Ops = plan.Ops.Without(btypes.ReconcilerOperationUndraft)
+
+	case btypes.ChangesetDependencyStateWaitingToMerge:
+		if plan.Ops.Contains(btypes.ReconcilerOperationPublish) {
+			if !plan.Changeset.SupportsDraft() {
+				return false, nil
+			}
+			plan.Ops = append(plan.Ops.Without(btypes.ReconcilerOperationPublish), btypes.ReconcilerOperationPublishDraft)
+		}
+		plan.Ops = plan.Ops.Without(btypes.ReconcilerOperationUndraft)
+	}
+
+	return true, nil
+}
@@ -32,6 +32,8 @@ func executePlan(ctx context.Context, gitserverClient GitserverClient, sourcer s
 		tx:                tx,
 		ch:                plan.Changeset,
 		spec:              plan.ChangesetSpec,
+		prevSpec:          plan.PreviousChangesetSpec,
+		delta:             plan.Delta,
 	}
 
 	return e.Run(ctx, plan)
@@ -44,6 +46,8 @@ type executor struct {
 	tx                *store.Store
 	ch                *btypes.Changeset
 	spec              *btypes.ChangesetSpec
+	prevSpec          *btypes.ChangesetSpec
+	delta             *ChangesetSpecDelta
 
 	css     sources.ChangesetSource
 	cssErr  error
@@ -81,6 +85,9 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		case btypes.ReconcilerOperationPush:
 			err = e.pushChangesetPatch(ctx)
 
+		case btypes.ReconcilerOperationReapplyDiff:
+			err = e.reapplyChangesetDiff(ctx)
+
 		case btypes.ReconcilerOperationPublish:
 			err = e.publishChangeset(ctx, false)
 
@@ -96,6 +103,9 @@ func (e *executor) Run(ctx context.Context, plan *Plan) (err error) {
 		case btypes.ReconcilerOperationUndraft:
 			err = e.undraftChangeset(ctx)
 
+		case btypes.ReconcilerOperationUpdateMetadata:
+			err = e.updateChangesetMetadata(ctx)
+
 		case btypes.ReconcilerOperationClose:
 			err = e.closeChangeset(ctx)
 
@@ -167,6 +177,43 @@ func (e *executor) pushChangesetPatch(ctx context.Context) (err error) {
 	return e.pushCommit(ctx, opts)
 }
 
+// reapplyChangesetDiff pushes a new commit to the changeset branch that applies the
+// diff of the changeset spec onto the base branch commit found by the
+// rebaser. If the diff doesn't apply to that commit anymore, the changeset is
+// marked as conflicting instead.
+//
+// The steps of the batch spec are not re-run against the new base: the diff
+// they produced is re-applied as is. Changes that depend on the contents of
+// the base branch have to be regenerated by applying the batch spec again.
+func (e *executor) reapplyChangesetDiff(ctx context.Context) (err error) {
+	css, err := e.changesetSource(ctx)
+	if err != nil {
+		return err
+	}
+	pushConf, err := css.GitserverPushConfig(ctx, e.tx.ExternalServices(), e.remoteRepo)
+	if err != nil {
+		return err
+	}
+	opts, err := buildCommitOpts(e.targetRepo, e.spec, pushConf)
+	if err != nil {
+		return err
+	}
+	opts.BaseCommit = api.CommitID(e.ch.RebaseBaseRev)
+
+	if _, err := e.gitserverClient.CreateCommitFromPatch(ctx, opts); err != nil {
+		var patchErr *protocol.PatchDoesNotApplyError
+		if errors.As(err, &patchErr) {
+			log15.Debug("changeset conflicts with its base branch", "changeset", e.ch.ID, "base", e.ch.RebaseBaseRev)
+			e.ch.ConflictState = btypes.ChangesetConflictStateConflicting
+			return nil
+		}
+		return describeCreateCommitFromPatchError(err)
+	}
+
+	e.ch.ConflictState = btypes.ChangesetConflictStateUpToDate
+	return nil
+}
+
 // publishChangeset creates the given changeset on its code host.
 func (e *executor) publishChangeset(ctx context.Context, asDraft bool) (err error) {
 	cs := &sources.Changeset{
@@ -301,6 +348,66 @@ func (e *executor) updateChangeset(ctx context.Context) (err error) {
 	return nil
 }
 
+// updateChangesetMetadata adds the reviewers, labels and assignees of the
+// ChangesetSpec to the changeset on the code host, removes the ones that were
+// dropped since the previous ChangesetSpec, and toggles auto-merge.
+func (e *executor) updateChangesetMetadata(ctx context.Context) (err error) {
+	css, err := e.changesetSource(ctx)
+	if err != nil {
+		return err
+	}
+
+	metadataCss, err := sources.ToMetadataChangesetSource(css)
+	if err != nil {
+		return err
+	}
+
+	cs := &sources.Changeset{
+		Title:      e.spec.Spec.Title,
+		Body:       e.spec.Spec.Body,
+		BaseRef:    e.spec.Spec.BaseRef,
+		HeadRef:    e.spec.Spec.HeadRef,
+		RemoteRepo: e.remoteRepo,
+		TargetRepo: e.targetRepo,
+		Reviewers:  e.spec.Spec.Reviewers,
+		Labels:     e.spec.Spec.Labels,
+		Assignees:  e.spec.Spec.Assignees,
+		Changeset:  e.ch,
+	}
+
+	if e.prevSpec != nil {
+		cs.RemovedReviewers = droppedStrings(e.prevSpec.Spec.Reviewers, e.spec.Spec.Reviewers)
+		cs.RemovedLabels = droppedStrings(e.prevSpec.Spec.Labels, e.spec.Spec.Labels)
+		cs.RemovedAssignees = droppedStrings(e.prevSpec.Spec.Assignees, e.spec.Spec.Assignees)
+	}
+
+	// Only touch the auto-merge setting of the changeset if the spec asks for
+	// it, or if it was enabled by a previous spec.
+	if e.spec.Spec.AutoMerge || (e.delta != nil && e.delta.AutoMergeChanged) {
+		autoMerge := e.spec.Spec.AutoMerge
+		cs.AutoMerge = &autoMerge
+	}
+
+	if err := metadataCss.UpdateChangesetMetadata(ctx, cs); err != nil {
+		return errors.Wrap(err, "updating changeset metadata")
+	}
+	return nil
+}
+
+// droppedStrings returns the values in previous that are not in current.
+func droppedStrings(previous, current []string) (dropped []string) {
+	kept := make(map[string]struct{}, len(current))
+	for _, v := range current {
+		kept[v] = struct{}{}
+	}
+	for _, v := range previous {
+		if _, ok := kept[v]; !ok {
+			dropped = append(dropped, v)
+		}
+	}
+	return dropped
+}
+
 // reopenChangeset reopens the given changeset attribute on the code host.
 func (e *executor) reopenChangeset(ctx context.Context) (err error) {
 	css, err := e.changesetSource(ctx)
@@ -482,22 +589,29 @@ func loadRemoteRepo(
 func (e *executor) pushCommit(ctx context.Context, opts protocol.CreateCommitFromPatchRequest) error {
 	_, err := e.gitserverClient.CreateCommitFromPatch(ctx, opts)
 	if err != nil {
-		var e *protocol.CreateCommitFromPatchError
-		if errors.As(err, &e) {
-			return errors.Errorf(
-				"creating commit from patch for repository %q: %s\n"+
-					"```\n"+
-					"$ %s\n"+
-					"%s\n"+
-					"```",
-				e.RepositoryName, e.InternalError, e.Command, strings.TrimSpace(e.CombinedOutput))
-		}
-		return err
+		return describeCreateCommitFromPatchError(err)
 	}
 
 	return nil
 }
 
+// describeCreateCommitFromPatchError includes the failed git command and its
+// output in the error, if the given error is a
+// protocol.CreateCommitFromPatchError.
+func describeCreateCommitFromPatchError(err error) error {
+	var e *protocol.CreateCommitFromPatchError
+	if errors.As(err, &e) {
+		return errors.Errorf(
+			"creating commit from patch for repository %q: %s\n"+
+				"```\n"+
+				"$ %s\n"+
+				"%s\n"+
+				"```",
+			e.RepositoryName, e.InternalError, e.Command, strings.TrimSpace(e.CombinedOutput))
+	}
+	return err
+}
+
 func buildCommitOpts(repo *types.Repo, spec *btypes.ChangesetSpec, pushOpts *protocol.PushConfig) (opts protocol.CreateCommitFromPatchRequest, err error) {
 	desc := spec.Spec
 
@@ -85,6 +85,7 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 		wantCloseOnCodeHost       bool
 		wantLoadFromCodeHost      bool
 		wantReopenOnCodeHost      bool
+		wantMetadataOnCodeHost    bool
 
 		wantGitserverCommit bool
 
@@ -250,6 +251,37 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 				DiffStat:         state.DiffStat,
 			},
 		},
+		"rebase sleep sync": {
+			hasCurrentSpec: true,
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalID:       "12345",
+				ExternalBranch:   gitdomain.EnsureRefPrefix("head-ref-on-github"),
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				ConflictState:    btypes.ChangesetConflictStateBehind,
+				RebaseBaseRev:    "d34db33f",
+			},
+
+			plan: &Plan{
+				Ops: Operations{
+					btypes.ReconcilerOperationReapplyDiff,
+					btypes.ReconcilerOperationSleep,
+					btypes.ReconcilerOperationSync,
+				},
+			},
+
+			wantGitserverCommit:  true,
+			wantLoadFromCodeHost: true,
+
+			wantChangeset: ct.ChangesetAssertions{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				ExternalID:       githubPR.ID,
+				ExternalBranch:   githubHeadRef,
+				DiffStat:         state.DiffStat,
+				ConflictState:    btypes.ChangesetConflictStateUpToDate,
+			},
+		},
 		"close open changeset": {
 			hasCurrentSpec: true,
 			changeset: ct.TestChangesetOpts{
@@ -371,6 +403,33 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 				DiffStat: state.DiffStat,
 			},
 		},
+		"update metadata": {
+			hasCurrentSpec: true,
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+			},
+
+			plan: &Plan{
+				Ops: Operations{
+					btypes.ReconcilerOperationUpdateMetadata,
+				},
+			},
+
+			wantMetadataOnCodeHost: true,
+
+			wantChangeset: ct.ChangesetAssertions{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+
+				ExternalID:     githubPR.ID,
+				ExternalBranch: githubHeadRef,
+				ExternalState:  btypes.ChangesetExternalStateOpen,
+
+				Title:    githubPR.Title,
+				Body:     githubPR.Body,
+				DiffStat: state.DiffStat,
+			},
+		},
 		"undraft": {
 			hasCurrentSpec: true,
 			changeset: ct.TestChangesetOpts{
@@ -552,6 +611,10 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 				t.Fatalf("wrong CloseChangeset call. wantCalled=%t, wasCalled=%t", want, have)
 			}
 
+			if have, want := fakeSource.UpdateMetadataCalled, tc.wantMetadataOnCodeHost; have != want {
+				t.Fatalf("wrong UpdateChangesetMetadata call. wantCalled=%t, wasCalled=%t", want, have)
+			}
+
 			if tc.wantNonRetryableErr {
 				return
 			}
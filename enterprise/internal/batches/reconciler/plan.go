@@ -6,22 +6,25 @@ import (
 	"strings"
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 var operationPrecedence = map[btypes.ReconcilerOperation]int{
-	btypes.ReconcilerOperationPush:         0,
-	btypes.ReconcilerOperationDetach:       0,
-	btypes.ReconcilerOperationArchive:      0,
-	btypes.ReconcilerOperationImport:       1,
-	btypes.ReconcilerOperationPublish:      1,
-	btypes.ReconcilerOperationPublishDraft: 1,
-	btypes.ReconcilerOperationClose:        1,
-	btypes.ReconcilerOperationReopen:       2,
-	btypes.ReconcilerOperationUndraft:      3,
-	btypes.ReconcilerOperationUpdate:       4,
-	btypes.ReconcilerOperationSleep:        5,
-	btypes.ReconcilerOperationSync:         6,
+	btypes.ReconcilerOperationPush:           0,
+	btypes.ReconcilerOperationReapplyDiff:    0,
+	btypes.ReconcilerOperationDetach:         0,
+	btypes.ReconcilerOperationArchive:        0,
+	btypes.ReconcilerOperationImport:         1,
+	btypes.ReconcilerOperationPublish:        1,
+	btypes.ReconcilerOperationPublishDraft:   1,
+	btypes.ReconcilerOperationClose:          1,
+	btypes.ReconcilerOperationReopen:         2,
+	btypes.ReconcilerOperationUndraft:        3,
+	btypes.ReconcilerOperationUpdate:         4,
+	btypes.ReconcilerOperationUpdateMetadata: 5,
+	btypes.ReconcilerOperationSleep:          6,
+	btypes.ReconcilerOperationSync:           7,
 }
 
 type Operations []btypes.ReconcilerOperation
@@ -48,6 +51,38 @@ func (ops Operations) Equal(b Operations) bool {
 	return true
 }
 
+// Publishes returns true if the operations include publishing the changeset,
+// either as a draft or not.
+func (ops Operations) Publishes() bool {
+	for _, op := range ops {
+		if op == btypes.ReconcilerOperationPublish || op == btypes.ReconcilerOperationPublishDraft {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains returns true if the operations include the given operation.
+func (ops Operations) Contains(op btypes.ReconcilerOperation) bool {
+	for _, o := range ops {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// Without returns the operations without the given operation.
+func (ops Operations) Without(op btypes.ReconcilerOperation) Operations {
+	without := make(Operations, 0, len(ops))
+	for _, o := range ops {
+		if o != op {
+			without = append(without, o)
+		}
+	}
+	return without
+}
+
 func (ops Operations) String() string {
 	if ops.IsNone() {
 		return "No operations required"
@@ -90,6 +125,9 @@ type Plan struct {
 	// The changeset spec that is used in this plan.
 	ChangesetSpec *btypes.ChangesetSpec
 
+	// The changeset spec that was previously applied to the changeset, if any.
+	PreviousChangesetSpec *btypes.ChangesetSpec
+
 	// The operations that need to be done to reconcile the changeset.
 	Ops Operations
 
@@ -108,8 +146,9 @@ func (p *Plan) SetOp(op btypes.ReconcilerOperation) { p.Ops = Operations{op} }
 // error.
 func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, ch *btypes.Changeset) (*Plan, error) {
 	pl := &Plan{
-		Changeset:     ch,
-		ChangesetSpec: currentSpec,
+		Changeset:             ch,
+		ChangesetSpec:         currentSpec,
+		PreviousChangesetSpec: previousSpec,
 	}
 
 	wantDetach := false
@@ -181,6 +220,16 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, ch *btypes.C
 			pl.SetOp(btypes.ReconcilerOperationPublishDraft)
 			pl.AddOp(btypes.ReconcilerOperationPush)
 		}
+
+		// Newly published changesets don't have any reviewers, labels or
+		// assignees yet, so we add the ones in the spec once they're created.
+		if !pl.Ops.IsNone() {
+			metadataDelta := &ChangesetSpecDelta{}
+			compareChangesetSpecMetadata(&btypes.ChangesetSpec{Spec: &batcheslib.ChangesetSpec{}}, currentSpec, metadataDelta)
+			if metadataDelta.NeedMetadataUpdate(ch.ExternalServiceType) {
+				pl.AddOp(btypes.ReconcilerOperationUpdateMetadata)
+			}
+		}
 		// TODO: test for Published.Nil() and then plan based on the UI
 		// publication state. For now, we'll let it fall through and treat it
 		// the same as being unpublished.
@@ -199,13 +248,23 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, ch *btypes.C
 		// applied, which would mean delta.Undraft is set, or because the UI
 		// publication state has been changed, for which we need to compare the
 		// current changeset state against the desired state.
+		undraft := false
 		if btypes.ExternalServiceSupports(ch.ExternalServiceType, btypes.CodehostCapabilityDraftChangesets) {
 			if delta.Undraft {
-				pl.AddOp(btypes.ReconcilerOperationUndraft)
+				undraft = true
 			} else if calc := calculatePublicationState(currentSpec.Spec.Published, ch.UiPublicationState); calc.IsPublished() && ch.ExternalState == btypes.ChangesetExternalStateDraft {
-				pl.AddOp(btypes.ReconcilerOperationUndraft)
+				undraft = true
 			}
 		}
+		if undraft {
+			pl.AddOp(btypes.ReconcilerOperationUndraft)
+		}
+
+		// Code hosts don't allow auto-merge to be enabled on drafts, so it is
+		// enabled once the changeset is undrafted.
+		if delta.NeedMetadataUpdate(ch.ExternalServiceType) || (undraft && currentSpec.Spec.AutoMerge) {
+			pl.AddOp(btypes.ReconcilerOperationUpdateMetadata)
+		}
 
 		if delta.AttributesChanged() {
 			if delta.NeedCommitUpdate() {
@@ -234,6 +293,16 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, ch *btypes.C
 			}
 		}
 
+		// The rebaser found that the base branch moved ahead of the
+		// changeset. If we don't push a new commit anyway, we re-apply the
+		// diff of the changeset to the new base and sync it afterwards, like
+		// above.
+		if needsReapplyDiff(ch, currentSpec) && !delta.NeedCommitUpdate() {
+			pl.AddOp(btypes.ReconcilerOperationReapplyDiff)
+			pl.AddOp(btypes.ReconcilerOperationSleep)
+			pl.AddOp(btypes.ReconcilerOperationSync)
+		}
+
 	default:
 		return pl, errors.Errorf("unknown changeset publication state: %s", ch.PublicationState)
 	}
@@ -241,6 +310,18 @@ func DeterminePlan(previousSpec, currentSpec *btypes.ChangesetSpec, ch *btypes.C
 	return pl, nil
 }
 
+// needsReapplyDiff returns whether the diff of the changeset should be
+// re-applied to the base branch commit recorded by the rebaser.
+func needsReapplyDiff(ch *btypes.Changeset, spec *btypes.ChangesetSpec) bool {
+	if !spec.Spec.AutoReapplyDiff || ch.RebaseBaseRev == "" {
+		return false
+	}
+	if ch.ExternalState != btypes.ChangesetExternalStateOpen && ch.ExternalState != btypes.ChangesetExternalStateDraft {
+		return false
+	}
+	return ch.ConflictState == btypes.ChangesetConflictStateBehind
+}
+
 func reopenAfterDetach(ch *btypes.Changeset) bool {
 	closed := ch.ExternalState == btypes.ChangesetExternalStateClosed
 	if !closed {
@@ -339,9 +420,45 @@ func compareChangesetSpecs(previous, current *btypes.ChangesetSpec, uiPublicatio
 		delta.AuthorEmailChanged = true
 	}
 
+	compareChangesetSpecMetadata(previous, current, delta)
+
 	return delta, nil
 }
 
+// compareChangesetSpecMetadata sets the fields of the given delta that describe
+// whether the reviewers, labels, assignees or auto-merge setting changed between
+// the two changeset specs. The order of reviewers, labels and assignees is
+// ignored.
+func compareChangesetSpecMetadata(previous, current *btypes.ChangesetSpec, delta *ChangesetSpecDelta) {
+	if !equalStringSets(previous.Spec.Reviewers, current.Spec.Reviewers) {
+		delta.ReviewersChanged = true
+	}
+	if !equalStringSets(previous.Spec.Labels, current.Spec.Labels) {
+		delta.LabelsChanged = true
+	}
+	if !equalStringSets(previous.Spec.Assignees, current.Spec.Assignees) {
+		delta.AssigneesChanged = true
+	}
+	if previous.Spec.AutoMerge != current.Spec.AutoMerge {
+		delta.AutoMergeChanged = true
+	}
+}
+
+func equalStringSets(a, b []string) bool {
+	set := make(map[string]struct{}, len(a))
+	for _, v := range a {
+		set[v] = struct{}{}
+	}
+	other := make(map[string]struct{}, len(b))
+	for _, v := range b {
+		if _, ok := set[v]; !ok {
+			return false
+		}
+		other[v] = struct{}{}
+	}
+	return len(set) == len(other)
+}
+
 type ChangesetSpecDelta struct {
 	TitleChanged         bool
 	BodyChanged          bool
@@ -351,6 +468,10 @@ type ChangesetSpecDelta struct {
 	CommitMessageChanged bool
 	AuthorNameChanged    bool
 	AuthorEmailChanged   bool
+	ReviewersChanged     bool
+	LabelsChanged        bool
+	AssigneesChanged     bool
+	AutoMergeChanged     bool
 }
 
 func (d *ChangesetSpecDelta) String() string { return fmt.Sprintf("%#v", d) }
@@ -363,6 +484,17 @@ func (d *ChangesetSpecDelta) NeedCodeHostUpdate() bool {
 	return d.TitleChanged || d.BodyChanged || d.BaseRefChanged
 }
 
+// NeedMetadataUpdate returns whether the reviewers, labels, assignees or
+// auto-merge setting of the changeset need to be updated on the code host. Only
+// changes to attributes that are supported by the given code host type are
+// taken into account.
+func (d *ChangesetSpecDelta) NeedMetadataUpdate(extSvcType string) bool {
+	return (d.ReviewersChanged && btypes.ExternalServiceSupports(extSvcType, btypes.CodehostCapabilityReviewers)) ||
+		(d.LabelsChanged && btypes.ExternalServiceSupports(extSvcType, btypes.CodehostCapabilityLabels)) ||
+		(d.AssigneesChanged && btypes.ExternalServiceSupports(extSvcType, btypes.CodehostCapabilityAssignees)) ||
+		(d.AutoMergeChanged && btypes.ExternalServiceSupports(extSvcType, btypes.CodehostCapabilityAutoMerge))
+}
+
 func (d *ChangesetSpecDelta) AttributesChanged() bool {
 	return d.NeedCommitUpdate() || d.NeedCodeHostUpdate()
 }
@@ -394,6 +394,116 @@ func TestDetermineReconcilerPlan(t *testing.T) {
 				btypes.ReconcilerOperationImport,
 			},
 		},
+		{
+			name:        "publish with metadata",
+			currentSpec: &ct.TestSpecOpts{Published: true, Reviewers: []string{"alice"}, Labels: []string{"automated"}},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStateUnpublished,
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationPublish,
+				btypes.ReconcilerOperationUpdateMetadata,
+			},
+		},
+		{
+			name:        "publish with unsupported metadata",
+			currentSpec: &ct.TestSpecOpts{Published: true, Labels: []string{"automated"}, AutoMerge: true},
+			changeset: ct.TestChangesetOpts{
+				ExternalServiceType: extsvc.TypeBitbucketServer,
+				PublicationState:    btypes.ChangesetPublicationStateUnpublished,
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationPublish,
+			},
+		},
+		{
+			name:         "reviewers changed",
+			previousSpec: &ct.TestSpecOpts{Published: true, Reviewers: []string{"alice", "bob"}},
+			currentSpec:  &ct.TestSpecOpts{Published: true, Reviewers: []string{"bob", "carol"}},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{btypes.ReconcilerOperationUpdateMetadata},
+		},
+		{
+			name:         "labels reordered",
+			previousSpec: &ct.TestSpecOpts{Published: true, Labels: []string{"a", "b"}},
+			currentSpec:  &ct.TestSpecOpts{Published: true, Labels: []string{"b", "a"}},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "auto-merge enabled on undraft",
+			previousSpec: &ct.TestSpecOpts{Published: "draft", AutoMerge: true},
+			currentSpec:  &ct.TestSpecOpts{Published: true, AutoMerge: true},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationUndraft,
+				btypes.ReconcilerOperationUpdateMetadata,
+			},
+		},
+		{
+			name:         "behind base branch with autoReapplyDiff",
+			previousSpec: &ct.TestSpecOpts{Published: true, AutoReapplyDiff: true},
+			currentSpec:  &ct.TestSpecOpts{Published: true, AutoReapplyDiff: true},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				ConflictState:    btypes.ChangesetConflictStateBehind,
+				RebaseBaseRev:    "d34db33f",
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationReapplyDiff,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+			},
+		},
+		{
+			name:         "behind base branch with autoReapplyDiff and new diff",
+			previousSpec: &ct.TestSpecOpts{Published: true, AutoReapplyDiff: true, CommitDiff: "a"},
+			currentSpec:  &ct.TestSpecOpts{Published: true, AutoReapplyDiff: true, CommitDiff: "b"},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				ConflictState:    btypes.ChangesetConflictStateBehind,
+				RebaseBaseRev:    "d34db33f",
+			},
+			wantOperations: Operations{
+				btypes.ReconcilerOperationPush,
+				btypes.ReconcilerOperationSleep,
+				btypes.ReconcilerOperationSync,
+			},
+		},
+		{
+			name:         "conflicting with base branch",
+			previousSpec: &ct.TestSpecOpts{Published: true, AutoReapplyDiff: true},
+			currentSpec:  &ct.TestSpecOpts{Published: true, AutoReapplyDiff: true},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				ConflictState:    btypes.ChangesetConflictStateConflicting,
+				RebaseBaseRev:    "d34db33f",
+			},
+			wantOperations: Operations{},
+		},
+		{
+			name:         "behind base branch without autoReapplyDiff",
+			previousSpec: &ct.TestSpecOpts{Published: true},
+			currentSpec:  &ct.TestSpecOpts{Published: true},
+			changeset: ct.TestChangesetOpts{
+				PublicationState: btypes.ChangesetPublicationStatePublished,
+				ExternalState:    btypes.ChangesetExternalStateOpen,
+				ConflictState:    btypes.ChangesetConflictStateBehind,
+				RebaseBaseRev:    "d34db33f",
+			},
+			wantOperations: Operations{},
+		},
 	}
 
 	for _, tc := range tcs {
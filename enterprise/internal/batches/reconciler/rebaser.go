@@ -0,0 +1,169 @@
+package reconciler
+
+import (
+	"context"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const rebaserInterval = 5 * time.Minute
+
+// RebaserGitserverClient is the subset of the gitserver client that the
+// rebaser uses to compare changeset branches with their base branch.
+type RebaserGitserverClient interface {
+	ResolveRevision(ctx context.Context, repo api.RepoName, spec string, opt gitserver.ResolveRevisionOptions) (api.CommitID, error)
+	GetBehindAhead(ctx context.Context, repo api.RepoName, left, right string) (*gitdomain.BehindAhead, error)
+}
+
+// NewRebaser returns a background routine that periodically compares the
+// branches of open changesets that enable autoReapplyDiff with their base
+// branch. It records whether a changeset is up to date with or behind its base
+// branch, and enqueues changesets that are behind, so that the reconciler
+// re-applies their diff to the new base.
+func NewRebaser(ctx context.Context, bstore *store.Store, client RebaserGitserverClient) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		rebaserInterval,
+		goroutine.NewHandlerWithErrorMessage("batch changes rebaser", func(ctx context.Context) error {
+			return enqueueChangesetsToRebase(ctx, bstore, client)
+		}),
+	)
+}
+
+func enqueueChangesetsToRebase(ctx context.Context, bstore *store.Store, client RebaserGitserverClient) error {
+	cs, err := bstore.ListChangesetsToRebase(ctx)
+	if err != nil {
+		return errors.Wrap(err, "listing changesets to rebase")
+	}
+	if len(cs) == 0 {
+		return nil
+	}
+
+	repos, err := bstore.Repos().GetReposSetByIDs(ctx, cs.RepoIDs()...)
+	if err != nil {
+		return errors.Wrap(err, "loading repositories")
+	}
+
+	var errs error
+	for _, ch := range cs {
+		repo, ok := repos[ch.RepoID]
+		if !ok {
+			continue
+		}
+
+		spec, err := bstore.GetChangesetSpecByID(ctx, ch.CurrentSpecID)
+		if err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "loading changeset spec for changeset %d", ch.ID))
+			continue
+		}
+
+		changed, err := updateChangesetConflictState(ctx, client, repo, spec, ch)
+		if err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "comparing changeset %d with its base branch", ch.ID))
+			continue
+		}
+		if !changed {
+			continue
+		}
+
+		if err := bstore.UpdateChangesetConflictState(ctx, ch); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "updating conflict state of changeset %d", ch.ID))
+			continue
+		}
+
+		if ch.ConflictState == btypes.ChangesetConflictStateBehind {
+			log15.Debug("enqueueing changeset to re-apply its diff", "changeset", ch.ID, "base", ch.RebaseBaseRev)
+			if err := bstore.EnqueueChangeset(ctx, ch, global.DefaultReconcilerEnqueueState(), btypes.ReconcilerStateCompleted); err != nil {
+				errs = errors.Append(errs, errors.Wrapf(err, "enqueueing changeset %d", ch.ID))
+			}
+		}
+	}
+
+	return errs
+}
+
+// updateChangesetConflictState compares the changeset branch with the latest
+// commit of its base branch and sets the conflict state and rebase base
+// revision of the changeset accordingly. It returns false if the changeset
+// wasn't updated, either because the base branch hasn't moved since the
+// changeset was last compared with it, or because it can't be compared yet.
+func updateChangesetConflictState(ctx context.Context, client RebaserGitserverClient, repo *types.Repo, spec *btypes.ChangesetSpec, ch *btypes.Changeset) (bool, error) {
+	// The head commit of changesets opened on a fork only exists in the fork.
+	if ch.ExternalForkNamespace != "" {
+		return false, nil
+	}
+
+	base, err := client.ResolveRevision(ctx, repo.Name, spec.Spec.BaseRef, gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return false, errors.Wrap(err, "resolving base branch")
+	}
+
+	// If the code host reports conflicts, the diff won't apply to the base
+	// branch either.
+	if ch.ExternalConflicting() {
+		if string(base) == ch.RebaseBaseRev && ch.ConflictState == btypes.ChangesetConflictStateConflicting {
+			return false, nil
+		}
+		ch.RebaseBaseRev = string(base)
+		ch.ConflictState = btypes.ChangesetConflictStateConflicting
+		return true, nil
+	}
+
+	// We already looked at the changeset with this base commit, and either
+	// re-applied its diff to it or found that it conflicts.
+	if string(base) == ch.RebaseBaseRev && ch.ConflictState != "" && ch.ConflictState != btypes.ChangesetConflictStateUnknown {
+		return false, nil
+	}
+
+	// The head commit is taken from the metadata synced from the code host,
+	// since the changeset branch isn't necessarily fetched by gitserver.
+	head, err := ch.HeadRefOid()
+	if err != nil {
+		return false, errors.Wrap(err, "getting head commit")
+	}
+	if head == "" {
+		// The code host doesn't report the head commit.
+		return false, nil
+	}
+	if _, err := client.ResolveRevision(ctx, repo.Name, head, gitserver.ResolveRevisionOptions{}); err != nil {
+		if errors.HasType(err, &gitdomain.RevisionNotFoundError{}) {
+			// gitserver fetches the commit in the background, so we compare
+			// the changeset the next time around.
+			return false, nil
+		}
+		return false, errors.Wrap(err, "resolving head commit")
+	}
+
+	// Behind counts the commits of the base branch that the changeset branch
+	// doesn't contain, and Ahead the commits of the changeset branch that the
+	// base branch doesn't contain.
+	behindAhead, err := client.GetBehindAhead(ctx, repo.Name, string(base), head)
+	if err != nil {
+		return false, errors.Wrap(err, "comparing with base branch")
+	}
+
+	ch.RebaseBaseRev = string(base)
+	switch {
+	case behindAhead.Behind == 0:
+		ch.ConflictState = btypes.ChangesetConflictStateUpToDate
+	case behindAhead.Ahead == 0:
+		// The base branch already contains the commits of the changeset
+		// branch, so there's no diff to re-apply.
+		ch.ConflictState = btypes.ChangesetConflictStateUpToDate
+	default:
+		// The branches diverged.
+		ch.ConflictState = btypes.ChangesetConflictStateBehind
+	}
+	return true, nil
+}
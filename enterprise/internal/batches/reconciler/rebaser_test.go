@@ -0,0 +1,172 @@
+package reconciler
+
+import (
+	"context"
+	"testing"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+type fakeRebaserGitserverClient struct {
+	base        api.CommitID
+	missingHead bool
+	behindAhead gitdomain.BehindAhead
+
+	compared [][2]string
+}
+
+func (c *fakeRebaserGitserverClient) ResolveRevision(_ context.Context, repo api.RepoName, spec string, _ gitserver.ResolveRevisionOptions) (api.CommitID, error) {
+	if spec == "refs/heads/main" {
+		return c.base, nil
+	}
+	if c.missingHead {
+		return "", &gitdomain.RevisionNotFoundError{Repo: repo, Spec: spec}
+	}
+	return api.CommitID(spec), nil
+}
+
+func (c *fakeRebaserGitserverClient) GetBehindAhead(_ context.Context, _ api.RepoName, left, right string) (*gitdomain.BehindAhead, error) {
+	c.compared = append(c.compared, [2]string{left, right})
+	return &c.behindAhead, nil
+}
+
+func TestUpdateChangesetConflictState(t *testing.T) {
+	ctx := context.Background()
+	repo := &types.Repo{Name: "github.com/sourcegraph/sourcegraph"}
+	spec := &btypes.ChangesetSpec{Spec: &batcheslib.ChangesetSpec{
+		BaseRef:         "refs/heads/main",
+		HeadRef:         "refs/heads/batch-change",
+		AutoReapplyDiff: true,
+	}}
+
+	changeset := func(state btypes.ChangesetConflictState, rebaseBaseRev string) *btypes.Changeset {
+		return &btypes.Changeset{
+			Metadata:      &github.PullRequest{HeadRefOid: "h34d", Mergeable: "MERGEABLE"},
+			ConflictState: state,
+			RebaseBaseRev: rebaseBaseRev,
+		}
+	}
+	diverged := gitdomain.BehindAhead{Behind: 2, Ahead: 1}
+
+	tcs := []struct {
+		name        string
+		changeset   *btypes.Changeset
+		behindAhead gitdomain.BehindAhead
+		missingHead bool
+
+		wantChanged  bool
+		wantCompared bool
+		wantState    btypes.ChangesetConflictState
+	}{
+		{
+			name:         "not compared yet, up to date",
+			changeset:    changeset("", ""),
+			behindAhead:  gitdomain.BehindAhead{Behind: 0, Ahead: 1},
+			wantChanged:  true,
+			wantCompared: true,
+			wantState:    btypes.ChangesetConflictStateUpToDate,
+		},
+		{
+			name:         "not compared yet, diverged",
+			changeset:    changeset("", ""),
+			behindAhead:  diverged,
+			wantChanged:  true,
+			wantCompared: true,
+			wantState:    btypes.ChangesetConflictStateBehind,
+		},
+		{
+			name:         "base branch contains the changeset branch",
+			changeset:    changeset("", ""),
+			behindAhead:  gitdomain.BehindAhead{Behind: 3, Ahead: 0},
+			wantChanged:  true,
+			wantCompared: true,
+			wantState:    btypes.ChangesetConflictStateUpToDate,
+		},
+		{
+			name:         "base branch moved",
+			changeset:    changeset(btypes.ChangesetConflictStateConflicting, "0ld"),
+			behindAhead:  diverged,
+			wantChanged:  true,
+			wantCompared: true,
+			wantState:    btypes.ChangesetConflictStateBehind,
+		},
+		{
+			name:        "base branch didn't move",
+			changeset:   changeset(btypes.ChangesetConflictStateConflicting, "d34db33f"),
+			behindAhead: diverged,
+			wantState:   btypes.ChangesetConflictStateConflicting,
+		},
+		{
+			name:        "head commit not fetched yet",
+			changeset:   changeset("", ""),
+			behindAhead: diverged,
+			missingHead: true,
+		},
+		{
+			name: "head commit unknown",
+			changeset: &btypes.Changeset{
+				Metadata: &github.PullRequest{},
+			},
+			behindAhead: diverged,
+		},
+		{
+			name: "opened on a fork",
+			changeset: &btypes.Changeset{
+				Metadata:              &github.PullRequest{HeadRefOid: "h34d"},
+				ExternalForkNamespace: "fork",
+			},
+			behindAhead: diverged,
+		},
+		{
+			name: "code host reports conflicts",
+			changeset: &btypes.Changeset{
+				Metadata:      &github.PullRequest{HeadRefOid: "h34d", Mergeable: "CONFLICTING"},
+				ConflictState: btypes.ChangesetConflictStateUpToDate,
+				RebaseBaseRev: "d34db33f",
+			},
+			behindAhead: diverged,
+			wantChanged: true,
+			wantState:   btypes.ChangesetConflictStateConflicting,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakeRebaserGitserverClient{
+				base:        "d34db33f",
+				missingHead: tc.missingHead,
+				behindAhead: tc.behindAhead,
+			}
+
+			changed, err := updateChangesetConflictState(ctx, client, repo, spec, tc.changeset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if changed != tc.wantChanged {
+				t.Errorf("unexpected changed: want=%t have=%t", tc.wantChanged, changed)
+			}
+			if compared := len(client.compared) > 0; compared != tc.wantCompared {
+				t.Errorf("unexpected comparison: want=%t have=%t", tc.wantCompared, compared)
+			}
+			if tc.wantCompared {
+				// The branch is compared by the head commit of the synced
+				// metadata, not by the name of the changeset branch.
+				if have, want := client.compared[0], [2]string{"d34db33f", "h34d"}; have != want {
+					t.Errorf("unexpected compared revisions: want=%v have=%v", want, have)
+				}
+			}
+			if have := tc.changeset.ConflictState; have != tc.wantState {
+				t.Errorf("unexpected conflict state: want=%q have=%q", tc.wantState, have)
+			}
+			if have, want := tc.changeset.RebaseBaseRev, "d34db33f"; tc.wantChanged && have != want {
+				t.Errorf("unexpected rebase base rev: want=%q have=%q", want, have)
+			}
+		})
+	}
+}
@@ -79,6 +79,17 @@ func (r *Reconciler) process(ctx context.Context, logger log.Logger, tx *store.S
 		return err
 	}
 
+	// Changesets that depend on other changesets wait for their dependencies
+	// to be merged: depending on the gate of the dependencies they stay
+	// unpublished or are only published as drafts. The dependency gate
+	// re-enqueues them once the dependencies have landed.
+	if proceed, err := applyDependencyGates(ctx, tx, plan); err != nil {
+		return err
+	} else if !proceed {
+		logger.Info("Reconciler holding back changeset with unmerged dependencies", log.Int64("changeset", ch.ID))
+		return nil
+	}
+
 	logger.Info("Reconciler processing changeset", log.Int64("changeset", ch.ID), log.String("operations", fmt.Sprintf("%+v", plan.Ops)))
 
 	return executePlan(
@@ -13,6 +13,10 @@ type ChangesetRewirer struct {
 	// The mappings need to be hydrated for the ChangesetRewirer to consume them.
 	mappings      btypes.RewirerMappings
 	batchChangeID int64
+
+	// keepAttached are the IDs of changesets that stay attached to the batch
+	// change although they weren't matched to a ChangesetSpec.
+	keepAttached map[int64]struct{}
 }
 
 func New(mappings btypes.RewirerMappings, batchChangeID int64) *ChangesetRewirer {
@@ -22,6 +26,19 @@ func New(mappings btypes.RewirerMappings, batchChangeID int64) *ChangesetRewirer
 	}
 }
 
+// KeepAttached marks the given changesets to stay attached to the batch change
+// when they aren't matched to a ChangesetSpec. This is used for changesets
+// that are tracked because they match an importChangesets query, since those
+// don't have a ChangesetSpec.
+func (r *ChangesetRewirer) KeepAttached(ids ...int64) {
+	if r.keepAttached == nil {
+		r.keepAttached = make(map[int64]struct{}, len(ids))
+	}
+	for _, id := range ids {
+		r.keepAttached[id] = struct{}{}
+	}
+}
+
 // Rewire uses RewirerMappings (mapping ChangesetSpecs to matching Changesets) generated by Store.GetRewirerMappings to update the Changesets
 // for consumption by the background reconciler.
 //
@@ -44,6 +61,12 @@ func (r *ChangesetRewirer) Rewire() (changesets []*btypes.Changeset, err error)
 				continue
 			}
 
+			// If the changeset is tracked through an importChangesets query,
+			// the importer detaches it once it no longer matches.
+			if _, ok := r.keepAttached[changeset.ID]; ok {
+				continue
+			}
+
 			r.closeChangeset(changeset)
 			changesets = append(changesets, changeset)
 
@@ -448,6 +448,29 @@ func TestRewirer_Rewire(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("no spec matching changeset imported by query", func(t *testing.T) {
+		changeset := ct.BuildChangeset(ct.TestChangesetOpts{
+			Repo:         testRepoID,
+			BatchChanges: []btypes.BatchChangeAssoc{{BatchChangeID: testBatchChangeID}},
+		})
+		changeset.ID = 42
+
+		r := New(btypes.RewirerMappings{{Changeset: changeset, Repo: testRepo}}, testBatchChangeID)
+		r.KeepAttached(changeset.ID)
+
+		changesets, err := r.Rewire()
+		if err != nil {
+			t.Fatal(err)
+		}
+		// The changeset should neither be detached nor closed.
+		if len(changesets) != 0 {
+			t.Fatalf("incorrect amount of changesets returned. want=0 have=%d", len(changesets))
+		}
+		if !changeset.AttachedTo(testBatchChangeID) {
+			t.Fatal("changeset was detached")
+		}
+	})
 }
 
 func assertResetReconcilerState(a ct.ChangesetAssertions) ct.ChangesetAssertions {
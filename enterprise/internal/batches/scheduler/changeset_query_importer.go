@@ -0,0 +1,351 @@
+package scheduler
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const changesetQueryImportInterval = 15 * time.Minute
+
+// NewChangesetQueryImporter returns a background routine that periodically
+// runs the importChangesets queries of open batch changes against the code
+// hosts. Matching pull and merge requests are attached to the batch change as
+// tracked changesets, and changesets that were attached by a query but no
+// longer match are detached again.
+func NewChangesetQueryImporter(ctx context.Context, bstore *store.Store, sourcer sources.Sourcer) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		changesetQueryImportInterval,
+		goroutine.NewHandlerWithErrorMessage("batch changes changeset query importer", func(ctx context.Context) error {
+			return importChangesetsByQuery(ctx, bstore, sourcer)
+		}),
+	)
+}
+
+func importChangesetsByQuery(ctx context.Context, bstore *store.Store, sourcer sources.Sourcer) error {
+	batchChanges, _, err := bstore.ListBatchChanges(ctx, store.ListBatchChangesOpts{
+		States:                []btypes.BatchChangeState{btypes.BatchChangeStateOpen},
+		OnlyWithImportQueries: true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing batch changes")
+	}
+	if len(batchChanges) == 0 {
+		return nil
+	}
+
+	codeHosts, err := bstore.ListCodeHosts(ctx, store.ListCodeHostsOpts{})
+	if err != nil {
+		return errors.Wrap(err, "listing code hosts")
+	}
+
+	var errs error
+	for _, bc := range batchChanges {
+		if err := importChangesetsForBatchChange(ctx, bstore, sourcer, codeHosts, bc); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "importing changesets for batch change %d", bc.ID))
+		}
+	}
+	return errs
+}
+
+// searchedChangesetKey identifies a changeset returned by a code host search.
+type searchedChangesetKey struct {
+	repoID     api.RepoID
+	externalID string
+}
+
+func importChangesetsForBatchChange(ctx context.Context, bstore *store.Store, sourcer sources.Sourcer, codeHosts []*btypes.CodeHost, bc *btypes.BatchChange) error {
+	spec, err := bstore.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: bc.BatchSpecID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch spec")
+	}
+	queries := spec.Spec.ImportChangesetQueries()
+	if len(queries) == 0 {
+		return nil
+	}
+
+	// complete is only true if every query was run on every code host it
+	// targets and returned all of its results. We must not detach changesets
+	// otherwise, since they may still match on the code host we couldn't
+	// search.
+	complete := true
+	var (
+		errs    error
+		matches = make(map[searchedChangesetKey]*types.Repo)
+	)
+	for _, ch := range codeHosts {
+		if ch.ExternalServiceType != extsvc.TypeGitHub && ch.ExternalServiceType != extsvc.TypeGitLab {
+			continue
+		}
+		targeted := queriesForCodeHost(queries, ch)
+		if len(targeted) == 0 {
+			continue
+		}
+
+		found, hostComplete, err := searchCodeHost(ctx, bstore, sourcer, ch, bc, targeted)
+		if err != nil {
+			complete = false
+			if !errors.Is(err, sources.ErrMissingCredentials) {
+				errs = errors.Append(errs, errors.Wrapf(err, "searching %s", ch.ExternalServiceID))
+			}
+			continue
+		}
+		if !hostComplete {
+			complete = false
+			errs = errors.Append(errs, errors.Newf(
+				"searching %s: a query matched more than %d changesets, so only those were imported and no changesets were detached",
+				ch.ExternalServiceID, sources.MaxSearchedChangesets,
+			))
+		}
+		for k, repo := range found {
+			matches[k] = repo
+		}
+	}
+
+	if err := updateChangesetQueryImports(ctx, bstore, bc.ID, matches, complete); err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return errs
+}
+
+// queriesForCodeHost returns the importChangesets queries that run on the
+// given code host: the ones that target it through codeHost, and the ones
+// that don't target a specific code host.
+func queriesForCodeHost(queries []batcheslib.ImportChangeset, ch *btypes.CodeHost) []batcheslib.ImportChangeset {
+	var targeted []batcheslib.ImportChangeset
+	for _, q := range queries {
+		if q.CodeHost == "" {
+			targeted = append(targeted, q)
+			continue
+		}
+		u, err := url.Parse(q.CodeHost)
+		if err != nil {
+			continue
+		}
+		if extsvc.NormalizeBaseURL(u).String() == ch.ExternalServiceID {
+			targeted = append(targeted, q)
+		}
+	}
+	return targeted
+}
+
+// updateChangesetQueryImports attaches the matched changesets to the batch
+// change, creating tracking changesets for the ones that don't exist yet. If
+// complete is true, the changesets previously imported by query that no
+// longer match are detached.
+func updateChangesetQueryImports(ctx context.Context, bstore *store.Store, batchChangeID int64, matches map[searchedChangesetKey]*types.Repo, complete bool) (err error) {
+	tx, err := bstore.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	previous, err := tx.ListChangesetQueryImports(ctx, batchChangeID)
+	if err != nil {
+		return errors.Wrap(err, "listing changesets imported by query")
+	}
+	previouslyImported := make(map[int64]struct{}, len(previous))
+	for _, id := range previous {
+		previouslyImported[id] = struct{}{}
+	}
+
+	imported := make([]int64, 0, len(matches))
+	for k, repo := range matches {
+		changeset, err := tx.GetChangeset(ctx, store.GetChangesetOpts{
+			RepoID:              k.repoID,
+			ExternalID:          k.externalID,
+			ExternalServiceType: repo.ExternalRepo.ServiceType,
+		})
+		if err != nil && err != store.ErrNoResults {
+			return errors.Wrap(err, "loading changeset")
+		}
+
+		if changeset == nil {
+			changeset = newQueryImportedChangeset(repo, k.externalID, batchChangeID)
+			if err := tx.CreateChangeset(ctx, changeset); err != nil {
+				return errors.Wrap(err, "creating changeset")
+			}
+		} else {
+			_, wasImported := previouslyImported[changeset.ID]
+			if !attachQueryImportedChangeset(changeset, batchChangeID, wasImported) {
+				continue
+			}
+			if err := tx.UpdateChangeset(ctx, changeset); err != nil {
+				return errors.Wrap(err, "updating changeset")
+			}
+		}
+		imported = append(imported, changeset.ID)
+	}
+
+	if err := tx.CreateChangesetQueryImports(ctx, batchChangeID, imported); err != nil {
+		return errors.Wrap(err, "recording changesets imported by query")
+	}
+
+	if !complete {
+		return nil
+	}
+
+	stillImported := make(map[int64]struct{}, len(imported))
+	for _, id := range imported {
+		stillImported[id] = struct{}{}
+	}
+
+	var stale []int64
+	for _, id := range previous {
+		if _, ok := stillImported[id]; ok {
+			continue
+		}
+		stale = append(stale, id)
+
+		changeset, err := tx.GetChangeset(ctx, store.GetChangesetOpts{ID: id})
+		if err != nil {
+			return errors.Wrap(err, "loading changeset")
+		}
+		if changeset.Detach(batchChangeID) {
+			changeset.ResetReconcilerState(global.DefaultReconcilerEnqueueState())
+			if err := tx.UpdateChangeset(ctx, changeset); err != nil {
+				return errors.Wrap(err, "detaching changeset")
+			}
+		}
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+	return tx.DeleteChangesetQueryImports(ctx, batchChangeID, stale)
+}
+
+// searchCodeHost runs the given queries on the code host with the credentials
+// of the last applier of the batch change, and returns the matching changesets
+// in repositories the last applier has access to. complete is false if the
+// results of a query were truncated.
+//
+// Queries that don't target the code host explicitly are skipped if the code
+// host doesn't support their syntax, since they are meant for other code
+// hosts.
+func searchCodeHost(ctx context.Context, bstore *store.Store, sourcer sources.Sourcer, ch *btypes.CodeHost, bc *btypes.BatchChange, queries []batcheslib.ImportChangeset) (found map[searchedChangesetKey]*types.Repo, complete bool, err error) {
+	css, err := sourcer.ForExternalService(ctx, bstore, store.GetExternalServiceIDsOpts{
+		ExternalServiceType: ch.ExternalServiceType,
+		ExternalServiceID:   ch.ExternalServiceID,
+	})
+	if err != nil {
+		return nil, false, errors.Wrap(err, "loading changeset source")
+	}
+	css, err = sources.WithAuthenticatorForUser(ctx, bstore, css, bc.LastApplierID, &types.Repo{
+		ExternalRepo: api.ExternalRepoSpec{
+			ServiceType: ch.ExternalServiceType,
+			ServiceID:   ch.ExternalServiceID,
+		},
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	searchable, err := sources.ToSearchableChangesetSource(css)
+	if err != nil {
+		return nil, false, err
+	}
+
+	complete = true
+	var results []*sources.SearchedChangeset
+	for _, q := range queries {
+		rs, truncated, err := searchable.SearchChangesets(ctx, q.Query)
+		if err != nil {
+			if q.CodeHost == "" && errors.Is(err, sources.ErrUnsupportedSearchQuery) {
+				continue
+			}
+			return nil, false, errors.Wrapf(err, "running query %q", q.Query)
+		}
+		if truncated {
+			complete = false
+		}
+		results = append(results, rs...)
+	}
+	if len(results) == 0 {
+		return nil, complete, nil
+	}
+
+	specs := make([]api.ExternalRepoSpec, 0, len(results))
+	seen := make(map[string]struct{}, len(results))
+	for _, r := range results {
+		if _, ok := seen[r.RepoExternalID]; ok {
+			continue
+		}
+		seen[r.RepoExternalID] = struct{}{}
+		specs = append(specs, api.ExternalRepoSpec{
+			ID:          r.RepoExternalID,
+			ServiceType: ch.ExternalServiceType,
+			ServiceID:   ch.ExternalServiceID,
+		})
+	}
+
+	// 🚨 SECURITY: We list the repositories as the last applier, so that only
+	// changesets in repositories they have access to are imported.
+	userCtx := actor.WithActor(ctx, actor.FromUser(bc.LastApplierID))
+	repos, err := bstore.Repos().List(userCtx, database.ReposListOptions{ExternalRepos: specs})
+	if err != nil {
+		return nil, false, errors.Wrap(err, "loading repositories")
+	}
+	reposByExternalID := make(map[string]*types.Repo, len(repos))
+	for _, repo := range repos {
+		reposByExternalID[repo.ExternalRepo.ID] = repo
+	}
+
+	found = make(map[searchedChangesetKey]*types.Repo, len(results))
+	for _, r := range results {
+		repo, ok := reposByExternalID[r.RepoExternalID]
+		if !ok {
+			continue
+		}
+		found[searchedChangesetKey{repoID: repo.ID, externalID: r.ExternalID}] = repo
+	}
+	return found, complete, nil
+}
+
+// newQueryImportedChangeset returns a tracking changeset for a pull or merge
+// request that isn't known to Sourcegraph yet.
+func newQueryImportedChangeset(repo *types.Repo, externalID string, batchChangeID int64) *btypes.Changeset {
+	return &btypes.Changeset{
+		RepoID:              repo.ID,
+		ExternalServiceType: repo.ExternalRepo.ServiceType,
+
+		BatchChanges: []btypes.BatchChangeAssoc{{BatchChangeID: batchChangeID}},
+		ExternalID:   externalID,
+
+		PublicationState: btypes.ChangesetPublicationStateUnpublished,
+
+		// Enqueue it so the reconciler syncs it.
+		ReconcilerState: btypes.ReconcilerStateQueued,
+	}
+}
+
+// attachQueryImportedChangeset attaches an existing changeset that matched an
+// importChangesets query to the batch change. It returns false if the
+// changeset is already attached to the batch change through other means, such
+// as a changeset spec, in which case it must not be recorded as imported by
+// query.
+func attachQueryImportedChangeset(changeset *btypes.Changeset, batchChangeID int64, wasImported bool) bool {
+	if changeset.AttachedTo(batchChangeID) && !wasImported {
+		return false
+	}
+
+	changeset.Attach(batchChangeID)
+
+	// If it's errored and not created by another batch change, we re-enqueue it.
+	if changeset.OwnedByBatchChangeID == 0 && (changeset.ReconcilerState == btypes.ReconcilerStateErrored || changeset.ReconcilerState == btypes.ReconcilerStateFailed) {
+		changeset.ResetReconcilerState(global.DefaultReconcilerEnqueueState())
+	}
+	return true
+}
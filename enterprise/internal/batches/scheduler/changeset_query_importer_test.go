@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/global"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestAttachQueryImportedChangeset(t *testing.T) {
+	const batchChangeID = 1
+
+	tcs := []struct {
+		name        string
+		changeset   *btypes.Changeset
+		wasImported bool
+
+		wantAttached bool
+		wantState    btypes.ReconcilerState
+	}{
+		{
+			name:         "not attached",
+			changeset:    &btypes.Changeset{ReconcilerState: btypes.ReconcilerStateCompleted},
+			wantAttached: true,
+			wantState:    btypes.ReconcilerStateCompleted,
+		},
+		{
+			name:         "not attached and errored",
+			changeset:    &btypes.Changeset{ReconcilerState: btypes.ReconcilerStateFailed},
+			wantAttached: true,
+			wantState:    global.DefaultReconcilerEnqueueState(),
+		},
+		{
+			name: "attached by changeset spec",
+			changeset: &btypes.Changeset{
+				BatchChanges:    []btypes.BatchChangeAssoc{{BatchChangeID: batchChangeID}},
+				ReconcilerState: btypes.ReconcilerStateCompleted,
+			},
+			wantAttached: false,
+			wantState:    btypes.ReconcilerStateCompleted,
+		},
+		{
+			name: "attached by query",
+			changeset: &btypes.Changeset{
+				BatchChanges:    []btypes.BatchChangeAssoc{{BatchChangeID: batchChangeID}},
+				ReconcilerState: btypes.ReconcilerStateCompleted,
+			},
+			wasImported:  true,
+			wantAttached: true,
+			wantState:    btypes.ReconcilerStateCompleted,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if have, want := attachQueryImportedChangeset(tc.changeset, batchChangeID, tc.wasImported), tc.wantAttached; have != want {
+				t.Fatalf("wrong result: have=%t want=%t", have, want)
+			}
+			if !tc.changeset.AttachedTo(batchChangeID) {
+				t.Fatal("changeset is not attached to the batch change")
+			}
+			if have, want := tc.changeset.ReconcilerState, tc.wantState; have != want {
+				t.Fatalf("wrong reconciler state: have=%s want=%s", have, want)
+			}
+		})
+	}
+}
+
+func TestQueriesForCodeHost(t *testing.T) {
+	queries := []batcheslib.ImportChangeset{
+		{Query: "author:renovate"},
+		{Query: "org:sourcegraph author:renovate", CodeHost: "https://GitHub.com"},
+		{Query: "label:security", CodeHost: "https://gitlab.com/"},
+	}
+
+	for name, tc := range map[string]struct {
+		codeHost *btypes.CodeHost
+		want     []string
+	}{
+		"github.com": {
+			codeHost: &btypes.CodeHost{ExternalServiceType: extsvc.TypeGitHub, ExternalServiceID: "https://github.com/"},
+			want:     []string{"author:renovate", "org:sourcegraph author:renovate"},
+		},
+		"gitlab.com": {
+			codeHost: &btypes.CodeHost{ExternalServiceType: extsvc.TypeGitLab, ExternalServiceID: "https://gitlab.com/"},
+			want:     []string{"author:renovate", "label:security"},
+		},
+		"other code host": {
+			codeHost: &btypes.CodeHost{ExternalServiceType: extsvc.TypeGitLab, ExternalServiceID: "https://gitlab.example.com/"},
+			want:     []string{"author:renovate"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var have []string
+			for _, q := range queriesForCodeHost(queries, tc.codeHost) {
+				have = append(have, q.Query)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("wrong queries (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const changesetReviewReminderInterval = 1 * time.Hour
+
+// NewChangesetReviewReminder returns a background routine that periodically
+// posts the changesetTemplate.reviewReminder comment of open batch changes on
+// their published changesets whose review has been pending for too long.
+func NewChangesetReviewReminder(ctx context.Context, bstore *store.Store) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		changesetReviewReminderInterval,
+		goroutine.NewHandlerWithErrorMessage("batch changes changeset review reminder", func(ctx context.Context) error {
+			return remindChangesetReviews(ctx, bstore)
+		}),
+	)
+}
+
+func remindChangesetReviews(ctx context.Context, bstore *store.Store) error {
+	batchChanges, _, err := bstore.ListBatchChanges(ctx, store.ListBatchChangesOpts{
+		States:                  []btypes.BatchChangeState{btypes.BatchChangeStateOpen},
+		OnlyWithReviewReminders: true,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing batch changes")
+	}
+
+	var errs error
+	for _, bc := range batchChanges {
+		if err := remindChangesetReviewsForBatchChange(ctx, bstore, bc); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "reminding reviewers of batch change %d", bc.ID))
+		}
+	}
+	return errs
+}
+
+func remindChangesetReviewsForBatchChange(ctx context.Context, bstore *store.Store, bc *btypes.BatchChange) (err error) {
+	spec, err := bstore.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: bc.BatchSpecID})
+	if err != nil {
+		return errors.Wrap(err, "loading batch spec")
+	}
+	if spec.Spec.ChangesetTemplate == nil || spec.Spec.ChangesetTemplate.ReviewReminder == nil {
+		return nil
+	}
+	reminder := spec.Spec.ChangesetTemplate.ReviewReminder
+
+	published := btypes.ChangesetPublicationStatePublished
+	pending := btypes.ChangesetReviewStatePending
+	cs, _, err := bstore.ListChangesets(ctx, store.ListChangesetsOpts{
+		BatchChangeID:        bc.ID,
+		OwnedByBatchChangeID: bc.ID,
+		PublicationState:     &published,
+		ExternalStates:       []btypes.ChangesetExternalState{btypes.ChangesetExternalStateOpen},
+		ExternalReviewState:  &pending,
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing changesets")
+	}
+	if len(cs) == 0 {
+		return nil
+	}
+
+	reminded, err := bstore.ListChangesetReviewReminders(ctx, bc.ID)
+	if err != nil {
+		return errors.Wrap(err, "listing review reminders")
+	}
+
+	now := bstore.Clock()()
+	var due []int64
+	for _, c := range cs {
+		if dueForReviewReminder(c, reminded[c.ID], reminder.After(), now) {
+			due = append(due, c.ID)
+		}
+	}
+	if len(due) == 0 {
+		return nil
+	}
+
+	bulkGroup, err := store.RandomID()
+	if err != nil {
+		return errors.Wrap(err, "creating bulk group")
+	}
+
+	tx, err := bstore.Transact(ctx)
+	if err != nil {
+		return errors.Wrap(err, "starting transaction")
+	}
+	defer func() { err = tx.Done(err) }()
+
+	jobs := reviewReminderJobs(bc, bulkGroup, reminder.Body, due)
+	if err := tx.CreateChangesetJob(ctx, jobs...); err != nil {
+		return errors.Wrap(err, "creating changeset jobs")
+	}
+
+	log15.Debug("posting changeset review reminders", "batchChange", bc.ID, "changesets", len(due))
+
+	return tx.UpsertChangesetReviewReminders(ctx, bc.ID, due)
+}
+
+// reviewReminderJobs returns the comment jobs that post the review reminder on
+// the given changesets. The comments are posted by the bulk processor, which
+// renders the body for each changeset.
+func reviewReminderJobs(bc *btypes.BatchChange, bulkGroup, body string, changesetIDs []int64) []*btypes.ChangesetJob {
+	jobs := make([]*btypes.ChangesetJob, 0, len(changesetIDs))
+	for _, id := range changesetIDs {
+		jobs = append(jobs, &btypes.ChangesetJob{
+			BulkGroup:     bulkGroup,
+			ChangesetID:   id,
+			BatchChangeID: bc.ID,
+			UserID:        bc.LastApplierID,
+			State:         btypes.ChangesetJobStateQueued,
+			JobType:       btypes.ChangesetJobTypeComment,
+			Payload:       &btypes.ChangesetJobCommentPayload{Message: body, Templated: true},
+		})
+	}
+	return jobs
+}
+
+// dueForReviewReminder returns whether a review reminder must be posted on the
+// changeset. That's the case once its review has been pending for the given
+// duration since it was opened on the code host, or since the last reminder.
+func dueForReviewReminder(c *btypes.Changeset, lastReminded time.Time, after time.Duration, now time.Time) bool {
+	since := c.ExternalCreatedAt()
+	if since.IsZero() {
+		since = c.CreatedAt
+	}
+	if lastReminded.After(since) {
+		since = lastReminded
+	}
+	return !now.Before(since.Add(after))
+}
@@ -0,0 +1,84 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+)
+
+func TestDueForReviewReminder(t *testing.T) {
+	now := time.Date(2022, 6, 20, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	after := 7 * day
+
+	changeset := func(openedAt time.Time) *btypes.Changeset {
+		return &btypes.Changeset{
+			CreatedAt: now.Add(-30 * day),
+			Metadata:  &github.PullRequest{CreatedAt: openedAt},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		changeset    *btypes.Changeset
+		lastReminded time.Time
+		want         bool
+	}{
+		"opened recently": {
+			changeset: changeset(now.Add(-6 * day)),
+			want:      false,
+		},
+		"opened long ago": {
+			changeset: changeset(now.Add(-7 * day)),
+			want:      true,
+		},
+		"reminded recently": {
+			changeset:    changeset(now.Add(-20 * day)),
+			lastReminded: now.Add(-2 * day),
+			want:         false,
+		},
+		"reminded long ago": {
+			changeset:    changeset(now.Add(-20 * day)),
+			lastReminded: now.Add(-8 * day),
+			want:         true,
+		},
+		"unknown opening time falls back to creation": {
+			changeset: &btypes.Changeset{CreatedAt: now.Add(-8 * day)},
+			want:      true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := dueForReviewReminder(tc.changeset, tc.lastReminded, after, now); have != tc.want {
+				t.Fatalf("wrong result. want=%t, have=%t", tc.want, have)
+			}
+		})
+	}
+}
+
+func TestReviewReminderJobs(t *testing.T) {
+	bc := &btypes.BatchChange{ID: 1, LastApplierID: 2}
+	body := "Please review ${{ repository.name }}"
+
+	have := reviewReminderJobs(bc, "bulk-group", body, []int64{3, 4})
+
+	job := func(changesetID int64) *btypes.ChangesetJob {
+		return &btypes.ChangesetJob{
+			BulkGroup:     "bulk-group",
+			ChangesetID:   changesetID,
+			BatchChangeID: 1,
+			UserID:        2,
+			State:         btypes.ChangesetJobStateQueued,
+			JobType:       btypes.ChangesetJobTypeComment,
+			// The reminder body is a template, so it must be rendered for
+			// each changeset.
+			Payload: &btypes.ChangesetJobCommentPayload{Message: body, Templated: true},
+		}
+	}
+	want := []*btypes.ChangesetJob{job(3), job(4)}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected jobs (-want +got):\n%s", diff)
+	}
+}
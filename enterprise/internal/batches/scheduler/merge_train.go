@@ -0,0 +1,168 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/config"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const mergeTrainInterval = 1 * time.Minute
+
+// NewMergeTrainScheduler returns a background routine that periodically
+// advances the running merge trains: once the previous wave of a merge train
+// has been processed, the wave interval has passed, the rollout windows allow
+// it and the health checks pass, the next wave of changesets is merged.
+func NewMergeTrainScheduler(ctx context.Context, bstore *store.Store) goroutine.BackgroundRoutine {
+	checker := newMergeTrainHealthChecker()
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		mergeTrainInterval,
+		goroutine.NewHandlerWithErrorMessage("batch changes merge train scheduler", func(ctx context.Context) error {
+			return advanceMergeTrains(ctx, bstore, checker, config.ActiveWindow())
+		}),
+	)
+}
+
+func advanceMergeTrains(ctx context.Context, bstore *store.Store, checker MergeTrainHealthChecker, windows *window.Configuration) error {
+	trains, err := bstore.ListMergeTrains(ctx, store.ListMergeTrainsOpts{
+		States: []btypes.MergeTrainState{btypes.MergeTrainStateRunning},
+	})
+	if err != nil {
+		return errors.Wrap(err, "listing running merge trains")
+	}
+
+	var errs error
+	for _, train := range trains {
+		if err := advanceMergeTrain(ctx, bstore, checker, windows, train); err != nil {
+			errs = errors.Append(errs, errors.Wrapf(err, "advancing merge train %d", train.ID))
+		}
+	}
+	return errs
+}
+
+func advanceMergeTrain(ctx context.Context, bstore *store.Store, checker MergeTrainHealthChecker, windows *window.Configuration, train *btypes.MergeTrain) error {
+	waves, err := bstore.ListMergeTrainWaves(ctx, train.ID)
+	if err != nil {
+		return errors.Wrap(err, "listing waves")
+	}
+
+	if len(waves) > 0 {
+		last := waves[len(waves)-1]
+		op, err := bstore.GetBulkOperation(ctx, store.GetBulkOperationOpts{ID: last.BulkGroup})
+		if err != nil {
+			return errors.Wrapf(err, "loading bulk operation of wave %d", last.Number)
+		}
+
+		switch op.State {
+		case btypes.BulkOperationStateProcessing:
+			// Wait for the previous wave to be merged.
+			return nil
+
+		case btypes.BulkOperationStateFailed:
+			// If the merge train was resumed after the wave failed, the user
+			// has acknowledged the failure.
+			if train.ResumedAt.IsZero() || train.LastWaveAt.After(train.ResumedAt) {
+				return pauseMergeTrain(ctx, bstore, train, fmt.Sprintf("wave %d failed to merge some changesets", last.Number))
+			}
+		}
+	}
+
+	pending, failed, err := bstore.CountPendingMergeTrainChangesets(ctx, train.ID)
+	if err != nil {
+		return errors.Wrap(err, "counting pending changesets")
+	}
+	if pending == 0 {
+		train.State = btypes.MergeTrainStateCompleted
+		return bstore.UpdateMergeTrain(ctx, train)
+	}
+	if pending == failed {
+		// Only changesets the reconciler processed successfully are merged,
+		// so the merge train can't advance until the failed ones are retried.
+		// Errored changesets are still retried by the reconciler, so we wait
+		// for them.
+		return pauseMergeTrain(ctx, bstore, train, fmt.Sprintf("the reconciler failed to process all %d remaining changesets", failed))
+	}
+
+	now := bstore.Clock()()
+	if !train.LastWaveAt.IsZero() && now.Before(train.LastWaveAt.Add(train.WaveInterval)) {
+		return nil
+	}
+	if !windows.IsOpen(now) {
+		return nil
+	}
+
+	reason, err := checker.Check(ctx, train)
+	if err != nil {
+		return errors.Wrap(err, "running health checks")
+	}
+	if reason != "" {
+		return pauseMergeTrain(ctx, bstore, train, reason)
+	}
+
+	ids, err := bstore.ListNextMergeTrainWaveChangesets(ctx, train)
+	if err != nil {
+		return errors.Wrap(err, "listing changesets of the next wave")
+	}
+	if len(ids) == 0 {
+		// The pending changesets are still being processed by the
+		// reconciler, so we try again later.
+		return nil
+	}
+
+	return startMergeTrainWave(ctx, bstore, train, int32(len(waves)+1), ids, now)
+}
+
+func startMergeTrainWave(ctx context.Context, bstore *store.Store, train *btypes.MergeTrain, wave int32, ids []int64, now time.Time) (err error) {
+	bulkGroup, err := store.RandomID()
+	if err != nil {
+		return errors.Wrap(err, "creating bulk group")
+	}
+
+	tx, err := bstore.Transact(ctx)
+	if err != nil {
+		return errors.Wrap(err, "starting transaction")
+	}
+	defer func() { err = tx.Done(err) }()
+
+	jobs := make([]*btypes.ChangesetJob, 0, len(ids))
+	for _, id := range ids {
+		jobs = append(jobs, &btypes.ChangesetJob{
+			BulkGroup:     bulkGroup,
+			ChangesetID:   id,
+			BatchChangeID: train.BatchChangeID,
+			UserID:        train.UserID,
+			State:         btypes.ChangesetJobStateQueued,
+			JobType:       btypes.ChangesetJobTypeMerge,
+			Payload:       &btypes.ChangesetJobMergePayload{Squash: train.Squash},
+		})
+	}
+	if err := tx.CreateChangesetJob(ctx, jobs...); err != nil {
+		return errors.Wrap(err, "creating changeset jobs")
+	}
+
+	if err := tx.MarkMergeTrainWaveStarted(ctx, train.ID, wave, bulkGroup, ids); err != nil {
+		return errors.Wrap(err, "marking wave as started")
+	}
+
+	log15.Debug("starting merge train wave", "mergeTrain", train.ID, "wave", wave, "changesets", len(ids))
+
+	train.LastWaveAt = now
+	return tx.UpdateMergeTrain(ctx, train)
+}
+
+func pauseMergeTrain(ctx context.Context, bstore *store.Store, train *btypes.MergeTrain, reason string) error {
+	log15.Info("pausing merge train", "mergeTrain", train.ID, "reason", reason)
+
+	train.State = btypes.MergeTrainStatePaused
+	train.PausedReason = reason
+	return bstore.UpdateMergeTrain(ctx, train)
+}
@@ -0,0 +1,164 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api/internalapi"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	streamapi "github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// MergeTrainHealthChecker runs the health checks of a merge train before the
+// next wave is merged.
+type MergeTrainHealthChecker interface {
+	// Check returns a non-empty reason if a health check of the merge train
+	// fails and the merge train should be paused.
+	Check(ctx context.Context, train *btypes.MergeTrain) (reason string, err error)
+}
+
+const mergeTrainSearchClientUserAgent = "Batch Changes merge train health check"
+
+type mergeTrainHealthChecker struct {
+	frontendInternalURL string
+	searchClient        httpcli.Doer
+	externalClient      httpcli.Doer
+}
+
+var _ MergeTrainHealthChecker = &mergeTrainHealthChecker{}
+
+func newMergeTrainHealthChecker() *mergeTrainHealthChecker {
+	externalClient, _ := httpcli.ExternalClientFactory.Doer(denyPrivateAddressesOpt)
+	return &mergeTrainHealthChecker{
+		frontendInternalURL: internalapi.Client.URL + "/.internal",
+		searchClient:        httpcli.InternalClient,
+		externalClient:      externalClient,
+	}
+}
+
+// denyPrivateAddressesOpt is a httpcli.Opt that makes the client refuse to
+// connect to loopback, private and link-local addresses, so that health check
+// URLs can't be used to reach services in the internal network. The addresses
+// are checked when dialing, which also covers redirects and host names that
+// resolve to such addresses. Proxies are not used, since they would hide the
+// address of the health check URL.
+func denyPrivateAddressesOpt(cli *http.Client) error {
+	if cli.Transport == nil {
+		cli.Transport = http.DefaultTransport
+	}
+	tr, ok := cli.Transport.(*http.Transport)
+	if !ok {
+		return errors.Errorf("http.Client.Transport is not an *http.Transport: %T", cli.Transport)
+	}
+
+	tr = tr.Clone()
+	tr.Proxy = nil
+	tr.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   denyPrivateAddresses,
+	}).DialContext
+	cli.Transport = tr
+
+	return nil
+}
+
+func denyPrivateAddresses(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || isPrivateIP(ip) {
+		return errors.Newf("connecting to %s is not allowed", host)
+	}
+	return nil
+}
+
+func isPrivateIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsUnspecified()
+}
+
+func (c *mergeTrainHealthChecker) Check(ctx context.Context, train *btypes.MergeTrain) (string, error) {
+	if train.HealthCheckURL != "" {
+		if reason := c.checkURL(ctx, train.HealthCheckURL); reason != "" {
+			return reason, nil
+		}
+	}
+
+	if train.HealthCheckQuery != "" {
+		// We impersonate the user who created the merge train, so that the
+		// search only sees the repositories they have access to.
+		ctx = actor.WithActor(ctx, actor.FromUser(train.UserID))
+
+		count, err := c.countSearchResults(ctx, train.HealthCheckQuery)
+		if err != nil {
+			return fmt.Sprintf("health check query failed: %s", err), nil
+		}
+		if count > 0 {
+			return fmt.Sprintf("health check query returned %d results", count), nil
+		}
+	}
+
+	return "", nil
+}
+
+func (c *mergeTrainHealthChecker) checkURL(ctx context.Context, url string) string {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Sprintf("invalid health check URL: %s", err)
+	}
+
+	resp, err := c.externalClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return fmt.Sprintf("health check URL request failed: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Sprintf("health check URL returned status %d", resp.StatusCode)
+	}
+	return ""
+}
+
+func (c *mergeTrainHealthChecker) countSearchResults(ctx context.Context, query string) (count int, err error) {
+	req, err := streamhttp.NewRequest(c.frontendInternalURL, query)
+	if err != nil {
+		return 0, err
+	}
+	req = req.WithContext(ctx)
+
+	req.Header.Set("User-Agent", mergeTrainSearchClientUserAgent)
+
+	resp, err := c.searchClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	dec := streamhttp.FrontendStreamDecoder{
+		OnMatches: func(matches []streamhttp.EventMatch) {
+			count += len(matches)
+		},
+		OnError: func(ee *streamhttp.EventError) {
+			err = errors.New(ee.Message)
+		},
+		OnProgress: func(p *streamapi.Progress) {},
+	}
+	if decErr := dec.ReadAll(resp.Body); decErr != nil {
+		return 0, decErr
+	}
+	return count, err
+}
@@ -0,0 +1,115 @@
+package scheduler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+)
+
+func TestMergeTrainHealthChecker(t *testing.T) {
+	ctx := context.Background()
+
+	searchServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ew, err := streamhttp.NewWriter(w)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if req.URL.Query().Get("q") == "type:diff error" {
+			ew.Event("matches", []streamhttp.EventMatch{
+				&streamhttp.EventContentMatch{Type: streamhttp.ContentMatchType, Path: "a.go"},
+				&streamhttp.EventContentMatch{Type: streamhttp.ContentMatchType, Path: "b.go"},
+			})
+		}
+		ew.Event("done", struct{}{})
+	}))
+	t.Cleanup(searchServer.Close)
+
+	healthServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/healthy" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(healthServer.Close)
+
+	checker := &mergeTrainHealthChecker{
+		frontendInternalURL: searchServer.URL,
+		searchClient:        http.DefaultClient,
+		externalClient:      http.DefaultClient,
+	}
+
+	for name, tc := range map[string]struct {
+		train      *btypes.MergeTrain
+		wantReason string
+	}{
+		"no health checks": {
+			train: &btypes.MergeTrain{},
+		},
+		"healthy URL": {
+			train: &btypes.MergeTrain{HealthCheckURL: healthServer.URL + "/healthy"},
+		},
+		"unhealthy URL": {
+			train:      &btypes.MergeTrain{HealthCheckURL: healthServer.URL + "/unhealthy"},
+			wantReason: "health check URL returned status 503",
+		},
+		"query without results": {
+			train: &btypes.MergeTrain{UserID: 1, HealthCheckQuery: "type:diff fine"},
+		},
+		"query with results": {
+			train:      &btypes.MergeTrain{UserID: 1, HealthCheckQuery: "type:diff error"},
+			wantReason: "health check query returned 2 results",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			reason, err := checker.Check(ctx, tc.train)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reason != tc.wantReason {
+				t.Fatalf("unexpected reason: have=%q want=%q", reason, tc.wantReason)
+			}
+		})
+	}
+}
+
+func TestDenyPrivateAddresses(t *testing.T) {
+	for address, wantErr := range map[string]bool{
+		"93.184.216.34:443":        false,
+		"[2606:2800:220:1::]:443":  false,
+		"127.0.0.1:80":             true,
+		"[::1]:80":                 true,
+		"10.0.0.1:80":              true,
+		"172.16.0.1:80":            true,
+		"192.168.1.1:80":           true,
+		"169.254.169.254:80":       true,
+		"[fe80::1]:80":             true,
+		"[fd00::1]:80":             true,
+		"0.0.0.0:80":               true,
+		"not-an-ip.example.com:80": true,
+	} {
+		if err := denyPrivateAddresses("tcp", address, nil); (err != nil) != wantErr {
+			t.Errorf("unexpected error for %s: %v", address, err)
+		}
+	}
+}
+
+func TestDenyPrivateAddressesOpt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {}))
+	t.Cleanup(server.Close)
+
+	var cli http.Client
+	if err := denyPrivateAddressesOpt(&cli); err != nil {
+		t.Fatal(err)
+	}
+
+	checker := &mergeTrainHealthChecker{externalClient: &cli}
+	reason := checker.checkURL(context.Background(), server.URL)
+	if want := "health check URL request failed"; !strings.HasPrefix(reason, want) {
+		t.Fatalf("unexpected reason: have=%q want prefix %q", reason, want)
+	}
+}
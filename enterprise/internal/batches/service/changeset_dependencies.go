@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+// changesetSpecDependency is an edge between two changeset specs of the same
+// batch spec: the changeset created from SpecID waits, as described by Gate,
+// until the changeset created from DependsOnSpecID has been merged.
+type changesetSpecDependency struct {
+	SpecID          int64
+	DependsOnSpecID int64
+	Gate            btypes.ChangesetDependencyGate
+}
+
+// loadChangesetSpecDependencies loads the changeset specs of the given batch
+// spec and resolves the dependencies between them. If the dependencies are
+// invalid, a changesetSpecDependencyErrs is returned.
+func loadChangesetSpecDependencies(ctx context.Context, s *store.Store, batchSpecID int64) ([]changesetSpecDependency, error) {
+	specs, _, err := s.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{BatchSpecID: batchSpecID})
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasChangesetSpecDependencies(specs) {
+		return nil, nil
+	}
+
+	// 🚨 SECURITY: database.Repos.GetReposSetByIDs uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	repos, err := s.Repos().GetReposSetByIDs(ctx, specs.RepoIDs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolveChangesetSpecDependencies(specs, repos)
+}
+
+func hasChangesetSpecDependencies(specs btypes.ChangesetSpecs) bool {
+	for _, spec := range specs {
+		if len(spec.Spec.DependsOn) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveChangesetSpecDependencies resolves the dependencies declared in the
+// given changeset specs to other changeset specs in the same set. A
+// dependency resolves to all other changeset specs that target the repository
+// and, if given, the branch of the dependency. Dependencies that don't resolve
+// to any changeset spec and cyclic dependencies are returned as errors.
+func resolveChangesetSpecDependencies(specs btypes.ChangesetSpecs, repos map[api.RepoID]*types.Repo) ([]changesetSpecDependency, error) {
+	specsByRepo := make(map[api.RepoName][]*btypes.ChangesetSpec)
+	for _, spec := range specs {
+		if repo, ok := repos[spec.RepoID]; ok {
+			specsByRepo[repo.Name] = append(specsByRepo[repo.Name], spec)
+		}
+	}
+
+	type edge struct{ from, to int64 }
+	var (
+		errs  changesetSpecDependencyErrs
+		deps  []changesetSpecDependency
+		seen  = make(map[edge]int)
+		edges = make(map[int64][]int64)
+	)
+	for _, spec := range specs {
+		for _, dep := range spec.Spec.DependsOn {
+			var candidates []*btypes.ChangesetSpec
+			for _, c := range specsByRepo[api.RepoName(dep.Repository)] {
+				if c.ID == spec.ID {
+					continue
+				}
+				if dep.HeadRef != "" && c.Spec.HeadRef != dep.HeadRef {
+					continue
+				}
+				candidates = append(candidates, c)
+			}
+
+			if len(candidates) == 0 {
+				errs = append(errs, &changesetSpecDependencyErr{
+					spec:       describeChangesetSpec(spec, repos),
+					dependency: dep,
+				})
+				continue
+			}
+			gate := btypes.ChangesetDependencyGatePublish
+			if dep.Gate == batcheslib.ChangesetDependencyGateMerge {
+				gate = btypes.ChangesetDependencyGateMerge
+			}
+			for _, c := range candidates {
+				// If the same dependency is declared more than once, the
+				// stricter gate wins.
+				e := edge{from: spec.ID, to: c.ID}
+				if i, ok := seen[e]; ok {
+					if gate == btypes.ChangesetDependencyGatePublish {
+						deps[i].Gate = gate
+					}
+					continue
+				}
+				seen[e] = len(deps)
+				deps = append(deps, changesetSpecDependency{SpecID: spec.ID, DependsOnSpecID: c.ID, Gate: gate})
+				edges[spec.ID] = append(edges[spec.ID], c.ID)
+			}
+		}
+	}
+
+	specsByID := make(map[int64]*btypes.ChangesetSpec, len(specs))
+	for _, spec := range specs {
+		specsByID[spec.ID] = spec
+	}
+	for _, cycle := range findDependencyCycles(specs, edges) {
+		described := make([]string, 0, len(cycle))
+		for _, id := range cycle {
+			described = append(described, describeChangesetSpec(specsByID[id], repos))
+		}
+		errs = append(errs, &changesetSpecDependencyCycle{specs: described})
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return deps, nil
+}
+
+// findDependencyCycles returns the cycles in the dependency graph described
+// by edges. Each cycle is returned as a list of spec IDs, starting and ending
+// with the same ID.
+func findDependencyCycles(specs btypes.ChangesetSpecs, edges map[int64][]int64) [][]int64 {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	var (
+		cycles [][]int64
+		state  = make(map[int64]int, len(specs))
+		path   []int64
+		visit  func(id int64)
+	)
+	visit = func(id int64) {
+		state[id] = visiting
+		path = append(path, id)
+		for _, next := range edges[id] {
+			switch state[next] {
+			case unvisited:
+				visit(next)
+			case visiting:
+				for i, p := range path {
+					if p == next {
+						cycle := append([]int64{}, path[i:]...)
+						cycles = append(cycles, append(cycle, next))
+						break
+					}
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[id] = visited
+	}
+
+	for _, spec := range specs {
+		if state[spec.ID] == unvisited {
+			visit(spec.ID)
+		}
+	}
+	return cycles
+}
+
+func describeChangesetSpec(spec *btypes.ChangesetSpec, repos map[api.RepoID]*types.Repo) string {
+	name := "unknown repository"
+	if repo, ok := repos[spec.RepoID]; ok {
+		name = string(repo.Name)
+	}
+	if spec.Spec.HeadRef == "" {
+		return name
+	}
+	return fmt.Sprintf("%s@%s", name, strings.TrimPrefix(spec.Spec.HeadRef, "refs/heads/"))
+}
+
+type changesetSpecDependencyErr struct {
+	spec       string
+	dependency batcheslib.ChangesetSpecDependency
+}
+
+func (e changesetSpecDependencyErr) Error() string {
+	target := e.dependency.Repository
+	if e.dependency.HeadRef != "" {
+		target = fmt.Sprintf("%s@%s", target, strings.TrimPrefix(e.dependency.HeadRef, "refs/heads/"))
+	}
+	return fmt.Sprintf("Changeset in %s depends on %s, but no matching changeset exists in this batch change.", e.spec, target)
+}
+
+type changesetSpecDependencyCycle struct {
+	specs []string
+}
+
+func (e changesetSpecDependencyCycle) Error() string {
+	return fmt.Sprintf("Changesets have cyclic dependencies: %s.", strings.Join(e.specs, " -> "))
+}
+
+// changesetSpecDependencyErrs represents a set of invalid changeset spec
+// dependencies and implements the error interface.
+type changesetSpecDependencyErrs []error
+
+func (es changesetSpecDependencyErrs) Error() string {
+	if len(es) == 1 {
+		return fmt.Sprintf("Validating changeset dependencies resulted in an error:\n* %s\n", es[0])
+	}
+
+	points := make([]string, len(es))
+	for i, err := range es {
+		points[i] = fmt.Sprintf("* %s", err)
+	}
+
+	return fmt.Sprintf(
+		"%d errors when validating changeset dependencies:\n%s\n",
+		len(es), strings.Join(points, "\n"))
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func TestResolveChangesetSpecDependencies(t *testing.T) {
+	repos := map[api.RepoID]*types.Repo{
+		1: {ID: 1, Name: "github.com/sourcegraph/lib"},
+		2: {ID: 2, Name: "github.com/sourcegraph/app"},
+		3: {ID: 3, Name: "github.com/sourcegraph/cli"},
+	}
+
+	spec := func(id int64, repo api.RepoID, headRef string, deps ...batcheslib.ChangesetSpecDependency) *btypes.ChangesetSpec {
+		return &btypes.ChangesetSpec{
+			ID:     id,
+			RepoID: repo,
+			Spec: &batcheslib.ChangesetSpec{
+				HeadRef:   headRef,
+				DependsOn: deps,
+			},
+		}
+	}
+	dep := func(repo, headRef string) batcheslib.ChangesetSpecDependency {
+		return batcheslib.ChangesetSpecDependency{Repository: repo, HeadRef: headRef}
+	}
+	mergeDep := func(repo, headRef string) batcheslib.ChangesetSpecDependency {
+		return batcheslib.ChangesetSpecDependency{Repository: repo, HeadRef: headRef, Gate: batcheslib.ChangesetDependencyGateMerge}
+	}
+
+	t.Run("resolved", func(t *testing.T) {
+		specs := btypes.ChangesetSpecs{
+			spec(1, 1, "refs/heads/upgrade"),
+			spec(2, 1, "refs/heads/other"),
+			spec(3, 2, "refs/heads/upgrade", dep("github.com/sourcegraph/lib", "refs/heads/upgrade")),
+			spec(4, 3, "refs/heads/upgrade", mergeDep("github.com/sourcegraph/app", "")),
+			spec(5, 3, "refs/heads/other",
+				mergeDep("github.com/sourcegraph/lib", ""),
+				dep("github.com/sourcegraph/lib", "refs/heads/upgrade"),
+			),
+		}
+
+		have, err := resolveChangesetSpecDependencies(specs, repos)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []changesetSpecDependency{
+			{SpecID: 3, DependsOnSpecID: 1, Gate: btypes.ChangesetDependencyGatePublish},
+			{SpecID: 4, DependsOnSpecID: 3, Gate: btypes.ChangesetDependencyGateMerge},
+			// The publish gate of the second dependency on spec 1 wins.
+			{SpecID: 5, DependsOnSpecID: 1, Gate: btypes.ChangesetDependencyGatePublish},
+			{SpecID: 5, DependsOnSpecID: 2, Gate: btypes.ChangesetDependencyGateMerge},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("unexpected dependencies (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("unresolved", func(t *testing.T) {
+		specs := btypes.ChangesetSpecs{
+			spec(1, 1, "refs/heads/upgrade"),
+			spec(2, 2, "refs/heads/upgrade",
+				dep("github.com/sourcegraph/lib", "refs/heads/missing"),
+				dep("github.com/sourcegraph/missing", ""),
+			),
+		}
+
+		_, err := resolveChangesetSpecDependencies(specs, repos)
+		errs, ok := err.(changesetSpecDependencyErrs)
+		if !ok {
+			t.Fatalf("unexpected error type %T: %v", err, err)
+		}
+		if len(errs) != 2 {
+			t.Fatalf("unexpected number of errors: %d", len(errs))
+		}
+		want := []string{
+			"Changeset in github.com/sourcegraph/app@upgrade depends on github.com/sourcegraph/lib@missing, but no matching changeset exists in this batch change.",
+			"Changeset in github.com/sourcegraph/app@upgrade depends on github.com/sourcegraph/missing, but no matching changeset exists in this batch change.",
+		}
+		for i, err := range errs {
+			if have := err.Error(); have != want[i] {
+				t.Errorf("unexpected error:\nhave: %s\nwant: %s", have, want[i])
+			}
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		specs := btypes.ChangesetSpecs{
+			spec(1, 1, "refs/heads/upgrade", dep("github.com/sourcegraph/cli", "")),
+			spec(2, 2, "refs/heads/upgrade", dep("github.com/sourcegraph/lib", "")),
+			spec(3, 3, "refs/heads/upgrade", dep("github.com/sourcegraph/app", "")),
+		}
+
+		_, err := resolveChangesetSpecDependencies(specs, repos)
+		if err == nil {
+			t.Fatal("unexpected nil error")
+		}
+		want := "Changesets have cyclic dependencies: github.com/sourcegraph/lib@upgrade -> github.com/sourcegraph/cli@upgrade -> github.com/sourcegraph/app@upgrade -> github.com/sourcegraph/lib@upgrade."
+		if have := err.Error(); !strings.Contains(have, want) {
+			t.Errorf("unexpected error:\n%s", have)
+		}
+	})
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrMergeTrainExists is returned by (*Service).CreateMergeTrain if the batch
+// change already has a merge train that is running or paused.
+var ErrMergeTrainExists = errors.New("the batch change already has an active merge train")
+
+// CreateMergeTrainOpts are the options for (*Service).CreateMergeTrain.
+type CreateMergeTrainOpts struct {
+	BatchChangeID     int64
+	ChangesetIDs      []int64
+	ChangesetsPerWave int32
+	WaveInterval      time.Duration
+	Squash            bool
+	HealthCheckQuery  string
+	HealthCheckURL    string
+}
+
+// CreateMergeTrain creates a merge train that merges the given open
+// changesets of the batch change in waves, checking whether the actor in the
+// context has permission to do so.
+func (s *Service) CreateMergeTrain(ctx context.Context, opts CreateMergeTrainOpts) (train *btypes.MergeTrain, err error) {
+	ctx, _, endObservation := s.operations.createMergeTrain.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	if opts.ChangesetsPerWave < 1 {
+		return nil, errors.New("changesetsPerWave must be at least 1")
+	}
+	if opts.WaveInterval < 0 {
+		return nil, errors.New("the wave interval must not be negative")
+	}
+	if opts.HealthCheckURL != "" {
+		u, err := url.Parse(opts.HealthCheckURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return nil, errors.Newf("invalid health check URL %q", opts.HealthCheckURL)
+		}
+	}
+	if len(opts.ChangesetIDs) == 0 {
+		return nil, errors.New("no changesets given")
+	}
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: opts.BatchChangeID})
+	if err != nil {
+		return nil, errors.Wrap(err, "loading batch change")
+	}
+
+	// 🚨 SECURITY: Only the author of the batch change can create merge trains.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Health check URLs are requested by Sourcegraph, so only
+	// site admins can set them.
+	if opts.HealthCheckURL != "" {
+		if err := backend.CheckCurrentUserIsSiteAdmin(ctx, s.store.DatabaseDB()); err != nil {
+			return nil, err
+		}
+	}
+
+	published := btypes.ChangesetPublicationStatePublished
+	cs, _, err := s.store.ListChangesets(ctx, store.ListChangesetsOpts{
+		IDs:              opts.ChangesetIDs,
+		BatchChangeID:    opts.BatchChangeID,
+		PublicationState: &published,
+		ExternalStates:   []btypes.ChangesetExternalState{btypes.ChangesetExternalStateOpen},
+		// We only want to allow changesets the user has access to.
+		EnforceAuthz: true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing changesets")
+	}
+	if len(cs) != len(opts.ChangesetIDs) {
+		return nil, ErrChangesetsForJobNotFound
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "starting transaction")
+	}
+	defer func() { err = tx.Done(err) }()
+
+	active, err := tx.ListMergeTrains(ctx, store.ListMergeTrainsOpts{
+		BatchChangeID: opts.BatchChangeID,
+		States:        []btypes.MergeTrainState{btypes.MergeTrainStateRunning, btypes.MergeTrainStatePaused},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing merge trains")
+	}
+	if len(active) > 0 {
+		return nil, ErrMergeTrainExists
+	}
+
+	train = &btypes.MergeTrain{
+		BatchChangeID:     opts.BatchChangeID,
+		UserID:            actor.FromContext(ctx).UID,
+		State:             btypes.MergeTrainStateRunning,
+		ChangesetsPerWave: opts.ChangesetsPerWave,
+		WaveInterval:      opts.WaveInterval,
+		Squash:            opts.Squash,
+		HealthCheckQuery:  opts.HealthCheckQuery,
+		HealthCheckURL:    opts.HealthCheckURL,
+	}
+	if err := tx.CreateMergeTrain(ctx, train, cs.IDs()); err != nil {
+		return nil, errors.Wrap(err, "creating merge train")
+	}
+
+	return train, nil
+}
+
+// ResumeMergeTrain resumes the given paused merge train, checking whether the
+// actor in the context has permission to do so. Failed waves that started
+// before the merge train was resumed don't pause it again.
+func (s *Service) ResumeMergeTrain(ctx context.Context, id int64) (train *btypes.MergeTrain, err error) {
+	ctx, _, endObservation := s.operations.resumeMergeTrain.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	train, err = s.loadMergeTrainForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if train.State != btypes.MergeTrainStatePaused {
+		return nil, errors.Newf("merge train is %s, not paused", train.State)
+	}
+
+	train.State = btypes.MergeTrainStateRunning
+	train.PausedReason = ""
+	train.ResumedAt = s.clock()
+	if err := s.store.UpdateMergeTrain(ctx, train); err != nil {
+		return nil, err
+	}
+	return train, nil
+}
+
+// CancelMergeTrain cancels the given merge train, checking whether the actor
+// in the context has permission to do so. Waves that already started are not
+// canceled.
+func (s *Service) CancelMergeTrain(ctx context.Context, id int64) (train *btypes.MergeTrain, err error) {
+	ctx, _, endObservation := s.operations.cancelMergeTrain.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	train, err = s.loadMergeTrainForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if train.State != btypes.MergeTrainStateRunning && train.State != btypes.MergeTrainStatePaused {
+		return nil, errors.Newf("merge train is %s and cannot be canceled", train.State)
+	}
+
+	train.State = btypes.MergeTrainStateCanceled
+	train.PausedReason = ""
+	if err := s.store.UpdateMergeTrain(ctx, train); err != nil {
+		return nil, err
+	}
+	return train, nil
+}
+
+func (s *Service) loadMergeTrainForUpdate(ctx context.Context, id int64) (*btypes.MergeTrain, error) {
+	train, err := s.store.GetMergeTrain(ctx, id)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading merge train")
+	}
+
+	batchChange, err := s.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: train.BatchChangeID})
+	if err != nil {
+		return nil, errors.Wrap(err, "loading batch change")
+	}
+
+	// 🚨 SECURITY: Only the author of the batch change can update merge trains.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, s.store.DatabaseDB(), batchChange.CreatorID); err != nil {
+		return nil, err
+	}
+
+	return train, nil
+}
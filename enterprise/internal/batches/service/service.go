@@ -83,6 +83,9 @@ type operations struct {
 	applyBatchChange                     *observation.Operation
 	reconcileBatchChange                 *observation.Operation
 	validateChangesetSpecs               *observation.Operation
+	createMergeTrain                     *observation.Operation
+	resumeMergeTrain                     *observation.Operation
+	cancelMergeTrain                     *observation.Operation
 }
 
 var (
@@ -133,6 +136,9 @@ func newOperations(observationContext *observation.Context) *operations {
 			applyBatchChange:                     op("ApplyBatchChange"),
 			reconcileBatchChange:                 op("ReconcileBatchChange"),
 			validateChangesetSpecs:               op("ValidateChangesetSpecs"),
+			createMergeTrain:                     op("CreateMergeTrain"),
+			resumeMergeTrain:                     op("ResumeMergeTrain"),
+			cancelMergeTrain:                     op("CancelMergeTrain"),
 		}
 	})
 
@@ -1135,7 +1141,8 @@ func (s *Service) CreateChangesetJobs(ctx context.Context, batchChangeID int64,
 }
 
 // ValidateChangesetSpecs checks whether the given BachSpec has ChangesetSpecs
-// that would publish to the same branch in the same repository.
+// that would publish to the same branch in the same repository, or whose
+// dependencies on other ChangesetSpecs can't be resolved.
 // If the return value is nil, then the BatchSpec is valid.
 func (s *Service) ValidateChangesetSpecs(ctx context.Context, batchSpecID int64) error {
 	// We don't use `err` here to distinguish between errors we want to trace
@@ -1151,7 +1158,12 @@ func (s *Service) ValidateChangesetSpecs(ctx context.Context, batchSpecID int64)
 	}
 
 	if len(conflicts) == 0 {
-		return nil
+		_, err := loadChangesetSpecDependencies(ctx, s.store, batchSpecID)
+		var depErrs changesetSpecDependencyErrs
+		if err != nil && !errors.As(err, &depErrs) {
+			nonValidationErr = err
+		}
+		return err
 	}
 
 	repoIDs := make([]api.RepoID, 0, len(conflicts))
@@ -1463,12 +1475,13 @@ type GetAvailableBulkOperationsOpts struct {
 // on an array of changesets.
 func (s *Service) GetAvailableBulkOperations(ctx context.Context, opts GetAvailableBulkOperationsOpts) ([]string, error) {
 	bulkOperationsCounter := map[btypes.ChangesetJobType]int{
-		btypes.ChangesetJobTypeClose:     0,
-		btypes.ChangesetJobTypeComment:   0,
-		btypes.ChangesetJobTypeDetach:    0,
-		btypes.ChangesetJobTypeMerge:     0,
-		btypes.ChangesetJobTypePublish:   0,
-		btypes.ChangesetJobTypeReenqueue: 0,
+		btypes.ChangesetJobTypeClose:       0,
+		btypes.ChangesetJobTypeComment:     0,
+		btypes.ChangesetJobTypeDetach:      0,
+		btypes.ChangesetJobTypeMerge:       0,
+		btypes.ChangesetJobTypePublish:     0,
+		btypes.ChangesetJobTypeReenqueue:   0,
+		btypes.ChangesetJobTypeRetryChecks: 0,
 	}
 
 	changesets, _, err := s.store.ListChangesets(ctx, store.ListChangesetsOpts{
@@ -1524,6 +1537,13 @@ func (s *Service) GetAvailableBulkOperations(ctx context.Context, opts GetAvaila
 		if isChangesetCommentable {
 			bulkOperationsCounter[btypes.ChangesetJobTypeComment] += 1
 		}
+
+		// RETRY_CHECKS
+		// Only checks that the code host can re-run have a retry ID.
+		hasRetryableChecks := changeset.ExternalCheckState == btypes.ChangesetCheckStateFailed && len(changeset.FailedCheckRetryIDs()) > 0
+		if !isChangesetArchived && (isChangesetOpen || isChangesetDraft) && hasRetryableChecks {
+			bulkOperationsCounter[btypes.ChangesetJobTypeRetryChecks] += 1
+		}
 	}
 
 	noOfChangesets := len(opts.Changesets)
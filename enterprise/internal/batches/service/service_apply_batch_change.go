@@ -142,8 +142,24 @@ func (s *Service) ApplyBatchChange(
 		return nil, err
 	}
 
+	rw := rewirer.New(mappings, batchChange.ID)
+
+	// Changesets tracked through importChangesets queries have no changeset
+	// spec. They stay attached as long as the new batch spec still imports
+	// changesets by query; the importer detaches them once they no longer
+	// match.
+	if len(batchSpec.Spec.ImportChangesetQueries()) > 0 {
+		ids, err := tx.ListChangesetQueryImports(ctx, batchChange.ID)
+		if err != nil {
+			return nil, err
+		}
+		rw.KeepAttached(ids...)
+	} else if err := tx.DeleteChangesetQueryImports(ctx, batchChange.ID, nil); err != nil {
+		return nil, err
+	}
+
 	// And execute the mapping.
-	changesets, err := rewirer.New(mappings, batchChange.ID).Rewire()
+	changesets, err := rw.Rewire()
 	if err != nil {
 		return nil, err
 	}
@@ -165,9 +181,49 @@ func (s *Service) ApplyBatchChange(
 		}
 	}
 
+	// Finally, record the dependencies between the changesets, so that the
+	// reconciler holds back changesets until their dependencies are merged.
+	if err := replaceChangesetDependencies(ctx, tx, batchChange, changesets); err != nil {
+		return nil, err
+	}
+
 	return batchChange, nil
 }
 
+func replaceChangesetDependencies(ctx context.Context, tx *store.Store, batchChange *btypes.BatchChange, changesets btypes.Changesets) error {
+	specDeps, err := loadChangesetSpecDependencies(ctx, tx, batchChange.BatchSpecID)
+	if err != nil {
+		return err
+	}
+
+	changesetIDsBySpecID := make(map[int64]int64, len(changesets))
+	for _, changeset := range changesets {
+		if changeset.CurrentSpecID != 0 {
+			changesetIDsBySpecID[changeset.CurrentSpecID] = changeset.ID
+		}
+	}
+
+	deps := make([]*btypes.ChangesetDependency, 0, len(specDeps))
+	for _, d := range specDeps {
+		changesetID, ok := changesetIDsBySpecID[d.SpecID]
+		if !ok {
+			continue
+		}
+		dependsOnChangesetID, ok := changesetIDsBySpecID[d.DependsOnSpecID]
+		if !ok {
+			continue
+		}
+		deps = append(deps, &btypes.ChangesetDependency{
+			BatchChangeID:        batchChange.ID,
+			ChangesetID:          changesetID,
+			DependsOnChangesetID: dependsOnChangesetID,
+			Gate:                 d.Gate,
+		})
+	}
+
+	return tx.ReplaceChangesetDependencies(ctx, batchChange.ID, deps)
+}
+
 func (s *Service) ReconcileBatchChange(
 	ctx context.Context,
 	batchSpec *btypes.BatchSpec,
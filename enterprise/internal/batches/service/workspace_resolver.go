@@ -6,11 +6,13 @@ import (
 	"os"
 	"path"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/gobwas/glob"
 	"github.com/grafana/regexp"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/codeowners"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
@@ -29,6 +31,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	codeownerslib "github.com/sourcegraph/sourcegraph/lib/batches/codeowners"
 	onlib "github.com/sourcegraph/sourcegraph/lib/batches/on"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -51,6 +54,12 @@ type RepoWorkspace struct {
 
 	OnlyFetchWorkspace bool
 
+	// Owner is the code owner the workspace was split off for, and CodeOwners
+	// the CODEOWNERS rules of the repository that determined the owner. Both
+	// are only set if the workspace configuration splits by code owner.
+	Owner      string
+	CodeOwners *codeownerslib.Ruleset
+
 	Ignored     bool
 	Unsupported bool
 }
@@ -97,7 +106,7 @@ func (wr *workspaceResolver) ResolveWorkspacesForBatchSpec(ctx context.Context,
 	}
 
 	// Now build the workspaces for the list of repos
-	workspaces, err = findWorkspaces(ctx, batchSpec, wr, repos)
+	workspaces, err = findWorkspaces(ctx, batchSpec, wr, wr, repos)
 	if err != nil {
 		return nil, err
 	}
@@ -123,7 +132,10 @@ func (wr *workspaceResolver) ResolveWorkspacesForBatchSpec(ctx context.Context,
 		if workspaces[i].Path != workspaces[j].Path {
 			return workspaces[i].Path < workspaces[j].Path
 		}
-		return workspaces[i].Branch < workspaces[j].Branch
+		if workspaces[i].Branch != workspaces[j].Branch {
+			return workspaces[i].Branch < workspaces[j].Branch
+		}
+		return workspaces[i].Owner < workspaces[j].Owner
 	})
 
 	return workspaces, nil
@@ -554,15 +566,40 @@ type directoryFinder interface {
 	FindDirectoriesInRepos(ctx context.Context, fileName string, repos ...*RepoRevision) (map[repoRevKey][]string, error)
 }
 
+// LoadCodeOwnersInRepos returns the CODEOWNERS rules of the given
+// repositories. Repositories without a CODEOWNERS file are left out.
+func (wr *workspaceResolver) LoadCodeOwnersInRepos(ctx context.Context, repos ...*RepoRevision) (map[repoRevKey]*codeownerslib.Ruleset, error) {
+	db := database.NewDBWith(wr.store)
+
+	results := make(map[repoRevKey]*codeownerslib.Ruleset, len(repos))
+	for _, repoRev := range repos {
+		rs, err := codeowners.Load(ctx, db, repoRev.Repo.Name, repoRev.Commit)
+		if err != nil {
+			return nil, errors.Wrapf(err, "loading CODEOWNERS of %s", repoRev.Repo.Name)
+		}
+		if rs != nil {
+			results[repoRev.Key()] = rs
+		}
+	}
+	return results, nil
+}
+
+type codeOwnersLoader interface {
+	LoadCodeOwnersInRepos(ctx context.Context, repos ...*RepoRevision) (map[repoRevKey]*codeownerslib.Ruleset, error)
+}
+
 // findWorkspaces matches the given repos to the workspace configs and
 // searches, via the Sourcegraph instance, the locations of the workspaces in
 // each repository.
 // The repositories that were matched by a workspace config and all repos that didn't
 // match a config are returned as workspaces.
+// Workspaces of configs that split by code owner are split up further, using
+// the CODEOWNERS rules returned by loader.
 func findWorkspaces(
 	ctx context.Context,
 	spec *batcheslib.BatchSpec,
 	finder directoryFinder,
+	loader codeOwnersLoader,
 	repoRevs []*RepoRevision,
 ) ([]*RepoWorkspace, error) {
 	// Pre-compile all globs.
@@ -616,13 +653,26 @@ func findWorkspaces(
 		*RepoRevision
 		Paths              []string
 		OnlyFetchWorkspace bool
+		SplitByCodeOwners  bool
 	}
 	workspacesByRepoRev := map[repoRevKey]repoWorkspaces{}
 	for idx, repoRevs := range matched {
 		conf := spec.Workspaces[idx]
-		repoRevDirs, err := finder.FindDirectoriesInRepos(ctx, conf.RootAtLocationOf, repoRevs...)
-		if err != nil {
-			return nil, err
+
+		var repoRevDirs map[repoRevKey][]string
+		if conf.RootAtLocationOf != "" {
+			var err error
+			repoRevDirs, err = finder.FindDirectoriesInRepos(ctx, conf.RootAtLocationOf, repoRevs...)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			// Configs that only split by code owner use the root of the
+			// repository as the workspace.
+			repoRevDirs = make(map[repoRevKey][]string, len(repoRevs))
+			for _, repoRev := range repoRevs {
+				repoRevDirs[repoRev.Key()] = []string{""}
+			}
 		}
 
 		repoRevsByKey := map[repoRevKey]*RepoRevision{}
@@ -639,10 +689,26 @@ func findWorkspaces(
 				RepoRevision:       repoRevsByKey[repoRevKey],
 				Paths:              dirs,
 				OnlyFetchWorkspace: conf.OnlyFetchWorkspace,
+				SplitByCodeOwners:  conf.SplitByCodeOwners,
 			}
 		}
 	}
 
+	var toSplit []*RepoRevision
+	for _, workspace := range workspacesByRepoRev {
+		if workspace.SplitByCodeOwners {
+			toSplit = append(toSplit, workspace.RepoRevision)
+		}
+	}
+	codeOwnersByRepoRev := map[repoRevKey]*codeownerslib.Ruleset{}
+	if len(toSplit) > 0 {
+		var err error
+		codeOwnersByRepoRev, err = loader.LoadCodeOwnersInRepos(ctx, toSplit...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// And add the root for repos.
 	for _, repoRev := range root {
 		conf, ok := workspacesByRepoRev[repoRev.Key()]
@@ -666,6 +732,13 @@ func findWorkspaces(
 				fetchWorkspace = false
 			}
 
+			// Repositories without a CODEOWNERS file can't be split, so they
+			// keep the workspace as is.
+			if rules, ok := codeOwnersByRepoRev[workspace.Key()]; ok && workspace.SplitByCodeOwners {
+				workspaces = append(workspaces, splitWorkspaceByCodeOwner(workspace.RepoRevision, path, fetchWorkspace, rules)...)
+				continue
+			}
+
 			workspaces = append(workspaces, &RepoWorkspace{
 				RepoRevision:       workspace.RepoRevision,
 				Path:               path,
@@ -677,6 +750,9 @@ func findWorkspaces(
 	// Stable sorting.
 	sort.Slice(workspaces, func(i, j int) bool {
 		if workspaces[i].Repo.Name == workspaces[j].Repo.Name {
+			if workspaces[i].Path == workspaces[j].Path {
+				return workspaces[i].Owner < workspaces[j].Owner
+			}
 			return workspaces[i].Path < workspaces[j].Path
 		}
 		return workspaces[i].Repo.Name < workspaces[j].Repo.Name
@@ -685,6 +761,42 @@ func findWorkspaces(
 	return workspaces, nil
 }
 
+// splitWorkspaceByCodeOwner returns a workspace for each code owner of the
+// file matches inside of the workspace at path. Each workspace only has the
+// file matches of its owner. File matches that aren't owned by anyone are left
+// out.
+func splitWorkspaceByCodeOwner(repoRev *RepoRevision, path string, onlyFetchWorkspace bool, rules *codeownerslib.Ruleset) []*RepoWorkspace {
+	fileMatchesByOwner := make(map[string][]string)
+	for _, fm := range repoRev.FileMatches {
+		if path != "" && !strings.HasPrefix(fm, path+"/") {
+			continue
+		}
+
+		owner := rules.Owner(fm)
+		if owner == "" {
+			continue
+		}
+		fileMatchesByOwner[owner] = append(fileMatchesByOwner[owner], fm)
+	}
+
+	workspaces := make([]*RepoWorkspace, 0, len(fileMatchesByOwner))
+	for owner, fileMatches := range fileMatchesByOwner {
+		workspaces = append(workspaces, &RepoWorkspace{
+			RepoRevision: &RepoRevision{
+				Repo:        repoRev.Repo,
+				Branch:      repoRev.Branch,
+				Commit:      repoRev.Commit,
+				FileMatches: fileMatches,
+			},
+			Path:               path,
+			OnlyFetchWorkspace: onlyFetchWorkspace,
+			Owner:              owner,
+			CodeOwners:         rules,
+		})
+	}
+	return workspaces
+}
+
 type repoRevKey struct {
 	RepoID int32
 	Branch string
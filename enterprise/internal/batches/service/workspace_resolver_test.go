@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
@@ -28,6 +29,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/util"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	codeownerslib "github.com/sourcegraph/sourcegraph/lib/batches/codeowners"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -487,16 +489,23 @@ func mockResolveRevision(t *testing.T, branches map[string]api.CommitID) {
 func TestFindWorkspaces(t *testing.T) {
 	repoRevs := []*RepoRevision{
 		{Repo: &types.Repo{ID: 1, Name: "github.com/sourcegraph/automation-testing"}},
-		{Repo: &types.Repo{ID: 2, Name: "github.com/sourcegraph/sourcegraph"}},
+		{Repo: &types.Repo{ID: 2, Name: "github.com/sourcegraph/sourcegraph"}, FileMatches: []string{"README.md", "cmd/main.go", "cmd/web/index.js", "web/index.js"}},
 		{Repo: &types.Repo{ID: 3, Name: "bitbucket.sgdev.org/SOUR/automation-testing"}},
 	}
 	steps := []batcheslib.Step{{Run: "echo 1"}}
 
+	codeOwners, err := codeownerslib.Parse("*.js @sourcegraph/frontend\n/cmd/ @sourcegraph/backend\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	type finderResults map[repoRevKey][]string
+	type codeOwnersResults map[repoRevKey]*codeownerslib.Ruleset
 
 	tests := map[string]struct {
-		spec          *batcheslib.BatchSpec
-		finderResults finderResults
+		spec              *batcheslib.BatchSpec
+		finderResults     finderResults
+		codeOwnersResults codeOwnersResults
 
 		// workspaces in which repo/path they are executed
 		wantWorkspaces []*RepoWorkspace
@@ -607,12 +616,65 @@ func TestFindWorkspaces(t *testing.T) {
 				{RepoRevision: repoRevs[2], Path: "a/b"},
 			},
 		},
+		"workspace configuration splitting by code owners": {
+			spec: &batcheslib.BatchSpec{
+				Steps: steps,
+				Workspaces: []batcheslib.WorkspaceConfiguration{
+					{In: "github.com/sourcegraph/*", SplitByCodeOwners: true},
+				},
+			},
+			codeOwnersResults: codeOwnersResults{
+				repoRevs[1].Key(): codeOwners,
+			},
+			wantWorkspaces: []*RepoWorkspace{
+				// Without a CODEOWNERS file the workspace isn't split.
+				{RepoRevision: repoRevs[0], Path: ""},
+				{
+					RepoRevision: &RepoRevision{Repo: repoRevs[1].Repo, FileMatches: []string{"cmd/main.go", "cmd/web/index.js"}},
+					Path:         "",
+					Owner:        "@sourcegraph/backend",
+					CodeOwners:   codeOwners,
+				},
+				{
+					RepoRevision: &RepoRevision{Repo: repoRevs[1].Repo, FileMatches: []string{"web/index.js"}},
+					Path:         "",
+					Owner:        "@sourcegraph/frontend",
+					CodeOwners:   codeOwners,
+				},
+				{RepoRevision: repoRevs[2], Path: ""},
+			},
+		},
+		"workspace configuration splitting workspaces by code owners": {
+			spec: &batcheslib.BatchSpec{
+				Steps: steps,
+				Workspaces: []batcheslib.WorkspaceConfiguration{
+					{In: "github.com/sourcegraph/sourcegraph", RootAtLocationOf: "go.mod", SplitByCodeOwners: true},
+				},
+			},
+			finderResults: finderResults{
+				repoRevs[1].Key(): {"cmd/web"},
+			},
+			codeOwnersResults: codeOwnersResults{
+				repoRevs[1].Key(): codeOwners,
+			},
+			wantWorkspaces: []*RepoWorkspace{
+				{RepoRevision: repoRevs[0], Path: ""},
+				{
+					RepoRevision: &RepoRevision{Repo: repoRevs[1].Repo, FileMatches: []string{"cmd/web/index.js"}},
+					Path:         "cmd/web",
+					Owner:        "@sourcegraph/backend",
+					CodeOwners:   codeOwners,
+				},
+				{RepoRevision: repoRevs[2], Path: ""},
+			},
+		},
 	}
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
 			finder := &mockDirectoryFinder{results: tt.finderResults}
-			workspaces, err := findWorkspaces(context.Background(), tt.spec, finder, repoRevs)
+			loader := &mockCodeOwnersLoader{results: tt.codeOwnersResults}
+			workspaces, err := findWorkspaces(context.Background(), tt.spec, finder, loader, repoRevs)
 			if err != nil {
 				t.Fatalf("unexpected err: %s", err)
 			}
@@ -620,12 +682,15 @@ func TestFindWorkspaces(t *testing.T) {
 			// Sort by ID, easier than by name for tests.
 			sort.Slice(workspaces, func(i, j int) bool {
 				if workspaces[i].Repo.ID == workspaces[j].Repo.ID {
+					if workspaces[i].Path == workspaces[j].Path {
+						return workspaces[i].Owner < workspaces[j].Owner
+					}
 					return workspaces[i].Path < workspaces[j].Path
 				}
 				return workspaces[i].Repo.ID < workspaces[j].Repo.ID
 			})
 
-			if diff := cmp.Diff(tt.wantWorkspaces, workspaces); diff != "" {
+			if diff := cmp.Diff(tt.wantWorkspaces, workspaces, cmpopts.IgnoreUnexported(codeownerslib.Rule{})); diff != "" {
 				t.Errorf("mismatch (-want +got):\n%s", diff)
 			}
 		})
@@ -639,3 +704,11 @@ type mockDirectoryFinder struct {
 func (m *mockDirectoryFinder) FindDirectoriesInRepos(ctx context.Context, fileName string, repos ...*RepoRevision) (map[repoRevKey][]string, error) {
 	return m.results, nil
 }
+
+type mockCodeOwnersLoader struct {
+	results map[repoRevKey]*codeownerslib.Ruleset
+}
+
+func (m *mockCodeOwnersLoader) LoadCodeOwnersInRepos(ctx context.Context, repos ...*RepoRevision) (map[repoRevKey]*codeownerslib.Ruleset, error) {
+	return m.results, nil
+}
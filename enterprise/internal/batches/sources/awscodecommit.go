@@ -0,0 +1,268 @@
+package sources
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/config"
+	awscredentials "github.com/aws/aws-sdk-go-v2/credentials"
+	"golang.org/x/net/http2"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// AWSCodeCommitSource is a ChangesetSource for AWS CodeCommit pull requests.
+// The AWS CodeCommit API is always accessed with the access key of the
+// external service, since it doesn't accept Git credentials. The
+// authenticator is only used to push commits.
+type AWSCodeCommitSource struct {
+	client *awscodecommit.Client
+	au     auth.Authenticator
+}
+
+var _ ChangesetSource = AWSCodeCommitSource{}
+
+// awsCodeCommitMaxAttempts is the number of attempts made for each API
+// request. The AWS CodeCommit API throttles aggressively, so we retry more
+// often and back off for longer than the AWS SDK does by default.
+const awsCodeCommitMaxAttempts = 8
+
+func NewAWSCodeCommitSource(svc *types.ExternalService, cf *httpcli.Factory) (*AWSCodeCommitSource, error) {
+	var c schema.AWSCodeCommitConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d", svc.ID)
+	}
+	return newAWSCodeCommitSource(&c, cf)
+}
+
+// newAWSCodeCommitSource creates an AWSCodeCommitSource for the given
+// configuration. The optFns are applied after the default options, which
+// allows tests to override the endpoint and retry behaviour.
+func newAWSCodeCommitSource(c *schema.AWSCodeCommitConnection, cf *httpcli.Factory, optFns ...func(*config.LoadOptions) error) (*AWSCodeCommitSource, error) {
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	cli, err := cf.Doer(func(c *http.Client) error {
+		tr := awshttp.NewBuildableClient().GetTransport()
+		if err := http2.ConfigureTransport(tr); err != nil {
+			return err
+		}
+		c.Transport = tr
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "creating external client")
+	}
+
+	opts := []func(*config.LoadOptions) error{
+		config.WithRegion(c.Region),
+		config.WithCredentialsProvider(
+			awscredentials.StaticCredentialsProvider{
+				Value: aws.Credentials{
+					AccessKeyID:     c.AccessKeyID,
+					SecretAccessKey: c.SecretAccessKey,
+					Source:          "sourcegraph-site-configuration",
+				},
+			},
+		),
+		config.WithHTTPClient(cli),
+		config.WithRetryer(func() aws.Retryer {
+			return retry.NewStandard(func(o *retry.StandardOptions) {
+				o.MaxAttempts = awsCodeCommitMaxAttempts
+				o.MaxBackoff = time.Minute
+			})
+		}),
+	}
+	awsConfig, err := config.LoadDefaultConfig(context.Background(), append(opts, optFns...)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading AWS configuration")
+	}
+
+	return &AWSCodeCommitSource{
+		client: awscodecommit.NewClient(awsConfig),
+		au: &auth.BasicAuth{
+			Username: c.GitCredentials.Username,
+			Password: c.GitCredentials.Password,
+		},
+	}, nil
+}
+
+// GitserverPushConfig returns an authenticated push config used for pushing
+// commits to the code host.
+func (s AWSCodeCommitSource) GitserverPushConfig(ctx context.Context, store database.ExternalServiceStore, repo *types.Repo) (*protocol.PushConfig, error) {
+	return gitserverPushConfig(ctx, store, repo, s.au)
+}
+
+// WithAuthenticator returns a copy of the original Source configured to use the
+// given authenticator, provided that authenticator type is supported by the
+// code host.
+func (s AWSCodeCommitSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	switch a.(type) {
+	case *auth.BasicAuth,
+		*auth.BasicAuthWithSSH:
+		break
+
+	default:
+		return nil, newUnsupportedAuthenticatorError("AWSCodeCommitSource", a)
+	}
+
+	return &AWSCodeCommitSource{client: s.client, au: a}, nil
+}
+
+// ValidateAuthenticator validates the currently set authenticator is usable.
+// Returns an error, when validating the Authenticator yielded an error.
+func (s AWSCodeCommitSource) ValidateAuthenticator(ctx context.Context) error {
+	// Git credentials can't be used with the AWS CodeCommit API, so the best
+	// we can do is to check that they are present. Invalid credentials will
+	// surface when pushing.
+	switch a := s.au.(type) {
+	case *auth.BasicAuth:
+		if a.Username == "" || a.Password == "" {
+			return errors.New("missing Git username or password")
+		}
+	case *auth.BasicAuthWithSSH:
+		if a.Username == "" || a.Password == "" {
+			return errors.New("missing Git username or password")
+		}
+	}
+	return nil
+}
+
+// LoadChangeset loads the given Changeset from the source and updates it. If
+// the Changeset could not be found on the source, a ChangesetNotFoundError is
+// returned.
+func (s AWSCodeCommitSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	pr, err := s.client.GetPullRequest(ctx, cs.ExternalID)
+	if err != nil {
+		if awscodecommit.IsPullRequestNotFound(err) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "getting pull request")
+	}
+
+	return cs.SetMetadata(pr)
+}
+
+// CreateChangeset will create the Changeset on the source. If it already
+// exists, *Changeset will be populated and the return value will be true.
+func (s AWSCodeCommitSource) CreateChangeset(ctx context.Context, cs *Changeset) (bool, error) {
+	repo := cs.TargetRepo.Metadata.(*awscodecommit.Repository)
+	headRef := gitdomain.EnsureRefPrefix(cs.HeadRef)
+	baseRef := gitdomain.EnsureRefPrefix(cs.BaseRef)
+
+	// AWS CodeCommit happily creates any number of pull requests between the
+	// same branches, so we have to look for an existing one first.
+	pr, err := s.client.FindOpenPullRequest(ctx, repo.Name, headRef, baseRef)
+	if err != nil {
+		return false, errors.Wrap(err, "finding existing pull request")
+	}
+	exists := pr != nil
+
+	if !exists {
+		pr, err = s.client.CreatePullRequest(ctx, awscodecommit.CreatePullRequestInput{
+			RepositoryName:       repo.Name,
+			Title:                cs.Title,
+			Description:          cs.Body,
+			SourceReference:      headRef,
+			DestinationReference: baseRef,
+		})
+		if err != nil {
+			return false, errors.Wrap(err, "creating pull request")
+		}
+	}
+
+	if err := cs.SetMetadata(pr); err != nil {
+		return false, errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return exists, nil
+}
+
+// CloseChangeset will close the Changeset on the source, where "close"
+// means the appropriate final state on the codehost (e.g. "declined" on
+// Bitbucket Server).
+func (s AWSCodeCommitSource) CloseChangeset(ctx context.Context, cs *Changeset) error {
+	pr := cs.Metadata.(*awscodecommit.PullRequest)
+
+	updated, err := s.client.ClosePullRequest(ctx, pr.ID)
+	if err != nil {
+		return errors.Wrap(err, "closing pull request")
+	}
+
+	return cs.SetMetadata(updated)
+}
+
+// UpdateChangeset can update Changesets.
+func (s AWSCodeCommitSource) UpdateChangeset(ctx context.Context, cs *Changeset) error {
+	pr := cs.Metadata.(*awscodecommit.PullRequest)
+
+	// The destination of an AWS CodeCommit pull request can't be changed.
+	if gitdomain.EnsureRefPrefix(cs.BaseRef) != pr.DestinationReference {
+		return errors.Errorf("the base branch of AWS CodeCommit pull requests cannot be changed from %q to %q", gitdomain.AbbreviateRef(pr.DestinationReference), gitdomain.AbbreviateRef(cs.BaseRef))
+	}
+
+	updated := pr
+	var err error
+	if cs.Title != pr.Title {
+		if updated, err = s.client.UpdatePullRequestTitle(ctx, pr.ID, cs.Title); err != nil {
+			return errors.Wrap(err, "updating pull request title")
+		}
+	}
+	if cs.Body != pr.Description {
+		if updated, err = s.client.UpdatePullRequestDescription(ctx, pr.ID, cs.Body); err != nil {
+			return errors.Wrap(err, "updating pull request description")
+		}
+	}
+
+	return cs.SetMetadata(updated)
+}
+
+// ReopenChangeset will reopen the Changeset on the source, if it's closed.
+// If not, it's a noop.
+func (s AWSCodeCommitSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
+	// Closed AWS CodeCommit pull requests can't be reopened, so, as with
+	// Bitbucket Cloud, we create a new pull request instead. If the pull
+	// request is still open, CreateChangeset will find it, which makes this a
+	// no-op as required.
+	_, err := s.CreateChangeset(ctx, cs)
+	return err
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s AWSCodeCommitSource) CreateComment(ctx context.Context, cs *Changeset, comment string) error {
+	pr := cs.Metadata.(*awscodecommit.PullRequest)
+	return s.client.CreatePullRequestComment(ctx, pr, comment)
+}
+
+// MergeChangeset merges a Changeset on the code host, if in a mergeable state.
+// If squash is true, and the code host supports squash merges, the source
+// must attempt a squash merge. Otherwise, it is expected to perform a regular
+// merge. If the changeset cannot be merged, because it is in an unmergeable
+// state, ChangesetNotMergeableError must be returned.
+func (s AWSCodeCommitSource) MergeChangeset(ctx context.Context, cs *Changeset, squash bool) error {
+	pr := cs.Metadata.(*awscodecommit.PullRequest)
+
+	updated, err := s.client.MergePullRequest(ctx, pr, squash)
+	if err != nil {
+		if awscodecommit.IsNotMergeable(err) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "merging pull request")
+	}
+
+	return cs.SetMetadata(updated)
+}
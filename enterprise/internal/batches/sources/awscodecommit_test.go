@@ -0,0 +1,366 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/stretchr/testify/assert"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestAWSCodeCommitSource_CreateChangeset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("existing pull request", func(t *testing.T) {
+		api := newFakeCodeCommit(t)
+		api.add(fakePullRequest{ID: "1", SourceReference: "refs/heads/other", DestinationReference: "refs/heads/main"})
+		api.add(fakePullRequest{ID: "2", SourceReference: "refs/heads/feature", DestinationReference: "refs/heads/main"})
+
+		cs := testAWSCodeCommitChangeset()
+		exists, err := api.source.CreateChangeset(ctx, cs)
+		assert.Nil(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, "2", cs.ExternalID)
+		assert.Equal(t, 0, api.calls["CreatePullRequest"])
+	})
+
+	t.Run("new pull request after throttling", func(t *testing.T) {
+		api := newFakeCodeCommit(t)
+		api.throttle["CreatePullRequest"] = 2
+
+		cs := testAWSCodeCommitChangeset()
+		exists, err := api.source.CreateChangeset(ctx, cs)
+		assert.Nil(t, err)
+		assert.False(t, exists)
+		assert.Equal(t, 3, api.calls["CreatePullRequest"])
+
+		pr := cs.Metadata.(*awscodecommit.PullRequest)
+		assert.Equal(t, cs.ExternalID, pr.ID)
+		assert.Equal(t, "refs/heads/feature", cs.ExternalBranch)
+		assert.Equal(t, "title", pr.Title)
+		assert.Equal(t, "body", pr.Description)
+	})
+}
+
+func TestAWSCodeCommitSource_LoadChangeset(t *testing.T) {
+	ctx := context.Background()
+	api := newFakeCodeCommit(t)
+	api.add(fakePullRequest{
+		ID:                   "1",
+		SourceReference:      "refs/heads/feature",
+		DestinationReference: "refs/heads/main",
+		Approvals:            []string{"arn:aws:iam::999999999999:user/reviewer"},
+	})
+
+	t.Run("found", func(t *testing.T) {
+		cs := testAWSCodeCommitChangeset()
+		cs.ExternalID = "1"
+		assert.Nil(t, api.source.LoadChangeset(ctx, cs))
+
+		pr := cs.Metadata.(*awscodecommit.PullRequest)
+		assert.Equal(t, []*awscodecommit.Approval{{UserARN: "arn:aws:iam::999999999999:user/reviewer", State: "APPROVE"}}, pr.Approvals)
+		assert.NotNil(t, pr.Evaluation)
+		assert.Equal(t, "https://us-west-1.console.aws.amazon.com/codesuite/codecommit/repositories/repo/pull-requests/1/details?region=us-west-1", pr.URL())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		cs := testAWSCodeCommitChangeset()
+		cs.ExternalID = "404"
+		err := api.source.LoadChangeset(ctx, cs)
+		assert.True(t, errors.HasType(err, ChangesetNotFoundError{}), "unexpected error: %v", err)
+	})
+}
+
+func TestAWSCodeCommitSource_UpdateChangeset(t *testing.T) {
+	ctx := context.Background()
+	api := newFakeCodeCommit(t)
+	api.add(fakePullRequest{ID: "1", Title: "title", Description: "body", SourceReference: "refs/heads/feature", DestinationReference: "refs/heads/main"})
+
+	t.Run("title", func(t *testing.T) {
+		cs := testAWSCodeCommitChangeset()
+		cs.ExternalID = "1"
+		assert.Nil(t, api.source.LoadChangeset(ctx, cs))
+
+		cs.Title = "new title"
+		assert.Nil(t, api.source.UpdateChangeset(ctx, cs))
+		assert.Equal(t, "new title", cs.Metadata.(*awscodecommit.PullRequest).Title)
+		assert.Equal(t, 1, api.calls["UpdatePullRequestTitle"])
+		assert.Equal(t, 0, api.calls["UpdatePullRequestDescription"])
+	})
+
+	t.Run("base ref", func(t *testing.T) {
+		cs := testAWSCodeCommitChangeset()
+		cs.ExternalID = "1"
+		assert.Nil(t, api.source.LoadChangeset(ctx, cs))
+
+		cs.BaseRef = "refs/heads/develop"
+		assert.NotNil(t, api.source.UpdateChangeset(ctx, cs))
+	})
+}
+
+func TestAWSCodeCommitSource_MergeChangeset(t *testing.T) {
+	ctx := context.Background()
+	api := newFakeCodeCommit(t)
+	api.add(fakePullRequest{ID: "1", SourceReference: "refs/heads/feature", DestinationReference: "refs/heads/main"})
+	api.add(fakePullRequest{ID: "2", SourceReference: "refs/heads/conflict", DestinationReference: "refs/heads/main", Conflicts: true})
+
+	t.Run("merged", func(t *testing.T) {
+		cs := testAWSCodeCommitChangeset()
+		cs.ExternalID = "1"
+		assert.Nil(t, api.source.LoadChangeset(ctx, cs))
+
+		assert.Nil(t, api.source.MergeChangeset(ctx, cs, true))
+		assert.True(t, cs.Metadata.(*awscodecommit.PullRequest).IsMerged)
+	})
+
+	t.Run("not mergeable", func(t *testing.T) {
+		cs := testAWSCodeCommitChangeset()
+		cs.ExternalID = "2"
+		assert.Nil(t, api.source.LoadChangeset(ctx, cs))
+
+		err := api.source.MergeChangeset(ctx, cs, true)
+		assert.True(t, errors.HasType(err, ChangesetNotMergeableError{}), "unexpected error: %v", err)
+	})
+}
+
+func TestAWSCodeCommitSource_WithAuthenticator(t *testing.T) {
+	api := newFakeCodeCommit(t)
+
+	t.Run("supported", func(t *testing.T) {
+		src, err := api.source.WithAuthenticator(&auth.BasicAuth{Username: "git", Password: "secret"})
+		assert.Nil(t, err)
+		assert.Nil(t, src.ValidateAuthenticator(context.Background()))
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := api.source.WithAuthenticator(&auth.OAuthBearerToken{Token: "token"})
+		assert.True(t, errors.HasType(err, UnsupportedAuthenticatorError{}), "unexpected error: %v", err)
+	})
+}
+
+func testAWSCodeCommitChangeset() *Changeset {
+	repo := &types.Repo{Metadata: &awscodecommit.Repository{Name: "repo"}}
+	return &Changeset{
+		Title:      "title",
+		Body:       "body",
+		HeadRef:    "refs/heads/feature",
+		BaseRef:    "refs/heads/main",
+		RemoteRepo: repo,
+		TargetRepo: repo,
+		Changeset:  &btypes.Changeset{},
+	}
+}
+
+type fakePullRequest struct {
+	ID                   string
+	Title                string
+	Description          string
+	Status               string
+	SourceReference      string
+	DestinationReference string
+	IsMerged             bool
+	Approvals            []string
+	Conflicts            bool
+}
+
+func (pr *fakePullRequest) toJSON() map[string]any {
+	return map[string]any{
+		"pullRequestId":     pr.ID,
+		"revisionId":        "rev-" + pr.ID,
+		"title":             pr.Title,
+		"description":       pr.Description,
+		"pullRequestStatus": pr.Status,
+		"authorArn":         "arn:aws:iam::999999999999:user/author",
+		"creationDate":      1600000000,
+		"lastActivityDate":  1600000000,
+		"pullRequestTargets": []any{map[string]any{
+			"repositoryName":       "repo",
+			"sourceReference":      pr.SourceReference,
+			"destinationReference": pr.DestinationReference,
+			"sourceCommit":         "deadbeef",
+			"destinationCommit":    "cafebabe",
+			"mergeMetadata":        map[string]any{"isMerged": pr.IsMerged},
+		}},
+	}
+}
+
+// fakeCodeCommit is a fake AWS CodeCommit API server that supports the
+// subset of the API used by AWSCodeCommitSource.
+type fakeCodeCommit struct {
+	source *AWSCodeCommitSource
+
+	mu       sync.Mutex
+	prs      map[string]*fakePullRequest
+	order    []string
+	calls    map[string]int
+	throttle map[string]int // number of times to throttle each operation
+}
+
+func newFakeCodeCommit(t *testing.T) *fakeCodeCommit {
+	t.Helper()
+
+	f := &fakeCodeCommit{
+		prs:      map[string]*fakePullRequest{},
+		calls:    map[string]int{},
+		throttle: map[string]int{},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(srv.Close)
+
+	src, err := newAWSCodeCommitSource(&schema.AWSCodeCommitConnection{
+		AccessKeyID:     "access-key",
+		SecretAccessKey: "secret-key",
+		Region:          "us-west-1",
+		GitCredentials:  schema.AWSCodeCommitGitCredentials{Username: "git", Password: "git-password"},
+	}, nil,
+		config.WithEndpointResolverWithOptions(aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...any) (aws.Endpoint, error) {
+			return aws.Endpoint{URL: srv.URL, SigningRegion: region}, nil
+		})),
+		config.WithRetryer(func() aws.Retryer {
+			return retry.NewStandard(func(o *retry.StandardOptions) {
+				o.MaxAttempts = awsCodeCommitMaxAttempts
+				o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) { return 0, nil })
+			})
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.source = src
+
+	return f
+}
+
+func (f *fakeCodeCommit) add(pr fakePullRequest) {
+	if pr.Status == "" {
+		pr.Status = "OPEN"
+	}
+	f.prs[pr.ID] = &pr
+	f.order = append(f.order, pr.ID)
+}
+
+func (f *fakeCodeCommit) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	op := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "CodeCommit_20150413.")
+	f.calls[op]++
+
+	writeError := func(code string) {
+		w.Header().Set("X-Amzn-ErrorType", code)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]any{"__type": code, "message": code})
+	}
+	if f.throttle[op] > 0 {
+		f.throttle[op]--
+		writeError("ThrottlingException")
+		return
+	}
+
+	var in struct {
+		PullRequestID string `json:"pullRequestId"`
+		NextToken     string `json:"nextToken"`
+		Title         string `json:"title"`
+		Description   string `json:"description"`
+		Status        string `json:"pullRequestStatus"`
+		Targets       []struct {
+			SourceReference      string `json:"sourceReference"`
+			DestinationReference string `json:"destinationReference"`
+		} `json:"targets"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	var pr *fakePullRequest
+	if in.PullRequestID != "" {
+		if pr = f.prs[in.PullRequestID]; pr == nil {
+			writeError("PullRequestDoesNotExistException")
+			return
+		}
+	}
+
+	var out any
+	switch op {
+	case "ListPullRequests":
+		// Return one pull request per page to exercise pagination.
+		var ids []string
+		for _, id := range f.order {
+			if f.prs[id].Status == "OPEN" {
+				ids = append(ids, id)
+			}
+		}
+		page := map[string]any{"pullRequestIds": []string{}}
+		for i, id := range ids {
+			if in.NextToken == "" || in.NextToken == id {
+				page["pullRequestIds"] = []string{id}
+				if i+1 < len(ids) {
+					page["nextToken"] = ids[i+1]
+				}
+				break
+			}
+		}
+		out = page
+	case "CreatePullRequest":
+		pr = &fakePullRequest{
+			ID:                   fmt.Sprint(len(f.order) + 1),
+			Title:                in.Title,
+			Description:          in.Description,
+			SourceReference:      in.Targets[0].SourceReference,
+			DestinationReference: in.Targets[0].DestinationReference,
+		}
+		f.add(*pr)
+		out = map[string]any{"pullRequest": pr.toJSON()}
+	case "GetPullRequest":
+		out = map[string]any{"pullRequest": pr.toJSON()}
+	case "UpdatePullRequestTitle":
+		pr.Title = in.Title
+		out = map[string]any{"pullRequest": pr.toJSON()}
+	case "UpdatePullRequestDescription":
+		pr.Description = in.Description
+		out = map[string]any{"pullRequest": pr.toJSON()}
+	case "UpdatePullRequestStatus":
+		pr.Status = in.Status
+		out = map[string]any{"pullRequest": pr.toJSON()}
+	case "MergePullRequestBySquash", "MergePullRequestByThreeWay":
+		if pr.Conflicts {
+			writeError("ManualMergeRequiredException")
+			return
+		}
+		pr.IsMerged = true
+		pr.Status = "CLOSED"
+		out = map[string]any{"pullRequest": pr.toJSON()}
+	case "GetPullRequestApprovalStates":
+		approvals := make([]any, 0, len(pr.Approvals))
+		for _, a := range pr.Approvals {
+			approvals = append(approvals, map[string]any{"userArn": a, "approvalState": "APPROVE"})
+		}
+		out = map[string]any{"approvals": approvals}
+	case "EvaluatePullRequestApprovalRules":
+		out = map[string]any{"evaluation": map[string]any{"approved": len(pr.Approvals) > 0}}
+	default:
+		http.Error(w, "unexpected operation "+op, http.StatusNotImplemented)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	_ = json.NewEncoder(w).Encode(out)
+}
@@ -22,6 +22,8 @@ type BitbucketCloudSource struct {
 	client bitbucketcloud.Client
 }
 
+var _ MetadataChangesetSource = BitbucketCloudSource{}
+
 var (
 	_ ForkableChangesetSource = BitbucketCloudSource{}
 )
@@ -150,6 +152,61 @@ func (s BitbucketCloudSource) UpdateChangeset(ctx context.Context, cs *Changeset
 	return s.setChangesetMetadata(ctx, targetRepo, updated, cs)
 }
 
+// UpdateChangesetMetadata adds the reviewers of the Changeset to the pull
+// request and removes the ones dropped from the spec. Bitbucket Cloud
+// identifies reviewers by their account UUID, and doesn't support labels,
+// assignees or auto-merge, so those are ignored.
+func (s BitbucketCloudSource) UpdateChangesetMetadata(ctx context.Context, cs *Changeset) error {
+	if len(cs.Reviewers) == 0 && len(cs.RemovedReviewers) == 0 {
+		return nil
+	}
+
+	targetRepo := cs.TargetRepo.Metadata.(*bitbucketcloud.Repo)
+	pr := cs.Metadata.(*bbcs.AnnotatedPullRequest)
+
+	// The reviewers of the pull request are replaced on update, so we have to
+	// send the existing ones along with the new ones, minus the removed ones.
+	seen := make(map[string]struct{}, len(pr.Reviewers)+len(cs.Reviewers)+len(cs.RemovedReviewers))
+	for _, uuid := range cs.RemovedReviewers {
+		seen[uuid] = struct{}{}
+	}
+	reviewers := make([]string, 0, len(pr.Reviewers)+len(cs.Reviewers))
+	for _, uuid := range append(accountUUIDs(pr.Reviewers), cs.Reviewers...) {
+		if _, ok := seen[uuid]; ok {
+			continue
+		}
+		seen[uuid] = struct{}{}
+		reviewers = append(reviewers, uuid)
+	}
+
+	destBranch := pr.Destination.Branch.Name
+	opts := bitbucketcloud.PullRequestInput{
+		Title:             pr.Title,
+		Description:       pr.Rendered.Description.Raw,
+		SourceBranch:      pr.Source.Branch.Name,
+		DestinationBranch: &destBranch,
+		Reviewers:         reviewers,
+	}
+	if pr.Source.Repo.FullName != targetRepo.FullName {
+		opts.SourceRepo = &pr.Source.Repo
+	}
+
+	updated, err := s.client.UpdatePullRequest(ctx, targetRepo, pr.ID, opts)
+	if err != nil {
+		return errors.Wrap(err, "updating pull request")
+	}
+
+	return s.setChangesetMetadata(ctx, targetRepo, updated, cs)
+}
+
+func accountUUIDs(accounts []bitbucketcloud.Account) []string {
+	uuids := make([]string, 0, len(accounts))
+	for _, a := range accounts {
+		uuids = append(uuids, a.UUID)
+	}
+	return uuids
+}
+
 // ReopenChangeset will reopen the Changeset on the source, if it's closed.
 // If not, it's a noop.
 func (s BitbucketCloudSource) ReopenChangeset(ctx context.Context, cs *Changeset) error {
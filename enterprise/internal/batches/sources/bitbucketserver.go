@@ -24,6 +24,7 @@ type BitbucketServerSource struct {
 }
 
 var _ ForkableChangesetSource = BitbucketServerSource{}
+var _ MetadataChangesetSource = BitbucketServerSource{}
 
 // NewBitbucketServerSource returns a new BitbucketServerSource from the given external service.
 func NewBitbucketServerSource(svc *types.ExternalService, cf *httpcli.Factory) (*BitbucketServerSource, error) {
@@ -208,17 +209,83 @@ func (s BitbucketServerSource) UpdateChangeset(ctx context.Context, c *Changeset
 	update.ToRef.Repository.Slug = pr.ToRef.Repository.Slug
 	update.ToRef.Repository.Project.Key = pr.ToRef.Repository.Project.Key
 
+	updated, err := s.updatePullRequest(ctx, pr, update)
+	if err != nil {
+		return err
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
+// UpdateChangesetMetadata adds the reviewers of the Changeset to the pull
+// request and removes the ones dropped from the spec. Bitbucket Server doesn't
+// support labels, assignees or auto-merge, so those are ignored.
+func (s BitbucketServerSource) UpdateChangesetMetadata(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*bitbucketserver.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a Bitbucket Server pull request")
+	}
+
+	if len(c.Reviewers) == 0 && len(c.RemovedReviewers) == 0 {
+		return nil
+	}
+
+	// The reviewers of the pull request are replaced on update, so we have to
+	// send the existing ones along with the new ones, minus the removed ones.
+	seen := make(map[string]struct{}, len(pr.Reviewers)+len(c.Reviewers)+len(c.RemovedReviewers))
+	for _, name := range c.RemovedReviewers {
+		seen[name] = struct{}{}
+	}
+	reviewers := make([]bitbucketserver.ReviewerInput, 0, len(pr.Reviewers)+len(c.Reviewers))
+	addReviewer := func(name string) {
+		if _, ok := seen[name]; ok {
+			return
+		}
+		seen[name] = struct{}{}
+		reviewers = append(reviewers, bitbucketserver.ReviewerInput{User: bitbucketserver.User{Name: name}})
+	}
+	for _, r := range pr.Reviewers {
+		if r.User != nil {
+			addReviewer(r.User.Name)
+		}
+	}
+	for _, name := range c.Reviewers {
+		addReviewer(name)
+	}
+
+	update := &bitbucketserver.UpdatePullRequestInput{
+		PullRequestID: strconv.Itoa(pr.ID),
+		Title:         pr.Title,
+		Description:   pr.Description,
+		Version:       pr.Version,
+		Reviewers:     reviewers,
+	}
+	update.ToRef.ID = pr.ToRef.ID
+	update.ToRef.Repository.Slug = pr.ToRef.Repository.Slug
+	update.ToRef.Repository.Project.Key = pr.ToRef.Repository.Project.Key
+
+	updated, err := s.updatePullRequest(ctx, pr, update)
+	if err != nil {
+		return err
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
+// updatePullRequest updates the pull request, retrying once with the newest
+// version of the pull request if the given version is outdated.
+func (s BitbucketServerSource) updatePullRequest(ctx context.Context, pr *bitbucketserver.PullRequest, update *bitbucketserver.UpdatePullRequestInput) (*bitbucketserver.PullRequest, error) {
 	updated, err := s.client.UpdatePullRequest(ctx, update)
 	if err != nil {
 		if !bitbucketserver.IsPullRequestOutOfDate(err) {
-			return err
+			return nil, err
 		}
 
 		// If we have an outdated version of the pull request we extract the
 		// pull request that was returned with the error...
 		newestPR, err2 := bitbucketserver.ExtractPullRequest(err)
 		if err2 != nil {
-			return errors.Wrap(err, "failed to extract pull request after receiving error")
+			return nil, errors.Wrap(err, "failed to extract pull request after receiving error")
 		}
 
 		log15.Info("Updating Bitbucket Server PR failed because it's outdated. Retrying with newer version", "ID", pr.ID, "oldVersion", pr.Version, "newestVerssion", newestPR.Version)
@@ -228,11 +295,11 @@ func (s BitbucketServerSource) UpdateChangeset(ctx context.Context, c *Changeset
 		updated, err = s.client.UpdatePullRequest(ctx, update)
 		if err != nil {
 			// If that didn't work, we bail out
-			return err
+			return nil, err
 		}
 	}
 
-	return c.Changeset.SetMetadata(updated)
+	return updated, nil
 }
 
 // ReopenChangeset reopens the *Changeset on the code host and updates the
@@ -10,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // ChangesetNotFoundError is returned by LoadChangeset if the changeset
@@ -39,6 +40,57 @@ type DraftChangesetSource interface {
 	UndraftChangeset(context.Context, *Changeset) error
 }
 
+// A MetadataChangesetSource can add and remove reviewers, labels and
+// assignees of changesets and toggle their auto-merge setting.
+type MetadataChangesetSource interface {
+	ChangesetSource
+
+	// UpdateChangesetMetadata adds the reviewers, labels and assignees set on
+	// the Changeset to the changeset on the code host and removes the ones in
+	// the Removed* fields. Metadata the code host doesn't support is ignored.
+	// Metadata that was added on the code host by other means is left
+	// untouched.
+	UpdateChangesetMetadata(context.Context, *Changeset) error
+}
+
+// A RetryChecksChangesetSource can re-run the failed CI checks of changesets.
+type RetryChecksChangesetSource interface {
+	ChangesetSource
+
+	// RetryFailedChecks re-runs the failed checks in Changeset.ExternalChecks
+	// on the code host. Checks the code host can't re-run are ignored.
+	RetryFailedChecks(context.Context, *Changeset) error
+}
+
+// A SearchableChangesetSource can search the code host for open or closed
+// changesets matching a query.
+type SearchableChangesetSource interface {
+	ChangesetSource
+
+	// SearchChangesets returns the changesets matching the given code host
+	// specific search query. Only the first MaxSearchedChangesets results are
+	// returned, in which case truncated is true. If the code host can't run
+	// the query, an error wrapping ErrUnsupportedSearchQuery is returned.
+	SearchChangesets(ctx context.Context, query string) (results []*SearchedChangeset, truncated bool, err error)
+}
+
+// MaxSearchedChangesets is the maximum number of changesets a
+// SearchableChangesetSource returns for a single query.
+const MaxSearchedChangesets = 1000
+
+// ErrUnsupportedSearchQuery is returned by SearchChangesets if the query uses
+// syntax the code host doesn't support.
+var ErrUnsupportedSearchQuery = errors.New("search query is not supported by the code host")
+
+// SearchedChangeset is a changeset returned by SearchChangesets.
+type SearchedChangeset struct {
+	// RepoExternalID is the external ID of the repository the changeset was
+	// opened in, as stored in api.ExternalRepoSpec.ID.
+	RepoExternalID string
+	// ExternalID is the external ID of the changeset.
+	ExternalID string
+}
+
 type ForkableChangesetSource interface {
 	ChangesetSource
 
@@ -119,6 +171,20 @@ type Changeset struct {
 	// opened.
 	TargetRepo *types.Repo
 
+	// Reviewers, Labels and Assignees are added to the changeset by
+	// MetadataChangesetSource implementations.
+	Reviewers []string
+	Labels    []string
+	Assignees []string
+	// RemovedReviewers, RemovedLabels and RemovedAssignees were set by a
+	// previous changeset spec and are removed from the changeset by
+	// MetadataChangesetSource implementations.
+	RemovedReviewers []string
+	RemovedLabels    []string
+	RemovedAssignees []string
+	// AutoMerge, if not nil, enables or disables auto-merge on the changeset.
+	AutoMerge *bool
+
 	*btypes.Changeset
 }
 
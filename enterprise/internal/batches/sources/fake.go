@@ -50,6 +50,12 @@ type FakeChangesetSource struct {
 	AuthenticatedUsernameCalled bool
 	ValidateAuthenticatorCalled bool
 	MergeChangesetCalled        bool
+	UpdateMetadataCalled        bool
+	RetryFailedChecksCalled     bool
+	SearchChangesetsCalled      bool
+
+	// The body of the last comment created with CreateComment.
+	CommentBody string
 
 	// The Changeset.HeadRef to be expected in CreateChangeset/UpdateChangeset calls.
 	WantHeadRef string
@@ -90,12 +96,30 @@ type FakeChangesetSource struct {
 	// UndraftedChangesets contains the changesets that were passed to UndraftChangeset
 	UndraftedChangesets []*Changeset
 
+	// MetadataUpdatedChangesets contains the changesets that were passed to
+	// UpdateChangesetMetadata
+	MetadataUpdatedChangesets []*Changeset
+
+	// RetriedChangesets contains the changesets that were passed to
+	// RetryFailedChecks
+	RetriedChangesets []*Changeset
+
+	// SearchedChangesets is returned by SearchChangesets.
+	SearchedChangesets []*SearchedChangeset
+	// SearchTruncated is returned by SearchChangesets.
+	SearchTruncated bool
+	// SearchQueries contains the queries that were passed to SearchChangesets
+	SearchQueries []string
+
 	// Username is the username returned by AuthenticatedUsername
 	Username string
 }
 
 var _ ChangesetSource = &FakeChangesetSource{}
 var _ DraftChangesetSource = &FakeChangesetSource{}
+var _ MetadataChangesetSource = &FakeChangesetSource{}
+var _ RetryChecksChangesetSource = &FakeChangesetSource{}
+var _ SearchableChangesetSource = &FakeChangesetSource{}
 
 func (s *FakeChangesetSource) CreateDraftChangeset(ctx context.Context, c *Changeset) (bool, error) {
 	s.CreateDraftChangesetCalled = true
@@ -140,6 +164,50 @@ func (s *FakeChangesetSource) UndraftChangeset(ctx context.Context, c *Changeset
 	return c.SetMetadata(s.FakeMetadata)
 }
 
+func (s *FakeChangesetSource) UpdateChangesetMetadata(ctx context.Context, c *Changeset) error {
+	s.UpdateMetadataCalled = true
+
+	if s.Err != nil {
+		return s.Err
+	}
+
+	if c.TargetRepo == nil {
+		return NoReposErr
+	}
+
+	s.MetadataUpdatedChangesets = append(s.MetadataUpdatedChangesets, c)
+
+	return c.SetMetadata(s.FakeMetadata)
+}
+
+func (s *FakeChangesetSource) RetryFailedChecks(ctx context.Context, c *Changeset) error {
+	s.RetryFailedChecksCalled = true
+
+	if s.Err != nil {
+		return s.Err
+	}
+
+	if c.TargetRepo == nil {
+		return NoReposErr
+	}
+
+	s.RetriedChangesets = append(s.RetriedChangesets, c)
+
+	return c.SetMetadata(s.FakeMetadata)
+}
+
+func (s *FakeChangesetSource) SearchChangesets(ctx context.Context, query string) ([]*SearchedChangeset, bool, error) {
+	s.SearchChangesetsCalled = true
+
+	if s.Err != nil {
+		return nil, false, s.Err
+	}
+
+	s.SearchQueries = append(s.SearchQueries, query)
+
+	return s.SearchedChangesets, s.SearchTruncated, nil
+}
+
 func (s *FakeChangesetSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
 	s.CreateChangesetCalled = true
 
@@ -253,6 +321,7 @@ func (s *FakeChangesetSource) ReopenChangeset(ctx context.Context, c *Changeset)
 
 func (s *FakeChangesetSource) CreateComment(ctx context.Context, c *Changeset, body string) error {
 	s.CreateCommentCalled = true
+	s.CommentBody = body
 	return s.Err
 }
 
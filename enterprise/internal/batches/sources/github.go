@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -25,6 +26,9 @@ type GithubSource struct {
 }
 
 var _ ForkableChangesetSource = GithubSource{}
+var _ MetadataChangesetSource = GithubSource{}
+var _ RetryChecksChangesetSource = GithubSource{}
+var _ SearchableChangesetSource = GithubSource{}
 
 func NewGithubSource(svc *types.ExternalService, cf *httpcli.Factory) (*GithubSource, error) {
 	var c schema.GitHubConnection
@@ -284,6 +288,122 @@ func (s GithubSource) MergeChangeset(ctx context.Context, c *Changeset, squash b
 	return c.Changeset.SetMetadata(pr)
 }
 
+// UpdateChangesetMetadata adds the reviewers, labels and assignees of the
+// Changeset to the pull request, removes the ones dropped from the spec and
+// toggles auto-merge. Auto-merge can't be enabled on draft pull requests, so it
+// is left untouched until the pull request is undrafted.
+func (s GithubSource) UpdateChangesetMetadata(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	repo := c.TargetRepo.Metadata.(*github.Repository)
+	owner, name, err := github.SplitRepositoryNameWithOwner(repo.NameWithOwner)
+	if err != nil {
+		return errors.Wrap(err, "getting repo owner and name")
+	}
+
+	if err := s.client.UpdatePullRequestMetadata(ctx, pr, &github.UpdatePullRequestMetadataInput{
+		Owner:            owner,
+		Name:             name,
+		Reviewers:        c.Reviewers,
+		Labels:           c.Labels,
+		Assignees:        c.Assignees,
+		RemovedReviewers: c.RemovedReviewers,
+		RemovedLabels:    c.RemovedLabels,
+		RemovedAssignees: c.RemovedAssignees,
+	}); err != nil {
+		return errors.Wrap(err, "updating pull request metadata")
+	}
+
+	if c.AutoMerge != nil && !pr.IsDraft {
+		if *c.AutoMerge {
+			err = s.client.EnablePullRequestAutoMerge(ctx, pr)
+		} else {
+			err = s.client.DisablePullRequestAutoMerge(ctx, pr)
+		}
+		if err != nil {
+			return errors.Wrap(err, "updating pull request auto-merge")
+		}
+	}
+
+	// The mutations above don't return the full pull request, so we reload it
+	// to pick up the new labels, reviewers and timeline events.
+	return s.LoadChangeset(ctx, c)
+}
+
+// RetryFailedChecks re-requests the check suites of the failed check runs of
+// the Changeset. Commit statuses can't be re-run through the GitHub API and
+// are ignored.
+func (s GithubSource) RetryFailedChecks(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*github.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitHub pull request")
+	}
+
+	for _, suiteID := range c.Changeset.FailedCheckRetryIDs() {
+		if err := s.client.RerequestCheckSuite(ctx, pr, suiteID); err != nil {
+			return errors.Wrapf(err, "re-requesting check suite %s", suiteID)
+		}
+	}
+
+	return s.LoadChangeset(ctx, c)
+}
+
+// SearchChangesets searches for pull requests matching the given GitHub search
+// query. "is:pr" is added to the query if it doesn't restrict the search to
+// pull requests already.
+func (s GithubSource) SearchChangesets(ctx context.Context, query string) ([]*SearchedChangeset, bool, error) {
+	if !strings.Contains(query, "is:pr") && !strings.Contains(query, "type:pr") {
+		query = strings.TrimSpace(query + " is:pr")
+	}
+
+	var (
+		results   []*SearchedChangeset
+		truncated bool
+		cursor    github.Cursor
+		total     int
+	)
+	for {
+		res, err := s.client.SearchPullRequests(ctx, github.SearchPullRequestsParams{
+			Query: query,
+			After: cursor,
+		})
+		if err != nil {
+			return nil, false, errors.Wrap(err, "searching pull requests")
+		}
+		total = res.TotalCount
+
+		for _, pr := range res.PullRequests {
+			results = append(results, &SearchedChangeset{
+				RepoExternalID: pr.Repository.ID,
+				ExternalID:     strconv.FormatInt(pr.Number, 10),
+			})
+		}
+
+		if res.EndCursor == "" {
+			break
+		}
+		if len(results) >= MaxSearchedChangesets {
+			truncated = true
+			break
+		}
+		cursor = res.EndCursor
+	}
+
+	// GitHub only returns the first 1000 results of a search and reports no
+	// further pages after that, even if more pull requests match.
+	if total > len(results) {
+		truncated = true
+	}
+	if len(results) > MaxSearchedChangesets {
+		results = results[:MaxSearchedChangesets]
+		truncated = true
+	}
+	return results, truncated, nil
+}
+
 // GetNamespaceFork returns a repo pointing to a fork of the given repo in
 // the given namespace, ensuring that the fork exists and is a fork of the
 // target repo.
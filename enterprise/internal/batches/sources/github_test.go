@@ -3,6 +3,9 @@ package sources
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
@@ -14,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/rcache"
 	"github.com/sourcegraph/sourcegraph/internal/testutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -534,6 +538,58 @@ func TestGithubSource_WithAuthenticator(t *testing.T) {
 	})
 }
 
+func TestGithubSource_SearchChangesets(t *testing.T) {
+	// newSource returns a source whose searches return pages of 100 pull
+	// requests, with the given total count and number of pages.
+	newSource := func(issueCount, pages int) GithubSource {
+		page := 0
+		doer := httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+			page++
+			nodes := make([]string, 0, 100)
+			for i := 0; i < 100; i++ {
+				nodes = append(nodes, fmt.Sprintf(`{"number": %d, "repository": {"id": "repo"}}`, page*100+i))
+			}
+			body := fmt.Sprintf(`{"data": {"search": {"issueCount": %d, "pageInfo": {"hasNextPage": %t, "endCursor": "page-%d"}, "nodes": [%s]}}}`,
+				issueCount, page < pages, page, strings.Join(nodes, ","))
+			return &http.Response{
+				Request:    r,
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		})
+		apiURL := &url.URL{Scheme: "https", Host: "api.github.com", Path: "/"}
+		return GithubSource{client: github.NewV4Client("Test", apiURL, nil, doer)}
+	}
+
+	t.Run("complete", func(t *testing.T) {
+		have, truncated, err := newSource(200, 2).SearchChangesets(context.Background(), "author:renovate")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if truncated {
+			t.Fatal("unexpected truncated results")
+		}
+		if len(have) != 200 {
+			t.Fatalf("unexpected number of results: have=%d want=%d", len(have), 200)
+		}
+	})
+
+	t.Run("capped by GitHub", func(t *testing.T) {
+		// GitHub stops returning pages after 1000 results, even though more
+		// pull requests match.
+		have, truncated, err := newSource(2500, 10).SearchChangesets(context.Background(), "author:renovate")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !truncated {
+			t.Fatal("results not truncated")
+		}
+		if len(have) != 1000 {
+			t.Fatalf("unexpected number of results: have=%d want=%d", len(have), 1000)
+		}
+	})
+}
+
 func TestGithubSource_GetUserFork(t *testing.T) {
 	ctx := context.Background()
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
@@ -26,6 +27,9 @@ type GitLabSource struct {
 var _ ChangesetSource = &GitLabSource{}
 var _ DraftChangesetSource = &GitLabSource{}
 var _ ForkableChangesetSource = &GitLabSource{}
+var _ MetadataChangesetSource = &GitLabSource{}
+var _ RetryChecksChangesetSource = &GitLabSource{}
+var _ SearchableChangesetSource = &GitLabSource{}
 
 // NewGitLabSource returns a new GitLabSource from the given external service.
 func NewGitLabSource(svc *types.ExternalService, cf *httpcli.Factory) (*GitLabSource, error) {
@@ -441,6 +445,109 @@ func (s *GitLabSource) UpdateChangeset(ctx context.Context, c *Changeset) error
 	return c.Changeset.SetMetadata(updated)
 }
 
+// UpdateChangesetMetadata adds the reviewers, labels and assignees of the
+// Changeset to the merge request and toggles merging it once its pipeline
+// succeeds.
+func (s *GitLabSource) UpdateChangesetMetadata(ctx context.Context, c *Changeset) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+	project := c.TargetRepo.Metadata.(*gitlab.Project)
+
+	// GitLab replaces the reviewers and assignees of a merge request, so we
+	// have to send the existing ones along with the new ones.
+	reviewerIDs, err := s.mergeUserIDs(ctx, mr.Reviewers, c.Reviewers, c.RemovedReviewers)
+	if err != nil {
+		return errors.Wrap(err, "resolving reviewers")
+	}
+	assigneeIDs, err := s.mergeUserIDs(ctx, mr.Assignees, c.Assignees, c.RemovedAssignees)
+	if err != nil {
+		return errors.Wrap(err, "resolving assignees")
+	}
+
+	updated := mr
+	if reviewerIDs != nil || assigneeIDs != nil || len(c.Labels) > 0 || len(c.RemovedLabels) > 0 {
+		updated, err = s.client.UpdateMergeRequest(ctx, project, mr, gitlab.UpdateMergeRequestOpts{
+			Title:        mr.Title,
+			TargetBranch: mr.TargetBranch,
+			AddLabels:    strings.Join(c.Labels, ","),
+			RemoveLabels: strings.Join(c.RemovedLabels, ","),
+			ReviewerIDs:  reviewerIDs,
+			AssigneeIDs:  assigneeIDs,
+		})
+		if err != nil {
+			return errors.Wrap(err, "updating GitLab merge request")
+		}
+	}
+
+	if c.AutoMerge != nil && *c.AutoMerge != updated.MergeWhenPipelineSucceeds {
+		if !*c.AutoMerge {
+			updated, err = s.client.CancelMergeRequestAutoMerge(ctx, project, updated)
+		} else if !updated.WorkInProgress && updated.HeadPipeline != nil {
+			// GitLab merges a merge request right away when it is set to merge
+			// once a pipeline succeeds but has no pipeline, so we only enable
+			// auto-merge on ready merge requests that have one.
+			updated, err = s.client.EnableMergeRequestAutoMerge(ctx, project, updated)
+		}
+		if err != nil {
+			return errors.Wrap(err, "updating GitLab merge request auto-merge")
+		}
+	}
+
+	// These additional API calls can go away once we can use the GraphQL API.
+	if err := s.decorateMergeRequestData(ctx, project, updated); err != nil {
+		return errors.Wrapf(err, "retrieving additional data for merge request %d", mr.IID)
+	}
+
+	return c.Changeset.SetMetadata(updated)
+}
+
+// mergeUserIDs returns the IDs of the existing users that aren't removed,
+// followed by the IDs of the users with the given usernames that aren't
+// already included. It returns nil if no users are added or removed, and a
+// single ID of 0 if all users are removed.
+func (s *GitLabSource) mergeUserIDs(ctx context.Context, existing []gitlab.User, usernames, removed []string) ([]int32, error) {
+	if len(usernames) == 0 && len(removed) == 0 {
+		return nil, nil
+	}
+
+	removedUsernames := make(map[string]struct{}, len(removed))
+	for _, username := range removed {
+		removedUsernames[username] = struct{}{}
+	}
+
+	ids := make([]int32, 0, len(existing)+len(usernames))
+	seen := make(map[int32]struct{}, len(existing)+len(usernames))
+	for _, u := range existing {
+		if _, ok := removedUsernames[u.Username]; ok {
+			continue
+		}
+		ids = append(ids, u.ID)
+		seen[u.ID] = struct{}{}
+	}
+
+	for _, username := range usernames {
+		users, _, err := s.client.ListUsers(ctx, "users?username="+url.QueryEscape(username))
+		if err != nil {
+			return nil, err
+		}
+		if len(users) == 0 {
+			return nil, errors.Errorf("user %q not found", username)
+		}
+		if _, ok := seen[users[0].ID]; ok {
+			continue
+		}
+		ids = append(ids, users[0].ID)
+		seen[users[0].ID] = struct{}{}
+	}
+
+	if len(ids) == 0 {
+		return []int32{0}, nil
+	}
+	return ids, nil
+}
+
 // UndraftChangeset marks the changeset as *not* work in progress anymore.
 func (s *GitLabSource) UndraftChangeset(ctx context.Context, c *Changeset) error {
 	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
@@ -493,6 +600,118 @@ func (s *GitLabSource) MergeChangeset(ctx context.Context, c *Changeset, squash
 	return c.Changeset.SetMetadata(updated)
 }
 
+// RetryFailedChecks retries the failed jobs of the failed pipelines of the
+// Changeset.
+func (s *GitLabSource) RetryFailedChecks(ctx context.Context, c *Changeset) error {
+	mr, ok := c.Changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return errors.New("Changeset is not a GitLab merge request")
+	}
+	project := c.TargetRepo.Metadata.(*gitlab.Project)
+
+	for _, retryID := range c.Changeset.FailedCheckRetryIDs() {
+		id, err := strconv.ParseInt(retryID, 10, 64)
+		if err != nil {
+			return errors.Wrapf(err, "parsing pipeline ID %q", retryID)
+		}
+		if _, err := s.client.RetryPipeline(ctx, project, gitlab.ID(id)); err != nil {
+			return errors.Wrapf(err, "retrying pipeline %d", id)
+		}
+	}
+
+	// These additional API calls can go away once we can use the GraphQL API.
+	if err := s.decorateMergeRequestData(ctx, project, mr); err != nil {
+		return errors.Wrapf(err, "retrieving additional data for merge request %d", mr.IID)
+	}
+
+	return c.Changeset.SetMetadata(mr)
+}
+
+// SearchChangesets searches for merge requests matching the given query. GitLab
+// has no search syntax for merge requests, so only the qualifiers supported by
+// parseGitLabMergeRequestQuery are understood.
+func (s *GitLabSource) SearchChangesets(ctx context.Context, query string) ([]*SearchedChangeset, bool, error) {
+	opts, err := parseGitLabMergeRequestQuery(query)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var (
+		results   []*SearchedChangeset
+		truncated bool
+	)
+	next := gitlab.SearchMergeRequestsURL(opts)
+	for next != "" {
+		mrs, nextPageURL, err := s.client.ListMergeRequests(ctx, next)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "searching merge requests")
+		}
+
+		for _, mr := range mrs {
+			results = append(results, &SearchedChangeset{
+				RepoExternalID: strconv.FormatInt(int64(mr.ProjectID), 10),
+				ExternalID:     strconv.FormatInt(int64(mr.IID), 10),
+			})
+		}
+
+		next = ""
+		if nextPageURL != nil {
+			next = *nextPageURL
+		}
+		if next != "" && len(results) >= MaxSearchedChangesets {
+			truncated = true
+			break
+		}
+	}
+
+	if len(results) > MaxSearchedChangesets {
+		results = results[:MaxSearchedChangesets]
+		truncated = true
+	}
+	return results, truncated, nil
+}
+
+// parseGitLabMergeRequestQuery translates a GitHub-style search query into the
+// filters of the GitLab merge requests API. The supported qualifiers are
+// author:, assignee:, label: and is:open, is:closed and is:merged. All other
+// terms are searched for in the title and description. is:pr and is:mr are
+// ignored, since only merge requests are searched anyway.
+func parseGitLabMergeRequestQuery(query string) (gitlab.SearchMergeRequestsOpts, error) {
+	opts := gitlab.SearchMergeRequestsOpts{State: "all"}
+	var terms []string
+	for _, field := range strings.Fields(query) {
+		key, value, ok := strings.Cut(field, ":")
+		if !ok {
+			terms = append(terms, field)
+			continue
+		}
+		value = strings.Trim(value, `"`)
+
+		switch key {
+		case "author":
+			opts.AuthorUsername = value
+		case "assignee":
+			opts.AssigneeUsername = value
+		case "label":
+			opts.Labels = append(opts.Labels, value)
+		case "is", "state":
+			switch value {
+			case "pr", "mr":
+			case "open":
+				opts.State = "opened"
+			case "closed", "merged":
+				opts.State = value
+			default:
+				return opts, errors.Wrapf(ErrUnsupportedSearchQuery, "unsupported qualifier for GitLab merge requests: %s", field)
+			}
+		default:
+			return opts, errors.Wrapf(ErrUnsupportedSearchQuery, "unsupported qualifier for GitLab merge requests: %s", field)
+		}
+	}
+	opts.Search = strings.Join(terms, " ")
+	return opts, nil
+}
+
 func (s *GitLabSource) GetNamespaceFork(ctx context.Context, targetRepo *types.Repo, namespace string) (*types.Repo, error) {
 	return s.getFork(ctx, targetRepo, &namespace)
 }
@@ -676,6 +676,85 @@ func TestGitLabSource_ChangesetSource(t *testing.T) {
 		}
 	})
 
+	t.Run("UpdateChangesetMetadata", func(t *testing.T) {
+		oldListUsers := gitlab.MockListUsers
+		oldUpdateMergeRequest := gitlab.MockUpdateMergeRequest
+		oldEnableAutoMerge := gitlab.MockEnableMergeRequestAutoMerge
+		t.Cleanup(func() {
+			gitlab.MockListUsers = oldListUsers
+			gitlab.MockUpdateMergeRequest = oldUpdateMergeRequest
+			gitlab.MockEnableMergeRequestAutoMerge = oldEnableAutoMerge
+		})
+
+		gitlab.MockListUsers = func(c *gitlab.Client, ctx context.Context, urlStr string) ([]*gitlab.User, *string, error) {
+			if want := "users?username=carol"; urlStr != want {
+				t.Errorf("unexpected URL: have %q; want %q", urlStr, want)
+			}
+			return []*gitlab.User{{ID: 3, Username: "carol"}}, nil, nil
+		}
+
+		for name, tc := range map[string]struct {
+			pipeline      *gitlab.Pipeline
+			draft         bool
+			wantAutoMerge bool
+		}{
+			"without pipeline": {},
+			"draft":            {pipeline: &gitlab.Pipeline{ID: 1}, draft: true},
+			"with pipeline":    {pipeline: &gitlab.Pipeline{ID: 1}, wantAutoMerge: true},
+		} {
+			t.Run(name, func(t *testing.T) {
+				in := &gitlab.MergeRequest{
+					IID:            2,
+					Reviewers:      []gitlab.User{{ID: 1, Username: "alice"}, {ID: 2, Username: "bob"}},
+					Assignees:      []gitlab.User{{ID: 1, Username: "alice"}},
+					WorkInProgress: tc.draft,
+				}
+				out := &gitlab.MergeRequest{IID: 2, HeadPipeline: tc.pipeline, WorkInProgress: tc.draft}
+
+				p := newGitLabChangesetSourceTestProvider(t)
+				p.changeset.Changeset.Metadata = in
+				p.changeset.Reviewers = []string{"carol"}
+				p.changeset.RemovedReviewers = []string{"bob"}
+				p.changeset.Labels = []string{"new"}
+				p.changeset.RemovedLabels = []string{"old"}
+				p.changeset.RemovedAssignees = []string{"alice"}
+				autoMerge := true
+				p.changeset.AutoMerge = &autoMerge
+
+				gitlab.MockUpdateMergeRequest = func(c *gitlab.Client, ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest, opts gitlab.UpdateMergeRequestOpts) (*gitlab.MergeRequest, error) {
+					if diff := cmp.Diff([]int32{1, 3}, opts.ReviewerIDs); diff != "" {
+						t.Errorf("unexpected reviewer IDs (-want +got):\n%s", diff)
+					}
+					// Removing the only assignee unassigns everyone
+					if diff := cmp.Diff([]int32{0}, opts.AssigneeIDs); diff != "" {
+						t.Errorf("unexpected assignee IDs (-want +got):\n%s", diff)
+					}
+					if opts.AddLabels != "new" || opts.RemoveLabels != "old" {
+						t.Errorf("unexpected labels: add=%q remove=%q", opts.AddLabels, opts.RemoveLabels)
+					}
+					return out, nil
+				}
+
+				autoMergeEnabled := false
+				gitlab.MockEnableMergeRequestAutoMerge = func(c *gitlab.Client, ctx context.Context, project *gitlab.Project, mr *gitlab.MergeRequest) (*gitlab.MergeRequest, error) {
+					autoMergeEnabled = true
+					return mr, nil
+				}
+
+				p.mockGetMergeRequestNotes(in.IID, nil, 20, nil)
+				p.mockGetMergeRequestResourceStateEvents(in.IID, nil, 20, nil)
+				p.mockGetMergeRequestPipelines(in.IID, nil, 20, nil)
+
+				if err := p.source.UpdateChangesetMetadata(p.ctx, p.changeset); err != nil {
+					t.Errorf("unexpected non-nil error: %+v", err)
+				}
+				if autoMergeEnabled != tc.wantAutoMerge {
+					t.Errorf("unexpected auto-merge: have %t; want %t", autoMergeEnabled, tc.wantAutoMerge)
+				}
+			})
+		}
+	})
+
 	t.Run("CreateComment", func(t *testing.T) {
 		commentBody := "test-comment"
 		t.Run("invalid metadata", func(t *testing.T) {
@@ -1028,6 +1107,7 @@ func (p *gitLabChangesetSourceTestProvider) unmock() {
 	gitlab.MockGetOpenMergeRequestByRefs = nil
 	gitlab.MockUpdateMergeRequest = nil
 	gitlab.MockCreateMergeRequestNote = nil
+	gitlab.MockListMergeRequests = nil
 }
 
 // panicDoer provides a httpcli.Doer implementation that panics if any attempt
@@ -1144,6 +1224,119 @@ func TestGitLabSource_WithAuthenticator(t *testing.T) {
 	})
 }
 
+func TestGitLabSource_SearchChangesets(t *testing.T) {
+	p := newGitLabChangesetSourceTestProvider(t)
+	defer p.unmock()
+
+	next := "https://gitlab.com/api/v4/merge_requests?page=2"
+	var urls []string
+	gitlab.MockListMergeRequests = func(c *gitlab.Client, ctx context.Context, urlStr string) ([]*gitlab.MergeRequest, *string, error) {
+		urls = append(urls, urlStr)
+		if urlStr == next {
+			return []*gitlab.MergeRequest{{ProjectID: 4, IID: 5}}, nil, nil
+		}
+		return []*gitlab.MergeRequest{{ProjectID: 3, IID: 2}}, &next, nil
+	}
+
+	have, truncated, err := p.source.SearchChangesets(p.ctx, "is:open author:renovate label:security")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if truncated {
+		t.Fatal("unexpected truncated results")
+	}
+	want := []*SearchedChangeset{
+		{RepoExternalID: "3", ExternalID: "2"},
+		{RepoExternalID: "4", ExternalID: "5"},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+
+	wantURLs := []string{
+		"merge_requests?author_username=renovate&labels=security&per_page=100&scope=all&state=opened&view=simple",
+		next,
+	}
+	if diff := cmp.Diff(wantURLs, urls); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestGitLabSource_SearchChangesets_Truncated(t *testing.T) {
+	p := newGitLabChangesetSourceTestProvider(t)
+	defer p.unmock()
+
+	next := "https://gitlab.com/api/v4/merge_requests?page=next"
+	gitlab.MockListMergeRequests = func(c *gitlab.Client, ctx context.Context, urlStr string) ([]*gitlab.MergeRequest, *string, error) {
+		mrs := make([]*gitlab.MergeRequest, 600)
+		for i := range mrs {
+			mrs[i] = &gitlab.MergeRequest{ProjectID: 1, IID: gitlab.ID(i)}
+		}
+		return mrs, &next, nil
+	}
+
+	have, truncated, err := p.source.SearchChangesets(p.ctx, "author:renovate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !truncated {
+		t.Fatal("results not truncated")
+	}
+	if len(have) != MaxSearchedChangesets {
+		t.Fatalf("unexpected number of results: have=%d want=%d", len(have), MaxSearchedChangesets)
+	}
+}
+
+func TestParseGitLabMergeRequestQuery(t *testing.T) {
+	for name, tc := range map[string]struct {
+		query   string
+		want    gitlab.SearchMergeRequestsOpts
+		wantErr bool
+	}{
+		"empty": {
+			query: "",
+			want:  gitlab.SearchMergeRequestsOpts{State: "all"},
+		},
+		"qualifiers": {
+			query: `is:pr is:merged author:renovate assignee:alice label:security label:"deps"`,
+			want: gitlab.SearchMergeRequestsOpts{
+				State:            "merged",
+				AuthorUsername:   "renovate",
+				AssigneeUsername: "alice",
+				Labels:           []string{"security", "deps"},
+			},
+		},
+		"free text": {
+			query: "is:open bump lodash",
+			want:  gitlab.SearchMergeRequestsOpts{State: "opened", Search: "bump lodash"},
+		},
+		"unsupported qualifier": {
+			query:   "org:sourcegraph",
+			wantErr: true,
+		},
+		"unsupported state": {
+			query:   "is:draft",
+			wantErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			have, err := parseGitLabMergeRequestQuery(tc.query)
+			if tc.wantErr {
+				if !errors.Is(err, ErrUnsupportedSearchQuery) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
 func TestDecorateMergeRequestData(t *testing.T) {
 	ctx := context.Background()
 
@@ -190,6 +190,36 @@ func ToDraftChangesetSource(css ChangesetSource) (DraftChangesetSource, error) {
 	return draftCss, nil
 }
 
+// ToMetadataChangesetSource returns a MetadataChangesetSource, if the
+// underlying source supports it. Returns an error if not.
+func ToMetadataChangesetSource(css ChangesetSource) (MetadataChangesetSource, error) {
+	metadataCss, ok := css.(MetadataChangesetSource)
+	if !ok {
+		return nil, errors.New("changeset source doesn't implement MetadataChangesetSource")
+	}
+	return metadataCss, nil
+}
+
+// ToRetryChecksChangesetSource returns a RetryChecksChangesetSource, if the
+// underlying source supports it. Returns an error if not.
+func ToRetryChecksChangesetSource(css ChangesetSource) (RetryChecksChangesetSource, error) {
+	retryCss, ok := css.(RetryChecksChangesetSource)
+	if !ok {
+		return nil, errors.New("changeset source doesn't implement RetryChecksChangesetSource")
+	}
+	return retryCss, nil
+}
+
+// ToSearchableChangesetSource returns a SearchableChangesetSource, if the
+// underlying source supports it. Returns an error if not.
+func ToSearchableChangesetSource(css ChangesetSource) (SearchableChangesetSource, error) {
+	searchCss, ok := css.(SearchableChangesetSource)
+	if !ok {
+		return nil, errors.New("changeset source doesn't implement SearchableChangesetSource")
+	}
+	return searchCss, nil
+}
+
 // WithAuthenticatorForChangeset authenticates the given ChangesetSource with a
 // credential appropriate to sync or reconcile the given changeset. If the
 // changeset was created by a batch change, then authentication will be based on
@@ -329,6 +359,10 @@ func loadExternalService(ctx context.Context, s database.ExternalServiceStore, o
 			if cfg.AppPassword != "" {
 				return e, nil
 			}
+		case *schema.AWSCodeCommitConnection:
+			if cfg.AccessKeyID != "" && cfg.SecretAccessKey != "" {
+				return e, nil
+			}
 		}
 	}
 
@@ -348,6 +382,8 @@ func buildChangesetSource(cf *httpcli.Factory, externalService *types.ExternalSe
 		return NewBitbucketServerSource(externalService, cf)
 	case extsvc.KindBitbucketCloud:
 		return NewBitbucketCloudSource(externalService, cf)
+	case extsvc.KindAWSCodeCommit:
+		return NewAWSCodeCommitSource(externalService, cf)
 	default:
 		return nil, errors.Errorf("unsupported external service type %q", extsvc.KindToType(externalService.Kind))
 	}
@@ -400,6 +436,9 @@ func setOAuthTokenAuth(u *vcs.URL, extSvcType, token string) error {
 	case extsvc.TypeBitbucketServer:
 		return errors.New("require username/token to push commits to BitbucketServer")
 
+	case extsvc.TypeAWSCodeCommit:
+		return errors.New("require Git credentials to push commits to AWS CodeCommit")
+
 	default:
 		panic(fmt.Sprintf("setOAuthTokenAuth: invalid external service type %q", extSvcType))
 	}
@@ -413,7 +452,7 @@ func setBasicAuth(u *vcs.URL, extSvcType, username, password string) error {
 	case extsvc.TypeGitHub, extsvc.TypeGitLab:
 		return errors.New("need token to push commits to " + extSvcType)
 
-	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud:
+	case extsvc.TypeBitbucketServer, extsvc.TypeBitbucketCloud, extsvc.TypeAWSCodeCommit:
 		u.User = url.UserPassword(username, password)
 
 	default:
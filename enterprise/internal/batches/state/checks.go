@@ -0,0 +1,253 @@
+package state
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+)
+
+// computeChecks computes the individual checks of the changeset based on the
+// checks of the last sync and any webhook events that have arrived after the
+// most recent sync. These are the checks that computeCheckState combines into
+// the overall check state.
+func computeChecks(c *btypes.Changeset, events ChangesetEvents) []btypes.ChangesetCheck {
+	var checks []btypes.ChangesetCheck
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		checks = computeGitHubChecks(c.UpdatedAt, m, events)
+
+	case *bitbucketserver.PullRequest:
+		checks = computeBitbucketServerChecks(c.UpdatedAt, m, events)
+
+	case *gitlab.MergeRequest:
+		checks = computeGitLabChecks(c.UpdatedAt, m, events)
+
+	case *bbcs.AnnotatedPullRequest:
+		checks = computeBitbucketCloudChecks(c.UpdatedAt, m, events)
+	}
+
+	sort.Slice(checks, func(i, j int) bool {
+		if checks[i].Name != checks[j].Name {
+			return checks[i].Name < checks[j].Name
+		}
+		return checks[i].URL < checks[j].URL
+	})
+	return checks
+}
+
+func computeGitHubChecks(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent) []btypes.ChangesetCheck {
+	// As in computeGitHubCheckState, we only consider the latest commit.
+	var latestCommitTime time.Time
+	var latestOID string
+	checksPerContext := make(map[string]btypes.ChangesetCheck)
+	checksPerCheckRun := make(map[string]btypes.ChangesetCheck)
+
+	if len(pr.Commits.Nodes) > 0 {
+		commit := pr.Commits.Nodes[0]
+		latestCommitTime = commit.Commit.CommittedDate
+		latestOID = commit.Commit.OID
+		for _, c := range commit.Commit.Status.Contexts {
+			checksPerContext[c.Context] = btypes.ChangesetCheck{
+				Name:       c.Context,
+				URL:        c.TargetURL,
+				State:      parseGithubCheckState(c.State),
+				Conclusion: c.State,
+				StartedAt:  c.CreatedAt,
+			}
+		}
+		for _, s := range commit.Commit.CheckSuites.Nodes {
+			for _, r := range s.CheckRuns.Nodes {
+				checksPerCheckRun[r.ID] = githubCheckRunCheck(r, s.ID)
+			}
+		}
+	}
+
+	var statuses []*github.CommitStatus
+	for _, e := range events {
+		switch m := e.Metadata.(type) {
+		case *github.CommitStatus:
+			if m.ReceivedAt.After(lastSynced) {
+				statuses = append(statuses, m)
+			}
+		case *github.PullRequestCommit:
+			if m.Commit.CommittedDate.After(latestCommitTime) {
+				latestCommitTime = m.Commit.CommittedDate
+				latestOID = m.Commit.OID
+				// The contexts are now out of date, reset them.
+				for k := range checksPerContext {
+					delete(checksPerContext, k)
+				}
+			}
+		case *github.CheckRun:
+			if m.ReceivedAt.After(lastSynced) {
+				suiteID := m.CheckSuiteID
+				if prev, ok := checksPerCheckRun[m.ID]; ok && suiteID == "" {
+					suiteID = prev.RetryID
+				}
+				checksPerCheckRun[m.ID] = githubCheckRunCheck(*m, suiteID)
+			}
+		}
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ReceivedAt.Before(statuses[j].ReceivedAt)
+	})
+	for _, s := range statuses {
+		if s.SHA != latestOID {
+			continue
+		}
+		check := btypes.ChangesetCheck{
+			Name:       s.Context,
+			URL:        s.TargetURL,
+			State:      parseGithubCheckState(s.State),
+			Conclusion: s.State,
+			StartedAt:  s.ReceivedAt,
+		}
+		// Statuses don't have a duration, but if we saw the context go from
+		// pending to a final state we can infer it.
+		if prev, ok := checksPerContext[s.Context]; ok && prev.State == btypes.ChangesetCheckStatePending {
+			check.StartedAt = prev.StartedAt
+			if check.State != btypes.ChangesetCheckStatePending {
+				check.CompletedAt = s.ReceivedAt
+			}
+		}
+		checksPerContext[s.Context] = check
+	}
+
+	checks := make([]btypes.ChangesetCheck, 0, len(checksPerContext)+len(checksPerCheckRun))
+	for _, c := range checksPerContext {
+		checks = append(checks, c)
+	}
+	for _, c := range checksPerCheckRun {
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+func githubCheckRunCheck(r github.CheckRun, suiteID string) btypes.ChangesetCheck {
+	return btypes.ChangesetCheck{
+		Name:        r.Name,
+		URL:         r.DetailsURL,
+		State:       parseGithubCheckSuiteState(r.Status, r.Conclusion),
+		Conclusion:  r.Conclusion,
+		StartedAt:   r.StartedAt,
+		CompletedAt: r.CompletedAt,
+		// Check runs are retried by re-requesting their check suite.
+		RetryID: suiteID,
+	}
+}
+
+func computeGitLabChecks(lastSynced time.Time, mr *gitlab.MergeRequest, events []*btypes.ChangesetEvent) []btypes.ChangesetCheck {
+	// The pipeline is the only check we know of on GitLab, see
+	// computeGitLabCheckState.
+	pipeline := latestGitLabPipeline(lastSynced, mr, events)
+	if pipeline == nil {
+		return nil
+	}
+
+	check := btypes.ChangesetCheck{
+		Name:       "Pipeline",
+		URL:        pipeline.WebURL,
+		State:      parseGitLabPipelineStatus(pipeline.Status),
+		Conclusion: string(pipeline.Status),
+		StartedAt:  pipeline.CreatedAt.Time,
+		RetryID:    strconv.FormatInt(int64(pipeline.ID), 10),
+	}
+	if check.State == btypes.ChangesetCheckStatePassed || check.State == btypes.ChangesetCheckStateFailed {
+		check.CompletedAt = pipeline.UpdatedAt.Time
+	}
+	return []btypes.ChangesetCheck{check}
+}
+
+func computeBitbucketServerChecks(lastSynced time.Time, pr *bitbucketserver.PullRequest, events []*btypes.ChangesetEvent) []btypes.ChangesetCheck {
+	var latestCommit bitbucketserver.Commit
+	for _, c := range pr.Commits {
+		if latestCommit.CommitterTimestamp <= c.CommitterTimestamp {
+			latestCommit = *c
+		}
+	}
+
+	checksPerKey := make(map[string]btypes.ChangesetCheck)
+	for _, status := range pr.CommitStatus {
+		checksPerKey[status.Key()] = bitbucketServerBuildStatusCheck(status.Status)
+	}
+	for _, e := range events {
+		switch m := e.Metadata.(type) {
+		case *bitbucketserver.CommitStatus:
+			if m.Commit != latestCommit.ID {
+				continue
+			}
+			if unixMilliToTime(m.Status.DateAdded).Before(lastSynced) {
+				continue
+			}
+			checksPerKey[m.Key()] = bitbucketServerBuildStatusCheck(m.Status)
+		}
+	}
+
+	checks := make([]btypes.ChangesetCheck, 0, len(checksPerKey))
+	for _, c := range checksPerKey {
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+func bitbucketServerBuildStatusCheck(s bitbucketserver.BuildStatus) btypes.ChangesetCheck {
+	name := s.Name
+	if name == "" {
+		name = s.Key
+	}
+	return btypes.ChangesetCheck{
+		Name:       name,
+		URL:        s.Url,
+		State:      parseBitbucketServerBuildState(s.State),
+		Conclusion: s.State,
+	}
+}
+
+func computeBitbucketCloudChecks(lastSynced time.Time, apr *bbcs.AnnotatedPullRequest, events []*btypes.ChangesetEvent) []btypes.ChangesetCheck {
+	checksPerKey := make(map[string]btypes.ChangesetCheck)
+	for _, status := range apr.Statuses {
+		checksPerKey[status.Key()] = bitbucketCloudStatusCheck(status.Name, status.URL, status.State, status.CreatedOn, status.UpdatedOn)
+	}
+
+	addCheck := func(key string, status *bitbucketcloud.CommitStatus) {
+		if lastSynced.Before(status.CreatedOn) {
+			checksPerKey[key] = bitbucketCloudStatusCheck(status.Name, status.URL, status.State, status.CreatedOn, status.UpdatedOn)
+		}
+	}
+	for _, e := range events {
+		switch m := e.Metadata.(type) {
+		case *bitbucketcloud.RepoCommitStatusCreatedEvent:
+			addCheck(m.Key(), &m.CommitStatus)
+		case *bitbucketcloud.RepoCommitStatusUpdatedEvent:
+			addCheck(m.Key(), &m.CommitStatus)
+		}
+	}
+
+	checks := make([]btypes.ChangesetCheck, 0, len(checksPerKey))
+	for _, c := range checksPerKey {
+		checks = append(checks, c)
+	}
+	return checks
+}
+
+func bitbucketCloudStatusCheck(name, url string, s bitbucketcloud.PullRequestStatusState, createdOn, updatedOn time.Time) btypes.ChangesetCheck {
+	check := btypes.ChangesetCheck{
+		Name:       name,
+		URL:        url,
+		State:      parseBitbucketCloudBuildState(s),
+		Conclusion: string(s),
+		StartedAt:  createdOn,
+	}
+	if check.State == btypes.ChangesetCheckStatePassed || check.State == btypes.ChangesetCheckStateFailed {
+		check.CompletedAt = updatedOn
+	}
+	return check
+}
@@ -0,0 +1,132 @@
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+)
+
+func TestComputeChecks(t *testing.T) {
+	t.Parallel()
+
+	now := timeutil.Now()
+	lastSynced := now.Add(-1 * time.Minute)
+	minutes := func(m int) time.Time { return now.Add(time.Duration(m) * time.Minute) }
+
+	t.Run("GitHub", func(t *testing.T) {
+		commit := github.CommitWithChecks{}
+		commit.Commit.OID = "deadbeef"
+		commit.Commit.Status.Contexts = []github.Context{
+			{Context: "ci/lint", State: "PENDING", TargetURL: "https://ci.example.com/lint", CreatedAt: minutes(-10)},
+		}
+		commit.Commit.CheckSuites.Nodes = []github.CheckSuite{{ID: "suite-1"}}
+		commit.Commit.CheckSuites.Nodes[0].CheckRuns.Nodes = []github.CheckRun{
+			{
+				ID:          "run-1",
+				Name:        "test",
+				DetailsURL:  "https://github.com/runs/1",
+				Status:      "COMPLETED",
+				Conclusion:  "FAILURE",
+				StartedAt:   minutes(-20),
+				CompletedAt: minutes(-15),
+			},
+			{
+				ID:     "run-2",
+				Name:   "build",
+				Status: "IN_PROGRESS",
+			},
+		}
+		pr := &github.PullRequest{}
+		pr.Commits.Nodes = []github.CommitWithChecks{commit}
+
+		c := &btypes.Changeset{UpdatedAt: lastSynced, Metadata: pr}
+		events := ChangesetEvents{
+			// Status update for the pending context.
+			{Metadata: &github.CommitStatus{SHA: "deadbeef", Context: "ci/lint", State: "SUCCESS", TargetURL: "https://ci.example.com/lint", ReceivedAt: minutes(1)}},
+			// Status update for another commit is ignored.
+			{Metadata: &github.CommitStatus{SHA: "cafebabe", Context: "ci/other", State: "FAILURE", ReceivedAt: minutes(1)}},
+			// The build check run finished.
+			{Metadata: &github.CheckRun{ID: "run-2", Name: "build", Status: "COMPLETED", Conclusion: "SUCCESS", StartedAt: minutes(-5), CompletedAt: minutes(2), ReceivedAt: minutes(2)}},
+		}
+
+		want := []btypes.ChangesetCheck{
+			{
+				Name:        "build",
+				State:       btypes.ChangesetCheckStatePassed,
+				Conclusion:  "SUCCESS",
+				StartedAt:   minutes(-5),
+				CompletedAt: minutes(2),
+				RetryID:     "suite-1",
+			},
+			{
+				Name:        "ci/lint",
+				URL:         "https://ci.example.com/lint",
+				State:       btypes.ChangesetCheckStatePassed,
+				Conclusion:  "SUCCESS",
+				StartedAt:   minutes(-10),
+				CompletedAt: minutes(1),
+			},
+			{
+				Name:        "test",
+				URL:         "https://github.com/runs/1",
+				State:       btypes.ChangesetCheckStateFailed,
+				Conclusion:  "FAILURE",
+				StartedAt:   minutes(-20),
+				CompletedAt: minutes(-15),
+				RetryID:     "suite-1",
+			},
+		}
+		have := computeChecks(c, events)
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("unexpected checks (-want +have):\n%s", diff)
+		}
+		if d := have[2].Duration(); d != 5*time.Minute {
+			t.Fatalf("unexpected duration: %s", d)
+		}
+	})
+
+	t.Run("GitLab", func(t *testing.T) {
+		mr := &gitlab.MergeRequest{
+			Pipelines: []*gitlab.Pipeline{
+				{ID: 1, Status: gitlab.PipelineStatusSuccess, CreatedAt: gitlab.Time{Time: minutes(-30)}, UpdatedAt: gitlab.Time{Time: minutes(-25)}},
+				{ID: 2, Status: gitlab.PipelineStatusFailed, WebURL: "https://gitlab.com/pipelines/2", CreatedAt: gitlab.Time{Time: minutes(-20)}, UpdatedAt: gitlab.Time{Time: minutes(-12)}},
+			},
+		}
+		c := &btypes.Changeset{UpdatedAt: lastSynced, Metadata: mr}
+
+		want := []btypes.ChangesetCheck{{
+			Name:        "Pipeline",
+			URL:         "https://gitlab.com/pipelines/2",
+			State:       btypes.ChangesetCheckStateFailed,
+			Conclusion:  "failed",
+			StartedAt:   minutes(-20),
+			CompletedAt: minutes(-12),
+			RetryID:     "2",
+		}}
+		if diff := cmp.Diff(want, computeChecks(c, nil)); diff != "" {
+			t.Fatalf("unexpected checks (-want +have):\n%s", diff)
+		}
+
+		// A running pipeline received via webhook takes precedence and has no
+		// completion time yet.
+		events := ChangesetEvents{
+			{Metadata: &gitlab.Pipeline{ID: 3, Status: gitlab.PipelineStatusRunning, CreatedAt: gitlab.Time{Time: minutes(1)}}},
+		}
+		want = []btypes.ChangesetCheck{{
+			Name:       "Pipeline",
+			State:      btypes.ChangesetCheckStatePending,
+			Conclusion: "running",
+			StartedAt:  minutes(1),
+			RetryID:    "3",
+		}}
+		if diff := cmp.Diff(want, computeChecks(c, events)); diff != "" {
+			t.Fatalf("unexpected checks (-want +have):\n%s", diff)
+		}
+	})
+}
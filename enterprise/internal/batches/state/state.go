@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
@@ -34,6 +35,7 @@ func SetDerivedState(ctx context.Context, repoStore database.RepoStore, c *btype
 	sort.Sort(events)
 
 	c.ExternalCheckState = computeCheckState(c, events)
+	c.ExternalChecks = computeChecks(c, events)
 
 	history, err := computeHistory(c, events)
 	if err != nil {
@@ -407,12 +409,22 @@ func parseGithubCheckSuiteState(status, conclusion string) btypes.ChangesetCheck
 func computeGitLabCheckState(lastSynced time.Time, mr *gitlab.MergeRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
 	// GitLab pipelines aren't tied to commits in the same way that GitHub
 	// checks are. We're simply looking for the most recent pipeline run that
-	// was associated with the merge request, which may live in a changeset
-	// event (via webhook) or on the Pipelines field of the merge request
-	// itself. We don't need to implement the same combinatorial logic that
-	// exists for other code hosts because that's essentially what the pipeline
-	// is, except GitLab handles the details of combining the job states.
+	// was associated with the merge request. We don't need to implement the
+	// same combinatorial logic that exists for other code hosts because that's
+	// essentially what the pipeline is, except GitLab handles the details of
+	// combining the job states.
+	pipeline := latestGitLabPipeline(lastSynced, mr, events)
+	if pipeline == nil {
+		return btypes.ChangesetCheckStateUnknown
+	}
+	return parseGitLabPipelineStatus(pipeline.Status)
+}
 
+// latestGitLabPipeline returns the most recent pipeline run that was
+// associated with the merge request, which may live in a changeset event (via
+// webhook) or on the Pipelines field of the merge request itself. If there is
+// no pipeline, nil is returned.
+func latestGitLabPipeline(lastSynced time.Time, mr *gitlab.MergeRequest, events []*btypes.ChangesetEvent) *gitlab.Pipeline {
 	// Let's figure out what the last pipeline event we saw in the events was.
 	var lastPipelineEvent *gitlab.Pipeline
 	for _, e := range events {
@@ -435,10 +447,7 @@ func computeGitLabCheckState(lastSynced time.Time, mr *gitlab.MergeRequest, even
 		// HeadPipeline. If that's empty, then we'll shrug and say we don't
 		// know.
 		if len(mr.Pipelines) == 0 {
-			if mr.HeadPipeline != nil {
-				return parseGitLabPipelineStatus(mr.HeadPipeline.Status)
-			}
-			return btypes.ChangesetCheckStateUnknown
+			return mr.HeadPipeline
 		}
 
 		// Sort into descending order so that the pipeline at index 0 is the latest.
@@ -447,10 +456,10 @@ func computeGitLabCheckState(lastSynced time.Time, mr *gitlab.MergeRequest, even
 			return pipelines[i].CreatedAt.After(pipelines[j].CreatedAt.Time)
 		})
 
-		return parseGitLabPipelineStatus(pipelines[0].Status)
+		return pipelines[0]
 	}
 
-	return parseGitLabPipelineStatus(lastPipelineEvent.Status)
+	return lastPipelineEvent
 }
 
 func parseGitLabPipelineStatus(status gitlab.PipelineStatus) btypes.ChangesetCheckState {
@@ -512,6 +521,18 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		default:
 			return "", errors.Errorf("unknown Bitbucket Cloud pull request state: %s", m.State)
 		}
+	case *awscodecommit.PullRequest:
+		// Merged pull requests are closed, too.
+		switch {
+		case m.IsMerged:
+			s = btypes.ChangesetExternalStateMerged
+		case m.Status == awscodecommit.PullRequestStatusClosed:
+			s = btypes.ChangesetExternalStateClosed
+		case m.Status == awscodecommit.PullRequestStatusOpen:
+			s = btypes.ChangesetExternalStateOpen
+		default:
+			return "", errors.Errorf("unknown AWS CodeCommit pull request status: %s", m.Status)
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -581,6 +602,23 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 			}
 		}
 
+	case *awscodecommit.PullRequest:
+		// If approval rules apply to the pull request, they decide whether it
+		// is approved. Otherwise, any approval of the current revision is
+		// enough. AWS CodeCommit has no notion of requesting changes.
+		if m.Evaluation.HasApprovalRules() {
+			if m.Evaluation.Approved || m.Evaluation.Overridden {
+				return btypes.ChangesetReviewStateApproved, nil
+			}
+			return btypes.ChangesetReviewStatePending, nil
+		}
+		for _, a := range m.Approvals {
+			if a.State == awscodecommit.ApprovalStateApprove {
+				return btypes.ChangesetReviewStateApproved, nil
+			}
+		}
+		return btypes.ChangesetReviewStatePending, nil
+
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -9,6 +9,7 @@ import (
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -535,6 +536,41 @@ func TestComputeReviewState(t *testing.T) {
 			},
 			want: btypes.ChangesetReviewStateChangesRequested,
 		},
+		{
+			name:      "awscodecommit - no approvals",
+			changeset: awsCodeCommitChangeset(daysAgo(0), &awscodecommit.PullRequest{Status: "OPEN"}),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "awscodecommit - approval without approval rules",
+			changeset: awsCodeCommitChangeset(daysAgo(0), &awscodecommit.PullRequest{
+				Status:     "OPEN",
+				Approvals:  []*awscodecommit.Approval{{UserARN: "arn:aws:iam::999999999999:user/reviewer", State: "APPROVE"}},
+				Evaluation: &awscodecommit.Evaluation{},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateApproved,
+		},
+		{
+			name: "awscodecommit - approval rules not satisfied",
+			changeset: awsCodeCommitChangeset(daysAgo(0), &awscodecommit.PullRequest{
+				Status:     "OPEN",
+				Approvals:  []*awscodecommit.Approval{{UserARN: "arn:aws:iam::999999999999:user/reviewer", State: "APPROVE"}},
+				Evaluation: &awscodecommit.Evaluation{ApprovalRulesNotSatisfied: []string{"two approvals"}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStatePending,
+		},
+		{
+			name: "awscodecommit - approval rules overridden",
+			changeset: awsCodeCommitChangeset(daysAgo(0), &awscodecommit.PullRequest{
+				Status:     "OPEN",
+				Evaluation: &awscodecommit.Evaluation{Overridden: true, ApprovalRulesNotSatisfied: []string{"two approvals"}},
+			}),
+			history: []changesetStatesAtTime{},
+			want:    btypes.ChangesetReviewStateApproved,
+		},
 	}
 
 	for i, tc := range tests {
@@ -715,6 +751,24 @@ func TestComputeExternalState(t *testing.T) {
 			},
 			want: btypes.ChangesetExternalStateDraft,
 		},
+		{
+			name:      "awscodecommit - open",
+			changeset: awsCodeCommitChangeset(daysAgo(0), &awscodecommit.PullRequest{Status: "OPEN"}),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateOpen,
+		},
+		{
+			name:      "awscodecommit - closed",
+			changeset: awsCodeCommitChangeset(daysAgo(0), &awscodecommit.PullRequest{Status: "CLOSED"}),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateClosed,
+		},
+		{
+			name:      "awscodecommit - merged",
+			changeset: awsCodeCommitChangeset(daysAgo(0), &awscodecommit.PullRequest{Status: "CLOSED", IsMerged: true}),
+			history:   []changesetStatesAtTime{},
+			want:      btypes.ChangesetExternalStateMerged,
+		},
 	}
 
 	for i, tc := range tests {
@@ -866,6 +920,14 @@ func gitLabChangeset(updatedAt time.Time, state gitlab.MergeRequestState, notes
 	}
 }
 
+func awsCodeCommitChangeset(updatedAt time.Time, pr *awscodecommit.PullRequest) *btypes.Changeset {
+	return &btypes.Changeset{
+		ExternalServiceType: extsvc.TypeAWSCodeCommit,
+		UpdatedAt:           updatedAt,
+		Metadata:            pr,
+	}
+}
+
 func setDeletedAt(c *btypes.Changeset, deletedAt time.Time) *btypes.Changeset {
 	c.ExternalDeletedAt = deletedAt
 	return c
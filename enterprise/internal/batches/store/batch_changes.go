@@ -453,6 +453,14 @@ type ListBatchChangesOpts struct {
 	RepoID api.RepoID
 
 	ExcludeDraftsNotOwnedByUserID int32
+
+	// OnlyWithImportQueries limits the batch changes to those whose current
+	// batch spec imports changesets with a search query.
+	OnlyWithImportQueries bool
+
+	// OnlyWithReviewReminders limits the batch changes to those whose current
+	// batch spec declares a changesetTemplate.reviewReminder.
+	OnlyWithReviewReminders bool
 }
 
 // ListBatchChanges lists batch changes with the given filters.
@@ -567,6 +575,24 @@ func listBatchChangesQuery(opts *ListBatchChangesOpts, repoAuthzConds *sqlf.Quer
 		)`, opts.RepoID, repoAuthzConds))
 	}
 
+	if opts.OnlyWithImportQueries {
+		preds = append(preds, sqlf.Sprintf(`EXISTS (
+			SELECT 1 FROM batch_specs
+			WHERE
+				batch_specs.id = batch_changes.batch_spec_id AND
+				jsonb_path_exists(batch_specs.spec, '$.importChangesets[*].query')
+		)`))
+	}
+
+	if opts.OnlyWithReviewReminders {
+		preds = append(preds, sqlf.Sprintf(`EXISTS (
+			SELECT 1 FROM batch_specs
+			WHERE
+				batch_specs.id = batch_changes.batch_spec_id AND
+				jsonb_path_exists(batch_specs.spec, '$.changesetTemplate.reviewReminder')
+		)`))
+	}
+
 	if len(preds) == 0 {
 		preds = append(preds, sqlf.Sprintf("TRUE"))
 	}
@@ -0,0 +1,235 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// batchSpecWorkspaceArtifactInsertColumns is the list of
+// batch_spec_workspace_artifacts columns that are modified in
+// CreateBatchSpecWorkspaceArtifacts.
+var batchSpecWorkspaceArtifactInsertColumns = []string{
+	"batch_spec_workspace_id",
+	"name",
+	"value",
+	"created_at",
+}
+
+// batchSpecWorkspaceArtifactColumns are used by the artifact related Store
+// methods to query and create artifacts.
+var batchSpecWorkspaceArtifactColumns = SQLColumns{
+	"batch_spec_workspace_artifacts.id",
+	"batch_spec_workspace_artifacts.batch_spec_workspace_id",
+	"batch_spec_workspace_artifacts.name",
+	"batch_spec_workspace_artifacts.value",
+	"batch_spec_workspace_artifacts.created_at",
+}
+
+// CreateBatchSpecWorkspaceArtifacts creates the given artifacts. If a workspace
+// already has an artifact with the same name, its value is overwritten.
+func (s *Store) CreateBatchSpecWorkspaceArtifacts(ctx context.Context, as ...*btypes.BatchSpecWorkspaceArtifact) (err error) {
+	ctx, _, endObservation := s.operations.createBatchSpecWorkspaceArtifacts.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("count", len(as)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	inserter := func(inserter *batch.Inserter) error {
+		for _, a := range as {
+			if a.CreatedAt.IsZero() {
+				a.CreatedAt = s.now()
+			}
+
+			value, err := json.Marshal(a.Value)
+			if err != nil {
+				return err
+			}
+
+			if err := inserter.Insert(
+				ctx,
+				a.BatchSpecWorkspaceID,
+				a.Name,
+				value,
+				a.CreatedAt,
+			); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+	i := -1
+	return batch.WithInserterWithReturn(
+		ctx,
+		s.Handle().DB(),
+		"batch_spec_workspace_artifacts",
+		batch.MaxNumPostgresParameters,
+		batchSpecWorkspaceArtifactInsertColumns,
+		"ON CONFLICT ON CONSTRAINT batch_spec_workspace_artifacts_name_unique DO UPDATE SET value = EXCLUDED.value, created_at = EXCLUDED.created_at",
+		batchSpecWorkspaceArtifactColumns,
+		func(rows dbutil.Scanner) error {
+			i++
+			return scanBatchSpecWorkspaceArtifact(as[i], rows)
+		},
+		inserter,
+	)
+}
+
+// ListBatchChangeArtifactsOpts captures the query options needed for listing
+// the artifacts of a batch change.
+type ListBatchChangeArtifactsOpts struct {
+	LimitOpts
+	Cursor int64
+
+	BatchChangeID int64
+	// Name, if set, only lists the artifacts with the given name.
+	Name string
+}
+
+// ListBatchChangeArtifacts lists the artifacts produced by the workspaces of
+// the current batch spec of a batch change. Only artifacts in repositories
+// that the actor in ctx can access are returned.
+func (s *Store) ListBatchChangeArtifacts(ctx context.Context, opts ListBatchChangeArtifactsOpts) (as []*btypes.BatchChangeArtifact, next int64, err error) {
+	ctx, _, endObservation := s.operations.listBatchChangeArtifacts.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(opts.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	where, err := batchChangeArtifactsConds(ctx, s.DatabaseDB(), opts, false)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	q := sqlf.Sprintf(
+		listBatchChangeArtifactsQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(batchSpecWorkspaceArtifactColumns.ToSqlf(), ", "),
+		where,
+	)
+
+	as = make([]*btypes.BatchChangeArtifact, 0)
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var a btypes.BatchChangeArtifact
+		if err := scanBatchChangeArtifact(&a, sc); err != nil {
+			return err
+		}
+		as = append(as, &a)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(as) == opts.DBLimit() {
+		next = as[len(as)-1].ID
+		as = as[:len(as)-1]
+	}
+
+	return as, next, err
+}
+
+var listBatchChangeArtifactsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_workspace_artifacts.go:ListBatchChangeArtifacts
+SELECT
+	%s,
+	batch_spec_workspaces.repo_id,
+	batch_spec_workspaces.path
+FROM batch_spec_workspace_artifacts
+INNER JOIN batch_spec_workspaces ON batch_spec_workspaces.id = batch_spec_workspace_artifacts.batch_spec_workspace_id
+INNER JOIN batch_changes ON batch_changes.batch_spec_id = batch_spec_workspaces.batch_spec_id
+INNER JOIN repo ON repo.id = batch_spec_workspaces.repo_id
+WHERE %s
+ORDER BY batch_spec_workspace_artifacts.id ASC
+`
+
+// CountBatchChangeArtifacts counts the artifacts of a batch change with the
+// given filters.
+func (s *Store) CountBatchChangeArtifacts(ctx context.Context, opts ListBatchChangeArtifactsOpts) (count int, err error) {
+	ctx, _, endObservation := s.operations.countBatchChangeArtifacts.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(opts.BatchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	where, err := batchChangeArtifactsConds(ctx, s.DatabaseDB(), opts, true)
+	if err != nil {
+		return 0, err
+	}
+
+	count, _, err = basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(countBatchChangeArtifactsQueryFmtstr, where)))
+	return count, err
+}
+
+var countBatchChangeArtifactsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_workspace_artifacts.go:CountBatchChangeArtifacts
+SELECT COUNT(1)
+FROM batch_spec_workspace_artifacts
+INNER JOIN batch_spec_workspaces ON batch_spec_workspaces.id = batch_spec_workspace_artifacts.batch_spec_workspace_id
+INNER JOIN batch_changes ON batch_changes.batch_spec_id = batch_spec_workspaces.batch_spec_id
+INNER JOIN repo ON repo.id = batch_spec_workspaces.repo_id
+WHERE %s
+`
+
+func batchChangeArtifactsConds(ctx context.Context, db database.DB, opts ListBatchChangeArtifactsOpts, forCount bool) (*sqlf.Query, error) {
+	if opts.BatchChangeID == 0 {
+		return nil, errors.New("cannot query artifacts without specifying BatchChangeID")
+	}
+
+	// 🚨 SECURITY: Artifacts can contain code, so we must only return the
+	// artifacts of repositories that the user has access to.
+	repoAuthzConds, err := database.AuthzQueryConds(ctx, db)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating authz query conds")
+	}
+
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("batch_changes.id = %s", opts.BatchChangeID),
+		sqlf.Sprintf("repo.deleted_at IS NULL"),
+		repoAuthzConds,
+	}
+	if opts.Name != "" {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspace_artifacts.name = %s", opts.Name))
+	}
+	if !forCount && opts.Cursor > 0 {
+		preds = append(preds, sqlf.Sprintf("batch_spec_workspace_artifacts.id >= %s", opts.Cursor))
+	}
+
+	return sqlf.Join(preds, "\n AND "), nil
+}
+
+func scanBatchSpecWorkspaceArtifact(a *btypes.BatchSpecWorkspaceArtifact, s dbutil.Scanner) error {
+	var value []byte
+	if err := s.Scan(
+		&a.ID,
+		&a.BatchSpecWorkspaceID,
+		&a.Name,
+		&value,
+		&a.CreatedAt,
+	); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(value, &a.Value)
+}
+
+func scanBatchChangeArtifact(a *btypes.BatchChangeArtifact, s dbutil.Scanner) error {
+	var value []byte
+	if err := s.Scan(
+		&a.ID,
+		&a.BatchSpecWorkspaceID,
+		&a.Name,
+		&value,
+		&a.CreatedAt,
+		&a.RepoID,
+		&a.Path,
+	); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(value, &a.Value)
+}
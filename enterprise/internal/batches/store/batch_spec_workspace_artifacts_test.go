@@ -0,0 +1,143 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func testStoreBatchSpecWorkspaceArtifacts(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	repoStore := database.ReposWith(s)
+	esStore := database.ExternalServicesWith(s)
+
+	repo := ct.TestRepo(t, esStore, extsvc.KindGitHub)
+	deletedRepo := ct.TestRepo(t, esStore, extsvc.KindGitHub)
+	for _, r := range []*types.Repo{repo, deletedRepo} {
+		if err := repoStore.Create(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := repoStore.Delete(ctx, deletedRepo.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	user := ct.CreateTestUser(t, s.DatabaseDB(), false)
+	spec := ct.CreateBatchSpec(t, ctx, s, "audit", user.ID)
+	batchChange := ct.CreateBatchChange(t, ctx, s, "audit", user.ID, spec.ID)
+
+	otherSpec := ct.CreateBatchSpec(t, ctx, s, "other-audit", user.ID)
+
+	workspaces := []*btypes.BatchSpecWorkspace{
+		{BatchSpecID: spec.ID, RepoID: repo.ID, Path: "a"},
+		{BatchSpecID: spec.ID, RepoID: repo.ID, Path: "b"},
+		{BatchSpecID: spec.ID, RepoID: deletedRepo.ID},
+		{BatchSpecID: otherSpec.ID, RepoID: repo.ID},
+	}
+	if err := s.CreateBatchSpecWorkspace(ctx, workspaces...); err != nil {
+		t.Fatal(err)
+	}
+
+	artifacts := []*btypes.BatchSpecWorkspaceArtifact{
+		{BatchSpecWorkspaceID: workspaces[0].ID, Name: "warnings", Value: "deprecated API used"},
+		{BatchSpecWorkspaceID: workspaces[0].ID, Name: "audit", Value: map[string]any{"count": float64(2)}},
+		{BatchSpecWorkspaceID: workspaces[1].ID, Name: "warnings", Value: ""},
+		{BatchSpecWorkspaceID: workspaces[2].ID, Name: "warnings", Value: "deleted"},
+		{BatchSpecWorkspaceID: workspaces[3].ID, Name: "warnings", Value: "other"},
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		if err := s.CreateBatchSpecWorkspaceArtifacts(ctx, artifacts...); err != nil {
+			t.Fatal(err)
+		}
+		for _, a := range artifacts {
+			if a.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+			if want, have := clock.Now(), a.CreatedAt; !have.Equal(want) {
+				t.Fatalf("artifact.CreatedAt is wrong.\n\twant=%s\n\thave=%s", want, have)
+			}
+		}
+
+		// Creating an artifact with the same name overwrites it.
+		conflict := &btypes.BatchSpecWorkspaceArtifact{BatchSpecWorkspaceID: workspaces[1].ID, Name: "warnings", Value: "no warnings"}
+		if err := s.CreateBatchSpecWorkspaceArtifacts(ctx, conflict); err != nil {
+			t.Fatal(err)
+		}
+		if conflict.ID != artifacts[2].ID {
+			t.Fatalf("artifact was not overwritten: want ID %d, have %d", artifacts[2].ID, conflict.ID)
+		}
+		artifacts[2] = conflict
+	})
+
+	t.Run("List", func(t *testing.T) {
+		want := []*btypes.BatchChangeArtifact{
+			{BatchSpecWorkspaceArtifact: *artifacts[0], RepoID: repo.ID, Path: "a"},
+			{BatchSpecWorkspaceArtifact: *artifacts[1], RepoID: repo.ID, Path: "a"},
+			{BatchSpecWorkspaceArtifact: *artifacts[2], RepoID: repo.ID, Path: "b"},
+		}
+
+		for _, tc := range []struct {
+			name string
+			opts ListBatchChangeArtifactsOpts
+			want []*btypes.BatchChangeArtifact
+		}{
+			{
+				name: "all",
+				opts: ListBatchChangeArtifactsOpts{BatchChangeID: batchChange.ID},
+				want: want,
+			},
+			{
+				name: "by name",
+				opts: ListBatchChangeArtifactsOpts{BatchChangeID: batchChange.ID, Name: "warnings"},
+				want: []*btypes.BatchChangeArtifact{want[0], want[2]},
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				have, _, err := s.ListBatchChangeArtifacts(ctx, tc.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(tc.want, have); diff != "" {
+					t.Fatal(diff)
+				}
+
+				count, err := s.CountBatchChangeArtifacts(ctx, tc.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if count != len(tc.want) {
+					t.Fatalf("wrong count: want %d, have %d", len(tc.want), count)
+				}
+			})
+		}
+
+		t.Run("pagination", func(t *testing.T) {
+			have, next, err := s.ListBatchChangeArtifacts(ctx, ListBatchChangeArtifactsOpts{
+				LimitOpts:     LimitOpts{Limit: 2},
+				BatchChangeID: batchChange.ID,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want[:2], have); diff != "" {
+				t.Fatal(diff)
+			}
+			if next != want[2].ID {
+				t.Fatalf("wrong cursor: want %d, have %d", want[2].ID, next)
+			}
+		})
+
+		t.Run("no batch change", func(t *testing.T) {
+			if _, _, err := s.ListBatchChangeArtifacts(ctx, ListBatchChangeArtifactsOpts{}); err == nil {
+				t.Fatal("unexpected nil error")
+			}
+		})
+	})
+}
@@ -37,6 +37,7 @@ var batchSpecWorkspaceInsertColumns = []string{
 	"skipped",
 	"cached_result_found",
 	"step_cache_results",
+	"owner",
 
 	"created_at",
 	"updated_at",
@@ -61,6 +62,7 @@ var BatchSpecWorkspaceColums = SQLColumns{
 	"batch_spec_workspaces.skipped",
 	"batch_spec_workspaces.cached_result_found",
 	"batch_spec_workspaces.step_cache_results",
+	"batch_spec_workspaces.owner",
 
 	"batch_spec_workspaces.created_at",
 	"batch_spec_workspaces.updated_at",
@@ -117,6 +119,7 @@ func (s *Store) CreateBatchSpecWorkspace(ctx context.Context, ws ...*btypes.Batc
 				wj.Skipped,
 				wj.CachedResultFound,
 				marshaledStepCacheResults,
+				wj.Owner,
 				wj.CreatedAt,
 				wj.UpdatedAt,
 			); err != nil {
@@ -406,6 +409,7 @@ func scanBatchSpecWorkspace(wj *btypes.BatchSpecWorkspace, s dbutil.Scanner) err
 		&wj.Skipped,
 		&wj.CachedResultFound,
 		&stepCacheResults,
+		&wj.Owner,
 		&wj.CreatedAt,
 		&wj.UpdatedAt,
 	); err != nil {
@@ -44,6 +44,7 @@ func testStoreBatchSpecWorkspaces(t *testing.T, ctx context.Context, s *Store, c
 				"a/b/c.go",
 			},
 			OnlyFetchWorkspace: true,
+			Owner:              "@sourcegraph/batchers",
 			Unsupported:        true,
 			Ignored:            true,
 			Skipped:            true,
@@ -0,0 +1,244 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// batchStepCacheEntryInsertColumns is the list of batch_step_cache_entries
+// columns that are modified in CreateBatchStepCacheEntry.
+var batchStepCacheEntryInsertColumns = SQLColumns{
+	"repo_id",
+	"key",
+	"value",
+	"version",
+	"last_used_at",
+	"created_at",
+}
+
+// batchStepCacheEntryColumns are used by the step cache entry related Store
+// methods to query and create cache entries.
+var batchStepCacheEntryColumns = SQLColumns{
+	"batch_step_cache_entries.id",
+	"batch_step_cache_entries.repo_id",
+	"batch_step_cache_entries.key",
+	"batch_step_cache_entries.value",
+	"batch_step_cache_entries.version",
+	"batch_step_cache_entries.last_used_at",
+	"batch_step_cache_entries.created_at",
+}
+
+// CreateBatchStepCacheEntry creates the given step cache entry. If an entry
+// with the same key already exists, it is overwritten.
+func (s *Store) CreateBatchStepCacheEntry(ctx context.Context, ce *btypes.BatchStepCacheEntry) (err error) {
+	ctx, _, endObservation := s.operations.createBatchStepCacheEntry.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("Key", ce.Key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if ce.CreatedAt.IsZero() {
+		ce.CreatedAt = s.now()
+	}
+
+	if ce.Version == 0 {
+		ce.Version = btypes.CurrentCacheVersion
+	}
+
+	lastUsedAt := &ce.LastUsedAt
+	if ce.LastUsedAt.IsZero() {
+		lastUsedAt = nil
+	}
+
+	q := sqlf.Sprintf(
+		createBatchStepCacheEntryQueryFmtstr,
+		sqlf.Join(batchStepCacheEntryInsertColumns.ToSqlf(), ", "),
+		ce.RepoID,
+		ce.Key,
+		ce.Value,
+		ce.Version,
+		&dbutil.NullTime{Time: lastUsedAt},
+		ce.CreatedAt,
+		sqlf.Join(batchStepCacheEntryColumns.ToSqlf(), ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return scanBatchStepCacheEntry(ce, sc)
+	})
+}
+
+var createBatchStepCacheEntryQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_step_cache_entries.go:CreateBatchStepCacheEntry
+INSERT INTO batch_step_cache_entries (%s)
+VALUES ` + batchStepCacheEntryInsertColumns.FmtStr() + `
+ON CONFLICT ON CONSTRAINT batch_step_cache_entries_key_unique
+DO UPDATE SET
+	repo_id = EXCLUDED.repo_id,
+	value = EXCLUDED.value,
+	version = EXCLUDED.version,
+	created_at = EXCLUDED.created_at
+RETURNING %s
+`
+
+// ListBatchStepCacheEntriesOpts captures the query options needed for
+// listing step cache entries.
+type ListBatchStepCacheEntriesOpts struct {
+	Keys    []string
+	RepoIDs []api.RepoID
+}
+
+// ListBatchStepCacheEntries gets the step cache entries matching the given
+// options. Only entries in repositories that the actor in ctx can access are
+// returned.
+func (s *Store) ListBatchStepCacheEntries(ctx context.Context, opts ListBatchStepCacheEntriesOpts) (cs []*btypes.BatchStepCacheEntry, err error) {
+	ctx, _, endObservation := s.operations.listBatchStepCacheEntries.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("Count", len(opts.Keys)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(opts.Keys) == 0 {
+		return nil, errors.New("cannot query step cache entries without specifying Keys")
+	}
+
+	if len(opts.RepoIDs) == 0 {
+		return nil, errors.New("cannot query step cache entries without specifying RepoIDs")
+	}
+
+	// 🚨 SECURITY: Step cache entries are shared across users, so we must only
+	// return entries in repositories that the user has access to.
+	repoAuthzConds, err := database.AuthzQueryConds(ctx, database.NewDB(s.Handle().DB()))
+	if err != nil {
+		return nil, errors.Wrap(err, "ListBatchStepCacheEntries generating authz query conds")
+	}
+
+	q := listBatchStepCacheEntriesQuery(&opts, repoAuthzConds)
+
+	cs = make([]*btypes.BatchStepCacheEntry, 0, len(opts.Keys))
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var c btypes.BatchStepCacheEntry
+		if err := scanBatchStepCacheEntry(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+
+	return cs, err
+}
+
+var listBatchStepCacheEntriesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_step_cache_entries.go:ListBatchStepCacheEntries
+SELECT %s FROM batch_step_cache_entries
+INNER JOIN repo ON repo.id = batch_step_cache_entries.repo_id
+WHERE %s
+`
+
+func listBatchStepCacheEntriesQuery(opts *ListBatchStepCacheEntriesOpts, repoAuthzConds *sqlf.Query) *sqlf.Query {
+	preds := []*sqlf.Query{
+		sqlf.Sprintf("batch_step_cache_entries.key = ANY (%s)", pq.Array(opts.Keys)),
+		sqlf.Sprintf("batch_step_cache_entries.repo_id = ANY (%s)", pq.Array(opts.RepoIDs)),
+		// Only consider records that are in the current cache version.
+		sqlf.Sprintf("batch_step_cache_entries.version = %s", btypes.CurrentCacheVersion),
+		sqlf.Sprintf("repo.deleted_at IS NULL"),
+		repoAuthzConds,
+	}
+
+	return sqlf.Sprintf(
+		listBatchStepCacheEntriesQueryFmtstr,
+		sqlf.Join(batchStepCacheEntryColumns.ToSqlf(), ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+const markUsedBatchStepCacheEntriesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_step_cache_entries.go:MarkUsedBatchStepCacheEntries
+UPDATE
+	batch_step_cache_entries
+SET last_used_at = %s
+WHERE
+	batch_step_cache_entries.id = ANY (%s)
+`
+
+// MarkUsedBatchStepCacheEntries updates the LastUsedAt of the given step cache
+// entries.
+func (s *Store) MarkUsedBatchStepCacheEntries(ctx context.Context, ids []int64) (err error) {
+	ctx, _, endObservation := s.operations.markUsedBatchStepCacheEntries.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("count", len(ids)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		markUsedBatchStepCacheEntriesQueryFmtstr,
+		s.now(),
+		pq.Array(ids),
+	)
+	return s.Exec(ctx, q)
+}
+
+// cleanBatchStepCacheEntriesQueryFmtstr works like
+// cleanBatchSpecExecutionEntriesQueryFmtstr: it deletes the least recently
+// used entries until the table is under maxCacheSize again, plus all entries
+// from older cache versions.
+const cleanBatchStepCacheEntriesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_step_cache_entries.go:CleanBatchStepCacheEntries
+WITH total_size AS (
+  SELECT sum(octet_length(value)) AS total FROM batch_step_cache_entries
+),
+candidates AS (
+  SELECT
+    id
+  FROM (
+    SELECT
+      entries.id,
+      SUM(octet_length(entries.value)) OVER (ORDER BY COALESCE(entries.last_used_at, entries.created_at) ASC, entries.id ASC) AS running_size
+    FROM batch_step_cache_entries entries
+  ) t
+  WHERE
+    ((SELECT total FROM total_size) - t.running_size) >= %s
+),
+outdated AS (
+	SELECT
+		id
+	FROM batch_step_cache_entries
+	WHERE
+		version < %s
+),
+ids AS (
+	SELECT id FROM outdated
+	UNION ALL
+	SELECT id FROM candidates
+)
+DELETE FROM batch_step_cache_entries WHERE id IN (SELECT id FROM ids)
+`
+
+// CleanBatchStepCacheEntries evicts step cache entries so that the total size
+// of their values is below maxCacheSize bytes.
+func (s *Store) CleanBatchStepCacheEntries(ctx context.Context, maxCacheSize int64) (err error) {
+	ctx, _, endObservation := s.operations.cleanBatchStepCacheEntries.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("MaxTableSize", int(maxCacheSize)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(cleanBatchStepCacheEntriesQueryFmtstr, maxCacheSize, btypes.CurrentCacheVersion))
+}
+
+func scanBatchStepCacheEntry(ce *btypes.BatchStepCacheEntry, s dbutil.Scanner) error {
+	return s.Scan(
+		&ce.ID,
+		&ce.RepoID,
+		&ce.Key,
+		&ce.Value,
+		&ce.Version,
+		&dbutil.NullTime{Time: &ce.LastUsedAt},
+		&ce.CreatedAt,
+	)
+}
@@ -0,0 +1,186 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func testStoreBatchStepCacheEntries(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	repoStore := database.ReposWith(s)
+	esStore := database.ExternalServicesWith(s)
+
+	repo := ct.TestRepo(t, esStore, extsvc.KindGitHub)
+	otherRepo := ct.TestRepo(t, esStore, extsvc.KindGitHub)
+	for _, r := range []*types.Repo{repo, otherRepo} {
+		if err := repoStore.Create(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries := make([]*btypes.BatchStepCacheEntry, 0, 3)
+	for i := 0; i < cap(entries); i++ {
+		entries = append(entries, &btypes.BatchStepCacheEntry{
+			RepoID: repo.ID,
+			Key:    fmt.Sprintf("global-step-cache-key-%d", i),
+			Value:  fmt.Sprintf("global-step-cache-value-%d", i),
+		})
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, entry := range entries {
+			if err := s.CreateBatchStepCacheEntry(ctx, entry); err != nil {
+				t.Fatal(err)
+			}
+
+			if entry.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+			if want, have := clock.Now(), entry.CreatedAt; !have.Equal(want) {
+				t.Fatalf("entry.CreatedAt is wrong.\n\twant=%s\n\thave=%s", want, have)
+			}
+			if want, have := btypes.CurrentCacheVersion, entry.Version; have != want {
+				t.Fatalf("entry.Version is wrong.\n\twant=%d\n\thave=%d", want, have)
+			}
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		for _, tc := range []struct {
+			name    string
+			keys    []string
+			repoIDs []api.RepoID
+			want    []*btypes.BatchStepCacheEntry
+		}{
+			{
+				name:    "by keys",
+				keys:    []string{entries[0].Key, entries[2].Key},
+				repoIDs: []api.RepoID{repo.ID},
+				want:    []*btypes.BatchStepCacheEntry{entries[0], entries[2]},
+			},
+			{
+				name:    "other repo",
+				keys:    []string{entries[0].Key},
+				repoIDs: []api.RepoID{otherRepo.ID},
+				want:    []*btypes.BatchStepCacheEntry{},
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				have, err := s.ListBatchStepCacheEntries(ctx, ListBatchStepCacheEntriesOpts{
+					Keys:    tc.keys,
+					RepoIDs: tc.repoIDs,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(tc.want, have); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+		}
+
+		t.Run("no repo IDs", func(t *testing.T) {
+			if _, err := s.ListBatchStepCacheEntries(ctx, ListBatchStepCacheEntriesOpts{Keys: []string{entries[0].Key}}); err == nil {
+				t.Fatal("unexpected nil error")
+			}
+		})
+	})
+
+	t.Run("CreateWithConflictingKey", func(t *testing.T) {
+		clock.Add(1 * time.Minute)
+
+		conflict := &btypes.BatchStepCacheEntry{
+			RepoID: repo.ID,
+			Key:    entries[0].Key,
+			Value:  "new value",
+		}
+		if err := s.CreateBatchStepCacheEntry(ctx, conflict); err != nil {
+			t.Fatal(err)
+		}
+		if conflict.ID != entries[0].ID {
+			t.Fatalf("entry was not overwritten: want ID %d, have %d", entries[0].ID, conflict.ID)
+		}
+
+		reloaded, err := s.ListBatchStepCacheEntries(ctx, ListBatchStepCacheEntriesOpts{
+			Keys:    []string{conflict.Key},
+			RepoIDs: []api.RepoID{repo.ID},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]*btypes.BatchStepCacheEntry{conflict}, reloaded); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("MarkUsed", func(t *testing.T) {
+		if err := s.MarkUsedBatchStepCacheEntries(ctx, []int64{entries[1].ID}); err != nil {
+			t.Fatal(err)
+		}
+
+		reloaded, err := s.ListBatchStepCacheEntries(ctx, ListBatchStepCacheEntriesOpts{
+			Keys:    []string{entries[1].Key},
+			RepoIDs: []api.RepoID{repo.ID},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(reloaded) != 1 {
+			t.Fatal("cache entry not found")
+		}
+		if want, have := clock.Now(), reloaded[0].LastUsedAt; !have.Equal(want) {
+			t.Fatalf("entry.LastUsedAt is wrong.\n\twant=%s\n\thave=%s", want, have)
+		}
+	})
+
+	t.Run("Clean", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			clock.Add(1 * time.Minute)
+			if err := s.CreateBatchStepCacheEntry(ctx, &btypes.BatchStepCacheEntry{
+				RepoID: repo.ID,
+				Key:    fmt.Sprintf("large-step-cache-key-%d", i),
+				Value:  strings.Repeat("a", 1024),
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		maxSize := 5 * 1024
+		if err := s.CleanBatchStepCacheEntries(ctx, int64(maxSize)); err != nil {
+			t.Fatal(err)
+		}
+
+		totalSize, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf("SELECT sum(octet_length(value)) AS total FROM batch_step_cache_entries")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if totalSize > maxSize {
+			t.Fatalf("total size not below maximum: %d", totalSize)
+		}
+
+		// The most recently created entries are kept.
+		left, err := s.ListBatchStepCacheEntries(ctx, ListBatchStepCacheEntriesOpts{
+			Keys:    []string{"large-step-cache-key-9", entries[2].Key},
+			RepoIDs: []api.RepoID{repo.ID},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(left) != 1 || left[0].Key != "large-step-cache-key-9" {
+			t.Fatalf("wrong entries left: %+v", left)
+		}
+	})
+}
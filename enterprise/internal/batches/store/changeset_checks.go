@@ -0,0 +1,94 @@
+package store
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// GetBatchChangeCheckStats aggregates the checks of the non-archived
+// changesets of the given batch change by check name. The checks that fail
+// most often are returned first. Only changesets in repositories the user can
+// access are taken into account.
+func (s *Store) GetBatchChangeCheckStats(ctx context.Context, batchChangeID int64) (stats []*btypes.ChangesetCheckStats, err error) {
+	ctx, _, endObservation := s.operations.getBatchChangeCheckStats.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	authzConds, err := database.AuthzQueryConds(ctx, database.NewDB(s.Handle().DB()))
+	if err != nil {
+		return nil, errors.Wrap(err, "GetBatchChangeCheckStats generating authz query conds")
+	}
+
+	batchChangeIDStr := strconv.Itoa(int(batchChangeID))
+	q := sqlf.Sprintf(
+		getBatchChangeCheckStatsQueryFmtstr,
+		btypes.ChangesetCheckStatePassed,
+		btypes.ChangesetCheckStateFailed,
+		btypes.ChangesetCheckStatePending,
+		batchChangeIDStr,
+		archivedInBatchChange(batchChangeIDStr),
+		authzConds,
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var (
+			st      btypes.ChangesetCheckStats
+			seconds float64
+		)
+		if err := sc.Scan(&st.Name, &st.Total, &st.Passed, &st.Failed, &st.Pending, &seconds); err != nil {
+			return err
+		}
+		st.AverageDuration = time.Duration(seconds * float64(time.Second))
+		stats = append(stats, &st)
+		return nil
+	})
+
+	return stats, err
+}
+
+var getBatchChangeCheckStatsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_checks.go:GetBatchChangeCheckStats
+SELECT
+	checks.name,
+	COUNT(*) AS total,
+	COUNT(*) FILTER (WHERE checks.state = %s) AS passed,
+	COUNT(*) FILTER (WHERE checks.state = %s) AS failed,
+	COUNT(*) FILTER (WHERE checks.state = %s) AS pending,
+	COALESCE(
+		AVG(EXTRACT(EPOCH FROM checks.completed_at - checks.started_at))
+			FILTER (WHERE checks.started_at > '0001-01-01T00:00:00Z' AND checks.completed_at >= checks.started_at),
+		0
+	) AS average_duration
+FROM changesets
+INNER JOIN repo ON repo.id = changesets.repo_id
+CROSS JOIN LATERAL (
+	SELECT
+		c->>'name' AS name,
+		c->>'state' AS state,
+		(c->>'startedAt')::timestamptz AS started_at,
+		(c->>'completedAt')::timestamptz AS completed_at
+	FROM jsonb_array_elements(changesets.external_checks) AS c
+) AS checks
+WHERE
+	repo.deleted_at IS NULL
+AND
+	changesets.batch_change_ids ? %s
+AND
+	NOT %s
+AND
+	-- authz conditions:
+	%s
+GROUP BY checks.name
+ORDER BY failed DESC, total DESC, checks.name ASC
+`
@@ -0,0 +1,132 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func testStoreChangesetChecks(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	repoStore := database.ReposWith(s)
+	esStore := database.ExternalServicesWith(s)
+
+	repo := ct.TestRepo(t, esStore, extsvc.KindGitHub)
+	if err := repoStore.Create(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	const batchChangeID = 1234
+
+	started := clock.Now().Add(-10 * time.Minute)
+	check := func(name string, state btypes.ChangesetCheckState, duration time.Duration) btypes.ChangesetCheck {
+		c := btypes.ChangesetCheck{Name: name, State: state, StartedAt: started}
+		if duration > 0 {
+			c.CompletedAt = started.Add(duration)
+		}
+		return c
+	}
+
+	opts := ct.TestChangesetOpts{
+		Repo:             repo.ID,
+		BatchChange:      batchChangeID,
+		PublicationState: btypes.ChangesetPublicationStatePublished,
+		ExternalState:    btypes.ChangesetExternalStateOpen,
+		ReconcilerState:  btypes.ReconcilerStateCompleted,
+	}
+
+	opts.ExternalChecks = []btypes.ChangesetCheck{
+		check("build", btypes.ChangesetCheckStatePassed, 2*time.Minute),
+		check("test", btypes.ChangesetCheckStateFailed, 4*time.Minute),
+	}
+	ct.CreateChangeset(t, ctx, s, opts)
+
+	opts.ExternalChecks = []btypes.ChangesetCheck{
+		check("build", btypes.ChangesetCheckStatePassed, 4*time.Minute),
+		check("test", btypes.ChangesetCheckStatePending, 0),
+		check("lint", btypes.ChangesetCheckStatePassed, time.Minute),
+	}
+	ct.CreateChangeset(t, ctx, s, opts)
+
+	// Checks of archived changesets are not counted.
+	opts.ExternalChecks = []btypes.ChangesetCheck{
+		check("build", btypes.ChangesetCheckStateFailed, time.Minute),
+	}
+	opts.IsArchived = true
+	ct.CreateChangeset(t, ctx, s, opts)
+
+	// Neither are changesets without any checks.
+	opts.ExternalChecks = nil
+	opts.IsArchived = false
+	ct.CreateChangeset(t, ctx, s, opts)
+
+	have, err := s.GetBatchChangeCheckStats(ctx, batchChangeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*btypes.ChangesetCheckStats{
+		{Name: "test", Total: 2, Failed: 1, Pending: 1, AverageDuration: 4 * time.Minute},
+		{Name: "build", Total: 2, Passed: 2, AverageDuration: 3 * time.Minute},
+		{Name: "lint", Total: 1, Passed: 1, AverageDuration: time.Minute},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected stats (-want +have):\n%s", diff)
+	}
+
+	t.Run("Unknown batch change", func(t *testing.T) {
+		have, err := s.GetBatchChangeCheckStats(ctx, batchChangeID+1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("unexpected stats: %+v", have)
+		}
+	})
+	t.Run("Inaccessible repository", func(t *testing.T) {
+		userID := ct.CreateTestUser(t, s.DatabaseDB(), false).ID
+		userCtx := actor.WithActor(ctx, actor.FromUser(userID))
+
+		privateRepo := ct.TestRepo(t, esStore, extsvc.KindGitHub)
+		privateRepo.Private = true
+		if err := repoStore.Create(ctx, privateRepo); err != nil {
+			t.Fatal(err)
+		}
+
+		opts.Repo = privateRepo.ID
+		opts.ExternalChecks = []btypes.ChangesetCheck{
+			check("deploy", btypes.ChangesetCheckStateFailed, time.Minute),
+		}
+		ct.CreateChangeset(t, ctx, s, opts)
+
+		hasDeploy := func() bool {
+			have, err := s.GetBatchChangeCheckStats(userCtx, batchChangeID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, st := range have {
+				if st.Name == "deploy" {
+					return true
+				}
+			}
+			return false
+		}
+
+		if !hasDeploy() {
+			t.Fatal("checks of accessible repository are not counted")
+		}
+
+		// Now revoke repo access, and check that its checks aren't counted
+		// anymore.
+		ct.MockRepoPermissions(t, s.DatabaseDB(), 0, privateRepo.ID)
+		if hasDeploy() {
+			t.Fatal("checks of inaccessible repository are counted")
+		}
+	})
+}
@@ -0,0 +1,232 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// changesetDependencyColumns are used by the changeset dependency related
+// Store methods to insert and query changeset dependencies.
+var changesetDependencyColumns = []string{
+	"batch_change_id",
+	"changeset_id",
+	"depends_on_changeset_id",
+	"gate",
+}
+
+// ReplaceChangesetDependencies replaces the dependencies of the changesets in
+// the given batch change with the given dependencies.
+func (s *Store) ReplaceChangesetDependencies(ctx context.Context, batchChangeID int64, deps []*btypes.ChangesetDependency) (err error) {
+	ctx, _, endObservation := s.operations.replaceChangesetDependencies.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+		log.Int("count", len(deps)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(deleteChangesetDependenciesQueryFmtstr, batchChangeID)
+	if err := s.Exec(ctx, q); err != nil {
+		return err
+	}
+
+	return batch.WithInserter(
+		ctx,
+		s.Handle().DB(),
+		"changeset_dependencies",
+		batch.MaxNumPostgresParameters,
+		changesetDependencyColumns,
+		func(inserter *batch.Inserter) error {
+			for _, d := range deps {
+				if err := inserter.Insert(ctx, batchChangeID, d.ChangesetID, d.DependsOnChangesetID, d.Gate); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	)
+}
+
+var deleteChangesetDependenciesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_dependencies.go:ReplaceChangesetDependencies
+DELETE FROM changeset_dependencies WHERE batch_change_id = %s
+`
+
+// ListChangesetDependenciesOpts captures the query options needed for
+// listing changeset dependencies.
+type ListChangesetDependenciesOpts struct {
+	BatchChangeID int64
+	ChangesetID   int64
+}
+
+// ListChangesetDependencies lists the changeset dependencies matching the
+// given options.
+func (s *Store) ListChangesetDependencies(ctx context.Context, opts ListChangesetDependenciesOpts) (deps []*btypes.ChangesetDependency, err error) {
+	ctx, _, endObservation := s.operations.listChangesetDependencies.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := listChangesetDependenciesQuery(opts)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var d btypes.ChangesetDependency
+		if err := sc.Scan(&d.BatchChangeID, &d.ChangesetID, &d.DependsOnChangesetID, &d.Gate); err != nil {
+			return err
+		}
+		deps = append(deps, &d)
+		return nil
+	})
+
+	return deps, err
+}
+
+var listChangesetDependenciesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_dependencies.go:ListChangesetDependencies
+SELECT %s FROM changeset_dependencies
+WHERE %s
+ORDER BY changeset_id ASC, depends_on_changeset_id ASC
+`
+
+func listChangesetDependenciesQuery(opts ListChangesetDependenciesOpts) *sqlf.Query {
+	preds := []*sqlf.Query{}
+	if opts.BatchChangeID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_change_id = %s", opts.BatchChangeID))
+	}
+	if opts.ChangesetID != 0 {
+		preds = append(preds, sqlf.Sprintf("changeset_id = %s", opts.ChangesetID))
+	}
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	columns := make([]*sqlf.Query, 0, len(changesetDependencyColumns))
+	for _, c := range changesetDependencyColumns {
+		columns = append(columns, sqlf.Sprintf(c))
+	}
+
+	return sqlf.Sprintf(
+		listChangesetDependenciesQueryFmtstr,
+		sqlf.Join(columns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// ListChangesetDependencyStatuses lists the dependencies of the given
+// changeset along with the external states of the changesets it depends on.
+func (s *Store) ListChangesetDependencyStatuses(ctx context.Context, changesetID int64) (statuses []*btypes.ChangesetDependencyStatus, err error) {
+	ctx, _, endObservation := s.operations.listChangesetDependencyStatuses.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("changesetID", int(changesetID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(listChangesetDependencyStatusesQueryFmtstr, changesetID)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var (
+			d             btypes.ChangesetDependencyStatus
+			externalState string
+		)
+		if err := sc.Scan(
+			&d.BatchChangeID,
+			&d.ChangesetID,
+			&d.DependsOnChangesetID,
+			&d.Gate,
+			&dbutil.NullString{S: &externalState},
+		); err != nil {
+			return err
+		}
+		d.DependsOnExternalState = btypes.ChangesetExternalState(externalState)
+		statuses = append(statuses, &d)
+		return nil
+	})
+
+	return statuses, err
+}
+
+var listChangesetDependencyStatusesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_dependencies.go:ListChangesetDependencyStatuses
+SELECT
+	changeset_dependencies.batch_change_id,
+	changeset_dependencies.changeset_id,
+	changeset_dependencies.depends_on_changeset_id,
+	changeset_dependencies.gate,
+	changesets.external_state
+FROM changeset_dependencies
+JOIN changesets ON changesets.id = changeset_dependencies.depends_on_changeset_id
+WHERE changeset_dependencies.changeset_id = %s
+ORDER BY changeset_dependencies.depends_on_changeset_id ASC
+`
+
+// ListChangesetsWithSettledDependencies lists the changesets that aren't being
+// reconciled, are unpublished or drafts, and whose dependencies have either
+// all been merged, or include one that was closed or deleted without being
+// merged. These changesets were possibly held back by the reconciler and can
+// now be published or undrafted, or have to be marked as failed.
+func (s *Store) ListChangesetsWithSettledDependencies(ctx context.Context) (cs btypes.Changesets, err error) {
+	ctx, _, endObservation := s.operations.listChangesetsWithSettledDependencies.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		listChangesetsWithSettledDependenciesQueryFmtstr,
+		sqlf.Join(changesetColumns, ", "),
+		btypes.ChangesetPublicationStateUnpublished,
+		btypes.ChangesetExternalStateDraft,
+		btypes.ReconcilerStateCompleted.ToDB(),
+		btypes.ChangesetExternalStateMerged,
+		pq.Array([]btypes.ChangesetExternalState{btypes.ChangesetExternalStateClosed, btypes.ChangesetExternalStateDeleted}),
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var c btypes.Changeset
+		if err := scanChangeset(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+
+	return cs, err
+}
+
+var listChangesetsWithSettledDependenciesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_dependencies.go:ListChangesetsWithSettledDependencies
+SELECT %s FROM changesets
+INNER JOIN repo ON repo.id = changesets.repo_id
+WHERE
+	repo.deleted_at IS NULL
+AND
+	(changesets.publication_state = %s OR changesets.external_state = %s)
+AND
+	changesets.reconciler_state = %s
+AND
+	EXISTS (
+		SELECT 1 FROM changeset_dependencies
+		WHERE changeset_dependencies.changeset_id = changesets.id
+	)
+AND
+	(
+		NOT EXISTS (
+			SELECT 1 FROM changeset_dependencies
+			JOIN changesets dependency ON dependency.id = changeset_dependencies.depends_on_changeset_id
+			WHERE
+				changeset_dependencies.changeset_id = changesets.id
+			AND
+				dependency.external_state IS DISTINCT FROM %s
+		)
+		OR
+		EXISTS (
+			SELECT 1 FROM changeset_dependencies
+			JOIN changesets dependency ON dependency.id = changeset_dependencies.depends_on_changeset_id
+			WHERE
+				changeset_dependencies.changeset_id = changesets.id
+			AND
+				dependency.external_state = ANY (%s)
+		)
+	)
+ORDER BY changesets.id ASC
+`
@@ -0,0 +1,139 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func testStoreChangesetDependencies(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	repoStore := database.ReposWith(s)
+	esStore := database.ExternalServicesWith(s)
+
+	repo := ct.TestRepo(t, esStore, extsvc.KindGitHub)
+	if err := repoStore.Create(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	const batchChangeID = 1234
+
+	upstream := ct.CreateChangeset(t, ctx, s, ct.TestChangesetOpts{
+		Repo:             repo.ID,
+		BatchChange:      batchChangeID,
+		PublicationState: btypes.ChangesetPublicationStatePublished,
+		ExternalState:    btypes.ChangesetExternalStateOpen,
+		ReconcilerState:  btypes.ReconcilerStateCompleted,
+	})
+	downstream := ct.CreateChangeset(t, ctx, s, ct.TestChangesetOpts{
+		Repo:             repo.ID,
+		BatchChange:      batchChangeID,
+		PublicationState: btypes.ChangesetPublicationStateUnpublished,
+		ReconcilerState:  btypes.ReconcilerStateCompleted,
+	})
+	independent := ct.CreateChangeset(t, ctx, s, ct.TestChangesetOpts{
+		Repo:             repo.ID,
+		BatchChange:      batchChangeID,
+		PublicationState: btypes.ChangesetPublicationStateUnpublished,
+		ReconcilerState:  btypes.ReconcilerStateCompleted,
+	})
+
+	deps := []*btypes.ChangesetDependency{
+		{BatchChangeID: batchChangeID, ChangesetID: downstream.ID, DependsOnChangesetID: upstream.ID, Gate: btypes.ChangesetDependencyGateMerge},
+	}
+
+	t.Run("Replace", func(t *testing.T) {
+		// Replacing twice must not duplicate the dependencies.
+		for i := 0; i < 2; i++ {
+			if err := s.ReplaceChangesetDependencies(ctx, batchChangeID, deps); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		have, err := s.ListChangesetDependencies(ctx, ListChangesetDependenciesOpts{BatchChangeID: batchChangeID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(deps, have); diff != "" {
+			t.Fatalf("unexpected dependencies (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("List by changeset", func(t *testing.T) {
+		have, err := s.ListChangesetDependencies(ctx, ListChangesetDependenciesOpts{ChangesetID: independent.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("unexpected dependencies: %+v", have)
+		}
+	})
+
+	t.Run("Unmerged dependencies", func(t *testing.T) {
+		statuses, err := s.ListChangesetDependencyStatuses(ctx, downstream.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*btypes.ChangesetDependencyStatus{
+			{ChangesetDependency: *deps[0], DependsOnExternalState: btypes.ChangesetExternalStateOpen},
+		}
+		if diff := cmp.Diff(want, statuses); diff != "" {
+			t.Fatalf("unexpected statuses (-want +have):\n%s", diff)
+		}
+
+		cs, err := s.ListChangesetsWithSettledDependencies(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cs) != 0 {
+			t.Fatalf("unexpected changesets: %+v", cs)
+		}
+	})
+
+	for _, state := range []btypes.ChangesetExternalState{
+		btypes.ChangesetExternalStateClosed,
+		btypes.ChangesetExternalStateMerged,
+	} {
+		t.Run(string(state)+" dependencies", func(t *testing.T) {
+			upstream.ExternalState = state
+			if err := s.UpdateChangeset(ctx, upstream); err != nil {
+				t.Fatal(err)
+			}
+
+			statuses, err := s.ListChangesetDependencyStatuses(ctx, downstream.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(statuses) != 1 || statuses[0].DependsOnExternalState != state {
+				t.Fatalf("unexpected statuses: %+v", statuses)
+			}
+
+			cs, err := s.ListChangesetsWithSettledDependencies(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(cs) != 1 || cs[0].ID != downstream.ID {
+				t.Fatalf("unexpected changesets: %+v", cs)
+			}
+		})
+	}
+
+	t.Run("Replace with empty", func(t *testing.T) {
+		if err := s.ReplaceChangesetDependencies(ctx, batchChangeID, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.ListChangesetDependencies(ctx, ListChangesetDependenciesOpts{BatchChangeID: batchChangeID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("unexpected dependencies: %+v", have)
+		}
+	})
+}
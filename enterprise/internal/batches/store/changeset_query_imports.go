@@ -0,0 +1,86 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// ListChangesetQueryImports returns the IDs of the changesets that are tracked
+// by the given batch change because they matched one of the importChangesets
+// queries of its batch spec.
+func (s *Store) ListChangesetQueryImports(ctx context.Context, batchChangeID int64) (ids []int64, err error) {
+	ctx, _, endObservation := s.operations.listChangesetQueryImports.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(listChangesetQueryImportsQueryFmtstr, batchChangeID)
+	return basestore.ScanInt64s(s.Query(ctx, q))
+}
+
+var listChangesetQueryImportsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_query_imports.go:ListChangesetQueryImports
+SELECT changeset_id FROM changeset_query_imports
+WHERE batch_change_id = %s
+ORDER BY changeset_id ASC
+`
+
+// CreateChangesetQueryImports records that the given changesets are tracked by
+// the batch change because they matched an importChangesets query. Changesets
+// that are already recorded are ignored.
+func (s *Store) CreateChangesetQueryImports(ctx context.Context, batchChangeID int64, changesetIDs []int64) (err error) {
+	ctx, _, endObservation := s.operations.createChangesetQueryImports.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+		log.Int("count", len(changesetIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return batch.WithInserterWithReturn(
+		ctx,
+		s.Handle().DB(),
+		"changeset_query_imports",
+		batch.MaxNumPostgresParameters,
+		[]string{"batch_change_id", "changeset_id", "created_at"},
+		"ON CONFLICT DO NOTHING",
+		nil,
+		nil,
+		func(inserter *batch.Inserter) error {
+			for _, id := range changesetIDs {
+				if err := inserter.Insert(ctx, batchChangeID, id, s.now()); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	)
+}
+
+// DeleteChangesetQueryImports removes the given changesets from the changesets
+// imported by query into the batch change. If changesetIDs is nil, all of
+// them are removed.
+func (s *Store) DeleteChangesetQueryImports(ctx context.Context, batchChangeID int64, changesetIDs []int64) (err error) {
+	ctx, _, endObservation := s.operations.deleteChangesetQueryImports.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+		log.Int("count", len(changesetIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	preds := []*sqlf.Query{sqlf.Sprintf("batch_change_id = %s", batchChangeID)}
+	if changesetIDs != nil {
+		preds = append(preds, sqlf.Sprintf("changeset_id = ANY (%s)", pq.Array(changesetIDs)))
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(deleteChangesetQueryImportsQueryFmtstr, sqlf.Join(preds, "\n AND ")))
+}
+
+var deleteChangesetQueryImportsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_query_imports.go:DeleteChangesetQueryImports
+DELETE FROM changeset_query_imports WHERE %s
+`
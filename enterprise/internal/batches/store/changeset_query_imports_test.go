@@ -0,0 +1,109 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func testStoreChangesetQueryImports(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	repoStore := database.ReposWith(s)
+	esStore := database.ExternalServicesWith(s)
+
+	repo := ct.TestRepo(t, esStore, extsvc.KindGitHub)
+	if err := repoStore.Create(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	user := ct.CreateTestUser(t, s.DatabaseDB(), false)
+
+	querySpec := &btypes.BatchSpec{
+		UserID:          user.ID,
+		NamespaceUserID: user.ID,
+		Spec: &batcheslib.BatchSpec{
+			Name: "query-imports",
+			ImportChangesets: []batcheslib.ImportChangeset{
+				{Query: "is:pr author:renovate label:security"},
+			},
+		},
+	}
+	if err := s.CreateBatchSpec(ctx, querySpec); err != nil {
+		t.Fatal(err)
+	}
+	queryBatchChange := ct.CreateBatchChange(t, ctx, s, "query-imports", user.ID, querySpec.ID)
+
+	otherSpec := ct.CreateBatchSpec(t, ctx, s, "no-query-imports", user.ID)
+	ct.CreateBatchChange(t, ctx, s, "no-query-imports", user.ID, otherSpec.ID)
+
+	changesets := make([]*btypes.Changeset, 0, 3)
+	for i := 0; i < cap(changesets); i++ {
+		changesets = append(changesets, ct.CreateChangeset(t, ctx, s, ct.TestChangesetOpts{
+			Repo:             repo.ID,
+			BatchChange:      queryBatchChange.ID,
+			PublicationState: btypes.ChangesetPublicationStatePublished,
+			ExternalState:    btypes.ChangesetExternalStateOpen,
+		}))
+	}
+
+	t.Run("ListBatchChanges", func(t *testing.T) {
+		have, _, err := s.ListBatchChanges(ctx, ListBatchChangesOpts{OnlyWithImportQueries: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 1 || have[0].ID != queryBatchChange.ID {
+			t.Fatalf("wrong batch changes returned: %+v", have)
+		}
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		// Creating twice must not duplicate the entries.
+		for i := 0; i < 2; i++ {
+			if err := s.CreateChangesetQueryImports(ctx, queryBatchChange.ID, []int64{changesets[0].ID, changesets[1].ID, changesets[2].ID}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		have, err := s.ListChangesetQueryImports(ctx, queryBatchChange.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []int64{changesets[0].ID, changesets[1].ID, changesets[2].ID}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := s.DeleteChangesetQueryImports(ctx, queryBatchChange.ID, []int64{changesets[1].ID}); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.ListChangesetQueryImports(ctx, queryBatchChange.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []int64{changesets[0].ID, changesets[2].ID}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		if err := s.DeleteChangesetQueryImports(ctx, queryBatchChange.ID, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err = s.ListChangesetQueryImports(ctx, queryBatchChange.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("changeset query imports not deleted: %v", have)
+		}
+	})
+}
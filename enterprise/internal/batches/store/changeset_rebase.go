@@ -0,0 +1,62 @@
+package store
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// ListChangesetsToRebase lists the open or draft changesets that were
+// published by a batch change, aren't being reconciled and whose current
+// changeset spec enables autoReapplyDiff. Changesets opened on a fork are not
+// included, since their branch doesn't exist in the target repository.
+func (s *Store) ListChangesetsToRebase(ctx context.Context) (cs btypes.Changesets, err error) {
+	ctx, _, endObservation := s.operations.listChangesetsToRebase.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		listChangesetsToRebaseQueryFmtstr,
+		sqlf.Join(changesetColumns, ", "),
+		btypes.ChangesetPublicationStatePublished,
+		btypes.ReconcilerStateCompleted.ToDB(),
+		btypes.ChangesetExternalStateOpen,
+		btypes.ChangesetExternalStateDraft,
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var c btypes.Changeset
+		if err := scanChangeset(&c, sc); err != nil {
+			return err
+		}
+		cs = append(cs, &c)
+		return nil
+	})
+
+	return cs, err
+}
+
+var listChangesetsToRebaseQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_rebase.go:ListChangesetsToRebase
+SELECT %s FROM changesets
+INNER JOIN repo ON repo.id = changesets.repo_id
+INNER JOIN changeset_specs ON changeset_specs.id = changesets.current_spec_id
+WHERE
+	repo.deleted_at IS NULL
+AND
+	changesets.owned_by_batch_change_id IS NOT NULL
+AND
+	changesets.publication_state = %s
+AND
+	changesets.reconciler_state = %s
+AND
+	changesets.external_state IN (%s, %s)
+AND
+	changesets.external_fork_namespace IS NULL
+AND
+	COALESCE((changeset_specs.spec->>'autoReapplyDiff')::boolean, FALSE)
+ORDER BY changesets.id ASC
+`
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// ListChangesetReviewReminders returns when the given batch change last posted
+// a review reminder on its changesets, keyed by changeset ID.
+func (s *Store) ListChangesetReviewReminders(ctx context.Context, batchChangeID int64) (reminded map[int64]time.Time, err error) {
+	ctx, _, endObservation := s.operations.listChangesetReviewReminders.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	reminded = make(map[int64]time.Time)
+	err = s.query(ctx, sqlf.Sprintf(listChangesetReviewRemindersQueryFmtstr, batchChangeID), func(sc dbutil.Scanner) error {
+		var (
+			id int64
+			at time.Time
+		)
+		if err := sc.Scan(&id, &at); err != nil {
+			return err
+		}
+		reminded[id] = at
+		return nil
+	})
+	return reminded, err
+}
+
+var listChangesetReviewRemindersQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changeset_review_reminders.go:ListChangesetReviewReminders
+SELECT changeset_id, reminded_at FROM changeset_review_reminders
+WHERE batch_change_id = %s
+`
+
+// UpsertChangesetReviewReminders records that the batch change posted a
+// review reminder on the given changesets now.
+func (s *Store) UpsertChangesetReviewReminders(ctx context.Context, batchChangeID int64, changesetIDs []int64) (err error) {
+	ctx, _, endObservation := s.operations.upsertChangesetReviewReminders.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(batchChangeID)),
+		log.Int("count", len(changesetIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return batch.WithInserterWithReturn(
+		ctx,
+		s.Handle().DB(),
+		"changeset_review_reminders",
+		batch.MaxNumPostgresParameters,
+		[]string{"batch_change_id", "changeset_id", "reminded_at"},
+		"ON CONFLICT (batch_change_id, changeset_id) DO UPDATE SET reminded_at = EXCLUDED.reminded_at",
+		nil,
+		nil,
+		func(inserter *batch.Inserter) error {
+			now := s.now()
+			for _, id := range changesetIDs {
+				if err := inserter.Insert(ctx, batchChangeID, id, now); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	)
+}
@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+)
+
+func testStoreChangesetReviewReminders(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	repoStore := database.ReposWith(s)
+	esStore := database.ExternalServicesWith(s)
+
+	repo := ct.TestRepo(t, esStore, extsvc.KindGitHub)
+	if err := repoStore.Create(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	user := ct.CreateTestUser(t, s.DatabaseDB(), false)
+
+	reminderSpec := &btypes.BatchSpec{
+		UserID:          user.ID,
+		NamespaceUserID: user.ID,
+		Spec: &batcheslib.BatchSpec{
+			Name: "review-reminders",
+			ChangesetTemplate: &batcheslib.ChangesetTemplate{
+				ReviewReminder: &batcheslib.ChangesetReviewReminder{AfterDays: 7, Body: "Please review"},
+			},
+		},
+	}
+	if err := s.CreateBatchSpec(ctx, reminderSpec); err != nil {
+		t.Fatal(err)
+	}
+	reminderBatchChange := ct.CreateBatchChange(t, ctx, s, "review-reminders", user.ID, reminderSpec.ID)
+
+	otherSpec := ct.CreateBatchSpec(t, ctx, s, "no-review-reminders", user.ID)
+	ct.CreateBatchChange(t, ctx, s, "no-review-reminders", user.ID, otherSpec.ID)
+
+	changesets := make([]*btypes.Changeset, 0, 2)
+	for i := 0; i < cap(changesets); i++ {
+		changesets = append(changesets, ct.CreateChangeset(t, ctx, s, ct.TestChangesetOpts{
+			Repo:             repo.ID,
+			BatchChange:      reminderBatchChange.ID,
+			PublicationState: btypes.ChangesetPublicationStatePublished,
+			ExternalState:    btypes.ChangesetExternalStateOpen,
+		}))
+	}
+
+	t.Run("ListBatchChanges", func(t *testing.T) {
+		have, _, err := s.ListBatchChanges(ctx, ListBatchChangesOpts{OnlyWithReviewReminders: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 1 || have[0].ID != reminderBatchChange.ID {
+			t.Fatalf("wrong batch changes returned: %+v", have)
+		}
+	})
+
+	t.Run("Upsert", func(t *testing.T) {
+		firstReminder := clock.Now()
+		if err := s.UpsertChangesetReviewReminders(ctx, reminderBatchChange.ID, []int64{changesets[0].ID, changesets[1].ID}); err != nil {
+			t.Fatal(err)
+		}
+
+		// Reminding again must only update the time.
+		secondReminder := clock.Add(24 * time.Hour)
+		if err := s.UpsertChangesetReviewReminders(ctx, reminderBatchChange.ID, []int64{changesets[1].ID}); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.ListChangesetReviewReminders(ctx, reminderBatchChange.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 2 {
+			t.Fatalf("wrong number of reminders: %v", have)
+		}
+		if at := have[changesets[0].ID]; !at.Equal(firstReminder) {
+			t.Fatalf("wrong reminder time. want=%s, have=%s", firstReminder, at)
+		}
+		if at := have[changesets[1].ID]; !at.Equal(secondReminder) {
+			t.Fatalf("wrong reminder time. want=%s, have=%s", secondReminder, at)
+		}
+	})
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -48,6 +49,7 @@ var changesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.external_state"),
 	sqlf.Sprintf("changesets.external_review_state"),
 	sqlf.Sprintf("changesets.external_check_state"),
+	sqlf.Sprintf("changesets.external_checks"),
 	sqlf.Sprintf("changesets.diff_stat_added"),
 	sqlf.Sprintf("changesets.diff_stat_changed"),
 	sqlf.Sprintf("changesets.diff_stat_deleted"),
@@ -66,6 +68,8 @@ var changesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.num_failures"),
 	sqlf.Sprintf("changesets.closing"),
 	sqlf.Sprintf("changesets.syncer_error"),
+	sqlf.Sprintf("changesets.conflict_state"),
+	sqlf.Sprintf("changesets.rebase_base_rev"),
 }
 
 // changesetInsertColumns is the list of changeset columns that are modified in
@@ -85,6 +89,7 @@ var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("external_state"),
 	sqlf.Sprintf("external_review_state"),
 	sqlf.Sprintf("external_check_state"),
+	sqlf.Sprintf("external_checks"),
 	sqlf.Sprintf("diff_stat_added"),
 	sqlf.Sprintf("diff_stat_changed"),
 	sqlf.Sprintf("diff_stat_deleted"),
@@ -103,6 +108,8 @@ var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("num_failures"),
 	sqlf.Sprintf("closing"),
 	sqlf.Sprintf("syncer_error"),
+	sqlf.Sprintf("conflict_state"),
+	sqlf.Sprintf("rebase_base_rev"),
 	// We additionally store the result of changeset.Title() in a column, so
 	// the business logic for determining it is in one place and the field is
 	// indexable for searching.
@@ -120,6 +127,7 @@ var changesetCodeHostStateInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("external_state"),
 	sqlf.Sprintf("external_review_state"),
 	sqlf.Sprintf("external_check_state"),
+	sqlf.Sprintf("external_checks"),
 	sqlf.Sprintf("diff_stat_added"),
 	sqlf.Sprintf("diff_stat_changed"),
 	sqlf.Sprintf("diff_stat_deleted"),
@@ -147,6 +155,11 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		return nil, err
 	}
 
+	externalChecks, err := externalChecksColumn(c)
+	if err != nil {
+		return nil, err
+	}
+
 	// Not being able to find a title is fine, we just have a NULL in the database then.
 	title, _ := c.Title()
 
@@ -168,6 +181,7 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		nullStringColumn(string(c.ExternalState)),
 		nullStringColumn(string(c.ExternalReviewState)),
 		nullStringColumn(string(c.ExternalCheckState)),
+		externalChecks,
 		c.DiffStatAdded,
 		c.DiffStatChanged,
 		c.DiffStatDeleted,
@@ -186,6 +200,8 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		c.NumFailures,
 		c.Closing,
 		c.SyncErrorMessage,
+		nullStringColumn(string(c.ConflictState)),
+		nullStringColumn(c.RebaseBaseRev),
 		nullStringColumn(title),
 	}
 
@@ -230,7 +246,7 @@ func (s *Store) CreateChangeset(ctx context.Context, c *btypes.Changeset) (err e
 var createChangesetQueryFmtstr = `
 -- source: enterprise/internal/batches/store.go:CreateChangeset
 INSERT INTO changesets (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -732,7 +748,7 @@ func (s *Store) UpdateChangeset(ctx context.Context, cs *btypes.Changeset) (err
 var updateChangesetQueryFmtstr = `
 -- source: enterprise/internal/batches/store_changesets.go:UpdateChangeset
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -795,6 +811,39 @@ RETURNING
   %s
 `
 
+// UpdateChangesetConflictState updates only the `conflict_state`,
+// `rebase_base_rev` & `updated_at` columns of the given Changeset.
+func (s *Store) UpdateChangesetConflictState(ctx context.Context, cs *btypes.Changeset) (err error) {
+	ctx, _, endObservation := s.operations.updateChangesetConflictState.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(cs.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	cs.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		updateChangesetConflictStateQueryFmtstr,
+		cs.UpdatedAt,
+		nullStringColumn(string(cs.ConflictState)),
+		nullStringColumn(cs.RebaseBaseRev),
+		cs.ID,
+		sqlf.Join(changesetColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) (err error) {
+		return scanChangeset(cs, sc)
+	})
+}
+
+var updateChangesetConflictStateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/changesets.go:UpdateChangesetConflictState
+UPDATE changesets
+SET (updated_at, conflict_state, rebase_base_rev) = (%s, %s, %s)
+WHERE id = %s
+RETURNING
+  %s
+`
+
 // UpdateChangesetCodeHostState updates only the columns of the given Changeset
 // that relate to the state of the changeset on the code host, e.g.
 // external_branch, external_state, etc.
@@ -827,6 +876,11 @@ func updateChangesetCodeHostStateQuery(c *btypes.Changeset) (*sqlf.Query, error)
 		return nil, err
 	}
 
+	externalChecks, err := externalChecksColumn(c)
+	if err != nil {
+		return nil, err
+	}
+
 	// Not being able to find a title is fine, we just have a NULL in the database then.
 	title, _ := c.Title()
 
@@ -841,6 +895,7 @@ func updateChangesetCodeHostStateQuery(c *btypes.Changeset) (*sqlf.Query, error)
 		nullStringColumn(string(c.ExternalState)),
 		nullStringColumn(string(c.ExternalReviewState)),
 		nullStringColumn(string(c.ExternalCheckState)),
+		externalChecks,
 		c.DiffStatAdded,
 		c.DiffStatChanged,
 		c.DiffStatDeleted,
@@ -857,7 +912,7 @@ func updateChangesetCodeHostStateQuery(c *btypes.Changeset) (*sqlf.Query, error)
 var updateChangesetCodeHostStateQueryFmtstr = `
 -- source: enterprise/internal/batches/store/changesets.go:UpdateChangesetCodeHostState
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -1126,7 +1181,7 @@ func (n jsonBatchChangeChangesetSet) Value() (driver.Value, error) {
 }
 
 func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
-	var metadata, syncState json.RawMessage
+	var metadata, syncState, externalChecks json.RawMessage
 
 	var (
 		externalState       string
@@ -1135,6 +1190,7 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		failureMessage      string
 		syncErrorMessage    string
 		reconcilerState     string
+		conflictState       string
 	)
 	err := s.Scan(
 		&t.ID,
@@ -1152,6 +1208,7 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		&dbutil.NullString{S: &externalState},
 		&dbutil.NullString{S: &externalReviewState},
 		&dbutil.NullString{S: &externalCheckState},
+		&externalChecks,
 		&t.DiffStatAdded,
 		&t.DiffStatChanged,
 		&t.DiffStatDeleted,
@@ -1170,6 +1227,8 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		&t.NumFailures,
 		&t.Closing,
 		&dbutil.NullString{S: &syncErrorMessage},
+		&dbutil.NullString{S: &conflictState},
+		&dbutil.NullString{S: &t.RebaseBaseRev},
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset")
@@ -1178,6 +1237,7 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 	t.ExternalState = btypes.ChangesetExternalState(externalState)
 	t.ExternalReviewState = btypes.ChangesetReviewState(externalReviewState)
 	t.ExternalCheckState = btypes.ChangesetCheckState(externalCheckState)
+	t.ConflictState = btypes.ChangesetConflictState(conflictState)
 	if failureMessage != "" {
 		t.FailureMessage = &failureMessage
 	}
@@ -1195,6 +1255,8 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 		t.Metadata = new(gitlab.MergeRequest)
 	case extsvc.TypeBitbucketCloud:
 		t.Metadata = new(bbcs.AnnotatedPullRequest)
+	case extsvc.TypeAWSCodeCommit:
+		t.Metadata = new(awscodecommit.PullRequest)
 	default:
 		return errors.New("unknown external service type")
 	}
@@ -1205,6 +1267,15 @@ func scanChangeset(t *btypes.Changeset, s dbutil.Scanner) error {
 	if err = json.Unmarshal(syncState, &t.SyncState); err != nil {
 		return errors.Wrapf(err, "scanChangeset: failed to unmarshal sync state: %s", syncState)
 	}
+	var checks []btypes.ChangesetCheck
+	if err = json.Unmarshal(externalChecks, &checks); err != nil {
+		return errors.Wrapf(err, "scanChangeset: failed to unmarshal external checks: %s", externalChecks)
+	}
+	if len(checks) > 0 {
+		t.ExternalChecks = checks
+	} else {
+		t.ExternalChecks = nil
+	}
 
 	return nil
 }
@@ -1459,6 +1530,13 @@ func batchChangesColumn(c *btypes.Changeset) ([]byte, error) {
 	return json.Marshal(assocsAsMap)
 }
 
+func externalChecksColumn(c *btypes.Changeset) ([]byte, error) {
+	if len(c.ExternalChecks) == 0 {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(c.ExternalChecks)
+}
+
 func uiPublicationStateColumn(c *btypes.Changeset) *string {
 	var uiPublicationState *string
 	if state := c.UiPublicationState; state != nil {
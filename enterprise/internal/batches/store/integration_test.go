@@ -33,11 +33,18 @@ func TestIntegration(t *testing.T) {
 		t.Run("CodeHosts", storeTest(db, nil, testStoreCodeHost))
 		t.Run("UserDeleteCascades", storeTest(db, nil, testUserDeleteCascades))
 		t.Run("ChangesetJobs", storeTest(db, nil, testStoreChangesetJobs))
+		t.Run("ChangesetDependencies", storeTest(db, nil, testStoreChangesetDependencies))
+		t.Run("ChangesetChecks", storeTest(db, nil, testStoreChangesetChecks))
+		t.Run("MergeTrains", storeTest(db, nil, testStoreMergeTrains))
+		t.Run("ChangesetQueryImports", storeTest(db, nil, testStoreChangesetQueryImports))
+		t.Run("ChangesetReviewReminders", storeTest(db, nil, testStoreChangesetReviewReminders))
 		t.Run("BulkOperations", storeTest(db, nil, testStoreBulkOperations))
 		t.Run("BatchSpecWorkspaces", storeTest(db, nil, testStoreBatchSpecWorkspaces))
 		t.Run("BatchSpecWorkspaceExecutionJobs", storeTest(db, nil, testStoreBatchSpecWorkspaceExecutionJobs))
 		t.Run("BatchSpecResolutionJobs", storeTest(db, nil, testStoreBatchSpecResolutionJobs))
 		t.Run("BatchSpecExecutionCacheEntries", storeTest(db, nil, testStoreBatchSpecExecutionCacheEntries))
+		t.Run("BatchStepCacheEntries", storeTest(db, nil, testStoreBatchStepCacheEntries))
+		t.Run("BatchSpecWorkspaceArtifacts", storeTest(db, nil, testStoreBatchSpecWorkspaceArtifacts))
 
 		for name, key := range map[string]encryption.Key{
 			"no key":   nil,
@@ -0,0 +1,430 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// mergeTrainColumns are used by the merge train related Store methods to
+// query merge trains.
+var mergeTrainColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_change_merge_trains.id"),
+	sqlf.Sprintf("batch_change_merge_trains.batch_change_id"),
+	sqlf.Sprintf("batch_change_merge_trains.user_id"),
+	sqlf.Sprintf("batch_change_merge_trains.state"),
+	sqlf.Sprintf("batch_change_merge_trains.changesets_per_wave"),
+	sqlf.Sprintf("batch_change_merge_trains.wave_interval_seconds"),
+	sqlf.Sprintf("batch_change_merge_trains.squash"),
+	sqlf.Sprintf("batch_change_merge_trains.health_check_query"),
+	sqlf.Sprintf("batch_change_merge_trains.health_check_url"),
+	sqlf.Sprintf("batch_change_merge_trains.paused_reason"),
+	sqlf.Sprintf("batch_change_merge_trains.last_wave_at"),
+	sqlf.Sprintf("batch_change_merge_trains.resumed_at"),
+	sqlf.Sprintf("batch_change_merge_trains.created_at"),
+	sqlf.Sprintf("batch_change_merge_trains.updated_at"),
+}
+
+// CreateMergeTrain creates the given merge train, which merges the changesets
+// with the given IDs.
+func (s *Store) CreateMergeTrain(ctx context.Context, t *btypes.MergeTrain, changesetIDs []int64) (err error) {
+	ctx, _, endObservation := s.operations.createMergeTrain.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("batchChangeID", int(t.BatchChangeID)),
+		log.Int("count", len(changesetIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = s.now()
+	}
+	if t.UpdatedAt.IsZero() {
+		t.UpdatedAt = t.CreatedAt
+	}
+	if t.State == "" {
+		t.State = btypes.MergeTrainStateRunning
+	}
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	q := sqlf.Sprintf(
+		createMergeTrainQueryFmtstr,
+		t.BatchChangeID,
+		t.UserID,
+		t.State,
+		t.ChangesetsPerWave,
+		int32(t.WaveInterval/time.Second),
+		t.Squash,
+		nullStringColumn(t.HealthCheckQuery),
+		nullStringColumn(t.HealthCheckURL),
+		nullStringColumn(t.PausedReason),
+		nullTimeColumn(t.LastWaveAt),
+		nullTimeColumn(t.ResumedAt),
+		t.CreatedAt,
+		t.UpdatedAt,
+		sqlf.Join(mergeTrainColumns, ", "),
+	)
+	if err := tx.query(ctx, q, func(sc dbutil.Scanner) error { return scanMergeTrain(t, sc) }); err != nil {
+		return err
+	}
+
+	return batch.WithInserter(
+		ctx,
+		tx.Handle().DB(),
+		"batch_change_merge_train_changesets",
+		batch.MaxNumPostgresParameters,
+		[]string{"merge_train_id", "changeset_id"},
+		func(inserter *batch.Inserter) error {
+			for _, id := range changesetIDs {
+				if err := inserter.Insert(ctx, t.ID, id); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	)
+}
+
+var createMergeTrainQueryFmtstr = `
+-- source: enterprise/internal/batches/store/merge_trains.go:CreateMergeTrain
+INSERT INTO batch_change_merge_trains (
+	batch_change_id,
+	user_id,
+	state,
+	changesets_per_wave,
+	wave_interval_seconds,
+	squash,
+	health_check_query,
+	health_check_url,
+	paused_reason,
+	last_wave_at,
+	resumed_at,
+	created_at,
+	updated_at
+)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING %s
+`
+
+// GetMergeTrain gets the merge train with the given ID.
+func (s *Store) GetMergeTrain(ctx context.Context, id int64) (t *btypes.MergeTrain, err error) {
+	ctx, _, endObservation := s.operations.getMergeTrain.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(id)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		getMergeTrainQueryFmtstr,
+		sqlf.Join(mergeTrainColumns, ", "),
+		id,
+	)
+
+	var train btypes.MergeTrain
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error { return scanMergeTrain(&train, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if train.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &train, nil
+}
+
+var getMergeTrainQueryFmtstr = `
+-- source: enterprise/internal/batches/store/merge_trains.go:GetMergeTrain
+SELECT %s FROM batch_change_merge_trains
+WHERE id = %s
+LIMIT 1
+`
+
+// ListMergeTrainsOpts captures the query options needed for listing merge
+// trains.
+type ListMergeTrainsOpts struct {
+	BatchChangeID int64
+	States        []btypes.MergeTrainState
+}
+
+// ListMergeTrains lists the merge trains matching the given options, newest
+// first.
+func (s *Store) ListMergeTrains(ctx context.Context, opts ListMergeTrainsOpts) (ts []*btypes.MergeTrain, err error) {
+	ctx, _, endObservation := s.operations.listMergeTrains.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	q := listMergeTrainsQuery(opts)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var t btypes.MergeTrain
+		if err := scanMergeTrain(&t, sc); err != nil {
+			return err
+		}
+		ts = append(ts, &t)
+		return nil
+	})
+
+	return ts, err
+}
+
+var listMergeTrainsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/merge_trains.go:ListMergeTrains
+SELECT %s FROM batch_change_merge_trains
+WHERE %s
+ORDER BY id DESC
+`
+
+func listMergeTrainsQuery(opts ListMergeTrainsOpts) *sqlf.Query {
+	preds := []*sqlf.Query{}
+	if opts.BatchChangeID != 0 {
+		preds = append(preds, sqlf.Sprintf("batch_change_merge_trains.batch_change_id = %s", opts.BatchChangeID))
+	}
+	if len(opts.States) > 0 {
+		states := make([]string, 0, len(opts.States))
+		for _, st := range opts.States {
+			states = append(states, string(st))
+		}
+		preds = append(preds, sqlf.Sprintf("batch_change_merge_trains.state = ANY(%s)", pq.Array(states)))
+	}
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return sqlf.Sprintf(
+		listMergeTrainsQueryFmtstr,
+		sqlf.Join(mergeTrainColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// UpdateMergeTrain updates the state, paused reason and timestamps of the
+// given merge train.
+func (s *Store) UpdateMergeTrain(ctx context.Context, t *btypes.MergeTrain) (err error) {
+	ctx, _, endObservation := s.operations.updateMergeTrain.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("ID", int(t.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	t.UpdatedAt = s.now()
+
+	q := sqlf.Sprintf(
+		updateMergeTrainQueryFmtstr,
+		t.State,
+		nullStringColumn(t.PausedReason),
+		nullTimeColumn(t.LastWaveAt),
+		nullTimeColumn(t.ResumedAt),
+		t.UpdatedAt,
+		t.ID,
+		sqlf.Join(mergeTrainColumns, ", "),
+	)
+
+	return s.query(ctx, q, func(sc dbutil.Scanner) error { return scanMergeTrain(t, sc) })
+}
+
+var updateMergeTrainQueryFmtstr = `
+-- source: enterprise/internal/batches/store/merge_trains.go:UpdateMergeTrain
+UPDATE batch_change_merge_trains
+SET
+	state = %s,
+	paused_reason = %s,
+	last_wave_at = %s,
+	resumed_at = %s,
+	updated_at = %s
+WHERE id = %s
+RETURNING %s
+`
+
+// ListMergeTrainWaves lists the waves the given merge train has started so
+// far, oldest first.
+func (s *Store) ListMergeTrainWaves(ctx context.Context, mergeTrainID int64) (ws []*btypes.MergeTrainWave, err error) {
+	ctx, _, endObservation := s.operations.listMergeTrainWaves.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("mergeTrainID", int(mergeTrainID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(listMergeTrainWavesQueryFmtstr, mergeTrainID)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		w := btypes.MergeTrainWave{MergeTrainID: mergeTrainID}
+		if err := sc.Scan(&w.Number, &w.BulkGroup, &w.ChangesetCount); err != nil {
+			return err
+		}
+		ws = append(ws, &w)
+		return nil
+	})
+
+	return ws, err
+}
+
+var listMergeTrainWavesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/merge_trains.go:ListMergeTrainWaves
+SELECT wave, bulk_group, COUNT(*)
+FROM batch_change_merge_train_changesets
+WHERE merge_train_id = %s AND wave IS NOT NULL
+GROUP BY wave, bulk_group
+ORDER BY wave ASC
+`
+
+// CountPendingMergeTrainChangesets returns the number of open changesets of
+// the given merge train that haven't been merged in a wave yet, and how many of
+// them the reconciler failed to process.
+func (s *Store) CountPendingMergeTrainChangesets(ctx context.Context, mergeTrainID int64) (pending, failed int, err error) {
+	ctx, _, endObservation := s.operations.countPendingMergeTrainChangesets.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("mergeTrainID", int(mergeTrainID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		countPendingMergeTrainChangesetsQueryFmtstr,
+		btypes.ReconcilerStateFailed.ToDB(),
+		mergeTrainID,
+		btypes.ChangesetPublicationStatePublished,
+		btypes.ChangesetExternalStateOpen,
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		return sc.Scan(&pending, &failed)
+	})
+
+	return pending, failed, err
+}
+
+var countPendingMergeTrainChangesetsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/merge_trains.go:CountPendingMergeTrainChangesets
+SELECT
+	COUNT(*),
+	COUNT(*) FILTER (WHERE changesets.reconciler_state = %s)
+FROM batch_change_merge_train_changesets
+JOIN changesets ON changesets.id = batch_change_merge_train_changesets.changeset_id
+JOIN repo ON repo.id = changesets.repo_id
+WHERE
+	batch_change_merge_train_changesets.merge_train_id = %s
+AND
+	batch_change_merge_train_changesets.wave IS NULL
+AND
+	repo.deleted_at IS NULL
+AND
+	changesets.publication_state = %s
+AND
+	changesets.external_state = %s
+`
+
+// ListNextMergeTrainWaveChangesets returns the IDs of the changesets that
+// should be merged in the next wave of the given merge train: at most
+// ChangesetsPerWave of the pending open changesets per code host.
+func (s *Store) ListNextMergeTrainWaveChangesets(ctx context.Context, t *btypes.MergeTrain) (ids []int64, err error) {
+	ctx, _, endObservation := s.operations.listNextMergeTrainWaveChangesets.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("mergeTrainID", int(t.ID)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	q := sqlf.Sprintf(
+		listNextMergeTrainWaveChangesetsQueryFmtstr,
+		t.ID,
+		btypes.ChangesetPublicationStatePublished,
+		btypes.ChangesetExternalStateOpen,
+		btypes.ReconcilerStateCompleted.ToDB(),
+		t.ChangesetsPerWave,
+	)
+
+	err = s.query(ctx, q, func(sc dbutil.Scanner) error {
+		var id int64
+		if err := sc.Scan(&id); err != nil {
+			return err
+		}
+		ids = append(ids, id)
+		return nil
+	})
+
+	return ids, err
+}
+
+var listNextMergeTrainWaveChangesetsQueryFmtstr = `
+-- source: enterprise/internal/batches/store/merge_trains.go:ListNextMergeTrainWaveChangesets
+SELECT id FROM (
+	SELECT
+		changesets.id,
+		ROW_NUMBER() OVER (
+			PARTITION BY repo.external_service_type, repo.external_service_id
+			ORDER BY changesets.id ASC
+		) AS position
+	FROM batch_change_merge_train_changesets
+	JOIN changesets ON changesets.id = batch_change_merge_train_changesets.changeset_id
+	JOIN repo ON repo.id = changesets.repo_id
+	WHERE
+		batch_change_merge_train_changesets.merge_train_id = %s
+	AND
+		batch_change_merge_train_changesets.wave IS NULL
+	AND
+		repo.deleted_at IS NULL
+	AND
+		changesets.publication_state = %s
+	AND
+		changesets.external_state = %s
+	AND
+		changesets.reconciler_state = %s
+) AS candidates
+WHERE position <= %s
+ORDER BY id ASC
+`
+
+// MarkMergeTrainWaveStarted records that the changesets with the given IDs
+// are merged in the given wave of the merge train, by the changeset jobs with
+// the given bulk group.
+func (s *Store) MarkMergeTrainWaveStarted(ctx context.Context, mergeTrainID int64, wave int32, bulkGroup string, changesetIDs []int64) (err error) {
+	ctx, _, endObservation := s.operations.markMergeTrainWaveStarted.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("mergeTrainID", int(mergeTrainID)),
+		log.Int("wave", int(wave)),
+		log.Int("count", len(changesetIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		markMergeTrainWaveStartedQueryFmtstr,
+		wave,
+		bulkGroup,
+		mergeTrainID,
+		pq.Array(changesetIDs),
+	))
+}
+
+var markMergeTrainWaveStartedQueryFmtstr = `
+-- source: enterprise/internal/batches/store/merge_trains.go:MarkMergeTrainWaveStarted
+UPDATE batch_change_merge_train_changesets
+SET wave = %s, bulk_group = %s
+WHERE merge_train_id = %s AND changeset_id = ANY(%s)
+`
+
+func scanMergeTrain(t *btypes.MergeTrain, s dbutil.Scanner) error {
+	var waveIntervalSeconds int32
+	if err := s.Scan(
+		&t.ID,
+		&t.BatchChangeID,
+		&t.UserID,
+		&t.State,
+		&t.ChangesetsPerWave,
+		&waveIntervalSeconds,
+		&t.Squash,
+		&dbutil.NullString{S: &t.HealthCheckQuery},
+		&dbutil.NullString{S: &t.HealthCheckURL},
+		&dbutil.NullString{S: &t.PausedReason},
+		&dbutil.NullTime{Time: &t.LastWaveAt},
+		&dbutil.NullTime{Time: &t.ResumedAt},
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	); err != nil {
+		return err
+	}
+	t.WaveInterval = time.Duration(waveIntervalSeconds) * time.Second
+	return nil
+}
@@ -0,0 +1,194 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func testStoreMergeTrains(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	repoStore := database.ReposWith(s)
+	esStore := database.ExternalServicesWith(s)
+
+	user := ct.CreateTestUser(t, s.DatabaseDB(), false)
+	spec := ct.CreateBatchSpec(t, ctx, s, "merge-trains", user.ID)
+	batchChange := ct.CreateBatchChange(t, ctx, s, "merge-trains", user.ID, spec.ID)
+
+	githubRepo := ct.TestRepo(t, esStore, extsvc.KindGitHub)
+	gitlabRepo := ct.TestRepo(t, esStore, extsvc.KindGitLab)
+	for _, r := range []*types.Repo{githubRepo, gitlabRepo} {
+		if err := repoStore.Create(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	createChangeset := func(repoID api.RepoID, state btypes.ChangesetExternalState) *btypes.Changeset {
+		return ct.CreateChangeset(t, ctx, s, ct.TestChangesetOpts{
+			Repo:             repoID,
+			BatchChange:      batchChange.ID,
+			PublicationState: btypes.ChangesetPublicationStatePublished,
+			ExternalState:    state,
+			ReconcilerState:  btypes.ReconcilerStateCompleted,
+		})
+	}
+
+	github1 := createChangeset(githubRepo.ID, btypes.ChangesetExternalStateOpen)
+	github2 := createChangeset(githubRepo.ID, btypes.ChangesetExternalStateOpen)
+	gitlab1 := createChangeset(gitlabRepo.ID, btypes.ChangesetExternalStateOpen)
+	closed := createChangeset(gitlabRepo.ID, btypes.ChangesetExternalStateClosed)
+
+	train := &btypes.MergeTrain{
+		BatchChangeID:     batchChange.ID,
+		UserID:            user.ID,
+		ChangesetsPerWave: 1,
+		WaveInterval:      30 * time.Minute,
+		HealthCheckURL:    "https://example.com/health",
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		if err := s.CreateMergeTrain(ctx, train, []int64{github1.ID, github2.ID, gitlab1.ID, closed.ID}); err != nil {
+			t.Fatal(err)
+		}
+		if train.ID == 0 {
+			t.Fatal("merge train has no ID")
+		}
+		if have, want := train.State, btypes.MergeTrainStateRunning; have != want {
+			t.Fatalf("unexpected state: have=%q want=%q", have, want)
+		}
+
+		// Only one active merge train is allowed per batch change.
+		if err := s.CreateMergeTrain(ctx, &btypes.MergeTrain{
+			BatchChangeID:     batchChange.ID,
+			UserID:            user.ID,
+			ChangesetsPerWave: 1,
+		}, nil); err == nil {
+			t.Fatal("unexpectedly created a second active merge train")
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		have, err := s.GetMergeTrain(ctx, train.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(train, have); diff != "" {
+			t.Fatalf("unexpected merge train (-want +have):\n%s", diff)
+		}
+
+		if _, err := s.GetMergeTrain(ctx, train.ID+1000); err != ErrNoResults {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		have, err := s.ListMergeTrains(ctx, ListMergeTrainsOpts{
+			BatchChangeID: batchChange.ID,
+			States:        []btypes.MergeTrainState{btypes.MergeTrainStateRunning},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]*btypes.MergeTrain{train}, have); diff != "" {
+			t.Fatalf("unexpected merge trains (-want +have):\n%s", diff)
+		}
+
+		have, err = s.ListMergeTrains(ctx, ListMergeTrainsOpts{
+			States: []btypes.MergeTrainState{btypes.MergeTrainStatePaused},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 0 {
+			t.Fatalf("unexpected merge trains: %+v", have)
+		}
+	})
+
+	t.Run("Waves", func(t *testing.T) {
+		pending, failed, err := s.CountPendingMergeTrainChangesets(ctx, train.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := pending, 3; have != want {
+			t.Fatalf("unexpected pending count: have=%d want=%d", have, want)
+		}
+		if have, want := failed, 0; have != want {
+			t.Fatalf("unexpected failed count: have=%d want=%d", have, want)
+		}
+
+		// One changeset per code host.
+		ids, err := s.ListNextMergeTrainWaveChangesets(ctx, train)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int64{github1.ID, gitlab1.ID}, ids); diff != "" {
+			t.Fatalf("unexpected wave changesets (-want +have):\n%s", diff)
+		}
+
+		if err := s.MarkMergeTrainWaveStarted(ctx, train.ID, 1, "bulk-1", ids); err != nil {
+			t.Fatal(err)
+		}
+
+		ids, err = s.ListNextMergeTrainWaveChangesets(ctx, train)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]int64{github2.ID}, ids); diff != "" {
+			t.Fatalf("unexpected wave changesets (-want +have):\n%s", diff)
+		}
+
+		waves, err := s.ListMergeTrainWaves(ctx, train.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []*btypes.MergeTrainWave{
+			{MergeTrainID: train.ID, Number: 1, BulkGroup: "bulk-1", ChangesetCount: 2},
+		}
+		if diff := cmp.Diff(want, waves); diff != "" {
+			t.Fatalf("unexpected waves (-want +have):\n%s", diff)
+		}
+
+		// Changesets the reconciler failed to process are still pending, but
+		// not merged.
+		ct.SetChangesetFailed(t, ctx, s, github2)
+		pending, failed, err = s.CountPendingMergeTrainChangesets(ctx, train.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pending != 1 || failed != 1 {
+			t.Fatalf("unexpected counts: pending=%d failed=%d, want 1 and 1", pending, failed)
+		}
+		ids, err = s.ListNextMergeTrainWaveChangesets(ctx, train)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ids) != 0 {
+			t.Fatalf("unexpected wave changesets: %v", ids)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		train.State = btypes.MergeTrainStatePaused
+		train.PausedReason = "health check failed"
+		train.LastWaveAt = clock.Now()
+		if err := s.UpdateMergeTrain(ctx, train); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.GetMergeTrain(ctx, train.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(train, have); diff != "" {
+			t.Fatalf("unexpected merge train (-want +have):\n%s", diff)
+		}
+	})
+}
@@ -226,6 +226,7 @@ type operations struct {
 	updateChangesetBatchChanges       *observation.Operation
 	updateChangesetUIPublicationState *observation.Operation
 	updateChangesetCodeHostState      *observation.Operation
+	updateChangesetConflictState      *observation.Operation
 	getChangesetExternalIDs           *observation.Operation
 	cancelQueuedBatchChangeChangesets *observation.Operation
 	enqueueChangesetsToClose          *observation.Operation
@@ -233,6 +234,29 @@ type operations struct {
 	getRepoChangesetsStats            *observation.Operation
 	enqueueNextScheduledChangeset     *observation.Operation
 	getChangesetPlaceInSchedulerQueue *observation.Operation
+	getBatchChangeCheckStats          *observation.Operation
+
+	replaceChangesetDependencies          *observation.Operation
+	listChangesetDependencies             *observation.Operation
+	listChangesetDependencyStatuses       *observation.Operation
+	listChangesetsWithSettledDependencies *observation.Operation
+	listChangesetsToRebase                *observation.Operation
+
+	createMergeTrain                 *observation.Operation
+	getMergeTrain                    *observation.Operation
+	listMergeTrains                  *observation.Operation
+	updateMergeTrain                 *observation.Operation
+	listMergeTrainWaves              *observation.Operation
+	countPendingMergeTrainChangesets *observation.Operation
+	listNextMergeTrainWaveChangesets *observation.Operation
+	markMergeTrainWaveStarted        *observation.Operation
+
+	listChangesetQueryImports   *observation.Operation
+	createChangesetQueryImports *observation.Operation
+	deleteChangesetQueryImports *observation.Operation
+
+	listChangesetReviewReminders   *observation.Operation
+	upsertChangesetReviewReminders *observation.Operation
 
 	listCodeHosts         *observation.Operation
 	getExternalServiceIDs *observation.Operation
@@ -249,6 +273,10 @@ type operations struct {
 	countBatchSpecWorkspaces       *observation.Operation
 	markSkippedBatchSpecWorkspaces *observation.Operation
 
+	createBatchSpecWorkspaceArtifacts *observation.Operation
+	listBatchChangeArtifacts          *observation.Operation
+	countBatchChangeArtifacts         *observation.Operation
+
 	createBatchSpecWorkspaceExecutionJobs              *observation.Operation
 	createBatchSpecWorkspaceExecutionJobsForWorkspaces *observation.Operation
 	getBatchSpecWorkspaceExecutionJob                  *observation.Operation
@@ -268,6 +296,11 @@ type operations struct {
 	markUsedBatchSpecExecutionCacheEntries *observation.Operation
 	createBatchSpecExecutionCacheEntry     *observation.Operation
 	cleanBatchSpecExecutionCacheEntries    *observation.Operation
+
+	createBatchStepCacheEntry     *observation.Operation
+	listBatchStepCacheEntries     *observation.Operation
+	markUsedBatchStepCacheEntries *observation.Operation
+	cleanBatchStepCacheEntries    *observation.Operation
 }
 
 var (
@@ -359,6 +392,7 @@ func newOperations(observationContext *observation.Context) *operations {
 			updateChangesetBatchChanges:       op("UpdateChangesetBatchChanges"),
 			updateChangesetUIPublicationState: op("UpdateChangesetUIPublicationState"),
 			updateChangesetCodeHostState:      op("UpdateChangesetCodeHostState"),
+			updateChangesetConflictState:      op("UpdateChangesetConflictState"),
 			getChangesetExternalIDs:           op("GetChangesetExternalIDs"),
 			cancelQueuedBatchChangeChangesets: op("CancelQueuedBatchChangeChangesets"),
 			enqueueChangesetsToClose:          op("EnqueueChangesetsToClose"),
@@ -366,6 +400,29 @@ func newOperations(observationContext *observation.Context) *operations {
 			getRepoChangesetsStats:            op("GetRepoChangesetsStats"),
 			enqueueNextScheduledChangeset:     op("EnqueueNextScheduledChangeset"),
 			getChangesetPlaceInSchedulerQueue: op("GetChangesetPlaceInSchedulerQueue"),
+			getBatchChangeCheckStats:          op("GetBatchChangeCheckStats"),
+
+			replaceChangesetDependencies:          op("ReplaceChangesetDependencies"),
+			listChangesetDependencies:             op("ListChangesetDependencies"),
+			listChangesetDependencyStatuses:       op("ListChangesetDependencyStatuses"),
+			listChangesetsWithSettledDependencies: op("ListChangesetsWithSettledDependencies"),
+			listChangesetsToRebase:                op("ListChangesetsToRebase"),
+
+			createMergeTrain:                 op("CreateMergeTrain"),
+			getMergeTrain:                    op("GetMergeTrain"),
+			listMergeTrains:                  op("ListMergeTrains"),
+			updateMergeTrain:                 op("UpdateMergeTrain"),
+			listMergeTrainWaves:              op("ListMergeTrainWaves"),
+			countPendingMergeTrainChangesets: op("CountPendingMergeTrainChangesets"),
+			listNextMergeTrainWaveChangesets: op("ListNextMergeTrainWaveChangesets"),
+			markMergeTrainWaveStarted:        op("MarkMergeTrainWaveStarted"),
+
+			listChangesetQueryImports:   op("ListChangesetQueryImports"),
+			createChangesetQueryImports: op("CreateChangesetQueryImports"),
+			deleteChangesetQueryImports: op("DeleteChangesetQueryImports"),
+
+			listChangesetReviewReminders:   op("ListChangesetReviewReminders"),
+			upsertChangesetReviewReminders: op("UpsertChangesetReviewReminders"),
 
 			listCodeHosts:         op("ListCodeHosts"),
 			getExternalServiceIDs: op("GetExternalServiceIDs"),
@@ -382,6 +439,10 @@ func newOperations(observationContext *observation.Context) *operations {
 			countBatchSpecWorkspaces:       op("CountBatchSpecWorkspaces"),
 			markSkippedBatchSpecWorkspaces: op("MarkSkippedBatchSpecWorkspaces"),
 
+			createBatchSpecWorkspaceArtifacts: op("CreateBatchSpecWorkspaceArtifacts"),
+			listBatchChangeArtifacts:          op("ListBatchChangeArtifacts"),
+			countBatchChangeArtifacts:         op("CountBatchChangeArtifacts"),
+
 			createBatchSpecWorkspaceExecutionJobs:              op("CreateBatchSpecWorkspaceExecutionJobs"),
 			createBatchSpecWorkspaceExecutionJobsForWorkspaces: op("CreateBatchSpecWorkspaceExecutionJobsForWorkspaces"),
 			getBatchSpecWorkspaceExecutionJob:                  op("GetBatchSpecWorkspaceExecutionJob"),
@@ -402,6 +463,11 @@ func newOperations(observationContext *observation.Context) *operations {
 			createBatchSpecExecutionCacheEntry:     op("CreateBatchSpecExecutionCacheEntry"),
 
 			cleanBatchSpecExecutionCacheEntries: op("CleanBatchSpecExecutionCacheEntries"),
+
+			createBatchStepCacheEntry:     op("CreateBatchStepCacheEntry"),
+			listBatchStepCacheEntries:     op("ListBatchStepCacheEntries"),
+			markUsedBatchStepCacheEntries: op("MarkUsedBatchStepCacheEntries"),
+			cleanBatchStepCacheEntries:    op("CleanBatchStepCacheEntries"),
 		}
 	})
 
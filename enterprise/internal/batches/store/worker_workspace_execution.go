@@ -12,16 +12,21 @@ import (
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/codeowners"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	codeownerslib "github.com/sourcegraph/sourcegraph/lib/batches/codeowners"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution/cache"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -245,6 +250,27 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 		}
 	}
 
+	globalStepResults, err := globalStepCacheEntries(batchSpec, workspace, repo, stepResults)
+	if err != nil {
+		return rollbackAndMarkFailed(err, fmt.Sprintf("failed to build step cache entries: %s", err))
+	}
+	for _, entry := range globalStepResults {
+		if err := tx.CreateBatchStepCacheEntry(ctx, entry); err != nil {
+			return rollbackAndMarkFailed(err, fmt.Sprintf("failed to save step cache entry: %s", err))
+		}
+	}
+
+	// Workspaces that were split off for a code owner only get the changes to
+	// the files of the owner, so we need the CODEOWNERS rules to build their
+	// changeset specs.
+	var codeOwners *codeownerslib.Ruleset
+	if workspace.Owner != "" {
+		codeOwners, err = codeowners.Load(ctx, tx.DatabaseDB(), repo.Name, api.CommitID(workspace.Commit))
+		if err != nil {
+			return rollbackAndMarkFailed(err, fmt.Sprintf("failed to load CODEOWNERS: %s", err))
+		}
+	}
+
 	changesetSpecIDs := []int64{}
 	for _, entry := range executionResults {
 		// Store the cache entry.
@@ -259,6 +285,13 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 			return rollbackAndMarkFailed(err, fmt.Sprintf("failed to parse cache entry: %s", err))
 		}
 
+		if len(executionResult.Artifacts) > 0 {
+			artifacts := btypes.NewBatchSpecWorkspaceArtifacts(workspace.ID, executionResult.Artifacts)
+			if err := tx.CreateBatchSpecWorkspaceArtifacts(ctx, artifacts...); err != nil {
+				return rollbackAndMarkFailed(err, fmt.Sprintf("failed to store artifacts: %s", err))
+			}
+		}
+
 		rawSpecs, err := cache.ChangesetSpecsFromCache(
 			batchSpec.Spec,
 			batcheslib.Repository{
@@ -269,6 +302,8 @@ func (s *batchSpecWorkspaceExecutionWorkerStore) MarkComplete(ctx context.Contex
 				FileMatches: workspace.FileMatches,
 			},
 			executionResult,
+			workspace.Owner,
+			codeOwners,
 		)
 		if err != nil {
 			return rollbackAndMarkFailed(err, fmt.Sprintf("failed to build changeset specs from cache: %s", err))
@@ -393,6 +428,57 @@ func extractCacheEntries(events []*batcheslib.LogEvent) (executionResults, stepR
 	return executionResults, stepResults, nil
 }
 
+// globalStepCacheEntries converts the step results of a workspace execution
+// into entries for the step cache that is shared across batch changes and
+// users. Results of steps that aren't cacheable globally are skipped.
+func globalStepCacheEntries(batchSpec *btypes.BatchSpec, workspace *btypes.BatchSpecWorkspace, repo *types.Repo, stepResults []*btypes.BatchSpecExecutionCacheEntry) ([]*btypes.BatchStepCacheEntry, error) {
+	if len(stepResults) == 0 {
+		return nil, nil
+	}
+
+	executionKey := cache.KeyForWorkspace(
+		&template.BatchChangeAttributes{
+			Name:        batchSpec.Spec.Name,
+			Description: batchSpec.Spec.Description,
+		},
+		batcheslib.Repository{
+			ID:          string(relay.MarshalID("Repository", repo.ID)),
+			Name:        string(repo.Name),
+			BaseRef:     workspace.Branch,
+			BaseRev:     workspace.Commit,
+			FileMatches: workspace.FileMatches,
+		},
+		workspace.Path,
+		workspace.OnlyFetchWorkspace,
+		batchSpec.Spec.Steps,
+	)
+
+	entries := make([]*btypes.BatchStepCacheEntry, 0, len(stepResults))
+	for _, result := range stepResults {
+		var stepResult execution.AfterStepResult
+		if err := json.Unmarshal([]byte(result.Value), &stepResult); err != nil {
+			return nil, err
+		}
+
+		key := cache.GlobalStepsCacheKey{ExecutionKey: &executionKey, StepIndex: stepResult.StepIndex}
+		if !key.Cacheable() {
+			continue
+		}
+		rawKey, err := key.Key()
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, &btypes.BatchStepCacheEntry{
+			RepoID: repo.ID,
+			Key:    rawKey,
+			Value:  result.Value,
+		})
+	}
+
+	return entries, nil
+}
+
 var ErrNoSrcCLILogEntry = errors.New("no src-cli log entry found in execution logs")
 
 func logEventsFromLogEntries(logs []workerutil.ExecutionLogEntry) ([]*batcheslib.LogEvent, error) {
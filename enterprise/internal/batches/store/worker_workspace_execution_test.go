@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
@@ -318,3 +319,37 @@ stdout: {"operation":"CACHE_AFTER_STEP_RESULT","timestamp":"2021-11-04T12:43:19.
 }
 
 func intptr(i int) *int { return &i }
+
+func TestGlobalStepCacheEntries(t *testing.T) {
+	batchSpec := &btypes.BatchSpec{Spec: &batcheslib.BatchSpec{
+		Name: "global-step-cache",
+		Steps: []batcheslib.Step{
+			{Run: "echo 1 > one.txt", Container: "alpine@sha256:4ed1812024ed78962a34727137627e8854a3b414d19e2c35a1dc727a47e16fba"},
+			{Run: "echo 2 > two.txt", Container: "alpine:3"},
+		},
+	}}
+	workspace := &btypes.BatchSpecWorkspace{Branch: "refs/heads/main", Commit: "d34db33f"}
+	repo := &types.Repo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+
+	stepResults := []*btypes.BatchSpecExecutionCacheEntry{
+		{Key: "per-spec-step-0", Value: `{"stepIndex":0,"diff":"","outputs":{}}`},
+		{Key: "per-spec-step-1", Value: `{"stepIndex":1,"diff":"","outputs":{}}`},
+	}
+
+	entries, err := globalStepCacheEntries(batchSpec, workspace, repo, stepResults)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The second step uses a container that isn't pinned by digest, so only the
+	// first result is shared.
+	if len(entries) != 1 {
+		t.Fatalf("wrong number of entries: want=1, have=%d", len(entries))
+	}
+	if have, want := entries[0].RepoID, repo.ID; have != want {
+		t.Fatalf("wrong repo ID: want=%d, have=%d", want, have)
+	}
+	if have, want := entries[0].Value, stepResults[0].Value; have != want {
+		t.Fatalf("wrong value: want=%q, have=%q", want, have)
+	}
+}
@@ -28,6 +28,10 @@ type TestChangesetOpts struct {
 	ExternalState         btypes.ChangesetExternalState
 	ExternalReviewState   btypes.ChangesetReviewState
 	ExternalCheckState    btypes.ChangesetCheckState
+	ExternalChecks        []btypes.ChangesetCheck
+
+	ConflictState btypes.ChangesetConflictState
+	RebaseBaseRev string
 
 	DiffStatAdded   int32
 	DiffStatChanged int32
@@ -89,6 +93,10 @@ func BuildChangeset(opts TestChangesetOpts) *btypes.Changeset {
 		ExternalState:       opts.ExternalState,
 		ExternalReviewState: opts.ExternalReviewState,
 		ExternalCheckState:  opts.ExternalCheckState,
+		ExternalChecks:      opts.ExternalChecks,
+
+		ConflictState: opts.ConflictState,
+		RebaseBaseRev: opts.RebaseBaseRev,
 
 		PublicationState:   opts.PublicationState,
 		UiPublicationState: opts.UiPublicationState,
@@ -149,6 +157,7 @@ type ChangesetAssertions struct {
 	ExternalForkNamespace string
 	DiffStat              *diff.Stat
 	Closing               bool
+	ConflictState         btypes.ChangesetConflictState
 
 	Title string
 	Body  string
@@ -273,6 +282,10 @@ func AssertChangeset(t *testing.T, c *btypes.Changeset, a ChangesetAssertions) {
 		}
 	}
 
+	if have, want := c.ConflictState, a.ConflictState; have != want {
+		t.Fatalf("changeset ConflictState wrong. want=%s, have=%s", want, have)
+	}
+
 	if a.ArchivedInOwnerBatchChange {
 		found := false
 		for _, assoc := range c.BatchChanges {
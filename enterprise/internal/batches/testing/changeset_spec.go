@@ -40,6 +40,12 @@ type TestSpecOpts struct {
 
 	BaseRev string
 	BaseRef string
+
+	Reviewers       []string
+	Labels          []string
+	Assignees       []string
+	AutoMerge       bool
+	AutoReapplyDiff bool
 }
 
 var TestChangsetSpecDiffStat = &diff.Stat{Added: 10, Changed: 5, Deleted: 2}
@@ -78,6 +84,12 @@ func BuildChangesetSpec(t *testing.T, opts TestSpecOpts) *btypes.ChangesetSpec {
 					AuthorName:  opts.CommitAuthorName,
 				},
 			},
+
+			Reviewers:       opts.Reviewers,
+			Labels:          opts.Labels,
+			Assignees:       opts.Assignees,
+			AutoMerge:       opts.AutoMerge,
+			AutoReapplyDiff: opts.AutoReapplyDiff,
 		},
 		DiffStatAdded:   TestChangsetSpecDiffStat.Added,
 		DiffStatChanged: TestChangsetSpecDiffStat.Changed,
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 )
 
@@ -17,6 +18,9 @@ func NewBatchSpecFromRaw(rawSpec string) (_ *BatchSpec, err error) {
 		AllowArrayEnvironments: true,
 		AllowTransformChanges:  true,
 		AllowConditionalExec:   true,
+		// Artifacts are collected by the executors, so they need to be
+		// enabled explicitly until all executors support them.
+		AllowArtifacts: conf.Get().BatchChangesEnableArtifacts,
 	})
 
 	return c, err
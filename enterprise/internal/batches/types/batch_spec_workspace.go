@@ -24,6 +24,9 @@ type BatchSpecWorkspace struct {
 	Path               string
 	FileMatches        []string
 	OnlyFetchWorkspace bool
+	// Owner is the code owner the workspace was split off for, if the
+	// workspace configuration splits workspaces by CODEOWNERS.
+	Owner string
 
 	Unsupported bool
 	Ignored     bool
@@ -0,0 +1,48 @@
+package types
+
+import (
+	"sort"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// BatchSpecWorkspaceArtifact is the value of an artifact declared by the steps
+// of a batch spec, as produced by executing them in a workspace.
+type BatchSpecWorkspaceArtifact struct {
+	ID int64
+
+	BatchSpecWorkspaceID int64
+
+	Name string
+	// Value is the value of the artifact: a string for text artifacts, or the
+	// parsed value of JSON artifacts.
+	Value any
+
+	CreatedAt time.Time
+}
+
+// BatchChangeArtifact is an artifact of a workspace of the current batch spec
+// of a batch change, together with the workspace that produced it.
+type BatchChangeArtifact struct {
+	BatchSpecWorkspaceArtifact
+
+	RepoID api.RepoID
+	// Path is the path of the workspace in the repository.
+	Path string
+}
+
+// NewBatchSpecWorkspaceArtifacts returns the artifacts of the given workspace
+// from the artifact values of an execution result, sorted by name.
+func NewBatchSpecWorkspaceArtifacts(workspaceID int64, values map[string]any) []*BatchSpecWorkspaceArtifact {
+	artifacts := make([]*BatchSpecWorkspaceArtifact, 0, len(values))
+	for name, value := range values {
+		artifacts = append(artifacts, &BatchSpecWorkspaceArtifact{
+			BatchSpecWorkspaceID: workspaceID,
+			Name:                 name,
+			Value:                value,
+		})
+	}
+	sort.Slice(artifacts, func(i, j int) bool { return artifacts[i].Name < artifacts[j].Name })
+	return artifacts
+}
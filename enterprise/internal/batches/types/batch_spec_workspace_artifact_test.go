@@ -0,0 +1,25 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewBatchSpecWorkspaceArtifacts(t *testing.T) {
+	have := NewBatchSpecWorkspaceArtifacts(42, map[string]any{
+		"warnings": "deprecated API used",
+		"audit":    map[string]any{"count": float64(2)},
+	})
+	want := []*BatchSpecWorkspaceArtifact{
+		{BatchSpecWorkspaceID: 42, Name: "audit", Value: map[string]any{"count": float64(2)}},
+		{BatchSpecWorkspaceID: 42, Name: "warnings", Value: "deprecated API used"},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if have := NewBatchSpecWorkspaceArtifacts(42, nil); len(have) != 0 {
+		t.Fatalf("expected no artifacts, have %d", len(have))
+	}
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// BatchStepCacheEntry is the result of executing the steps of a batch spec up
+// to a given step in a repository workspace. Unlike a
+// BatchSpecExecutionCacheEntry it isn't scoped to a user: the key is derived
+// from the content of the steps only, so that the result can be reused by
+// other batch changes. It is only returned to users that can access RepoID.
+type BatchStepCacheEntry struct {
+	ID int64
+
+	RepoID api.RepoID
+
+	Key   string
+	Value string
+
+	Version int
+
+	LastUsedAt time.Time
+	CreatedAt  time.Time
+}
@@ -11,6 +11,7 @@ import (
 	bbcs "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/sources/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
@@ -231,6 +232,31 @@ func (s ChangesetCheckState) Valid() bool {
 	}
 }
 
+// ChangesetConflictState describes whether a published changeset is up to
+// date with its base branch, as determined by the rebaser.
+type ChangesetConflictState string
+
+// ChangesetConflictState constants.
+const (
+	ChangesetConflictStateUnknown     ChangesetConflictState = "UNKNOWN"
+	ChangesetConflictStateUpToDate    ChangesetConflictState = "UP_TO_DATE"
+	ChangesetConflictStateBehind      ChangesetConflictState = "BEHIND"
+	ChangesetConflictStateConflicting ChangesetConflictState = "CONFLICTING"
+)
+
+// Valid returns true if the given Changeset conflict state is valid.
+func (s ChangesetConflictState) Valid() bool {
+	switch s {
+	case ChangesetConflictStateUnknown,
+		ChangesetConflictStateUpToDate,
+		ChangesetConflictStateBehind,
+		ChangesetConflictStateConflicting:
+		return true
+	default:
+		return false
+	}
+}
+
 // BatchChangeAssoc stores the details of a association to a BatchChange.
 type BatchChangeAssoc struct {
 	BatchChangeID int64 `json:"-"`
@@ -259,10 +285,13 @@ type Changeset struct {
 	ExternalState         ChangesetExternalState
 	ExternalReviewState   ChangesetReviewState
 	ExternalCheckState    ChangesetCheckState
-	DiffStatAdded         *int32
-	DiffStatChanged       *int32
-	DiffStatDeleted       *int32
-	SyncState             ChangesetSyncState
+	// ExternalChecks are the individual checks that ExternalCheckState
+	// summarizes.
+	ExternalChecks  []ChangesetCheck
+	DiffStatAdded   *int32
+	DiffStatChanged *int32
+	DiffStatDeleted *int32
+	SyncState       ChangesetSyncState
 
 	// The batch change that "owns" this changeset: it can create/close
 	// it on code host. If this is 0, it is imported/tracked by a batch change.
@@ -288,6 +317,13 @@ type Changeset struct {
 	// Closing is set to true (along with the ReocncilerState) when the
 	// reconciler should close the changeset.
 	Closing bool
+
+	// ConflictState is set by the rebaser when it compares the changeset
+	// branch with its base branch.
+	ConflictState ChangesetConflictState
+	// RebaseBaseRev is the commit of the base branch that the diff of the
+	// changeset should be re-applied to. It is set by the rebaser.
+	RebaseBaseRev string
 }
 
 // RecordID is needed to implement the workerutil.Record interface.
@@ -327,6 +363,11 @@ func (c *Changeset) IsImporting() bool { return c.Unpublished() && c.CurrentSpec
 func (c *Changeset) SetCurrentSpec(spec *ChangesetSpec) {
 	c.CurrentSpecID = spec.ID
 
+	// The new spec may be based on a different base revision, so the
+	// rebaser needs to look at the changeset again.
+	c.ConflictState = ""
+	c.RebaseBaseRev = ""
+
 	// Copy over diff stat from the spec.
 	diffStat := spec.DiffStat()
 	c.SetDiffStat(&diffStat)
@@ -412,6 +453,14 @@ func (c *Changeset) SetMetadata(meta any) error {
 		} else {
 			c.ExternalForkNamespace = ""
 		}
+	case *awscodecommit.PullRequest:
+		c.Metadata = pr
+		c.ExternalID = pr.ID
+		c.ExternalServiceType = extsvc.TypeAWSCodeCommit
+		c.ExternalBranch = gitdomain.EnsureRefPrefix(pr.SourceReference)
+		c.ExternalUpdatedAt = pr.LastActivityDate
+		// AWS CodeCommit doesn't support forks.
+		c.ExternalForkNamespace = ""
 	default:
 		return errors.New("unknown changeset type")
 	}
@@ -439,6 +488,8 @@ func (c *Changeset) Title() (string, error) {
 		return m.Title, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Title, nil
+	case *awscodecommit.PullRequest:
+		return m.Title, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -458,6 +509,8 @@ func (c *Changeset) AuthorName() (string, error) {
 		return m.Author.Username, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Author.Username, nil
+	case *awscodecommit.PullRequest:
+		return m.AuthorName(), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -485,6 +538,9 @@ func (c *Changeset) AuthorEmail() (string, error) {
 		// Bitbucket Cloud does not provide the e-mail of the author under any
 		// circumstances.
 		return "", nil
+	case *awscodecommit.PullRequest:
+		// IAM users don't have e-mail addresses.
+		return "", nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -503,11 +559,27 @@ func (c *Changeset) ExternalCreatedAt() time.Time {
 		return m.CreatedAt.Time
 	case *bbcs.AnnotatedPullRequest:
 		return m.CreatedOn
+	case *awscodecommit.PullRequest:
+		return m.CreationDate
 	default:
 		return time.Time{}
 	}
 }
 
+// ExternalConflicting returns whether the code host reports that the Changeset
+// has conflicts with its base branch. Code hosts that don't report this are
+// assumed to not have conflicts.
+func (c *Changeset) ExternalConflicting() bool {
+	switch m := c.Metadata.(type) {
+	case *github.PullRequest:
+		return m.Mergeable == "CONFLICTING"
+	case *gitlab.MergeRequest:
+		return m.HasConflicts
+	default:
+		return false
+	}
+}
+
 // Body of the Changeset.
 func (c *Changeset) Body() (string, error) {
 	switch m := c.Metadata.(type) {
@@ -519,6 +591,8 @@ func (c *Changeset) Body() (string, error) {
 		return m.Description, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Rendered.Description.Raw, nil
+	case *awscodecommit.PullRequest:
+		return m.Description, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -564,6 +638,8 @@ func (c *Changeset) URL() (s string, err error) {
 		// pull request ID, but since the link _should_ be there, we'll error
 		// instead.
 		return "", errors.New("Bitbucket Cloud pull request does not have a html link")
+	case *awscodecommit.PullRequest:
+		return m.URL(), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -753,6 +829,8 @@ func (c *Changeset) HeadRefOid() (string, error) {
 		return m.DiffRefs.HeadSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Source.Commit.Hash, nil
+	case *awscodecommit.PullRequest:
+		return m.SourceCommit, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -770,6 +848,8 @@ func (c *Changeset) HeadRef() (string, error) {
 		return "refs/heads/" + m.SourceBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Source.Branch.Name, nil
+	case *awscodecommit.PullRequest:
+		return gitdomain.EnsureRefPrefix(m.SourceReference), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -788,6 +868,8 @@ func (c *Changeset) BaseRefOid() (string, error) {
 		return m.DiffRefs.BaseSHA, nil
 	case *bbcs.AnnotatedPullRequest:
 		return m.Destination.Commit.Hash, nil
+	case *awscodecommit.PullRequest:
+		return m.DestinationCommit, nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -805,6 +887,8 @@ func (c *Changeset) BaseRef() (string, error) {
 		return "refs/heads/" + m.TargetBranch, nil
 	case *bbcs.AnnotatedPullRequest:
 		return "refs/heads/" + m.Destination.Branch.Name, nil
+	case *awscodecommit.PullRequest:
+		return gitdomain.EnsureRefPrefix(m.DestinationReference), nil
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -0,0 +1,59 @@
+package types
+
+import "time"
+
+// ChangesetCheck is a single check, such as a GitHub check run, a commit
+// status or a GitLab pipeline, reported by the code host for the latest commit
+// of a changeset.
+type ChangesetCheck struct {
+	Name  string              `json:"name"`
+	URL   string              `json:"url,omitempty"`
+	State ChangesetCheckState `json:"state"`
+	// Conclusion is the raw, code host specific conclusion or status of the
+	// check.
+	Conclusion  string    `json:"conclusion,omitempty"`
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt"`
+	// RetryID identifies what needs to be re-run on the code host to retry
+	// the check: the check suite on GitHub and the pipeline on GitLab. It is
+	// empty if the check can't be retried.
+	RetryID string `json:"retryID,omitempty"`
+}
+
+// Duration returns how long the check ran for, or 0 if it hasn't completed.
+func (c ChangesetCheck) Duration() time.Duration {
+	if c.StartedAt.IsZero() || c.CompletedAt.IsZero() || c.CompletedAt.Before(c.StartedAt) {
+		return 0
+	}
+	return c.CompletedAt.Sub(c.StartedAt)
+}
+
+// FailedCheckRetryIDs returns the distinct retry IDs of the failed checks of
+// the changeset, in the order of the checks.
+func (c *Changeset) FailedCheckRetryIDs() []string {
+	var ids []string
+	seen := make(map[string]struct{})
+	for _, check := range c.ExternalChecks {
+		if check.State != ChangesetCheckStateFailed || check.RetryID == "" {
+			continue
+		}
+		if _, ok := seen[check.RetryID]; ok {
+			continue
+		}
+		seen[check.RetryID] = struct{}{}
+		ids = append(ids, check.RetryID)
+	}
+	return ids
+}
+
+// ChangesetCheckStats aggregates the checks with the same name across the
+// changesets of a batch change.
+type ChangesetCheckStats struct {
+	Name    string
+	Total   int32
+	Passed  int32
+	Failed  int32
+	Pending int32
+	// AverageDuration is the average duration of the completed checks.
+	AverageDuration time.Duration
+}
@@ -0,0 +1,91 @@
+package types
+
+// ChangesetDependency is an edge in the dependency graph of the changesets of
+// a batch change: the changeset with ChangesetID waits for the changeset with
+// DependsOnChangesetID to be merged, as described by Gate.
+type ChangesetDependency struct {
+	BatchChangeID        int64
+	ChangesetID          int64
+	DependsOnChangesetID int64
+	Gate                 ChangesetDependencyGate
+}
+
+// ChangesetDependencyGate defines what a changeset waits for until the
+// changeset it depends on has been merged.
+type ChangesetDependencyGate string
+
+// ChangesetDependencyGate constants.
+const (
+	// ChangesetDependencyGatePublish keeps the changeset unpublished.
+	ChangesetDependencyGatePublish ChangesetDependencyGate = "publish"
+	// ChangesetDependencyGateMerge publishes the changeset as a draft, so that
+	// it can be reviewed but not merged. On code hosts that don't support
+	// drafts it behaves like ChangesetDependencyGatePublish.
+	ChangesetDependencyGateMerge ChangesetDependencyGate = "merge"
+)
+
+// ChangesetDependencyStatus is a ChangesetDependency along with the external
+// state of the changeset that is depended on.
+type ChangesetDependencyStatus struct {
+	ChangesetDependency
+	DependsOnExternalState ChangesetExternalState
+}
+
+// Merged returns true if the changeset that is depended on has been merged.
+func (s *ChangesetDependencyStatus) Merged() bool {
+	return s.DependsOnExternalState == ChangesetExternalStateMerged
+}
+
+// Abandoned returns true if the changeset that is depended on was closed or
+// deleted without being merged, so it will never be merged.
+func (s *ChangesetDependencyStatus) Abandoned() bool {
+	return s.DependsOnExternalState == ChangesetExternalStateClosed ||
+		s.DependsOnExternalState == ChangesetExternalStateDeleted
+}
+
+// ChangesetDependencyState describes how a changeset is affected by the
+// changesets it depends on.
+type ChangesetDependencyState string
+
+// ChangesetDependencyState constants.
+const (
+	// ChangesetDependencyStateNone means that the changeset has no
+	// dependencies.
+	ChangesetDependencyStateNone ChangesetDependencyState = ""
+	// ChangesetDependencyStateWaitingToPublish means that the changeset stays
+	// unpublished until its dependencies have been merged.
+	ChangesetDependencyStateWaitingToPublish ChangesetDependencyState = "WAITING_TO_PUBLISH"
+	// ChangesetDependencyStateWaitingToMerge means that the changeset is only
+	// published as a draft until its dependencies have been merged.
+	ChangesetDependencyStateWaitingToMerge ChangesetDependencyState = "WAITING_TO_MERGE"
+	// ChangesetDependencyStateBlocked means that a dependency of the changeset
+	// was closed without being merged.
+	ChangesetDependencyStateBlocked ChangesetDependencyState = "BLOCKED"
+	// ChangesetDependencyStateSatisfied means that all dependencies of the
+	// changeset have been merged.
+	ChangesetDependencyStateSatisfied ChangesetDependencyState = "SATISFIED"
+)
+
+// ComputeChangesetDependencyState computes the ChangesetDependencyState of a
+// changeset from the statuses of all of its dependencies.
+func ComputeChangesetDependencyState(deps []*ChangesetDependencyStatus) ChangesetDependencyState {
+	if len(deps) == 0 {
+		return ChangesetDependencyStateNone
+	}
+
+	state := ChangesetDependencyStateSatisfied
+	for _, d := range deps {
+		switch {
+		case d.Merged():
+		case d.Abandoned():
+			return ChangesetDependencyStateBlocked
+		case d.Gate == ChangesetDependencyGateMerge:
+			if state == ChangesetDependencyStateSatisfied {
+				state = ChangesetDependencyStateWaitingToMerge
+			}
+		default:
+			state = ChangesetDependencyStateWaitingToPublish
+		}
+	}
+	return state
+}
@@ -0,0 +1,70 @@
+package types
+
+import "testing"
+
+func TestComputeChangesetDependencyState(t *testing.T) {
+	dep := func(gate ChangesetDependencyGate, state ChangesetExternalState) *ChangesetDependencyStatus {
+		return &ChangesetDependencyStatus{
+			ChangesetDependency:    ChangesetDependency{Gate: gate},
+			DependsOnExternalState: state,
+		}
+	}
+
+	tests := map[string]struct {
+		deps []*ChangesetDependencyStatus
+		want ChangesetDependencyState
+	}{
+		"no dependencies": {
+			want: ChangesetDependencyStateNone,
+		},
+		"all merged": {
+			deps: []*ChangesetDependencyStatus{
+				dep(ChangesetDependencyGatePublish, ChangesetExternalStateMerged),
+				dep(ChangesetDependencyGateMerge, ChangesetExternalStateMerged),
+			},
+			want: ChangesetDependencyStateSatisfied,
+		},
+		"unmerged merge gate": {
+			deps: []*ChangesetDependencyStatus{
+				dep(ChangesetDependencyGatePublish, ChangesetExternalStateMerged),
+				dep(ChangesetDependencyGateMerge, ChangesetExternalStateOpen),
+			},
+			want: ChangesetDependencyStateWaitingToMerge,
+		},
+		"unpublished dependency": {
+			deps: []*ChangesetDependencyStatus{
+				dep(ChangesetDependencyGatePublish, ""),
+			},
+			want: ChangesetDependencyStateWaitingToPublish,
+		},
+		"publish gate wins over merge gate": {
+			deps: []*ChangesetDependencyStatus{
+				dep(ChangesetDependencyGateMerge, ChangesetExternalStateOpen),
+				dep(ChangesetDependencyGatePublish, ChangesetExternalStateDraft),
+				dep(ChangesetDependencyGateMerge, ChangesetExternalStateOpen),
+			},
+			want: ChangesetDependencyStateWaitingToPublish,
+		},
+		"closed dependency": {
+			deps: []*ChangesetDependencyStatus{
+				dep(ChangesetDependencyGatePublish, ChangesetExternalStateOpen),
+				dep(ChangesetDependencyGateMerge, ChangesetExternalStateClosed),
+			},
+			want: ChangesetDependencyStateBlocked,
+		},
+		"deleted dependency": {
+			deps: []*ChangesetDependencyStatus{
+				dep(ChangesetDependencyGateMerge, ChangesetExternalStateDeleted),
+			},
+			want: ChangesetDependencyStateBlocked,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if have := ComputeChangesetDependencyState(tc.deps); have != tc.want {
+				t.Errorf("unexpected state: have %q; want %q", have, tc.want)
+			}
+		})
+	}
+}
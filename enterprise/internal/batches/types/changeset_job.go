@@ -41,16 +41,20 @@ func (s ChangesetJobState) ToDB() string { return strings.ToLower(string(s)) }
 type ChangesetJobType string
 
 var (
-	ChangesetJobTypeComment   ChangesetJobType = "commentatore"
-	ChangesetJobTypeDetach    ChangesetJobType = "detach"
-	ChangesetJobTypeReenqueue ChangesetJobType = "reenqueue"
-	ChangesetJobTypeMerge     ChangesetJobType = "merge"
-	ChangesetJobTypeClose     ChangesetJobType = "close"
-	ChangesetJobTypePublish   ChangesetJobType = "publish"
+	ChangesetJobTypeComment     ChangesetJobType = "commentatore"
+	ChangesetJobTypeDetach      ChangesetJobType = "detach"
+	ChangesetJobTypeReenqueue   ChangesetJobType = "reenqueue"
+	ChangesetJobTypeMerge       ChangesetJobType = "merge"
+	ChangesetJobTypeClose       ChangesetJobType = "close"
+	ChangesetJobTypePublish     ChangesetJobType = "publish"
+	ChangesetJobTypeRetryChecks ChangesetJobType = "retry_checks"
 )
 
 type ChangesetJobCommentPayload struct {
 	Message string `json:"message"`
+	// Templated is true if Message is a template that is rendered for each
+	// changeset.
+	Templated bool `json:"templated,omitempty"`
 }
 
 type ChangesetJobDetachPayload struct{}
@@ -67,6 +71,8 @@ type ChangesetJobPublishPayload struct {
 	Draft bool `json:"draft"`
 }
 
+type ChangesetJobRetryChecksPayload struct{}
+
 // ChangesetJob describes a one-time action to be taken on a changeset.
 type ChangesetJob struct {
 	ID int64
@@ -411,6 +411,41 @@ func TestChangeset_HeadRefOid(t *testing.T) {
 	})
 }
 
+func TestChangeset_ExternalConflicting(t *testing.T) {
+	for name, tc := range map[string]struct {
+		meta any
+		want bool
+	}{
+		"bitbucketserver": {
+			meta: &bitbucketserver.PullRequest{},
+			want: false,
+		},
+		"GitHub conflicting": {
+			meta: &github.PullRequest{Mergeable: "CONFLICTING"},
+			want: true,
+		},
+		"GitHub unknown": {
+			meta: &github.PullRequest{Mergeable: "UNKNOWN"},
+			want: false,
+		},
+		"GitLab conflicting": {
+			meta: &gitlab.MergeRequest{HasConflicts: true},
+			want: true,
+		},
+		"GitLab mergeable": {
+			meta: &gitlab.MergeRequest{},
+			want: false,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := &Changeset{Metadata: tc.meta}
+			if have := c.ExternalConflicting(); have != tc.want {
+				t.Errorf("unexpected conflicting: have %t; want %t", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestChangeset_HeadRef(t *testing.T) {
 	for name, tc := range map[string]struct {
 		meta any
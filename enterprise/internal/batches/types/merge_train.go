@@ -0,0 +1,68 @@
+package types
+
+import (
+	"time"
+)
+
+// MergeTrainState defines the possible states of a merge train.
+type MergeTrainState string
+
+// MergeTrainState constants.
+const (
+	MergeTrainStateRunning   MergeTrainState = "RUNNING"
+	MergeTrainStatePaused    MergeTrainState = "PAUSED"
+	MergeTrainStateCompleted MergeTrainState = "COMPLETED"
+	MergeTrainStateCanceled  MergeTrainState = "CANCELED"
+)
+
+// Valid returns true if the given MergeTrainState is valid.
+func (s MergeTrainState) Valid() bool {
+	switch s {
+	case MergeTrainStateRunning,
+		MergeTrainStatePaused,
+		MergeTrainStateCompleted,
+		MergeTrainStateCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// MergeTrain merges a set of changesets of a batch change in waves. Each wave
+// merges at most ChangesetsPerWave changesets per code host, and the next wave
+// only starts once WaveInterval has passed, the rollout windows allow it and
+// the health checks pass.
+type MergeTrain struct {
+	ID            int64
+	BatchChangeID int64
+	UserID        int32
+	State         MergeTrainState
+
+	ChangesetsPerWave int32
+	WaveInterval      time.Duration
+	Squash            bool
+
+	// HealthCheckQuery is a search query that must not return any results
+	// for the next wave to start.
+	HealthCheckQuery string
+	// HealthCheckURL is a URL that must respond with 200 OK for the next
+	// wave to start.
+	HealthCheckURL string
+
+	PausedReason string
+
+	LastWaveAt time.Time
+	ResumedAt  time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// MergeTrainWave is a set of changesets that was merged together by a merge
+// train, using a single bulk operation.
+type MergeTrainWave struct {
+	MergeTrainID   int64
+	Number         int32
+	BulkGroup      string
+	ChangesetCount int32
+}
@@ -4,24 +4,27 @@ package types
 type ReconcilerOperation string
 
 const (
-	ReconcilerOperationPush         ReconcilerOperation = "PUSH"
-	ReconcilerOperationUpdate       ReconcilerOperation = "UPDATE"
-	ReconcilerOperationUndraft      ReconcilerOperation = "UNDRAFT"
-	ReconcilerOperationPublish      ReconcilerOperation = "PUBLISH"
-	ReconcilerOperationPublishDraft ReconcilerOperation = "PUBLISH_DRAFT"
-	ReconcilerOperationSync         ReconcilerOperation = "SYNC"
-	ReconcilerOperationImport       ReconcilerOperation = "IMPORT"
-	ReconcilerOperationClose        ReconcilerOperation = "CLOSE"
-	ReconcilerOperationReopen       ReconcilerOperation = "REOPEN"
-	ReconcilerOperationSleep        ReconcilerOperation = "SLEEP"
-	ReconcilerOperationDetach       ReconcilerOperation = "DETACH"
-	ReconcilerOperationArchive      ReconcilerOperation = "ARCHIVE"
+	ReconcilerOperationPush           ReconcilerOperation = "PUSH"
+	ReconcilerOperationReapplyDiff    ReconcilerOperation = "REAPPLY_DIFF"
+	ReconcilerOperationUpdate         ReconcilerOperation = "UPDATE"
+	ReconcilerOperationUndraft        ReconcilerOperation = "UNDRAFT"
+	ReconcilerOperationPublish        ReconcilerOperation = "PUBLISH"
+	ReconcilerOperationPublishDraft   ReconcilerOperation = "PUBLISH_DRAFT"
+	ReconcilerOperationSync           ReconcilerOperation = "SYNC"
+	ReconcilerOperationImport         ReconcilerOperation = "IMPORT"
+	ReconcilerOperationClose          ReconcilerOperation = "CLOSE"
+	ReconcilerOperationReopen         ReconcilerOperation = "REOPEN"
+	ReconcilerOperationSleep          ReconcilerOperation = "SLEEP"
+	ReconcilerOperationDetach         ReconcilerOperation = "DETACH"
+	ReconcilerOperationArchive        ReconcilerOperation = "ARCHIVE"
+	ReconcilerOperationUpdateMetadata ReconcilerOperation = "UPDATE_METADATA"
 )
 
 // Valid returns true if the given ReconcilerOperation is valid.
 func (r ReconcilerOperation) Valid() bool {
 	switch r {
 	case ReconcilerOperationPush,
+		ReconcilerOperationReapplyDiff,
 		ReconcilerOperationUpdate,
 		ReconcilerOperationUndraft,
 		ReconcilerOperationPublish,
@@ -32,7 +35,8 @@ func (r ReconcilerOperation) Valid() bool {
 		ReconcilerOperationReopen,
 		ReconcilerOperationSleep,
 		ReconcilerOperationDetach,
-		ReconcilerOperationArchive:
+		ReconcilerOperationArchive,
+		ReconcilerOperationUpdateMetadata:
 		return true
 	default:
 		return false
@@ -197,6 +197,37 @@ func TestConfiguration_Schedule(t *testing.T) {
 	}
 }
 
+func TestConfiguration_IsOpen(t *testing.T) {
+	// 2021-05-03 was a Monday.
+	monday := time.Date(2021, 5, 3, 12, 0, 0, 0, time.UTC)
+	tuesday := monday.Add(24 * time.Hour)
+	wednesday := tuesday.Add(24 * time.Hour)
+
+	cfg := &Configuration{
+		windows: []Window{
+			{days: newWeekdaySet(time.Monday), rate: rate{n: 10, unit: ratePerHour}},
+			{days: newWeekdaySet(time.Tuesday), rate: rate{n: 0}},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		cfg  *Configuration
+		at   time.Time
+		want bool
+	}{
+		"no rollout windows": {cfg: &Configuration{}, at: tuesday, want: true},
+		"open window":        {cfg: cfg, at: monday, want: true},
+		"zero window":        {cfg: cfg, at: tuesday, want: false},
+		"no window":          {cfg: cfg, at: wednesday, want: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if have := tc.cfg.IsOpen(tc.at); have != tc.want {
+				t.Errorf("unexpected result: have=%v want=%v", have, tc.want)
+			}
+		})
+	}
+}
+
 func TestConfiguration_currentFor(t *testing.T) {
 	// Let's set up some common windows to simplify defining the test cases.
 
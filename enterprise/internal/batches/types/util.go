@@ -12,6 +12,9 @@ type CodehostCapability string
 const (
 	CodehostCapabilityLabels          CodehostCapability = "Labels"
 	CodehostCapabilityDraftChangesets CodehostCapability = "DraftChangesets"
+	CodehostCapabilityReviewers       CodehostCapability = "Reviewers"
+	CodehostCapabilityAssignees       CodehostCapability = "Assignees"
+	CodehostCapabilityAutoMerge       CodehostCapability = "AutoMerge"
 )
 
 type CodehostCapabilities map[CodehostCapability]bool
@@ -21,10 +24,23 @@ type CodehostCapabilities map[CodehostCapability]bool
 // whose type is not in this list will simply be filtered out from the search
 // results.
 var SupportedExternalServices = map[string]CodehostCapabilities{
-	extsvc.TypeGitHub:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true},
-	extsvc.TypeBitbucketServer: {},
-	extsvc.TypeGitLab:          {CodehostCapabilityLabels: true, CodehostCapabilityDraftChangesets: true},
-	extsvc.TypeBitbucketCloud:  {},
+	extsvc.TypeGitHub: {
+		CodehostCapabilityLabels:          true,
+		CodehostCapabilityDraftChangesets: true,
+		CodehostCapabilityReviewers:       true,
+		CodehostCapabilityAssignees:       true,
+		CodehostCapabilityAutoMerge:       true,
+	},
+	extsvc.TypeBitbucketServer: {CodehostCapabilityReviewers: true},
+	extsvc.TypeGitLab: {
+		CodehostCapabilityLabels:          true,
+		CodehostCapabilityDraftChangesets: true,
+		CodehostCapabilityReviewers:       true,
+		CodehostCapabilityAssignees:       true,
+		CodehostCapabilityAutoMerge:       true,
+	},
+	extsvc.TypeBitbucketCloud: {CodehostCapabilityReviewers: true},
+	extsvc.TypeAWSCodeCommit:  {},
 }
 
 // IsRepoSupported returns whether the given ExternalRepoSpec is supported by
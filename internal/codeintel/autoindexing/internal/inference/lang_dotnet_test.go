@@ -0,0 +1,77 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestDotnetGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "dotnet solution",
+			repositoryContents: map[string]string{
+				"App.sln":                  "",
+				"src/App/App.csproj":       "",
+				"src/Core/Core.csproj":     "",
+				"tests/App.Tests.csproj":   "",
+				"tools/Tool/Tool.csproj":   "",
+				"tools/Other/Other.csproj": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "",
+							Image:    "sourcegraph/scip-dotnet:latest",
+							Commands: []string{"dotnet restore App.sln"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-dotnet:latest",
+					IndexerArgs: []string{"scip-dotnet", "index", "App.sln"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "dotnet projects without solution",
+			repositoryContents: map[string]string{
+				"src/App/App.csproj":          "",
+				"services/Api/Api.sln":        "",
+				"services/Api/Web/Web.csproj": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "services/Api",
+							Image:    "sourcegraph/scip-dotnet:latest",
+							Commands: []string{"dotnet restore Api.sln"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "services/Api",
+					Indexer:     "sourcegraph/scip-dotnet:latest",
+					IndexerArgs: []string{"scip-dotnet", "index", "Api.sln"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps: []config.DockerStep{
+						{
+							Root:     "src/App",
+							Image:    "sourcegraph/scip-dotnet:latest",
+							Commands: []string{"dotnet restore App.csproj"},
+						},
+					},
+					LocalSteps:  nil,
+					Root:        "src/App",
+					Indexer:     "sourcegraph/scip-dotnet:latest",
+					IndexerArgs: []string{"scip-dotnet", "index", "App.csproj"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+	)
+}
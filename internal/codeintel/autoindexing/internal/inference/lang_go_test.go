@@ -45,6 +45,14 @@ func TestGoGenerator(t *testing.T) {
 				},
 			},
 		},
+		generatorTestCase{
+			description: "go modules in excluded directories (no match)",
+			repositoryContents: map[string]string{
+				"vendor/github.com/foo/bar/go.mod": "",
+				"testdata/mod/go.mod":              "",
+			},
+			expected: []config.IndexJob{},
+		},
 		generatorTestCase{
 			description: "go files in root",
 			repositoryContents: map[string]string{
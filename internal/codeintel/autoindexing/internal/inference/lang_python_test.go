@@ -0,0 +1,50 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestPythonGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "python projects",
+			repositoryContents: map[string]string{
+				"setup.py":                     "",
+				"requirements.txt":             "",
+				"libs/client/pyproject.toml":   "",
+				"libs/client/setup.py":         "",
+				"tools/requirements.txt":       "",
+				"tests/fixture/pyproject.toml": "",
+				".venv/lib/pkg/setup.py":       "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  []string{"pip install -r requirements.txt", "pip install ."},
+					Root:        "",
+					Indexer:     "sourcegraph/scip-python:autoindex",
+					IndexerArgs: []string{"scip-python", "index"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  []string{"pip install ."},
+					Root:        "libs/client",
+					Indexer:     "sourcegraph/scip-python:autoindex",
+					IndexerArgs: []string{"scip-python", "index"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "python requirements only (no match)",
+			repositoryContents: map[string]string{
+				"requirements.txt": "",
+				"main.py":          "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+}
@@ -0,0 +1,46 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestRubyGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "ruby projects",
+			repositoryContents: map[string]string{
+				"Gemfile":                         "",
+				"Gemfile.lock":                    "",
+				"engines/billing/Gemfile":         "",
+				"vendor/bundle/gems/rake/Gemfile": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  []string{"bundle install"},
+					Root:        "",
+					Indexer:     "sourcegraph/scip-ruby:autoindex",
+					IndexerArgs: []string{"scip-ruby", "--index-file", "index.scip", "."},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  []string{"bundle install"},
+					Root:        "engines/billing",
+					Indexer:     "sourcegraph/scip-ruby:autoindex",
+					IndexerArgs: []string{"scip-ruby", "--index-file", "index.scip", "."},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "ruby files without Gemfile (no match)",
+			repositoryContents: map[string]string{
+				"lib/app.rb": "",
+			},
+			expected: []config.IndexJob{},
+		},
+	)
+}
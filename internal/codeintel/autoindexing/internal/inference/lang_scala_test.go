@@ -0,0 +1,75 @@
+package inference
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+)
+
+func TestScalaGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "sbt builds",
+			repositoryContents: map[string]string{
+				"build.sbt":                       "",
+				"core/build.sbt":                  "",
+				"core/src/main/scala/App.scala":   "",
+				"plugins/other/build.sbt":         "",
+				"target/scala-2.13/gen/build.sbt": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-java",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=sbt"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "sbt build with lsif-java.json",
+			repositoryContents: map[string]string{
+				"build.sbt":          "",
+				"lsif-java.json":     "",
+				"src/main/App.scala": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "",
+					Indexer:     "sourcegraph/scip-java",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=lsif"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+		generatorTestCase{
+			description: "nested sbt builds without root build",
+			repositoryContents: map[string]string{
+				"services/a/build.sbt": "",
+				"services/b/build.sbt": "",
+			},
+			expected: []config.IndexJob{
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "services/a",
+					Indexer:     "sourcegraph/scip-java",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=sbt"},
+					Outfile:     "index.scip",
+				},
+				{
+					Steps:       nil,
+					LocalSteps:  nil,
+					Root:        "services/b",
+					Indexer:     "sourcegraph/scip-java",
+					IndexerArgs: []string{"scip-java", "index", "--build-tool=sbt"},
+					Outfile:     "index.scip",
+				},
+			},
+		},
+	)
+}
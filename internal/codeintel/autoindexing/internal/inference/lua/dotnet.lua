@@ -0,0 +1,77 @@
+local path = require("path")
+local patterns = require("sg.patterns")
+local recognizers = require("sg.recognizers")
+
+local shared = loadfile("shared.lua")()
+
+local indexer = "sourcegraph/scip-dotnet:latest"
+
+local is_solution_file = function(base)
+    return string.sub(base, -4) == ".sln"
+end
+
+local new_job = function(root, base)
+    return {
+        steps = {
+            {
+                root = root,
+                image = indexer,
+                commands = { "dotnet restore " .. base },
+            },
+        },
+        root = root,
+        indexer = indexer,
+        indexer_args = { "scip-dotnet", "index", base },
+        outfile = "index.scip",
+    }
+end
+
+return recognizers.path_recognizer {
+    patterns = {
+        patterns.path_extension("sln"),
+        patterns.path_extension("csproj"),
+        patterns.path_exclude(shared.exclude_paths),
+    },
+
+    -- Invoked when solution or C# project files exist. A job is scheduled for the
+    -- first solution file of each directory. Project files are indexed on their own
+    -- only if no solution file exists in the same or an ancestor directory, as such
+    -- projects are already indexed as part of the solution.
+    generate = function(_, paths)
+        local jobs = {}
+        local solution_dirs = {}
+
+        for i = 1, #paths do
+            local root = path.dirname(paths[i])
+            local base = path.basename(paths[i])
+
+            if solution_dirs[root] == nil and is_solution_file(base) then
+                table.insert(jobs, new_job(root, base))
+                solution_dirs[root] = true
+            end
+        end
+
+        local project_dirs = {}
+        for i = 1, #paths do
+            local root = path.dirname(paths[i])
+            local base = path.basename(paths[i])
+
+            if project_dirs[root] == nil and not is_solution_file(base) then
+                local in_solution = false
+                local ancestors = path.ancestors(paths[i])
+                for j = 1, #ancestors do
+                    if solution_dirs[ancestors[j]] then
+                        in_solution = true
+                    end
+                end
+
+                if not in_solution then
+                    table.insert(jobs, new_job(root, base))
+                    project_dirs[root] = true
+                end
+            end
+        end
+
+        return jobs
+    end,
+}
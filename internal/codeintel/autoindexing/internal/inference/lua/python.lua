@@ -0,0 +1,61 @@
+local path = require("path")
+local patterns = require("sg.patterns")
+local recognizers = require("sg.recognizers")
+
+local shared = loadfile("shared.lua")()
+local util = loadfile("util.lua")()
+
+local indexer = "sourcegraph/scip-python:autoindex"
+
+local exclude_paths = patterns.path_combine(shared.exclude_paths, {
+    patterns.path_segment("site-packages"),
+    patterns.path_segment("venv"),
+    patterns.path_segment(".venv"),
+})
+
+local is_project_file = function(base)
+    return base == "setup.py" or base == "pyproject.toml"
+end
+
+return recognizers.path_recognizer {
+    patterns = {
+        patterns.path_basename("setup.py"),
+        patterns.path_basename("pyproject.toml"),
+        -- To determine installation steps
+        patterns.path_basename("requirements.txt"),
+        patterns.path_exclude(exclude_paths),
+    },
+
+    -- Invoked when setup.py, pyproject.toml, or requirements.txt files exist
+    generate = function(_, paths)
+        local jobs = {}
+        local visited = {}
+
+        for i = 1, #paths do
+            local root = path.dirname(paths[i])
+
+            if visited[root] == nil and is_project_file(path.basename(paths[i])) then
+                -- Dependencies are installed into the indexing container so that
+                -- scip-python can resolve imports of third-party packages
+                local local_steps = {}
+                if util.contains(paths, path.join(root, "requirements.txt")) then
+                    table.insert(local_steps, "pip install -r requirements.txt")
+                end
+                table.insert(local_steps, "pip install .")
+
+                table.insert(jobs, {
+                    steps = {},
+                    local_steps = local_steps,
+                    root = root,
+                    indexer = indexer,
+                    indexer_args = { "scip-python", "index" },
+                    outfile = "index.scip",
+                })
+
+                visited[root] = true
+            end
+        end
+
+        return jobs
+    end,
+}
@@ -0,0 +1,56 @@
+local path = require("path")
+local patterns = require("sg.patterns")
+local recognizers = require("sg.recognizers")
+
+local shared = loadfile("shared.lua")()
+local util = loadfile("util.lua")()
+
+local indexer = "sourcegraph/scip-java"
+
+local exclude_paths = patterns.path_combine(shared.exclude_paths, {
+    patterns.path_segment("target"),
+})
+
+return recognizers.path_recognizer {
+    patterns = {
+        patterns.path_basename("build.sbt"),
+        -- To avoid scheduling a job that duplicates the one of the java recognizer
+        patterns.path_literal("lsif-java.json"),
+        patterns.path_exclude(exclude_paths),
+    },
+
+    -- Invoked when build.sbt files exist. Only the outermost build of nested sbt
+    -- builds is indexed, as subprojects are built as part of their parent build.
+    generate = function(_, paths)
+        local jobs = {}
+        for i = 1, #paths do
+            local root = path.dirname(paths[i])
+
+            if path.basename(paths[i]) == "build.sbt" then
+                local is_nested = false
+                if root ~= "" then
+                    local ancestors = path.ancestors(root)
+                    for j = 1, #ancestors do
+                        if util.contains(paths, path.join(ancestors[j], "build.sbt")) then
+                            is_nested = true
+                        end
+                    end
+                end
+
+                local has_lsif_java_config = root == "" and util.contains(paths, "lsif-java.json")
+
+                if not is_nested and not has_lsif_java_config then
+                    table.insert(jobs, {
+                        steps = {},
+                        root = root,
+                        indexer = indexer,
+                        indexer_args = { "scip-java", "index", "--build-tool=sbt" },
+                        outfile = "index.scip",
+                    })
+                end
+            end
+        end
+
+        return jobs
+    end,
+}
@@ -53,7 +53,8 @@ func FlattenPatterns(pathPatterns []*PathPattern, inverted bool) (patterns []str
 }
 
 // FlattenPattern returns the set of patterns matching the given inverted flag on this
-// path pattern or any of its descendants.
+// path pattern or any of its descendants. The descendants of a matching exclude pattern
+// are the patterns it excludes, so they are collected as regular (non-inverted) patterns.
 func FlattenPattern(pathPattern *PathPattern, inverted bool) (patterns []string) {
 	if pathPattern.invert == inverted {
 		if pathPattern.pattern != "" {
@@ -61,7 +62,7 @@ func FlattenPattern(pathPattern *PathPattern, inverted bool) (patterns []string)
 		}
 
 		for _, child := range pathPattern.children {
-			patterns = append(patterns, FlattenPattern(child, inverted)...)
+			patterns = append(patterns, FlattenPattern(child, false)...)
 		}
 	}
 
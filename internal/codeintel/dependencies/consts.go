@@ -8,4 +8,6 @@ const (
 	GoModulesScheme      = shared.GoModulesScheme
 	PythonPackagesScheme = shared.PythonPackagesScheme
 	RustPackagesScheme   = shared.RustPackagesScheme
+	RubyPackagesScheme   = shared.RubyPackagesScheme
+	PHPPackagesScheme    = shared.PHPPackagesScheme
 )
@@ -96,6 +96,6 @@ func TestService(db database.DB, gitService GitService, syncer Syncer) *Service
 
 type shim struct{ store.Store }
 
-func (s *shim) UpsertLockfileDependencies(ctx context.Context, repoName, commit string, deps []shared.PackageDependency) error {
+func (s *shim) UpsertLockfileDependencies(ctx context.Context, repoName, commit string, deps []shared.PackageDependency, graph shared.DependencyGraph) error {
 	return nil
 }
@@ -0,0 +1,98 @@
+package lockfiles
+
+import (
+	"io"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+//
+// Cargo.lock
+//
+
+// parseCargoLockFile extracts all crates from a Cargo.lock file that were resolved from
+// a registry. Packages without a source are members of the workspace itself, and packages
+// with a git source aren't published crates, so both are skipped. The dependencies of
+// workspace members are the direct dependencies of the graph.
+func parseCargoLockFile(r io.Reader) ([]reposource.PackageDependency, *DependencyGraph, error) {
+	var lockfile struct {
+		Packages []struct {
+			Name         string   `toml:"name"`
+			Version      string   `toml:"version"`
+			Source       string   `toml:"source"`
+			Dependencies []string `toml:"dependencies"`
+		} `toml:"package"`
+	}
+
+	if _, err := toml.DecodeReader(r, &lockfile); err != nil {
+		return nil, nil, errors.Errorf("error decoding Cargo.lock: %w", err)
+	}
+
+	var (
+		libs = make([]reposource.PackageDependency, 0, len(lockfile.Packages))
+		// crates maps crate names to the registry crates of that name, by version
+		crates = map[string]map[string]reposource.PackageDependency{}
+	)
+	for _, pkg := range lockfile.Packages {
+		if !strings.HasPrefix(pkg.Source, "registry+") {
+			continue
+		}
+		dep := reposource.NewRustDependency(pkg.Name, pkg.Version)
+		libs = append(libs, dep)
+
+		if _, ok := crates[pkg.Name]; !ok {
+			crates[pkg.Name] = map[string]reposource.PackageDependency{}
+		}
+		crates[pkg.Name][pkg.Version] = dep
+	}
+
+	// Entries of the dependencies array look like "name", or like "name version" or
+	// "name version (source)" when several versions of a crate are in the lockfile.
+	resolve := func(entry string) (reposource.PackageDependency, bool) {
+		fields := strings.Fields(entry)
+		if len(fields) == 0 {
+			return nil, false
+		}
+
+		versions := crates[fields[0]]
+		if len(fields) > 1 {
+			dep, ok := versions[fields[1]]
+			return dep, ok
+		}
+		for _, dep := range versions {
+			return dep, len(versions) == 1
+		}
+		return nil, false
+	}
+
+	graph := newDependencyGraph()
+	for _, pkg := range lockfile.Packages {
+		var source reposource.PackageDependency
+		switch {
+		case pkg.Source == "":
+		case strings.HasPrefix(pkg.Source, "registry+"):
+			source = crates[pkg.Name][pkg.Version]
+			graph.addPackage(source)
+		default:
+			continue
+		}
+
+		for _, entry := range pkg.Dependencies {
+			target, ok := resolve(entry)
+			if !ok {
+				continue
+			}
+			if source == nil {
+				graph.addRoot(target)
+			} else {
+				graph.addEdge(source, target)
+			}
+		}
+	}
+
+	return libs, graph, nil
+}
@@ -0,0 +1,54 @@
+package lockfiles
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+//
+// composer.lock
+//
+
+// parseComposerLockFile extracts all dependencies, both "packages" and "packages-dev",
+// from a composer.lock file. Both lists contain transitive dependencies too.
+func parseComposerLockFile(r io.Reader) ([]reposource.PackageDependency, error) {
+	type packageInfo struct {
+		Name    string
+		Version string
+	}
+
+	var lockfile struct {
+		Packages    []packageInfo `json:"packages"`
+		PackagesDev []packageInfo `json:"packages-dev"`
+	}
+
+	if err := json.NewDecoder(r).Decode(&lockfile); err != nil {
+		return nil, errors.Errorf("error decoding composer.lock: %w", err)
+	}
+
+	var (
+		errs errors.MultiError
+		libs = make([]reposource.PackageDependency, 0, len(lockfile.Packages)+len(lockfile.PackagesDev))
+	)
+
+	for _, pkg := range append(lockfile.Packages, lockfile.PackagesDev...) {
+		// Packages installed from a VCS branch have versions like "dev-main", which
+		// don't correspond to any release we could sync.
+		if strings.HasPrefix(pkg.Version, "dev-") {
+			continue
+		}
+
+		dep, err := reposource.ParsePHPDependency(pkg.Name + "@" + strings.TrimPrefix(pkg.Version, "v"))
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+		libs = append(libs, dep)
+	}
+
+	return libs, errs
+}
@@ -0,0 +1,121 @@
+package lockfiles
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+//
+// Gemfile.lock
+//
+
+// gemfileSpecPattern matches the lines listing the resolved gems of a source section,
+// e.g. "    nokogiri (1.13.6-x86_64-linux)". Dependencies of these gems are indented
+// further and are listed as gems of their own.
+var gemfileSpecPattern = lazyregexp.New(`^    ([^\s(]+) \(([^)]+)\)$`)
+
+// gemfileSpecDependencyPattern matches the lines listing the dependencies of a resolved
+// gem, e.g. "      racc (~> 1.4)", and the lines of the DEPENDENCIES section, e.g.
+// "  rails (~> 7.0.3)" or "  billing!".
+var gemfileSpecDependencyPattern = lazyregexp.New(`^(?:  |      )([^\s(!]+)!?(?: \([^)]*\))?$`)
+
+// parseGemfileLockFile extracts all gems installed from the GEM sections of a
+// Gemfile.lock file. Gems from GIT and PATH sections are not hosted on rubygems.org
+// and are skipped. The gems listed in the DEPENDENCIES section, as well as the
+// dependencies of gems from GIT and PATH sections, are the direct dependencies of
+// the graph.
+func parseGemfileLockFile(r io.Reader) ([]reposource.PackageDependency, *DependencyGraph, error) {
+	var (
+		libs    []reposource.PackageDependency
+		gems    = map[string]reposource.PackageDependency{}
+		section string
+		// spec is the name of the gem whose dependencies are currently listed
+		spec string
+		// requirements maps gem names to the names of the gems they depend on. Gems
+		// that are direct dependencies are required by the empty name.
+		requirements = map[string][]string{}
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line != "" && !strings.HasPrefix(line, " ") {
+			section = line
+			continue
+		}
+
+		switch section {
+		case "GEM", "GIT", "PATH":
+		case "DEPENDENCIES":
+			if m := gemfileSpecDependencyPattern.FindStringSubmatch(line); m != nil {
+				requirements[""] = append(requirements[""], m[1])
+			}
+			continue
+		default:
+			continue
+		}
+
+		if m := gemfileSpecDependencyPattern.FindStringSubmatch(line); m != nil && strings.HasPrefix(line, "      ") {
+			requirements[spec] = append(requirements[spec], m[1])
+			continue
+		}
+
+		m := gemfileSpecPattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		if section != "GEM" {
+			// Dependencies of gems vendored into the project or pulled from git
+			// are direct dependencies of the project
+			spec = ""
+			continue
+		}
+
+		// Versions of native gems carry a platform suffix, e.g. "1.13.6-x86_64-linux".
+		// Gem versions themselves never contain dashes.
+		name := m[1]
+		version, _, _ := strings.Cut(m[2], "-")
+		spec = name
+
+		// Native gems may be listed once per platform
+		if _, ok := gems[name]; ok {
+			continue
+		}
+
+		dep := reposource.NewRubyDependency(name, version)
+		gems[name] = dep
+		libs = append(libs, dep)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, errors.Errorf("error reading Gemfile.lock: %w", err)
+	}
+
+	graph := newDependencyGraph()
+	for _, dep := range libs {
+		graph.addPackage(dep)
+	}
+	for name, required := range requirements {
+		for _, requiredName := range required {
+			target, ok := gems[requiredName]
+			if !ok {
+				continue
+			}
+
+			if name == "" {
+				graph.addRoot(target)
+			} else if source, ok := gems[name]; ok {
+				graph.addEdge(source, target)
+			}
+		}
+	}
+
+	return libs, graph, nil
+}
@@ -0,0 +1,47 @@
+package lockfiles
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+//
+// gradle.lockfile
+//
+
+// parseGradleLockFile extracts all dependencies from a gradle.lockfile, which lists one
+// "group:artifact:version=configuration,..." entry per line.
+//
+// https://docs.gradle.org/current/userguide/dependency_locking.html#lock_state_location_and_format
+func parseGradleLockFile(r io.Reader) ([]reposource.PackageDependency, error) {
+	var (
+		errs errors.MultiError
+		libs []reposource.PackageDependency
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "empty=") {
+			continue
+		}
+
+		coordinates, _, _ := strings.Cut(line, "=")
+		dep, err := reposource.ParseMavenDependency(coordinates)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+		libs = append(libs, dep)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Errorf("error reading gradle.lockfile: %w", err)
+	}
+
+	return libs, errs
+}
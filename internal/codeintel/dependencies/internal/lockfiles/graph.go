@@ -0,0 +1,160 @@
+package lockfiles
+
+import (
+	"sort"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+)
+
+// DependencyGraph describes how the packages listed in one or more lockfiles relate to
+// each other. Roots are the direct dependencies of the project owning the lockfiles, and
+// edges point from a package to the packages it depends on. Packages are identified by
+// their package manager syntax, e.g. "react@18.2.0".
+type DependencyGraph struct {
+	packages map[string]reposource.PackageDependency
+	roots    map[string]struct{}
+	edges    map[string]map[string]struct{}
+}
+
+func newDependencyGraph() *DependencyGraph {
+	return &DependencyGraph{
+		packages: map[string]reposource.PackageDependency{},
+		roots:    map[string]struct{}{},
+		edges:    map[string]map[string]struct{}{},
+	}
+}
+
+// flatDependencyGraph returns a graph in which every given package is a direct dependency.
+// It is used for lockfiles that don't describe the relationships between their packages.
+func flatDependencyGraph(deps []reposource.PackageDependency) *DependencyGraph {
+	g := newDependencyGraph()
+	for _, dep := range deps {
+		g.addRoot(dep)
+	}
+	return g
+}
+
+func (g *DependencyGraph) addPackage(pkg reposource.PackageDependency) string {
+	key := pkg.PackageManagerSyntax()
+	if _, ok := g.packages[key]; !ok {
+		g.packages[key] = pkg
+	}
+	return key
+}
+
+func (g *DependencyGraph) addRoot(pkg reposource.PackageDependency) {
+	g.roots[g.addPackage(pkg)] = struct{}{}
+}
+
+func (g *DependencyGraph) addEdge(source, target reposource.PackageDependency) {
+	sourceKey, targetKey := g.addPackage(source), g.addPackage(target)
+	if sourceKey == targetKey {
+		return
+	}
+
+	if _, ok := g.edges[sourceKey]; !ok {
+		g.edges[sourceKey] = map[string]struct{}{}
+	}
+	g.edges[sourceKey][targetKey] = struct{}{}
+}
+
+// merge adds the packages, roots, and edges of other to g. The roots of other are resolved
+// before merging, so that packages of other that aren't reachable from its recorded roots
+// don't become reachable through the roots of g.
+func (g *DependencyGraph) merge(other *DependencyGraph) {
+	for key, pkg := range other.packages {
+		if _, ok := g.packages[key]; !ok {
+			g.packages[key] = pkg
+		}
+	}
+	for _, root := range other.Roots() {
+		g.roots[root.PackageManagerSyntax()] = struct{}{}
+	}
+	for source, targets := range other.edges {
+		if _, ok := g.edges[source]; !ok {
+			g.edges[source] = map[string]struct{}{}
+		}
+		for target := range targets {
+			g.edges[source][target] = struct{}{}
+		}
+	}
+}
+
+// Roots returns the direct dependencies of the graph sorted by their package manager syntax.
+//
+// Not all lockfiles tell us which packages are direct dependencies. Packages that are not
+// reachable from any recorded root are considered direct dependencies when no other package
+// depends on them. Remaining unreachable packages are members of dependency cycles, and are
+// promoted to direct dependencies as well, so that every package can be reached from a root.
+func (g *DependencyGraph) Roots() []reposource.PackageDependency {
+	roots := make(map[string]struct{}, len(g.roots))
+	for key := range g.roots {
+		roots[key] = struct{}{}
+	}
+
+	hasIncomingEdges := map[string]struct{}{}
+	for _, targets := range g.edges {
+		for target := range targets {
+			hasIncomingEdges[target] = struct{}{}
+		}
+	}
+
+	visited := map[string]struct{}{}
+	var visit func(key string)
+	visit = func(key string) {
+		if _, ok := visited[key]; ok {
+			return
+		}
+		visited[key] = struct{}{}
+
+		for target := range g.edges[key] {
+			visit(target)
+		}
+	}
+
+	for key := range roots {
+		visit(key)
+	}
+	for _, key := range sortedKeys(g.packages) {
+		if _, ok := visited[key]; ok {
+			continue
+		}
+		if _, ok := hasIncomingEdges[key]; !ok {
+			roots[key] = struct{}{}
+			visit(key)
+		}
+	}
+	for _, key := range sortedKeys(g.packages) {
+		if _, ok := visited[key]; !ok {
+			roots[key] = struct{}{}
+			visit(key)
+		}
+	}
+
+	out := make([]reposource.PackageDependency, 0, len(roots))
+	for _, key := range sortedKeys(roots) {
+		out = append(out, g.packages[key])
+	}
+	return out
+}
+
+// Edges returns all pairs of packages where the first package depends on the second one,
+// sorted by their package manager syntax.
+func (g *DependencyGraph) Edges() [][2]reposource.PackageDependency {
+	var out [][2]reposource.PackageDependency
+	for _, source := range sortedKeys(g.edges) {
+		for _, target := range sortedKeys(g.edges[source]) {
+			out = append(out, [2]reposource.PackageDependency{g.packages[source], g.packages[target]})
+		}
+	}
+	return out
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
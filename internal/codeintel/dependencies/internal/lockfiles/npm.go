@@ -18,20 +18,43 @@ import (
 type packageLockDependency struct {
 	Version      string
 	Dev          bool
+	Requires     map[string]string
 	Dependencies map[string]*packageLockDependency
 }
 
-func parsePackageLockFile(r io.Reader) ([]reposource.PackageDependency, error) {
+func parsePackageLockFile(r io.Reader) ([]reposource.PackageDependency, *DependencyGraph, error) {
 	var lockFile struct {
 		Dependencies map[string]*packageLockDependency
+		// Packages is only present in lockfiles of version 2 and later. The package
+		// with an empty key describes the project itself.
+		Packages map[string]struct {
+			Dependencies    map[string]string
+			DevDependencies map[string]string
+		}
 	}
 
 	err := json.NewDecoder(r).Decode(&lockFile)
 	if err != nil {
-		return nil, errors.Errorf("decode error: %w", err)
+		return nil, nil, errors.Errorf("decode error: %w", err)
+	}
+
+	deps, err := parsePackageLockDependencies(lockFile.Dependencies)
+
+	graph := newDependencyGraph()
+	addPackageLockEdges(graph, nil, lockFile.Dependencies)
+
+	root := lockFile.Packages[""]
+	for _, names := range []map[string]string{root.Dependencies, root.DevDependencies} {
+		for name := range names {
+			if d, ok := lockFile.Dependencies[name]; ok {
+				if dep, err := reposource.ParseNpmDependency(name + "@" + d.Version); err == nil {
+					graph.addRoot(dep)
+				}
+			}
+		}
 	}
 
-	return parsePackageLockDependencies(lockFile.Dependencies)
+	return deps, graph, err
 }
 
 func parsePackageLockDependencies(in map[string]*packageLockDependency) ([]reposource.PackageDependency, error) {
@@ -59,6 +82,39 @@ func parsePackageLockDependencies(in map[string]*packageLockDependency) ([]repos
 	return out, errs
 }
 
+// addPackageLockEdges adds an edge for every requirement of the given packages. Like node
+// does, requirements are resolved to the package installed in the nearest node_modules
+// directory: first the package's own nested dependencies, then the ones of its ancestors.
+func addPackageLockEdges(g *DependencyGraph, ancestors []map[string]*packageLockDependency, in map[string]*packageLockDependency) {
+	scopes := append(ancestors[:len(ancestors):len(ancestors)], in)
+
+	for name, d := range in {
+		dep, err := reposource.ParseNpmDependency(name + "@" + d.Version)
+		if err != nil {
+			continue
+		}
+		g.addPackage(dep)
+
+		for required := range d.Requires {
+			target, ok := d.Dependencies[required]
+			for i := len(scopes) - 1; !ok && i >= 0; i-- {
+				target, ok = scopes[i][required]
+			}
+			if !ok {
+				continue
+			}
+
+			if targetDep, err := reposource.ParseNpmDependency(required + "@" + target.Version); err == nil {
+				g.addEdge(dep, targetDep)
+			}
+		}
+
+		if d.Dependencies != nil {
+			addPackageLockEdges(g, scopes, d.Dependencies)
+		}
+	}
+}
+
 //
 // yarn.lock
 //
@@ -7,14 +7,29 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 )
 
-type parser func(io.Reader) ([]reposource.PackageDependency, error)
+// parser extracts the packages listed in a lockfile. Parsers of lockfiles that describe
+// how their packages depend on each other also return a dependency graph.
+type parser func(io.Reader) ([]reposource.PackageDependency, *DependencyGraph, error)
 
 var parsers = map[string]parser{
 	"package-lock.json": parsePackageLockFile,
-	"yarn.lock":         parseYarnLockFile,
-	"go.mod":            parseGoModFile,
+	"yarn.lock":         withoutGraph(parseYarnLockFile),
+	"go.mod":            withoutGraph(parseGoModFile),
 	"poetry.lock":       parsePoetryLockFile,
-	"Pipfile.lock":      parsePipfileLockFile,
+	"Pipfile.lock":      withoutGraph(parsePipfileLockFile),
+	"Cargo.lock":        parseCargoLockFile,
+	"Gemfile.lock":      parseGemfileLockFile,
+	"composer.lock":     withoutGraph(parseComposerLockFile),
+	"pnpm-lock.yaml":    parsePnpmLockFile,
+	"gradle.lockfile":   withoutGraph(parseGradleLockFile),
+}
+
+// withoutGraph adapts the parser of a lockfile that only lists packages.
+func withoutGraph(parse func(io.Reader) ([]reposource.PackageDependency, error)) parser {
+	return func(r io.Reader) ([]reposource.PackageDependency, *DependencyGraph, error) {
+		deps, err := parse(r)
+		return deps, nil, err
+	}
 }
 
 // lockfilePathspecs is the list of git pathspecs that match lockfiles.
@@ -30,7 +30,7 @@ func TestParse(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			deps, err := parse(lockFile, f)
+			deps, graph, err := parse(lockFile, f)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -44,6 +44,23 @@ func TestParse(t *testing.T) {
 
 			g := goldie.New(t, goldie.WithFixtureDir(lockFile))
 			g.AssertJson(t, name, got)
+
+			if graph == nil {
+				return
+			}
+
+			gotGraph := struct {
+				Roots []string
+				Edges [][2]string
+			}{}
+			for _, root := range graph.Roots() {
+				gotGraph.Roots = append(gotGraph.Roots, root.PackageManagerSyntax())
+			}
+			for _, edge := range graph.Edges() {
+				gotGraph.Edges = append(gotGraph.Edges, [2]string{edge[0].PackageManagerSyntax(), edge[1].PackageManagerSyntax()})
+			}
+
+			g.AssertJson(t, name+".graph", gotGraph)
 		})
 	}
 }
@@ -0,0 +1,137 @@
+package lockfiles
+
+import (
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+//
+// pnpm-lock.yaml
+//
+
+// parsePnpmLockFile extracts all packages from a pnpm-lock.yaml file. The keys of the
+// "packages" object identify resolved packages, and look like "/name/1.2.3" or
+// "/@scope/name/1.2.3" in lockfile versions 5.x and like "/name@1.2.3" in 6.x. Either
+// may carry a suffix describing resolved peer dependencies, e.g. "/name/1.2.3_react@18.1.0".
+func parsePnpmLockFile(r io.Reader) ([]reposource.PackageDependency, *DependencyGraph, error) {
+	var lockfile struct {
+		// The top-level dependencies map names to versions in lockfile versions 5.x,
+		// and to objects with a "version" field in 6.x.
+		Dependencies         map[string]interface{} `yaml:"dependencies"`
+		DevDependencies      map[string]interface{} `yaml:"devDependencies"`
+		OptionalDependencies map[string]interface{} `yaml:"optionalDependencies"`
+		Packages             map[string]struct {
+			Dependencies         map[string]string `yaml:"dependencies"`
+			OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+		} `yaml:"packages"`
+	}
+
+	if err := yaml.NewDecoder(r).Decode(&lockfile); err != nil && err != io.EOF {
+		return nil, nil, errors.Errorf("error decoding pnpm-lock.yaml: %w", err)
+	}
+
+	var (
+		errs errors.MultiError
+		libs = make([]reposource.PackageDependency, 0, len(lockfile.Packages))
+		// packages maps package keys to the package they identify
+		packages = make(map[string]reposource.PackageDependency, len(lockfile.Packages))
+	)
+
+	for key := range lockfile.Packages {
+		name, version, ok := parsePnpmPackageKey(key)
+		if !ok {
+			// Tarball and git dependencies are keyed by their URL and aren't
+			// published to the registry
+			continue
+		}
+
+		dep, err := reposource.ParseNpmDependency(name + "@" + version)
+		if err != nil {
+			errs = errors.Append(errs, err)
+			continue
+		}
+		libs = append(libs, dep)
+		packages[key] = dep
+	}
+
+	// resolve returns the locked package a dependency entry refers to. Versions are
+	// formatted like the version part of the package keys, peer dependency suffix included.
+	resolve := func(name, version string) (reposource.PackageDependency, bool) {
+		for _, key := range []string{"/" + name + "/" + version, "/" + name + "@" + version} {
+			if dep, ok := packages[key]; ok {
+				return dep, true
+			}
+		}
+		return nil, false
+	}
+
+	graph := newDependencyGraph()
+	for _, deps := range []map[string]interface{}{lockfile.Dependencies, lockfile.DevDependencies, lockfile.OptionalDependencies} {
+		for name, v := range deps {
+			var version string
+			switch v := v.(type) {
+			case string:
+				version = v
+			case map[interface{}]interface{}:
+				version, _ = v["version"].(string)
+			}
+
+			if dep, ok := resolve(name, version); ok {
+				graph.addRoot(dep)
+			}
+		}
+	}
+
+	for key, pkg := range lockfile.Packages {
+		source, ok := packages[key]
+		if !ok {
+			continue
+		}
+		graph.addPackage(source)
+
+		for _, deps := range []map[string]string{pkg.Dependencies, pkg.OptionalDependencies} {
+			for name, version := range deps {
+				if target, ok := resolve(name, version); ok {
+					graph.addEdge(source, target)
+				}
+			}
+		}
+	}
+
+	return libs, graph, errs
+}
+
+func parsePnpmPackageKey(key string) (name, version string, ok bool) {
+	if !strings.HasPrefix(key, "/") {
+		return "", "", false
+	}
+	key = strings.TrimPrefix(key, "/")
+
+	// Strip the peer dependencies suffix
+	if i := strings.IndexAny(key, "_("); i != -1 {
+		key = key[:i]
+	}
+
+	// The version separator is the first "@" or "/" after the (possibly scoped) name
+	offset := 0
+	if strings.HasPrefix(key, "@") {
+		i := strings.Index(key, "/")
+		if i == -1 {
+			return "", "", false
+		}
+		offset = i + 1
+	}
+
+	i := strings.IndexAny(key[offset:], "@/")
+	if i == -1 {
+		return "", "", false
+	}
+
+	name, version = key[:offset+i], key[offset+i+1:]
+	return name, version, name != "" && version != ""
+}
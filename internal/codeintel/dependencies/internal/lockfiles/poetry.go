@@ -2,10 +2,12 @@ package lockfiles
 
 import (
 	"io"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -13,22 +15,49 @@ import (
 // poetry.lock
 //
 
-func parsePoetryLockFile(r io.Reader) ([]reposource.PackageDependency, error) {
+func parsePoetryLockFile(r io.Reader) ([]reposource.PackageDependency, *DependencyGraph, error) {
 	var lockfile struct {
 		Packages []struct {
-			Name    string `toml:"name"`
-			Version string `toml:"version"`
+			Name         string                 `toml:"name"`
+			Version      string                 `toml:"version"`
+			Dependencies map[string]interface{} `toml:"dependencies"`
 		} `toml:"package"`
 	}
 
 	if _, err := toml.DecodeReader(r, &lockfile); err != nil {
-		return nil, errors.Errorf("error decoding poetry lockfile: %w", err)
+		return nil, nil, errors.Errorf("error decoding poetry lockfile: %w", err)
 	}
 
-	libs := make([]reposource.PackageDependency, 0, len(lockfile.Packages))
+	var (
+		libs = make([]reposource.PackageDependency, 0, len(lockfile.Packages))
+		// packages maps normalized package names to the locked package of that name
+		packages = make(map[string]reposource.PackageDependency, len(lockfile.Packages))
+	)
 	for _, pkg := range lockfile.Packages {
-		libs = append(libs, reposource.NewPythonDependency(pkg.Name, pkg.Version))
+		dep := reposource.NewPythonDependency(pkg.Name, pkg.Version)
+		libs = append(libs, dep)
+		packages[normalizePythonPackageName(pkg.Name)] = dep
 	}
 
-	return libs, nil
+	// poetry.lock doesn't record the direct dependencies of the project, those are only
+	// listed in pyproject.toml.
+	graph := newDependencyGraph()
+	for i, pkg := range lockfile.Packages {
+		graph.addPackage(libs[i])
+		for name := range pkg.Dependencies {
+			if target, ok := packages[normalizePythonPackageName(name)]; ok {
+				graph.addEdge(libs[i], target)
+			}
+		}
+	}
+
+	return libs, graph, nil
 }
+
+// normalizePythonPackageName normalizes package names as described in
+// https://peps.python.org/pep-0503/#normalized-names.
+func normalizePythonPackageName(name string) string {
+	return strings.ToLower(pythonPackageNameSeparators.ReplaceAllString(name, "-"))
+}
+
+var pythonPackageNameSeparators = lazyregexp.New(`[-_.]+`)
@@ -30,19 +30,37 @@ func newService(gitSvc GitService, observationContext *observation.Context) *Ser
 	}
 }
 
-func (s *Service) ListDependencies(ctx context.Context, repo api.RepoName, rev string) (deps []reposource.PackageDependency, err error) {
+// ListDependencies returns the packages listed in all lockfiles of the given repository at the
+// given revision, along with the graph describing how those packages depend on each other.
+func (s *Service) ListDependencies(ctx context.Context, repo api.RepoName, rev string) (deps []reposource.PackageDependency, graph *DependencyGraph, err error) {
 	ctx, _, endObservation := s.operations.listDependencies.With(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.String("repo", string(repo)),
 		log.String("rev", rev),
 	}})
 	defer endObservation(1, observation.Args{})
 
-	err = s.StreamDependencies(ctx, repo, rev, func(d reposource.PackageDependency) error {
-		deps = append(deps, d)
+	set := map[string]struct{}{}
+	graph = newDependencyGraph()
+	err = s.parseLockfiles(ctx, repo, rev, func(ds []reposource.PackageDependency, g *DependencyGraph) error {
+		for _, d := range ds {
+			k := d.PackageManagerSyntax()
+			if _, ok := set[k]; !ok {
+				set[k] = struct{}{}
+				deps = append(deps, d)
+			}
+		}
+
+		if g == nil {
+			g = flatDependencyGraph(ds)
+		}
+		graph.merge(g)
 		return nil
 	})
+	if err != nil {
+		return nil, nil, err
+	}
 
-	return deps, err
+	return deps, graph, nil
 }
 
 func (s *Service) StreamDependencies(ctx context.Context, repo api.RepoName, rev string, cb func(reposource.PackageDependency) error) (err error) {
@@ -52,6 +70,24 @@ func (s *Service) StreamDependencies(ctx context.Context, repo api.RepoName, rev
 	}})
 	defer endObservation(1, observation.Args{})
 
+	set := map[string]struct{}{}
+	return s.parseLockfiles(ctx, repo, rev, func(ds []reposource.PackageDependency, _ *DependencyGraph) error {
+		for _, d := range ds {
+			k := d.PackageManagerSyntax()
+			if _, ok := set[k]; !ok {
+				set[k] = struct{}{}
+				if err := cb(d); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// parseLockfiles invokes the given callback with the packages and the dependency graph of each
+// lockfile of the given repository at the given revision.
+func (s *Service) parseLockfiles(ctx context.Context, repo api.RepoName, rev string, cb func([]reposource.PackageDependency, *DependencyGraph) error) error {
 	// First call ls-files to find matching lockfiles, then pass those literal paths to archive.
 	//
 	// This ls-files call might appear redundant with the subsequent archive call, but it turns out
@@ -96,50 +132,43 @@ func (s *Service) StreamDependencies(ctx context.Context, repo api.RepoName, rev
 		return err
 	}
 
-	set := map[string]struct{}{}
 	for _, f := range zr.File {
 		if f.Mode().IsDir() {
 			continue
 		}
 
-		ds, err := parseZipLockfile(f)
+		ds, graph, err := parseZipLockfile(f)
 		if err != nil {
 			return errors.Wrapf(err, "failed to parse %q", f.Name)
 		}
 
-		for _, d := range ds {
-			k := d.PackageManagerSyntax()
-			if _, ok := set[k]; !ok {
-				set[k] = struct{}{}
-				if err = cb(d); err != nil {
-					return err
-				}
-			}
+		if err := cb(ds, graph); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-func parseZipLockfile(f *zip.File) ([]reposource.PackageDependency, error) {
+func parseZipLockfile(f *zip.File) ([]reposource.PackageDependency, *DependencyGraph, error) {
 	r, err := f.Open()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer r.Close()
 
-	deps, err := parse(f.Name, r)
+	deps, graph, err := parse(f.Name, r)
 	if err != nil {
 		log15.Warn("failed to parse some lockfile dependencies", "error", err, "file", f.Name)
 	}
 
-	return deps, nil
+	return deps, graph, nil
 }
 
-func parse(file string, r io.Reader) ([]reposource.PackageDependency, error) {
+func parse(file string, r io.Reader) ([]reposource.PackageDependency, *DependencyGraph, error) {
 	parser, ok := parsers[path.Base(file)]
 	if !ok {
-		return nil, ErrUnsupported
+		return nil, nil, ErrUnsupported
 	}
 	return parser(r)
 }
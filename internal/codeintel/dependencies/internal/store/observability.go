@@ -11,6 +11,7 @@ type operations struct {
 	deleteDependencyReposByID    *observation.Operation
 	listDependencyRepos          *observation.Operation
 	lockfileDependencies         *observation.Operation
+	lockfileDependencyGraph      *observation.Operation
 	lockfileDependents           *observation.Operation
 	preciseDependencies          *observation.Operation
 	preciseDependents            *observation.Operation
@@ -40,6 +41,7 @@ func newOperations(observationContext *observation.Context) *operations {
 		deleteDependencyReposByID:    op("DeleteDependencyReposByID"),
 		listDependencyRepos:          op("ListDependencyRepos"),
 		lockfileDependencies:         op("LockfileDependencies"),
+		lockfileDependencyGraph:      op("LockfileDependencyGraph"),
 		lockfileDependents:           op("LockfileDependents"),
 		preciseDependencies:          op("PreciseDependencies"),
 		preciseDependents:            op("PreciseDependents"),
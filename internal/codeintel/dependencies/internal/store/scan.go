@@ -1,6 +1,8 @@
 package store
 
 import (
+	"database/sql"
+
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/types"
@@ -17,6 +19,54 @@ var scanPackageDependencies = basestore.NewSliceScanner(func(s dbutil.Scanner) (
 	return v, err
 })
 
+//
+// Scans `[]lockfileReference`
+
+type lockfileReference struct {
+	id  int
+	pkg shared.PackageDependencyLiteral
+}
+
+var scanLockfileReferences = basestore.NewSliceScanner(func(s dbutil.Scanner) (lockfileReference, error) {
+	var v lockfileReference
+	err := s.Scan(&v.id, &v.pkg.RepoNameValue, &v.pkg.GitTagFromVersionValue, &v.pkg.SchemeValue, &v.pkg.PackageSyntaxValue, &v.pkg.PackageVersionValue)
+	return v, err
+})
+
+//
+// Scans `[][2]shared.PackageDependency`, where the source package is nil for direct dependencies
+
+var scanLockfileEdges = basestore.NewSliceScanner(func(s dbutil.Scanner) (edge [2]shared.PackageDependency, _ error) {
+	var (
+		source                                   [5]sql.NullString
+		repoName, revSpec, scheme, name, version string
+	)
+	if err := s.Scan(
+		&source[0], &source[1], &source[2], &source[3], &source[4],
+		&repoName, &revSpec, &scheme, &name, &version,
+	); err != nil {
+		return edge, err
+	}
+
+	if source[0].Valid {
+		edge[0] = shared.PackageDependencyLiteral{
+			RepoNameValue:          api.RepoName(source[0].String),
+			GitTagFromVersionValue: source[1].String,
+			SchemeValue:            source[2].String,
+			PackageSyntaxValue:     source[3].String,
+			PackageVersionValue:    source[4].String,
+		}
+	}
+	edge[1] = shared.PackageDependencyLiteral{
+		RepoNameValue:          api.RepoName(repoName),
+		GitTagFromVersionValue: revSpec,
+		SchemeValue:            scheme,
+		PackageSyntaxValue:     name,
+		PackageVersionValue:    version,
+	}
+	return edge, nil
+})
+
 //
 // Scans `[]api.RepoCommit`
 
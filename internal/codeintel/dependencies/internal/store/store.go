@@ -23,7 +23,8 @@ type Store interface {
 	PreciseDependencies(ctx context.Context, repoName, commit string) (deps map[api.RepoName]types.RevSpecSet, err error)
 	PreciseDependents(ctx context.Context, repoName, commit string) (deps map[api.RepoName]types.RevSpecSet, err error)
 	LockfileDependencies(ctx context.Context, repoName, commit string) (deps []shared.PackageDependency, found bool, err error)
-	UpsertLockfileDependencies(ctx context.Context, repoName, commit string, deps []shared.PackageDependency) (err error)
+	LockfileDependencyGraph(ctx context.Context, repoName, commit string) (graph shared.DependencyGraph, found bool, err error)
+	UpsertLockfileDependencies(ctx context.Context, repoName, commit string, deps []shared.PackageDependency, graph shared.DependencyGraph) (err error)
 	SelectRepoRevisionsToResolve(ctx context.Context, batchSize int, minimumCheckInterval time.Duration) (_ map[string][]string, err error)
 	UpdateResolvedRevisions(ctx context.Context, repoRevsToResolvedRevs map[string]map[string]string) (err error)
 	LockfileDependents(ctx context.Context, repoName, commit string) (deps []api.RepoCommit, err error)
@@ -164,14 +165,80 @@ FROM codeintel_lockfiles
 WHERE repository_id = (SELECT id FROM repo WHERE name = %s) AND commit_bytea = %s
 `
 
+// LockfileDependencyGraph returns the dependency graph of a previous lockfiles result for the
+// given repository and commit. Results that were stored without a dependency graph are returned
+// as a graph in which every package dependency is a direct dependency. It is assumed that the
+// given commit is the canonical 40-character hash.
+func (s *store) LockfileDependencyGraph(ctx context.Context, repoName, commit string) (graph shared.DependencyGraph, found bool, err error) {
+	ctx, _, endObservation := s.operations.lockfileDependencyGraph.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("repoName", repoName),
+		log.String("commit", commit),
+	}})
+	defer func() {
+		endObservation(1, observation.Args{LogFields: []log.Field{
+			log.Bool("found", found),
+			log.Int("numRoots", len(graph.Roots)),
+			log.Int("numEdges", len(graph.Edges)),
+		}})
+	}()
+
+	edges, err := scanLockfileEdges(s.db.Query(ctx, sqlf.Sprintf(
+		lockfileDependencyGraphQuery,
+		repoName,
+		dbutil.CommitBytea(commit),
+	)))
+	if err != nil {
+		return shared.DependencyGraph{}, false, err
+	}
+
+	if len(edges) == 0 {
+		deps, found, err := s.LockfileDependencies(ctx, repoName, commit)
+		return shared.DependencyGraph{Roots: deps}, found, err
+	}
+
+	for _, edge := range edges {
+		if edge[0] == nil {
+			graph.Roots = append(graph.Roots, edge[1])
+		} else {
+			graph.Edges = append(graph.Edges, edge)
+		}
+	}
+
+	return graph, true, nil
+}
+
+const lockfileDependencyGraphQuery = `
+-- source: internal/codeintel/dependencies/internal/store/store.go:LockfileDependencyGraph
+SELECT
+	sr.repository_name,
+	sr.revspec,
+	sr.package_scheme,
+	sr.package_name,
+	sr.package_version,
+	tr.repository_name,
+	tr.revspec,
+	tr.package_scheme,
+	tr.package_name,
+	tr.package_version
+FROM codeintel_lockfile_edges e
+JOIN codeintel_lockfiles lf ON lf.id = e.codeintel_lockfile_id
+LEFT JOIN codeintel_lockfile_references sr ON sr.id = e.source_reference_id
+JOIN codeintel_lockfile_references tr ON tr.id = e.target_reference_id
+WHERE lf.repository_id = (SELECT id FROM repo WHERE name = %s) AND lf.commit_bytea = %s
+ORDER BY sr.repository_name NULLS FIRST, sr.revspec, tr.repository_name, tr.revspec
+`
+
 // UpsertLockfileDependencies inserts the given package dependencies if they do not exist
-// and inserts a new lockfiles result for the given repository and commit. It is assumed
-// that the given commit is the canonical 40-character hash.
-func (s *store) UpsertLockfileDependencies(ctx context.Context, repoName, commit string, deps []shared.PackageDependency) (err error) {
+// and inserts a new lockfiles result for the given repository and commit, along with the
+// edges of the given dependency graph. It is assumed that the given commit is the canonical
+// 40-character hash.
+func (s *store) UpsertLockfileDependencies(ctx context.Context, repoName, commit string, deps []shared.PackageDependency, graph shared.DependencyGraph) (err error) {
 	ctx, _, endObservation := s.operations.upsertLockfileDependencies.With(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.String("repoName", repoName),
 		log.String("commit", commit),
 		log.Int("numDeps", len(deps)),
+		log.Int("numRoots", len(graph.Roots)),
+		log.Int("numEdges", len(graph.Edges)),
 	}})
 	defer endObservation(1, observation.Args{})
 
@@ -196,22 +263,43 @@ func (s *store) UpsertLockfileDependencies(ctx context.Context, repoName, commit
 		return err
 	}
 
-	ids, err := basestore.ScanInts(tx.db.Query(ctx, sqlf.Sprintf(upsertLockfileReferencesQuery)))
+	references, err := scanLockfileReferences(tx.db.Query(ctx, sqlf.Sprintf(upsertLockfileReferencesQuery)))
 	if err != nil {
 		return err
 	}
-	if ids == nil {
-		ids = []int{}
+
+	ids := make([]int, 0, len(references))
+	idsByPackage := make(map[shared.PackageDependencyLiteral]int, len(references))
+	for _, reference := range references {
+		ids = append(ids, reference.id)
+		idsByPackage[reference.pkg] = reference.id
 	}
 	idsArray := pq.Array(ids)
 
-	return tx.db.Exec(ctx, sqlf.Sprintf(
+	lockfileID, ok, err := basestore.ScanFirstInt(tx.db.Query(ctx, sqlf.Sprintf(
 		insertLockfilesQuery,
 		dbutil.CommitBytea(commit),
 		idsArray,
 		repoName,
 		idsArray,
-	))
+	)))
+	if err != nil || !ok {
+		return err
+	}
+
+	// Last write wins
+	if err := tx.db.Exec(ctx, sqlf.Sprintf(deleteLockfileEdgesQuery, lockfileID)); err != nil {
+		return err
+	}
+
+	return batch.InsertValues(
+		ctx,
+		tx.db.Handle().DB(),
+		"codeintel_lockfile_edges",
+		batch.MaxNumPostgresParameters,
+		[]string{"codeintel_lockfile_id", "source_reference_id", "target_reference_id"},
+		populateLockfileEdgeChannel(lockfileID, graph, idsByPackage),
+	)
 }
 
 const temporaryLockfileReferencesTableQuery = `
@@ -231,10 +319,10 @@ WITH ins AS (
 	INSERT INTO codeintel_lockfile_references (repository_name, revspec, package_scheme, package_name, package_version)
 	SELECT repository_name, revspec, package_scheme, package_name, package_version FROM t_codeintel_lockfile_references
 	ON CONFLICT DO NOTHING
-	RETURNING id
+	RETURNING id, repository_name, revspec, package_scheme, package_name, package_version
 ),
 duplicates AS (
-	SELECT id
+	SELECT r.id, r.repository_name, r.revspec, r.package_scheme, r.package_name, r.package_version
 	FROM t_codeintel_lockfile_references t
 	JOIN codeintel_lockfile_references r
 	ON
@@ -244,8 +332,8 @@ duplicates AS (
 		r.package_name = t.package_name AND
 		r.package_version = t.package_version
 )
-SELECT id FROM ins UNION
-SELECT id FROM duplicates
+SELECT * FROM ins UNION
+SELECT * FROM duplicates
 ORDER BY id
 `
 
@@ -262,6 +350,12 @@ WHERE name = %s
 -- Last write wins
 ON CONFLICT (repository_id, commit_bytea) DO UPDATE
 SET codeintel_lockfile_reference_ids = %s
+RETURNING id
+`
+
+const deleteLockfileEdgesQuery = `
+-- source: internal/codeintel/dependencies/internal/store/store.go:UpsertLockfileDependencies
+DELETE FROM codeintel_lockfile_edges WHERE codeintel_lockfile_id = %s
 `
 
 // populatePackageDependencyChannel populates a channel with the given dependencies for bulk insertion.
@@ -285,6 +379,43 @@ func populatePackageDependencyChannel(deps []shared.PackageDependency) <-chan []
 	return ch
 }
 
+// populateLockfileEdgeChannel populates a channel with the edges of the given graph for bulk
+// insertion. Direct dependencies are inserted as edges without a source reference. Packages
+// that don't have a reference identifier are skipped.
+func populateLockfileEdgeChannel(lockfileID int, graph shared.DependencyGraph, idsByPackage map[shared.PackageDependencyLiteral]int) <-chan []any {
+	ch := make(chan []any, len(graph.Roots)+len(graph.Edges))
+
+	go func() {
+		defer close(ch)
+
+		for _, root := range graph.Roots {
+			if id, ok := idsByPackage[packageDependencyLiteral(root)]; ok {
+				ch <- []any{lockfileID, nil, id}
+			}
+		}
+
+		for _, edge := range graph.Edges {
+			sourceID, ok1 := idsByPackage[packageDependencyLiteral(edge[0])]
+			targetID, ok2 := idsByPackage[packageDependencyLiteral(edge[1])]
+			if ok1 && ok2 {
+				ch <- []any{lockfileID, sourceID, targetID}
+			}
+		}
+	}()
+
+	return ch
+}
+
+func packageDependencyLiteral(dep shared.PackageDependency) shared.PackageDependencyLiteral {
+	return shared.PackageDependencyLiteral{
+		RepoNameValue:          dep.RepoName(),
+		GitTagFromVersionValue: dep.GitTagFromVersion(),
+		SchemeValue:            dep.Scheme(),
+		PackageSyntaxValue:     dep.PackageSyntax(),
+		PackageVersionValue:    dep.PackageVersion(),
+	}
+}
+
 // SelectRepoRevisionsToResolve selects the references lockfile packages to
 // possibly resolve them to repositories on the Sourcegraph instance.
 func (s *store) SelectRepoRevisionsToResolve(ctx context.Context, batchSize int, minimumCheckInterval time.Duration) (_ map[string][]string, err error) {
@@ -194,14 +194,14 @@ func TestLockfileDependencies(t *testing.T) {
 	}
 
 	for commit, deps := range commits {
-		if err := store.UpsertLockfileDependencies(ctx, "foo", commit, deps); err != nil {
+		if err := store.UpsertLockfileDependencies(ctx, "foo", commit, deps, shared.DependencyGraph{}); err != nil {
 			t.Fatalf("unexpected error upserting lockfile dependencies: %s", err)
 		}
 	}
 
 	// Update twice to show idempotency
 	for commit, expected := range commits {
-		if err := store.UpsertLockfileDependencies(ctx, "foo", commit, expected); err != nil {
+		if err := store.UpsertLockfileDependencies(ctx, "foo", commit, expected, shared.DependencyGraph{}); err != nil {
 			t.Fatalf("unexpected error upserting lockfile dependencies: %s", err)
 		}
 	}
@@ -230,6 +230,67 @@ func TestLockfileDependencies(t *testing.T) {
 	}
 }
 
+func TestLockfileDependencyGraph(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := database.NewDB(dbtest.NewDB(t))
+	store := New(db, &observation.TestContext)
+
+	if _, err := db.ExecContext(ctx, `INSERT INTO repo (name) VALUES ('foo')`); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	packageA := shared.TestPackageDependencyLiteral(api.RepoName("A"), "1", "2", "3", "4")
+	packageB := shared.TestPackageDependencyLiteral(api.RepoName("B"), "2", "3", "4", "5")
+	packageC := shared.TestPackageDependencyLiteral(api.RepoName("C"), "3", "4", "5", "6")
+	packageD := shared.TestPackageDependencyLiteral(api.RepoName("D"), "4", "5", "6", "7")
+
+	graph := shared.DependencyGraph{
+		Roots: []shared.PackageDependency{packageA, packageB},
+		Edges: [][2]shared.PackageDependency{
+			{packageA, packageC},
+			{packageC, packageD},
+		},
+	}
+
+	// Upsert twice to show that edges are replaced rather than duplicated
+	for i := 0; i < 2; i++ {
+		if err := store.UpsertLockfileDependencies(ctx, "foo", "cafebabe", []shared.PackageDependency{packageA, packageB, packageC, packageD}, graph); err != nil {
+			t.Fatalf("unexpected error upserting lockfile dependencies: %s", err)
+		}
+	}
+	if err := store.UpsertLockfileDependencies(ctx, "foo", "deadbeef", []shared.PackageDependency{packageA, packageB}, shared.DependencyGraph{}); err != nil {
+		t.Fatalf("unexpected error upserting lockfile dependencies: %s", err)
+	}
+
+	testCases := []struct {
+		commit        string
+		expectedGraph shared.DependencyGraph
+		expectedFound bool
+	}{
+		{commit: "cafebabe", expectedGraph: graph, expectedFound: true},
+		// Results without edges are flat graphs
+		{commit: "deadbeef", expectedGraph: shared.DependencyGraph{Roots: []shared.PackageDependency{packageA, packageB}}, expectedFound: true},
+		{commit: "d00dd00d", expectedFound: false},
+	}
+
+	for _, testCase := range testCases {
+		graph, found, err := store.LockfileDependencyGraph(ctx, "foo", testCase.commit)
+		if err != nil {
+			t.Fatalf("unexpected error querying lockfile dependency graph of %s: %s", testCase.commit, err)
+		}
+		if found != testCase.expectedFound {
+			t.Fatalf("unexpected found value for %s. want=%v have=%v", testCase.commit, testCase.expectedFound, found)
+		}
+		if diff := cmp.Diff(testCase.expectedGraph, graph); diff != "" {
+			t.Fatalf("unexpected dependency graph for commit %s (-want, +have): %s", testCase.commit, diff)
+		}
+	}
+}
+
 func TestUpsertDependencyRepo(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -350,7 +411,7 @@ func TestSelectRepoRevisionsToResolve(t *testing.T) {
 	repoName := "repo-1"
 	packages := []shared.PackageDependency{packageA, packageB, packageC, packageD, packageE}
 
-	if err := store.UpsertLockfileDependencies(ctx, repoName, commit, packages); err != nil {
+	if err := store.UpsertLockfileDependencies(ctx, repoName, commit, packages, shared.DependencyGraph{}); err != nil {
 		t.Fatalf("unexpected error upserting lockfile dependencies: %s", err)
 	}
 
@@ -436,7 +497,7 @@ func TestUpdateResolvedRevisions(t *testing.T) {
 		packageD = shared.TestPackageDependencyLiteral(api.RepoName("pkg-4"), "v4", "5", "6", "7")
 	)
 
-	if err := store.UpsertLockfileDependencies(ctx, "repo-1", "cafebabe", []shared.PackageDependency{packageA, packageB, packageC, packageD}); err != nil {
+	if err := store.UpsertLockfileDependencies(ctx, "repo-1", "cafebabe", []shared.PackageDependency{packageA, packageB, packageC, packageD}, shared.DependencyGraph{}); err != nil {
 		t.Fatalf("unexpected error upserting lockfile dependencies: %s", err)
 	}
 
@@ -494,13 +555,13 @@ func TestLockfileDependents(t *testing.T) {
 		packageD = shared.TestPackageDependencyLiteral(api.RepoName("pkg-4"), "v4", "5", "6", "7")
 	)
 
-	if err := store.UpsertLockfileDependencies(ctx, "repo-1", "cafebabe", []shared.PackageDependency{packageA, packageB, packageC, packageD}); err != nil {
+	if err := store.UpsertLockfileDependencies(ctx, "repo-1", "cafebabe", []shared.PackageDependency{packageA, packageB, packageC, packageD}, shared.DependencyGraph{}); err != nil {
 		t.Fatalf("unexpected error upserting lockfile dependencies: %s", err)
 	}
-	if err := store.UpsertLockfileDependencies(ctx, "repo-2", "cafebeef", []shared.PackageDependency{packageB}); err != nil {
+	if err := store.UpsertLockfileDependencies(ctx, "repo-2", "cafebeef", []shared.PackageDependency{packageB}, shared.DependencyGraph{}); err != nil {
 		t.Fatalf("unexpected error upserting lockfile dependencies: %s", err)
 	}
-	if err := store.UpsertLockfileDependencies(ctx, "repo-3", "d00dd00d", []shared.PackageDependency{packageC}); err != nil {
+	if err := store.UpsertLockfileDependencies(ctx, "repo-3", "d00dd00d", []shared.PackageDependency{packageC}, shared.DependencyGraph{}); err != nil {
 		t.Fatalf("unexpected error upserting lockfile dependencies: %s", err)
 	}
 
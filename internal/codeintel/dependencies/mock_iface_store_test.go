@@ -32,6 +32,9 @@ type MockStore struct {
 	// LockfileDependenciesFunc is an instance of a mock function object
 	// controlling the behavior of the method LockfileDependencies.
 	LockfileDependenciesFunc *StoreLockfileDependenciesFunc
+	// LockfileDependencyGraphFunc is an instance of a mock function object
+	// controlling the behavior of the method LockfileDependencyGraph.
+	LockfileDependencyGraphFunc *StoreLockfileDependencyGraphFunc
 	// LockfileDependentsFunc is an instance of a mock function object
 	// controlling the behavior of the method LockfileDependents.
 	LockfileDependentsFunc *StoreLockfileDependentsFunc
@@ -76,6 +79,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		LockfileDependencyGraphFunc: &StoreLockfileDependencyGraphFunc{
+			defaultHook: func(context.Context, string, string) (r0 shared.DependencyGraph, r1 bool, r2 error) {
+				return
+			},
+		},
 		LockfileDependentsFunc: &StoreLockfileDependentsFunc{
 			defaultHook: func(context.Context, string, string) (r0 []api.RepoCommit, r1 error) {
 				return
@@ -107,7 +115,7 @@ func NewMockStore() *MockStore {
 			},
 		},
 		UpsertLockfileDependenciesFunc: &StoreUpsertLockfileDependenciesFunc{
-			defaultHook: func(context.Context, string, string, []shared.PackageDependency) (r0 error) {
+			defaultHook: func(context.Context, string, string, []shared.PackageDependency, shared.DependencyGraph) (r0 error) {
 				return
 			},
 		},
@@ -133,6 +141,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.LockfileDependencies")
 			},
 		},
+		LockfileDependencyGraphFunc: &StoreLockfileDependencyGraphFunc{
+			defaultHook: func(context.Context, string, string) (shared.DependencyGraph, bool, error) {
+				panic("unexpected invocation of MockStore.LockfileDependencyGraph")
+			},
+		},
 		LockfileDependentsFunc: &StoreLockfileDependentsFunc{
 			defaultHook: func(context.Context, string, string) ([]api.RepoCommit, error) {
 				panic("unexpected invocation of MockStore.LockfileDependents")
@@ -164,7 +177,7 @@ func NewStrictMockStore() *MockStore {
 			},
 		},
 		UpsertLockfileDependenciesFunc: &StoreUpsertLockfileDependenciesFunc{
-			defaultHook: func(context.Context, string, string, []shared.PackageDependency) error {
+			defaultHook: func(context.Context, string, string, []shared.PackageDependency, shared.DependencyGraph) error {
 				panic("unexpected invocation of MockStore.UpsertLockfileDependencies")
 			},
 		},
@@ -184,6 +197,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		LockfileDependenciesFunc: &StoreLockfileDependenciesFunc{
 			defaultHook: i.LockfileDependencies,
 		},
+		LockfileDependencyGraphFunc: &StoreLockfileDependencyGraphFunc{
+			defaultHook: i.LockfileDependencyGraph,
+		},
 		LockfileDependentsFunc: &StoreLockfileDependentsFunc{
 			defaultHook: i.LockfileDependents,
 		},
@@ -544,6 +560,121 @@ func (c StoreLockfileDependenciesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// StoreLockfileDependencyGraphFunc describes the behavior when the
+// LockfileDependencyGraph method of the parent MockStore instance is
+// invoked.
+type StoreLockfileDependencyGraphFunc struct {
+	defaultHook func(context.Context, string, string) (shared.DependencyGraph, bool, error)
+	hooks       []func(context.Context, string, string) (shared.DependencyGraph, bool, error)
+	history     []StoreLockfileDependencyGraphFuncCall
+	mutex       sync.Mutex
+}
+
+// LockfileDependencyGraph delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) LockfileDependencyGraph(v0 context.Context, v1 string, v2 string) (shared.DependencyGraph, bool, error) {
+	r0, r1, r2 := m.LockfileDependencyGraphFunc.nextHook()(v0, v1, v2)
+	m.LockfileDependencyGraphFunc.appendCall(StoreLockfileDependencyGraphFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// LockfileDependencyGraph method of the parent MockStore instance is
+// invoked and the hook queue is empty.
+func (f *StoreLockfileDependencyGraphFunc) SetDefaultHook(hook func(context.Context, string, string) (shared.DependencyGraph, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// LockfileDependencyGraph method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreLockfileDependencyGraphFunc) PushHook(hook func(context.Context, string, string) (shared.DependencyGraph, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreLockfileDependencyGraphFunc) SetDefaultReturn(r0 shared.DependencyGraph, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, string, string) (shared.DependencyGraph, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreLockfileDependencyGraphFunc) PushReturn(r0 shared.DependencyGraph, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, string, string) (shared.DependencyGraph, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *StoreLockfileDependencyGraphFunc) nextHook() func(context.Context, string, string) (shared.DependencyGraph, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreLockfileDependencyGraphFunc) appendCall(r0 StoreLockfileDependencyGraphFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreLockfileDependencyGraphFuncCall
+// objects describing the invocations of this function.
+func (f *StoreLockfileDependencyGraphFunc) History() []StoreLockfileDependencyGraphFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreLockfileDependencyGraphFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreLockfileDependencyGraphFuncCall is an object that describes an
+// invocation of method LockfileDependencyGraph on an instance of MockStore.
+type StoreLockfileDependencyGraphFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 shared.DependencyGraph
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreLockfileDependencyGraphFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreLockfileDependencyGraphFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // StoreLockfileDependentsFunc describes the behavior when the
 // LockfileDependents method of the parent MockStore instance is invoked.
 type StoreLockfileDependentsFunc struct {
@@ -1209,24 +1340,24 @@ func (c StoreUpsertDependencyReposFuncCall) Results() []interface{} {
 // UpsertLockfileDependencies method of the parent MockStore instance is
 // invoked.
 type StoreUpsertLockfileDependenciesFunc struct {
-	defaultHook func(context.Context, string, string, []shared.PackageDependency) error
-	hooks       []func(context.Context, string, string, []shared.PackageDependency) error
+	defaultHook func(context.Context, string, string, []shared.PackageDependency, shared.DependencyGraph) error
+	hooks       []func(context.Context, string, string, []shared.PackageDependency, shared.DependencyGraph) error
 	history     []StoreUpsertLockfileDependenciesFuncCall
 	mutex       sync.Mutex
 }
 
 // UpsertLockfileDependencies delegates to the next hook function in the
 // queue and stores the parameter and result values of this invocation.
-func (m *MockStore) UpsertLockfileDependencies(v0 context.Context, v1 string, v2 string, v3 []shared.PackageDependency) error {
-	r0 := m.UpsertLockfileDependenciesFunc.nextHook()(v0, v1, v2, v3)
-	m.UpsertLockfileDependenciesFunc.appendCall(StoreUpsertLockfileDependenciesFuncCall{v0, v1, v2, v3, r0})
+func (m *MockStore) UpsertLockfileDependencies(v0 context.Context, v1 string, v2 string, v3 []shared.PackageDependency, v4 shared.DependencyGraph) error {
+	r0 := m.UpsertLockfileDependenciesFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.UpsertLockfileDependenciesFunc.appendCall(StoreUpsertLockfileDependenciesFuncCall{v0, v1, v2, v3, v4, r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the
 // UpsertLockfileDependencies method of the parent MockStore instance is
 // invoked and the hook queue is empty.
-func (f *StoreUpsertLockfileDependenciesFunc) SetDefaultHook(hook func(context.Context, string, string, []shared.PackageDependency) error) {
+func (f *StoreUpsertLockfileDependenciesFunc) SetDefaultHook(hook func(context.Context, string, string, []shared.PackageDependency, shared.DependencyGraph) error) {
 	f.defaultHook = hook
 }
 
@@ -1235,7 +1366,7 @@ func (f *StoreUpsertLockfileDependenciesFunc) SetDefaultHook(hook func(context.C
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *StoreUpsertLockfileDependenciesFunc) PushHook(hook func(context.Context, string, string, []shared.PackageDependency) error) {
+func (f *StoreUpsertLockfileDependenciesFunc) PushHook(hook func(context.Context, string, string, []shared.PackageDependency, shared.DependencyGraph) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1244,19 +1375,19 @@ func (f *StoreUpsertLockfileDependenciesFunc) PushHook(hook func(context.Context
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *StoreUpsertLockfileDependenciesFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, string, string, []shared.PackageDependency) error {
+	f.SetDefaultHook(func(context.Context, string, string, []shared.PackageDependency, shared.DependencyGraph) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *StoreUpsertLockfileDependenciesFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, string, string, []shared.PackageDependency) error {
+	f.PushHook(func(context.Context, string, string, []shared.PackageDependency, shared.DependencyGraph) error {
 		return r0
 	})
 }
 
-func (f *StoreUpsertLockfileDependenciesFunc) nextHook() func(context.Context, string, string, []shared.PackageDependency) error {
+func (f *StoreUpsertLockfileDependenciesFunc) nextHook() func(context.Context, string, string, []shared.PackageDependency, shared.DependencyGraph) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1302,6 +1433,9 @@ type StoreUpsertLockfileDependenciesFuncCall struct {
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
 	Arg3 []shared.PackageDependency
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 shared.DependencyGraph
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -1310,7 +1444,7 @@ type StoreUpsertLockfileDependenciesFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c StoreUpsertLockfileDependenciesFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
 }
 
 // Results returns an interface slice containing the results of this
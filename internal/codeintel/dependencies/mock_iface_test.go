@@ -11,6 +11,7 @@ import (
 	"sync"
 
 	api "github.com/sourcegraph/sourcegraph/internal/api"
+	lockfiles "github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/internal/lockfiles"
 	reposource "github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	gitdomain "github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 )
@@ -31,7 +32,7 @@ type MockLockfilesService struct {
 func NewMockLockfilesService() *MockLockfilesService {
 	return &MockLockfilesService{
 		ListDependenciesFunc: &LockfilesServiceListDependenciesFunc{
-			defaultHook: func(context.Context, api.RepoName, string) (r0 []reposource.PackageDependency, r1 error) {
+			defaultHook: func(context.Context, api.RepoName, string) (r0 []reposource.PackageDependency, r1 *lockfiles.DependencyGraph, r2 error) {
 				return
 			},
 		},
@@ -43,7 +44,7 @@ func NewMockLockfilesService() *MockLockfilesService {
 func NewStrictMockLockfilesService() *MockLockfilesService {
 	return &MockLockfilesService{
 		ListDependenciesFunc: &LockfilesServiceListDependenciesFunc{
-			defaultHook: func(context.Context, api.RepoName, string) ([]reposource.PackageDependency, error) {
+			defaultHook: func(context.Context, api.RepoName, string) ([]reposource.PackageDependency, *lockfiles.DependencyGraph, error) {
 				panic("unexpected invocation of MockLockfilesService.ListDependencies")
 			},
 		},
@@ -65,24 +66,24 @@ func NewMockLockfilesServiceFrom(i LockfilesService) *MockLockfilesService {
 // ListDependencies method of the parent MockLockfilesService instance is
 // invoked.
 type LockfilesServiceListDependenciesFunc struct {
-	defaultHook func(context.Context, api.RepoName, string) ([]reposource.PackageDependency, error)
-	hooks       []func(context.Context, api.RepoName, string) ([]reposource.PackageDependency, error)
+	defaultHook func(context.Context, api.RepoName, string) ([]reposource.PackageDependency, *lockfiles.DependencyGraph, error)
+	hooks       []func(context.Context, api.RepoName, string) ([]reposource.PackageDependency, *lockfiles.DependencyGraph, error)
 	history     []LockfilesServiceListDependenciesFuncCall
 	mutex       sync.Mutex
 }
 
 // ListDependencies delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockLockfilesService) ListDependencies(v0 context.Context, v1 api.RepoName, v2 string) ([]reposource.PackageDependency, error) {
-	r0, r1 := m.ListDependenciesFunc.nextHook()(v0, v1, v2)
-	m.ListDependenciesFunc.appendCall(LockfilesServiceListDependenciesFuncCall{v0, v1, v2, r0, r1})
-	return r0, r1
+func (m *MockLockfilesService) ListDependencies(v0 context.Context, v1 api.RepoName, v2 string) ([]reposource.PackageDependency, *lockfiles.DependencyGraph, error) {
+	r0, r1, r2 := m.ListDependenciesFunc.nextHook()(v0, v1, v2)
+	m.ListDependenciesFunc.appendCall(LockfilesServiceListDependenciesFuncCall{v0, v1, v2, r0, r1, r2})
+	return r0, r1, r2
 }
 
 // SetDefaultHook sets function that is called when the ListDependencies
 // method of the parent MockLockfilesService instance is invoked and the
 // hook queue is empty.
-func (f *LockfilesServiceListDependenciesFunc) SetDefaultHook(hook func(context.Context, api.RepoName, string) ([]reposource.PackageDependency, error)) {
+func (f *LockfilesServiceListDependenciesFunc) SetDefaultHook(hook func(context.Context, api.RepoName, string) ([]reposource.PackageDependency, *lockfiles.DependencyGraph, error)) {
 	f.defaultHook = hook
 }
 
@@ -91,7 +92,7 @@ func (f *LockfilesServiceListDependenciesFunc) SetDefaultHook(hook func(context.
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *LockfilesServiceListDependenciesFunc) PushHook(hook func(context.Context, api.RepoName, string) ([]reposource.PackageDependency, error)) {
+func (f *LockfilesServiceListDependenciesFunc) PushHook(hook func(context.Context, api.RepoName, string) ([]reposource.PackageDependency, *lockfiles.DependencyGraph, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -99,20 +100,20 @@ func (f *LockfilesServiceListDependenciesFunc) PushHook(hook func(context.Contex
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *LockfilesServiceListDependenciesFunc) SetDefaultReturn(r0 []reposource.PackageDependency, r1 error) {
-	f.SetDefaultHook(func(context.Context, api.RepoName, string) ([]reposource.PackageDependency, error) {
-		return r0, r1
+func (f *LockfilesServiceListDependenciesFunc) SetDefaultReturn(r0 []reposource.PackageDependency, r1 *lockfiles.DependencyGraph, r2 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, string) ([]reposource.PackageDependency, *lockfiles.DependencyGraph, error) {
+		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *LockfilesServiceListDependenciesFunc) PushReturn(r0 []reposource.PackageDependency, r1 error) {
-	f.PushHook(func(context.Context, api.RepoName, string) ([]reposource.PackageDependency, error) {
-		return r0, r1
+func (f *LockfilesServiceListDependenciesFunc) PushReturn(r0 []reposource.PackageDependency, r1 *lockfiles.DependencyGraph, r2 error) {
+	f.PushHook(func(context.Context, api.RepoName, string) ([]reposource.PackageDependency, *lockfiles.DependencyGraph, error) {
+		return r0, r1, r2
 	})
 }
 
-func (f *LockfilesServiceListDependenciesFunc) nextHook() func(context.Context, api.RepoName, string) ([]reposource.PackageDependency, error) {
+func (f *LockfilesServiceListDependenciesFunc) nextHook() func(context.Context, api.RepoName, string) ([]reposource.PackageDependency, *lockfiles.DependencyGraph, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -160,7 +161,10 @@ type LockfilesServiceListDependenciesFuncCall struct {
 	Result0 []reposource.PackageDependency
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 error
+	Result1 *lockfiles.DependencyGraph
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
@@ -172,7 +176,7 @@ func (c LockfilesServiceListDependenciesFuncCall) Args() []interface{} {
 // Results returns an interface slice containing the results of this
 // invocation.
 func (c LockfilesServiceListDependenciesFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
+	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
 // MockSyncer is a mock implementation of the Syncer interface (from the
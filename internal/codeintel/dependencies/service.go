@@ -12,6 +12,7 @@ import (
 	"golang.org/x/sync/semaphore"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/internal/lockfiles"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/internal/store"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/types"
@@ -53,10 +54,33 @@ func newService(
 // Dependencies resolves the (transitive) dependencies for a set of repository and revisions.
 // Both the input repoRevs and the output dependencyRevs are a map from repository names to revspecs.
 func (s *Service) Dependencies(ctx context.Context, repoRevs map[api.RepoName]types.RevSpecSet) (dependencyRevs map[api.RepoName]types.RevSpecSet, err error) {
-	ctx, _, endObservation := s.operations.dependencies.With(ctx, &err, observation.Args{LogFields: constructLogFields(repoRevs)})
+	dependencyRevs, _, err = s.dependencies(ctx, repoRevs, 0)
+	return dependencyRevs, err
+}
+
+// TransitiveDependencies resolves the dependencies for a set of repository and revisions that are
+// at most depth edges away from the repositories in the dependency graphs of their lockfiles. Direct
+// dependencies have a depth of one. Along with the dependency revisions, the shortest path from a
+// repository to each of its lockfile dependencies is returned.
+func (s *Service) TransitiveDependencies(ctx context.Context, repoRevs map[api.RepoName]types.RevSpecSet, depth int) (dependencyRevs map[api.RepoName]types.RevSpecSet, paths []shared.DependencyPath, err error) {
+	if depth < 1 {
+		return nil, nil, errors.Newf("invalid dependency depth %d", depth)
+	}
+
+	return s.dependencies(ctx, repoRevs, depth)
+}
+
+// dependencies resolves the dependencies for a set of repository and revisions. If depth is zero,
+// all lockfile dependencies are returned and no dependency paths are computed.
+func (s *Service) dependencies(ctx context.Context, repoRevs map[api.RepoName]types.RevSpecSet, depth int) (dependencyRevs map[api.RepoName]types.RevSpecSet, paths []shared.DependencyPath, err error) {
+	ctx, _, endObservation := s.operations.dependencies.With(ctx, &err, observation.Args{LogFields: append(
+		constructLogFields(repoRevs),
+		log.Int("depth", depth),
+	)})
 	defer func() {
 		endObservation(1, observation.Args{LogFields: []log.Field{
 			log.Int("numDependencyRevs", len(dependencyRevs)),
+			log.Int("numPaths", len(paths)),
 		}})
 	}()
 
@@ -64,13 +88,23 @@ func (s *Service) Dependencies(ctx context.Context, repoRevs map[api.RepoName]ty
 	// TODO - Process unresolved commits.
 	repoCommits, _, err := s.resolveRepoCommits(ctx, repoRevs)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Parse lockfile contents for the given repository and revision pairs
 	deps, err := s.lockfileDependencies(ctx, repoCommits)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	if depth > 0 {
+		// Restrict the dependencies to the ones close enough to the source repositories. The
+		// lockfiles of all repo-commit pairs have been persisted by lockfileDependencies above,
+		// unless lockfile indexing is disabled.
+		deps, paths, err = s.lockfileDependencyPaths(ctx, repoCommits, depth)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	hash := func(dep Repo) string {
@@ -104,7 +138,7 @@ func (s *Service) Dependencies(ctx context.Context, repoRevs map[api.RepoName]ty
 	// Write dependencies to database
 	newDependencies, err := s.dependenciesStore.UpsertDependencyRepos(ctx, dependencies)
 	if err != nil {
-		return nil, errors.Wrap(err, "store.UpsertDependencyRepos")
+		return nil, nil, errors.Wrap(err, "store.UpsertDependencyRepos")
 	}
 
 	// Determine the set of repo names that were recently inserted. Package and repository
@@ -124,18 +158,19 @@ func (s *Service) Dependencies(ctx context.Context, repoRevs map[api.RepoName]ty
 
 	// Lazily sync all the repos that were newly added
 	if err := s.sync(ctx, newRepos); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if !enablePreciseQueries {
-		return dependencyRevs, nil
+		return dependencyRevs, paths, nil
 	}
 
+	// Precise dependencies are direct dependencies, and are included at any depth
 	for _, repoCommit := range repoCommits {
 		// TODO - batch these requests in the store layer
 		preciseDeps, err := s.dependenciesStore.PreciseDependencies(ctx, string(repoCommit.Repo), repoCommit.ResolvedCommit)
 		if err != nil {
-			return nil, errors.Wrap(err, "store.PreciseDependencies")
+			return nil, nil, errors.Wrap(err, "store.PreciseDependencies")
 		}
 
 		for repoName, commits := range preciseDeps {
@@ -148,7 +183,89 @@ func (s *Service) Dependencies(ctx context.Context, repoRevs map[api.RepoName]ty
 		}
 	}
 
-	return dependencyRevs, nil
+	return dependencyRevs, paths, nil
+}
+
+// lockfileDependencyPaths returns the lockfile dependencies of the given repo-commit pairs that are at
+// most depth edges away from the repositories in their dependency graphs, along with the shortest path
+// to each of them.
+func (s *Service) lockfileDependencyPaths(ctx context.Context, repoCommits []repoCommitResolvedCommit, depth int) (deps []shared.PackageDependency, paths []shared.DependencyPath, _ error) {
+	for _, repoCommit := range repoCommits {
+		// TODO - batch these requests in the store layer
+		graph, ok, err := s.dependenciesStore.LockfileDependencyGraph(ctx, string(repoCommit.Repo), repoCommit.ResolvedCommit)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "store.LockfileDependencyGraph")
+		}
+		if !ok {
+			// Lockfiles results are not persisted when lockfile indexing is disabled
+			_, lockfileGraph, err := s.lockfilesSvc.ListDependencies(ctx, repoCommit.Repo, string(repoCommit.CommitID))
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "lockfiles.ListDependencies")
+			}
+			graph = serializeDependencyGraph(lockfileGraph)
+		}
+
+		for _, path := range shortestDependencyPaths(graph, depth) {
+			deps = append(deps, path[len(path)-1])
+			paths = append(paths, shared.DependencyPath{
+				Repo:     repoCommit.Repo,
+				Rev:      api.RevSpec(repoCommit.CommitID),
+				Packages: path,
+			})
+		}
+	}
+
+	return deps, paths, nil
+}
+
+// shortestDependencyPaths returns the shortest path from the roots of the given graph to each package
+// that is at most depth edges away from the repository owning the graph. Direct dependencies have a
+// depth of one. Paths are returned in breadth-first order.
+func shortestDependencyPaths(graph shared.DependencyGraph, depth int) [][]shared.PackageDependency {
+	key := func(dep shared.PackageDependency) string {
+		return strings.Join([]string{dep.Scheme(), dep.PackageSyntax(), dep.PackageVersion()}, ":")
+	}
+
+	dependencies := map[string][]shared.PackageDependency{}
+	for _, edge := range graph.Edges {
+		dependencies[key(edge[0])] = append(dependencies[key(edge[0])], edge[1])
+	}
+
+	var (
+		paths   [][]shared.PackageDependency
+		visited = map[string]struct{}{}
+		queue   [][]shared.PackageDependency
+	)
+	for _, root := range graph.Roots {
+		queue = append(queue, []shared.PackageDependency{root})
+	}
+
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+
+		last := path[len(path)-1]
+		if _, ok := visited[key(last)]; ok {
+			continue
+		}
+		visited[key(last)] = struct{}{}
+		paths = append(paths, path)
+
+		if len(path) >= depth {
+			continue
+		}
+		for _, dep := range dependencies[key(last)] {
+			if _, ok := visited[key(dep)]; ok {
+				continue
+			}
+
+			next := make([]shared.PackageDependency, len(path), len(path)+1)
+			copy(next, path)
+			queue = append(queue, append(next, dep))
+		}
+	}
+
+	return paths
 }
 
 type repoCommitResolvedCommit struct {
@@ -315,7 +432,7 @@ func (s *Service) resolveLockfileDependenciesFromArchive(ctx context.Context, re
 // given repo-commit pair and persists the result to the database. This aids in both caching
 // and building an inverted index to power dependents search.
 func (s *Service) listAndPersistLockfileDependencies(ctx context.Context, repoCommit repoCommitResolvedCommit) ([]shared.PackageDependency, error) {
-	repoDeps, err := s.lockfilesSvc.ListDependencies(ctx, repoCommit.Repo, string(repoCommit.CommitID))
+	repoDeps, graph, err := s.lockfilesSvc.ListDependencies(ctx, repoCommit.Repo, string(repoCommit.CommitID))
 	if err != nil {
 		return nil, errors.Wrap(err, "lockfiles.ListDependencies")
 	}
@@ -327,6 +444,7 @@ func (s *Service) listAndPersistLockfileDependencies(ctx context.Context, repoCo
 		string(repoCommit.Repo),
 		repoCommit.ResolvedCommit,
 		serializableRepoDeps,
+		serializeDependencyGraph(graph),
 	); err != nil {
 		return nil, errors.Wrap(err, "store.UpsertLockfileDependencies")
 	}
@@ -334,6 +452,28 @@ func (s *Service) listAndPersistLockfileDependencies(ctx context.Context, repoCo
 	return serializableRepoDeps, nil
 }
 
+// serializeDependencyGraph converts the given lockfiles dependency graph into one that can be
+// written to the database.
+func serializeDependencyGraph(graph *lockfiles.DependencyGraph) shared.DependencyGraph {
+	if graph == nil {
+		return shared.DependencyGraph{}
+	}
+
+	edges := graph.Edges()
+	serializableEdges := make([][2]shared.PackageDependency, 0, len(edges))
+	for _, edge := range edges {
+		serializableEdges = append(serializableEdges, [2]shared.PackageDependency{
+			shared.SerializePackageDependency(edge[0]),
+			shared.SerializePackageDependency(edge[1]),
+		})
+	}
+
+	return shared.DependencyGraph{
+		Roots: shared.SerializePackageDependencies(graph.Roots()),
+		Edges: serializableEdges,
+	}
+}
+
 // sync invokes the Syncer for every repo in the supplied slice.
 func (s *Service) sync(ctx context.Context, repos []api.RepoName) error {
 	ctx, cancel := context.WithCancel(ctx)
@@ -395,11 +535,30 @@ func (s *Service) ResolveDependencies(ctx context.Context, repoRevs map[api.Repo
 // Dependents resolves the (transitive) inverse dependencies for a set of repository and revisions.
 // Both the input repoRevs and the output dependencyRevs are a map from repository names to revspecs.
 func (s *Service) Dependents(ctx context.Context, repoRevs map[api.RepoName]types.RevSpecSet) (dependentsRevs map[api.RepoName]types.RevSpecSet, err error) {
+	dependentsRevs, _, err = s.dependents(ctx, repoRevs, 0)
+	return dependentsRevs, err
+}
+
+// TransitiveDependents resolves the inverse dependencies for a set of repository and revisions that
+// are at most depth edges away from the dependent repositories in the dependency graphs of their
+// lockfiles. Direct dependents have a depth of one. Along with the dependent revisions, the shortest
+// path from each dependent repository to the given repositories is returned.
+func (s *Service) TransitiveDependents(ctx context.Context, repoRevs map[api.RepoName]types.RevSpecSet, depth int) (dependentsRevs map[api.RepoName]types.RevSpecSet, paths []shared.DependencyPath, err error) {
+	if depth < 1 {
+		return nil, nil, errors.Newf("invalid dependency depth %d", depth)
+	}
+
+	return s.dependents(ctx, repoRevs, depth)
+}
+
+// dependents resolves the inverse dependencies for a set of repository and revisions. If depth is
+// zero, all lockfile dependents are returned and no dependency paths are computed.
+func (s *Service) dependents(ctx context.Context, repoRevs map[api.RepoName]types.RevSpecSet, depth int) (dependentsRevs map[api.RepoName]types.RevSpecSet, paths []shared.DependencyPath, err error) {
 	// Resolve the revhashes for the source repo-commit pairs.
 	// TODO - Process unresolved commits.
 	repoCommits, _, err := s.resolveRepoCommits(ctx, repoRevs)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	var deps []api.RepoCommit
@@ -407,10 +566,28 @@ func (s *Service) Dependents(ctx context.Context, repoRevs map[api.RepoName]type
 		// TODO - batch these requests in the store layer
 		repoDeps, err := s.dependenciesStore.LockfileDependents(ctx, string(commit.Repo), commit.ResolvedCommit)
 		if err != nil {
-			return nil, errors.Wrap(err, "store.LockfileDependents")
+			return nil, nil, errors.Wrap(err, "store.LockfileDependents")
 		}
-		deps = append(deps, repoDeps...)
 
+		if depth > 0 {
+			// Only keep the dependents that are close enough to the given package
+			filtered := repoDeps[:0]
+			for _, dep := range repoDeps {
+				path, ok, err := s.lockfileDependentPath(ctx, dep, commit, depth)
+				if err != nil {
+					return nil, nil, err
+				}
+				if !ok {
+					continue
+				}
+
+				filtered = append(filtered, dep)
+				paths = append(paths, path)
+			}
+			repoDeps = filtered
+		}
+
+		deps = append(deps, repoDeps...)
 	}
 
 	dependentsRevs = map[api.RepoName]types.RevSpecSet{}
@@ -422,14 +599,15 @@ func (s *Service) Dependents(ctx context.Context, repoRevs map[api.RepoName]type
 	}
 
 	if !enablePreciseQueries {
-		return dependentsRevs, nil
+		return dependentsRevs, paths, nil
 	}
 
+	// Precise dependents are direct dependents, and are included at any depth
 	for _, repoCommit := range repoCommits {
 		// TODO - batch these requests in the store layer
 		preciseDeps, err := s.dependenciesStore.PreciseDependents(ctx, string(repoCommit.Repo), repoCommit.ResolvedCommit)
 		if err != nil {
-			return nil, errors.Wrap(err, "store.PreciseDependents")
+			return nil, nil, errors.Wrap(err, "store.PreciseDependents")
 		}
 
 		for repoName, commits := range preciseDeps {
@@ -442,7 +620,41 @@ func (s *Service) Dependents(ctx context.Context, repoRevs map[api.RepoName]type
 		}
 	}
 
-	return dependentsRevs, nil
+	return dependentsRevs, paths, nil
+}
+
+// lockfileDependentPath returns the shortest path from the given dependent repo-commit pair to the
+// package of the given repo-commit pair in the dependency graph of the dependent's lockfiles. False is
+// returned if the package is more than depth edges away from the dependent.
+func (s *Service) lockfileDependentPath(ctx context.Context, dependent api.RepoCommit, repoCommit repoCommitResolvedCommit, depth int) (shared.DependencyPath, bool, error) {
+	graph, _, err := s.dependenciesStore.LockfileDependencyGraph(ctx, string(dependent.Repo), string(dependent.CommitID))
+	if err != nil {
+		return shared.DependencyPath{}, false, errors.Wrap(err, "store.LockfileDependencyGraph")
+	}
+
+	var candidate []shared.PackageDependency
+	for _, path := range shortestDependencyPaths(graph, depth) {
+		if last := path[len(path)-1]; last.RepoName() == repoCommit.Repo {
+			// Several versions of the package may be reachable. Prefer the one that was
+			// requested over the shortest path to any version.
+			if last.GitTagFromVersion() == string(repoCommit.CommitID) {
+				candidate = path
+				break
+			}
+			if candidate == nil {
+				candidate = path
+			}
+		}
+	}
+	if candidate == nil {
+		return shared.DependencyPath{}, false, nil
+	}
+
+	return shared.DependencyPath{
+		Repo:     dependent.Repo,
+		Rev:      api.RevSpec(dependent.CommitID),
+		Packages: candidate,
+	}, true, nil
 }
 
 func constructLogFields(repoRevs map[api.RepoName]types.RevSpecSet) []log.Field {
@@ -12,6 +12,7 @@ import (
 	"golang.org/x/sync/semaphore"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/internal/lockfiles"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/internal/store"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
 	"github.com/sourcegraph/sourcegraph/internal/codeintel/types"
@@ -99,16 +100,16 @@ func TestDependencies(t *testing.T) {
 	})
 
 	// Return archive dependencies for repos `foo` and `bar`
-	lockfilesService.ListDependenciesFunc.SetDefaultHook(func(ctx context.Context, repoName api.RepoName, rev string) ([]reposource.PackageDependency, error) {
+	lockfilesService.ListDependenciesFunc.SetDefaultHook(func(ctx context.Context, repoName api.RepoName, rev string) ([]reposource.PackageDependency, *lockfiles.DependencyGraph, error) {
 		if repoName != "github.com/example/foo" && repoName != "github.com/example/bar" {
-			return nil, nil
+			return nil, nil, nil
 		}
 
 		return []reposource.PackageDependency{
 			&reposource.MavenDependency{MavenModule: &reposource.MavenModule{GroupID: "g1", ArtifactID: "a1"}, Version: fmt.Sprintf("1-%s-%s", repoName, rev)},
 			&reposource.MavenDependency{MavenModule: &reposource.MavenModule{GroupID: "g2", ArtifactID: "a2"}, Version: fmt.Sprintf("2-%s-%s", repoName, rev)},
 			&reposource.MavenDependency{MavenModule: &reposource.MavenModule{GroupID: "g3", ArtifactID: "a3"}, Version: fmt.Sprintf("3-%s-%s", repoName, rev)},
-		}, nil
+		}, nil, nil
 	})
 
 	repoRevs := map[api.RepoName]types.RevSpecSet{
@@ -324,6 +325,143 @@ func TestDependents(t *testing.T) {
 	}
 }
 
+func TestTransitiveDependencies(t *testing.T) {
+	// Ensure the precise flag is disabled
+	enablePreciseQueries = false
+
+	ctx := context.Background()
+	mockStore := NewMockStore()
+	gitService := NewMockLocalGitService()
+	lockfilesService := NewMockLockfilesService()
+	syncer := NewMockSyncer()
+	service := testService(mockStore, gitService, lockfilesService, syncer)
+
+	// GetCommits returns the same values as input; no errors
+	gitService.GetCommitsFunc.SetDefaultHook(func(ctx context.Context, repoCommits []api.RepoCommit, _ bool) (commits []*gitdomain.Commit, _ error) {
+		for _, repoCommit := range repoCommits {
+			commits = append(commits, &gitdomain.Commit{ID: repoCommit.CommitID})
+		}
+		return commits, nil
+	})
+
+	packageA := shared.TestPackageDependencyLiteral("npm/a", "v1", "npm", "a", "1")
+	packageB := shared.TestPackageDependencyLiteral("npm/b", "v2", "npm", "b", "2")
+	packageC := shared.TestPackageDependencyLiteral("npm/c", "v3", "npm", "c", "3")
+	packageD := shared.TestPackageDependencyLiteral("npm/d", "v4", "npm", "d", "4")
+
+	mockStore.LockfileDependenciesFunc.SetDefaultReturn([]shared.PackageDependency{packageA, packageB, packageC, packageD}, true, nil)
+	mockStore.LockfileDependencyGraphFunc.SetDefaultReturn(shared.DependencyGraph{
+		Roots: []shared.PackageDependency{packageA},
+		Edges: [][2]shared.PackageDependency{
+			{packageA, packageB},
+			{packageA, packageC},
+			{packageB, packageC},
+			{packageC, packageD},
+		},
+	}, true, nil)
+
+	repoRevs := map[api.RepoName]types.RevSpecSet{
+		api.RepoName("github.com/example/foo"): {api.RevSpec("deadbeef1"): struct{}{}},
+	}
+	dependencies, paths, err := service.TransitiveDependencies(ctx, repoRevs, 2)
+	if err != nil {
+		t.Fatalf("unexpected error querying dependencies: %s", err)
+	}
+
+	expectedDependencies := map[api.RepoName]types.RevSpecSet{
+		"npm/a": {"v1": struct{}{}},
+		"npm/b": {"v2": struct{}{}},
+		"npm/c": {"v3": struct{}{}},
+	}
+	if diff := cmp.Diff(expectedDependencies, dependencies); diff != "" {
+		t.Errorf("unexpected dependencies (-want +got):\n%s", diff)
+	}
+
+	expectedPaths := []shared.DependencyPath{
+		{Repo: "github.com/example/foo", Rev: "deadbeef1", Packages: []shared.PackageDependency{packageA}},
+		{Repo: "github.com/example/foo", Rev: "deadbeef1", Packages: []shared.PackageDependency{packageA, packageB}},
+		{Repo: "github.com/example/foo", Rev: "deadbeef1", Packages: []shared.PackageDependency{packageA, packageC}},
+	}
+	if diff := cmp.Diff(expectedPaths, paths); diff != "" {
+		t.Errorf("unexpected paths (-want +got):\n%s", diff)
+	}
+
+	if _, _, err := service.TransitiveDependencies(ctx, repoRevs, 0); err == nil {
+		t.Errorf("expected an error for a depth of zero")
+	}
+}
+
+func TestTransitiveDependents(t *testing.T) {
+	// Ensure the precise flag is disabled
+	enablePreciseQueries = false
+
+	ctx := context.Background()
+	mockStore := NewMockStore()
+	gitService := NewMockLocalGitService()
+	lockfilesService := NewMockLockfilesService()
+	syncer := NewMockSyncer()
+	service := testService(mockStore, gitService, lockfilesService, syncer)
+
+	// GetCommits returns the same values as input; no errors
+	gitService.GetCommitsFunc.SetDefaultHook(func(ctx context.Context, repoCommits []api.RepoCommit, _ bool) (commits []*gitdomain.Commit, _ error) {
+		for _, repoCommit := range repoCommits {
+			commits = append(commits, &gitdomain.Commit{ID: repoCommit.CommitID})
+		}
+		return commits, nil
+	})
+
+	packageA := shared.TestPackageDependencyLiteral("npm/a", "v1", "npm", "a", "1")
+	packageB := shared.TestPackageDependencyLiteral("npm/b", "v2", "npm", "b", "2")
+	packageC := shared.TestPackageDependencyLiteral("npm/c", "v3", "npm", "c", "3")
+
+	mockStore.LockfileDependentsFunc.SetDefaultReturn([]api.RepoCommit{
+		{Repo: "github.com/example/direct", CommitID: "c1"},
+		{Repo: "github.com/example/indirect", CommitID: "c2"},
+		{Repo: "github.com/example/distant", CommitID: "c3"},
+	}, nil)
+
+	mockStore.LockfileDependencyGraphFunc.SetDefaultHook(func(ctx context.Context, repoName, commit string) (shared.DependencyGraph, bool, error) {
+		switch repoName {
+		case "github.com/example/direct":
+			return shared.DependencyGraph{Roots: []shared.PackageDependency{packageA, packageC}}, true, nil
+		case "github.com/example/indirect":
+			return shared.DependencyGraph{
+				Roots: []shared.PackageDependency{packageB},
+				Edges: [][2]shared.PackageDependency{{packageB, packageC}},
+			}, true, nil
+		default:
+			return shared.DependencyGraph{
+				Roots: []shared.PackageDependency{packageA},
+				Edges: [][2]shared.PackageDependency{{packageA, packageB}, {packageB, packageC}},
+			}, true, nil
+		}
+	})
+
+	repoRevs := map[api.RepoName]types.RevSpecSet{
+		api.RepoName("npm/c"): {api.RevSpec("v3"): struct{}{}},
+	}
+	dependents, paths, err := service.TransitiveDependents(ctx, repoRevs, 2)
+	if err != nil {
+		t.Fatalf("unexpected error querying dependents: %s", err)
+	}
+
+	expectedDependents := map[api.RepoName]types.RevSpecSet{
+		"github.com/example/direct":   {"c1": struct{}{}},
+		"github.com/example/indirect": {"c2": struct{}{}},
+	}
+	if diff := cmp.Diff(expectedDependents, dependents); diff != "" {
+		t.Errorf("unexpected dependents (-want +got):\n%s", diff)
+	}
+
+	expectedPaths := []shared.DependencyPath{
+		{Repo: "github.com/example/direct", Rev: "c1", Packages: []shared.PackageDependency{packageC}},
+		{Repo: "github.com/example/indirect", Rev: "c2", Packages: []shared.PackageDependency{packageB, packageC}},
+	}
+	if diff := cmp.Diff(expectedPaths, paths); diff != "" {
+		t.Errorf("unexpected paths (-want +got):\n%s", diff)
+	}
+}
+
 func TestResolveDependencies(t *testing.T) {
 	// Ensure lockfile indexing is enabled
 	oldLockfileIndexingEnabled := lockfileIndexingEnabled
@@ -346,16 +484,16 @@ func TestResolveDependencies(t *testing.T) {
 	})
 
 	// Return archive dependencies for repos `foo` and `bar`
-	lockfilesService.ListDependenciesFunc.SetDefaultHook(func(ctx context.Context, repoName api.RepoName, rev string) ([]reposource.PackageDependency, error) {
+	lockfilesService.ListDependenciesFunc.SetDefaultHook(func(ctx context.Context, repoName api.RepoName, rev string) ([]reposource.PackageDependency, *lockfiles.DependencyGraph, error) {
 		if repoName != "github.com/example/foo" && repoName != "github.com/example/bar" {
-			return nil, nil
+			return nil, nil, nil
 		}
 
 		return []reposource.PackageDependency{
 			&reposource.MavenDependency{MavenModule: &reposource.MavenModule{GroupID: "g1", ArtifactID: "a1"}, Version: fmt.Sprintf("1-%s-%s", repoName, rev)},
 			&reposource.MavenDependency{MavenModule: &reposource.MavenModule{GroupID: "g2", ArtifactID: "a2"}, Version: fmt.Sprintf("2-%s-%s", repoName, rev)},
 			&reposource.MavenDependency{MavenModule: &reposource.MavenModule{GroupID: "g3", ArtifactID: "a3"}, Version: fmt.Sprintf("3-%s-%s", repoName, rev)},
-		}, nil
+		}, nil, nil
 	})
 
 	repoRevs := map[api.RepoName]types.RevSpecSet{
@@ -6,4 +6,6 @@ const (
 	NpmPackagesScheme    = "npm"
 	PythonPackagesScheme = "python"
 	RustPackagesScheme   = "rust-analyzer"
+	RubyPackagesScheme   = "ruby"
+	PHPPackagesScheme    = "php"
 )
@@ -50,6 +50,23 @@ func (d PackageDependencyLiteral) Scheme() string            { return d.SchemeVa
 func (d PackageDependencyLiteral) PackageSyntax() string     { return d.PackageSyntaxValue }
 func (d PackageDependencyLiteral) PackageVersion() string    { return d.PackageVersionValue }
 
+// DependencyGraph describes how the package dependencies of a repository relate to each other.
+type DependencyGraph struct {
+	// Roots are the direct dependencies of the repository.
+	Roots []PackageDependency
+	// Edges are pairs of packages where the first package depends on the second one.
+	Edges [][2]PackageDependency
+}
+
+// DependencyPath describes how a repository depends on a package, possibly transitively.
+// Packages are ordered from the direct dependency of the repository to the package the
+// path leads to.
+type DependencyPath struct {
+	Repo     api.RepoName
+	Rev      api.RevSpec
+	Packages []PackageDependency
+}
+
 func SerializePackageDependencies(deps []reposource.PackageDependency) []PackageDependency {
 	serializableRepoDeps := make([]PackageDependency, 0, len(deps))
 	for _, dep := range deps {
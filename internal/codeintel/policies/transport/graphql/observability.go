@@ -8,13 +8,14 @@ import (
 )
 
 type operations struct {
-	codeIntelligenceConfiogurationPolicies    *observation.Operation
-	configurationPolicyByID                   *observation.Operation
-	createCodeIntelligenceConfigurationPolicy *observation.Operation
-	deleteCodeIntelligenceConfigurationPolicy *observation.Operation
-	previewGitObjectFilter                    *observation.Operation
-	previewRepositoryFilter                   *observation.Operation
-	updateCodeIntelligenceConfigurationPolicy *observation.Operation
+	codeIntelligenceConfiogurationPolicies     *observation.Operation
+	configurationPolicyByID                    *observation.Operation
+	createCodeIntelligenceConfigurationPolicy  *observation.Operation
+	deleteCodeIntelligenceConfigurationPolicy  *observation.Operation
+	previewCodeIntelligenceConfigurationPolicy *observation.Operation
+	previewGitObjectFilter                     *observation.Operation
+	previewRepositoryFilter                    *observation.Operation
+	updateCodeIntelligenceConfigurationPolicy  *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -34,12 +35,13 @@ func newOperations(observationContext *observation.Context) *operations {
 	}
 
 	return &operations{
-		codeIntelligenceConfiogurationPolicies:    op("CodeIntelligenceConfiogurationPolicies"),
-		configurationPolicyByID:                   op("ConfigurationPolicyByID"),
-		createCodeIntelligenceConfigurationPolicy: op("CreateCodeIntelligenceConfigurationPolicy"),
-		deleteCodeIntelligenceConfigurationPolicy: op("DeleteCodeIntelligenceConfigurationPolicy"),
-		previewGitObjectFilter:                    op("PreviewGitObjectFilter"),
-		previewRepositoryFilter:                   op("PreviewRepositoryFilter"),
-		updateCodeIntelligenceConfigurationPolicy: op("UpdateCodeIntelligenceConfigurationPolicy"),
+		codeIntelligenceConfiogurationPolicies:     op("CodeIntelligenceConfiogurationPolicies"),
+		configurationPolicyByID:                    op("ConfigurationPolicyByID"),
+		createCodeIntelligenceConfigurationPolicy:  op("CreateCodeIntelligenceConfigurationPolicy"),
+		deleteCodeIntelligenceConfigurationPolicy:  op("DeleteCodeIntelligenceConfigurationPolicy"),
+		previewCodeIntelligenceConfigurationPolicy: op("PreviewCodeIntelligenceConfigurationPolicy"),
+		previewGitObjectFilter:                     op("PreviewGitObjectFilter"),
+		previewRepositoryFilter:                    op("PreviewRepositoryFilter"),
+		updateCodeIntelligenceConfigurationPolicy:  op("UpdateCodeIntelligenceConfigurationPolicy"),
 	}
 }
@@ -77,6 +77,15 @@ func (r *Resolver) PreviewRepositoryFilter(ctx context.Context, args *gql.Previe
 	return nil, errors.New("unimplemented: PreviewRepositoryFilter")
 }
 
+func (r *Resolver) PreviewCodeIntelligenceConfigurationPolicy(ctx context.Context, id graphql.ID, args *gql.PreviewCodeIntelligenceConfigurationPolicyArgs) (_ gql.CodeIntelligenceConfigurationPolicyPreviewResolver, err error) {
+	ctx, _, endObservation := r.operations.previewCodeIntelligenceConfigurationPolicy.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	// To be implemented in - https://github.com/sourcegraph/sourcegraph/issues/33376
+	_, _, _ = ctx, id, args
+	return nil, errors.New("unimplemented: PreviewCodeIntelligenceConfigurationPolicy")
+}
+
 func (r *Resolver) PreviewGitObjectFilter(ctx context.Context, id graphql.ID, args *gql.PreviewGitObjectFilterArgs) (_ []gql.GitObjectFilterPreviewResolver, err error) {
 	ctx, _, endObservation := r.operations.previewGitObjectFilter.With(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
@@ -0,0 +1,374 @@
+package conversion
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/pathexistence"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Field numbers of the scip.Index message. We decode the index message by hand so
+// that documents can be decoded (and garbage collected) one at a time.
+const (
+	indexMetadataField        = 1
+	indexDocumentsField       = 2
+	indexExternalSymbolsField = 3
+)
+
+// Correlate reads a SCIP index from the given reader and returns the same data converted
+// into the format we write to the codeintel database.
+//
+// Unlike LSIF correlation, we never build an in-memory graph of the entire index, nor do
+// we read the entire payload into memory. The index is decoded from the reader one field
+// at a time, and each document is discarded once its occurrences have been recorded.
+//
+// If getChildren == nil, no pruning of irrelevant data is performed.
+func Correlate(ctx context.Context, r io.Reader, root string, getChildren pathexistence.GetChildrenFunc) (*precise.GroupedBundleDataChans, error) {
+	// Read raw upload and build the symbol table
+	state, err := correlateFromReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if getChildren != nil {
+		// Remove documents we don't need to store
+		if err := prune(ctx, state, root, getChildren); err != nil {
+			return nil, err
+		}
+	}
+
+	// Convert data to the format we send to the writer
+	return groupBundleData(ctx, state)
+}
+
+// correlateFromReader decodes the metadata, documents, and external symbols of the raw
+// index read from r and returns a correlation state object.
+func correlateFromReader(r io.Reader) (*State, error) {
+	var (
+		br              = bufio.NewReader(r)
+		metadata        *scip.Metadata
+		externalSymbols []*scip.SymbolInformation
+		state           = newState()
+	)
+
+	for {
+		num, value, err := readField(br)
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errors.Wrap(err, "malformed SCIP index")
+		}
+
+		switch num {
+		case indexMetadataField:
+			metadata = &scip.Metadata{}
+			if err := proto.Unmarshal(value, metadata); err != nil {
+				return nil, errors.Wrap(err, "malformed SCIP metadata")
+			}
+
+		case indexDocumentsField:
+			var document scip.Document
+			if err := proto.Unmarshal(value, &document); err != nil {
+				return nil, errors.Wrapf(err, "malformed SCIP document at index %d", len(state.Documents))
+			}
+
+			documentPath := path.Clean(document.RelativePath)
+			state.Documents = append(state.Documents, &DocumentState{
+				Path: documentPath,
+				// Do not write documents outside of the root
+				Pruned: strings.HasPrefix(documentPath, ".."),
+			})
+			state.correlateDocument(len(state.Documents)-1, &document)
+
+		case indexExternalSymbolsField:
+			var info scip.SymbolInformation
+			if err := proto.Unmarshal(value, &info); err != nil {
+				return nil, errors.Wrap(err, "malformed SCIP external symbol")
+			}
+			externalSymbols = append(externalSymbols, &info)
+		}
+	}
+
+	if metadata == nil {
+		return nil, ErrMissingMetaData
+	}
+
+	// External symbols are usually encoded after the documents. Their documentation takes
+	// precedence over the documentation of symbols within the documents.
+	for _, info := range externalSymbols {
+		if hover := formatHover(info.Documentation); hover != "" && scip.IsGlobalSymbol(info.Symbol) {
+			state.symbol(-1, info.Symbol).Hover = hover
+		}
+	}
+
+	state.finalize()
+	return state, nil
+}
+
+// readField reads the next field of a protobuf message from r. The values of fields
+// other than length-delimited ones are discarded, and a nil value is returned for them.
+// At the end of the message, io.EOF is returned.
+func readField(r *bufio.Reader) (protowire.Number, []byte, error) {
+	tag, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, nil, err
+	}
+	num, typ := protowire.DecodeTag(tag)
+	if num < protowire.MinValidNumber {
+		return 0, nil, errors.Newf("invalid field number %d", num)
+	}
+
+	switch typ {
+	case protowire.VarintType:
+		_, err = binary.ReadUvarint(r)
+	case protowire.Fixed32Type:
+		_, err = r.Discard(4)
+	case protowire.Fixed64Type:
+		_, err = r.Discard(8)
+	case protowire.BytesType:
+		var length uint64
+		if length, err = binary.ReadUvarint(r); err != nil {
+			break
+		}
+		// Grow the buffer as the value is read rather than trusting the length prefix,
+		// so that a corrupt length can't make us allocate arbitrarily large buffers.
+		var buf bytes.Buffer
+		if _, err = io.CopyN(&buf, r, int64(length)); err != nil {
+			break
+		}
+		return num, buf.Bytes(), nil
+	default:
+		return 0, nil, errors.Newf("unsupported wire type %d of field %d", typ, num)
+	}
+
+	if err == io.EOF {
+		// The field was truncated
+		err = io.ErrUnexpectedEOF
+	}
+	return num, nil, err
+}
+
+// correlateDocument assigns identifiers to the ranges of the given document and records
+// the location of each occurrence with the symbols it refers to. The occurrences are also
+// retained on the document state, so that the document can be serialized without being
+// decoded again.
+func (s *State) correlateDocument(i int, document *scip.Document) {
+	d := s.Documents[i]
+	d.ID = s.nextID()
+
+	// Reserve a contiguous block of identifiers for the ranges of this document. These
+	// can then be recomputed when the document is serialized without storing them.
+	d.RangeBase = s.maxID + 1
+	numRanges := 0
+	for _, occurrence := range document.Occurrences {
+		if _, ok := occurrenceRange(occurrence); ok {
+			s.nextID()
+			numRanges++
+		}
+	}
+	d.Occurrences = make([]OccurrenceState, 0, numRanges)
+
+	symbolInformation := make(map[string]*scip.SymbolInformation, len(document.Symbols))
+	for _, info := range document.Symbols {
+		symbolInformation[info.Symbol] = info
+
+		symbol := s.symbol(i, info.Symbol)
+		symbol.Defined = true
+		symbol.setHover(info.Documentation)
+
+		for _, relationship := range info.Relationships {
+			if relationship.IsImplementation && scip.IsGlobalSymbol(relationship.Symbol) {
+				symbol.Implements = append(symbol.Implements, relationship.Symbol)
+			}
+		}
+	}
+
+	rangeID := d.RangeBase
+	for _, occurrence := range document.Occurrences {
+		r, ok := occurrenceRange(occurrence)
+		if !ok {
+			continue
+		}
+		loc := Location{Document: i, RangeID: rangeID, Range: r}
+		rangeID++
+
+		symbol := s.symbol(i, occurrence.Symbol)
+		symbol.References = append(symbol.References, loc)
+		d.Occurrences = append(d.Occurrences, newOccurrenceState(symbol, r, occurrence))
+
+		if occurrence.SymbolRoles&int32(scip.SymbolRole_Definition) == 0 {
+			continue
+		}
+		symbol.Defined = true
+		symbol.Definitions = append(symbol.Definitions, loc)
+
+		info, ok := symbolInformation[occurrence.Symbol]
+		if !ok {
+			continue
+		}
+		for _, relationship := range info.Relationships {
+			target := s.symbol(i, relationship.Symbol)
+			if relationship.IsImplementation {
+				target.Implementations = append(target.Implementations, loc)
+			}
+			if relationship.IsReference {
+				target.References = append(target.References, loc)
+			}
+		}
+	}
+}
+
+// finalize assigns result identifiers and monikers to every symbol once all documents
+// have been correlated, at which point we know which symbols are defined in this index.
+func (s *State) finalize() {
+	for _, symbol := range s.symbolOrder {
+		if len(symbol.References) == 0 && len(symbol.Implementations) == 0 {
+			// Symbol does not occur in this index
+			continue
+		}
+
+		if len(symbol.Definitions) > 0 {
+			symbol.DefinitionResultID = s.nextID()
+			s.NumResults++
+		}
+		if len(symbol.References) > 0 {
+			symbol.ReferenceResultID = s.nextID()
+			s.NumResults++
+		}
+		if len(symbol.Implementations) > 0 {
+			symbol.ImplementationResultID = s.nextID()
+			s.NumResults++
+		}
+		if symbol.Hover != "" {
+			symbol.HoverResultID = s.nextID()
+		}
+
+		if !symbol.Local {
+			kind := "import"
+			if symbol.Defined {
+				kind = "export"
+			}
+			s.addMoniker(symbol, kind, symbol.Symbol)
+		}
+
+		for _, implemented := range symbol.Implements {
+			// Link implementations to the symbols they implement. This resolves supertypes
+			// defined in this index as well as in other indexes through the same moniker
+			// search, and lets dependents find implementations of our exported symbols.
+			s.addMoniker(symbol, "implementation", implemented)
+		}
+	}
+}
+
+// addMoniker attaches a moniker with the given kind and identifier to the given symbol.
+// Symbols without a scheme do not get a moniker.
+func (s *State) addMoniker(symbol *SymbolState, kind, identifier string) {
+	parsed, err := scip.ParsePartialSymbol(identifier, false)
+	if err != nil || parsed == nil || parsed.Scheme == "" {
+		return
+	}
+
+	scheme := parsed.Scheme
+	if parsed.Package != nil {
+		// The query path uses the scheme of monikers where it should use the package
+		// manager, so we use the same schemes as the LSIF indexers we replace.
+		switch scheme {
+		case "scip-java", "lsif-java":
+			scheme = "semanticdb"
+		case "scip-typescript", "lsif-typescript":
+			scheme = "npm"
+		}
+	}
+
+	moniker := MonikerState{Kind: kind, Scheme: scheme, Identifier: identifier}
+	if pkg := parsed.Package; pkg != nil && pkg.Manager != "" && pkg.Name != "" && pkg.Version != "" {
+		id, ok := s.packageIDs[pkg.ID()]
+		if !ok {
+			id = s.nextID()
+			s.packageIDs[pkg.ID()] = id
+			s.PackageInformationData[id] = precise.PackageInformationData{Name: pkg.Name, Version: pkg.Version}
+		}
+		moniker.PackageInformationID = id
+	}
+
+	id := s.nextID()
+	s.MonikerData[id] = moniker
+	symbol.MonikerIDs = append(symbol.MonikerIDs, id)
+
+	if moniker.PackageInformationID != 0 {
+		switch kind {
+		case "export":
+			s.ExportedMonikers[id] = struct{}{}
+		case "import":
+			s.ImportedMonikers[id] = struct{}{}
+		case "implementation":
+			s.ImplementedMonikers[id] = struct{}{}
+		}
+	}
+}
+
+// occurrenceRange returns the range of the given occurrence. Occurrences with a malformed
+// range or without a symbol (e.g. those only carrying syntax highlighting) are skipped.
+func occurrenceRange(occurrence *scip.Occurrence) (Range, bool) {
+	if occurrence.Symbol == "" {
+		return Range{}, false
+	}
+
+	r := occurrence.Range
+	switch len(r) {
+	case 3:
+		return Range{StartLine: int(r[0]), StartCharacter: int(r[1]), EndLine: int(r[0]), EndCharacter: int(r[2])}, true
+	case 4:
+		return Range{StartLine: int(r[0]), StartCharacter: int(r[1]), EndLine: int(r[2]), EndCharacter: int(r[3])}, true
+	default:
+		return Range{}, false
+	}
+}
+
+// prune marks the documents in the given correlation state that do not exist in the git
+// clone at the target commit. Locations within these documents are not written.
+func prune(ctx context.Context, state *State, root string, getChildren pathexistence.GetChildrenFunc) error {
+	paths := make([]string, 0, len(state.Documents))
+	for _, document := range state.Documents {
+		paths = append(paths, document.Path)
+	}
+
+	checker, err := pathexistence.NewExistenceChecker(ctx, root, paths, getChildren)
+	if err != nil {
+		return err
+	}
+
+	for _, document := range state.Documents {
+		if !checker.Exists(document.Path) {
+			// Document does not exist in git
+			document.Pruned = true
+		}
+	}
+
+	return nil
+}
+
+func toID(id int) precise.ID {
+	if id == 0 {
+		return precise.ID("")
+	}
+
+	return precise.ID(strconv.FormatInt(int64(id), 10))
+}
+
+func makeKey(parts ...string) string {
+	return strings.Join(parts, ":")
+}
@@ -0,0 +1,349 @@
+package conversion
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"testing"
+	"testing/iotest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	testFooSymbol   = "scip-go gomod github.com/test/foo v1.0.0 `github.com/test/foo`/Foo()."
+	testImplSymbol  = "scip-go gomod github.com/test/foo v1.0.0 `github.com/test/foo`/Impl#"
+	testBarSymbol   = "scip-go gomod github.com/test/dep v2.0.0 `github.com/test/dep`/Bar()."
+	testIfaceSymbol = "scip-go gomod github.com/test/dep v2.0.0 `github.com/test/dep`/Iface#"
+)
+
+func testIndex() *scip.Index {
+	return &scip.Index{
+		Metadata: &scip.Metadata{
+			ToolInfo:    &scip.ToolInfo{Name: "scip-go"},
+			ProjectRoot: "file:///test",
+		},
+		Documents: []*scip.Document{
+			{
+				RelativePath: "foo.go",
+				Occurrences: []*scip.Occurrence{
+					{Range: []int32{1, 5, 8}, Symbol: testFooSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+					{Range: []int32{2, 1, 2}, Symbol: "local 0", SymbolRoles: int32(scip.SymbolRole_Definition)},
+					{Range: []int32{3, 1, 4, 2}, Symbol: "local 0"},
+					{Range: []int32{5, 5, 9}, Symbol: testImplSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+					{Range: []int32{6, 0, 1}},
+				},
+				Symbols: []*scip.SymbolInformation{
+					{Symbol: testFooSymbol, Documentation: []string{"```go\nfunc Foo()\n```", "Foo does things."}},
+					{
+						Symbol: testImplSymbol,
+						Relationships: []*scip.Relationship{
+							{Symbol: testIfaceSymbol, IsImplementation: true},
+						},
+					},
+				},
+			},
+			{
+				RelativePath: "bar.go",
+				Occurrences: []*scip.Occurrence{
+					{Range: []int32{1, 0, 3}, Symbol: testFooSymbol},
+					{
+						Range:  []int32{2, 0, 3},
+						Symbol: testBarSymbol,
+						Diagnostics: []*scip.Diagnostic{
+							{Severity: scip.Severity_Warning, Code: "SA1019", Message: "Bar is deprecated", Source: "staticcheck"},
+						},
+					},
+					{Range: []int32{3, 0, 1}, Symbol: "local 0", SymbolRoles: int32(scip.SymbolRole_Definition)},
+				},
+			},
+		},
+		ExternalSymbols: []*scip.SymbolInformation{
+			{Symbol: testBarSymbol, Documentation: []string{"Bar does other things."}},
+		},
+	}
+}
+
+func correlateTestIndex(t *testing.T, index *scip.Index, getChildren func(ctx context.Context, dirnames []string) (map[string][]string, error)) (*precise.GroupedBundleDataMaps, []precise.MonikerLocations) {
+	payload, err := proto.Marshal(index)
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	chans, err := Correlate(context.Background(), bytes.NewReader(payload), "", getChildren)
+	if err != nil {
+		t.Fatalf("unexpected error correlating index: %s", err)
+	}
+
+	var implementations []precise.MonikerLocations
+	for row := range chans.Implementations {
+		implementations = append(implementations, row)
+	}
+
+	return precise.GroupedBundleDataChansToMaps(chans), implementations
+}
+
+func TestCorrelate(t *testing.T) {
+	maps, implementations := correlateTestIndex(t, testIndex(), nil)
+
+	if maps.Meta.NumResultChunks != 1 {
+		t.Errorf("unexpected number of result chunks. want=%d have=%d", 1, maps.Meta.NumResultChunks)
+	}
+
+	var paths []string
+	for path := range maps.Documents {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	if diff := cmp.Diff([]string{"bar.go", "foo.go"}, paths); diff != "" {
+		t.Errorf("unexpected documents (-want +got):\n%s", diff)
+	}
+
+	// Resolve the definition of Foo from its reference in bar.go through the result chunk
+	fooReference := findRange(t, maps.Documents["bar.go"], 1, 0)
+	if hover := maps.Documents["bar.go"].HoverResults[fooReference.HoverResultID]; hover != "```go\nfunc Foo()\n```\n\n---\n\nFoo does things." {
+		t.Errorf("unexpected hover text %q", hover)
+	}
+	if diff := cmp.Diff([]string{"foo.go:1:5"}, resolveLocations(maps, fooReference.DefinitionResultID)); diff != "" {
+		t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"bar.go:1:0", "foo.go:1:5"}, resolveLocations(maps, fooReference.ReferenceResultID)); diff != "" {
+		t.Errorf("unexpected references (-want +got):\n%s", diff)
+	}
+
+	// Local symbols with the same name in different documents are distinct
+	localReference := findRange(t, maps.Documents["foo.go"], 3, 1)
+	if diff := cmp.Diff([]string{"foo.go:2:1", "foo.go:3:1"}, resolveLocations(maps, localReference.ReferenceResultID)); diff != "" {
+		t.Errorf("unexpected local references (-want +got):\n%s", diff)
+	}
+	if len(localReference.MonikerIDs) != 0 {
+		t.Errorf("unexpected monikers on local symbol: %v", localReference.MonikerIDs)
+	}
+
+	// External symbols are imported and carry documentation
+	barReference := findRange(t, maps.Documents["bar.go"], 2, 0)
+	if hover := maps.Documents["bar.go"].HoverResults[barReference.HoverResultID]; hover != "Bar does other things." {
+		t.Errorf("unexpected hover text %q", hover)
+	}
+	if barReference.DefinitionResultID != "" {
+		t.Errorf("unexpected definition result for external symbol")
+	}
+	if len(barReference.MonikerIDs) != 1 {
+		t.Fatalf("unexpected monikers. want=%d have=%d", 1, len(barReference.MonikerIDs))
+	}
+	barMoniker := maps.Documents["bar.go"].Monikers[barReference.MonikerIDs[0]]
+	if barMoniker.Kind != "import" || barMoniker.Scheme != "scip-go" || barMoniker.Identifier != testBarSymbol {
+		t.Errorf("unexpected moniker %+v", barMoniker)
+	}
+	if pkg := maps.Documents["bar.go"].PackageInformation[barMoniker.PackageInformationID]; pkg.Name != "github.com/test/dep" || pkg.Version != "v2.0.0" {
+		t.Errorf("unexpected package information %+v", pkg)
+	}
+
+	expectedDiagnostics := []precise.DiagnosticData{
+		{
+			Severity:       int(scip.Severity_Warning),
+			Code:           "SA1019",
+			Message:        "Bar is deprecated",
+			Source:         "staticcheck",
+			StartLine:      2,
+			StartCharacter: 0,
+			EndLine:        2,
+			EndCharacter:   3,
+		},
+	}
+	if diff := cmp.Diff(expectedDiagnostics, maps.Documents["bar.go"].Diagnostics); diff != "" {
+		t.Errorf("unexpected diagnostics (-want +got):\n%s", diff)
+	}
+
+	expectedDefinitions := map[string]map[string]map[string][]precise.LocationData{
+		"export": {
+			"scip-go": {
+				testFooSymbol:  {{URI: "foo.go", StartLine: 1, StartCharacter: 5, EndLine: 1, EndCharacter: 8}},
+				testImplSymbol: {{URI: "foo.go", StartLine: 5, StartCharacter: 5, EndLine: 5, EndCharacter: 9}},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedDefinitions, maps.Definitions); diff != "" {
+		t.Errorf("unexpected definitions (-want +got):\n%s", diff)
+	}
+
+	expectedReferences := map[string]map[string]map[string][]precise.LocationData{
+		"export": {
+			"scip-go": {
+				testFooSymbol: {
+					{URI: "bar.go", StartLine: 1, StartCharacter: 0, EndLine: 1, EndCharacter: 3},
+					{URI: "foo.go", StartLine: 1, StartCharacter: 5, EndLine: 1, EndCharacter: 8},
+				},
+				testImplSymbol: {{URI: "foo.go", StartLine: 5, StartCharacter: 5, EndLine: 5, EndCharacter: 9}},
+			},
+		},
+		"import": {
+			"scip-go": {
+				testBarSymbol: {{URI: "bar.go", StartLine: 2, StartCharacter: 0, EndLine: 2, EndCharacter: 3}},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedReferences, maps.References); diff != "" {
+		t.Errorf("unexpected references (-want +got):\n%s", diff)
+	}
+
+	expectedImplementations := []precise.MonikerLocations{
+		{
+			Kind:       "implementation",
+			Scheme:     "scip-go",
+			Identifier: testIfaceSymbol,
+			Locations:  []precise.LocationData{{URI: "foo.go", StartLine: 5, StartCharacter: 5, EndLine: 5, EndCharacter: 9}},
+		},
+	}
+	if diff := cmp.Diff(expectedImplementations, implementations); diff != "" {
+		t.Errorf("unexpected implementations (-want +got):\n%s", diff)
+	}
+
+	expectedPackages := []precise.Package{
+		{Scheme: "scip-go", Name: "github.com/test/foo", Version: "v1.0.0"},
+	}
+	if diff := cmp.Diff(expectedPackages, maps.Packages); diff != "" {
+		t.Errorf("unexpected packages (-want +got):\n%s", diff)
+	}
+
+	expectedPackageReferences := []precise.PackageReference{
+		{Package: precise.Package{Scheme: "scip-go", Name: "github.com/test/dep", Version: "v2.0.0"}},
+	}
+	if diff := cmp.Diff(expectedPackageReferences, maps.PackageReferences); diff != "" {
+		t.Errorf("unexpected package references (-want +got):\n%s", diff)
+	}
+}
+
+func TestCorrelateLocalImplementations(t *testing.T) {
+	const testLocalIfaceSymbol = "scip-go gomod github.com/test/foo v1.0.0 `github.com/test/foo`/LocalIface#"
+
+	index := testIndex()
+	index.Documents[0].Occurrences = append(index.Documents[0].Occurrences,
+		&scip.Occurrence{Range: []int32{8, 5, 15}, Symbol: testLocalIfaceSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+	)
+	index.Documents[0].Symbols[1].Relationships = append(index.Documents[0].Symbols[1].Relationships,
+		&scip.Relationship{Symbol: testLocalIfaceSymbol, IsImplementation: true},
+	)
+
+	maps, implementations := correlateTestIndex(t, index, nil)
+	document := maps.Documents["foo.go"]
+
+	// The interface defined in this index resolves its implementations locally
+	ifaceDefinition := findRange(t, document, 8, 5)
+	if diff := cmp.Diff([]string{"foo.go:5:5"}, resolveLocations(maps, ifaceDefinition.ImplementationResultID)); diff != "" {
+		t.Errorf("unexpected implementations (-want +got):\n%s", diff)
+	}
+
+	// The implementation links to both of its supertypes through monikers
+	implDefinition := findRange(t, document, 5, 5)
+	var implemented []string
+	for _, id := range implDefinition.MonikerIDs {
+		if moniker := document.Monikers[id]; moniker.Kind == "implementation" {
+			implemented = append(implemented, moniker.Identifier)
+		}
+	}
+	sort.Strings(implemented)
+	if diff := cmp.Diff([]string{testIfaceSymbol, testLocalIfaceSymbol}, implemented); diff != "" {
+		t.Errorf("unexpected implementation monikers (-want +got):\n%s", diff)
+	}
+
+	var identifiers []string
+	for _, row := range implementations {
+		identifiers = append(identifiers, row.Identifier)
+	}
+	sort.Strings(identifiers)
+	if diff := cmp.Diff([]string{testIfaceSymbol, testLocalIfaceSymbol}, identifiers); diff != "" {
+		t.Errorf("unexpected implementation rows (-want +got):\n%s", diff)
+	}
+}
+
+func TestCorrelatePrune(t *testing.T) {
+	getChildren := func(ctx context.Context, dirnames []string) (map[string][]string, error) {
+		return map[string][]string{"": {"foo.go"}}, nil
+	}
+
+	maps, _ := correlateTestIndex(t, testIndex(), getChildren)
+
+	if _, ok := maps.Documents["bar.go"]; ok {
+		t.Errorf("expected bar.go to be pruned")
+	}
+
+	fooDefinition := findRange(t, maps.Documents["foo.go"], 1, 5)
+	if diff := cmp.Diff([]string{"foo.go:1:5"}, resolveLocations(maps, fooDefinition.ReferenceResultID)); diff != "" {
+		t.Errorf("unexpected references (-want +got):\n%s", diff)
+	}
+	if _, ok := maps.References["import"]; ok {
+		t.Errorf("unexpected import monikers locations from pruned document")
+	}
+}
+
+func TestCorrelateMissingMetadata(t *testing.T) {
+	index := testIndex()
+	index.Metadata = nil
+
+	payload, err := proto.Marshal(index)
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	if _, err := Correlate(context.Background(), bytes.NewReader(payload), "", nil); err != ErrMissingMetaData {
+		t.Fatalf("unexpected error. want=%q have=%q", ErrMissingMetaData, err)
+	}
+}
+
+func TestCorrelateMalformed(t *testing.T) {
+	payload, err := proto.Marshal(testIndex())
+	if err != nil {
+		t.Fatalf("unexpected error marshalling index: %s", err)
+	}
+
+	for name, payload := range map[string][]byte{
+		"truncated index": payload[:len(payload)-1],
+		// A documents field whose value is not a document message
+		"malformed document": append(append([]byte{}, payload...), 0x12, 0x02, 0xff, 0xff),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Correlate(context.Background(), bytes.NewReader(payload), "", nil); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+
+	t.Run("read error", func(t *testing.T) {
+		readErr := errors.New("connection reset")
+		r := io.MultiReader(bytes.NewReader(payload[:len(payload)/2]), iotest.ErrReader(readErr))
+		if _, err := Correlate(context.Background(), r, "", nil); !errors.Is(err, readErr) {
+			t.Fatalf("unexpected error. want=%q have=%q", readErr, err)
+		}
+	})
+}
+
+func findRange(t *testing.T, document precise.DocumentData, line, character int) precise.RangeData {
+	for _, r := range document.Ranges {
+		if r.StartLine == line && r.StartCharacter == character {
+			return r
+		}
+	}
+
+	t.Fatalf("no range at %d:%d", line, character)
+	return precise.RangeData{}
+}
+
+func resolveLocations(maps *precise.GroupedBundleDataMaps, resultID precise.ID) (locations []string) {
+	resultChunk := maps.ResultChunks[precise.HashKey(resultID, maps.Meta.NumResultChunks)]
+
+	for _, documentIDRangeID := range resultChunk.DocumentIDRangeIDs[resultID] {
+		path := resultChunk.DocumentPaths[documentIDRangeID.DocumentID]
+		r := maps.Documents[path].Ranges[documentIDRangeID.RangeID]
+		locations = append(locations, fmt.Sprintf("%s:%d:%d", path, r.StartLine, r.StartCharacter))
+	}
+
+	return locations
+}
@@ -0,0 +1,374 @@
+package conversion
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// resultsPerResultChunk is the number of target keys in a single result chunk. This mirrors
+// the value used by LSIF correlation so that both produce similarly sized result chunks.
+const resultsPerResultChunk = 512
+
+// groupBundleData converts a correlation State into a GroupedBundleData. Documents are
+// decoded a second time from the raw index as they are consumed by the writer.
+func groupBundleData(ctx context.Context, state *State) (*precise.GroupedBundleDataChans, error) {
+	numResultChunks := int(math.Max(1, math.Floor(float64(state.NumResults)/resultsPerResultChunk)))
+
+	meta := precise.MetaData{NumResultChunks: numResultChunks}
+	documents := serializeBundleDocuments(ctx, state)
+	resultChunks := serializeResultChunks(ctx, state, numResultChunks)
+	definitionRows := gatherMonikersLocations(ctx, state, []string{"export"}, func(s *SymbolState) []Location { return s.Definitions })
+	referenceRows := gatherMonikersLocations(ctx, state, []string{"import", "export"}, func(s *SymbolState) []Location { return s.References })
+	implementationRows := gatherMonikersLocations(ctx, state, []string{"implementation"}, func(s *SymbolState) []Location { return s.Definitions })
+	packages := gatherPackages(state)
+	packageReferences := gatherPackageReferences(state, packages)
+
+	return &precise.GroupedBundleDataChans{
+		Meta:              meta,
+		Documents:         documents,
+		ResultChunks:      resultChunks,
+		Definitions:       definitionRows,
+		References:        referenceRows,
+		Implementations:   implementationRows,
+		Packages:          packages,
+		PackageReferences: packageReferences,
+	}, nil
+}
+
+func serializeBundleDocuments(ctx context.Context, state *State) chan precise.KeyedDocumentData {
+	ch := make(chan precise.KeyedDocumentData)
+
+	go func() {
+		defer close(ch)
+
+		// Hover text overriding the documentation of a symbol is attached to a single
+		// occurrence. These identifiers are allocated after all correlated identifiers.
+		nextHoverID := state.maxID
+
+		for _, documentState := range state.Documents {
+			if documentState.Pruned {
+				continue
+			}
+
+			data := precise.KeyedDocumentData{
+				Path:     documentState.Path,
+				Document: serializeDocument(state, documentState, &nextHoverID),
+			}
+			// The occurrences are no longer needed once the document is serialized
+			documentState.Occurrences = nil
+
+			select {
+			case ch <- data:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+func serializeDocument(state *State, documentState *DocumentState, nextHoverID *int) precise.DocumentData {
+	document := precise.DocumentData{
+		Ranges:             make(map[precise.ID]precise.RangeData, len(documentState.Occurrences)),
+		HoverResults:       map[precise.ID]string{},
+		Monikers:           map[precise.ID]precise.MonikerData{},
+		PackageInformation: map[precise.ID]precise.PackageInformationData{},
+		Diagnostics:        []precise.DiagnosticData{},
+	}
+
+	for i, occurrence := range documentState.Occurrences {
+		id := documentState.RangeBase + i
+		r := occurrence.Range
+		symbol := occurrence.Symbol
+		document.Diagnostics = append(document.Diagnostics, occurrence.Diagnostics...)
+
+		hoverResultID := symbol.HoverResultID
+		if hover := occurrence.Hover; hover != "" {
+			*nextHoverID++
+			hoverResultID = *nextHoverID
+			document.HoverResults[toID(hoverResultID)] = hover
+		} else if hoverResultID != 0 {
+			document.HoverResults[toID(hoverResultID)] = symbol.Hover
+		}
+
+		monikerIDs := make([]precise.ID, 0, len(symbol.MonikerIDs))
+		for _, monikerID := range symbol.MonikerIDs {
+			moniker := state.MonikerData[monikerID]
+			monikerIDs = append(monikerIDs, toID(monikerID))
+
+			document.Monikers[toID(monikerID)] = precise.MonikerData{
+				Kind:                 moniker.Kind,
+				Scheme:               moniker.Scheme,
+				Identifier:           moniker.Identifier,
+				PackageInformationID: toID(moniker.PackageInformationID),
+			}
+
+			if moniker.PackageInformationID != 0 {
+				document.PackageInformation[toID(moniker.PackageInformationID)] = state.PackageInformationData[moniker.PackageInformationID]
+			}
+		}
+
+		document.Ranges[toID(id)] = precise.RangeData{
+			StartLine:              r.StartLine,
+			StartCharacter:         r.StartCharacter,
+			EndLine:                r.EndLine,
+			EndCharacter:           r.EndCharacter,
+			DefinitionResultID:     toID(symbol.DefinitionResultID),
+			ReferenceResultID:      toID(symbol.ReferenceResultID),
+			ImplementationResultID: toID(symbol.ImplementationResultID),
+			HoverResultID:          toID(hoverResultID),
+			MonikerIDs:             monikerIDs,
+		}
+	}
+
+	return document
+}
+
+func serializeResultChunks(ctx context.Context, state *State, numResultChunks int) chan precise.IndexedResultChunkData {
+	type entry struct {
+		id        int
+		locations []Location
+	}
+	chunkAssignments := make(map[int][]entry, numResultChunks)
+	assign := func(id int, locations []Location) {
+		if id == 0 {
+			return
+		}
+
+		index := precise.HashKey(toID(id), numResultChunks)
+		chunkAssignments[index] = append(chunkAssignments[index], entry{id: id, locations: locations})
+	}
+	for _, symbol := range state.symbolOrder {
+		assign(symbol.DefinitionResultID, symbol.Definitions)
+		assign(symbol.ReferenceResultID, symbol.References)
+		assign(symbol.ImplementationResultID, symbol.Implementations)
+	}
+
+	ch := make(chan precise.IndexedResultChunkData)
+
+	go func() {
+		defer close(ch)
+
+		for index, entries := range chunkAssignments {
+			documentPaths := map[precise.ID]string{}
+			rangeIDsByResultID := make(map[precise.ID][]precise.DocumentIDRangeID, len(entries))
+
+			for _, entry := range entries {
+				locations := make([]Location, 0, len(entry.locations))
+				for _, location := range entry.locations {
+					if !state.Documents[location.Document].Pruned {
+						locations = append(locations, location)
+					}
+				}
+
+				// Sort locations by containing document path then by offset within the text
+				// document (in reading order). This provides us with an obvious and deterministic
+				// ordering of a result set over multiple API requests.
+
+				sort.Slice(locations, func(i, j int) bool {
+					return lessLocation(state, locations[i], locations[j])
+				})
+
+				documentIDRangeIDs := make([]precise.DocumentIDRangeID, 0, len(locations))
+				for _, location := range locations {
+					document := state.Documents[location.Document]
+					documentPaths[toID(document.ID)] = document.Path
+
+					documentIDRangeIDs = append(documentIDRangeIDs, precise.DocumentIDRangeID{
+						DocumentID: toID(document.ID),
+						RangeID:    toID(location.RangeID),
+					})
+				}
+
+				rangeIDsByResultID[toID(entry.id)] = documentIDRangeIDs
+			}
+
+			data := precise.IndexedResultChunkData{
+				Index: index,
+				ResultChunk: precise.ResultChunkData{
+					DocumentPaths:      documentPaths,
+					DocumentIDRangeIDs: rangeIDsByResultID,
+				},
+			}
+
+			select {
+			case ch <- data:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+func lessLocation(state *State, a, b Location) bool {
+	if aPath, bPath := state.Documents[a.Document].Path, state.Documents[b.Document].Path; aPath != bPath {
+		return aPath < bPath
+	}
+
+	if a.Range.StartLine != b.Range.StartLine {
+		return a.Range.StartLine < b.Range.StartLine
+	}
+
+	return a.Range.StartCharacter < b.Range.StartCharacter
+}
+
+func gatherMonikersLocations(ctx context.Context, state *State, kinds []string, getLocations func(s *SymbolState) []Location) chan precise.MonikerLocations {
+	type monikerKey struct {
+		kind, scheme, identifier string
+	}
+	var keys []monikerKey
+	locationsByKey := map[monikerKey][]Location{}
+
+	for _, symbol := range state.symbolOrder {
+		for _, monikerID := range symbol.MonikerIDs {
+			moniker := state.MonikerData[monikerID]
+			found := false
+			for _, kind := range kinds {
+				if moniker.Kind == kind {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+
+			key := monikerKey{moniker.Kind, moniker.Scheme, moniker.Identifier}
+			if _, ok := locationsByKey[key]; !ok {
+				keys = append(keys, key)
+			}
+			locationsByKey[key] = append(locationsByKey[key], getLocations(symbol)...)
+		}
+	}
+
+	ch := make(chan precise.MonikerLocations)
+
+	go func() {
+		defer close(ch)
+
+		for _, key := range keys {
+			var locations []precise.LocationData
+			for _, location := range locationsByKey[key] {
+				document := state.Documents[location.Document]
+				if document.Pruned {
+					continue
+				}
+
+				locations = append(locations, precise.LocationData{
+					URI:            document.Path,
+					StartLine:      location.Range.StartLine,
+					StartCharacter: location.Range.StartCharacter,
+					EndLine:        location.Range.EndLine,
+					EndCharacter:   location.Range.EndCharacter,
+				})
+			}
+
+			if len(locations) == 0 {
+				continue
+			}
+
+			// Sort locations by containing document path then by offset within the text
+			// document (in reading order). This provides us with an obvious and deterministic
+			// ordering of a result set over multiple API requests.
+
+			sort.Sort(sortableLocations(locations))
+
+			data := precise.MonikerLocations{
+				Kind:       key.kind,
+				Scheme:     key.scheme,
+				Identifier: key.identifier,
+				Locations:  locations,
+			}
+
+			select {
+			case ch <- data:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// sortableLocations implements sort.Interface for locations.
+type sortableLocations []precise.LocationData
+
+func (s sortableLocations) Len() int      { return len(s) }
+func (s sortableLocations) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s sortableLocations) Less(i, j int) bool {
+	if s[i].URI != s[j].URI {
+		return s[i].URI <= s[j].URI
+	}
+
+	if cmp := s[i].StartLine - s[j].StartLine; cmp != 0 {
+		return cmp < 0
+	}
+
+	return s[i].StartCharacter < s[j].StartCharacter
+}
+
+func gatherPackages(state *State) []precise.Package {
+	uniques := make(map[string]precise.Package, len(state.ExportedMonikers))
+	for id := range state.ExportedMonikers {
+		source := state.MonikerData[id]
+		packageInfo := state.PackageInformationData[source.PackageInformationID]
+
+		uniques[makeKey(source.Scheme, packageInfo.Name, packageInfo.Version)] = precise.Package{
+			Scheme:  source.Scheme,
+			Name:    packageInfo.Name,
+			Version: packageInfo.Version,
+		}
+	}
+
+	packages := make([]precise.Package, 0, len(uniques))
+	for _, v := range uniques {
+		packages = append(packages, v)
+	}
+
+	return packages
+}
+
+func gatherPackageReferences(state *State, packageDefinitions []precise.Package) []precise.PackageReference {
+	packageDefinitionKeySet := make(map[string]struct{}, len(packageDefinitions))
+	for _, pkg := range packageDefinitions {
+		packageDefinitionKeySet[makeKey(pkg.Scheme, pkg.Name, pkg.Version)] = struct{}{}
+	}
+
+	uniques := make(map[string]precise.Package, len(state.ImportedMonikers))
+
+	collect := func(monikers map[int]struct{}) {
+		for id := range monikers {
+			source := state.MonikerData[id]
+			packageInfo := state.PackageInformationData[source.PackageInformationID]
+			key := makeKey(source.Scheme, packageInfo.Name, packageInfo.Version)
+
+			if _, ok := packageDefinitionKeySet[key]; ok {
+				// Storing self-references is a waste of space, see the LSIF conversion
+				continue
+			}
+
+			uniques[key] = precise.Package{
+				Scheme:  source.Scheme,
+				Name:    packageInfo.Name,
+				Version: packageInfo.Version,
+			}
+		}
+	}
+
+	collect(state.ImportedMonikers)
+	collect(state.ImplementedMonikers)
+
+	packageReferences := make([]precise.PackageReference, 0, len(uniques))
+	for _, v := range uniques {
+		packageReferences = append(packageReferences, precise.PackageReference{Package: v})
+	}
+
+	return packageReferences
+}
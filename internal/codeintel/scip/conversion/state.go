@@ -0,0 +1,161 @@
+package conversion
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrMissingMetaData occurs when the index does not contain a metadata message.
+var ErrMissingMetaData = errors.New("no metadata defined")
+
+// State is the correlated data of a SCIP index. Unlike the LSIF correlation state, this
+// state does not hold the documents of the index. Only the symbol, range, hover override
+// and diagnostics of each occurrence are retained to serialize the document.
+type State struct {
+	Documents              []*DocumentState
+	NumResults             int
+	MonikerData            map[int]MonikerState
+	PackageInformationData map[int]precise.PackageInformationData
+	ExportedMonikers       map[int]struct{}
+	ImportedMonikers       map[int]struct{}
+	ImplementedMonikers    map[int]struct{}
+
+	maxID       int
+	symbols     map[string]*SymbolState
+	symbolOrder []*SymbolState
+	packageIDs  map[string]int
+}
+
+// DocumentState holds the identifiers assigned to a single document of the index. The
+// identifier of the range of an occurrence is RangeBase plus the index of the occurrence.
+type DocumentState struct {
+	ID          int
+	Path        string
+	RangeBase   int
+	Pruned      bool
+	Occurrences []OccurrenceState
+}
+
+// OccurrenceState is an occurrence of a symbol within a document.
+type OccurrenceState struct {
+	Symbol      *SymbolState
+	Range       Range
+	Hover       string
+	Diagnostics []precise.DiagnosticData
+}
+
+// SymbolState holds the locations and result identifiers of a single symbol.
+type SymbolState struct {
+	Symbol                 string
+	Local                  bool
+	Defined                bool
+	Hover                  string
+	Implements             []string
+	Definitions            []Location
+	References             []Location
+	Implementations        []Location
+	DefinitionResultID     int
+	ReferenceResultID      int
+	ImplementationResultID int
+	HoverResultID          int
+	MonikerIDs             []int
+}
+
+// Location is a range within a document of the index.
+type Location struct {
+	Document int
+	RangeID  int
+	Range    Range
+}
+
+// Range is the zero-indexed, end-exclusive span of an occurrence.
+type Range struct {
+	StartLine      int
+	StartCharacter int
+	EndLine        int
+	EndCharacter   int
+}
+
+// MonikerState is a moniker attached to a symbol.
+type MonikerState struct {
+	Kind                 string
+	Scheme               string
+	Identifier           string
+	PackageInformationID int
+}
+
+// newState create a new State with zero-valued map fields.
+func newState() *State {
+	return &State{
+		MonikerData:            map[int]MonikerState{},
+		PackageInformationData: map[int]precise.PackageInformationData{},
+		ExportedMonikers:       map[int]struct{}{},
+		ImportedMonikers:       map[int]struct{}{},
+		ImplementedMonikers:    map[int]struct{}{},
+		symbols:                map[string]*SymbolState{},
+		packageIDs:             map[string]int{},
+	}
+}
+
+// nextID returns a fresh identifier unique within this index.
+func (s *State) nextID() int {
+	s.maxID++
+	return s.maxID
+}
+
+// symbol returns the state of the given symbol, creating it if necessary. Local symbols
+// are only unique within a single document and are keyed by the index of that document.
+func (s *State) symbol(document int, symbol string) *SymbolState {
+	key := symbolKey(document, symbol)
+	if state, ok := s.symbols[key]; ok {
+		return state
+	}
+
+	state := &SymbolState{Symbol: symbol, Local: scip.IsLocalSymbol(symbol)}
+	s.symbols[key] = state
+	s.symbolOrder = append(s.symbolOrder, state)
+	return state
+}
+
+func symbolKey(document int, symbol string) string {
+	if scip.IsLocalSymbol(symbol) {
+		return strconv.Itoa(document) + ":" + symbol
+	}
+
+	return symbol
+}
+
+// newOccurrenceState returns the state of the given occurrence of symbol at range r.
+func newOccurrenceState(symbol *SymbolState, r Range, occurrence *scip.Occurrence) OccurrenceState {
+	o := OccurrenceState{Symbol: symbol, Range: r, Hover: formatHover(occurrence.OverrideDocumentation)}
+	for _, diagnostic := range occurrence.Diagnostics {
+		o.Diagnostics = append(o.Diagnostics, precise.DiagnosticData{
+			Severity:       int(diagnostic.Severity),
+			Code:           diagnostic.Code,
+			Message:        diagnostic.Message,
+			Source:         diagnostic.Source,
+			StartLine:      r.StartLine,
+			StartCharacter: r.StartCharacter,
+			EndLine:        r.EndLine,
+			EndCharacter:   r.EndCharacter,
+		})
+	}
+	return o
+}
+
+// setHover sets the hover text of the symbol unless it was already set by another
+// document or external symbol.
+func (s *SymbolState) setHover(documentation []string) {
+	if s.Hover == "" {
+		s.Hover = formatHover(documentation)
+	}
+}
+
+func formatHover(documentation []string) string {
+	return strings.Join(documentation, "\n\n---\n\n")
+}
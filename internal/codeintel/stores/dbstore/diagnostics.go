@@ -0,0 +1,404 @@
+package dbstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+)
+
+// DiagnosticCount is the number of diagnostics with the same severity, source, and code.
+type DiagnosticCount struct {
+	Severity int
+	Source   string
+	Code     string
+	Count    int
+}
+
+// SelectRepositoriesForDiagnosticSnapshot returns a set of repository identifiers with uploads visible
+// at the tip of their default branch. Repositories for which a diagnostic snapshot was recorded within
+// the given process delay are not returned.
+func (s *Store) SelectRepositoriesForDiagnosticSnapshot(ctx context.Context, processDelay time.Duration, limit int) (_ []int, err error) {
+	return s.selectRepositoriesForDiagnosticSnapshot(ctx, processDelay, limit, timeutil.Now())
+}
+
+func (s *Store) selectRepositoriesForDiagnosticSnapshot(ctx context.Context, processDelay time.Duration, limit int, now time.Time) (_ []int, err error) {
+	ctx, trace, endObservation := s.operations.selectRepositoriesForDiagnosticSnapshot.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	repositoryIDs, err := basestore.ScanInts(s.Query(ctx, sqlf.Sprintf(
+		selectRepositoriesForDiagnosticSnapshotQuery,
+		now,
+		int(processDelay/time.Second),
+		limit,
+	)))
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numRepositories", len(repositoryIDs)))
+
+	return repositoryIDs, nil
+}
+
+const selectRepositoriesForDiagnosticSnapshotQuery = `
+-- source: internal/codeintel/stores/dbstore/diagnostics.go:selectRepositoriesForDiagnosticSnapshot
+WITH
+candidate_repositories AS (
+	SELECT DISTINCT uvt.repository_id AS id
+	FROM lsif_uploads_visible_at_tip uvt
+	WHERE uvt.is_default_branch
+),
+last_snapshots AS (
+	SELECT s.repository_id, MAX(s.recorded_at) AS recorded_at
+	FROM codeintel_diagnostic_snapshots s
+	GROUP BY s.repository_id
+)
+SELECT cr.id
+FROM candidate_repositories cr
+JOIN repo r ON r.id = cr.id
+LEFT JOIN last_snapshots ls ON ls.repository_id = cr.id
+WHERE
+	r.deleted_at IS NULL AND
+	-- Ignore repositories that have been snapshotted recently. Note this condition
+	-- is true for a null recorded_at (which has never been snapshotted).
+	(%s - ls.recorded_at > (%s * '1 second'::interval)) IS DISTINCT FROM FALSE
+ORDER BY
+	ls.recorded_at NULLS FIRST,
+	cr.id -- tie breaker
+LIMIT %s
+`
+
+// GetUploadIDsVisibleAtDefaultBranchTip returns the identifiers of the completed uploads that are visible
+// at the tip of the default branch of the given repository.
+func (s *Store) GetUploadIDsVisibleAtDefaultBranchTip(ctx context.Context, repositoryID int) (_ []int, err error) {
+	ctx, trace, endObservation := s.operations.getUploadIDsVisibleAtDefaultBranchTip.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	uploadIDs, err := basestore.ScanInts(s.Query(ctx, sqlf.Sprintf(getUploadIDsVisibleAtDefaultBranchTipQuery, repositoryID)))
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numUploads", len(uploadIDs)))
+
+	return uploadIDs, nil
+}
+
+const getUploadIDsVisibleAtDefaultBranchTipQuery = `
+-- source: internal/codeintel/stores/dbstore/diagnostics.go:GetUploadIDsVisibleAtDefaultBranchTip
+SELECT u.id
+FROM lsif_uploads_visible_at_tip uvt
+JOIN lsif_uploads u ON u.id = uvt.upload_id
+WHERE
+	uvt.repository_id = %s AND
+	uvt.is_default_branch AND
+	u.state = 'completed'
+ORDER BY u.id
+`
+
+// InsertDiagnosticSnapshot records the given diagnostic counts for the set of uploads visible at the tip
+// of the default branch of the given repository. Each count must have a distinct severity, source, and
+// code. A snapshot is recorded even when there are no diagnostics so that the repository is not selected
+// again until the process delay has elapsed.
+func (s *Store) InsertDiagnosticSnapshot(ctx context.Context, repositoryID int, uploadIDs []int, counts []DiagnosticCount) error {
+	return s.insertDiagnosticSnapshot(ctx, repositoryID, uploadIDs, counts, timeutil.Now())
+}
+
+func (s *Store) insertDiagnosticSnapshot(ctx context.Context, repositoryID int, uploadIDs []int, counts []DiagnosticCount, now time.Time) (err error) {
+	ctx, _, endObservation := s.operations.insertDiagnosticSnapshot.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.String("uploadIDs", intsToString(uploadIDs)),
+		log.Int("numCounts", len(counts)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if uploadIDs == nil {
+		uploadIDs = []int{}
+	}
+
+	snapshotID, _, err := basestore.ScanFirstInt(tx.Query(ctx, sqlf.Sprintf(
+		insertDiagnosticSnapshotQuery,
+		repositoryID,
+		pq.Array(uploadIDs),
+		now,
+	)))
+	if err != nil {
+		return err
+	}
+
+	return batch.InsertValues(
+		ctx,
+		tx.Handle().DB(),
+		"codeintel_diagnostic_counts",
+		batch.MaxNumPostgresParameters,
+		[]string{"snapshot_id", "severity", "source", "code", "count"},
+		loadDiagnosticCountsChannel(snapshotID, counts),
+	)
+}
+
+const insertDiagnosticSnapshotQuery = `
+-- source: internal/codeintel/stores/dbstore/diagnostics.go:insertDiagnosticSnapshot
+INSERT INTO codeintel_diagnostic_snapshots (repository_id, upload_ids, recorded_at)
+VALUES (%s, %s, %s)
+RETURNING id
+`
+
+// CopyDiagnosticSnapshotIfUnchanged records a copy of the most recent diagnostic snapshot of the given
+// repository if it was recorded for exactly the given set of uploads. This avoids recomputing the counts
+// of uploads that have already been aggregated while keeping a snapshot in each time bucket. This method
+// returns false if no snapshot was copied.
+func (s *Store) CopyDiagnosticSnapshotIfUnchanged(ctx context.Context, repositoryID int, uploadIDs []int) (bool, error) {
+	return s.copyDiagnosticSnapshotIfUnchanged(ctx, repositoryID, uploadIDs, timeutil.Now())
+}
+
+func (s *Store) copyDiagnosticSnapshotIfUnchanged(ctx context.Context, repositoryID int, uploadIDs []int, now time.Time) (_ bool, err error) {
+	ctx, trace, endObservation := s.operations.copyDiagnosticSnapshotIfUnchanged.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.String("uploadIDs", intsToString(uploadIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if uploadIDs == nil {
+		uploadIDs = []int{}
+	}
+
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(
+		copyDiagnosticSnapshotIfUnchangedQuery,
+		repositoryID,
+		now,
+		pq.Array(uploadIDs),
+	)))
+	if err != nil {
+		return false, err
+	}
+	trace.Log(log.Bool("copied", count > 0))
+
+	return count > 0, nil
+}
+
+const copyDiagnosticSnapshotIfUnchangedQuery = `
+-- source: internal/codeintel/stores/dbstore/diagnostics.go:copyDiagnosticSnapshotIfUnchanged
+WITH
+latest_snapshot AS (
+	SELECT s.id, s.repository_id, s.upload_ids
+	FROM codeintel_diagnostic_snapshots s
+	WHERE s.repository_id = %s
+	ORDER BY s.recorded_at DESC, s.id DESC
+	LIMIT 1
+),
+inserted AS (
+	INSERT INTO codeintel_diagnostic_snapshots (repository_id, upload_ids, recorded_at)
+	SELECT ls.repository_id, ls.upload_ids, %s
+	FROM latest_snapshot ls
+	WHERE ls.upload_ids = %s
+	RETURNING id
+),
+copied AS (
+	INSERT INTO codeintel_diagnostic_counts (snapshot_id, severity, source, code, count)
+	SELECT i.id, c.severity, c.source, c.code, c.count
+	FROM inserted i
+	CROSS JOIN latest_snapshot ls
+	JOIN codeintel_diagnostic_counts c ON c.snapshot_id = ls.id
+)
+SELECT COUNT(*) FROM inserted
+`
+
+func loadDiagnosticCountsChannel(snapshotID int, counts []DiagnosticCount) <-chan []any {
+	ch := make(chan []any, len(counts))
+
+	go func() {
+		defer close(ch)
+
+		for _, c := range counts {
+			ch <- []any{snapshotID, c.Severity, c.Source, c.Code, c.Count}
+		}
+	}()
+
+	return ch
+}
+
+// DeleteDiagnosticSnapshotsBefore removes the diagnostic snapshots recorded before the given time
+// and returns the number of snapshots deleted.
+func (s *Store) DeleteDiagnosticSnapshotsBefore(ctx context.Context, before time.Time) (_ int, err error) {
+	ctx, trace, endObservation := s.operations.deleteDiagnosticSnapshotsBefore.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("before", before.String()),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(deleteDiagnosticSnapshotsBeforeQuery, before)))
+	if err != nil {
+		return 0, err
+	}
+	trace.Log(log.Int("count", count))
+
+	return count, nil
+}
+
+const deleteDiagnosticSnapshotsBeforeQuery = `
+-- source: internal/codeintel/stores/dbstore/diagnostics.go:DeleteDiagnosticSnapshotsBefore
+WITH deleted AS (
+	DELETE FROM codeintel_diagnostic_snapshots
+	WHERE recorded_at < %s
+	RETURNING id
+)
+SELECT COUNT(*) FROM deleted
+`
+
+// DiagnosticTrendGrouping determines which fields distinguish the series returned by GetDiagnosticTrends.
+type DiagnosticTrendGrouping string
+
+const (
+	DiagnosticTrendGroupingSeverity DiagnosticTrendGrouping = "severity"
+	DiagnosticTrendGroupingSource   DiagnosticTrendGrouping = "source"
+	DiagnosticTrendGroupingCode     DiagnosticTrendGrouping = "code"
+)
+
+// DiagnosticTrendInterval is the width of the time buckets returned by GetDiagnosticTrends.
+type DiagnosticTrendInterval string
+
+const (
+	DiagnosticTrendIntervalDay   DiagnosticTrendInterval = "day"
+	DiagnosticTrendIntervalWeek  DiagnosticTrendInterval = "week"
+	DiagnosticTrendIntervalMonth DiagnosticTrendInterval = "month"
+)
+
+// DiagnosticTrendPoint is the total number of diagnostics in a series at the start of a time bucket.
+// Fields that do not distinguish the series under the requested grouping are zero-valued. Grouping by
+// code also groups by source, as diagnostic codes are specific to the tool that produced them.
+type DiagnosticTrendPoint struct {
+	Time     time.Time
+	Severity int
+	Source   string
+	Code     string
+	Count    int
+}
+
+// GetDiagnosticTrendsOptions filters and groups the results of GetDiagnosticTrends.
+type GetDiagnosticTrendsOptions struct {
+	// RepositoryID restricts results to a single repository when non-zero.
+	RepositoryID int
+
+	// Severity, Source, and Code restrict results to the matching diagnostics when non-zero.
+	Severity int
+	Source   string
+	Code     string
+
+	GroupBy  DiagnosticTrendGrouping
+	Interval DiagnosticTrendInterval
+
+	// From and To restrict results to snapshots recorded in the half-open interval [From, To)
+	// when non-nil.
+	From *time.Time
+	To   *time.Time
+}
+
+func scanDiagnosticTrendPoint(s dbutil.Scanner) (point DiagnosticTrendPoint, err error) {
+	return point, s.Scan(&point.Time, &point.Severity, &point.Source, &point.Code, &point.Count)
+}
+
+var scanDiagnosticTrendPoints = basestore.NewSliceScanner(scanDiagnosticTrendPoint)
+
+// GetDiagnosticTrends returns the number of diagnostics in each time bucket of the requested interval,
+// summed over all (or the requested) repositories. Each repository contributes its most recent snapshot
+// within the bucket. Points are ordered by time, then by series.
+func (s *Store) GetDiagnosticTrends(ctx context.Context, opts GetDiagnosticTrendsOptions) (_ []DiagnosticTrendPoint, err error) {
+	ctx, trace, endObservation := s.operations.getDiagnosticTrends.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", opts.RepositoryID),
+		log.Int("severity", opts.Severity),
+		log.String("source", opts.Source),
+		log.String("code", opts.Code),
+		log.String("groupBy", string(opts.GroupBy)),
+		log.String("interval", string(opts.Interval)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	interval := opts.Interval
+	if interval == "" {
+		interval = DiagnosticTrendIntervalDay
+	}
+
+	snapshotConds := []*sqlf.Query{sqlf.Sprintf("r.deleted_at IS NULL")}
+	if opts.RepositoryID != 0 {
+		snapshotConds = append(snapshotConds, sqlf.Sprintf("s.repository_id = %s", opts.RepositoryID))
+	}
+	if opts.From != nil {
+		snapshotConds = append(snapshotConds, sqlf.Sprintf("s.recorded_at >= %s", *opts.From))
+	}
+	if opts.To != nil {
+		snapshotConds = append(snapshotConds, sqlf.Sprintf("s.recorded_at < %s", *opts.To))
+	}
+
+	countConds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.Severity != 0 {
+		countConds = append(countConds, sqlf.Sprintf("c.severity = %s", opts.Severity))
+	}
+	if opts.Source != "" {
+		countConds = append(countConds, sqlf.Sprintf("c.source = %s", opts.Source))
+	}
+	if opts.Code != "" {
+		countConds = append(countConds, sqlf.Sprintf("c.code = %s", opts.Code))
+	}
+
+	var groupColumns *sqlf.Query
+	switch opts.GroupBy {
+	case DiagnosticTrendGroupingSource:
+		groupColumns = sqlf.Sprintf("0, c.source, ''")
+	case DiagnosticTrendGroupingCode:
+		groupColumns = sqlf.Sprintf("0, c.source, c.code")
+	default:
+		groupColumns = sqlf.Sprintf("c.severity, '', ''")
+	}
+
+	points, err := scanDiagnosticTrendPoints(s.Query(ctx, sqlf.Sprintf(
+		getDiagnosticTrendsQuery,
+		string(interval),
+		sqlf.Join(snapshotConds, " AND "),
+		groupColumns,
+		sqlf.Join(countConds, " AND "),
+	)))
+	if err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numPoints", len(points)))
+
+	return points, nil
+}
+
+const getDiagnosticTrendsQuery = `
+-- source: internal/codeintel/stores/dbstore/diagnostics.go:GetDiagnosticTrends
+WITH
+snapshots AS (
+	SELECT s.id, s.repository_id, s.recorded_at, date_trunc(%s, s.recorded_at) AS bucket
+	FROM codeintel_diagnostic_snapshots s
+	JOIN repo r ON r.id = s.repository_id
+	WHERE %s
+),
+latest_snapshots AS (
+	SELECT DISTINCT ON (s.repository_id, s.bucket) s.id, s.bucket
+	FROM snapshots s
+	ORDER BY s.repository_id, s.bucket, s.recorded_at DESC
+)
+SELECT ls.bucket, %s, SUM(c.count)
+FROM latest_snapshots ls
+JOIN codeintel_diagnostic_counts c ON c.snapshot_id = ls.id
+WHERE %s
+GROUP BY 1, 2, 3, 4
+ORDER BY 1, 2, 3, 4
+`
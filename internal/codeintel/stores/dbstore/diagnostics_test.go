@@ -0,0 +1,222 @@
+package dbstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+)
+
+func TestSelectRepositoriesForDiagnosticSnapshot(t *testing.T) {
+	db := dbtest.NewDB(t)
+	store := testStore(db)
+	ctx := context.Background()
+
+	insertUploads(t, db,
+		Upload{ID: 1, RepositoryID: 50, State: "completed"},
+		Upload{ID: 2, RepositoryID: 51, State: "completed"},
+		Upload{ID: 3, RepositoryID: 52, State: "completed"},
+		Upload{ID: 4, RepositoryID: 53, State: "completed"},
+	)
+	insertVisibleAtTip(t, db, 50, 1)
+	insertVisibleAtTip(t, db, 51, 2)
+	insertVisibleAtTip(t, db, 52, 3)
+	insertVisibleAtTipNonDefaultBranch(t, db, 53, 4)
+
+	now := timeutil.Now()
+
+	if repositoryIDs, err := store.selectRepositoriesForDiagnosticSnapshot(ctx, time.Hour, 2, now); err != nil {
+		t.Fatalf("unexpected error selecting repositories: %s", err)
+	} else if diff := cmp.Diff([]int{50, 51}, repositoryIDs); diff != "" {
+		t.Fatalf("unexpected repository list (-want +got):\n%s", diff)
+	}
+
+	for _, repositoryID := range []int{50, 51} {
+		if err := store.insertDiagnosticSnapshot(ctx, repositoryID, nil, nil, now); err != nil {
+			t.Fatalf("unexpected error inserting snapshot: %s", err)
+		}
+	}
+
+	// 30 minutes later, recently snapshotted repositories are still on cooldown
+	if repositoryIDs, err := store.selectRepositoriesForDiagnosticSnapshot(ctx, time.Hour, 100, now.Add(time.Minute*30)); err != nil {
+		t.Fatalf("unexpected error selecting repositories: %s", err)
+	} else if diff := cmp.Diff([]int{52}, repositoryIDs); diff != "" {
+		t.Fatalf("unexpected repository list (-want +got):\n%s", diff)
+	}
+
+	// 90 minutes later, all repositories are visible (least recently snapshotted first)
+	if repositoryIDs, err := store.selectRepositoriesForDiagnosticSnapshot(ctx, time.Hour, 100, now.Add(time.Minute*90)); err != nil {
+		t.Fatalf("unexpected error selecting repositories: %s", err)
+	} else if diff := cmp.Diff([]int{52, 50, 51}, repositoryIDs); diff != "" {
+		t.Fatalf("unexpected repository list (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetUploadIDsVisibleAtDefaultBranchTip(t *testing.T) {
+	db := dbtest.NewDB(t)
+	store := testStore(db)
+
+	insertUploads(t, db,
+		Upload{ID: 1, RepositoryID: 50, State: "completed"},
+		Upload{ID: 2, RepositoryID: 50, State: "completed"},
+		Upload{ID: 3, RepositoryID: 50, State: "completed"},
+		Upload{ID: 4, RepositoryID: 50, State: "deleting"},
+	)
+	insertVisibleAtTip(t, db, 50, 1, 3, 4)
+	insertVisibleAtTipNonDefaultBranch(t, db, 50, 2)
+
+	if uploadIDs, err := store.GetUploadIDsVisibleAtDefaultBranchTip(context.Background(), 50); err != nil {
+		t.Fatalf("unexpected error getting uploads: %s", err)
+	} else if diff := cmp.Diff([]int{1, 3}, uploadIDs); diff != "" {
+		t.Fatalf("unexpected upload ids (-want +got):\n%s", diff)
+	}
+}
+
+func TestCopyDiagnosticSnapshotIfUnchanged(t *testing.T) {
+	db := dbtest.NewDB(t)
+	store := testStore(db)
+	ctx := context.Background()
+
+	insertRepo(t, db, 50, "")
+
+	day1 := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.Add(time.Hour * 24)
+
+	if copied, err := store.copyDiagnosticSnapshotIfUnchanged(ctx, 50, []int{1, 2}, day1); err != nil {
+		t.Fatalf("unexpected error copying snapshot: %s", err)
+	} else if copied {
+		t.Fatalf("unexpected copy of missing snapshot")
+	}
+
+	counts := []DiagnosticCount{
+		{Severity: 1, Source: "go", Code: "E1", Count: 8},
+		{Severity: 2, Source: "lint", Code: "W1", Count: 5},
+	}
+	if err := store.insertDiagnosticSnapshot(ctx, 50, []int{1, 2}, counts, day1); err != nil {
+		t.Fatalf("unexpected error inserting snapshot: %s", err)
+	}
+
+	if copied, err := store.copyDiagnosticSnapshotIfUnchanged(ctx, 50, []int{1, 3}, day2); err != nil {
+		t.Fatalf("unexpected error copying snapshot: %s", err)
+	} else if copied {
+		t.Fatalf("unexpected copy of snapshot with different uploads")
+	}
+
+	if copied, err := store.copyDiagnosticSnapshotIfUnchanged(ctx, 50, []int{1, 2}, day2); err != nil {
+		t.Fatalf("unexpected error copying snapshot: %s", err)
+	} else if !copied {
+		t.Fatalf("expected snapshot to be copied")
+	}
+
+	points, err := store.GetDiagnosticTrends(ctx, GetDiagnosticTrendsOptions{GroupBy: DiagnosticTrendGroupingSeverity})
+	if err != nil {
+		t.Fatalf("unexpected error getting trends: %s", err)
+	}
+	expected := []DiagnosticTrendPoint{
+		{Time: day1, Severity: 1, Count: 8},
+		{Time: day1, Severity: 2, Count: 5},
+		{Time: day2, Severity: 1, Count: 8},
+		{Time: day2, Severity: 2, Count: 5},
+	}
+	if diff := cmp.Diff(expected, points); diff != "" {
+		t.Errorf("unexpected trend points (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetDiagnosticTrends(t *testing.T) {
+	db := dbtest.NewDB(t)
+	store := testStore(db)
+	ctx := context.Background()
+
+	insertRepo(t, db, 50, "")
+	insertRepo(t, db, 51, "")
+
+	day1 := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.Add(time.Hour * 24)
+
+	snapshots := []struct {
+		repositoryID int
+		recordedAt   time.Time
+		counts       []DiagnosticCount
+	}{
+		{50, day1.Add(time.Hour), []DiagnosticCount{
+			{Severity: 1, Source: "go", Code: "E1", Count: 10},
+			{Severity: 2, Source: "lint", Code: "W1", Count: 5},
+		}},
+		// Supersedes the earlier snapshot of repository 50 on day 1
+		{50, day1.Add(time.Hour * 12), []DiagnosticCount{
+			{Severity: 1, Source: "go", Code: "E1", Count: 8},
+			{Severity: 2, Source: "lint", Code: "W1", Count: 5},
+		}},
+		{51, day1.Add(time.Hour * 6), []DiagnosticCount{
+			{Severity: 2, Source: "lint", Code: "W1", Count: 3},
+			{Severity: 2, Source: "lint", Code: "W2", Count: 1},
+		}},
+		{50, day2.Add(time.Hour), []DiagnosticCount{
+			{Severity: 2, Source: "lint", Code: "W1", Count: 4},
+		}},
+	}
+	for _, snapshot := range snapshots {
+		if err := store.insertDiagnosticSnapshot(ctx, snapshot.repositoryID, []int{1}, snapshot.counts, snapshot.recordedAt); err != nil {
+			t.Fatalf("unexpected error inserting snapshot: %s", err)
+		}
+	}
+
+	testCases := []struct {
+		opts     GetDiagnosticTrendsOptions
+		expected []DiagnosticTrendPoint
+	}{
+		{
+			opts: GetDiagnosticTrendsOptions{GroupBy: DiagnosticTrendGroupingSeverity},
+			expected: []DiagnosticTrendPoint{
+				{Time: day1, Severity: 1, Count: 8},
+				{Time: day1, Severity: 2, Count: 9},
+				{Time: day2, Severity: 2, Count: 4},
+			},
+		},
+		{
+			opts: GetDiagnosticTrendsOptions{GroupBy: DiagnosticTrendGroupingCode, Source: "lint"},
+			expected: []DiagnosticTrendPoint{
+				{Time: day1, Source: "lint", Code: "W1", Count: 8},
+				{Time: day1, Source: "lint", Code: "W2", Count: 1},
+				{Time: day2, Source: "lint", Code: "W1", Count: 4},
+			},
+		},
+		{
+			opts: GetDiagnosticTrendsOptions{GroupBy: DiagnosticTrendGroupingSource, RepositoryID: 51},
+			expected: []DiagnosticTrendPoint{
+				{Time: day1, Source: "lint", Count: 4},
+			},
+		},
+		{
+			opts: GetDiagnosticTrendsOptions{GroupBy: DiagnosticTrendGroupingSeverity, From: &day2},
+			expected: []DiagnosticTrendPoint{
+				{Time: day2, Severity: 2, Count: 4},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		points, err := store.GetDiagnosticTrends(ctx, testCase.opts)
+		if err != nil {
+			t.Fatalf("unexpected error getting diagnostic trends: %s", err)
+		}
+
+		for i := range points {
+			points[i].Time = points[i].Time.UTC()
+		}
+		if diff := cmp.Diff(testCase.expected, points); diff != "" {
+			t.Errorf("unexpected points for %+v (-want +got):\n%s", testCase.opts, diff)
+		}
+	}
+
+	if count, err := store.DeleteDiagnosticSnapshotsBefore(ctx, day2); err != nil {
+		t.Fatalf("unexpected error deleting snapshots: %s", err)
+	} else if count != 3 {
+		t.Errorf("unexpected number of deleted snapshots. want=%d have=%d", 3, count)
+	}
+}
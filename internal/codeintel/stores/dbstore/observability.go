@@ -12,9 +12,12 @@ type operations struct {
 	calculateVisibleUploads                     *observation.Operation
 	commitGraphMetadata                         *observation.Operation
 	commitsVisibleToUpload                      *observation.Operation
+	copyDiagnosticSnapshotIfUnchanged           *observation.Operation
+	copyPackagesAndReferences                   *observation.Operation
 	createConfigurationPolicy                   *observation.Operation
 	definitionDumps                             *observation.Operation
 	deleteConfigurationPolicyByID               *observation.Operation
+	deleteDiagnosticSnapshotsBefore             *observation.Operation
 	deleteIndexByID                             *observation.Operation
 	deleteIndexesWithoutRepository              *observation.Operation
 	deleteOldAuditLogs                          *observation.Operation
@@ -30,6 +33,7 @@ type operations struct {
 	findClosestDumpsFromGraphFragment           *observation.Operation
 	getConfigurationPolicies                    *observation.Operation
 	getConfigurationPolicyByID                  *observation.Operation
+	getDiagnosticTrends                         *observation.Operation
 	getDumpsByIDs                               *observation.Operation
 	getIndexByID                                *observation.Operation
 	getIndexConfigurationByRepositoryID         *observation.Operation
@@ -37,6 +41,7 @@ type operations struct {
 	getIndexesByIDs                             *observation.Operation
 	getOldestCommitDate                         *observation.Operation
 	getUploadByID                               *observation.Operation
+	getUploadIDsVisibleAtDefaultBranchTip       *observation.Operation
 	getUploads                                  *observation.Operation
 	getUploadsByIDs                             *observation.Operation
 	hardDeleteUploadByID                        *observation.Operation
@@ -46,6 +51,7 @@ type operations struct {
 	insertCloneableDependencyRepo               *observation.Operation
 	insertDependencyIndexingJob                 *observation.Operation
 	insertDependencySyncingJob                  *observation.Operation
+	insertDiagnosticSnapshot                    *observation.Operation
 	insertIndex                                 *observation.Operation
 	insertUpload                                *observation.Operation
 	isQueued                                    *observation.Operation
@@ -72,6 +78,7 @@ type operations struct {
 	requeue                                     *observation.Operation
 	requeueIndex                                *observation.Operation
 	selectPoliciesForRepositoryMembershipUpdate *observation.Operation
+	selectRepositoriesForDiagnosticSnapshot     *observation.Operation
 	selectRepositoriesForIndexScan              *observation.Operation
 	selectRepositoriesForRetentionScan          *observation.Operation
 	softDeleteExpiredUploads                    *observation.Operation
@@ -118,9 +125,12 @@ func newOperations(observationContext *observation.Context, metrics *metrics.RED
 		calculateVisibleUploads:              op("CalculateVisibleUploads"),
 		commitGraphMetadata:                  op("CommitGraphMetadata"),
 		commitsVisibleToUpload:               op("CommitsVisibleToUpload"),
+		copyDiagnosticSnapshotIfUnchanged:    op("CopyDiagnosticSnapshotIfUnchanged"),
+		copyPackagesAndReferences:            op("CopyPackagesAndReferences"),
 		createConfigurationPolicy:            op("CreateConfigurationPolicy"),
 		definitionDumps:                      op("DefinitionDumps"),
 		deleteConfigurationPolicyByID:        op("DeleteConfigurationPolicyByID"),
+		deleteDiagnosticSnapshotsBefore:      op("DeleteDiagnosticSnapshotsBefore"),
 		deleteIndexByID:                      op("DeleteIndexByID"),
 		deleteIndexesWithoutRepository:       op("DeleteIndexesWithoutRepository"),
 		deleteOldAuditLogs:                   op("DeleteOldAuditLogs"),
@@ -136,6 +146,7 @@ func newOperations(observationContext *observation.Context, metrics *metrics.RED
 		findClosestDumpsFromGraphFragment:    op("FindClosestDumpsFromGraphFragment"),
 		getConfigurationPolicies:             op("GetConfigurationPolicies"),
 		getConfigurationPolicyByID:           op("GetConfigurationPolicyByID"),
+		getDiagnosticTrends:                  op("GetDiagnosticTrends"),
 		getDumpsByIDs:                        op("GetDumpsByIDs"),
 		getIndexByID:                         op("GetIndexByID"),
 		getIndexConfigurationByRepositoryID:  op("GetIndexConfigurationByRepositoryID"),
@@ -152,6 +163,7 @@ func newOperations(observationContext *observation.Context, metrics *metrics.RED
 		insertCloneableDependencyRepo:        op("InsertCloneableDependencyRepo"),
 		insertDependencyIndexingJob:          op("InsertDependencyIndexingJob"),
 		insertDependencySyncingJob:           op("InsertDependencySyncingJob"),
+		insertDiagnosticSnapshot:             op("InsertDiagnosticSnapshot"),
 		insertIndex:                          op("InsertIndex"),
 		insertUpload:                         op("InsertUpload"),
 		isQueued:                             op("IsQueued"),
@@ -179,6 +191,7 @@ func newOperations(observationContext *observation.Context, metrics *metrics.RED
 		requeueIndex:                         op("RequeueIndex"),
 
 		selectPoliciesForRepositoryMembershipUpdate: op("selectPoliciesForRepositoryMembershipUpdate"),
+		selectRepositoriesForDiagnosticSnapshot:     op("SelectRepositoriesForDiagnosticSnapshot"),
 		selectRepositoriesForIndexScan:              op("SelectRepositoriesForIndexScan"),
 		selectRepositoriesForRetentionScan:          op("SelectRepositoriesForRetentionScan"),
 		softDeleteExpiredUploads:                    op("SoftDeleteExpiredUploads"),
@@ -187,6 +200,8 @@ func newOperations(observationContext *observation.Context, metrics *metrics.RED
 		updateConfigurationPolicy:                   op("UpdateConfigurationPolicy"),
 		updateReferenceCounts:                       op("UpdateReferenceCounts"),
 
+		getUploadIDsVisibleAtDefaultBranchTip: op("GetUploadIDsVisibleAtDefaultBranchTip"),
+
 		updateIndexConfigurationByRepositoryID: op("UpdateIndexConfigurationByRepositoryID"),
 		updatePackageReferences:                op("UpdatePackageReferences"),
 		updatePackages:                         op("UpdatePackages"),
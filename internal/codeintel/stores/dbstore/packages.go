@@ -79,3 +79,64 @@ func loadPackagesChannel(packages []precise.Package) <-chan []any {
 
 	return ch
 }
+
+// CopyPackagesAndReferences copies the package and package reference rows of the source dump to the
+// target dump. Rows describing a package already provided (or referenced) by the target dump are not
+// copied. This is used to carry the cross-repository data of a base upload forward to an incremental
+// upload, which may over-approximate the set of packages referenced by the target dump when a changed
+// document no longer references a package referenced by the base upload.
+func (s *Store) CopyPackagesAndReferences(ctx context.Context, sourceDumpID, targetDumpID int) (err error) {
+	ctx, _, endObservation := s.operations.copyPackagesAndReferences.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("sourceDumpID", sourceDumpID),
+		log.Int("targetDumpID", targetDumpID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tx, err := s.transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(copyPackagesQuery, targetDumpID, sourceDumpID, targetDumpID)); err != nil {
+		return err
+	}
+
+	return tx.Exec(ctx, sqlf.Sprintf(copyReferencesQuery, targetDumpID, sourceDumpID, targetDumpID))
+}
+
+const copyPackagesQuery = `
+-- source: internal/codeintel/stores/dbstore/packages.go:CopyPackagesAndReferences
+INSERT INTO lsif_packages (dump_id, scheme, name, version)
+SELECT %s, p.scheme, p.name, p.version
+FROM lsif_packages p
+WHERE
+	p.dump_id = %s AND
+	NOT EXISTS (
+		SELECT 1
+		FROM lsif_packages t
+		WHERE
+			t.dump_id = %s AND
+			t.scheme = p.scheme AND
+			t.name = p.name AND
+			t.version IS NOT DISTINCT FROM p.version
+	)
+`
+
+const copyReferencesQuery = `
+-- source: internal/codeintel/stores/dbstore/packages.go:CopyPackagesAndReferences
+INSERT INTO lsif_references (dump_id, scheme, name, version, filter)
+SELECT %s, r.scheme, r.name, r.version, r.filter
+FROM lsif_references r
+WHERE
+	r.dump_id = %s AND
+	NOT EXISTS (
+		SELECT 1
+		FROM lsif_references t
+		WHERE
+			t.dump_id = %s AND
+			t.scheme = r.scheme AND
+			t.name = r.name AND
+			t.version IS NOT DISTINCT FROM r.version
+	)
+`
@@ -56,3 +56,56 @@ func TestUpdatePackagesEmpty(t *testing.T) {
 		t.Errorf("unexpected package count. want=%d have=%d", 0, count)
 	}
 }
+
+func TestCopyPackagesAndReferences(t *testing.T) {
+	db := dbtest.NewDB(t)
+	store := testStore(db)
+
+	// for foreign key relation
+	insertUploads(t, db, Upload{ID: 42}, Upload{ID: 43})
+
+	if err := store.UpdatePackages(context.Background(), 42, []precise.Package{
+		{Scheme: "s0", Name: "n0", Version: "v0"},
+		{Scheme: "s1", Name: "n1", Version: "v1"},
+	}); err != nil {
+		t.Fatalf("unexpected error updating packages: %s", err)
+	}
+	if err := store.UpdatePackageReferences(context.Background(), 42, []precise.PackageReference{
+		{Package: precise.Package{Scheme: "s2", Name: "n2", Version: "v2"}},
+		{Package: precise.Package{Scheme: "s3", Name: "n3", Version: "v3"}},
+	}); err != nil {
+		t.Fatalf("unexpected error updating package references: %s", err)
+	}
+
+	// Overlaps with data of the source dump
+	if err := store.UpdatePackages(context.Background(), 43, []precise.Package{
+		{Scheme: "s1", Name: "n1", Version: "v1"},
+	}); err != nil {
+		t.Fatalf("unexpected error updating packages: %s", err)
+	}
+	if err := store.UpdatePackageReferences(context.Background(), 43, []precise.PackageReference{
+		{Package: precise.Package{Scheme: "s3", Name: "n3", Version: "v3"}},
+	}); err != nil {
+		t.Fatalf("unexpected error updating package references: %s", err)
+	}
+
+	if err := store.CopyPackagesAndReferences(context.Background(), 42, 43); err != nil {
+		t.Fatalf("unexpected error copying packages and references: %s", err)
+	}
+
+	packageCount, _, err := basestore.ScanFirstInt(db.Query("SELECT COUNT(*) FROM lsif_packages WHERE dump_id = 43"))
+	if err != nil {
+		t.Fatalf("unexpected error checking package count: %s", err)
+	}
+	if packageCount != 2 {
+		t.Errorf("unexpected package count. want=%d have=%d", 2, packageCount)
+	}
+
+	referenceCount, _, err := basestore.ScanFirstInt(db.Query("SELECT COUNT(*) FROM lsif_references WHERE dump_id = 43"))
+	if err != nil {
+		t.Fatalf("unexpected error checking package reference count: %s", err)
+	}
+	if referenceCount != 2 {
+		t.Errorf("unexpected package reference count. want=%d have=%d", 2, referenceCount)
+	}
+}
@@ -46,6 +46,8 @@ type Upload struct {
 	UploadSize        *int64     `json:"uploadSize"`
 	Rank              *int       `json:"placeInQueue"`
 	AssociatedIndexID *int       `json:"associatedIndex"`
+	BaseUploadID      *int       `json:"baseUploadId"`
+	ChangedPaths      []string   `json:"changedPaths"`
 }
 
 func (u Upload) RecordID() int {
@@ -75,6 +77,8 @@ func scanUpload(s dbutil.Scanner) (upload Upload, _ error) {
 		pq.Array(&rawUploadedParts),
 		&upload.UploadSize,
 		&upload.AssociatedIndexID,
+		&upload.BaseUploadID,
+		pq.Array(&upload.ChangedPaths),
 		&upload.Rank,
 	); err != nil {
 		return upload, err
@@ -111,6 +115,8 @@ func scanUploadWithCount(s dbutil.Scanner) (upload Upload, count int, _ error) {
 		pq.Array(&rawUploadedParts),
 		&upload.UploadSize,
 		&upload.AssociatedIndexID,
+		&upload.BaseUploadID,
+		pq.Array(&upload.ChangedPaths),
 		&upload.Rank,
 		&count,
 	); err != nil {
@@ -205,6 +211,8 @@ SELECT
 	u.uploaded_parts,
 	u.upload_size,
 	u.associated_index_id,
+	u.base_upload_id,
+	u.changed_paths,
 	s.rank
 FROM lsif_uploads u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
@@ -263,6 +271,8 @@ SELECT
 	u.uploaded_parts,
 	u.upload_size,
 	u.associated_index_id,
+	u.base_upload_id,
+	u.changed_paths,
 	s.rank
 FROM lsif_uploads u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
@@ -504,6 +514,8 @@ SELECT
 	u.uploaded_parts,
 	u.upload_size,
 	u.associated_index_id,
+	u.base_upload_id,
+	u.changed_paths,
 	s.rank,
 	COUNT(*) OVER() AS count
 FROM lsif_uploads u
@@ -618,6 +630,8 @@ func (s *Store) InsertUpload(ctx context.Context, upload Upload) (id int, err er
 			pq.Array(upload.UploadedParts),
 			upload.UploadSize,
 			upload.AssociatedIndexID,
+			upload.BaseUploadID,
+			pq.Array(upload.ChangedPaths),
 		),
 	))
 
@@ -636,8 +650,10 @@ INSERT INTO lsif_uploads (
 	num_parts,
 	uploaded_parts,
 	upload_size,
-	associated_index_id
-) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+	associated_index_id,
+	base_upload_id,
+	changed_paths
+) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING id
 `
 
@@ -722,6 +738,8 @@ var uploadColumnsWithNullRank = []*sqlf.Query{
 	sqlf.Sprintf("u.uploaded_parts"),
 	sqlf.Sprintf("u.upload_size"),
 	sqlf.Sprintf("u.associated_index_id"),
+	sqlf.Sprintf("u.base_upload_id"),
+	sqlf.Sprintf("u.changed_paths"),
 	sqlf.Sprintf("NULL"),
 }
 
@@ -1527,6 +1545,8 @@ SELECT
 	u.uploaded_parts,
 	u.upload_size,
 	u.associated_index_id,
+	u.base_upload_id,
+	u.changed_paths,
 	s.rank
 FROM lsif_uploads_with_repository_name u
 LEFT JOIN (` + uploadRankQueryFragment + `) s
@@ -0,0 +1,150 @@
+package lsifstore
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// ReadMeta is called from the precise-code-intel-worker to read the metadata of the base
+// bundle of an incremental upload. This method returns a false-valued flag if the bundle
+// does not exist.
+func (s *Store) ReadMeta(ctx context.Context, bundleID int) (_ precise.MetaData, _ bool, err error) {
+	ctx, _, endObservation := s.operations.readMeta.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	numResultChunks, exists, err := basestore.ScanFirstInt(s.Store.Query(ctx, sqlf.Sprintf(readMetaQuery, bundleID)))
+	if err != nil || !exists {
+		return precise.MetaData{}, false, err
+	}
+
+	return precise.MetaData{NumResultChunks: numResultChunks}, true, nil
+}
+
+const readMetaQuery = `
+-- source: internal/codeintel/stores/lsifstore/data_copy.go:ReadMeta
+SELECT num_result_chunks FROM lsif_data_metadata WHERE dump_id = %s
+`
+
+// CopyDocuments is called (transactionally) from the precise-code-intel-worker to copy the
+// documents of the base bundle of an incremental upload that are not replaced by the upload.
+// Documents of the source bundle whose path is in the given set of excluded paths are skipped.
+func (s *Store) CopyDocuments(ctx context.Context, sourceBundleID, targetBundleID int, excludedPaths []string) (count uint32, err error) {
+	ctx, trace, endObservation := s.operations.copyDocuments.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("sourceBundleID", sourceBundleID),
+		log.Int("targetBundleID", targetBundleID),
+		log.Int("numExcludedPaths", len(excludedPaths)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if excludedPaths == nil {
+		excludedPaths = []string{}
+	}
+
+	numCopied, _, err := basestore.ScanFirstInt(s.Store.Query(ctx, sqlf.Sprintf(
+		copyDocumentsQuery,
+		targetBundleID,
+		sourceBundleID,
+		pq.Array(excludedPaths),
+	)))
+	if err != nil {
+		return 0, err
+	}
+	trace.Log(log.Int("numDocumentRecords", numCopied))
+
+	return uint32(numCopied), nil
+}
+
+const copyDocumentsQuery = `
+-- source: internal/codeintel/stores/lsifstore/data_copy.go:CopyDocuments
+WITH ins AS (
+	INSERT INTO lsif_data_documents (dump_id, schema_version, path, data, ranges, hovers, monikers, packages, diagnostics, num_diagnostics)
+	SELECT %s, d.schema_version, d.path, d.data, d.ranges, d.hovers, d.monikers, d.packages, d.diagnostics, d.num_diagnostics
+	FROM lsif_data_documents d
+	WHERE d.dump_id = %s AND NOT (d.path = ANY(%s))
+	RETURNING 1
+)
+SELECT COUNT(*) FROM ins
+`
+
+// ScanResultChunks is called from the precise-code-intel-worker to read the result chunks of
+// the base bundle of an incremental upload. The given function is invoked with each result
+// chunk of the bundle.
+func (s *Store) ScanResultChunks(ctx context.Context, bundleID int, f func(index int, resultChunk precise.ResultChunkData)) (err error) {
+	ctx, _, endObservation := s.operations.scanResultChunks.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.makeResultChunkVisitor(s.Store.Query(ctx, sqlf.Sprintf(scanResultChunksQuery, bundleID)))(f)
+}
+
+const scanResultChunksQuery = `
+-- source: internal/codeintel/stores/lsifstore/data_copy.go:ScanResultChunks
+SELECT idx, data FROM lsif_data_result_chunks WHERE dump_id = %s ORDER BY idx
+`
+
+// ScanDefinitions is called from the precise-code-intel-worker to read the definition moniker
+// locations of the base bundle of an incremental upload.
+func (s *Store) ScanDefinitions(ctx context.Context, bundleID int, f func(monikerLocations precise.MonikerLocations)) (err error) {
+	ctx, _, endObservation := s.operations.scanDefinitions.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.scanMonikers(ctx, bundleID, "lsif_data_definitions", f)
+}
+
+// ScanReferences is called from the precise-code-intel-worker to read the reference moniker
+// locations of the base bundle of an incremental upload.
+func (s *Store) ScanReferences(ctx context.Context, bundleID int, f func(monikerLocations precise.MonikerLocations)) (err error) {
+	ctx, _, endObservation := s.operations.scanReferences.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.scanMonikers(ctx, bundleID, "lsif_data_references", f)
+}
+
+// ScanImplementations is called from the precise-code-intel-worker to read the implementation
+// moniker locations of the base bundle of an incremental upload.
+func (s *Store) ScanImplementations(ctx context.Context, bundleID int, f func(monikerLocations precise.MonikerLocations)) (err error) {
+	ctx, _, endObservation := s.operations.scanImplementations.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.scanMonikers(ctx, bundleID, "lsif_data_implementations", f)
+}
+
+func (s *Store) scanMonikers(ctx context.Context, bundleID int, tableName string, f func(monikerLocations precise.MonikerLocations)) (err error) {
+	rows, err := s.Store.Query(ctx, sqlf.Sprintf(scanMonikersQuery, sqlf.Sprintf(tableName), bundleID))
+	if err != nil {
+		return err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	for rows.Next() {
+		record, err := s.scanSingleQualifiedMonikerLocationsObject(rows)
+		if err != nil {
+			return err
+		}
+
+		f(record.MonikerLocations)
+	}
+
+	return nil
+}
+
+const scanMonikersQuery = `
+-- source: internal/codeintel/stores/lsifstore/data_copy.go:scanMonikers
+SELECT dump_id, scheme, identifier, data FROM %s WHERE dump_id = %s ORDER BY scheme, identifier
+`
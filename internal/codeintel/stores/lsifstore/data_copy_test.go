@@ -0,0 +1,75 @@
+package lsifstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+func TestDatabaseReadMeta(t *testing.T) {
+	store := populateTestStore(t)
+
+	meta, exists, err := store.ReadMeta(context.Background(), testBundleID)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if !exists {
+		t.Fatalf("expected metadata to exist")
+	}
+	if diff := cmp.Diff(precise.MetaData{NumResultChunks: 3}, meta); diff != "" {
+		t.Errorf("unexpected metadata (-want +got):\n%s", diff)
+	}
+
+	if _, exists, err := store.ReadMeta(context.Background(), testBundleID+1); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	} else if exists {
+		t.Errorf("unexpected metadata for missing bundle")
+	}
+}
+
+func TestDatabaseCopyDocuments(t *testing.T) {
+	store := populateTestStore(t)
+	targetBundleID := testBundleID + 1
+
+	count, err := store.CopyDocuments(context.Background(), testBundleID, targetBundleID, []string{"cmd/lsif-go/main.go"})
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if count != 6 {
+		t.Errorf("unexpected number of copied documents. want=%d have=%d", 6, count)
+	}
+
+	testCases := []struct {
+		path     string
+		expected bool
+	}{
+		{"cmd/lsif-go/main.go", false},
+		{"internal/index/indexer.go", true},
+	}
+
+	for _, testCase := range testCases {
+		if exists, err := store.Exists(context.Background(), targetBundleID, testCase.path); err != nil {
+			t.Fatalf("unexpected error %s", err)
+		} else if exists != testCase.expected {
+			t.Errorf("unexpected exists result for %s. want=%v have=%v", testCase.path, testCase.expected, exists)
+		}
+	}
+}
+
+func TestDatabaseScanResultChunks(t *testing.T) {
+	store := populateTestStore(t)
+
+	var indexes []int
+	if err := store.ScanResultChunks(context.Background(), testBundleID, func(index int, resultChunk precise.ResultChunkData) {
+		indexes = append(indexes, index)
+	}); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	if diff := cmp.Diff([]int{0, 1, 2}, indexes); diff != "" {
+		t.Errorf("unexpected result chunk indexes (-want +got):\n%s", diff)
+	}
+}
@@ -2,11 +2,13 @@ package lsifstore
 
 import (
 	"context"
+	"sort"
 
 	"github.com/keegancsmith/sqlf"
 	"github.com/opentracing/opentracing-go/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
 )
 
 // Diagnostics returns the diagnostics for the documents that have the given path prefix. This method
@@ -68,3 +70,75 @@ WHERE
 	path LIKE %s
 ORDER BY path
 `
+
+// DiagnosticCounts returns the number of diagnostics in the given bundle grouped by severity, source,
+// and code. The result set is ordered by descending count.
+func (s *Store) DiagnosticCounts(ctx context.Context, bundleID int) (_ []DiagnosticCount, err error) {
+	ctx, trace, endObservation := s.operations.diagnosticCounts.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	type key struct {
+		severity     int
+		source, code string
+	}
+	counts := map[key]int{}
+
+	numDocuments := 0
+	visitor := s.makeDocumentVisitor(func(path string, document precise.DocumentData) {
+		numDocuments++
+
+		for _, diagnostic := range document.Diagnostics {
+			counts[key{diagnostic.Severity, diagnostic.Source, diagnostic.Code}]++
+		}
+	})
+	if err := visitor(s.Store.Query(ctx, sqlf.Sprintf(diagnosticCountsQuery, bundleID))); err != nil {
+		return nil, err
+	}
+	trace.Log(log.Int("numDocuments", numDocuments))
+
+	diagnosticCounts := make([]DiagnosticCount, 0, len(counts))
+	for k, count := range counts {
+		diagnosticCounts = append(diagnosticCounts, DiagnosticCount{
+			Severity: k.severity,
+			Source:   k.source,
+			Code:     k.code,
+			Count:    count,
+		})
+	}
+	sort.Slice(diagnosticCounts, func(i, j int) bool {
+		a, b := diagnosticCounts[i], diagnosticCounts[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Severity != b.Severity {
+			return a.Severity < b.Severity
+		}
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		return a.Code < b.Code
+	})
+	trace.Log(log.Int("numDiagnosticCounts", len(diagnosticCounts)))
+
+	return diagnosticCounts, nil
+}
+
+const diagnosticCountsQuery = `
+-- source: internal/codeintel/stores/lsifstore/diagnostics.go:DiagnosticCounts
+SELECT
+	dump_id,
+	path,
+	data,
+	NULL AS ranges,
+	NULL AS hovers,
+	NULL AS monikers,
+	NULL AS packages,
+	diagnostics
+FROM
+	lsif_data_documents
+WHERE
+	dump_id = %s AND
+	num_diagnostics > 0
+`
@@ -10,9 +10,11 @@ import (
 type operations struct {
 	bulkMonikerResults     *observation.Operation
 	clear                  *observation.Operation
+	copyDocuments          *observation.Operation
 	definitions            *observation.Operation
 	deleteOldSearchRecords *observation.Operation
 	diagnostics            *observation.Operation
+	diagnosticCounts       *observation.Operation
 	exists                 *observation.Operation
 	hover                  *observation.Operation
 	implementations        *observation.Operation
@@ -20,7 +22,12 @@ type operations struct {
 	monikersByPosition     *observation.Operation
 	packageInformation     *observation.Operation
 	ranges                 *observation.Operation
+	readMeta               *observation.Operation
 	references             *observation.Operation
+	scanDefinitions        *observation.Operation
+	scanImplementations    *observation.Operation
+	scanReferences         *observation.Operation
+	scanResultChunks       *observation.Operation
 	stencil                *observation.Operation
 	writeDefinitions       *observation.Operation
 	writeDocuments         *observation.Operation
@@ -61,9 +68,11 @@ func newOperations(observationContext *observation.Context) *operations {
 	return &operations{
 		bulkMonikerResults:     op("BulkMonikerResults"),
 		clear:                  op("Clear"),
+		copyDocuments:          op("CopyDocuments"),
 		definitions:            op("Definitions"),
 		deleteOldSearchRecords: op("DeleteOldSearchRecords"),
 		diagnostics:            op("Diagnostics"),
+		diagnosticCounts:       op("DiagnosticCounts"),
 		exists:                 op("Exists"),
 		hover:                  op("Hover"),
 		implementations:        op("Implementations"),
@@ -71,7 +80,12 @@ func newOperations(observationContext *observation.Context) *operations {
 		monikersByPosition:     op("MonikersByPosition"),
 		packageInformation:     op("PackageInformation"),
 		ranges:                 op("Ranges"),
+		readMeta:               op("ReadMeta"),
 		references:             op("References"),
+		scanDefinitions:        op("ScanDefinitions"),
+		scanImplementations:    op("ScanImplementations"),
+		scanReferences:         op("ScanReferences"),
+		scanResultChunks:       op("ScanResultChunks"),
 		stencil:                op("Stencil"),
 		writeDefinitions:       op("WriteDefinitions"),
 		writeDocuments:         op("WriteDocuments"),
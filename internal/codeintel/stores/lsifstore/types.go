@@ -29,6 +29,14 @@ type Diagnostic struct {
 	precise.DiagnosticData
 }
 
+// DiagnosticCount is the number of diagnostics in a single upload with the same severity, source, and code.
+type DiagnosticCount struct {
+	Severity int
+	Source   string
+	Code     string
+	Count    int
+}
+
 // CodeIntelligenceRange pairs a range with its definitions, references, implementations, and hover text.
 type CodeIntelligenceRange struct {
 	Range           Range
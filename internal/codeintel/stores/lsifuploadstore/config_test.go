@@ -106,6 +106,57 @@ func TestConfigGCS(t *testing.T) {
 	}
 }
 
+func TestConfigAzure(t *testing.T) {
+	env := map[string]string{
+		"PRECISE_CODE_INTEL_UPLOAD_BACKEND":            "Azure",
+		"PRECISE_CODE_INTEL_UPLOAD_BUCKET":             "lsif-uploads",
+		"PRECISE_CODE_INTEL_UPLOAD_AZURE_ACCOUNT_NAME": "test-account",
+		"PRECISE_CODE_INTEL_UPLOAD_AZURE_ACCOUNT_KEY":  "test-account-key",
+	}
+
+	config := Config{}
+	config.SetMockGetter(mapGetter(env))
+	config.Load()
+
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+
+	if config.AzureAccountName != "test-account" {
+		t.Errorf("unexpected value for Azure.AccountName. want=%s have=%s", "test-account", config.AzureAccountName)
+	}
+	if config.AzureAccountKey != "test-account-key" {
+		t.Errorf("unexpected value for Azure.AccountKey. want=%s have=%s", "test-account-key", config.AzureAccountKey)
+	}
+	if config.AzureEndpoint != "" {
+		t.Errorf("unexpected value for Azure.Endpoint. want=%s have=%s", "", config.AzureEndpoint)
+	}
+	if !config.RequiresExpiration() {
+		t.Errorf("expected Azure backend to require expiration")
+	}
+}
+
+func TestConfigFilesystem(t *testing.T) {
+	env := map[string]string{
+		"PRECISE_CODE_INTEL_UPLOAD_BACKEND": "Filesystem",
+	}
+
+	config := Config{}
+	config.SetMockGetter(mapGetter(env))
+	config.Load()
+
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+
+	if config.FilesystemRoot != "/data/lsif-uploads" {
+		t.Errorf("unexpected value for Filesystem.Root. want=%s have=%s", "/data/lsif-uploads", config.FilesystemRoot)
+	}
+	if !config.RequiresExpiration() {
+		t.Errorf("expected Filesystem backend to require expiration")
+	}
+}
+
 func mapGetter(env map[string]string) func(name, defaultValue, description string) string {
 	return func(name, defaultValue, description string) string {
 		if v, ok := env[name]; ok {
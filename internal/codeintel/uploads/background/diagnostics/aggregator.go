@@ -0,0 +1,144 @@
+package diagnostics
+
+import (
+	"context"
+	"sort"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type aggregator struct {
+	dbStore   DBStore
+	lsifStore LSIFStore
+	metrics   *metrics
+}
+
+var (
+	_ goroutine.Handler      = &aggregator{}
+	_ goroutine.ErrorHandler = &aggregator{}
+)
+
+// Handle records a snapshot of the diagnostic counts of the uploads visible at the tip of the default
+// branch for each repository that has not been snapshotted recently, then removes snapshots that have
+// exceeded the retention period.
+func (a *aggregator) Handle(ctx context.Context) (err error) {
+	// Get the batch of repositories that we'll handle in this invocation of the periodic goroutine. This
+	// set contains repositories that have never been snapshotted, or that have been snapshotted least
+	// recently, so that every repository is eventually snapshotted even with a large backlog.
+	repositoryIDs, err := a.dbStore.SelectRepositoriesForDiagnosticSnapshot(ctx, ConfigInst.RepositoryProcessDelay, ConfigInst.RepositoryBatchSize)
+	if err != nil {
+		return errors.Wrap(err, "dbstore.SelectRepositoriesForDiagnosticSnapshot")
+	}
+
+	for _, repositoryID := range repositoryIDs {
+		if repositoryErr := a.handleRepository(ctx, repositoryID); repositoryErr != nil {
+			err = errors.Append(err, repositoryErr)
+		}
+	}
+
+	if ConfigInst.SnapshotRetention > 0 {
+		count, deleteErr := a.dbStore.DeleteDiagnosticSnapshotsBefore(ctx, timeutil.Now().Add(-ConfigInst.SnapshotRetention))
+		if deleteErr != nil {
+			return errors.Append(err, errors.Wrap(deleteErr, "dbstore.DeleteDiagnosticSnapshotsBefore"))
+		}
+		a.metrics.numSnapshotsDeleted.Add(float64(count))
+	}
+
+	return err
+}
+
+func (a *aggregator) HandleError(err error) {
+	a.metrics.numErrors.Inc()
+	log15.Error("Failed to aggregate codeintel diagnostics", "error", err)
+}
+
+func (a *aggregator) handleRepository(ctx context.Context, repositoryID int) error {
+	uploadIDs, err := a.dbStore.GetUploadIDsVisibleAtDefaultBranchTip(ctx, repositoryID)
+	if err != nil {
+		return errors.Wrap(err, "dbstore.GetUploadIDsVisibleAtDefaultBranchTip")
+	}
+
+	// Nothing has been uploaded since the last snapshot, so the previous counts still hold
+	if copied, err := a.dbStore.CopyDiagnosticSnapshotIfUnchanged(ctx, repositoryID, uploadIDs); err != nil {
+		return errors.Wrap(err, "dbstore.CopyDiagnosticSnapshotIfUnchanged")
+	} else if copied {
+		a.metrics.numRepositoriesSnapshotted.Inc()
+		a.metrics.numSnapshotsCopied.Inc()
+		return nil
+	}
+
+	dumps, err := a.dbStore.GetDumpsByIDs(ctx, uploadIDs)
+	if err != nil {
+		return errors.Wrap(err, "dbstore.GetDumpsByIDs")
+	}
+
+	type key struct {
+		severity     int
+		source, code string
+	}
+	countsByRoot := map[string]map[key]int{}
+
+	// Multiple uploads visible at the tip of the default branch may index the same root (one per
+	// indexer), and each of them may report the same diagnostics. Within a root we keep the largest
+	// count reported by any upload so that shared diagnostics are only counted once.
+	for _, dump := range dumps {
+		uploadCounts, err := a.lsifStore.DiagnosticCounts(ctx, dump.ID)
+		if err != nil {
+			return errors.Wrap(err, "lsifstore.DiagnosticCounts")
+		}
+
+		rootCounts, ok := countsByRoot[dump.Root]
+		if !ok {
+			rootCounts = map[key]int{}
+			countsByRoot[dump.Root] = rootCounts
+		}
+
+		for _, c := range uploadCounts {
+			if k := (key{c.Severity, c.Source, c.Code}); c.Count > rootCounts[k] {
+				rootCounts[k] = c.Count
+			}
+		}
+	}
+
+	counts := map[key]int{}
+	for _, rootCounts := range countsByRoot {
+		for k, count := range rootCounts {
+			counts[k] += count
+		}
+	}
+
+	total := 0
+	diagnosticCounts := make([]dbstore.DiagnosticCount, 0, len(counts))
+	for k, count := range counts {
+		total += count
+		diagnosticCounts = append(diagnosticCounts, dbstore.DiagnosticCount{
+			Severity: k.severity,
+			Source:   k.source,
+			Code:     k.code,
+			Count:    count,
+		})
+	}
+	sort.Slice(diagnosticCounts, func(i, j int) bool {
+		a, b := diagnosticCounts[i], diagnosticCounts[j]
+		if a.Severity != b.Severity {
+			return a.Severity < b.Severity
+		}
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		return a.Code < b.Code
+	})
+
+	if err := a.dbStore.InsertDiagnosticSnapshot(ctx, repositoryID, uploadIDs, diagnosticCounts); err != nil {
+		return errors.Wrap(err, "dbstore.InsertDiagnosticSnapshot")
+	}
+
+	a.metrics.numRepositoriesSnapshotted.Inc()
+	a.metrics.numDiagnosticsRecorded.Add(float64(total))
+	return nil
+}
@@ -0,0 +1,109 @@
+package diagnostics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func init() {
+	ConfigInst.RepositoryProcessDelay = 12 * time.Hour
+	ConfigInst.RepositoryBatchSize = 100
+	ConfigInst.SnapshotRetention = 24 * time.Hour
+}
+
+func TestAggregator(t *testing.T) {
+	dbStore := NewMockDBStore()
+	dbStore.SelectRepositoriesForDiagnosticSnapshotFunc.SetDefaultReturn([]int{50, 51, 52}, nil)
+	dbStore.GetUploadIDsVisibleAtDefaultBranchTipFunc.SetDefaultHook(func(ctx context.Context, repositoryID int) ([]int, error) {
+		switch repositoryID {
+		case 50:
+			return []int{1, 2, 3}, nil
+		case 52:
+			return []int{4}, nil
+		}
+		return nil, nil
+	})
+	dbStore.GetDumpsByIDsFunc.SetDefaultHook(func(ctx context.Context, ids []int) (dumps []dbstore.Dump, _ error) {
+		roots := map[int]string{1: "", 2: "sub/", 3: "sub/"}
+		for _, id := range ids {
+			dumps = append(dumps, dbstore.Dump{ID: id, Root: roots[id]})
+		}
+		return dumps, nil
+	})
+	dbStore.CopyDiagnosticSnapshotIfUnchangedFunc.SetDefaultHook(func(ctx context.Context, repositoryID int, uploadIDs []int) (bool, error) {
+		// The uploads of repository 52 have not changed since its last snapshot
+		return repositoryID == 52, nil
+	})
+
+	lsifStore := NewMockLSIFStore()
+	lsifStore.DiagnosticCountsFunc.SetDefaultHook(func(ctx context.Context, bundleID int) ([]lsifstore.DiagnosticCount, error) {
+		switch bundleID {
+		case 1:
+			return []lsifstore.DiagnosticCount{
+				{Severity: 2, Source: "lint", Code: "W1", Count: 3},
+				{Severity: 1, Source: "go", Code: "E1", Count: 1},
+			}, nil
+		case 2:
+			return []lsifstore.DiagnosticCount{
+				{Severity: 2, Source: "lint", Code: "W1", Count: 4},
+			}, nil
+		case 3:
+			// Indexes the same root as upload 2 and reports some of the same diagnostics
+			return []lsifstore.DiagnosticCount{
+				{Severity: 2, Source: "lint", Code: "W1", Count: 2},
+				{Severity: 1, Source: "go", Code: "E2", Count: 5},
+			}, nil
+		}
+		return nil, nil
+	})
+
+	aggregator := &aggregator{
+		dbStore:   dbStore,
+		lsifStore: lsifStore,
+		metrics:   newMetrics(&observation.TestContext),
+	}
+
+	if err := aggregator.Handle(context.Background()); err != nil {
+		t.Fatalf("unexpected error from handle: %s", err)
+	}
+
+	calls := dbStore.InsertDiagnosticSnapshotFunc.History()
+	if len(calls) != 2 {
+		t.Fatalf("unexpected number of snapshots. want=%d have=%d", 2, len(calls))
+	}
+
+	if diff := cmp.Diff([]int{1, 2, 3}, calls[0].Arg2); diff != "" {
+		t.Errorf("unexpected upload identifiers (-want +got):\n%s", diff)
+	}
+	expectedCounts := []dbstore.DiagnosticCount{
+		{Severity: 1, Source: "go", Code: "E1", Count: 1},
+		{Severity: 1, Source: "go", Code: "E2", Count: 5},
+		{Severity: 2, Source: "lint", Code: "W1", Count: 7},
+	}
+	if diff := cmp.Diff(expectedCounts, calls[0].Arg3); diff != "" {
+		t.Errorf("unexpected diagnostic counts (-want +got):\n%s", diff)
+	}
+
+	// Repositories without diagnostics are still snapshotted
+	if calls[1].Arg1 != 51 || len(calls[1].Arg3) != 0 {
+		t.Errorf("unexpected empty snapshot. want=%d have=%d (%d counts)", 51, calls[1].Arg1, len(calls[1].Arg3))
+	}
+
+	// Unchanged repositories are copied without recounting
+	for _, call := range lsifStore.DiagnosticCountsFunc.History() {
+		if call.Arg1 == 4 {
+			t.Errorf("unexpected diagnostic counts requested for upload %d", call.Arg1)
+		}
+	}
+
+	if len(dbStore.DeleteDiagnosticSnapshotsBeforeFunc.History()) != 1 {
+		t.Errorf("expected expired snapshots to be deleted")
+	}
+}
@@ -0,0 +1,25 @@
+package diagnostics
+
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+)
+
+type config struct {
+	env.BaseConfig
+
+	Interval               time.Duration
+	RepositoryBatchSize    int
+	RepositoryProcessDelay time.Duration
+	SnapshotRetention      time.Duration
+}
+
+var ConfigInst = &config{}
+
+func (c *config) Load() {
+	c.Interval = c.GetInterval("CODEINTEL_DIAGNOSTICS_AGGREGATOR_INTERVAL", "1m", "How frequently to run the diagnostics aggregator routine.")
+	c.RepositoryBatchSize = c.GetInt("CODEINTEL_DIAGNOSTICS_AGGREGATOR_REPOSITORY_BATCH_SIZE", "100", "The number of repositories to snapshot at a time.")
+	c.RepositoryProcessDelay = c.GetInterval("CODEINTEL_DIAGNOSTICS_AGGREGATOR_REPOSITORY_PROCESS_DELAY", "12h", "The minimum frequency that the diagnostics of the same repository can be snapshotted.")
+	c.SnapshotRetention = c.GetInterval("CODEINTEL_DIAGNOSTICS_AGGREGATOR_SNAPSHOT_RETENTION", "8760h", "The age after which diagnostic snapshots are deleted. Zero disables deletion.") // about 1 year
+}
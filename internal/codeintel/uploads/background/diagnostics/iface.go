@@ -0,0 +1,22 @@
+package diagnostics
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/stores/lsifstore"
+)
+
+type DBStore interface {
+	SelectRepositoriesForDiagnosticSnapshot(ctx context.Context, processDelay time.Duration, limit int) ([]int, error)
+	GetUploadIDsVisibleAtDefaultBranchTip(ctx context.Context, repositoryID int) ([]int, error)
+	GetDumpsByIDs(ctx context.Context, ids []int) ([]dbstore.Dump, error)
+	CopyDiagnosticSnapshotIfUnchanged(ctx context.Context, repositoryID int, uploadIDs []int) (bool, error)
+	InsertDiagnosticSnapshot(ctx context.Context, repositoryID int, uploadIDs []int, counts []dbstore.DiagnosticCount) error
+	DeleteDiagnosticSnapshotsBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+type LSIFStore interface {
+	DiagnosticCounts(ctx context.Context, bundleID int) ([]lsifstore.DiagnosticCount, error)
+}
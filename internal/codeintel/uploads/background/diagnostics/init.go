@@ -0,0 +1,15 @@
+package diagnostics
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+func NewAggregator(dbStore DBStore, lsifStore LSIFStore, metrics *metrics) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), ConfigInst.Interval, &aggregator{
+		dbStore:   dbStore,
+		lsifStore: lsifStore,
+		metrics:   metrics,
+	})
+}
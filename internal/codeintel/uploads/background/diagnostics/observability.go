@@ -0,0 +1,58 @@
+package diagnostics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type metrics struct {
+	numRepositoriesSnapshotted prometheus.Counter
+	numSnapshotsCopied         prometheus.Counter
+	numDiagnosticsRecorded     prometheus.Counter
+	numSnapshotsDeleted        prometheus.Counter
+	numErrors                  prometheus.Counter
+}
+
+var NewMetrics = newMetrics
+
+func newMetrics(observationContext *observation.Context) *metrics {
+	counter := func(name, help string) prometheus.Counter {
+		counter := prometheus.NewCounter(prometheus.CounterOpts{
+			Name: name,
+			Help: help,
+		})
+
+		observationContext.Registerer.MustRegister(counter)
+		return counter
+	}
+
+	numRepositoriesSnapshotted := counter(
+		"src_codeintel_background_diagnostic_snapshots_recorded_total",
+		"The number of repositories whose default branch diagnostics were snapshotted.",
+	)
+	numSnapshotsCopied := counter(
+		"src_codeintel_background_diagnostic_snapshots_copied_total",
+		"The number of snapshots copied from the previous snapshot as the visible uploads did not change.",
+	)
+	numDiagnosticsRecorded := counter(
+		"src_codeintel_background_diagnostics_recorded_total",
+		"The number of diagnostics counted in recorded snapshots.",
+	)
+	numSnapshotsDeleted := counter(
+		"src_codeintel_background_diagnostic_snapshots_deleted_total",
+		"The number of diagnostic snapshots deleted after exceeding the retention period.",
+	)
+	numErrors := counter(
+		"src_codeintel_background_diagnostics_aggregator_errors_total",
+		"The number of errors that occur during diagnostics aggregation.",
+	)
+
+	return &metrics{
+		numRepositoriesSnapshotted: numRepositoriesSnapshotted,
+		numSnapshotsCopied:         numSnapshotsCopied,
+		numDiagnosticsRecorded:     numDiagnosticsRecorded,
+		numSnapshotsDeleted:        numSnapshotsDeleted,
+		numErrors:                  numErrors,
+	}
+}
@@ -21,7 +21,7 @@ import "github.com/sourcegraph/sourcegraph/internal/api"
 // practical purposes. For naming values, prefer "VersionedPackage" for
 // situations where there is no connotation of a dependency edge.
 type PackageDependency interface {
-	// The scheme of the dependency (semanticdb, npm)
+	// The scheme of the dependency (semanticdb, npm, ...)
 	Scheme() string
 
 	// Returns the name of the dependency as used by the package manager,
@@ -54,4 +54,7 @@ var (
 	_ PackageDependency = (*NpmDependency)(nil)
 	_ PackageDependency = (*GoDependency)(nil)
 	_ PackageDependency = (*PythonDependency)(nil)
+	_ PackageDependency = (*RustDependency)(nil)
+	_ PackageDependency = (*RubyDependency)(nil)
+	_ PackageDependency = (*PHPDependency)(nil)
 )
@@ -0,0 +1,95 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// PHPDependency is a Composer package hosted on Packagist. Composer package names
+// always consist of a vendor and a project name, such as "symfony/console".
+type PHPDependency struct {
+	Name    string
+	Version string
+
+	// The URL of the archive of this version to download. Possibly empty.
+	PackageURL string
+}
+
+func NewPHPDependency(name, version string) *PHPDependency {
+	return &PHPDependency{
+		Name:    name,
+		Version: version,
+	}
+}
+
+// ParsePHPDependency parses a string in a '<vendor>/<name>(@version>)?' format into
+// a PHPDependency.
+func ParsePHPDependency(dependency string) (*PHPDependency, error) {
+	var dep PHPDependency
+	if i := strings.LastIndex(dependency, "@"); i == -1 {
+		dep.Name = dependency
+	} else {
+		dep.Name = strings.TrimSpace(dependency[:i])
+		dep.Version = strings.TrimSpace(dependency[i+1:])
+	}
+
+	if vendor, project, ok := strings.Cut(dep.Name, "/"); !ok || vendor == "" || project == "" || strings.Contains(project, "/") {
+		return nil, errors.Newf("invalid PHP package name %q, expected <vendor>/<name>", dep.Name)
+	}
+
+	// Composer package names are case-insensitive and lowercase on Packagist.
+	dep.Name = strings.ToLower(dep.Name)
+	return &dep, nil
+}
+
+// ParsePHPDependencyFromRepoName is a convenience function to parse a repo name in a
+// 'packagist/<vendor>/<name>(@<version>)?' format into a PHPDependency.
+func ParsePHPDependencyFromRepoName(name string) (*PHPDependency, error) {
+	dependency := strings.TrimPrefix(name, "packagist/")
+	if len(dependency) == len(name) {
+		return nil, errors.Newf("invalid PHP dependency repo name, missing packagist/ prefix '%s'", name)
+	}
+	return ParsePHPDependency(dependency)
+}
+
+func (p *PHPDependency) Scheme() string {
+	return "php"
+}
+
+func (p *PHPDependency) PackageSyntax() string {
+	return p.Name
+}
+
+func (p *PHPDependency) PackageManagerSyntax() string {
+	if p.Version == "" {
+		return p.Name
+	}
+	return p.Name + "@" + p.Version
+}
+
+func (p *PHPDependency) PackageVersion() string {
+	return p.Version
+}
+
+func (p *PHPDependency) Description() string { return "" }
+
+func (p *PHPDependency) RepoName() api.RepoName {
+	return api.RepoName("packagist/" + p.Name)
+}
+
+func (p *PHPDependency) GitTagFromVersion() string {
+	version := strings.TrimPrefix(p.Version, "v")
+	return "v" + version
+}
+
+func (p *PHPDependency) Less(other PackageDependency) bool {
+	o := other.(*PHPDependency)
+
+	if p.Name == o.Name {
+		return versionGreaterThan(p.Version, o.Version)
+	}
+
+	return p.Name > o.Name
+}
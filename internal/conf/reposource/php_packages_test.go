@@ -0,0 +1,52 @@
+package reposource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestParsePHPDependency(t *testing.T) {
+	tests := []struct {
+		name         string
+		wantRepoName string
+		wantVersion  string
+	}{
+		{
+			name:         "symfony/console",
+			wantRepoName: "packagist/symfony/console",
+			wantVersion:  "",
+		},
+		{
+			name:         "Symfony/Console@v6.1.1",
+			wantRepoName: "packagist/symfony/console",
+			wantVersion:  "v6.1.1",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dep, err := ParsePHPDependency(test.name)
+			require.NoError(t, err)
+			assert.Equal(t, api.RepoName(test.wantRepoName), dep.RepoName())
+			assert.Equal(t, test.wantVersion, dep.PackageVersion())
+		})
+	}
+
+	for _, name := range []string{"console", "symfony/", "/console", "symfony/console/extra"} {
+		if _, err := ParsePHPDependency(name); err == nil {
+			t.Errorf("expected error parsing %q", name)
+		}
+	}
+}
+
+func TestParsePHPDependencyFromRepoName(t *testing.T) {
+	dep, err := ParsePHPDependencyFromRepoName("packagist/monolog/monolog@2.7.0")
+	require.NoError(t, err)
+	assert.Equal(t, NewPHPDependency("monolog/monolog", "2.7.0"), dep)
+
+	_, err = ParsePHPDependencyFromRepoName("monolog/monolog")
+	require.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type RubyDependency struct {
+	Name    string
+	Version string
+}
+
+func NewRubyDependency(name, version string) *RubyDependency {
+	return &RubyDependency{
+		Name:    name,
+		Version: version,
+	}
+}
+
+// ParseRubyDependency parses a string in a '<name>(@version>)?' format into an
+// RubyDependency.
+func ParseRubyDependency(dependency string) (*RubyDependency, error) {
+	var dep RubyDependency
+	if i := strings.LastIndex(dependency, "@"); i == -1 {
+		dep.Name = dependency
+	} else {
+		dep.Name = strings.TrimSpace(dependency[:i])
+		dep.Version = strings.TrimSpace(dependency[i+1:])
+	}
+	return &dep, nil
+}
+
+// ParseRubyDependencyFromRepoName is a convenience function to parse a repo name in a
+// 'rubygems/<name>(@<version>)?' format into a RubyDependency.
+func ParseRubyDependencyFromRepoName(name string) (*RubyDependency, error) {
+	dependency := strings.TrimPrefix(name, "rubygems/")
+	if len(dependency) == len(name) {
+		return nil, errors.Newf("invalid Ruby dependency repo name, missing rubygems/ prefix '%s'", name)
+	}
+	return ParseRubyDependency(dependency)
+}
+
+func (p *RubyDependency) Scheme() string {
+	return "ruby"
+}
+
+func (p *RubyDependency) PackageSyntax() string {
+	return p.Name
+}
+
+func (p *RubyDependency) PackageManagerSyntax() string {
+	if p.Version == "" {
+		return p.Name
+	}
+	return p.Name + "@" + p.Version
+}
+
+func (p *RubyDependency) PackageVersion() string {
+	return p.Version
+}
+
+func (p *RubyDependency) Description() string { return "" }
+
+func (p *RubyDependency) RepoName() api.RepoName {
+	return api.RepoName("rubygems/" + p.Name)
+}
+
+func (p *RubyDependency) GitTagFromVersion() string {
+	version := strings.TrimPrefix(p.Version, "v")
+	return "v" + version
+}
+
+func (p *RubyDependency) Less(other PackageDependency) bool {
+	o := other.(*RubyDependency)
+
+	if p.Name == o.Name {
+		return versionGreaterThan(p.Version, o.Version)
+	}
+
+	return p.Name > o.Name
+}
@@ -25756,6 +25756,9 @@ type MockRepoStore struct {
 	// CreateFunc is an instance of a mock function object controlling the
 	// behavior of the method Create.
 	CreateFunc *RepoStoreCreateFunc
+	// CreateRedirectFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateRedirect.
+	CreateRedirectFunc *RepoStoreCreateRedirectFunc
 	// DeleteFunc is an instance of a mock function object controlling the
 	// behavior of the method Delete.
 	DeleteFunc *RepoStoreDeleteFunc
@@ -25774,6 +25777,9 @@ type MockRepoStore struct {
 	// GetByNameFunc is an instance of a mock function object controlling
 	// the behavior of the method GetByName.
 	GetByNameFunc *RepoStoreGetByNameFunc
+	// GetByRedirectFunc is an instance of a mock function object
+	// controlling the behavior of the method GetByRedirect.
+	GetByRedirectFunc *RepoStoreGetByRedirectFunc
 	// GetFirstRepoNameByCloneURLFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// GetFirstRepoNameByCloneURL.
@@ -25824,6 +25830,11 @@ func NewMockRepoStore() *MockRepoStore {
 				return
 			},
 		},
+		CreateRedirectFunc: &RepoStoreCreateRedirectFunc{
+			defaultHook: func(context.Context, api.RepoName, api.RepoID) (r0 error) {
+				return
+			},
+		},
 		DeleteFunc: &RepoStoreDeleteFunc{
 			defaultHook: func(context.Context, ...api.RepoID) (r0 error) {
 				return
@@ -25854,6 +25865,11 @@ func NewMockRepoStore() *MockRepoStore {
 				return
 			},
 		},
+		GetByRedirectFunc: &RepoStoreGetByRedirectFunc{
+			defaultHook: func(context.Context, api.RepoName) (r0 *types.Repo, r1 error) {
+				return
+			},
+		},
 		GetFirstRepoNameByCloneURLFunc: &RepoStoreGetFirstRepoNameByCloneURLFunc{
 			defaultHook: func(context.Context, string) (r0 api.RepoName, r1 error) {
 				return
@@ -25926,6 +25942,11 @@ func NewStrictMockRepoStore() *MockRepoStore {
 				panic("unexpected invocation of MockRepoStore.Create")
 			},
 		},
+		CreateRedirectFunc: &RepoStoreCreateRedirectFunc{
+			defaultHook: func(context.Context, api.RepoName, api.RepoID) error {
+				panic("unexpected invocation of MockRepoStore.CreateRedirect")
+			},
+		},
 		DeleteFunc: &RepoStoreDeleteFunc{
 			defaultHook: func(context.Context, ...api.RepoID) error {
 				panic("unexpected invocation of MockRepoStore.Delete")
@@ -25956,6 +25977,11 @@ func NewStrictMockRepoStore() *MockRepoStore {
 				panic("unexpected invocation of MockRepoStore.GetByName")
 			},
 		},
+		GetByRedirectFunc: &RepoStoreGetByRedirectFunc{
+			defaultHook: func(context.Context, api.RepoName) (*types.Repo, error) {
+				panic("unexpected invocation of MockRepoStore.GetByRedirect")
+			},
+		},
 		GetFirstRepoNameByCloneURLFunc: &RepoStoreGetFirstRepoNameByCloneURLFunc{
 			defaultHook: func(context.Context, string) (api.RepoName, error) {
 				panic("unexpected invocation of MockRepoStore.GetFirstRepoNameByCloneURL")
@@ -26024,6 +26050,9 @@ func NewMockRepoStoreFrom(i RepoStore) *MockRepoStore {
 		CreateFunc: &RepoStoreCreateFunc{
 			defaultHook: i.Create,
 		},
+		CreateRedirectFunc: &RepoStoreCreateRedirectFunc{
+			defaultHook: i.CreateRedirect,
+		},
 		DeleteFunc: &RepoStoreDeleteFunc{
 			defaultHook: i.Delete,
 		},
@@ -26042,6 +26071,9 @@ func NewMockRepoStoreFrom(i RepoStore) *MockRepoStore {
 		GetByNameFunc: &RepoStoreGetByNameFunc{
 			defaultHook: i.GetByName,
 		},
+		GetByRedirectFunc: &RepoStoreGetByRedirectFunc{
+			defaultHook: i.GetByRedirect,
+		},
 		GetFirstRepoNameByCloneURLFunc: &RepoStoreGetFirstRepoNameByCloneURLFunc{
 			defaultHook: i.GetFirstRepoNameByCloneURL,
 		},
@@ -26296,6 +26328,114 @@ func (c RepoStoreCreateFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// RepoStoreCreateRedirectFunc describes the behavior when the
+// CreateRedirect method of the parent MockRepoStore instance is invoked.
+type RepoStoreCreateRedirectFunc struct {
+	defaultHook func(context.Context, api.RepoName, api.RepoID) error
+	hooks       []func(context.Context, api.RepoName, api.RepoID) error
+	history     []RepoStoreCreateRedirectFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateRedirect delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockRepoStore) CreateRedirect(v0 context.Context, v1 api.RepoName, v2 api.RepoID) error {
+	r0 := m.CreateRedirectFunc.nextHook()(v0, v1, v2)
+	m.CreateRedirectFunc.appendCall(RepoStoreCreateRedirectFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the CreateRedirect
+// method of the parent MockRepoStore instance is invoked and the hook queue
+// is empty.
+func (f *RepoStoreCreateRedirectFunc) SetDefaultHook(hook func(context.Context, api.RepoName, api.RepoID) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateRedirect method of the parent MockRepoStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *RepoStoreCreateRedirectFunc) PushHook(hook func(context.Context, api.RepoName, api.RepoID) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreCreateRedirectFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, api.RepoID) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreCreateRedirectFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoName, api.RepoID) error {
+		return r0
+	})
+}
+
+func (f *RepoStoreCreateRedirectFunc) nextHook() func(context.Context, api.RepoName, api.RepoID) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreCreateRedirectFunc) appendCall(r0 RepoStoreCreateRedirectFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreCreateRedirectFuncCall objects
+// describing the invocations of this function.
+func (f *RepoStoreCreateRedirectFunc) History() []RepoStoreCreateRedirectFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreCreateRedirectFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreCreateRedirectFuncCall is an object that describes an invocation
+// of method CreateRedirect on an instance of MockRepoStore.
+type RepoStoreCreateRedirectFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoStoreCreateRedirectFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreCreateRedirectFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // RepoStoreDeleteFunc describes the behavior when the Delete method of the
 // parent MockRepoStore instance is invoked.
 type RepoStoreDeleteFunc struct {
@@ -26944,6 +27084,114 @@ func (c RepoStoreGetByNameFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// RepoStoreGetByRedirectFunc describes the behavior when the GetByRedirect
+// method of the parent MockRepoStore instance is invoked.
+type RepoStoreGetByRedirectFunc struct {
+	defaultHook func(context.Context, api.RepoName) (*types.Repo, error)
+	hooks       []func(context.Context, api.RepoName) (*types.Repo, error)
+	history     []RepoStoreGetByRedirectFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByRedirect delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockRepoStore) GetByRedirect(v0 context.Context, v1 api.RepoName) (*types.Repo, error) {
+	r0, r1 := m.GetByRedirectFunc.nextHook()(v0, v1)
+	m.GetByRedirectFunc.appendCall(RepoStoreGetByRedirectFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByRedirect method
+// of the parent MockRepoStore instance is invoked and the hook queue is
+// empty.
+func (f *RepoStoreGetByRedirectFunc) SetDefaultHook(hook func(context.Context, api.RepoName) (*types.Repo, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByRedirect method of the parent MockRepoStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *RepoStoreGetByRedirectFunc) PushHook(hook func(context.Context, api.RepoName) (*types.Repo, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoStoreGetByRedirectFunc) SetDefaultReturn(r0 *types.Repo, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName) (*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoStoreGetByRedirectFunc) PushReturn(r0 *types.Repo, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName) (*types.Repo, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoStoreGetByRedirectFunc) nextHook() func(context.Context, api.RepoName) (*types.Repo, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoStoreGetByRedirectFunc) appendCall(r0 RepoStoreGetByRedirectFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoStoreGetByRedirectFuncCall objects
+// describing the invocations of this function.
+func (f *RepoStoreGetByRedirectFunc) History() []RepoStoreGetByRedirectFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoStoreGetByRedirectFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoStoreGetByRedirectFuncCall is an object that describes an invocation
+// of method GetByRedirect on an instance of MockRepoStore.
+type RepoStoreGetByRedirectFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.Repo
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoStoreGetByRedirectFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoStoreGetByRedirectFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // RepoStoreGetFirstRepoNameByCloneURLFunc describes the behavior when the
 // GetFirstRepoNameByCloneURL method of the parent MockRepoStore instance is
 // invoked.
@@ -36,6 +36,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/pagure"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/perforce"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/phabricator"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/sourcegraph"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -75,6 +76,8 @@ type RepoStore interface {
 	GetByIDs(context.Context, ...api.RepoID) ([]*types.Repo, error)
 	GetByName(context.Context, api.RepoName) (*types.Repo, error)
 	GetByHashedName(context.Context, api.RepoHashedName) (*types.Repo, error)
+	GetByRedirect(context.Context, api.RepoName) (*types.Repo, error)
+	CreateRedirect(context.Context, api.RepoName, api.RepoID) error
 	GetFirstRepoNameByCloneURL(context.Context, string) (api.RepoName, error)
 	GetReposSetByIDs(context.Context, ...api.RepoID) (map[api.RepoID]*types.Repo, error)
 	List(context.Context, ReposListOptions) ([]*types.Repo, error)
@@ -222,6 +225,55 @@ func (s *repoStore) GetByName(ctx context.Context, nameOrURI api.RepoName) (_ *t
 	return repos[0], repos[0].IsBlocked()
 }
 
+// GetByRedirect returns the repository that was previously known under the
+// given name, because it has been renamed or transferred on its code host.
+// Callers should prefer GetByName and only fall back to GetByRedirect when no
+// repository with that name exists.
+func (s *repoStore) GetByRedirect(ctx context.Context, name api.RepoName) (_ *types.Repo, err error) {
+	tr, ctx := trace.New(ctx, "repos.GetByRedirect", string(name))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	id, ok, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(getRepoRedirectQueryFmtstr, name)))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &RepoNotFoundErr{Name: name}
+	}
+
+	repo, err := s.Get(ctx, api.RepoID(id))
+	if err != nil {
+		if errors.HasType(err, &RepoNotFoundErr{}) {
+			return nil, &RepoNotFoundErr{Name: name}
+		}
+		return nil, err
+	}
+	return repo, nil
+}
+
+const getRepoRedirectQueryFmtstr = `
+-- source:internal/database/repos.go:GetByRedirect
+SELECT repo_id FROM repo_redirects WHERE name = %s
+`
+
+// CreateRedirect records that the repository with the given ID was
+// previously known under the given name. If a redirect for that name already
+// exists, it is updated to point to the given repository.
+func (s *repoStore) CreateRedirect(ctx context.Context, name api.RepoName, id api.RepoID) error {
+	return s.Exec(ctx, sqlf.Sprintf(createRepoRedirectQueryFmtstr, id, name))
+}
+
+const createRepoRedirectQueryFmtstr = `
+-- source:internal/database/repos.go:CreateRedirect
+INSERT INTO repo_redirects (repo_id, name)
+VALUES (%s, %s)
+ON CONFLICT (name) DO UPDATE
+SET repo_id = EXCLUDED.repo_id, created_at = now()
+`
+
 // GetByHashedName returns the repository with the given hashedName from the database, or an error.
 // RepoHashedName is the repository hashed name.
 // When a repo isn't found or has been blocked, an error is returned.
@@ -490,6 +542,8 @@ func scanRepo(rows *sql.Rows, r *types.Repo) (err error) {
 		r.Metadata = new(phabricator.Repo)
 	case extsvc.TypePagure:
 		r.Metadata = new(pagure.Project)
+	case extsvc.TypeSourcegraph:
+		r.Metadata = new(sourcegraph.Repository)
 	case extsvc.TypeOther:
 		r.Metadata = new(extsvc.OtherRepoMetadata)
 	case extsvc.TypeJVMPackages:
@@ -502,6 +556,10 @@ func scanRepo(rows *sql.Rows, r *types.Repo) (err error) {
 		r.Metadata = &struct{}{}
 	case extsvc.TypeRustPackages:
 		r.Metadata = &struct{}{}
+	case extsvc.TypeRubyPackages:
+		r.Metadata = &struct{}{}
+	case extsvc.TypePHPPackages:
+		r.Metadata = &struct{}{}
 	default:
 		log15.Warn("scanRepo - unknown service type", "type", typ)
 		return nil
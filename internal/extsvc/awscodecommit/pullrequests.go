@@ -0,0 +1,357 @@
+package awscodecommit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/codecommit"
+	codecommittypes "github.com/aws/aws-sdk-go-v2/service/codecommit/types"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// PullRequest is an AWS CodeCommit pull request, annotated with its approval
+// state.
+type PullRequest struct {
+	ID          string // the ID of the pull request
+	RevisionID  string // the ID of the current revision, which changes on every push to the source branch
+	Title       string
+	Description string
+	Status      string // OPEN or CLOSED
+	AuthorARN   string // the ARN of the IAM user that created the pull request
+	Region      string // the AWS region of the repository
+
+	CreationDate     time.Time
+	LastActivityDate time.Time
+
+	RepositoryName       string
+	SourceReference      string // the full ref of the source branch, such as refs/heads/my-branch
+	DestinationReference string // the full ref of the destination branch
+	SourceCommit         string
+	DestinationCommit    string
+	MergeBase            string
+
+	IsMerged bool
+	MergedBy string // the ARN of the IAM user that merged the pull request
+
+	// Approvals are the approvals of the current revision. Revoked approvals
+	// are not included.
+	Approvals []*Approval
+	// Evaluation is the evaluation of the approval rules of the pull request
+	// against the approvals of the current revision.
+	Evaluation *Evaluation
+}
+
+// Pull request statuses.
+const (
+	PullRequestStatusOpen   = string(codecommittypes.PullRequestStatusEnumOpen)
+	PullRequestStatusClosed = string(codecommittypes.PullRequestStatusEnumClosed)
+)
+
+// Approval is an approval of a pull request revision.
+type Approval struct {
+	UserARN string
+	State   string
+}
+
+// ApprovalStateApprove is the state of an approval that hasn't been revoked.
+const ApprovalStateApprove = string(codecommittypes.ApprovalStateApprove)
+
+// Evaluation is the evaluation of the approval rules of a pull request.
+type Evaluation struct {
+	// Approved is true if all approval rules are satisfied.
+	Approved bool
+	// Overridden is true if the approval rules have been overridden.
+	Overridden                bool
+	ApprovalRulesSatisfied    []string
+	ApprovalRulesNotSatisfied []string
+}
+
+// HasApprovalRules returns true if any approval rules apply to the pull
+// request.
+func (e *Evaluation) HasApprovalRules() bool {
+	return e != nil && len(e.ApprovalRulesSatisfied)+len(e.ApprovalRulesNotSatisfied) > 0
+}
+
+// AuthorName returns the name of the IAM user or role that created the pull
+// request, which is the last segment of its ARN.
+func (pr *PullRequest) AuthorName() string {
+	if i := strings.LastIndex(pr.AuthorARN, "/"); i >= 0 {
+		return pr.AuthorARN[i+1:]
+	}
+	return pr.AuthorARN
+}
+
+// URL returns the URL of the pull request in the AWS console.
+func (pr *PullRequest) URL() string {
+	return fmt.Sprintf(
+		"https://%s.console.aws.amazon.com/codesuite/codecommit/repositories/%s/pull-requests/%s/details?region=%s",
+		pr.Region, url.PathEscape(pr.RepositoryName), url.PathEscape(pr.ID), url.QueryEscape(pr.Region),
+	)
+}
+
+// CreatePullRequestInput is the input to CreatePullRequest.
+type CreatePullRequestInput struct {
+	RepositoryName       string
+	Title                string
+	Description          string
+	SourceReference      string
+	DestinationReference string
+}
+
+// CreatePullRequest creates a pull request.
+func (c *Client) CreatePullRequest(ctx context.Context, in CreatePullRequestInput) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	out, err := svc.CreatePullRequest(ctx, &codecommit.CreatePullRequestInput{
+		Title:       aws.String(in.Title),
+		Description: aws.String(in.Description),
+		Targets: []codecommittypes.Target{{
+			RepositoryName:       aws.String(in.RepositoryName),
+			SourceReference:      aws.String(in.SourceReference),
+			DestinationReference: aws.String(in.DestinationReference),
+		}},
+	})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return c.annotatePullRequest(ctx, svc, out.PullRequest)
+}
+
+// GetPullRequest gets the pull request with the given ID.
+func (c *Client) GetPullRequest(ctx context.Context, id string) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	out, err := svc.GetPullRequest(ctx, &codecommit.GetPullRequestInput{PullRequestId: aws.String(id)})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return c.annotatePullRequest(ctx, svc, out.PullRequest)
+}
+
+// FindOpenPullRequest returns the open pull request of the given repository
+// from the source to the destination reference, following the pagination of
+// the API. AWS CodeCommit allows any number of pull requests between the same
+// references, in which case the first one found is returned. If there is no
+// such pull request, nil is returned.
+func (c *Client) FindOpenPullRequest(ctx context.Context, repositoryName, sourceReference, destinationReference string) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	in := &codecommit.ListPullRequestsInput{
+		RepositoryName:    aws.String(repositoryName),
+		PullRequestStatus: codecommittypes.PullRequestStatusEnumOpen,
+	}
+
+	for {
+		out, err := svc.ListPullRequests(ctx, in)
+		if err != nil {
+			return nil, &wrappedError{err: err}
+		}
+
+		for _, id := range out.PullRequestIds {
+			got, err := svc.GetPullRequest(ctx, &codecommit.GetPullRequestInput{PullRequestId: aws.String(id)})
+			if err != nil {
+				return nil, &wrappedError{err: err}
+			}
+			// Only the matching pull request is annotated, to keep the number
+			// of requests down.
+			if got.PullRequest == nil {
+				continue
+			}
+			if pr := fromPullRequest(got.PullRequest); pr.SourceReference == sourceReference && pr.DestinationReference == destinationReference {
+				return c.annotatePullRequest(ctx, svc, got.PullRequest)
+			}
+		}
+
+		if out.NextToken == nil || *out.NextToken == "" {
+			return nil, nil
+		}
+		in.NextToken = out.NextToken
+	}
+}
+
+// UpdatePullRequestTitle updates the title of a pull request.
+func (c *Client) UpdatePullRequestTitle(ctx context.Context, id, title string) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	out, err := svc.UpdatePullRequestTitle(ctx, &codecommit.UpdatePullRequestTitleInput{
+		PullRequestId: aws.String(id),
+		Title:         aws.String(title),
+	})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return c.annotatePullRequest(ctx, svc, out.PullRequest)
+}
+
+// UpdatePullRequestDescription updates the description of a pull request.
+func (c *Client) UpdatePullRequestDescription(ctx context.Context, id, description string) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	out, err := svc.UpdatePullRequestDescription(ctx, &codecommit.UpdatePullRequestDescriptionInput{
+		PullRequestId: aws.String(id),
+		Description:   aws.String(description),
+	})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return c.annotatePullRequest(ctx, svc, out.PullRequest)
+}
+
+// ClosePullRequest closes a pull request. Closed pull requests cannot be
+// reopened.
+func (c *Client) ClosePullRequest(ctx context.Context, id string) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+	out, err := svc.UpdatePullRequestStatus(ctx, &codecommit.UpdatePullRequestStatusInput{
+		PullRequestId:     aws.String(id),
+		PullRequestStatus: codecommittypes.PullRequestStatusEnumClosed,
+	})
+	if err != nil {
+		return nil, &wrappedError{err: err}
+	}
+	return c.annotatePullRequest(ctx, svc, out.PullRequest)
+}
+
+// MergePullRequest merges the given pull request with a three-way merge, or
+// squashes its commits if squash is true. The merge fails if the source branch
+// has changed since the pull request was loaded.
+func (c *Client) MergePullRequest(ctx context.Context, pr *PullRequest, squash bool) (*PullRequest, error) {
+	svc := codecommit.NewFromConfig(c.aws)
+
+	var merged *codecommittypes.PullRequest
+	if squash {
+		out, err := svc.MergePullRequestBySquash(ctx, &codecommit.MergePullRequestBySquashInput{
+			PullRequestId:  aws.String(pr.ID),
+			RepositoryName: aws.String(pr.RepositoryName),
+			SourceCommitId: aws.String(pr.SourceCommit),
+		})
+		if err != nil {
+			return nil, &wrappedError{err: err}
+		}
+		merged = out.PullRequest
+	} else {
+		out, err := svc.MergePullRequestByThreeWay(ctx, &codecommit.MergePullRequestByThreeWayInput{
+			PullRequestId:  aws.String(pr.ID),
+			RepositoryName: aws.String(pr.RepositoryName),
+			SourceCommitId: aws.String(pr.SourceCommit),
+		})
+		if err != nil {
+			return nil, &wrappedError{err: err}
+		}
+		merged = out.PullRequest
+	}
+	return c.annotatePullRequest(ctx, svc, merged)
+}
+
+// CreatePullRequestComment posts a comment on the changes of the given pull
+// request.
+func (c *Client) CreatePullRequestComment(ctx context.Context, pr *PullRequest, content string) error {
+	svc := codecommit.NewFromConfig(c.aws)
+	_, err := svc.PostCommentForPullRequest(ctx, &codecommit.PostCommentForPullRequestInput{
+		PullRequestId:  aws.String(pr.ID),
+		RepositoryName: aws.String(pr.RepositoryName),
+		BeforeCommitId: aws.String(pr.DestinationCommit),
+		AfterCommitId:  aws.String(pr.SourceCommit),
+		Content:        aws.String(content),
+	})
+	if err != nil {
+		return &wrappedError{err: err}
+	}
+	return nil
+}
+
+// annotatePullRequest converts the API pull request and adds the approval
+// state of its current revision.
+func (c *Client) annotatePullRequest(ctx context.Context, svc *codecommit.Client, p *codecommittypes.PullRequest) (*PullRequest, error) {
+	if p == nil {
+		return nil, errors.New("no pull request returned by AWS CodeCommit")
+	}
+	pr := fromPullRequest(p)
+	pr.Region = c.aws.Region
+
+	approvals, err := svc.GetPullRequestApprovalStates(ctx, &codecommit.GetPullRequestApprovalStatesInput{
+		PullRequestId: p.PullRequestId,
+		RevisionId:    p.RevisionId,
+	})
+	if err != nil {
+		return nil, &wrappedError{err: errors.Wrap(err, "getting approval states")}
+	}
+	for _, a := range approvals.Approvals {
+		pr.Approvals = append(pr.Approvals, &Approval{
+			UserARN: aws.ToString(a.UserArn),
+			State:   string(a.ApprovalState),
+		})
+	}
+
+	// Approval rules can only be evaluated for open pull requests.
+	if pr.Status == PullRequestStatusOpen {
+		eval, err := svc.EvaluatePullRequestApprovalRules(ctx, &codecommit.EvaluatePullRequestApprovalRulesInput{
+			PullRequestId: p.PullRequestId,
+			RevisionId:    p.RevisionId,
+		})
+		if err != nil {
+			return nil, &wrappedError{err: errors.Wrap(err, "evaluating approval rules")}
+		}
+		if e := eval.Evaluation; e != nil {
+			pr.Evaluation = &Evaluation{
+				Approved:                  e.Approved,
+				Overridden:                e.Overridden,
+				ApprovalRulesSatisfied:    e.ApprovalRulesSatisfied,
+				ApprovalRulesNotSatisfied: e.ApprovalRulesNotSatisfied,
+			}
+		}
+	}
+
+	return pr, nil
+}
+
+func fromPullRequest(p *codecommittypes.PullRequest) *PullRequest {
+	pr := &PullRequest{
+		ID:          aws.ToString(p.PullRequestId),
+		RevisionID:  aws.ToString(p.RevisionId),
+		Title:       aws.ToString(p.Title),
+		Description: aws.ToString(p.Description),
+		Status:      string(p.PullRequestStatus),
+		AuthorARN:   aws.ToString(p.AuthorArn),
+	}
+	if p.CreationDate != nil {
+		pr.CreationDate = *p.CreationDate
+	}
+	if p.LastActivityDate != nil {
+		pr.LastActivityDate = *p.LastActivityDate
+	}
+
+	// Pull requests created through the API or the console always have a
+	// single target.
+	if len(p.PullRequestTargets) > 0 {
+		t := p.PullRequestTargets[0]
+		pr.RepositoryName = aws.ToString(t.RepositoryName)
+		pr.SourceReference = aws.ToString(t.SourceReference)
+		pr.DestinationReference = aws.ToString(t.DestinationReference)
+		pr.SourceCommit = aws.ToString(t.SourceCommit)
+		pr.DestinationCommit = aws.ToString(t.DestinationCommit)
+		pr.MergeBase = aws.ToString(t.MergeBase)
+		if m := t.MergeMetadata; m != nil {
+			pr.IsMerged = m.IsMerged
+			pr.MergedBy = aws.ToString(m.MergedBy)
+		}
+	}
+
+	return pr
+}
+
+// IsPullRequestNotFound reports whether err is an AWS CodeCommit API error
+// for a pull request that doesn't exist.
+func IsPullRequestNotFound(err error) bool {
+	return errors.HasType(err, &codecommittypes.PullRequestDoesNotExistException{})
+}
+
+// IsNotMergeable reports whether err is an AWS CodeCommit API error for a
+// pull request that cannot be merged in its current state, such as because
+// of conflicts, unsatisfied approval rules or a changed source branch.
+func IsNotMergeable(err error) bool {
+	return errors.HasType(err, &codecommittypes.ManualMergeRequiredException{}) ||
+		errors.HasType(err, &codecommittypes.PullRequestApprovalRulesNotSatisfiedException{}) ||
+		errors.HasType(err, &codecommittypes.TipOfSourceReferenceIsDifferentException{}) ||
+		errors.HasType(err, &codecommittypes.TipsDivergenceExceededException{}) ||
+		errors.HasType(err, &codecommittypes.PullRequestAlreadyClosedException{})
+}
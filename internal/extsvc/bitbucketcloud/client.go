@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/opentracing-contrib/go-stdlib/nethttp"
 
@@ -42,9 +43,13 @@ type Client interface {
 
 	Repo(ctx context.Context, namespace, slug string) (*Repo, error)
 	Repos(ctx context.Context, pageToken *PageToken, accountName string) ([]*Repo, *PageToken, error)
+	LastCommitDate(ctx context.Context, repo *Repo) (*time.Time, error)
 	ForkRepository(ctx context.Context, upstream *Repo, input ForkInput) (*Repo, error)
 
 	CurrentUser(ctx context.Context) (*User, error)
+	CurrentUserEmails(ctx context.Context) ([]*UserEmail, error)
+	CurrentUserRepoPermissions(ctx context.Context, pageToken *PageToken) ([]*RepoPermission, *PageToken, error)
+	ListExplicitUserPermsForRepo(ctx context.Context, pageToken *PageToken, namespace, slug string) ([]*UserPermission, *PageToken, error)
 }
 
 // client access a Bitbucket Cloud via the REST API 2.0.
@@ -112,8 +117,8 @@ func (c *client) Authenticator() auth.Authenticator {
 // the given authenticator instance.
 //
 // Note that using an unsupported Authenticator implementation may result in
-// unexpected behaviour, or (more likely) errors. At present, only BasicAuth is
-// supported.
+// unexpected behaviour, or (more likely) errors. At present, only BasicAuth and
+// OAuthBearerToken are supported.
 func (c *client) WithAuthenticator(a auth.Authenticator) Client {
 	return &client{
 		httpClient: c.httpClient,
@@ -0,0 +1,22 @@
+package bitbucketcloud
+
+import (
+	"net/url"
+
+	"golang.org/x/oauth2"
+)
+
+// RequestedOAuthScopes are the scopes requested from OAuth consumers when
+// users connect their Bitbucket Cloud account. The email scope is needed to
+// match the account to a Sourcegraph user, the repository scope to sync the
+// permissions of the user.
+var RequestedOAuthScopes = []string{"account", "email", "repository"}
+
+// OAuthEndpoint returns the OAuth 2.0 endpoint of the Bitbucket Cloud instance
+// at the given URL, such as https://bitbucket.org.
+func OAuthEndpoint(baseURL *url.URL) oauth2.Endpoint {
+	return oauth2.Endpoint{
+		AuthURL:  baseURL.ResolveReference(&url.URL{Path: "/site/oauth2/authorize"}).String(),
+		TokenURL: baseURL.ResolveReference(&url.URL{Path: "/site/oauth2/access_token"}).String(),
+	}
+}
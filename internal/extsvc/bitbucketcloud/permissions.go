@@ -0,0 +1,68 @@
+package bitbucketcloud
+
+import (
+	"context"
+	"fmt"
+)
+
+// Permission is the level of access an account has to a repository.
+type Permission string
+
+const (
+	PermissionRead  Permission = "read"
+	PermissionWrite Permission = "write"
+	PermissionAdmin Permission = "admin"
+)
+
+// RepoPermission describes the effective permission of the authenticated user
+// on a single repository.
+type RepoPermission struct {
+	Permission Permission `json:"permission"`
+	User       *Account   `json:"user"`
+	Repo       *Repo      `json:"repository"`
+}
+
+// UserPermission describes the explicit permission of a single user on a
+// repository.
+type UserPermission struct {
+	Permission Permission `json:"permission"`
+	User       *Account   `json:"user"`
+}
+
+// CurrentUserRepoPermissions returns the repositories the authenticated user
+// has access to, along with their effective permission on each. If the
+// argument pageToken.Next is not empty, it will be used directly as the URL to
+// make the request.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-repositories/#api-user-permissions-repositories-get
+func (c *client) CurrentUserRepoPermissions(ctx context.Context, pageToken *PageToken) ([]*RepoPermission, *PageToken, error) {
+	var perms []*RepoPermission
+	var next *PageToken
+	var err error
+	if pageToken.HasMore() {
+		next, err = c.reqPage(ctx, pageToken.Next, &perms)
+	} else {
+		next, err = c.page(ctx, "/2.0/user/permissions/repositories", nil, pageToken, &perms)
+	}
+	return perms, next, err
+}
+
+// ListExplicitUserPermsForRepo returns the users that have been granted
+// explicit access to the given repository. If the argument pageToken.Next is
+// not empty, it will be used directly as the URL to make the request.
+//
+// Note that this requires the authenticator in use to have admin access to the
+// repository.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-repositories/#api-repositories-workspace-repo-slug-permissions-config-users-get
+func (c *client) ListExplicitUserPermsForRepo(ctx context.Context, pageToken *PageToken, namespace, slug string) ([]*UserPermission, *PageToken, error) {
+	var perms []*UserPermission
+	var next *PageToken
+	var err error
+	if pageToken.HasMore() {
+		next, err = c.reqPage(ctx, pageToken.Next, &perms)
+	} else {
+		next, err = c.page(ctx, fmt.Sprintf("/2.0/repositories/%s/%s/permissions-config/users", namespace, slug), nil, pageToken, &perms)
+	}
+	return perms, next, err
+}
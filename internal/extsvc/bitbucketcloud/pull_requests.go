@@ -21,6 +21,9 @@ type PullRequestInput struct {
 	// If SourceRepo is provided, only FullName is actually used.
 	SourceRepo        *Repo
 	DestinationBranch *string
+	// If Reviewers is not empty, the reviewers of the pull request are
+	// replaced by the accounts with the given UUIDs.
+	Reviewers []string
 }
 
 // CreatePullRequest opens a new pull request.
@@ -197,11 +200,16 @@ func (input *PullRequestInput) MarshalJSON() ([]byte, error) {
 		Repository *repository `json:"repository,omitempty"`
 	}
 
+	type reviewer struct {
+		UUID string `json:"uuid"`
+	}
+
 	type request struct {
-		Title       string  `json:"title"`
-		Description string  `json:"description,omitempty"`
-		Source      source  `json:"source"`
-		Destination *source `json:"destination,omitempty"`
+		Title       string     `json:"title"`
+		Description string     `json:"description,omitempty"`
+		Source      source     `json:"source"`
+		Destination *source    `json:"destination,omitempty"`
+		Reviewers   []reviewer `json:"reviewers,omitempty"`
 	}
 
 	req := request{
@@ -221,6 +229,9 @@ func (input *PullRequestInput) MarshalJSON() ([]byte, error) {
 			Branch: branch{Name: *input.DestinationBranch},
 		}
 	}
+	for _, uuid := range input.Reviewers {
+		req.Reviewers = append(req.Reviewers, reviewer{UUID: uuid})
+	}
 
 	return json.Marshal(&req)
 }
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -65,6 +66,23 @@ type ForkInput struct {
 	Project     *ForkInputProject  `json:"project,omitempty"`
 }
 
+// LastCommitDate returns the date of the most recent commit on any branch of
+// the repository, or nil if the repository doesn't have any commits.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-commits/#api-repositories-workspace-repo-slug-commits-get
+func (c *client) LastCommitDate(ctx context.Context, repo *Repo) (*time.Time, error) {
+	var commits []struct {
+		Date *time.Time `json:"date"`
+	}
+	if _, err := c.page(ctx, fmt.Sprintf("/2.0/repositories/%s/commits", repo.FullName), nil, &PageToken{Pagelen: 1}, &commits); err != nil {
+		return nil, err
+	}
+	if len(commits) == 0 {
+		return nil, nil
+	}
+	return commits[0].Date, nil
+}
+
 // ForkRepository forks the given upstream repository.
 func (c *client) ForkRepository(ctx context.Context, upstream *Repo, input ForkInput) (*Repo, error) {
 	data, err := json.Marshal(&input)
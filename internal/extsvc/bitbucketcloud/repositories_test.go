@@ -61,6 +61,8 @@ func TestClient_Repos(t *testing.T) {
 				HTML: Link{Href: "https://bitbucket.org/sourcegraph-testing/src-cli"},
 			},
 			ForkPolicy: ForkPolicyNoPublic,
+			Size:       3500951,
+			UpdatedOn:  timePtr(t, "2022-03-17T11:17:49.067413+00:00"),
 		},
 		"sourcegraph": {
 			Slug:      "sourcegraph",
@@ -77,6 +79,8 @@ func TestClient_Repos(t *testing.T) {
 				HTML: Link{Href: "https://bitbucket.org/sourcegraph-testing/sourcegraph"},
 			},
 			ForkPolicy: ForkPolicyNoPublic,
+			Size:       657151990,
+			UpdatedOn:  timePtr(t, "2022-03-17T11:18:32.485296+00:00"),
 		},
 	}
 
@@ -238,3 +242,14 @@ func TestRepo_Namespace(t *testing.T) {
 		})
 	}
 }
+
+// timePtr parses v the same way the JSON decoder does, so that the result can
+// be compared with reflect.DeepEqual.
+func timePtr(t *testing.T, v string) *time.Time {
+	t.Helper()
+	ts, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &ts
+}
@@ -194,6 +194,13 @@ type Repo struct {
 	IsPrivate   bool       `json:"is_private"`
 	Links       RepoLinks  `json:"links"`
 	ForkPolicy  ForkPolicy `json:"fork_policy"`
+
+	// Metadata used to evaluate exclude and include rules
+	Size      int64      `json:"size,omitempty"` // in bytes
+	Language  string     `json:"language,omitempty"`
+	UpdatedOn *time.Time `json:"updated_on,omitempty"`
+	// LastCommitAt is only set when fetched with LastCommitDate.
+	LastCommitAt *time.Time `json:"-"`
 }
 
 func (r *Repo) Namespace() (string, error) {
@@ -4,6 +4,9 @@ import (
 	"context"
 	"net/http"
 
+	"golang.org/x/oauth2"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -22,8 +25,68 @@ func (c *client) CurrentUser(ctx context.Context) (*User, error) {
 	return &user, nil
 }
 
+// CurrentUserEmails returns all the email addresses of the user associated
+// with the authenticator in use.
+//
+// API docs: https://developer.atlassian.com/cloud/bitbucket/rest/api-group-users/#api-user-emails-get
+func (c *client) CurrentUserEmails(ctx context.Context) ([]*UserEmail, error) {
+	var emails []*UserEmail
+	var pageToken *PageToken
+	for {
+		var page []*UserEmail
+		var err error
+		if pageToken.HasMore() {
+			pageToken, err = c.reqPage(ctx, pageToken.Next, &page)
+		} else {
+			pageToken, err = c.page(ctx, "/2.0/user/emails", nil, pageToken, &page)
+		}
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, page...)
+		if !pageToken.HasMore() {
+			return emails, nil
+		}
+	}
+}
+
 type User struct {
 	Account
 	IsStaff   bool   `json:"is_staff"`
 	AccountID string `json:"account_id"`
 }
+
+type UserEmail struct {
+	Email       string `json:"email"`
+	IsConfirmed bool   `json:"is_confirmed"`
+	IsPrimary   bool   `json:"is_primary"`
+}
+
+// GetExternalAccountData returns the deserialized user and token from the external account data
+// JSON blob in a typesafe way.
+func GetExternalAccountData(data *extsvc.AccountData) (usr *User, tok *oauth2.Token, err error) {
+	var (
+		u User
+		t oauth2.Token
+	)
+
+	if data.Data != nil {
+		if err := data.GetAccountData(&u); err != nil {
+			return nil, nil, err
+		}
+		usr = &u
+	}
+	if data.AuthData != nil {
+		if err := data.GetAuthData(&t); err != nil {
+			return nil, nil, err
+		}
+		tok = &t
+	}
+	return usr, tok, nil
+}
+
+// SetExternalAccountData sets the user and token into the external account data blob.
+func SetExternalAccountData(data *extsvc.AccountData, user *User, token *oauth2.Token) {
+	data.SetAccountData(user)
+	data.SetAuthData(token)
+}
@@ -477,6 +477,14 @@ type UpdatePullRequestInput struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	ToRef       Ref    `json:"toRef"`
+
+	// Reviewers, if not empty, replaces the reviewers of the pull request.
+	Reviewers []ReviewerInput `json:"reviewers,omitempty"`
+}
+
+// ReviewerInput identifies a reviewer of a pull request by its user name.
+type ReviewerInput struct {
+	User User `json:"user"`
 }
 
 func (c *Client) UpdatePullRequest(ctx context.Context, in *UpdatePullRequestInput) (*PullRequest, error) {
@@ -92,11 +92,18 @@ func (c *CheckSuite) Key() string {
 
 // CheckRun represents the status of a checkrun
 type CheckRun struct {
-	ID string
+	ID         string
+	Name       string
+	DetailsURL string
 	// One of COMPLETED, IN_PROGRESS, QUEUED, REQUESTED
 	Status string
 	// One of ACTION_REQUIRED, CANCELLED, FAILURE, NEUTRAL, SUCCESS, TIMED_OUT
-	Conclusion string
+	Conclusion  string
+	StartedAt   time.Time
+	CompletedAt time.Time
+	// CheckSuiteID is the ID of the check suite the run belongs to. It is only
+	// set when the run was received via a webhook.
+	CheckSuiteID string
 	// When the run was received via a webhook
 	ReceivedAt time.Time
 }
@@ -129,6 +136,7 @@ type CommitStatus struct {
 	SHA        string
 	Context    string
 	State      string
+	TargetURL  string
 	ReceivedAt time.Time
 }
 
@@ -143,6 +151,8 @@ type Context struct {
 	Context     string
 	Description string
 	State       string
+	TargetURL   string
+	CreatedAt   time.Time
 }
 
 type Label struct {
@@ -180,8 +190,11 @@ type PullRequest struct {
 	TimelineItems  []TimelineItem
 	Commits        struct{ Nodes []CommitWithChecks }
 	IsDraft        bool
-	CreatedAt      time.Time
-	UpdatedAt      time.Time
+	// Mergeable is MERGEABLE, CONFLICTING or UNKNOWN, if GitHub hasn't
+	// computed it yet.
+	Mergeable string
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 // AssignedEvent represents an 'assigned' event on a PullRequest.
@@ -1014,6 +1027,336 @@ func (c *V4Client) MergePullRequest(ctx context.Context, pr *PullRequest, squash
 	return nil
 }
 
+// UpdatePullRequestMetadataInput describes the reviewers, labels and assignees
+// to add to and remove from a pull request.
+type UpdatePullRequestMetadataInput struct {
+	// Owner and Name are the owner and name of the repository of the pull
+	// request, in which the labels are looked up.
+	Owner string
+	Name  string
+
+	// Reviewers are the logins of the users, or the "org/team-slug" names of
+	// the teams, whose review is requested.
+	Reviewers []string
+	// Labels are the names of labels that exist in the repository.
+	Labels []string
+	// Assignees are the logins of the users to assign.
+	Assignees []string
+
+	// RemovedReviewers, RemovedLabels and RemovedAssignees are removed from the
+	// pull request. Ones that don't exist (anymore) are ignored.
+	RemovedReviewers []string
+	RemovedLabels    []string
+	RemovedAssignees []string
+}
+
+// UpdatePullRequestMetadata requests reviews on the PullRequest on GitHub, adds
+// labels to it and assigns users to it, and removes the given reviewers,
+// labels and assignees from it. Reviewers, labels and assignees that are
+// neither added nor removed are left untouched.
+func (c *V4Client) UpdatePullRequestMetadata(ctx context.Context, pr *PullRequest, in *UpdatePullRequestMetadataInput) error {
+	ids, err := c.resolvePullRequestMetadataIDs(ctx, pr, in)
+	if err != nil {
+		return err
+	}
+
+	var (
+		params    []string
+		mutations []string
+		vars      = map[string]any{}
+	)
+	// GitHub has no mutation to remove a single review request, so when
+	// reviewers are removed we replace the requested reviewers instead.
+	if ids.replaceReviewers || len(ids.reviewerUserIDs) > 0 || len(ids.reviewerTeamIDs) > 0 {
+		params = append(params, "$reviews: RequestReviewsInput!")
+		mutations = append(mutations, "reviews: requestReviews(input: $reviews) { clientMutationId }")
+		vars["reviews"] = map[string]any{
+			"pullRequestId": pr.ID,
+			"userIds":       nonNilStrings(ids.reviewerUserIDs),
+			"teamIds":       nonNilStrings(ids.reviewerTeamIDs),
+			"union":         !ids.replaceReviewers,
+		}
+	}
+	if len(ids.labelIDs) > 0 {
+		params = append(params, "$labels: AddLabelsToLabelableInput!")
+		mutations = append(mutations, "labels: addLabelsToLabelable(input: $labels) { clientMutationId }")
+		vars["labels"] = map[string]any{
+			"labelableId": pr.ID,
+			"labelIds":    ids.labelIDs,
+		}
+	}
+	if len(ids.removedLabelIDs) > 0 {
+		params = append(params, "$removedLabels: RemoveLabelsFromLabelableInput!")
+		mutations = append(mutations, "removedLabels: removeLabelsFromLabelable(input: $removedLabels) { clientMutationId }")
+		vars["removedLabels"] = map[string]any{
+			"labelableId": pr.ID,
+			"labelIds":    ids.removedLabelIDs,
+		}
+	}
+	if len(ids.assigneeIDs) > 0 {
+		params = append(params, "$assignees: AddAssigneesToAssignableInput!")
+		mutations = append(mutations, "assignees: addAssigneesToAssignable(input: $assignees) { clientMutationId }")
+		vars["assignees"] = map[string]any{
+			"assignableId": pr.ID,
+			"assigneeIds":  ids.assigneeIDs,
+		}
+	}
+	if len(ids.removedAssigneeIDs) > 0 {
+		params = append(params, "$removedAssignees: RemoveAssigneesFromAssignableInput!")
+		mutations = append(mutations, "removedAssignees: removeAssigneesFromAssignable(input: $removedAssignees) { clientMutationId }")
+		vars["removedAssignees"] = map[string]any{
+			"assignableId": pr.ID,
+			"assigneeIds":  ids.removedAssigneeIDs,
+		}
+	}
+	if len(mutations) == 0 {
+		return nil
+	}
+
+	q := fmt.Sprintf("mutation UpdatePullRequestMetadata(%s) {\n%s\n}", strings.Join(params, ", "), strings.Join(mutations, "\n"))
+	return c.requestGraphQL(ctx, q, vars, nil)
+}
+
+// nonNilStrings returns an empty slice for nil, so that it's encoded as an
+// empty JSON list instead of null.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+type pullRequestMetadataIDs struct {
+	reviewerUserIDs []string
+	reviewerTeamIDs []string
+	labelIDs        []string
+	assigneeIDs     []string
+
+	removedLabelIDs    []string
+	removedAssigneeIDs []string
+
+	// replaceReviewers is true if reviewerUserIDs and reviewerTeamIDs are the
+	// complete set of requested reviewers, rather than ones to add.
+	replaceReviewers bool
+}
+
+// resolvePullRequestMetadataIDs looks up the node IDs of the users, teams and
+// labels in the given input in a single query. If reviewers are removed, the
+// reviewers currently requested on the pull request are looked up as well.
+func (c *V4Client) resolvePullRequestMetadataIDs(ctx context.Context, pr *PullRequest, in *UpdatePullRequestMetadataInput) (*pullRequestMetadataIDs, error) {
+	// The logins, slugs and label names are passed as variables, so that they
+	// don't need to be escaped in the query.
+	var (
+		q     strings.Builder
+		decls []string
+		vars  = map[string]any{}
+	)
+	variable := func(typ string, value any) string {
+		name := fmt.Sprintf("v%d", len(vars))
+		vars[name] = value
+		decls = append(decls, fmt.Sprintf("$%s: %s", name, typ))
+		return "$" + name
+	}
+
+	// Users can be both reviewers and assignees, so we only look them up once.
+	userAliases := map[string]string{}
+	userAlias := func(login string) {
+		if _, ok := userAliases[login]; ok {
+			return
+		}
+		alias := fmt.Sprintf("u%d", len(userAliases))
+		userAliases[login] = alias
+		q.WriteString(fmt.Sprintf("%s: user(login: %s) { id }\n", alias, variable("String!", login)))
+	}
+
+	var reviewerUsers, reviewerTeams []string
+	for _, reviewer := range in.Reviewers {
+		if org, team, ok := strings.Cut(reviewer, "/"); ok {
+			q.WriteString(fmt.Sprintf("t%d: organization(login: %s) { team(slug: %s) { id } }\n", len(reviewerTeams), variable("String!", org), variable("String!", team)))
+			reviewerTeams = append(reviewerTeams, reviewer)
+		} else {
+			userAlias(reviewer)
+			reviewerUsers = append(reviewerUsers, reviewer)
+		}
+	}
+	for _, assignee := range in.Assignees {
+		userAlias(assignee)
+	}
+	for _, assignee := range in.RemovedAssignees {
+		userAlias(assignee)
+	}
+	if len(in.Labels) > 0 || len(in.RemovedLabels) > 0 {
+		q.WriteString(fmt.Sprintf("repository(owner: %s, name: %s) {\n", variable("String!", in.Owner), variable("String!", in.Name)))
+		for i, label := range in.Labels {
+			q.WriteString(fmt.Sprintf("l%d: label(name: %s) { id }\n", i, variable("String!", label)))
+		}
+		for i, label := range in.RemovedLabels {
+			q.WriteString(fmt.Sprintf("r%d: label(name: %s) { id }\n", i, variable("String!", label)))
+		}
+		q.WriteString("}\n")
+	}
+	if len(in.RemovedReviewers) > 0 {
+		q.WriteString(fmt.Sprintf(`pullRequest: node(id: %s) {
+... on PullRequest {
+reviewRequests(first: 100) {
+nodes {
+requestedReviewer {
+... on User { id login }
+... on Team { id slug organization { login } }
+}
+}
+}
+}
+}
+`, variable("ID!", pr.ID)))
+	}
+
+	ids := &pullRequestMetadataIDs{}
+	if len(vars) == 0 {
+		return ids, nil
+	}
+	query := fmt.Sprintf("query(%s) {\n%s}", strings.Join(decls, ", "), q.String())
+
+	type node struct{ ID string }
+	var result map[string]json.RawMessage
+	if err := c.requestGraphQL(ctx, query, vars, &result); err != nil {
+		return nil, errors.Wrap(err, "looking up reviewers, labels and assignees")
+	}
+
+	nodeID := func(raw json.RawMessage) (string, error) {
+		var n *node
+		if err := json.Unmarshal(raw, &n); err != nil || n == nil {
+			return "", err
+		}
+		return n.ID, nil
+	}
+
+	if len(in.RemovedReviewers) > 0 {
+		var current *struct {
+			ReviewRequests struct {
+				Nodes []struct {
+					RequestedReviewer struct {
+						ID           string
+						Login        string
+						Slug         string
+						Organization struct{ Login string }
+					}
+				}
+			}
+		}
+		if err := json.Unmarshal(result["pullRequest"], &current); err != nil || current == nil {
+			return nil, errors.Errorf("pull request %s not found", pr.ID)
+		}
+
+		removed := make(map[string]struct{}, len(in.RemovedReviewers))
+		for _, reviewer := range in.RemovedReviewers {
+			removed[reviewer] = struct{}{}
+		}
+		for _, n := range current.ReviewRequests.Nodes {
+			r := n.RequestedReviewer
+			if r.Slug != "" {
+				if _, ok := removed[r.Organization.Login+"/"+r.Slug]; !ok {
+					ids.reviewerTeamIDs = append(ids.reviewerTeamIDs, r.ID)
+				}
+			} else if r.Login != "" {
+				if _, ok := removed[r.Login]; !ok {
+					ids.reviewerUserIDs = append(ids.reviewerUserIDs, r.ID)
+				}
+			}
+		}
+		ids.replaceReviewers = true
+	}
+
+	for _, login := range reviewerUsers {
+		id, err := nodeID(result[userAliases[login]])
+		if err != nil || id == "" {
+			return nil, errors.Errorf("reviewer %q not found", login)
+		}
+		ids.reviewerUserIDs = appendUnique(ids.reviewerUserIDs, id)
+	}
+	for i, name := range reviewerTeams {
+		var org *struct{ Team *node }
+		if err := json.Unmarshal(result[fmt.Sprintf("t%d", i)], &org); err != nil || org == nil || org.Team == nil {
+			return nil, errors.Errorf("reviewer team %q not found", name)
+		}
+		ids.reviewerTeamIDs = appendUnique(ids.reviewerTeamIDs, org.Team.ID)
+	}
+	for _, login := range in.Assignees {
+		id, err := nodeID(result[userAliases[login]])
+		if err != nil || id == "" {
+			return nil, errors.Errorf("assignee %q not found", login)
+		}
+		ids.assigneeIDs = append(ids.assigneeIDs, id)
+	}
+	for _, login := range in.RemovedAssignees {
+		if id, err := nodeID(result[userAliases[login]]); err == nil && id != "" {
+			ids.removedAssigneeIDs = append(ids.removedAssigneeIDs, id)
+		}
+	}
+	if len(in.Labels) > 0 || len(in.RemovedLabels) > 0 {
+		var repo map[string]json.RawMessage
+		if err := json.Unmarshal(result["repository"], &repo); err != nil || repo == nil {
+			return nil, errors.Errorf("repository %s/%s not found", in.Owner, in.Name)
+		}
+		for i, label := range in.Labels {
+			id, err := nodeID(repo[fmt.Sprintf("l%d", i)])
+			if err != nil || id == "" {
+				return nil, errors.Errorf("label %q does not exist in repository %s/%s", label, in.Owner, in.Name)
+			}
+			ids.labelIDs = append(ids.labelIDs, id)
+		}
+		for i := range in.RemovedLabels {
+			if id, err := nodeID(repo[fmt.Sprintf("r%d", i)]); err == nil && id != "" {
+				ids.removedLabelIDs = append(ids.removedLabelIDs, id)
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+func appendUnique(ids []string, id string) []string {
+	for _, existing := range ids {
+		if existing == id {
+			return ids
+		}
+	}
+	return append(ids, id)
+}
+
+// EnablePullRequestAutoMerge enables auto-merge on the PullRequest on GitHub,
+// so that it is merged once all of its requirements are met.
+func (c *V4Client) EnablePullRequestAutoMerge(ctx context.Context, pr *PullRequest) error {
+	input := map[string]any{"input": struct {
+		PullRequestID string `json:"pullRequestId"`
+	}{PullRequestID: pr.ID}}
+	return c.requestGraphQL(ctx, `mutation EnablePullRequestAutoMerge($input: EnablePullRequestAutoMergeInput!) {
+  enablePullRequestAutoMerge(input: $input) { clientMutationId }
+}`, input, nil)
+}
+
+// DisablePullRequestAutoMerge disables auto-merge on the PullRequest on GitHub.
+func (c *V4Client) DisablePullRequestAutoMerge(ctx context.Context, pr *PullRequest) error {
+	input := map[string]any{"input": struct {
+		PullRequestID string `json:"pullRequestId"`
+	}{PullRequestID: pr.ID}}
+	return c.requestGraphQL(ctx, `mutation DisablePullRequestAutoMerge($input: DisablePullRequestAutoMergeInput!) {
+  disablePullRequestAutoMerge(input: $input) { clientMutationId }
+}`, input, nil)
+}
+
+// RerequestCheckSuite re-runs the check suite with the given ID in the base
+// repository of the PullRequest on GitHub.
+func (c *V4Client) RerequestCheckSuite(ctx context.Context, pr *PullRequest, checkSuiteID string) error {
+	input := map[string]any{"input": struct {
+		RepositoryID string `json:"repositoryId"`
+		CheckSuiteID string `json:"checkSuiteId"`
+	}{RepositoryID: pr.BaseRepository.ID, CheckSuiteID: checkSuiteID}}
+	return c.requestGraphQL(ctx, `mutation RerequestCheckSuite($input: RerequestCheckSuiteInput!) {
+  rerequestCheckSuite(input: $input) { clientMutationId }
+}`, input, nil)
+}
+
 func (c *V4Client) loadRemainingTimelineItems(ctx context.Context, prID string, pageInfo PageInfo) (items []TimelineItem, err error) {
 	version := c.determineGitHubVersion(ctx)
 	timelineItemTypes, err := timelineItemTypes(version)
@@ -1355,6 +1698,8 @@ fragment commitWithChecks on Commit {
       context
       state
       description
+      targetUrl
+      createdAt
     }
   }
   checkSuites(last: 20) {
@@ -1365,8 +1710,12 @@ fragment commitWithChecks on Commit {
       checkRuns(last: 20) {
         nodes {
           id
+          name
+          detailsUrl
           status
           conclusion
+          startedAt
+          completedAt
         }
       }
     }
@@ -1400,6 +1749,7 @@ fragment pr on PullRequest {
   baseRefOid
   headRefName
   baseRefName
+  mergeable
   %s
   author {
     ...actor
@@ -1735,6 +2085,54 @@ type Repository struct {
 	// to identify if a repository is public or private or internal.
 	// https://developer.github.com/changes/2019-12-03-internal-visibility-changes/#repository-visibility-fields
 	Visibility Visibility `json:",omitempty"`
+
+	// Metadata used to evaluate exclude and include rules
+	DiskUsage        int               `json:",omitempty"` // approximate size of the repository in kilobytes
+	PushedAt         *time.Time        `json:",omitempty"` // time of the last push to the repository
+	PrimaryLanguage  *Language         `json:",omitempty"`
+	RepositoryTopics *RepositoryTopics `json:",omitempty"`
+}
+
+// Language is a programming language as detected by GitHub.
+type Language struct {
+	Name string
+}
+
+// RepositoryTopics is the list of topics of a repository, in the shape
+// returned by the GraphQL API.
+type RepositoryTopics struct {
+	Nodes []RepositoryTopic
+}
+
+// RepositoryTopic wraps a single topic of a repository.
+type RepositoryTopic struct {
+	Topic Topic
+}
+
+// Topic is a GitHub topic.
+type Topic struct {
+	Name string
+}
+
+// TopicNames returns the names of the topics of the repository.
+func (r *Repository) TopicNames() []string {
+	if r.RepositoryTopics == nil {
+		return nil
+	}
+	names := make([]string, 0, len(r.RepositoryTopics.Nodes))
+	for _, n := range r.RepositoryTopics.Nodes {
+		names = append(names, n.Topic.Name)
+	}
+	return names
+}
+
+// PrimaryLanguageName returns the name of the primary language of the
+// repository, or an empty string if GitHub didn't detect one.
+func (r *Repository) PrimaryLanguageName() string {
+	if r.PrimaryLanguage == nil {
+		return ""
+	}
+	return r.PrimaryLanguage.Name
 }
 
 type restRepositoryPermissions struct {
@@ -1758,6 +2156,10 @@ type restRepository struct {
 	Stars       int                       `json:"stargazers_count"`
 	Forks       int                       `json:"forks_count"`
 	Visibility  string                    `json:"visibility"`
+	Size        int                       `json:"size"` // in kilobytes
+	PushedAt    *time.Time                `json:"pushed_at"`
+	Language    string                    `json:"language"`
+	Topics      []string                  `json:"topics"`
 }
 
 // getRepositoryFromAPI attempts to fetch a repository from the GitHub API without use of the redis cache.
@@ -1793,6 +2195,18 @@ func convertRestRepo(restRepo restRepository) *Repository {
 		ViewerPermission: convertRestRepoPermissions(restRepo.Permissions),
 		StargazerCount:   restRepo.Stars,
 		ForkCount:        restRepo.Forks,
+		DiskUsage:        restRepo.Size,
+		PushedAt:         restRepo.PushedAt,
+	}
+
+	if restRepo.Language != "" {
+		repo.PrimaryLanguage = &Language{Name: restRepo.Language}
+	}
+	if len(restRepo.Topics) > 0 {
+		repo.RepositoryTopics = &RepositoryTopics{}
+		for _, t := range restRepo.Topics {
+			repo.RepositoryTopics.Nodes = append(repo.RepositoryTopics.Nodes, RepositoryTopic{Topic: Topic{Name: t}})
+		}
 	}
 
 	if conf.ExperimentalFeatures().EnableGithubInternalRepoVisibility {
@@ -1,11 +1,19 @@
 package github
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 )
 
 func TestSplitRepositoryNameWithOwner(t *testing.T) {
@@ -59,3 +67,136 @@ func repoListsAreEqual(a []*Repository, b []*Repository) bool {
 	}
 	return true
 }
+
+func TestResolvePullRequestMetadataIDs(t *testing.T) {
+	mock := mockHTTPResponseBody{responseBody: `{"data": {
+		"u0": {"id": "user-alice"},
+		"t0": {"team": {"id": "team-backend"}},
+		"u1": {"id": "user-bob"},
+		"repository": {"l0": {"id": "label-automated"}, "l1": null}
+	}}`}
+	apiURL := &url.URL{Scheme: "https", Host: "example.com", Path: "/"}
+	c := NewV4Client("Test", apiURL, nil, &mock)
+
+	in := &UpdatePullRequestMetadataInput{
+		Owner:     "sourcegraph",
+		Name:      "sourcegraph",
+		Reviewers: []string{"alice", "sourcegraph/backend"},
+		Assignees: []string{"bob", "alice"},
+		Labels:    []string{"automated"},
+	}
+
+	ids, err := c.resolvePullRequestMetadataIDs(context.Background(), &PullRequest{ID: "pr"}, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &pullRequestMetadataIDs{
+		reviewerUserIDs: []string{"user-alice"},
+		reviewerTeamIDs: []string{"team-backend"},
+		labelIDs:        []string{"label-automated"},
+		assigneeIDs:     []string{"user-bob", "user-alice"},
+	}
+	if diff := cmp.Diff(want, ids, cmp.AllowUnexported(pullRequestMetadataIDs{})); diff != "" {
+		t.Fatalf("unexpected ids (-want +got):\n%s", diff)
+	}
+
+	// Labels that don't exist in the repository can't be added.
+	in.Labels = append(in.Labels, "missing")
+	if _, err := c.resolvePullRequestMetadataIDs(context.Background(), &PullRequest{ID: "pr"}, in); err == nil || !strings.Contains(err.Error(), `label "missing" does not exist`) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestResolvePullRequestMetadataIDs_Variables(t *testing.T) {
+	var req struct {
+		Query     string
+		Variables map[string]any
+	}
+	doer := httpcli.DoerFunc(func(r *http.Request) (*http.Response, error) {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, err
+		}
+		return &http.Response{
+			Request:    r,
+			StatusCode: http.StatusOK,
+			Body: io.NopCloser(strings.NewReader(`{"data": {
+				"u0": {"id": "user-alice"},
+				"t0": {"team": {"id": "team-backend"}},
+				"repository": {"l0": {"id": "label-quoted"}}
+			}}`)),
+		}, nil
+	})
+	apiURL := &url.URL{Scheme: "https", Host: "example.com", Path: "/"}
+	c := NewV4Client("Test", apiURL, nil, doer)
+
+	// Values that Go would escape differently than GraphQL must be passed
+	// through unchanged.
+	label := "needs \"review\" \x01 ✨"
+	in := &UpdatePullRequestMetadataInput{
+		Owner:     "sourcegraph",
+		Name:      "sourcegraph",
+		Reviewers: []string{"alice", "sourcegraph/back\x7fend"},
+		Labels:    []string{label},
+	}
+	if _, err := c.resolvePullRequestMetadataIDs(context.Background(), &PullRequest{ID: "pr"}, in); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, value := range []string{"alice", "back", "review"} {
+		if strings.Contains(req.Query, value) {
+			t.Errorf("query contains value %q:\n%s", value, req.Query)
+		}
+	}
+	var have []string
+	for _, v := range req.Variables {
+		have = append(have, v.(string))
+	}
+	want := []string{"alice", "sourcegraph", "back\x7fend", "sourcegraph", "sourcegraph", label}
+	if diff := cmp.Diff(want, have, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Fatalf("unexpected variables (-want +got):\n%s", diff)
+	}
+}
+
+func TestResolvePullRequestMetadataIDs_Removed(t *testing.T) {
+	mock := mockHTTPResponseBody{responseBody: `{"data": {
+		"u0": {"id": "user-alice"},
+		"u1": {"id": "user-carol"},
+		"u2": null,
+		"repository": {"r0": {"id": "label-stale"}, "r1": null},
+		"pullRequest": {"reviewRequests": {"nodes": [
+			{"requestedReviewer": {"id": "user-bob", "login": "bob"}},
+			{"requestedReviewer": {"id": "user-dave", "login": "dave"}},
+			{"requestedReviewer": {"id": "team-backend", "slug": "backend", "organization": {"login": "sourcegraph"}}}
+		]}}
+	}}`}
+	apiURL := &url.URL{Scheme: "https", Host: "example.com", Path: "/"}
+	c := NewV4Client("Test", apiURL, nil, &mock)
+
+	in := &UpdatePullRequestMetadataInput{
+		Owner:            "sourcegraph",
+		Name:             "sourcegraph",
+		Reviewers:        []string{"alice"},
+		RemovedReviewers: []string{"bob", "sourcegraph/backend"},
+		RemovedAssignees: []string{"carol", "deleted"},
+		RemovedLabels:    []string{"stale", "deleted"},
+	}
+
+	ids, err := c.resolvePullRequestMetadataIDs(context.Background(), &PullRequest{ID: "pr"}, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Removed reviewers are dropped from the current review requests, which
+	// then replace the requested reviewers. Missing users and labels are
+	// ignored.
+	want := &pullRequestMetadataIDs{
+		reviewerUserIDs:    []string{"user-dave", "user-alice"},
+		removedLabelIDs:    []string{"label-stale"},
+		removedAssigneeIDs: []string{"user-carol"},
+		replaceReviewers:   true,
+	}
+	if diff := cmp.Diff(want, ids, cmp.AllowUnexported(pullRequestMetadataIDs{})); diff != "" {
+		t.Fatalf("unexpected ids (-want +got):\n%s", diff)
+	}
+}
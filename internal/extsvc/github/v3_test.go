@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
@@ -85,6 +86,8 @@ func TestListAffiliatedRepositories(t *testing.T) {
 					URL:              "https://github.com/sourcegraph-vcr-repos/private-org-repo-1",
 					IsPrivate:        true,
 					ViewerPermission: "ADMIN",
+					DiskUsage:        1,
+					PushedAt:         timePtr(t, "2020-05-11T12:20:40Z"),
 				}, {
 					ID:               "MDEwOlJlcG9zaXRvcnkyNjMwMzQwNzM=",
 					DatabaseID:       263034073,
@@ -92,18 +95,24 @@ func TestListAffiliatedRepositories(t *testing.T) {
 					URL:              "https://github.com/sourcegraph-vcr/private-user-repo-1",
 					IsPrivate:        true,
 					ViewerPermission: "ADMIN",
+					DiskUsage:        14,
+					PushedAt:         timePtr(t, "2020-05-11T12:20:14Z"),
 				}, {
 					ID:               "MDEwOlJlcG9zaXRvcnkyNjMwMzM5NDk=",
 					DatabaseID:       263033949,
 					NameWithOwner:    "sourcegraph-vcr/public-user-repo-1",
 					URL:              "https://github.com/sourcegraph-vcr/public-user-repo-1",
 					ViewerPermission: "ADMIN",
+					DiskUsage:        5,
+					PushedAt:         timePtr(t, "2020-05-11T12:19:47Z"),
 				}, {
 					ID:               "MDEwOlJlcG9zaXRvcnkyNjMwMzM3NjE=",
 					DatabaseID:       263033761,
 					NameWithOwner:    "sourcegraph-vcr-repos/public-org-repo-1",
 					URL:              "https://github.com/sourcegraph-vcr-repos/public-org-repo-1",
 					ViewerPermission: "ADMIN",
+					DiskUsage:        1,
+					PushedAt:         timePtr(t, "2020-05-11T12:18:51Z"),
 				},
 			},
 		},
@@ -117,12 +126,16 @@ func TestListAffiliatedRepositories(t *testing.T) {
 					NameWithOwner:    "sourcegraph-vcr/public-user-repo-1",
 					URL:              "https://github.com/sourcegraph-vcr/public-user-repo-1",
 					ViewerPermission: "ADMIN",
+					DiskUsage:        5,
+					PushedAt:         timePtr(t, "2020-05-11T12:19:47Z"),
 				}, {
 					ID:               "MDEwOlJlcG9zaXRvcnkyNjMwMzM3NjE=",
 					DatabaseID:       263033761,
 					NameWithOwner:    "sourcegraph-vcr-repos/public-org-repo-1",
 					URL:              "https://github.com/sourcegraph-vcr-repos/public-org-repo-1",
 					ViewerPermission: "ADMIN",
+					DiskUsage:        1,
+					PushedAt:         timePtr(t, "2020-05-11T12:18:51Z"),
 				},
 			},
 		},
@@ -137,6 +150,8 @@ func TestListAffiliatedRepositories(t *testing.T) {
 					URL:              "https://github.com/sourcegraph-vcr-repos/private-org-repo-1",
 					IsPrivate:        true,
 					ViewerPermission: "ADMIN",
+					DiskUsage:        1,
+					PushedAt:         timePtr(t, "2020-05-11T12:20:40Z"),
 				}, {
 					ID:               "MDEwOlJlcG9zaXRvcnkyNjMwMzQwNzM=",
 					DatabaseID:       263034073,
@@ -144,6 +159,8 @@ func TestListAffiliatedRepositories(t *testing.T) {
 					URL:              "https://github.com/sourcegraph-vcr/private-user-repo-1",
 					IsPrivate:        true,
 					ViewerPermission: "ADMIN",
+					DiskUsage:        14,
+					PushedAt:         timePtr(t, "2020-05-11T12:20:14Z"),
 				},
 			},
 		},
@@ -158,12 +175,16 @@ func TestListAffiliatedRepositories(t *testing.T) {
 					URL:              "https://github.com/sourcegraph-vcr/private-user-repo-1",
 					IsPrivate:        true,
 					ViewerPermission: "ADMIN",
+					DiskUsage:        14,
+					PushedAt:         timePtr(t, "2020-05-11T12:20:14Z"),
 				}, {
 					ID:               "MDEwOlJlcG9zaXRvcnkyNjMwMzM5NDk=",
 					DatabaseID:       263033949,
 					NameWithOwner:    "sourcegraph-vcr/public-user-repo-1",
 					URL:              "https://github.com/sourcegraph-vcr/public-user-repo-1",
 					ViewerPermission: "ADMIN",
+					DiskUsage:        5,
+					PushedAt:         timePtr(t, "2020-05-11T12:19:47Z"),
 				},
 			},
 		},
@@ -817,6 +838,15 @@ func newV3TestEnterpriseClient(t testing.TB, name string) (*V3Client, func()) {
 
 func strPtr(s string) *string { return &s }
 
+func timePtr(t *testing.T, v string) *time.Time {
+	t.Helper()
+	ts, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &ts
+}
+
 func TestClient_ListRepositoriesForSearch(t *testing.T) {
 	cli, save := newV3TestClient(t, "ListRepositoriesForSearch")
 	defer save()
@@ -467,6 +467,98 @@ query($query: String!, $type: SearchType!, $after: String, $first: Int!) {
 	return b.String()
 }
 
+// SearchPullRequestsParams are the inputs to the SearchPullRequests method.
+type SearchPullRequestsParams struct {
+	// Query is the GitHub search query. See https://docs.github.com/en/search-github/searching-on-github/searching-issues-and-pull-requests
+	Query string
+	// After is the cursor to paginate from.
+	After Cursor
+	// First is the page size. Default to 100 if left zero.
+	First int
+}
+
+// PullRequestSearchResult is a pull request returned by SearchPullRequests.
+type PullRequestSearchResult struct {
+	Number     int64
+	Repository struct {
+		// ID is the GraphQL ID of the repository the pull request was opened
+		// in.
+		ID string
+	}
+}
+
+// SearchPullRequestsResults is the result type of SearchPullRequests.
+type SearchPullRequestsResults struct {
+	// The pull requests that matched the Query in SearchPullRequestsParams.
+	PullRequests []PullRequestSearchResult
+	// The total result count of the Query in SearchPullRequestsParams.
+	TotalCount int
+	// The cursor pointing to the next page of results.
+	EndCursor Cursor
+}
+
+// SearchPullRequests searches for pull requests matching the given search
+// query. Issues matching the query are ignored, so callers should add "is:pr"
+// to the query to not waste result pages on them.
+func (c *V4Client) SearchPullRequests(ctx context.Context, p SearchPullRequestsParams) (SearchPullRequestsResults, error) {
+	if p.First == 0 {
+		p.First = 100
+	}
+
+	vars := map[string]any{
+		"query": p.Query,
+		"type":  "ISSUE",
+		"first": p.First,
+	}
+
+	if p.After != "" {
+		vars["after"] = p.After
+	}
+
+	var resp struct {
+		Search struct {
+			IssueCount int
+			PageInfo   struct {
+				HasNextPage bool
+				EndCursor   Cursor
+			}
+			Nodes []PullRequestSearchResult
+		}
+	}
+
+	if err := c.requestGraphQL(ctx, searchPullRequestsQuery, vars, &resp); err != nil {
+		return SearchPullRequestsResults{}, err
+	}
+
+	results := SearchPullRequestsResults{
+		PullRequests: make([]PullRequestSearchResult, 0, len(resp.Search.Nodes)),
+		TotalCount:   resp.Search.IssueCount,
+	}
+	for _, n := range resp.Search.Nodes {
+		// Issues are returned as empty nodes, since the query only selects
+		// fields on pull requests.
+		if n.Number == 0 {
+			continue
+		}
+		results.PullRequests = append(results.PullRequests, n)
+	}
+
+	if resp.Search.PageInfo.HasNextPage {
+		results.EndCursor = resp.Search.PageInfo.EndCursor
+	}
+
+	return results, nil
+}
+
+const searchPullRequestsQuery = `
+query($query: String!, $type: SearchType!, $after: String, $first: Int!) {
+	search(query: $query, type: $type, after: $after, first: $first) {
+		issueCount
+		pageInfo { hasNextPage, endCursor }
+		nodes { ... on PullRequest { number, repository { id } } }
+	}
+}`
+
 // GetReposByNameWithOwner fetches the specified repositories (namesWithOwners)
 // from the GitHub GraphQL API and returns a slice of repositories.
 // If a repository is not found, it will return an error.
@@ -552,6 +644,10 @@ fragment RepositoryFields on Repository {
 	viewerPermission
 	stargazerCount
 	forkCount
+	diskUsage
+	pushedAt
+	primaryLanguage { name }
+	repositoryTopics(first: 100) { nodes { topic { name } } }
 }
 	`
 	}
@@ -582,6 +678,10 @@ fragment RepositoryFields on Repository {
 	isLocked
 	isDisabled
 	forkCount
+	diskUsage
+	pushedAt
+	primaryLanguage { name }
+	repositoryTopics(first: 100) { nodes { topic { name } } }
 	%s
 }
 	`, strings.Join(conditionalGHEFields, "\n	"))
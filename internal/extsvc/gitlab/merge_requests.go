@@ -10,6 +10,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/peterhellberg/link"
+
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -44,6 +46,11 @@ type MergeRequest struct {
 	WebURL                 string            `json:"web_url"`
 	WorkInProgress         bool              `json:"work_in_progress"`
 	Author                 User              `json:"author"`
+	Assignees              []User            `json:"assignees"`
+	Reviewers              []User            `json:"reviewers"`
+
+	MergeWhenPipelineSucceeds bool `json:"merge_when_pipeline_succeeds"`
+	HasConflicts              bool `json:"has_conflicts"`
 
 	DiffRefs DiffRefs `json:"diff_refs"`
 
@@ -197,11 +204,91 @@ func (c *Client) GetOpenMergeRequestByRefs(ctx context.Context, project *Project
 	return c.GetMergeRequest(ctx, project, resp[0].IID)
 }
 
+// SearchMergeRequestsOpts are the filters supported by SearchMergeRequestsURL.
+// Empty fields are not filtered on.
+type SearchMergeRequestsOpts struct {
+	// State is one of "opened", "closed", "merged" or "all".
+	State            string
+	AuthorUsername   string
+	AssigneeUsername string
+	Labels           []string
+	// Search is matched against the title and description of merge requests.
+	Search string
+}
+
+// SearchMergeRequestsURL returns the URL of the first page of merge requests
+// across all projects visible to the authenticated user that match the given
+// options. It is meant to be passed to ListMergeRequests.
+func SearchMergeRequestsURL(opts SearchMergeRequestsOpts) string {
+	values := make(url.Values)
+	values.Add("scope", "all")
+	values.Add("per_page", "100")
+	// We only need the project and IID of the merge requests.
+	values.Add("view", "simple")
+	if opts.State != "" {
+		values.Add("state", opts.State)
+	}
+	if opts.AuthorUsername != "" {
+		values.Add("author_username", opts.AuthorUsername)
+	}
+	if opts.AssigneeUsername != "" {
+		values.Add("assignee_username", opts.AssigneeUsername)
+	}
+	if len(opts.Labels) > 0 {
+		values.Add("labels", strings.Join(opts.Labels, ","))
+	}
+	if opts.Search != "" {
+		values.Add("search", opts.Search)
+	}
+
+	u := &url.URL{Path: "merge_requests", RawQuery: values.Encode()}
+	return u.String()
+}
+
+// ListMergeRequests lists the merge requests at the given URL, which is either
+// returned by SearchMergeRequestsURL or is the nextPageURL returned by a
+// previous call.
+func (c *Client) ListMergeRequests(ctx context.Context, urlStr string) (mrs []*MergeRequest, nextPageURL *string, err error) {
+	if MockListMergeRequests != nil {
+		return MockListMergeRequests(c, ctx, urlStr)
+	}
+
+	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
+
+	req, err := http.NewRequest("GET", urlStr, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "creating request to list merge requests")
+	}
+
+	respHeader, _, err := c.do(ctx, req, &mrs)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "sending request to list merge requests")
+	}
+
+	// Get URL to next page. See https://docs.gitlab.com/ee/api/README.html#pagination-link-header.
+	if l := link.Parse(respHeader.Get("Link"))["next"]; l != nil {
+		nextPageURL = &l.URI
+	}
+
+	return mrs, nextPageURL, nil
+}
+
 type UpdateMergeRequestOpts struct {
 	TargetBranch string                       `json:"target_branch"`
 	Title        string                       `json:"title"`
 	Description  string                       `json:"description,omitempty"`
 	StateEvent   UpdateMergeRequestStateEvent `json:"state_event,omitempty"`
+
+	// AddLabels is a comma separated list of labels to add to the merge
+	// request, without removing the existing ones.
+	AddLabels string `json:"add_labels,omitempty"`
+	// RemoveLabels is a comma separated list of labels to remove from the
+	// merge request.
+	RemoveLabels string `json:"remove_labels,omitempty"`
+	// ReviewerIDs and AssigneeIDs replace the reviewers and assignees of the
+	// merge request when set. A single ID of 0 removes all of them.
+	ReviewerIDs []int32 `json:"reviewer_ids,omitempty"`
+	AssigneeIDs []int32 `json:"assignee_ids,omitempty"`
 }
 
 type UpdateMergeRequestStateEvent string
@@ -286,6 +373,59 @@ func (c *Client) MergeMergeRequest(ctx context.Context, project *Project, mr *Me
 	return resp, nil
 }
 
+// EnableMergeRequestAutoMerge sets the merge request to be merged once its
+// pipeline succeeds.
+func (c *Client) EnableMergeRequestAutoMerge(ctx context.Context, project *Project, mr *MergeRequest) (*MergeRequest, error) {
+	if MockEnableMergeRequestAutoMerge != nil {
+		return MockEnableMergeRequestAutoMerge(c, ctx, project, mr)
+	}
+
+	data, err := json.Marshal(struct {
+		MergeWhenPipelineSucceeds bool `json:"merge_when_pipeline_succeeds"`
+	}{
+		MergeWhenPipelineSucceeds: true,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling options")
+	}
+
+	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
+
+	req, err := http.NewRequest("PUT", fmt.Sprintf("projects/%d/merge_requests/%d/merge", project.ID, mr.IID), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to enable auto-merge on a merge request")
+	}
+
+	resp := &MergeRequest{}
+	if _, _, err := c.do(ctx, req, resp); err != nil {
+		return nil, errors.Wrap(err, "sending request to enable auto-merge on a merge request")
+	}
+
+	return resp, nil
+}
+
+// CancelMergeRequestAutoMerge cancels a previous request to merge the merge
+// request once its pipeline succeeds.
+func (c *Client) CancelMergeRequestAutoMerge(ctx context.Context, project *Project, mr *MergeRequest) (*MergeRequest, error) {
+	if MockCancelMergeRequestAutoMerge != nil {
+		return MockCancelMergeRequestAutoMerge(c, ctx, project, mr)
+	}
+
+	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("projects/%d/merge_requests/%d/cancel_merge_when_pipeline_succeeds", project.ID, mr.IID), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to cancel auto-merge on a merge request")
+	}
+
+	resp := &MergeRequest{}
+	if _, _, err := c.do(ctx, req, resp); err != nil {
+		return nil, errors.Wrap(err, "sending request to cancel auto-merge on a merge request")
+	}
+
+	return resp, nil
+}
+
 func (c *Client) CreateMergeRequestNote(ctx context.Context, project *Project, mr *MergeRequest, body string) error {
 	if MockCreateMergeRequestNote != nil {
 		return MockCreateMergeRequestNote(c, ctx, project, mr, body)
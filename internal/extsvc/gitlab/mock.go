@@ -1,6 +1,9 @@
 package gitlab
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // MockListProjects, if non-nil, will be called instead of every invocation of Client.ListProjects.
 var MockListProjects func(c *Client, ctx context.Context, urlStr string) (proj []*Project, nextPageURL *string, err error)
@@ -14,6 +17,9 @@ var MockGetUser func(c *Client, ctx context.Context, id string) (*User, error)
 // MockGetProject, if non-nil, will be called instead of Client.GetProject
 var MockGetProject func(c *Client, ctx context.Context, op GetProjectOp) (*Project, error)
 
+// MockLastCommitDate, if non-nil, will be called instead of Client.LastCommitDate
+var MockLastCommitDate func(c *Client, ctx context.Context, project *Project) (*time.Time, error)
+
 // MockListTree, if non-nil, will be called instead of Client.ListTree
 var MockListTree func(c *Client, ctx context.Context, op ListTreeOp) ([]*Tree, error)
 
@@ -37,10 +43,18 @@ var MockGetMergeRequestNotes func(c *Client, ctx context.Context, project *Proje
 // Client.GetMergeRequestPipelines
 var MockGetMergeRequestPipelines func(c *Client, ctx context.Context, project *Project, iid ID) func() ([]*Pipeline, error)
 
+// MockRetryPipeline, if non-nil, will be called instead of
+// Client.RetryPipeline
+var MockRetryPipeline func(c *Client, ctx context.Context, project *Project, id ID) (*Pipeline, error)
+
 // MockGetOpenMergeRequestByRefs, if non-nil, will be called instead of
 // Client.GetOpenMergeRequestByRefs
 var MockGetOpenMergeRequestByRefs func(c *Client, ctx context.Context, project *Project, source, target string) (*MergeRequest, error)
 
+// MockListMergeRequests, if non-nil, will be called instead of
+// Client.ListMergeRequests
+var MockListMergeRequests func(c *Client, ctx context.Context, urlStr string) (mrs []*MergeRequest, nextPageURL *string, err error)
+
 // MockUpdateMergeRequest, if non-nil, will be called instead of
 // Client.UpdateMergeRequest
 var MockUpdateMergeRequest func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, opts UpdateMergeRequestOpts) (*MergeRequest, error)
@@ -49,6 +63,14 @@ var MockUpdateMergeRequest func(c *Client, ctx context.Context, project *Project
 // Client.MergeMergeRequest
 var MockMergeMergeRequest func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, squash bool) (*MergeRequest, error)
 
+// MockEnableMergeRequestAutoMerge, if non-nil, will be called instead of
+// Client.EnableMergeRequestAutoMerge
+var MockEnableMergeRequestAutoMerge func(c *Client, ctx context.Context, project *Project, mr *MergeRequest) (*MergeRequest, error)
+
+// MockCancelMergeRequestAutoMerge, if non-nil, will be called instead of
+// Client.CancelMergeRequestAutoMerge
+var MockCancelMergeRequestAutoMerge func(c *Client, ctx context.Context, project *Project, mr *MergeRequest) (*MergeRequest, error)
+
 // MockCreateMergeRequestNote, if non-nil, will be called instead of
 // Client.CreateMergeRequestNote
 var MockCreateMergeRequestNote func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, body string) error
@@ -59,6 +59,27 @@ func (c *Client) GetMergeRequestPipelines(ctx context.Context, project *Project,
 	}
 }
 
+// RetryPipeline retries the failed or canceled jobs of the given pipeline.
+func (c *Client) RetryPipeline(ctx context.Context, project *Project, id ID) (*Pipeline, error) {
+	if MockRetryPipeline != nil {
+		return MockRetryPipeline(c, ctx, project, id)
+	}
+
+	time.Sleep(c.rateLimitMonitor.RecommendedWaitForBackgroundOp(1))
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("projects/%d/pipelines/%d/retry", project.ID, id), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating pipeline retry request")
+	}
+
+	pipeline := &Pipeline{}
+	if _, _, err := c.do(ctx, req, pipeline); err != nil {
+		return nil, errors.Wrap(err, "retrying pipeline")
+	}
+
+	return pipeline, nil
+}
+
 type Pipeline struct {
 	ID        ID             `json:"id"`
 	SHA       string         `json:"sha"`
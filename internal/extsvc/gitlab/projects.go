@@ -34,6 +34,18 @@ type Project struct {
 	Archived          bool           `json:"archived"`
 	StarCount         int            `json:"star_count"`
 	ForksCount        int            `json:"forks_count"`
+
+	// Metadata used to evaluate exclude and include rules
+	Topics         []string           `json:"topics,omitempty"`
+	LastActivityAt *time.Time         `json:"last_activity_at,omitempty"`
+	Statistics     *ProjectStatistics `json:"statistics,omitempty"` // only set when requested with statistics=true
+	LastCommitAt   *time.Time         `json:"-"`                    // only set when fetched with LastCommitDate
+}
+
+// ProjectStatistics contains the storage statistics of a project. GitLab only
+// returns them to users with at least Reporter access to the project.
+type ProjectStatistics struct {
+	RepositorySize int64 `json:"repository_size"` // in bytes
 }
 
 type ProjectCommon struct {
@@ -241,6 +253,29 @@ func (c *Client) ListProjects(ctx context.Context, urlStr string) (projs []*Proj
 	return projs, nextPageURL, nil
 }
 
+// LastCommitDate returns the date of the most recent commit on any branch of
+// the project, or nil if the project doesn't have any commits.
+func (c *Client) LastCommitDate(ctx context.Context, project *Project) (*time.Time, error) {
+	if MockLastCommitDate != nil {
+		return MockLastCommitDate(c, ctx, project)
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("projects/%d/repository/commits?all=true&per_page=1", project.ID), nil)
+	if err != nil {
+		return nil, err
+	}
+	var commits []struct {
+		CommittedDate *time.Time `json:"committed_date"`
+	}
+	if _, _, err := c.do(ctx, req, &commits); err != nil {
+		return nil, err
+	}
+	if len(commits) == 0 {
+		return nil, nil
+	}
+	return commits[0].CommittedDate, nil
+}
+
 // Fork forks a GitLab project. If namespace is nil, then the project will be
 // forked into the current user's namespace.
 //
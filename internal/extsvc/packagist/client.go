@@ -0,0 +1,161 @@
+// Package packagist is a client for the Packagist metadata API of Composer packages.
+//
+// https://packagist.org/apidoc
+package packagist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const defaultURL = "https://repo.packagist.org"
+
+type Client struct {
+	url string
+	cli httpcli.Doer
+
+	// Self-imposed rate-limiter.
+	limiter *ratelimit.InstrumentedLimiter
+}
+
+func NewClient(urn string, cli httpcli.Doer) *Client {
+	return &Client{
+		url:     defaultURL,
+		cli:     cli,
+		limiter: ratelimit.DefaultRegistry.Get(urn),
+	}
+}
+
+// Version is a single release of a Composer package.
+type Version struct {
+	Version string `json:"version"`
+	Dist    Dist   `json:"dist"`
+}
+
+// Dist describes the archive of a release.
+type Dist struct {
+	Type      string `json:"type"`
+	URL       string `json:"url"`
+	Reference string `json:"reference"`
+}
+
+// NotFoundError is returned when a package or one of its versions does not exist.
+type NotFoundError struct {
+	error
+}
+
+func (e NotFoundError) NotFound() bool {
+	return true
+}
+
+// Versions returns the metadata of all versions of a package, newest first.
+func (c *Client) Versions(ctx context.Context, name string) ([]*Version, error) {
+	data, err := c.get(ctx, fmt.Sprintf("%s/p2/%s.json", c.url, strings.ToLower(name)))
+	if err != nil {
+		return nil, err
+	}
+
+	var payload struct {
+		Minified string                                  `json:"minified"`
+		Packages map[string][]map[string]json.RawMessage `json:"packages"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, errors.Wrap(err, "invalid Packagist metadata")
+	}
+
+	// In the minified format (the default of the p2 endpoint), each version only lists
+	// the fields that changed from the version listed before it.
+	var versions []*Version
+	expanded := map[string]json.RawMessage{}
+	for _, fields := range payload.Packages[strings.ToLower(name)] {
+		if payload.Minified == "" {
+			expanded = map[string]json.RawMessage{}
+		}
+		for key, value := range fields {
+			if string(value) == `"__unset"` {
+				delete(expanded, key)
+			} else {
+				expanded[key] = value
+			}
+		}
+
+		var v Version
+		if err := json.Unmarshal(expanded["version"], &v.Version); err != nil {
+			return nil, errors.Wrap(err, "invalid Packagist version")
+		}
+		if dist, ok := expanded["dist"]; ok {
+			if err := json.Unmarshal(dist, &v.Dist); err != nil {
+				return nil, errors.Wrap(err, "invalid Packagist dist")
+			}
+		}
+		versions = append(versions, &v)
+	}
+
+	if len(versions) == 0 {
+		return nil, NotFoundError{errors.Errorf("package %q not found", name)}
+	}
+
+	return versions, nil
+}
+
+// Version returns the metadata of the given version of a package. Versions are
+// matched with or without a leading "v".
+func (c *Client) Version(ctx context.Context, name, version string) (*Version, error) {
+	versions, err := c.Versions(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range versions {
+		if strings.TrimPrefix(v.Version, "v") == strings.TrimPrefix(version, "v") {
+			return v, nil
+		}
+	}
+
+	return nil, NotFoundError{errors.Errorf("version %q of package %q not found", version, name)}
+}
+
+// Download returns the archive at the given dist URL.
+func (c *Client) Download(ctx context.Context, url string) ([]byte, error) {
+	return c.get(ctx, url)
+}
+
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("User-Agent", "sourcegraph-packagist-syncer (sourcegraph.com)")
+
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, NotFoundError{errors.Errorf("not found: %s", req.URL.Path)}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("bad response with status code %d for %s: %s", resp.StatusCode, req.URL.Path, string(bs))
+	}
+
+	return bs, nil
+}
@@ -0,0 +1,72 @@
+package packagist
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+const monologMetadata = `{
+  "minified": "composer/2.0",
+  "packages": {
+    "monolog/monolog": [
+      {
+        "name": "monolog/monolog",
+        "version": "2.7.0",
+        "dist": {"type": "zip", "url": "https://api.github.com/repos/Seldaek/monolog/zipball/5579edf", "reference": "5579edf"},
+        "license": ["MIT"]
+      },
+      {
+        "version": "v2.6.0",
+        "dist": {"type": "zip", "url": "https://api.github.com/repos/Seldaek/monolog/zipball/247918", "reference": "247918"}
+      },
+      {
+        "version": "2.5.0",
+        "license": "__unset"
+      }
+    ]
+  }
+}`
+
+func TestVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/p2/monolog/monolog.json" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(monologMetadata))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient("urn", httpcli.ExternalDoer)
+	client.url = server.URL
+
+	ctx := context.Background()
+
+	for version, expected := range map[string]Version{
+		"2.7.0":  {Version: "2.7.0", Dist: Dist{Type: "zip", URL: "https://api.github.com/repos/Seldaek/monolog/zipball/5579edf", Reference: "5579edf"}},
+		"2.6.0":  {Version: "v2.6.0", Dist: Dist{Type: "zip", URL: "https://api.github.com/repos/Seldaek/monolog/zipball/247918", Reference: "247918"}},
+		"v2.5.0": {Version: "2.5.0", Dist: Dist{Type: "zip", URL: "https://api.github.com/repos/Seldaek/monolog/zipball/247918", Reference: "247918"}},
+	} {
+		v, err := client.Version(ctx, "Monolog/Monolog", version)
+		if err != nil {
+			t.Fatalf("unexpected error for version %s: %s", version, err)
+		}
+		if diff := cmp.Diff(expected, *v); diff != "" {
+			t.Errorf("unexpected version %s (-want +got):\n%s", version, diff)
+		}
+	}
+
+	if _, err := client.Version(ctx, "monolog/monolog", "1.0.0"); !errcode.IsNotFound(err) {
+		t.Errorf("expected not found error for missing version, got %v", err)
+	}
+	if _, err := client.Version(ctx, "monolog/missing", "1.0.0"); !errcode.IsNotFound(err) {
+		t.Errorf("expected not found error for missing package, got %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+// Package rubygems is a client for the rubygems.org API.
+//
+// https://guides.rubygems.org/rubygems-org-api/
+package rubygems
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+)
+
+const defaultURL = "https://rubygems.org"
+
+type Client struct {
+	url string
+	cli httpcli.Doer
+
+	// Self-imposed rate-limiter.
+	limiter *ratelimit.InstrumentedLimiter
+}
+
+func NewClient(urn string, cli httpcli.Doer) *Client {
+	return &Client{
+		url:     defaultURL,
+		cli:     cli,
+		limiter: ratelimit.DefaultRegistry.Get(urn),
+	}
+}
+
+// GetGem returns the raw metadata of the latest version of a gem. An error
+// satisfying errcode.IsNotFound is returned if the gem does not exist.
+func (c *Client) GetGem(ctx context.Context, name string) ([]byte, error) {
+	return c.get(ctx, fmt.Sprintf("%s/api/v1/gems/%s.json", c.url, url.PathEscape(name)))
+}
+
+// GetVersion returns the raw metadata of the given version of a gem. An error
+// satisfying errcode.IsNotFound is returned if the gem or version does not exist.
+func (c *Client) GetVersion(ctx context.Context, name, version string) ([]byte, error) {
+	return c.get(ctx, fmt.Sprintf("%s/api/v2/rubygems/%s/versions/%s.json", c.url, url.PathEscape(name), url.PathEscape(version)))
+}
+
+// Download returns the .gem archive of the given version of a gem. A .gem file
+// is a plain tar archive containing the gzipped sources in "data.tar.gz".
+func (c *Client) Download(ctx context.Context, name, version string) ([]byte, error) {
+	return c.get(ctx, fmt.Sprintf("%s/downloads/%s-%s.gem", c.url, url.PathEscape(name), url.PathEscape(version)))
+}
+
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("User-Agent", "sourcegraph-rubygems-syncer (sourcegraph.com)")
+
+	return c.do(req)
+}
+
+type Error struct {
+	path    string
+	code    int
+	message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("bad response with status code %d for %s: %s", e.code, e.path, e.message)
+}
+
+func (e *Error) NotFound() bool {
+	return e.code == http.StatusNotFound
+}
+
+func (c *Client) do(req *http.Request) ([]byte, error) {
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &Error{path: req.URL.Path, code: resp.StatusCode, message: string(bs)}
+	}
+
+	return bs, nil
+}
@@ -0,0 +1,260 @@
+// Package sourcegraph implements a client for the GraphQL API of another
+// Sourcegraph instance, used to mirror its repositories.
+package sourcegraph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Client accesses the GraphQL API of a remote Sourcegraph instance.
+type Client struct {
+	// URL is the base URL of the remote Sourcegraph instance.
+	URL *url.URL
+
+	token string
+
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// RateLimit is the self-imposed rate limiter.
+	rateLimit *ratelimit.InstrumentedLimiter
+}
+
+// NewClient returns a Client for the remote instance in the given
+// configuration. If a nil httpClient is provided, httpcli.ExternalDoer will be
+// used.
+func NewClient(urn string, config *schema.SourcegraphConnection, httpClient httpcli.Doer) (*Client, error) {
+	u, err := url.Parse(config.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer
+	}
+
+	return &Client{
+		URL:        u,
+		token:      config.Token,
+		httpClient: httpClient,
+		rateLimit:  ratelimit.DefaultRegistry.Get(urn),
+	}, nil
+}
+
+// Repository is a repository on the remote Sourcegraph instance.
+type Repository struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	IsFork      bool   `json:"isFork"`
+	IsArchived  bool   `json:"isArchived"`
+	IsPrivate   bool   `json:"isPrivate"`
+}
+
+const repositoryFieldsFragment = `
+fragment RepositoryFields on Repository {
+	id
+	name
+	description
+	url
+	isFork
+	isArchived
+	isPrivate
+}
+`
+
+const listRepositoriesQuery = `
+query ListRepositories($first: Int!, $after: String) {
+	repositories(first: $first, after: $after) {
+		nodes {
+			...RepositoryFields
+		}
+		pageInfo {
+			endCursor
+			hasNextPage
+		}
+	}
+}
+` + repositoryFieldsFragment
+
+// ListRepositories returns a page of all repositories on the remote instance
+// that are visible to the authenticated user, starting after the given cursor.
+// The returned cursor is empty if there are no more pages.
+func (c *Client) ListRepositories(ctx context.Context, first int, after string) (repos []*Repository, next string, err error) {
+	vars := map[string]any{"first": first}
+	if after != "" {
+		vars["after"] = after
+	}
+
+	var result struct {
+		Repositories struct {
+			Nodes    []*Repository
+			PageInfo struct {
+				EndCursor   string
+				HasNextPage bool
+			}
+		}
+	}
+	if err := c.requestGraphQL(ctx, listRepositoriesQuery, vars, &result); err != nil {
+		return nil, "", err
+	}
+
+	if result.Repositories.PageInfo.HasNextPage {
+		next = result.Repositories.PageInfo.EndCursor
+	}
+	return result.Repositories.Nodes, next, nil
+}
+
+const searchRepositoriesQuery = `
+query SearchRepositories($query: String!) {
+	search(query: $query, version: V2) {
+		results {
+			results {
+				__typename
+				...RepositoryFields
+			}
+			alert {
+				title
+				description
+			}
+		}
+	}
+}
+` + repositoryFieldsFragment
+
+// SearchRepositories returns all repositories on the remote instance that
+// match the given query, in the remote's search query syntax.
+func (c *Client) SearchRepositories(ctx context.Context, query string) ([]*Repository, error) {
+	vars := map[string]any{"query": strings.TrimSpace(query) + " type:repo count:all"}
+
+	var result struct {
+		Search struct {
+			Results struct {
+				Results []struct {
+					Typename string `json:"__typename"`
+					Repository
+				}
+				Alert *struct {
+					Title       string
+					Description string
+				}
+			}
+		}
+	}
+	if err := c.requestGraphQL(ctx, searchRepositoriesQuery, vars, &result); err != nil {
+		return nil, err
+	}
+
+	// Alerts are returned instead of results for invalid queries.
+	if alert := result.Search.Results.Alert; alert != nil && len(result.Search.Results.Results) == 0 {
+		return nil, errors.Errorf("repository query %q: %s: %s", query, alert.Title, alert.Description)
+	}
+
+	repos := make([]*Repository, 0, len(result.Search.Results.Results))
+	for _, r := range result.Search.Results.Results {
+		if r.Typename != "Repository" {
+			continue
+		}
+		repo := r.Repository
+		repos = append(repos, &repo)
+	}
+	return repos, nil
+}
+
+func (c *Client) requestGraphQL(ctx context.Context, query string, vars map[string]any, result any) error {
+	reqBody, err := json.Marshal(map[string]any{
+		"query":     query,
+		"variables": vars,
+	})
+	if err != nil {
+		return err
+	}
+
+	u := c.URL.ResolveReference(&url.URL{Path: ".api/graphql"})
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+
+	if err := c.rateLimit.Wait(ctx); err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	var respBody struct {
+		Data   json.RawMessage `json:"data"`
+		Errors graphqlErrors   `json:"errors"`
+	}
+	if err := json.Unmarshal(bs, &respBody); err != nil {
+		return errors.Wrap(err, "decoding GraphQL response")
+	}
+	if len(respBody.Errors) > 0 {
+		return respBody.Errors
+	}
+	return json.Unmarshal(respBody.Data, result)
+}
+
+type graphqlErrors []struct {
+	Message string `json:"message"`
+}
+
+func (es graphqlErrors) Error() string {
+	msgs := make([]string, 0, len(es))
+	for _, e := range es {
+		msgs = append(msgs, e.Message)
+	}
+	return "Sourcegraph GraphQL API error: " + strings.Join(msgs, "; ")
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Sourcegraph API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
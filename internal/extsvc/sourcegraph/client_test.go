@@ -0,0 +1,67 @@
+package sourcegraph
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func newTestClient(t *testing.T, body string) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	cli, err := NewClient("extsvc:sourcegraph:1", &schema.SourcegraphConnection{Url: srv.URL}, http.DefaultClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cli
+}
+
+func TestClient_SearchRepositories(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("only repositories are returned", func(t *testing.T) {
+		cli := newTestClient(t, `{"data": {"search": {"results": {"results": [
+			{"__typename": "Repository", "id": "UmVwbzox", "name": "github.com/foo/bar", "isFork": true},
+			{"__typename": "FileMatch"}
+		]}}}}`)
+
+		have, err := cli.SearchRepositories(ctx, "repo:^github\\.com/foo/")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []*Repository{{ID: "UmVwbzox", Name: "github.com/foo/bar", IsFork: true}}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatalf("repositories mismatch (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("alert", func(t *testing.T) {
+		cli := newTestClient(t, `{"data": {"search": {"results": {"results": [], "alert": {"title": "Invalid query", "description": "bad filter"}}}}}`)
+
+		_, err := cli.SearchRepositories(ctx, "repo:(")
+		if err == nil || !strings.Contains(err.Error(), "bad filter") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("GraphQL errors", func(t *testing.T) {
+		cli := newTestClient(t, `{"errors": [{"message": "not authenticated"}]}`)
+
+		_, err := cli.SearchRepositories(ctx, "repo:foo")
+		if err == nil || !strings.Contains(err.Error(), "not authenticated") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
@@ -87,7 +87,10 @@ const (
 	KindJVMPackages     = "JVMPACKAGES"
 	KindPythonPackages  = "PYTHONPACKAGES"
 	KindRustPackages    = "RUSTPACKAGES"
+	KindRubyPackages    = "RUBYPACKAGES"
+	KindPHPPackages     = "PHPPACKAGES"
 	KindPagure          = "PAGURE"
+	KindSourcegraph     = "SOURCEGRAPH"
 	KindNpmPackages     = "NPMPACKAGES"
 	KindOther           = "OTHER"
 )
@@ -134,6 +137,10 @@ const (
 	// TypePagure is the (api.ExternalRepoSpec).ServiceType value for Pagure projects.
 	TypePagure = "pagure"
 
+	// TypeSourcegraph is the (api.ExternalRepoSpec).ServiceType value for repositories mirrored from
+	// another Sourcegraph instance. The ServiceID value is the base URL of the remote instance.
+	TypeSourcegraph = "sourcegraph"
+
 	// TypeNpmPackages is the (api.ExternalRepoSpec).ServiceType value for Npm packages (JavaScript/TypeScript ecosystem libraries).
 	TypeNpmPackages = "npmPackages"
 
@@ -146,6 +153,12 @@ const (
 	// TypeRustPackages is the (api.ExternalRepoSpec).ServiceType value for Python packages.
 	TypeRustPackages = "rustPackages"
 
+	// TypeRubyPackages is the (api.ExternalRepoSpec).ServiceType value for Ruby gems.
+	TypeRubyPackages = "rubyPackages"
+
+	// TypePHPPackages is the (api.ExternalRepoSpec).ServiceType value for PHP Composer packages.
+	TypePHPPackages = "phpPackages"
+
 	// TypeOther is the (api.ExternalRepoSpec).ServiceType value for other projects.
 	TypeOther = "other"
 )
@@ -178,12 +191,18 @@ func KindToType(kind string) string {
 		return TypePythonPackages
 	case KindRustPackages:
 		return TypeRustPackages
+	case KindRubyPackages:
+		return TypeRubyPackages
+	case KindPHPPackages:
+		return TypePHPPackages
 	case KindNpmPackages:
 		return TypeNpmPackages
 	case KindGoModules:
 		return TypeGoModules
 	case KindPagure:
 		return TypePagure
+	case KindSourcegraph:
+		return TypeSourcegraph
 	case KindOther:
 		return TypeOther
 	default:
@@ -221,10 +240,16 @@ func TypeToKind(t string) string {
 		return KindPythonPackages
 	case TypeRustPackages:
 		return KindRustPackages
+	case TypeRubyPackages:
+		return KindRubyPackages
+	case TypePHPPackages:
+		return KindPHPPackages
 	case TypeGoModules:
 		return KindGoModules
 	case TypePagure:
 		return KindPagure
+	case TypeSourcegraph:
+		return KindSourcegraph
 	case TypeOther:
 		return KindOther
 	default:
@@ -241,6 +266,8 @@ var (
 	goLower     = strings.ToLower(TypeGoModules)
 	pythonLower = strings.ToLower(TypePythonPackages)
 	rustLower   = strings.ToLower(TypeRustPackages)
+	rubyLower   = strings.ToLower(TypeRubyPackages)
+	phpLower    = strings.ToLower(TypePHPPackages)
 )
 
 // ParseServiceType will return a ServiceType constant after doing a case insensitive match on s.
@@ -275,8 +302,14 @@ func ParseServiceType(s string) (string, bool) {
 		return TypePythonPackages, true
 	case rustLower:
 		return TypeRustPackages, true
+	case rubyLower:
+		return TypeRubyPackages, true
+	case phpLower:
+		return TypePHPPackages, true
 	case TypePagure:
 		return TypePagure, true
+	case TypeSourcegraph:
+		return TypeSourcegraph, true
 	case TypeOther:
 		return TypeOther, true
 	default:
@@ -314,8 +347,14 @@ func ParseServiceKind(s string) (string, bool) {
 		return KindPythonPackages, true
 	case KindRustPackages:
 		return KindRustPackages, true
+	case KindRubyPackages:
+		return KindRubyPackages, true
+	case KindPHPPackages:
+		return KindPHPPackages, true
 	case KindPagure:
 		return KindPagure, true
+	case KindSourcegraph:
+		return KindSourcegraph, true
 	case KindOther:
 		return KindOther, true
 	default:
@@ -363,12 +402,18 @@ func ParseConfig(kind, config string) (cfg any, _ error) {
 		cfg = &schema.JVMPackagesConnection{}
 	case KindPagure:
 		cfg = &schema.PagureConnection{}
+	case KindSourcegraph:
+		cfg = &schema.SourcegraphConnection{}
 	case KindNpmPackages:
 		cfg = &schema.NpmPackagesConnection{}
 	case KindPythonPackages:
 		cfg = &schema.PythonPackagesConnection{}
 	case KindRustPackages:
 		cfg = &schema.RustPackagesConnection{}
+	case KindRubyPackages:
+		cfg = &schema.RubyPackagesConnection{}
+	case KindPHPPackages:
+		cfg = &schema.PHPPackagesConnection{}
 	case KindOther:
 		cfg = &schema.OtherExternalServiceConnection{}
 	default:
@@ -427,6 +472,8 @@ func ExtractToken(config string, kind string) (string, error) {
 		return c.Token, nil
 	case *schema.PagureConnection:
 		return c.Token, nil
+	case *schema.SourcegraphConnection:
+		return c.Token, nil
 	default:
 		return "", errors.Errorf("unable to extract token for service kind %q", kind)
 	}
@@ -496,6 +543,11 @@ func GetLimitFromConfig(kind string, config any) (rate.Limit, error) {
 		if c != nil && c.RateLimit != nil {
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.SourcegraphConnection:
+		limit = rate.Limit(2) // Same as default in sourcegraph.schema.json
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	case *schema.NpmPackagesConnection:
 		limit = rate.Limit(3000 / 3600.0) // Same as the default in npm-packages.schema.json
 		if c != nil && c.RateLimit != nil {
@@ -522,6 +574,16 @@ func GetLimitFromConfig(kind string, config any) (rate.Limit, error) {
 		if c != nil && c.RateLimit != nil {
 			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
 		}
+	case *schema.RubyPackagesConnection:
+		limit = rate.Limit(1) // Same as default in ruby-packages.schema.json
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
+	case *schema.PHPPackagesConnection:
+		limit = rate.Limit(1) // Same as default in php-packages.schema.json
+		if c != nil && c.RateLimit != nil {
+			limit = limitOrInf(c.RateLimit.Enabled, c.RateLimit.RequestsPerHour)
+		}
 	default:
 		return limit, ErrRateLimitUnsupported{codehostKind: kind}
 	}
@@ -545,10 +607,11 @@ func (e ErrRateLimitUnsupported) Error() string {
 }
 
 const (
-	URNGitHubAppCloud = "GitHubAppCloud"
-	URNGitHubOAuth    = "GitHubOAuth"
-	URNGitLabOAuth    = "GitLabOAuth"
-	URNCodeIntel      = "CodeIntel"
+	URNGitHubAppCloud      = "GitHubAppCloud"
+	URNGitHubOAuth         = "GitHubOAuth"
+	URNGitLabOAuth         = "GitLabOAuth"
+	URNBitbucketCloudOAuth = "BitbucketCloudOAuth"
+	URNCodeIntel           = "CodeIntel"
 )
 
 // URN returns a unique resource identifier of an external service by given kind and ID.
@@ -629,8 +692,14 @@ func UniqueCodeHostIdentifier(kind, config string) (string, error) {
 		return KindPythonPackages, nil
 	case *schema.RustPackagesConnection:
 		return KindRustPackages, nil
+	case *schema.RubyPackagesConnection:
+		return KindRubyPackages, nil
+	case *schema.PHPPackagesConnection:
+		return KindPHPPackages, nil
 	case *schema.PagureConnection:
 		rawURL = c.Url
+	case *schema.SourcegraphConnection:
+		rawURL = c.Url
 	default:
 		return "", errors.Errorf("unknown external service kind: %s", kind)
 	}
@@ -111,6 +111,18 @@ func TestExtractRateLimitConfig(t *testing.T) {
 			kind:   KindBitbucketCloud,
 			want:   1.0,
 		},
+		{
+			name:   "Sourcegraph default",
+			config: `{"url": "https://example.com/"}`,
+			kind:   KindSourcegraph,
+			want:   2.0,
+		},
+		{
+			name:   "Sourcegraph non-default",
+			config: `{"url": "https://example.com/", "rateLimit": {"enabled": true, "requestsPerHour": 3600}}`,
+			kind:   KindSourcegraph,
+			want:   1.0,
+		},
 		{
 			name:   "NPM default",
 			config: `{"registry": "https://registry.npmjs.org"}`,
@@ -185,7 +185,8 @@ type Client interface {
 	GitCommand(repo api.RepoName, args ...string) GitCommand
 
 	// CreateCommitFromPatch will attempt to create a commit from a patch
-	// If possible, the error returned will be of type protocol.CreateCommitFromPatchError
+	// If possible, the error returned will be of type protocol.CreateCommitFromPatchError,
+	// or protocol.PatchDoesNotApplyError if the patch doesn't apply to the base commit.
 	CreateCommitFromPatch(context.Context, protocol.CreateCommitFromPatchRequest) (string, error)
 
 	// GetObject fetches git object data in the supplied repo
@@ -1384,6 +1385,9 @@ func (c *ClientImplementor) CreateCommitFromPatch(ctx context.Context, req proto
 	}
 
 	if res.Error != nil {
+		if res.Error.PatchDoesNotApply {
+			return res.Rev, &protocol.PatchDoesNotApplyError{CreateCommitFromPatchError: res.Error}
+		}
 		return res.Rev, res.Error
 	}
 	return res.Rev, nil
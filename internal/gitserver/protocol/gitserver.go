@@ -367,6 +367,10 @@ type CreateCommitFromPatchError struct {
 	Command string
 	// CombinedOutput is the combined stderr and stdout from running the command
 	CombinedOutput string
+
+	// PatchDoesNotApply is true if the command that failed applied the patch to
+	// the base commit.
+	PatchDoesNotApply bool
 }
 
 // Error returns a detailed error conforming to the error interface
@@ -374,6 +378,17 @@ func (e *CreateCommitFromPatchError) Error() string {
 	return e.InternalError
 }
 
+// PatchDoesNotApplyError is returned by CreateCommitFromPatch if the patch
+// doesn't apply to the base commit.
+type PatchDoesNotApplyError struct {
+	*CreateCommitFromPatchError
+}
+
+// Unwrap returns the CreateCommitFromPatchError describing the failed command.
+func (e *PatchDoesNotApplyError) Unwrap() error {
+	return e.CreateCommitFromPatchError
+}
+
 type GetObjectRequest struct {
 	Repo       api.RepoName
 	ObjectName string
@@ -26,6 +26,7 @@ type BitbucketCloudSource struct {
 	svc     *types.ExternalService
 	config  *schema.BitbucketCloudConnection
 	exclude excludeFunc
+	rules   *metadataRules
 	client  bitbucketcloud.Client
 }
 
@@ -51,15 +52,39 @@ func newBitbucketCloudSource(svc *types.ExternalService, c *schema.BitbucketClou
 	}
 
 	var eb excludeBuilder
+	var mb metadataRulesBuilder
 	for _, r := range c.Exclude {
+		// Items that set metadata fields only exclude repositories that match
+		// all of their fields, including the name.
+		if mb.Exclude(metadataRuleConfig{
+			SizeOverMB:   r.SizeOverMB,
+			PushedBefore: r.PushedBefore,
+			Languages:    r.Languages,
+			Names:        []string{r.Name, r.Uuid},
+			Pattern:      r.Pattern,
+		}) {
+			continue
+		}
+
 		eb.Exact(r.Name)
 		eb.Exact(r.Uuid)
 		eb.Pattern(r.Pattern)
 	}
+	for _, r := range c.Include {
+		mb.Include(metadataRuleConfig{
+			SizeOverMB:   r.SizeOverMB,
+			PushedBefore: r.PushedBefore,
+			Languages:    r.Languages,
+		})
+	}
 	exclude, err := eb.Build()
 	if err != nil {
 		return nil, err
 	}
+	rules, err := mb.Build()
+	if err != nil {
+		return nil, err
+	}
 
 	client, err := bitbucketcloud.NewClient(svc.URN(), c, cli)
 	if err != nil {
@@ -70,6 +95,7 @@ func newBitbucketCloudSource(svc *types.ExternalService, c *schema.BitbucketClou
 		svc:     svc,
 		config:  c,
 		exclude: exclude,
+		rules:   rules,
 		client:  client,
 	}, nil
 }
@@ -80,6 +106,15 @@ func (s BitbucketCloudSource) ListRepos(ctx context.Context, results chan Source
 	s.listAllRepos(ctx, results)
 }
 
+func (s BitbucketCloudSource) metadataRules() *metadataRules {
+	return s.rules
+}
+
+func (s BitbucketCloudSource) withoutMetadataRules() Source {
+	s.rules = s.rules.withDryRun()
+	return &s
+}
+
 // ExternalServices returns a singleton slice containing the external service.
 func (s BitbucketCloudSource) ExternalServices() types.ExternalServices {
 	return types.ExternalServices{s.svc}
@@ -217,7 +252,20 @@ func (s *BitbucketCloudSource) listAllRepos(ctx context.Context, results chan So
 			}
 
 			if !seen[repo.UUID] && !s.excludes(repo) {
-				results <- SourceResult{Source: s, Repo: s.makeRepo(repo)}
+				if s.rules.needsPushedAt() {
+					// Bitbucket Cloud doesn't report when a repository was
+					// last pushed to, so we use the date of its most recent
+					// commit.
+					lastCommitAt, err := s.client.LastCommitDate(ctx, repo)
+					if err != nil {
+						results <- SourceResult{Source: s, Err: errors.Wrapf(err, "getting last commit of Bitbucket Cloud repository %q", repo.FullName)}
+						continue
+					}
+					repo.LastCommitAt = lastCommitAt
+				}
+				if sourced := s.makeRepo(repo); !s.rules.excludes(sourced) {
+					results <- SourceResult{Source: s, Repo: sourced}
+				}
 				seen[repo.UUID] = true
 			}
 		}
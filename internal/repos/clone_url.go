@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/pagure"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/perforce"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/phabricator"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/sourcegraph"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -74,6 +75,10 @@ func CloneURL(kind, config string, repo *types.Repo) (string, error) {
 		if r, ok := repo.Metadata.(*pagure.Project); ok {
 			return r.FullURL, nil
 		}
+	case *schema.SourcegraphConnection:
+		if r, ok := repo.Metadata.(*sourcegraph.Repository); ok {
+			return sourcegraphCloneURL(r, t), nil
+		}
 	case *schema.OtherExternalServiceConnection:
 		if r, ok := repo.Metadata.(*extsvc.OtherRepoMetadata); ok {
 			return otherCloneURL(repo, r), nil
@@ -84,6 +89,10 @@ func CloneURL(kind, config string, repo *types.Repo) (string, error) {
 		return string(repo.Name), nil
 	case *schema.RustPackagesConnection:
 		return string(repo.Name), nil
+	case *schema.RubyPackagesConnection:
+		return string(repo.Name), nil
+	case *schema.PHPPackagesConnection:
+		return string(repo.Name), nil
 	case *schema.JVMPackagesConnection:
 		if r, ok := repo.Metadata.(*reposource.MavenMetadata); ok {
 			return r.Module.CloneURL(), nil
@@ -297,3 +306,9 @@ func phabricatorCloneURL(repo *phabricator.Repo, _ *schema.PhabricatorConnection
 func otherCloneURL(repo *types.Repo, m *extsvc.OtherRepoMetadata) string {
 	return repo.ExternalRepo.ServiceID + m.RelativePath
 }
+
+func sourcegraphCloneURL(repo *sourcegraph.Repository, cfg *schema.SourcegraphConnection) string {
+	// The repository is cloned by its name on the remote instance, which may
+	// differ from its name on this instance.
+	return strings.TrimSuffix(cfg.GitURL, "/") + "/" + repo.Name
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/perforce"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/phabricator"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/sourcegraph"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -268,6 +269,24 @@ func TestPerforceCloneURL(t *testing.T) {
 	}
 }
 
+func TestSourcegraphCloneURL(t *testing.T) {
+	cfg := schema.SourcegraphConnection{
+		Url:                   "https://sourcegraph.example.com",
+		GitURL:                "http://sourcegraph-frontend-internal:3090/.internal/git/",
+		RepositoryPathPattern: "{host}/{name}",
+	}
+
+	repo := &sourcegraph.Repository{
+		Name: "github.com/foo/bar",
+	}
+
+	got := sourcegraphCloneURL(repo, &cfg)
+	want := "http://sourcegraph-frontend-internal:3090/.internal/git/github.com/foo/bar"
+	if got != want {
+		t.Fatalf("wrong cloneURL, got: %q, want: %q", got, want)
+	}
+}
+
 func TestPhabricatorCloneURL(t *testing.T) {
 	meta := `
 {
@@ -41,6 +41,7 @@ type GitHubSource struct {
 	exclude         excludeFunc
 	excludeArchived bool
 	excludeForks    bool
+	rules           *metadataRules
 	githubDotCom    bool
 	baseURL         *url.URL
 	v3Client        *github.V3Client
@@ -185,11 +186,27 @@ func newGithubSource(
 
 	var (
 		eb              excludeBuilder
+		mb              metadataRulesBuilder
 		excludeArchived bool
 		excludeForks    bool
 	)
 
 	for _, r := range c.Exclude {
+		// Items that set metadata fields only exclude repositories that match
+		// all of their fields, including the name.
+		if mb.Exclude(metadataRuleConfig{
+			SizeOverMB:   r.SizeOverMB,
+			PushedBefore: r.PushedBefore,
+			Topics:       r.Topics,
+			Languages:    r.Languages,
+			Names:        []string{r.Name, r.Id},
+			Pattern:      r.Pattern,
+			Archived:     r.Archived,
+			Forks:        r.Forks,
+		}) {
+			continue
+		}
+
 		eb.Exact(r.Name)
 		eb.Exact(r.Id)
 		eb.Pattern(r.Pattern)
@@ -203,10 +220,23 @@ func newGithubSource(
 		}
 	}
 
+	for _, r := range c.Include {
+		mb.Include(metadataRuleConfig{
+			SizeOverMB:   r.SizeOverMB,
+			PushedBefore: r.PushedBefore,
+			Topics:       r.Topics,
+			Languages:    r.Languages,
+		})
+	}
+
 	exclude, err := eb.Build()
 	if err != nil {
 		return nil, err
 	}
+	rules, err := mb.Build()
+	if err != nil {
+		return nil, err
+	}
 	token := &auth.OAuthBearerToken{Token: c.Token}
 	urn := svc.URN()
 
@@ -286,6 +316,7 @@ func newGithubSource(
 		exclude:          exclude,
 		excludeArchived:  excludeArchived,
 		excludeForks:     excludeForks,
+		rules:            rules,
 		baseURL:          baseURL,
 		githubDotCom:     githubDotCom,
 		v3Client:         v3Client,
@@ -344,12 +375,23 @@ func (s GitHubSource) ListRepos(ctx context.Context, results chan SourceResult)
 			continue
 		}
 		if !seen[res.repo.DatabaseID] && !s.excludes(res.repo) {
-			results <- SourceResult{Source: s, Repo: s.makeRepo(res.repo)}
+			if repo := s.makeRepo(res.repo); !s.rules.excludes(repo) {
+				results <- SourceResult{Source: s, Repo: repo}
+			}
 			seen[res.repo.DatabaseID] = true
 		}
 	}
 }
 
+func (s GitHubSource) metadataRules() *metadataRules {
+	return s.rules
+}
+
+func (s GitHubSource) withoutMetadataRules() Source {
+	s.rules = s.rules.withDryRun()
+	return &s
+}
+
 // ExternalServices returns a singleton slice containing the external service.
 func (s GitHubSource) ExternalServices() types.ExternalServices {
 	return types.ExternalServices{s.svc}
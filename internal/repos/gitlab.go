@@ -34,6 +34,7 @@ type GitLabSource struct {
 	svc                 *types.ExternalService
 	config              *schema.GitLabConnection
 	exclude             excludeFunc
+	rules               *metadataRules
 	baseURL             *url.URL // URL with path /api/v4 (no trailing slash)
 	nameTransformations reposource.NameTransformations
 	provider            *gitlab.ClientProvider
@@ -86,14 +87,41 @@ func newGitLabSource(ctx context.Context, db database.DB, svc *types.ExternalSer
 	}
 
 	var eb excludeBuilder
+	var mb metadataRulesBuilder
 	for _, r := range c.Exclude {
+		names := []string{r.Name}
+		if r.Id != 0 {
+			names = append(names, strconv.Itoa(r.Id))
+		}
+		// Items that set metadata fields only exclude projects that match
+		// all of their fields, including the name.
+		if mb.Exclude(metadataRuleConfig{
+			SizeOverMB:   r.SizeOverMB,
+			PushedBefore: r.PushedBefore,
+			Topics:       r.Topics,
+			Names:        names,
+		}) {
+			continue
+		}
+
 		eb.Exact(r.Name)
 		eb.Exact(strconv.Itoa(r.Id))
 	}
+	for _, r := range c.Include {
+		mb.Include(metadataRuleConfig{
+			SizeOverMB:   r.SizeOverMB,
+			PushedBefore: r.PushedBefore,
+			Topics:       r.Topics,
+		})
+	}
 	exclude, err := eb.Build()
 	if err != nil {
 		return nil, err
 	}
+	rules, err := mb.Build()
+	if err != nil {
+		return nil, err
+	}
 
 	// Validate and cache user-defined name transformations.
 	nts, err := reposource.CompileGitLabNameTransformations(c.NameTransformations)
@@ -131,6 +159,7 @@ func newGitLabSource(ctx context.Context, db database.DB, svc *types.ExternalSer
 		svc:                 svc,
 		config:              c,
 		exclude:             exclude,
+		rules:               rules,
 		baseURL:             baseURL,
 		nameTransformations: nts,
 		provider:            provider,
@@ -182,6 +211,15 @@ func (s GitLabSource) GetRepo(ctx context.Context, pathWithNamespace string) (*t
 	return s.makeRepo(proj), nil
 }
 
+func (s GitLabSource) metadataRules() *metadataRules {
+	return s.rules
+}
+
+func (s GitLabSource) withoutMetadataRules() Source {
+	s.rules = s.rules.withDryRun()
+	return &s
+}
+
 // ExternalServices returns a singleton slice containing the external service.
 func (s GitLabSource) ExternalServices() types.ExternalServices {
 	return types.ExternalServices{s.svc}
@@ -302,6 +340,10 @@ func (s *GitLabSource) listAllProjects(ctx context.Context, results chan SourceR
 				ch <- batch{err: errors.Wrapf(err, "invalid GitLab projectQuery=%q", projectQuery)}
 				return
 			}
+			if s.rules.needsSize() {
+				// Project statistics are only returned when explicitly requested.
+				url += "&statistics=true"
+			}
 
 			for {
 				if err := ctx.Err(); err != nil {
@@ -339,7 +381,19 @@ func (s *GitLabSource) listAllProjects(ctx context.Context, results chan SourceR
 
 		for _, proj := range b.projs {
 			if !seen[proj.ID] && !s.excludes(proj) {
-				results <- SourceResult{Source: s, Repo: s.makeRepo(proj)}
+				if s.rules.needsPushedAt() {
+					// GitLab doesn't report when a project was last pushed
+					// to, so we use the date of its most recent commit.
+					lastCommitAt, err := s.client.LastCommitDate(ctx, proj)
+					if err != nil {
+						results <- SourceResult{Source: s, Err: errors.Wrapf(err, "getting last commit of GitLab project %q", proj.PathWithNamespace)}
+						continue
+					}
+					proj.LastCommitAt = lastCommitAt
+				}
+				if repo := s.makeRepo(proj); !s.rules.excludes(repo) {
+					results <- SourceResult{Source: s, Repo: repo}
+				}
 				seen[proj.ID] = true
 			}
 		}
@@ -0,0 +1,438 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// metadataRuleConfig is the code host agnostic form of an exclude or include
+// item of a code host connection that matches repositories by metadata.
+type metadataRuleConfig struct {
+	SizeOverMB   float64
+	PushedBefore string
+	Topics       []string
+	Languages    []string
+
+	// Names, Pattern, Archived and Forks further restrict an exclude item
+	// that also sets metadata fields, so that they all have to match.
+	Names    []string
+	Pattern  string
+	Archived bool
+	Forks    bool
+}
+
+// metadataRule matches repositories by the metadata reported by their code
+// host. All of the fields that are set must match for the rule to match.
+type metadataRule struct {
+	config metadataRuleConfig
+
+	sizeOverBytes int64
+	pushedBefore  time.Time
+	topics        map[string]struct{}
+	languages     map[string]struct{}
+	name          excludeFunc
+}
+
+// newMetadataRule returns a metadataRule for the given config, or nil if the
+// config doesn't set any metadata fields.
+func newMetadataRule(c metadataRuleConfig) (*metadataRule, error) {
+	r := &metadataRule{config: c}
+	set := false
+
+	if c.SizeOverMB > 0 {
+		r.sizeOverBytes = int64(c.SizeOverMB * 1024 * 1024)
+		set = true
+	}
+
+	if c.PushedBefore != "" {
+		t, err := time.Parse("2006-01-02", c.PushedBefore)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pushedBefore date %q", c.PushedBefore)
+		}
+		r.pushedBefore = t
+		set = true
+	}
+
+	if len(c.Topics) > 0 {
+		r.topics = lowerSet(c.Topics)
+		set = true
+	}
+
+	if len(c.Languages) > 0 {
+		r.languages = lowerSet(c.Languages)
+		set = true
+	}
+
+	if !set {
+		return nil, nil
+	}
+
+	if len(c.Names) > 0 || c.Pattern != "" {
+		var eb excludeBuilder
+		for _, name := range c.Names {
+			eb.Exact(name)
+		}
+		eb.Pattern(c.Pattern)
+		name, err := eb.Build()
+		if err != nil {
+			return nil, err
+		}
+		r.name = name
+	}
+
+	return r, nil
+}
+
+// String returns a human readable description of the rule, such as
+// `sizeOverMB: 5000, topics: [deprecated]`.
+func (r *metadataRule) String() string {
+	var parts []string
+	for _, name := range r.config.Names {
+		if name != "" {
+			parts = append(parts, fmt.Sprintf("name: %s", name))
+		}
+	}
+	if r.config.Pattern != "" {
+		parts = append(parts, fmt.Sprintf("pattern: %s", r.config.Pattern))
+	}
+	if r.config.Archived {
+		parts = append(parts, "archived: true")
+	}
+	if r.config.Forks {
+		parts = append(parts, "forks: true")
+	}
+	if r.sizeOverBytes > 0 {
+		parts = append(parts, fmt.Sprintf("sizeOverMB: %v", r.config.SizeOverMB))
+	}
+	if !r.pushedBefore.IsZero() {
+		parts = append(parts, fmt.Sprintf("pushedBefore: %s", r.config.PushedBefore))
+	}
+	if len(r.topics) > 0 {
+		parts = append(parts, fmt.Sprintf("topics: [%s]", strings.Join(r.config.Topics, ", ")))
+	}
+	if len(r.languages) > 0 {
+		parts = append(parts, fmt.Sprintf("languages: [%s]", strings.Join(r.config.Languages, ", ")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// matches returns true if the given metadata matches all of the fields set on
+// the rule. Metadata that the code host didn't report never matches.
+func (r *metadataRule) matches(m *repoMetadata) bool {
+	if r.name != nil && !r.name(m.name) && !r.name(m.id) {
+		return false
+	}
+
+	if r.config.Archived && !m.archived {
+		return false
+	}
+
+	if r.config.Forks && !m.fork {
+		return false
+	}
+
+	if r.sizeOverBytes > 0 && (m.sizeBytes <= 0 || m.sizeBytes <= r.sizeOverBytes) {
+		return false
+	}
+
+	if !r.pushedBefore.IsZero() && (m.pushedAt.IsZero() || !m.pushedAt.Before(r.pushedBefore)) {
+		return false
+	}
+
+	if len(r.topics) > 0 && !containsAny(r.topics, m.topics) {
+		return false
+	}
+
+	if len(r.languages) > 0 && (m.language == "" || !containsAny(r.languages, []string{m.language})) {
+		return false
+	}
+
+	return true
+}
+
+// metadataRules is the set of exclude and include metadata rules configured on
+// a code host connection.
+type metadataRules struct {
+	exclude []*metadataRule
+	include []*metadataRule
+
+	// dryRun is set when the metadata the rules need is still fetched, but
+	// no repositories are excluded.
+	dryRun bool
+}
+
+// metadataRulesBuilder builds metadataRules.
+type metadataRulesBuilder struct {
+	rules metadataRules
+	err   error
+}
+
+// Exclude adds an exclude rule for the given config. Configs that don't set
+// any metadata fields are ignored and false is returned, in which case the
+// caller is expected to exclude by name instead.
+func (b *metadataRulesBuilder) Exclude(c metadataRuleConfig) bool {
+	r, err := newMetadataRule(c)
+	if err != nil {
+		b.err = errors.Append(b.err, err)
+		return true
+	} else if r != nil {
+		b.rules.exclude = append(b.rules.exclude, r)
+		return true
+	}
+	return false
+}
+
+// Include adds an include rule for the given config.
+func (b *metadataRulesBuilder) Include(c metadataRuleConfig) {
+	r, err := newMetadataRule(c)
+	if err != nil {
+		b.err = errors.Append(b.err, err)
+	} else if r != nil {
+		b.rules.include = append(b.rules.include, r)
+	}
+}
+
+// Build returns the metadataRules based on the previous calls to Exclude and
+// Include.
+func (b *metadataRulesBuilder) Build() (*metadataRules, error) {
+	rules := b.rules
+	return &rules, b.err
+}
+
+// empty returns true if no rules are configured.
+func (rs *metadataRules) empty() bool {
+	return rs == nil || (len(rs.exclude) == 0 && len(rs.include) == 0)
+}
+
+// needsSize returns true if any of the rules match on the repository size.
+func (rs *metadataRules) needsSize() bool {
+	if rs == nil {
+		return false
+	}
+	for _, r := range rs.all() {
+		if r.sizeOverBytes > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// needsPushedAt returns true if any of the rules match on the time of the
+// last push.
+func (rs *metadataRules) needsPushedAt() bool {
+	if rs == nil {
+		return false
+	}
+	for _, r := range rs.all() {
+		if !r.pushedBefore.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// withDryRun returns a copy of the rules that never excludes repositories.
+func (rs *metadataRules) withDryRun() *metadataRules {
+	if rs == nil {
+		return nil
+	}
+	dryRun := *rs
+	dryRun.dryRun = true
+	return &dryRun
+}
+
+// all returns the exclude rules followed by the include rules.
+func (rs *metadataRules) all() []*metadataRule {
+	all := make([]*metadataRule, 0, len(rs.exclude)+len(rs.include))
+	all = append(all, rs.exclude...)
+	return append(all, rs.include...)
+}
+
+// excludes returns true if the given repository should not be synced: either
+// because it matches an exclude rule, or because include rules are configured
+// and it matches none of them. Repositories whose metadata is of an unknown
+// type are never excluded.
+func (rs *metadataRules) excludes(r *types.Repo) bool {
+	if rs.empty() || rs.dryRun {
+		return false
+	}
+
+	m, ok := repoMetadataOf(r.Metadata)
+	if !ok {
+		return false
+	}
+
+	for _, rule := range rs.exclude {
+		if rule.matches(m) {
+			return true
+		}
+	}
+
+	if len(rs.include) == 0 {
+		return false
+	}
+
+	for _, rule := range rs.include {
+		if rule.matches(m) {
+			return false
+		}
+	}
+	return true
+}
+
+// metadataRulesSource is implemented by sources that support excluding and
+// including repositories by metadata.
+type metadataRulesSource interface {
+	Source
+	// metadataRules returns the metadata rules configured for the source.
+	metadataRules() *metadataRules
+	// withoutMetadataRules returns a copy of the source that yields
+	// repositories regardless of its metadata rules, but still fetches the
+	// metadata that the rules need.
+	withoutMetadataRules() Source
+}
+
+// MetadataRulesDryRun lists the repositories yielded by src while ignoring its
+// metadata rules and reports which of them each rule matches. The repositories
+// that the rules would keep are compared to synced, the names of the
+// repositories currently synced by the external service, to determine which
+// would be added and removed.
+func MetadataRulesDryRun(ctx context.Context, src Source, synced []api.RepoName) (*protocol.MetadataRulesDryRunResult, error) {
+	rs, ok := src.(metadataRulesSource)
+	if !ok {
+		return nil, errors.New("metadata rules are not supported by this kind of external service")
+	}
+
+	rules := rs.metadataRules()
+	if rules == nil {
+		rules = &metadataRules{}
+	}
+
+	all, err := listAll(ctx, rs.withoutMetadataRules())
+	if err != nil {
+		return nil, err
+	}
+
+	res := &protocol.MetadataRulesDryRunResult{}
+	for _, r := range rules.exclude {
+		res.Rules = append(res.Rules, &protocol.MetadataRuleDryRun{Kind: "exclude", Rule: r.String()})
+	}
+	for _, r := range rules.include {
+		res.Rules = append(res.Rules, &protocol.MetadataRuleDryRun{Kind: "include", Rule: r.String()})
+	}
+
+	kept := make(map[api.RepoName]struct{}, len(all))
+	for _, repo := range all {
+		if m, ok := repoMetadataOf(repo.Metadata); ok {
+			for i, r := range rules.all() {
+				if r.matches(m) {
+					res.Rules[i].Matched = append(res.Rules[i].Matched, repo.Name)
+				}
+			}
+		}
+
+		if !rules.excludes(repo) {
+			kept[repo.Name] = struct{}{}
+		}
+	}
+
+	current := make(map[api.RepoName]struct{}, len(synced))
+	for _, name := range synced {
+		current[name] = struct{}{}
+		if _, ok := kept[name]; !ok {
+			res.Removed = append(res.Removed, name)
+		}
+	}
+	for name := range kept {
+		if _, ok := current[name]; !ok {
+			res.Added = append(res.Added, name)
+		}
+	}
+
+	sort.Slice(res.Added, func(i, j int) bool { return res.Added[i] < res.Added[j] })
+	sort.Slice(res.Removed, func(i, j int) bool { return res.Removed[i] < res.Removed[j] })
+
+	return res, nil
+}
+
+// repoMetadata is the code host agnostic subset of repository metadata that
+// metadata rules are evaluated against. Zero values mean the code host didn't
+// report the field.
+type repoMetadata struct {
+	name      string
+	id        string
+	sizeBytes int64
+	pushedAt  time.Time
+	topics    []string
+	language  string
+	archived  bool
+	fork      bool
+}
+
+// repoMetadataOf extracts the repoMetadata from the value stored in
+// types.Repo.Metadata.
+func repoMetadataOf(metadata any) (*repoMetadata, bool) {
+	var m repoMetadata
+	switch r := metadata.(type) {
+	case *github.Repository:
+		m.name, m.id = r.NameWithOwner, r.ID
+		m.archived, m.fork = r.IsArchived, r.IsFork
+		m.sizeBytes = int64(r.DiskUsage) * 1024
+		if r.PushedAt != nil {
+			m.pushedAt = *r.PushedAt
+		}
+		m.topics = r.TopicNames()
+		m.language = r.PrimaryLanguageName()
+
+	case *gitlab.Project:
+		m.name, m.id = r.PathWithNamespace, strconv.Itoa(r.ID)
+		if r.Statistics != nil {
+			m.sizeBytes = r.Statistics.RepositorySize
+		}
+		if r.LastCommitAt != nil {
+			m.pushedAt = *r.LastCommitAt
+		}
+		m.topics = r.Topics
+
+	case *bitbucketcloud.Repo:
+		m.name, m.id = r.FullName, r.UUID
+		m.sizeBytes = r.Size
+		if r.LastCommitAt != nil {
+			m.pushedAt = *r.LastCommitAt
+		}
+		m.language = r.Language
+
+	default:
+		return nil, false
+	}
+	return &m, true
+}
+
+func lowerSet(vs []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(vs))
+	for _, v := range vs {
+		set[strings.ToLower(v)] = struct{}{}
+	}
+	return set
+}
+
+func containsAny(set map[string]struct{}, vs []string) bool {
+	for _, v := range vs {
+		if _, ok := set[strings.ToLower(v)]; ok {
+			return true
+		}
+	}
+	return false
+}
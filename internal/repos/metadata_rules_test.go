@@ -0,0 +1,250 @@
+package repos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestMetadataRules_Excludes(t *testing.T) {
+	pushed := func(v string) *time.Time {
+		ts, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &ts
+	}
+
+	giantAbandoned := &types.Repo{Name: "github.com/org/giant", Metadata: &github.Repository{
+		NameWithOwner: "org/giant",
+		DiskUsage:     6000 * 1024,
+		PushedAt:      pushed("2018-06-01"),
+	}}
+	deprecated := &types.Repo{Name: "github.com/org/deprecated", Metadata: &github.Repository{
+		NameWithOwner:    "org/deprecated",
+		DiskUsage:        10,
+		PushedAt:         pushed("2021-06-01"),
+		PrimaryLanguage:  &github.Language{Name: "Go"},
+		RepositoryTopics: &github.RepositoryTopics{Nodes: []github.RepositoryTopic{{Topic: github.Topic{Name: "Deprecated"}}}},
+	}}
+	active := &types.Repo{Name: "gitlab.com/org/active", Metadata: &gitlab.Project{
+		ProjectCommon: gitlab.ProjectCommon{ID: 42, PathWithNamespace: "org/active"},
+		Topics:        []string{"backend"},
+		// Recent activity, such as new issues, isn't a push.
+		LastActivityAt: pushed("2022-01-01"),
+		LastCommitAt:   pushed("2018-01-01"),
+		Statistics:     &gitlab.ProjectStatistics{RepositorySize: 20 * 1024 * 1024},
+	}}
+	cobol := &types.Repo{Name: "bitbucket.org/org/cobol", Metadata: &bitbucketcloud.Repo{
+		FullName:     "org/cobol",
+		Size:         1024,
+		Language:     "cobol",
+		UpdatedOn:    pushed("2018-01-01"),
+		LastCommitAt: pushed("2022-01-01"),
+	}}
+	unknown := &types.Repo{Name: "example.com/org/unknown"}
+
+	for _, tc := range []struct {
+		name     string
+		exclude  []metadataRuleConfig
+		include  []metadataRuleConfig
+		excluded []*types.Repo
+	}{
+		{
+			name: "no rules",
+		},
+		{
+			name:     "size",
+			exclude:  []metadataRuleConfig{{SizeOverMB: 5000}},
+			excluded: []*types.Repo{giantAbandoned},
+		},
+		{
+			name:     "pushed before",
+			exclude:  []metadataRuleConfig{{PushedBefore: "2019-01-01"}},
+			excluded: []*types.Repo{giantAbandoned, active},
+		},
+		{
+			name:     "name and metadata must both match",
+			exclude:  []metadataRuleConfig{{Names: []string{"org/active"}, PushedBefore: "2019-01-01"}, {Names: []string{"org/cobol"}, PushedBefore: "2019-01-01"}},
+			excluded: []*types.Repo{active},
+		},
+		{
+			name:     "id and metadata must both match",
+			exclude:  []metadataRuleConfig{{Names: []string{"", "42"}, Topics: []string{"backend"}}},
+			excluded: []*types.Repo{active},
+		},
+		{
+			name:     "pattern and metadata must both match",
+			exclude:  []metadataRuleConfig{{Pattern: "^org/(giant|deprecated)$", SizeOverMB: 1}},
+			excluded: []*types.Repo{giantAbandoned},
+		},
+		{
+			name:     "topics are case-insensitive",
+			exclude:  []metadataRuleConfig{{Topics: []string{"deprecated"}}},
+			excluded: []*types.Repo{deprecated},
+		},
+		{
+			name:     "languages are case-insensitive",
+			exclude:  []metadataRuleConfig{{Languages: []string{"COBOL"}}},
+			excluded: []*types.Repo{cobol},
+		},
+		{
+			name:     "all fields of a rule must match",
+			exclude:  []metadataRuleConfig{{SizeOverMB: 10, Topics: []string{"backend"}}, {SizeOverMB: 1000, PushedBefore: "2019-01-01"}},
+			excluded: []*types.Repo{giantAbandoned, active},
+		},
+		{
+			name:     "include",
+			include:  []metadataRuleConfig{{Topics: []string{"backend"}}, {Languages: []string{"go"}}},
+			excluded: []*types.Repo{giantAbandoned, cobol},
+		},
+		{
+			name:     "exclude takes precedence over include",
+			exclude:  []metadataRuleConfig{{Topics: []string{"deprecated"}}},
+			include:  []metadataRuleConfig{{Languages: []string{"go"}}},
+			excluded: []*types.Repo{giantAbandoned, deprecated, active, cobol},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var b metadataRulesBuilder
+			for _, c := range tc.exclude {
+				b.Exclude(c)
+			}
+			for _, c := range tc.include {
+				b.Include(c)
+			}
+			rules, err := b.Build()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var excluded []*types.Repo
+			for _, r := range []*types.Repo{giantAbandoned, deprecated, active, cobol, unknown} {
+				if rules.excludes(r) {
+					excluded = append(excluded, r)
+				}
+			}
+
+			if diff := cmp.Diff(tc.excluded, excluded); diff != "" {
+				t.Fatalf("excluded mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("metadata without names", func(t *testing.T) {
+		var b metadataRulesBuilder
+		if b.Exclude(metadataRuleConfig{Names: []string{"org/active"}}) {
+			t.Fatal("exclude item without metadata fields added as metadata rule")
+		}
+		if !b.Exclude(metadataRuleConfig{Names: []string{"org/active"}, Topics: []string{"backend"}}) {
+			t.Fatal("exclude item with metadata fields not added as metadata rule")
+		}
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		var b metadataRulesBuilder
+		b.Exclude(metadataRuleConfig{PushedBefore: "last year"})
+		if _, err := b.Build(); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+// fakeMetadataRulesSource is a FakeSource that applies metadata rules.
+type fakeMetadataRulesSource struct {
+	*FakeSource
+	rules *metadataRules
+}
+
+func (s fakeMetadataRulesSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	for _, r := range s.repos {
+		if !s.rules.excludes(r) {
+			results <- SourceResult{Source: s, Repo: r}
+		}
+	}
+}
+
+func (s fakeMetadataRulesSource) metadataRules() *metadataRules { return s.rules }
+
+func (s fakeMetadataRulesSource) withoutMetadataRules() Source {
+	s.rules = s.rules.withDryRun()
+	return s
+}
+
+func TestMetadataRulesDryRun(t *testing.T) {
+	big := &types.Repo{Name: "github.com/org/big", Metadata: &github.Repository{DiskUsage: 2 * 1024 * 1024}}
+	small := &types.Repo{Name: "github.com/org/small", Metadata: &github.Repository{DiskUsage: 1}}
+	tagged := &types.Repo{Name: "github.com/org/tagged", Metadata: &github.Repository{
+		DiskUsage:        1,
+		RepositoryTopics: &github.RepositoryTopics{Nodes: []github.RepositoryTopic{{Topic: github.Topic{Name: "keep"}}}},
+	}}
+
+	var b metadataRulesBuilder
+	b.Exclude(metadataRuleConfig{SizeOverMB: 1024})
+	b.Include(metadataRuleConfig{Topics: []string{"keep"}})
+	rules, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := fakeMetadataRulesSource{FakeSource: NewFakeSource(nil, nil, big, small, tagged), rules: rules}
+	have, err := MetadataRulesDryRun(context.Background(), src, []api.RepoName{big.Name, small.Name})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &protocol.MetadataRulesDryRunResult{
+		Rules: []*protocol.MetadataRuleDryRun{
+			{Kind: "exclude", Rule: "sizeOverMB: 1024", Matched: []api.RepoName{big.Name}},
+			{Kind: "include", Rule: "topics: [keep]", Matched: []api.RepoName{tagged.Name}},
+		},
+		Added:   []api.RepoName{tagged.Name},
+		Removed: []api.RepoName{big.Name, small.Name},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("result mismatch (-want +got):\n%s", diff)
+	}
+
+	t.Run("unsupported source", func(t *testing.T) {
+		if _, err := MetadataRulesDryRun(context.Background(), NewFakeSource(nil, nil), nil); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestMetadataRules_WithDryRun(t *testing.T) {
+	var b metadataRulesBuilder
+	b.Exclude(metadataRuleConfig{SizeOverMB: 1})
+	b.Include(metadataRuleConfig{PushedBefore: "2019-01-01"})
+	rules, err := b.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	big := &types.Repo{Name: "github.com/org/big", Metadata: &github.Repository{DiskUsage: 2 * 1024}}
+	if !rules.excludes(big) {
+		t.Fatal("repository not excluded")
+	}
+
+	// The metadata that the rules need must still be fetched in a dry run,
+	// but nothing is excluded.
+	dryRun := rules.withDryRun()
+	if !dryRun.needsSize() || !dryRun.needsPushedAt() {
+		t.Fatal("dry run doesn't fetch the metadata needed by the rules")
+	}
+	if dryRun.excludes(big) {
+		t.Fatal("repository excluded in dry run")
+	}
+	if !rules.excludes(big) {
+		t.Fatal("dry run modified the original rules")
+	}
+}
@@ -0,0 +1,64 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/packagist"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// NewPHPPackagesSource returns a new PHPPackagesSource from the given external service.
+func NewPHPPackagesSource(svc *types.ExternalService, cf *httpcli.Factory) (*DependenciesSource, error) {
+	var c schema.PHPPackagesConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	return &DependenciesSource{
+		svc:        svc,
+		configDeps: c.Dependencies,
+		scheme:     dependencies.PHPPackagesScheme,
+		src:        &phpPackagesSource{client: packagist.NewClient(svc.URN(), cli)},
+	}, nil
+}
+
+type phpPackagesSource struct {
+	client *packagist.Client
+}
+
+var _ dependenciesSource = &phpPackagesSource{}
+
+func (s *phpPackagesSource) Get(ctx context.Context, name, version string) (reposource.PackageDependency, error) {
+	dep := reposource.NewPHPDependency(name, version)
+
+	var err error
+	if version == "" {
+		_, err = s.client.Versions(ctx, name)
+	} else {
+		_, err = s.client.Version(ctx, name, version)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch Packagist metadata for %s", dep.PackageManagerSyntax())
+	}
+
+	return dep, nil
+}
+
+func (phpPackagesSource) ParseDependency(dep string) (reposource.PackageDependency, error) {
+	return reposource.ParsePHPDependency(dep)
+}
+
+func (phpPackagesSource) ParseDependencyFromRepoName(repoName string) (reposource.PackageDependency, error) {
+	return reposource.ParsePHPDependencyFromRepoName(repoName)
+}
@@ -0,0 +1,64 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/rubygems"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// NewRubyPackagesSource returns a new RubyPackagesSource from the given external service.
+func NewRubyPackagesSource(svc *types.ExternalService, cf *httpcli.Factory) (*DependenciesSource, error) {
+	var c schema.RubyPackagesConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	return &DependenciesSource{
+		svc:        svc,
+		configDeps: c.Dependencies,
+		scheme:     dependencies.RubyPackagesScheme,
+		src:        &rubyPackagesSource{client: rubygems.NewClient(svc.URN(), cli)},
+	}, nil
+}
+
+type rubyPackagesSource struct {
+	client *rubygems.Client
+}
+
+var _ dependenciesSource = &rubyPackagesSource{}
+
+func (s *rubyPackagesSource) Get(ctx context.Context, name, version string) (reposource.PackageDependency, error) {
+	dep := reposource.NewRubyDependency(name, version)
+
+	var err error
+	if version == "" {
+		_, err = s.client.GetGem(ctx, name)
+	} else {
+		_, err = s.client.GetVersion(ctx, name, version)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch gem metadata for %s", dep.PackageManagerSyntax())
+	}
+
+	return dep, nil
+}
+
+func (rubyPackagesSource) ParseDependency(dep string) (reposource.PackageDependency, error) {
+	return reposource.ParseRubyDependency(dep)
+}
+
+func (rubyPackagesSource) ParseDependencyFromRepoName(repoName string) (reposource.PackageDependency, error) {
+	return reposource.ParseRubyDependencyFromRepoName(repoName)
+}
@@ -0,0 +1,151 @@
+package repos
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/sourcegraph"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A SourcegraphSource yields the repositories of another Sourcegraph instance,
+// configured in Sourcegraph via the external services configuration. The
+// repositories are listed through the remote's GraphQL API and cloned from its
+// git smart-HTTP endpoint, so this instance doesn't need credentials for the
+// code hosts the remote instance mirrors.
+type SourcegraphSource struct {
+	svc       *types.ExternalService
+	config    *schema.SourcegraphConnection
+	exclude   excludeFunc
+	cli       *sourcegraph.Client
+	serviceID string
+	perPage   int
+}
+
+// NewSourcegraphSource returns a new SourcegraphSource from the given external
+// service.
+func NewSourcegraphSource(svc *types.ExternalService, cf *httpcli.Factory) (*SourcegraphSource, error) {
+	var c schema.SourcegraphConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	if cf == nil {
+		cf = httpcli.ExternalClientFactory
+	}
+
+	httpCli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		eb.Pattern(r.Pattern)
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := sourcegraph.NewClient(svc.URN(), &c, httpCli)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SourcegraphSource{
+		svc:       svc,
+		config:    &c,
+		exclude:   exclude,
+		cli:       cli,
+		serviceID: extsvc.NormalizeBaseURL(cli.URL).String(),
+		perPage:   100,
+	}, nil
+}
+
+// ListRepos returns all public repositories of the remote instance that match
+// the configured repository queries, or all of its public repositories if none
+// are configured.
+//
+// Private repositories are skipped: there is no authz provider for Sourcegraph
+// connections, so a mirrored private repository would be readable by every
+// user of this instance.
+func (s *SourcegraphSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	seen := make(map[string]struct{})
+	emit := func(r *sourcegraph.Repository) {
+		if _, ok := seen[r.ID]; ok || r.IsPrivate || s.exclude(r.Name) {
+			return
+		}
+		seen[r.ID] = struct{}{}
+		results <- SourceResult{Source: s, Repo: s.makeRepo(r)}
+	}
+
+	if len(s.config.RepositoryQuery) == 0 {
+		var cursor string
+		for {
+			repos, next, err := s.cli.ListRepositories(ctx, s.perPage, cursor)
+			if err != nil {
+				results <- SourceResult{Source: s, Err: err}
+				return
+			}
+
+			for _, r := range repos {
+				emit(r)
+			}
+
+			if next == "" {
+				return
+			}
+			cursor = next
+		}
+	}
+
+	for _, query := range s.config.RepositoryQuery {
+		repos, err := s.cli.SearchRepositories(ctx, query)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			continue
+		}
+
+		for _, r := range repos {
+			emit(r)
+		}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s *SourcegraphSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s *SourcegraphSource) makeRepo(r *sourcegraph.Repository) *types.Repo {
+	urn := s.svc.URN()
+	name := reposource.SourcegraphRepoName(s.config.RepositoryPathPattern, s.cli.URL.Hostname(), r.Name)
+
+	return &types.Repo{
+		Name:        name,
+		URI:         string(name),
+		Description: r.Description,
+		Fork:        r.IsFork,
+		Archived:    r.IsArchived,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          r.ID,
+			ServiceType: extsvc.TypeSourcegraph,
+			ServiceID:   s.serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: sourcegraphCloneURL(r, s.config),
+			},
+		},
+		Metadata: r,
+	}
+}
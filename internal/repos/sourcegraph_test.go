@@ -0,0 +1,140 @@
+package repos
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestSourcegraphSource_ListRepos(t *testing.T) {
+	repo := func(id, name string) map[string]any {
+		return map[string]any{"__typename": "Repository", "id": id, "name": name, "url": "/" + name}
+	}
+	private := func(r map[string]any) map[string]any {
+		r["isPrivate"] = true
+		return r
+	}
+
+	// A fake remote instance that serves the repositories in two pages, and
+	// answers search queries with a fixed set of results. The private
+	// repository it returns must never be mirrored.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/.api/graphql" || r.Header.Get("Authorization") != "token secret" {
+			http.Error(w, "unexpected request", http.StatusUnauthorized)
+			return
+		}
+
+		var req struct {
+			Query     string
+			Variables map[string]any
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+
+		var data any
+		switch {
+		case strings.Contains(req.Query, "query ListRepositories"):
+			if req.Variables["after"] == nil {
+				data = map[string]any{"repositories": map[string]any{
+					"nodes":    []any{repo("1", "github.com/foo/a"), repo("2", "github.com/foo/b")},
+					"pageInfo": map[string]any{"endCursor": "2", "hasNextPage": true},
+				}}
+			} else {
+				data = map[string]any{"repositories": map[string]any{
+					"nodes":    []any{repo("3", "gitlab.com/bar/c"), private(repo("4", "gitlab.com/bar/secret"))},
+					"pageInfo": map[string]any{"endCursor": "3", "hasNextPage": false},
+				}}
+			}
+		case strings.Contains(req.Query, "query SearchRepositories"):
+			results := []any{repo("1", "github.com/foo/a"), private(repo("4", "gitlab.com/bar/secret"))}
+			if strings.HasPrefix(req.Variables["query"].(string), "repo:^gitlab") {
+				results = []any{repo("3", "gitlab.com/bar/c"), repo("1", "github.com/foo/a")}
+			}
+			data = map[string]any{"search": map[string]any{"results": map[string]any{"results": results}}}
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer srv.Close()
+
+	for _, tc := range []struct {
+		name string
+		conf *schema.SourcegraphConnection
+		want []api.RepoName
+	}{
+		{
+			name: "all repositories",
+			conf: &schema.SourcegraphConnection{},
+			want: []api.RepoName{"github.com/foo/a", "github.com/foo/b", "gitlab.com/bar/c"},
+		},
+		{
+			name: "repository queries are deduplicated",
+			conf: &schema.SourcegraphConnection{
+				RepositoryQuery: []string{"repo:^github", "repo:^gitlab"},
+			},
+			want: []api.RepoName{"github.com/foo/a", "gitlab.com/bar/c"},
+		},
+		{
+			name: "exclude and repositoryPathPattern",
+			conf: &schema.SourcegraphConnection{
+				Exclude:               []*schema.ExcludedSourcegraphRepo{{Pattern: "^github\\.com/foo/b$"}},
+				RepositoryPathPattern: "remote/{name}",
+			},
+			want: []api.RepoName{"remote/github.com/foo/a", "remote/gitlab.com/bar/c"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.conf.Url = srv.URL
+			tc.conf.Token = "secret"
+			tc.conf.GitURL = "http://frontend-internal:3090/.internal/git"
+
+			svc := &types.ExternalService{
+				ID:     1,
+				Kind:   extsvc.KindSourcegraph,
+				Config: marshalJSON(t, tc.conf),
+			}
+
+			src, err := NewSourcegraphSource(svc, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			src.perPage = 2
+
+			repos, err := listAll(context.Background(), src)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var have []api.RepoName
+			for _, r := range repos {
+				have = append(have, r.Name)
+			}
+			sort.Slice(have, func(i, j int) bool { return have[i] < have[j] })
+
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("repos mismatch (-want +have):\n%s", diff)
+			}
+
+			// Repositories are cloned by their remote name, regardless of
+			// the repositoryPathPattern.
+			for _, r := range repos {
+				cloneURL := r.Sources[svc.URN()].CloneURL
+				if !strings.HasPrefix(cloneURL, tc.conf.GitURL+"/") || !strings.HasSuffix(string(r.Name), strings.TrimPrefix(cloneURL, tc.conf.GitURL+"/")) {
+					t.Errorf("unexpected clone URL %q for %q", cloneURL, r.Name)
+				}
+			}
+		})
+	}
+}
@@ -68,12 +68,18 @@ func NewSource(ctx context.Context, db database.DB, svc *types.ExternalService,
 		return NewJVMPackagesSource(svc)
 	case extsvc.KindPagure:
 		return NewPagureSource(svc, cf)
+	case extsvc.KindSourcegraph:
+		return NewSourcegraphSource(svc, cf)
 	case extsvc.KindNpmPackages:
 		return NewNpmPackagesSource(svc, cf)
 	case extsvc.KindPythonPackages:
 		return NewPythonPackagesSource(svc, cf)
 	case extsvc.KindRustPackages:
 		return NewRustPackagesSource(svc, cf)
+	case extsvc.KindRubyPackages:
+		return NewRubyPackagesSource(svc, cf)
+	case extsvc.KindPHPPackages:
+		return NewPHPPackagesSource(svc, cf)
 	case extsvc.KindOther:
 		return NewOtherSource(svc, cf)
 	default:
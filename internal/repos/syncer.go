@@ -719,6 +719,7 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 		stored = types.Repos{existing}
 		fallthrough
 	case 1: // Existing repo, update.
+		oldName, wasDeleted := stored[0].Name, !stored[0].DeletedAt.IsZero()
 		if !stored[0].Update(sourced) {
 			d.Unmodified = append(d.Unmodified, stored[0])
 			break
@@ -728,6 +729,15 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 			return Diff{}, errors.Wrap(err, "syncer: failed to update external service repo")
 		}
 
+		// The repo was renamed or transferred on the code host. Remember its
+		// old name so that URLs and queries referencing it keep working.
+		// Soft-deleted repos have a generated name that nobody refers to.
+		if !wasDeleted && oldName != stored[0].Name {
+			if err = tx.RepoStore().CreateRedirect(ctx, oldName, stored[0].ID); err != nil {
+				return Diff{}, errors.Wrap(err, "syncer: failed to create repo redirect")
+			}
+		}
+
 		*sourced = *stored[0]
 		d.Modified = append(d.Modified, stored[0])
 	case 0: // New repo, create.
@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
@@ -1756,6 +1757,72 @@ func testNameOnConflictOnRename(store repos.Store) func(*testing.T) {
 	}
 }
 
+func testRenameCreatesRedirect(store repos.Store) func(*testing.T) {
+	return func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		now := time.Now()
+
+		svc := &types.ExternalService{
+			Kind:        extsvc.KindGitHub,
+			DisplayName: "Github - Test",
+			Config:      `{"url": "https://github.com"}`,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if err := store.ExternalServiceStore().Upsert(ctx, svc); err != nil {
+			t.Fatal(err)
+		}
+
+		githubRepo := &types.Repo{
+			Name:     "github.com/org/foo",
+			Metadata: &github.Repository{},
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          "foo-external-foo",
+				ServiceID:   "https://github.com/",
+				ServiceType: extsvc.TypeGitHub,
+			},
+		}
+
+		sync := func(r *types.Repo) {
+			t.Helper()
+			syncer := &repos.Syncer{
+				Logger: logtest.Scoped(t),
+				Sourcer: func(context.Context, *types.ExternalService) (repos.Source, error) {
+					return repos.NewFakeSource(svc, nil, r), nil
+				},
+				Store: store,
+				Now:   time.Now,
+			}
+			if err := syncer.SyncExternalService(ctx, svc.ID, 10*time.Second); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		sync(githubRepo)
+		if _, err := store.RepoStore().GetByRedirect(ctx, githubRepo.Name); !errcode.IsNotFound(err) {
+			t.Fatalf("expected no redirect before rename, got %v", err)
+		}
+
+		// Transfer the repo to another org, then rename it.
+		transferred := githubRepo.With(func(r *types.Repo) { r.Name = "github.com/other/foo" })
+		sync(transferred)
+		renamed := githubRepo.With(func(r *types.Repo) { r.Name = "github.com/other/bar" })
+		sync(renamed)
+
+		for _, name := range []api.RepoName{githubRepo.Name, transferred.Name} {
+			repo, err := store.RepoStore().GetByRedirect(ctx, name)
+			if err != nil {
+				t.Fatalf("GetByRedirect(%q): %v", name, err)
+			}
+			if repo.Name != renamed.Name {
+				t.Fatalf("GetByRedirect(%q): want %q, got %q", name, renamed.Name, repo.Name)
+			}
+		}
+	}
+}
+
 func testDeleteExternalService(store repos.Store) func(*testing.T) {
 	return func(t *testing.T) {
 		ctx, cancel := context.WithCancel(context.Background())
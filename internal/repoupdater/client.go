@@ -270,6 +270,39 @@ func (c *Client) SyncExternalService(
 	return &result, nil
 }
 
+// MetadataRulesDryRun requests the metadata exclude and include rules of the
+// given external service's configuration to be evaluated without syncing it.
+func (c *Client) MetadataRulesDryRun(
+	ctx context.Context,
+	svc api.ExternalService,
+) (*protocol.MetadataRulesDryRunResult, error) {
+	req := &protocol.MetadataRulesDryRunRequest{ExternalService: svc}
+	resp, err := c.httpPost(ctx, "metadata-rules-dry-run", req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response body")
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.New(string(bs))
+	}
+
+	var result protocol.MetadataRulesDryRunResult
+	if err = json.Unmarshal(bs, &result); err != nil {
+		return nil, err
+	}
+
+	if result.Error != "" {
+		return nil, errors.New(result.Error)
+	}
+	return &result, nil
+}
+
 // RepoExternalServices requests the external services associated with a
 // repository with the given id.
 func (c *Client) RepoExternalServices(ctx context.Context, id api.RepoID) ([]api.ExternalService, error) {
@@ -287,3 +287,38 @@ type ExternalServiceSyncResult struct {
 	ExternalService api.ExternalService
 	Error           string
 }
+
+// MetadataRulesDryRunRequest is a request to evaluate the metadata exclude and
+// include rules of an external service's configuration without syncing it.
+//
+// The configuration of the given external service is used as is, so it may
+// differ from the one currently stored for it.
+type MetadataRulesDryRunRequest struct {
+	ExternalService api.ExternalService
+}
+
+// MetadataRulesDryRunResult is the result of a MetadataRulesDryRunRequest.
+type MetadataRulesDryRunResult struct {
+	// Rules contains one entry per configured metadata rule, with exclude rules
+	// listed before include rules.
+	Rules []*MetadataRuleDryRun
+	// Added are the repositories that would be synced with the configuration
+	// but aren't synced by the external service yet.
+	Added []api.RepoName
+	// Removed are the repositories synced by the external service that would
+	// no longer be synced with the configuration.
+	Removed []api.RepoName
+	Error   string
+}
+
+// MetadataRuleDryRun describes the repositories matched by a single metadata
+// rule.
+type MetadataRuleDryRun struct {
+	// Kind is either "exclude" or "include".
+	Kind string
+	// Rule is a human readable description of the rule.
+	Rule string
+	// Matched are the repositories matched by the rule. Matched repositories
+	// are removed by exclude rules and kept by include rules.
+	Matched []api.RepoName
+}
@@ -2,11 +2,13 @@ package search
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 	"unicode"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -221,6 +223,31 @@ func AlertForMissingDependencyRepoRevs(missingRepoRevs []*RepositoryRevisions) *
 	}
 }
 
+func AlertForRenamedRepos(renamed map[api.RepoName]api.RepoName) *Alert {
+	oldNames := make([]string, 0, len(renamed))
+	for oldName := range renamed {
+		oldNames = append(oldNames, string(oldName))
+	}
+	sort.Strings(oldNames)
+
+	var description string
+	if len(oldNames) == 1 {
+		description = fmt.Sprintf("The repository %s matched by your repo: filter has been renamed to %s. Showing results for %s instead.", oldNames[0], renamed[api.RepoName(oldNames[0])], renamed[api.RepoName(oldNames[0])])
+	} else {
+		b := strings.Builder{}
+		b.WriteString("The following repositories matched by your repo: filters have been renamed. Showing results for their new names instead:")
+		for _, oldName := range oldNames {
+			_, _ = fmt.Fprintf(&b, "\n* %s → %s", oldName, renamed[api.RepoName(oldName)])
+		}
+		description = b.String()
+	}
+	return &Alert{
+		PrometheusType: "renamed_repos",
+		Title:          "Repository renamed",
+		Description:    description + "\n\nUpdate your query to use the new name.",
+	}
+}
+
 func AlertForInvalidRevision(revision string) *Alert {
 	revision = strings.TrimSuffix(revision, "^0")
 	return &Alert{
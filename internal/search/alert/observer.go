@@ -241,6 +241,7 @@ func (o *Observer) errorToAlert(ctx context.Context, err error) (*search.Alert,
 
 	var (
 		mErr *searchrepos.MissingRepoRevsError
+		rErr *searchrepos.RenamedReposError
 		oErr *errOverRepoLimit
 	)
 
@@ -280,6 +281,12 @@ func (o *Observer) errorToAlert(ctx context.Context, err error) (*search.Alert,
 		return a, nil
 	}
 
+	if errors.As(err, &rErr) {
+		a := search.AlertForRenamedRepos(rErr.Renamed)
+		a.Priority = 1
+		return a, nil
+	}
+
 	if strings.Contains(err.Error(), "Worker_oomed") || strings.Contains(err.Error(), "Worker_exited_abnormally") {
 		return &search.Alert{
 			PrometheusType: "structural_search_needs_more_memory",
@@ -338,6 +345,11 @@ func needsPackageHostConfiguration(ctx context.Context, db database.DB) (bool, e
 		Kinds: []string{
 			extsvc.KindNpmPackages,
 			extsvc.KindGoModules,
+			extsvc.KindPythonPackages,
+			extsvc.KindJVMPackages,
+			extsvc.KindRustPackages,
+			extsvc.KindRubyPackages,
+			extsvc.KindPHPPackages,
 		},
 	})
 	if err != nil {
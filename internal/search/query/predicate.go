@@ -2,6 +2,7 @@ package query
 
 import (
 	"regexp/syntax" //nolint:depguard
+	"strconv"
 	"strings"
 
 	"github.com/grafana/regexp"
@@ -38,8 +39,8 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"contains.commit.after": func() Predicate { return &RepoContainsCommitAfterPredicate{} },
 		"dependencies":          func() Predicate { return &RepoDependenciesPredicate{} },
 		"deps":                  func() Predicate { return &RepoDependenciesPredicate{} },
-		"dependents":            func() Predicate { return &RepoDependenciesPredicate{} },
-		"revdeps":               func() Predicate { return &RepoDependenciesPredicate{} },
+		"dependents":            func() Predicate { return &RepoDependentsPredicate{} },
+		"revdeps":               func() Predicate { return &RepoDependentsPredicate{} },
 	},
 	FieldFile: {
 		"contains.content": func() Predicate { return &FileContainsContentPredicate{} },
@@ -272,24 +273,17 @@ func (f *RepoContainsCommitAfterPredicate) Plan(parent Basic) (Plan, error) {
 
 // RepoDependenciesPredicate represents the `repo:dependencies(regex@rev)` predicate,
 // which filters to repos that are dependencies of the repos matching the given of regex.
-type RepoDependenciesPredicate struct{}
+// The optional `depth:N` argument, as in `repo:dependencies(regex@rev depth:2)`, limits
+// the dependencies to the ones at most N edges away in the dependency graph of the
+// matching repos. Direct dependencies have a depth of one.
+type RepoDependenciesPredicate struct {
+	RepoRev string
+	Depth   int
+}
 
 func (f *RepoDependenciesPredicate) ParseParams(params string) (err error) {
-	re := params
-	if n := strings.LastIndex(params, "@"); n > 0 {
-		re = re[:n]
-	}
-
-	if re == "" {
-		return errors.Errorf("empty repo:dependencies predicate parameter %q", params)
-	}
-
-	_, err = syntax.Parse(re, syntax.ClassNL|syntax.PerlX|syntax.UnicodeGroups)
-	if err != nil {
-		return errors.Errorf("invalid repo:dependencies predicate parameter %q: %v", params, err)
-	}
-
-	return nil
+	f.RepoRev, f.Depth, err = parseDependencyPredicateParams(f.Name(), params)
+	return err
 }
 
 func (f *RepoDependenciesPredicate) Field() string { return FieldRepo }
@@ -300,31 +294,56 @@ func (f *RepoDependenciesPredicate) Plan(parent Basic) (Plan, error) {
 
 // RepoDependentsPredicate represents the `repo:dependents(regex@rev)`
 // predicate, which filters to repos that depend on the repos matching the
-// given of regex.
-type RepoDependentsPredicate struct{}
+// given of regex. The optional `depth:N` argument, as in
+// `repo:dependents(regex@rev depth:2)`, limits the dependents to the ones
+// that reach the matching repos in at most N edges of their dependency graph.
+type RepoDependentsPredicate struct {
+	RepoRev string
+	Depth   int
+}
 
 func (f *RepoDependentsPredicate) ParseParams(params string) (err error) {
-	re := params
-	if n := strings.LastIndex(params, "@"); n > 0 {
+	f.RepoRev, f.Depth, err = parseDependencyPredicateParams(f.Name(), params)
+	return err
+}
+
+func (f *RepoDependentsPredicate) Field() string { return FieldRepo }
+func (f *RepoDependentsPredicate) Name() string  { return "dependents" }
+func (f *RepoDependentsPredicate) Plan(parent Basic) (Plan, error) {
+	return nil, nil
+}
+
+// parseDependencyPredicateParams parses the `regex@rev` parameter of the
+// repo:dependencies and repo:dependents predicates, optionally followed by a
+// `depth:N` argument. A depth of zero is returned if no depth was given.
+func parseDependencyPredicateParams(name, params string) (repoRev string, depth int, err error) {
+	repoRev = strings.TrimSpace(params)
+	if i := strings.LastIndexAny(repoRev, " \t"); i != -1 {
+		if last := repoRev[i+1:]; strings.HasPrefix(last, "depth:") {
+			value := strings.TrimPrefix(last, "depth:")
+			depth, err = strconv.Atoi(value)
+			if err != nil || depth < 1 {
+				return "", 0, errors.Errorf("invalid repo:%s predicate depth %q: expected a positive integer", name, value)
+			}
+			repoRev = strings.TrimSpace(repoRev[:i])
+		}
+	}
+
+	re := repoRev
+	if n := strings.LastIndex(repoRev, "@"); n > 0 {
 		re = re[:n]
 	}
 
 	if re == "" {
-		return errors.Errorf("empty repo:dependents predicate parameter %q", params)
+		return "", 0, errors.Errorf("empty repo:%s predicate parameter %q", name, params)
 	}
 
 	_, err = syntax.Parse(re, syntax.ClassNL|syntax.PerlX|syntax.UnicodeGroups)
 	if err != nil {
-		return errors.Errorf("invalid repo:dependents predicate parameter %q: %v", params, err)
+		return "", 0, errors.Errorf("invalid repo:%s predicate parameter %q: %v", name, params, err)
 	}
 
-	return nil
-}
-
-func (f *RepoDependentsPredicate) Field() string { return FieldRepo }
-func (f *RepoDependentsPredicate) Name() string  { return "dependents" }
-func (f *RepoDependentsPredicate) Plan(parent Basic) (Plan, error) {
-	return nil, nil
+	return repoRev, depth, nil
 }
 
 /* repo:contains.content(pattern) */
@@ -90,8 +90,10 @@ func TestRepoDependenciesPredicate(t *testing.T) {
 		}
 
 		valid := []test{
-			{`literal`, `test`, &RepoDependenciesPredicate{}},
-			{`regex with revs`, `^npm/@bar:baz`, &RepoDependenciesPredicate{}},
+			{`literal`, `test`, &RepoDependenciesPredicate{RepoRev: "test"}},
+			{`regex with revs`, `^npm/@bar:baz`, &RepoDependenciesPredicate{RepoRev: "^npm/@bar:baz"}},
+			{`depth`, `^github\.com/foo/bar$@main depth:2`, &RepoDependenciesPredicate{RepoRev: `^github\.com/foo/bar$@main`, Depth: 2}},
+			{`depth without rev`, `  foo   depth:1 `, &RepoDependenciesPredicate{RepoRev: "foo", Depth: 1}},
 		}
 
 		for _, tc := range valid {
@@ -111,6 +113,9 @@ func TestRepoDependenciesPredicate(t *testing.T) {
 		invalid := []test{
 			{`empty`, ``, nil},
 			{`catch invalid regexp`, `([)`, nil},
+			{`empty depth`, `foo depth:`, nil},
+			{`zero depth`, `foo depth:0`, nil},
+			{`invalid depth`, `foo depth:two`, nil},
 		}
 
 		for _, tc := range invalid {
@@ -134,9 +139,10 @@ func TestRepoDependentsPredicate(t *testing.T) {
 		}
 
 		valid := []test{
-			{`literal`, `test`, &RepoDependentsPredicate{}},
-			{`regex with revs`, `^npm/@bar:baz`, &RepoDependentsPredicate{}},
-			{`regex with single rev`, `^npm/foobar$@2.3.4`, &RepoDependentsPredicate{}},
+			{`literal`, `test`, &RepoDependentsPredicate{RepoRev: "test"}},
+			{`regex with revs`, `^npm/@bar:baz`, &RepoDependentsPredicate{RepoRev: "^npm/@bar:baz"}},
+			{`regex with single rev`, `^npm/foobar$@2.3.4`, &RepoDependentsPredicate{RepoRev: "^npm/foobar$@2.3.4"}},
+			{`depth`, `^maven/org\.apache\.logging\.log4j/log4j-core$@v2.14.1 depth:3`, &RepoDependentsPredicate{RepoRev: `^maven/org\.apache\.logging\.log4j/log4j-core$@v2.14.1`, Depth: 3}},
 		}
 
 		for _, tc := range valid {
@@ -156,6 +162,7 @@ func TestRepoDependentsPredicate(t *testing.T) {
 		invalid := []test{
 			{`empty`, ``, nil},
 			{`catch invalid regexp`, `([)`, nil},
+			{`negative depth`, `foo depth:-1`, nil},
 		}
 
 		for _, tc := range invalid {
@@ -20,9 +20,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	livedependencies "github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/live"
+	codeintelshared "github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
 	codeintelTypes "github.com/sourcegraph/sourcegraph/internal/codeintel/types"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search"
@@ -44,6 +46,28 @@ type Resolved struct {
 	// Next points to the next page of resolved repository revisions. It will
 	// be nil if there are no more pages left.
 	Next types.MultiCursor
+
+	// DependencyPaths holds the shortest dependency path leading to the repository
+	// revisions resolved from repo:dependencies and repo:dependents predicates with
+	// a depth argument. It is keyed by repository name and then by revspec.
+	DependencyPaths DependencyPaths
+}
+
+// DependencyPaths maps repository names and revspecs to a dependency path, which lists
+// the "repo@rev" nodes from a dependent repository to one of its (transitive) dependencies.
+type DependencyPaths map[api.RepoName]map[string][]string
+
+func (p DependencyPaths) add(repoName api.RepoName, rev string, path []string) {
+	revs, ok := p[repoName]
+	if !ok {
+		revs = map[string][]string{}
+		p[repoName] = revs
+	}
+
+	// Keep the shortest path
+	if existing, ok := revs[rev]; !ok || len(path) < len(existing) {
+		revs[rev] = path
+	}
 }
 
 func (r *Resolved) String() string {
@@ -80,7 +104,7 @@ func (r *Resolver) Paginate(ctx context.Context, handle func(*Resolved) error) (
 		page, err := r.Resolve(ctx, opts)
 		if err != nil {
 			errs = errors.Append(errs, err)
-			if !errors.Is(err, &MissingRepoRevsError{}) { // Non-fatal errors
+			if !errors.IsAny(err, &MissingRepoRevsError{}, &RenamedReposError{}) { // Non-fatal errors
 				break
 			}
 		}
@@ -102,6 +126,28 @@ func (r *Resolver) Paginate(ctx context.Context, handle func(*Resolved) error) (
 }
 
 func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (Resolved, error) {
+	res, err := r.resolve(ctx, op)
+	if len(res.RepoRevs) > 0 || len(op.Cursors) > 0 || (err != nil && !errors.Is(err, ErrNoResolvedRepos)) {
+		return res, err
+	}
+
+	// Nothing matched. Only now do we check whether repo: filters refer to
+	// renamed repositories, so that searches by current names never pay for
+	// the extra lookups.
+	repoFilters, renamed := r.resolveRenamedRepos(ctx, op.RepoFilters)
+	if len(renamed) == 0 {
+		return res, err
+	}
+
+	op.RepoFilters = repoFilters
+	res, err = r.resolve(ctx, op)
+	if err != nil && !errors.IsAny(err, ErrNoResolvedRepos, &MissingRepoRevsError{}) {
+		return res, err
+	}
+	return res, errors.Append(err, &RenamedReposError{Renamed: renamed})
+}
+
+func (r *Resolver) resolve(ctx context.Context, op search.RepoOptions) (Resolved, error) {
 	var err error
 	tr, ctx := trace.New(ctx, "searchrepos.Resolve", op.String())
 	defer func() {
@@ -123,10 +169,11 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (Resolved
 	var (
 		dependencyNames []string
 		dependencyRevs  = map[api.RepoName][]search.RevisionSpecifier{}
+		dependencyPaths = DependencyPaths{}
 	)
 
 	if len(op.Dependencies) > 0 {
-		depNames, depRevs, err := r.dependencies(ctx, &op)
+		depNames, depRevs, err := r.dependencies(ctx, &op, dependencyPaths)
 		if err != nil {
 			return Resolved{}, err
 		}
@@ -142,7 +189,7 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (Resolved
 	}
 
 	if len(op.Dependents) > 0 {
-		revDepNames, revDepRevs, err := r.dependents(ctx, &op)
+		revDepNames, revDepRevs, err := r.dependents(ctx, &op, dependencyPaths)
 		if err != nil {
 			return Resolved{}, err
 		}
@@ -264,6 +311,9 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (Resolved
 		RepoRevs: make([]*search.RepositoryRevisions, len(repos)),
 		Next:     next,
 	}
+	if len(dependencyPaths) > 0 {
+		res.DependencyPaths = dependencyPaths
+	}
 
 	sem := semaphore.NewWeighted(128)
 	g, ctx := errgroup.WithContext(ctx)
@@ -417,6 +467,47 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (Resolved
 	return res.Resolved, err
 }
 
+// resolveRenamedRepos rewrites repo: filters that match a single repository by
+// a name it no longer has, because it was renamed or transferred on its code
+// host, to match the current name of the repository. It returns the rewritten
+// filters and a map of old to new names for every filter it rewrote.
+func (r *Resolver) resolveRenamedRepos(ctx context.Context, repoFilters []string) ([]string, map[api.RepoName]api.RepoName) {
+	var renamed map[api.RepoName]api.RepoName
+	rewritten := make([]string, 0, len(repoFilters))
+	for _, filter := range repoFilters {
+		name, ok := exactRepoName(filter)
+		if !ok {
+			rewritten = append(rewritten, filter)
+			continue
+		}
+
+		// This is best effort: on any error we search with the original
+		// filter, which surfaces problems with it the usual way.
+		repo, err := r.DB.Repos().GetByRedirect(ctx, name)
+		if err != nil {
+			rewritten = append(rewritten, filter)
+			continue
+		}
+		// A repository that currently has the name always takes precedence.
+		if _, err := r.DB.Repos().GetByName(ctx, name); !errcode.IsNotFound(err) {
+			rewritten = append(rewritten, filter)
+			continue
+		}
+
+		var revs string
+		if i := strings.Index(filter, "@"); i != -1 {
+			revs = filter[i:]
+		}
+		rewritten = append(rewritten, "^"+regexp.QuoteMeta(string(repo.Name))+"$"+revs)
+
+		if renamed == nil {
+			renamed = make(map[api.RepoName]api.RepoName)
+		}
+		renamed[name] = repo.Name
+	}
+	return rewritten, renamed
+}
+
 // computeExcludedRepos computes the ExcludedRepos that the given RepoOptions would not match. This is
 // used to show in the search UI what repos are excluded precisely.
 func computeExcludedRepos(ctx context.Context, db database.DB, op search.RepoOptions) (ex ExcludedRepos, err error) {
@@ -520,11 +611,12 @@ func computeExcludedRepos(ctx context.Context, db database.DB, op search.RepoOpt
 // dependencies resolves `repo:dependencies` predicates to a specific list of
 // dependency repositories for the given repos and revision(s). It does so by:
 //
-// 1. Expanding each `repo:dependencies(regex@revA:revB:...)` filter regex to a list of repositories that exist in the DB.
-// 2. For each of those (repo, rev) tuple, asking the code intelligence dependency API for their (transitive) dependencies.
-//    Calling this API also has the effect of triggering a sync of all discovered dependency repos.
-// 3. Return those dependencies to the caller to be included in repository resolution.
-func (r *Resolver) dependencies(ctx context.Context, op *search.RepoOptions) (_ []string, _ map[api.RepoName][]search.RevisionSpecifier, err error) {
+//  1. Expanding each `repo:dependencies(regex@revA:revB:... depth:N)` filter regex to a list of repositories that exist in the DB.
+//  2. For each of those (repo, rev) tuple, asking the code intelligence dependency API for their (transitive) dependencies,
+//     up to the given depth if any. Calling this API also has the effect of triggering a sync of all discovered dependency repos.
+//  3. Return those dependencies to the caller to be included in repository resolution. The paths leading to the dependencies
+//     of predicates with a depth argument are added to the given paths.
+func (r *Resolver) dependencies(ctx context.Context, op *search.RepoOptions, paths DependencyPaths) (_ []string, _ map[api.RepoName][]search.RevisionSpecifier, err error) {
 	tr, ctx := trace.New(ctx, "searchrepos.dependencies", "")
 	defer func() {
 		tr.LazyPrintf("deps: %v", op.Dependencies)
@@ -536,20 +628,106 @@ func (r *Resolver) dependencies(ctx context.Context, op *search.RepoOptions) (_
 		return nil, nil, errors.Errorf("support for `repo:dependencies()` is disabled in site config (`experimentalFeatures.dependenciesSearch`)")
 	}
 
-	repoRevs, err := listDependencyRepos(ctx, r.DB.Repos(), op.Dependencies, op.CaseSensitiveRepoFilters)
-	if err != nil {
-		return nil, nil, err
+	svc := livedependencies.GetService(r.DB, livedependencies.NewSyncer())
+
+	return r.resolveDependencyPredicates(ctx, op, op.Dependencies, func() query.Predicate { return &query.RepoDependenciesPredicate{} }, func(repoRevs map[api.RepoName]codeintelTypes.RevSpecSet, depth int) (map[api.RepoName]codeintelTypes.RevSpecSet, error) {
+		if depth == 0 {
+			return svc.Dependencies(ctx, repoRevs)
+		}
+
+		dependencyRepoRevs, dependencyPaths, err := svc.TransitiveDependencies(ctx, repoRevs, depth)
+		for _, path := range dependencyPaths {
+			dependency := path.Packages[len(path.Packages)-1]
+			paths.add(dependency.RepoName(), dependency.GitTagFromVersion(), formatDependencyPath(path))
+		}
+		return dependencyRepoRevs, err
+	})
+}
+
+// dependents resolves `repo:dependents` predicates to a specific list of repositories
+// depending on the given repos and revision(s), like dependencies does for `repo:dependencies`
+// predicates.
+func (r *Resolver) dependents(ctx context.Context, op *search.RepoOptions, paths DependencyPaths) (_ []string, _ map[api.RepoName][]search.RevisionSpecifier, err error) {
+	tr, ctx := trace.New(ctx, "searchrepos.reverseDependencies", "")
+	defer func() {
+		tr.LazyPrintf("dependents: %v", op.Dependents)
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if !conf.DependeciesSearchEnabled() {
+		return nil, nil, errors.Errorf("support for `repo:dependents()` is disabled in site config (`experimentalFeatures.dependenciesSearch`)")
 	}
 
-	dependencyRepoRevs, err := livedependencies.GetService(r.DB, livedependencies.NewSyncer()).Dependencies(ctx, repoRevs)
-	if err != nil {
-		return nil, nil, err
+	svc := livedependencies.GetService(r.DB, livedependencies.NewSyncer())
+
+	return r.resolveDependencyPredicates(ctx, op, op.Dependents, func() query.Predicate { return &query.RepoDependentsPredicate{} }, func(repoRevs map[api.RepoName]codeintelTypes.RevSpecSet, depth int) (map[api.RepoName]codeintelTypes.RevSpecSet, error) {
+		if depth == 0 {
+			return svc.Dependents(ctx, repoRevs)
+		}
+
+		dependentRepoRevs, dependentPaths, err := svc.TransitiveDependents(ctx, repoRevs, depth)
+		for _, path := range dependentPaths {
+			paths.add(path.Repo, string(path.Rev), formatDependencyPath(path))
+		}
+		return dependentRepoRevs, err
+	})
+}
+
+// resolveDependencyPredicates parses the given values of `repo:dependencies` or `repo:dependents`
+// predicates and invokes resolve once per distinct depth with the repository revisions matching the
+// predicates of that depth. A depth of zero means that the predicates have no depth argument. It
+// returns the names and revisions of all resolved repositories.
+func (r *Resolver) resolveDependencyPredicates(
+	ctx context.Context,
+	op *search.RepoOptions,
+	values []string,
+	predicate func() query.Predicate,
+	resolve func(repoRevs map[api.RepoName]codeintelTypes.RevSpecSet, depth int) (map[api.RepoName]codeintelTypes.RevSpecSet, error),
+) ([]string, map[api.RepoName][]search.RevisionSpecifier, error) {
+	patternsByDepth := map[int][]string{}
+	for _, value := range values {
+		p := predicate()
+		if err := p.ParseParams(value); err != nil {
+			return nil, nil, err
+		}
+
+		repoRev, depth := dependencyPredicateParams(p)
+		patternsByDepth[depth] = append(patternsByDepth[depth], repoRev)
+	}
+
+	depths := make([]int, 0, len(patternsByDepth))
+	for depth := range patternsByDepth {
+		depths = append(depths, depth)
 	}
+	sort.Ints(depths)
 
-	depRevs := make(map[api.RepoName][]search.RevisionSpecifier, len(dependencyRepoRevs))
-	depNames := make([]string, 0, len(dependencyRepoRevs))
+	depRevSets := map[api.RepoName]codeintelTypes.RevSpecSet{}
+	for _, depth := range depths {
+		repoRevs, err := listDependencyRepos(ctx, r.DB.Repos(), patternsByDepth[depth], op.CaseSensitiveRepoFilters)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		dependencyRepoRevs, err := resolve(repoRevs, depth)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for repoName, revs := range dependencyRepoRevs {
+			if _, ok := depRevSets[repoName]; !ok {
+				depRevSets[repoName] = codeintelTypes.RevSpecSet{}
+			}
+			for rev := range revs {
+				depRevSets[repoName][rev] = struct{}{}
+			}
+		}
+	}
+
+	depRevs := make(map[api.RepoName][]search.RevisionSpecifier, len(depRevSets))
+	depNames := make([]string, 0, len(depRevSets))
 
-	for repoName, revs := range dependencyRepoRevs {
+	for repoName, revs := range depRevSets {
 		depNames = append(depNames, string(repoName))
 		revSpecs := make([]search.RevisionSpecifier, 0, len(revs))
 		for rev := range revs {
@@ -561,6 +739,27 @@ func (r *Resolver) dependencies(ctx context.Context, op *search.RepoOptions) (_
 	return depNames, depRevs, nil
 }
 
+func dependencyPredicateParams(p query.Predicate) (repoRev string, depth int) {
+	switch p := p.(type) {
+	case *query.RepoDependenciesPredicate:
+		return p.RepoRev, p.Depth
+	case *query.RepoDependentsPredicate:
+		return p.RepoRev, p.Depth
+	}
+	return "", 0
+}
+
+// formatDependencyPath returns the "repo@rev" nodes of the given dependency path, starting
+// with the dependent repository.
+func formatDependencyPath(path codeintelshared.DependencyPath) []string {
+	nodes := make([]string, 0, len(path.Packages)+1)
+	nodes = append(nodes, string(path.Repo)+"@"+string(path.Rev))
+	for _, pkg := range path.Packages {
+		nodes = append(nodes, string(pkg.RepoName())+"@"+pkg.GitTagFromVersion())
+	}
+	return nodes
+}
+
 func listDependencyRepos(ctx context.Context, repoStore database.RepoStore, revSpecPatterns []string, caseSensitive bool) (map[api.RepoName]codeintelTypes.RevSpecSet, error) {
 	repoRevs := make(map[api.RepoName]codeintelTypes.RevSpecSet, len(revSpecPatterns))
 	for _, depParams := range revSpecPatterns {
@@ -597,62 +796,33 @@ func listDependencyRepos(ctx context.Context, repoStore database.RepoStore, revS
 	return repoRevs, nil
 }
 
-func (r *Resolver) dependents(ctx context.Context, op *search.RepoOptions) (_ []string, _ map[api.RepoName][]search.RevisionSpecifier, err error) {
-	tr, ctx := trace.New(ctx, "searchrepos.reverseDependencies", "")
-	defer func() {
-		tr.LazyPrintf("dependents: %v", op.Dependents)
-		tr.SetError(err)
-		tr.Finish()
-	}()
-
-	if !conf.DependeciesSearchEnabled() {
-		return nil, nil, errors.Errorf("support for `repo:dependents()` is disabled in site config (`experimentalFeatures.dependenciesSearch`)")
-	}
-
-	repoRevs, err := listDependencyRepos(ctx, r.DB.Repos(), op.Dependents, op.CaseSensitiveRepoFilters)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	dependencyRepoRevs, err := livedependencies.GetService(r.DB, livedependencies.NewSyncer()).Dependents(ctx, repoRevs)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	depRevs := make(map[api.RepoName][]search.RevisionSpecifier, len(dependencyRepoRevs))
-	depNames := make([]string, 0, len(dependencyRepoRevs))
-
-	for repoName, revs := range dependencyRepoRevs {
-		depNames = append(depNames, string(repoName))
-		revSpecs := make([]search.RevisionSpecifier, 0, len(revs))
-		for rev := range revs {
-			revSpecs = append(revSpecs, search.RevisionSpecifier{RevSpec: string(rev)})
-		}
-		depRevs[repoName] = revSpecs
-	}
-
-	return depNames, depRevs, nil
-}
-
 // ExactlyOneRepo returns whether exactly one repo: literal field is specified and
 // delineated by regex anchors ^ and $. This function helps determine whether we
 // should return results for a single repo regardless of whether it is a fork or
 // archive.
 func ExactlyOneRepo(repoFilters []string) bool {
 	if len(repoFilters) == 1 {
-		filter, _ := search.ParseRepositoryRevisions(repoFilters[0])
-		if strings.HasPrefix(filter, "^") && strings.HasSuffix(filter, "$") {
-			filter := strings.TrimSuffix(strings.TrimPrefix(filter, "^"), "$")
-			r, err := regexpsyntax.Parse(filter, regexpFlags)
-			if err != nil {
-				return false
-			}
-			return r.Op == regexpsyntax.OpLiteral
-		}
+		_, ok := exactRepoName(repoFilters[0])
+		return ok
 	}
 	return false
 }
 
+// exactRepoName returns the repository name matched by a repo: filter that is
+// a literal delineated by regex anchors ^ and $, if any.
+func exactRepoName(repoFilter string) (api.RepoName, bool) {
+	filter, _ := search.ParseRepositoryRevisions(repoFilter)
+	if strings.HasPrefix(filter, "^") && strings.HasSuffix(filter, "$") {
+		filter := strings.TrimSuffix(strings.TrimPrefix(filter, "^"), "$")
+		r, err := regexpsyntax.Parse(filter, regexpFlags)
+		if err != nil || r.Op != regexpsyntax.OpLiteral {
+			return "", false
+		}
+		return api.RepoName(string(r.Rune)), true
+	}
+	return "", false
+}
+
 // Cf. golang/go/src/regexp/syntax/parse.go.
 const regexpFlags = regexpsyntax.ClassNL | regexpsyntax.PerlX | regexpsyntax.UnicodeGroups
 
@@ -790,6 +960,14 @@ type MissingRepoRevsError struct {
 
 func (MissingRepoRevsError) Error() string { return "missing repo revs" }
 
+// RenamedReposError is a non-fatal error returned when repo: filters referred
+// to repositories by their old name. Renamed maps old to new names.
+type RenamedReposError struct {
+	Renamed map[api.RepoName]api.RepoName
+}
+
+func (RenamedReposError) Error() string { return "renamed repos" }
+
 // Get all private repos for the the current actor. On sourcegraph.com, those are
 // only the repos directly added by the user. Otherwise it's all repos the user has
 // access to on all connected code hosts / external services.
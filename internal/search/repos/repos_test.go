@@ -13,6 +13,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	codeintelshared "github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -179,6 +181,68 @@ func TestRevisionValidation(t *testing.T) {
 	}
 }
 
+func TestResolveRenamedRepos(t *testing.T) {
+	gitserver.Mocks.ResolveRevision = func(spec string, opt gitserver.ResolveRevisionOptions) (api.CommitID, error) {
+		return "", nil
+	}
+	defer func() { gitserver.Mocks.ResolveRevision = nil }()
+
+	repos := database.NewMockRepoStore()
+	repos.GetByRedirectFunc.SetDefaultHook(func(_ context.Context, name api.RepoName) (*types.Repo, error) {
+		if name == "github.com/old/repo" {
+			return &types.Repo{ID: 1, Name: "github.com/new/repo"}, nil
+		}
+		return nil, &database.RepoNotFoundErr{Name: name}
+	})
+	repos.GetByNameFunc.SetDefaultHook(func(_ context.Context, name api.RepoName) (*types.Repo, error) {
+		return nil, &database.RepoNotFoundErr{Name: name}
+	})
+	repos.ListMinimalReposFunc.SetDefaultHook(func(_ context.Context, opts database.ReposListOptions) ([]types.MinimalRepo, error) {
+		for _, p := range opts.IncludePatterns {
+			if p == `^github\.com/new/repo$` || p == "current" {
+				return []types.MinimalRepo{{ID: 1, Name: "github.com/new/repo"}}, nil
+			}
+		}
+		return nil, nil
+	})
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	repositoryResolver := &Resolver{DB: db}
+
+	// Redirects are not looked up when the repo: filters match repositories.
+	_, err := repositoryResolver.Resolve(context.Background(), search.RepoOptions{RepoFilters: []string{"current"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mockrequire.NotCalled(t, repos.GetByRedirectFunc)
+
+	op := search.RepoOptions{RepoFilters: []string{`^github\.com/old/repo$@main`, "other"}}
+	resolved, err := repositoryResolver.Resolve(context.Background(), op)
+
+	var rErr *RenamedReposError
+	if !errors.As(err, &rErr) {
+		t.Fatalf("got: %v, expected a RenamedReposError", err)
+	}
+	if diff := cmp.Diff(map[api.RepoName]api.RepoName{"github.com/old/repo": "github.com/new/repo"}, rErr.Renamed); diff != "" {
+		t.Error(diff)
+	}
+
+	wantRepoRevs := []*search.RepositoryRevisions{{
+		Repo: types.MinimalRepo{ID: 1, Name: "github.com/new/repo"},
+		Revs: []search.RevisionSpecifier{{RevSpec: "main"}},
+	}}
+	if diff := cmp.Diff(wantRepoRevs, resolved.RepoRevs); diff != "" {
+		t.Error(diff)
+	}
+
+	history := repos.ListMinimalReposFunc.History()
+	opts := history[len(history)-1].Arg1
+	if diff := cmp.Diff([]string{`^github\.com/new/repo$`, "other"}, opts.IncludePatterns); diff != "" {
+		t.Error(diff)
+	}
+}
+
 // TestSearchRevspecs tests a repository name against a list of
 // repository specs with optional revspecs, and determines whether
 // we get the expected error, list of matching rev specs, or list
@@ -535,3 +599,48 @@ func TestResolveRepositoriesWithSearchContext(t *testing.T) {
 		t.Errorf("got repository revisions %+v, want %+v", resolved.RepoRevs, wantRepositoryRevisions)
 	}
 }
+
+func TestDependencyPaths(t *testing.T) {
+	react, err := reposource.ParseNpmDependency("react@18.2.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	looseEnvify, err := reposource.ParseNpmDependency("loose-envify@1.4.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shortPath := formatDependencyPath(codeintelshared.DependencyPath{
+		Repo:     "github.com/sourcegraph/sourcegraph",
+		Rev:      "main",
+		Packages: []codeintelshared.PackageDependency{looseEnvify},
+	})
+	longPath := formatDependencyPath(codeintelshared.DependencyPath{
+		Repo:     "github.com/sourcegraph/sourcegraph",
+		Rev:      "main",
+		Packages: []codeintelshared.PackageDependency{react, looseEnvify},
+	})
+
+	wantLongPath := []string{
+		"github.com/sourcegraph/sourcegraph@main",
+		"npm/react@v18.2.0",
+		"npm/loose-envify@v1.4.0",
+	}
+	if diff := cmp.Diff(wantLongPath, longPath); diff != "" {
+		t.Fatalf("unexpected path (-want +got):\n%s", diff)
+	}
+
+	paths := DependencyPaths{}
+	paths.add("npm/loose-envify", "v1.4.0", longPath)
+	paths.add("npm/loose-envify", "v1.4.0", shortPath)
+	paths.add("npm/loose-envify", "v1.4.0", longPath)
+
+	want := DependencyPaths{
+		"npm/loose-envify": {
+			"v1.4.0": {"github.com/sourcegraph/sourcegraph@main", "npm/loose-envify@v1.4.0"},
+		},
+	}
+	if diff := cmp.Diff(want, paths); diff != "" {
+		t.Fatalf("unexpected paths (-want +got):\n%s", diff)
+	}
+}
@@ -14,6 +14,12 @@ type RepoMatch struct {
 
 	// rev optionally specifies a revision to go to for search results.
 	Rev string
+
+	// DependencyPath optionally lists the "repo@rev" nodes of the shortest
+	// dependency path from a dependent repository to this repository. It is
+	// set for results of repo:dependencies and repo:dependents predicates
+	// with a depth argument.
+	DependencyPath []string
 }
 
 func (r RepoMatch) RepoName() types.MinimalRepo {
@@ -42,7 +42,7 @@ func (s *RepoSearchJob) Run(ctx context.Context, clients job.RuntimeClients, str
 		}
 
 		stream.Send(streaming.SearchEvent{
-			Results: repoRevsToRepoMatches(ctx, clients.DB, page.RepoRevs),
+			Results: repoRevsToRepoMatches(ctx, clients.DB, page.RepoRevs, page.DependencyPaths),
 		})
 
 		return nil
@@ -68,7 +68,7 @@ func (s *RepoSearchJob) Tags() []log.Field {
 	}
 }
 
-func repoRevsToRepoMatches(ctx context.Context, db database.DB, repos []*search.RepositoryRevisions) []result.Match {
+func repoRevsToRepoMatches(ctx context.Context, db database.DB, repos []*search.RepositoryRevisions, dependencyPaths searchrepos.DependencyPaths) []result.Match {
 	matches := make([]result.Match, 0, len(repos))
 	for _, r := range repos {
 		revs, err := r.ExpandedRevSpecs(ctx, db)
@@ -77,9 +77,10 @@ func repoRevsToRepoMatches(ctx context.Context, db database.DB, repos []*search.
 		}
 		for _, rev := range revs {
 			matches = append(matches, &result.RepoMatch{
-				Name: r.Repo.Name,
-				ID:   r.Repo.ID,
-				Rev:  rev,
+				Name:           r.Repo.Name,
+				ID:             r.Repo.ID,
+				Rev:            rev,
+				DependencyPath: dependencyPaths[r.Repo.Name][rev],
 			})
 		}
 	}
@@ -95,6 +95,7 @@ type EventRepoMatch struct {
 	Fork            bool       `json:"fork,omitempty"`
 	Archived        bool       `json:"archived,omitempty"`
 	Private         bool       `json:"private,omitempty"`
+	DependencyPath  []string   `json:"dependencyPath,omitempty"`
 }
 
 func (e *EventRepoMatch) eventMatch() {}
@@ -151,16 +151,6 @@ func Compute(ctx context.Context, repoName types.MinimalRepo, commitID api.Commi
 		return searchZoekt(ctx, repoName, commitID, inputRev, branch, query, first, includePatterns)
 	}
 
-	serverTimeout := 5 * time.Second
-	clientTimeout := 2 * serverTimeout
-
-	ctx, done := context.WithTimeout(ctx, clientTimeout)
-	defer done()
-	defer func() {
-		if ctx.Err() != nil && len(res) == 0 {
-			err = errors.Newf("The symbols service appears unresponsive, check the logs for errors.")
-		}
-	}()
 	var includePatternsSlice []string
 	if includePatterns != nil {
 		includePatternsSlice = *includePatterns
@@ -171,12 +161,63 @@ func Compute(ctx context.Context, repoName types.MinimalRepo, commitID api.Commi
 		First:           limitOrDefault(first) + 1, // add 1 so we can determine PageInfo.hasNextPage
 		Repo:            repoName.Name,
 		IncludePatterns: includePatternsSlice,
-		Timeout:         int(serverTimeout.Seconds()),
 	}
 	if query != nil {
 		searchArgs.Query = *query
 	}
 
+	return searchSymbolsService(ctx, repoName, commitID, inputRev, searchArgs)
+}
+
+// ComputeExact returns the symbols whose name is exactly the given name. Unlike Compute, which
+// matches a literal query as a case-insensitive substring, the query is anchored, so symbols
+// that merely contain the name can't crowd out the exact matches.
+func ComputeExact(ctx context.Context, repoName types.MinimalRepo, commitID api.CommitID, name string, first int32) ([]*result.SymbolMatch, error) {
+	pattern := "^" + regexp.QuoteMeta(name) + "$"
+
+	var (
+		matches []*result.SymbolMatch
+		err     error
+	)
+	if branch := indexedSymbolsBranch(ctx, &repoName, string(commitID)); branch != "" {
+		matches, err = searchZoekt(ctx, repoName, commitID, nil, branch, &pattern, &first, nil)
+	} else {
+		matches, err = searchSymbolsService(ctx, repoName, commitID, nil, search.SymbolsParameters{
+			CommitID:        commitID,
+			First:           int(first),
+			Repo:            repoName.Name,
+			Query:           pattern,
+			IsRegExp:        true,
+			IsCaseSensitive: true,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Zoekt matches the pattern case-insensitively.
+	exact := matches[:0]
+	for _, match := range matches {
+		if match.Symbol.Name == name {
+			exact = append(exact, match)
+		}
+	}
+	return exact, nil
+}
+
+func searchSymbolsService(ctx context.Context, repoName types.MinimalRepo, commitID api.CommitID, inputRev *string, searchArgs search.SymbolsParameters) (res []*result.SymbolMatch, err error) {
+	serverTimeout := 5 * time.Second
+	clientTimeout := 2 * serverTimeout
+
+	ctx, done := context.WithTimeout(ctx, clientTimeout)
+	defer done()
+	defer func() {
+		if ctx.Err() != nil && len(res) == 0 {
+			err = errors.Newf("The symbols service appears unresponsive, check the logs for errors.")
+		}
+	}()
+	searchArgs.Timeout = int(serverTimeout.Seconds())
+
 	symbols, err := backend.Symbols.ListTags(ctx, searchArgs)
 	if err != nil {
 		return nil, err
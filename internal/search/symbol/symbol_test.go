@@ -0,0 +1,89 @@
+package symbol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grafana/regexp"
+	"github.com/neelance/parallel"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	symbolsclient "github.com/sourcegraph/sourcegraph/internal/symbols"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestComputeExact(t *testing.T) {
+	searchIndexEnabled := false
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{SearchIndexEnabled: &searchIndexEnabled}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	// Many symbols that contain the name come before the one that is named
+	// exactly like it.
+	var symbols result.Symbols
+	for i := 0; i < 200; i++ {
+		symbols = append(symbols, result.Symbol{Name: fmt.Sprintf("GetUser%d", i), Path: "users.go", Line: i})
+	}
+	symbols = append(symbols, result.Symbol{Name: "get", Path: "lower.go", Line: 1})
+	symbols = append(symbols, result.Symbol{Name: "Get", Path: "client.go", Line: 10})
+
+	// The fake symbols service matches like the real one: literal queries are
+	// case-insensitive substrings.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var args search.SymbolsParameters
+		if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		match := func(name string) bool {
+			return strings.Contains(strings.ToLower(name), strings.ToLower(args.Query))
+		}
+		if args.IsRegExp {
+			pattern := args.Query
+			if !args.IsCaseSensitive {
+				pattern = "(?i)" + pattern
+			}
+			re := regexp.MustCompile(pattern)
+			match = re.MatchString
+		}
+
+		var res search.SymbolsResponse
+		for _, s := range symbols {
+			if len(res.Symbols) == args.First {
+				break
+			}
+			if match(s.Name) {
+				res.Symbols = append(res.Symbols, s)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	t.Cleanup(server.Close)
+
+	prev := symbolsclient.DefaultClient
+	symbolsclient.DefaultClient = &symbolsclient.Client{
+		URL:         server.URL,
+		HTTPClient:  http.DefaultClient,
+		HTTPLimiter: parallel.NewRun(1),
+	}
+	t.Cleanup(func() { symbolsclient.DefaultClient = prev })
+
+	matches, err := ComputeExact(context.Background(), types.MinimalRepo{Name: "github.com/sourcegraph/sourcegraph"}, "deadbeef", "Get", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("unexpected number of matches: have=%d want=1", len(matches))
+	}
+	if have, want := matches[0].File.Path, "client.go"; have != want {
+		t.Fatalf("unexpected match: have=%q want=%q", have, want)
+	}
+}
@@ -72,12 +72,22 @@ func (e *ExternalService) RedactedConfig() (string, error) {
 		}
 	case *schema.RustPackagesConnection:
 		// Nothing to redact
+	case *schema.RubyPackagesConnection:
+		// Nothing to redact
+	case *schema.PHPPackagesConnection:
+		// Nothing to redact
 	case *schema.JVMPackagesConnection:
 		if c.Maven != nil {
 			es.redactString(c.Maven.Credentials, "maven", "credentials")
 		}
 	case *schema.PagureConnection:
 		es.redactString(c.Token, "token")
+	case *schema.SourcegraphConnection:
+		es.redactString(c.Token, "token")
+		err = es.redactURL(c.GitURL, "gitURL")
+		if err != nil {
+			return "", err
+		}
 	case *schema.NpmPackagesConnection:
 		es.redactString(c.Credentials, "credentials")
 	case *schema.OtherExternalServiceConnection:
@@ -160,6 +170,10 @@ func (e *ExternalService) UnredactConfig(old *ExternalService) error {
 		}
 	case *schema.RustPackagesConnection:
 		// Nothing to unredact
+	case *schema.RubyPackagesConnection:
+		// Nothing to unredact
+	case *schema.PHPPackagesConnection:
+		// Nothing to unredact
 	case *schema.JVMPackagesConnection:
 		o := oldCfg.(*schema.JVMPackagesConnection)
 		if c.Maven != nil && o.Maven != nil {
@@ -168,6 +182,13 @@ func (e *ExternalService) UnredactConfig(old *ExternalService) error {
 	case *schema.PagureConnection:
 		o := oldCfg.(*schema.PagureConnection)
 		es.unredactString(c.Token, o.Token, "token")
+	case *schema.SourcegraphConnection:
+		o := oldCfg.(*schema.SourcegraphConnection)
+		es.unredactString(c.Token, o.Token, "token")
+		err = es.unredactURL(c.GitURL, o.GitURL, "gitURL")
+		if err != nil {
+			return err
+		}
 	case *schema.NpmPackagesConnection:
 		o := oldCfg.(*schema.NpmPackagesConnection)
 		es.unredactString(c.Credentials, o.Credentials, "credentials")
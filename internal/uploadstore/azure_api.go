@@ -0,0 +1,328 @@
+package uploadstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// azureAPI is the subset of the Azure Blob Storage REST API used by the Azure store.
+type azureAPI interface {
+	CreateContainer(ctx context.Context, container string) error
+	GetBlob(ctx context.Context, container, name string) (io.ReadCloser, error)
+	StageBlock(ctx context.Context, container, name, blockID string, data []byte) error
+	CommitBlockList(ctx context.Context, container, name string, blockIDs []string) error
+	DeleteBlob(ctx context.Context, container, name string) error
+	ListBlobs(ctx context.Context, container, prefix, marker string) (azureBlobPage, error)
+}
+
+type azureBlob struct {
+	Name         string
+	LastModified time.Time
+}
+
+type azureBlobPage struct {
+	Blobs      []azureBlob
+	NextMarker string
+}
+
+// azureResponseError is returned from the Azure API when a request results in an
+// unexpected status code.
+type azureResponseError struct {
+	StatusCode int
+	Code       string
+}
+
+func (e *azureResponseError) Error() string {
+	return fmt.Sprintf("unexpected status code %d (%s)", e.StatusCode, e.Code)
+}
+
+// isAzureError returns true if the given error is an azureResponseError with the given
+// status code and error code. An empty error code matches any error code.
+func isAzureError(err error, statusCode int, code string) bool {
+	var responseErr *azureResponseError
+	if !errors.As(err, &responseErr) {
+		return false
+	}
+
+	return responseErr.StatusCode == statusCode && (code == "" || responseErr.Code == code)
+}
+
+// azureAPIVersion is the version of the Blob Storage REST API sent with each request.
+const azureAPIVersion = "2020-10-02"
+
+// azureResponseHeaderTimeout bounds how long a single attempt waits for the Azure API to
+// respond. The whole request is not bounded as reading a blob may take arbitrarily long.
+const azureResponseHeaderTimeout = time.Minute
+
+// azureMaxRetries is the maximum number of times a failed request is retried.
+const azureMaxRetries = 5
+
+// azureClientFactory creates clients that retry failed requests with backoff. Retried
+// requests are replayed as-is, which is fine as the signature covers only the request
+// itself and every request the store makes is idempotent.
+var azureClientFactory = httpcli.NewFactory(
+	httpcli.NewMiddleware(httpcli.ContextErrorMiddleware),
+	azureResponseHeaderTimeoutOpt,
+	httpcli.ExternalTransportOpt,
+	httpcli.NewErrorResilientTransportOpt(
+		httpcli.NewRetryPolicy(httpcli.MaxRetries(azureMaxRetries)),
+		httpcli.ExpJitterDelay(200*time.Millisecond, 3*time.Second),
+	),
+	httpcli.TracedTransportOpt,
+)
+
+func azureResponseHeaderTimeoutOpt(cli *http.Client) error {
+	tr, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return errors.Errorf("http.DefaultTransport is not an *http.Transport: %T", http.DefaultTransport)
+	}
+
+	tr = tr.Clone()
+	tr.ResponseHeaderTimeout = azureResponseHeaderTimeout
+	cli.Transport = tr
+	return nil
+}
+
+type azureAPIShim struct {
+	client      httpcli.Doer
+	endpoint    *url.URL
+	accountName string
+	accountKey  []byte
+}
+
+var _ azureAPI = &azureAPIShim{}
+
+func newAzureAPIShim(client httpcli.Doer, config AzureConfig) (*azureAPIShim, error) {
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", config.AccountName)
+	}
+
+	u, err := url.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid endpoint")
+	}
+
+	accountKey, err := base64.StdEncoding.DecodeString(config.AccountKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid account key")
+	}
+
+	return &azureAPIShim{
+		client:      client,
+		endpoint:    u,
+		accountName: config.AccountName,
+		accountKey:  accountKey,
+	}, nil
+}
+
+func (s *azureAPIShim) CreateContainer(ctx context.Context, container string) error {
+	resp, err := s.do(ctx, http.MethodPut, container, "", url.Values{"restype": {"container"}}, nil, nil, http.StatusCreated)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func (s *azureAPIShim) GetBlob(ctx context.Context, container, name string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, container, name, nil, nil, nil, http.StatusOK)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (s *azureAPIShim) StageBlock(ctx context.Context, container, name, blockID string, data []byte) error {
+	query := url.Values{"comp": {"block"}, "blockid": {blockID}}
+
+	resp, err := s.do(ctx, http.MethodPut, container, name, query, nil, data, http.StatusCreated)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+type azureBlockList struct {
+	XMLName xml.Name `xml:"BlockList"`
+	Latest  []string `xml:"Latest"`
+}
+
+func (s *azureAPIShim) CommitBlockList(ctx context.Context, container, name string, blockIDs []string) error {
+	payload, err := xml.Marshal(azureBlockList{Latest: blockIDs})
+	if err != nil {
+		return err
+	}
+
+	headers := http.Header{"Content-Type": {"application/xml"}}
+	body := append([]byte(xml.Header), payload...)
+
+	resp, err := s.do(ctx, http.MethodPut, container, name, url.Values{"comp": {"blocklist"}}, headers, body, http.StatusCreated)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+func (s *azureAPIShim) DeleteBlob(ctx context.Context, container, name string) error {
+	resp, err := s.do(ctx, http.MethodDelete, container, name, nil, nil, nil, http.StatusAccepted)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+type azureListBlobsResult struct {
+	Blobs []struct {
+		Name       string `xml:"Name"`
+		Properties struct {
+			LastModified string `xml:"Last-Modified"`
+		} `xml:"Properties"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+func (s *azureAPIShim) ListBlobs(ctx context.Context, container, prefix, marker string) (_ azureBlobPage, err error) {
+	query := url.Values{"restype": {"container"}, "comp": {"list"}}
+	if prefix != "" {
+		query.Set("prefix", prefix)
+	}
+	if marker != "" {
+		query.Set("marker", marker)
+	}
+
+	resp, err := s.do(ctx, http.MethodGet, container, "", query, nil, nil, http.StatusOK)
+	if err != nil {
+		return azureBlobPage{}, err
+	}
+	defer resp.Body.Close()
+
+	var result azureListBlobsResult
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return azureBlobPage{}, errors.Wrap(err, "failed to decode blob list")
+	}
+
+	blobs := make([]azureBlob, 0, len(result.Blobs))
+	for _, blob := range result.Blobs {
+		lastModified, err := http.ParseTime(blob.Properties.LastModified)
+		if err != nil {
+			return azureBlobPage{}, errors.Wrapf(err, "invalid last modified time for blob %q", blob.Name)
+		}
+
+		blobs = append(blobs, azureBlob{Name: blob.Name, LastModified: lastModified})
+	}
+
+	return azureBlobPage{Blobs: blobs, NextMarker: result.NextMarker}, nil
+}
+
+// do performs an authenticated request against the given container or blob. If the response
+// does not have the expected status code, the response body is closed and an azureResponseError
+// is returned.
+func (s *azureAPIShim) do(ctx context.Context, method, container, name string, query url.Values, headers http.Header, body []byte, expectedStatusCode int) (*http.Response, error) {
+	u := *s.endpoint
+	u.Path = s.endpoint.Path + "/" + container
+	if name != "" {
+		u.Path += "/" + name
+	}
+	u.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range headers {
+		req.Header[key] = values
+	}
+	req.ContentLength = int64(len(body))
+	if len(body) == 0 {
+		req.Body = nil
+	}
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureAPIVersion)
+	req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", s.accountName, s.sign(req)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != expectedStatusCode {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		return nil, &azureResponseError{StatusCode: resp.StatusCode, Code: resp.Header.Get("x-ms-error-code")}
+	}
+
+	return resp, nil
+}
+
+// sign returns the Shared Key signature of the given request.
+//
+// See https://docs.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key.
+func (s *azureAPIShim) sign(req *http.Request) string {
+	contentLength := ""
+	if req.ContentLength > 0 {
+		contentLength = strconv.FormatInt(req.ContentLength, 10)
+	}
+
+	parts := []string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		contentLength,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"", // Date (superseded by x-ms-date)
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+	}
+
+	var msHeaders []string
+	for key := range req.Header {
+		if name := strings.ToLower(key); strings.HasPrefix(name, "x-ms-") {
+			msHeaders = append(msHeaders, name+":"+strings.TrimSpace(req.Header.Get(key)))
+		}
+	}
+	sort.Strings(msHeaders)
+
+	resource := "/" + s.accountName + req.URL.EscapedPath()
+	query := req.URL.Query()
+	var queryKeys []string
+	for key := range query {
+		queryKeys = append(queryKeys, key)
+	}
+	sort.Strings(queryKeys)
+	for _, key := range queryKeys {
+		values := query[key]
+		sort.Strings(values)
+		resource += "\n" + strings.ToLower(key) + ":" + strings.Join(values, ",")
+	}
+
+	stringToSign := strings.Join(parts, "\n") + "\n" + strings.Join(append(msHeaders, resource), "\n")
+
+	mac := hmac.New(sha256.New, s.accountKey)
+	_, _ = mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,145 @@
+package uploadstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var testAzureAccountKey = base64.StdEncoding.EncodeToString([]byte("test-account-key"))
+
+func TestAzureAPISharedKeySignature(t *testing.T) {
+	var requestPath, requestQuery, authorization, stringToSign string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.EscapedPath()
+		requestQuery = r.URL.RawQuery
+		authorization = r.Header.Get("Authorization")
+		stringToSign = strings.Join([]string{
+			"PUT", "", "", "5", "", "", "", "", "", "", "", "",
+			"x-ms-date:" + r.Header.Get("x-ms-date"),
+			"x-ms-version:" + azureAPIVersion,
+			"/devstoreaccount1/devstoreaccount1/test-container/test%20key\nblockid:YmxvY2s=\ncomp:block",
+		}, "\n")
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+
+	api := testAzureAPI(t, server.URL+"/devstoreaccount1")
+	if err := api.StageBlock(context.Background(), "test-container", "test key", "YmxvY2s=", []byte("block")); err != nil {
+		t.Fatalf("unexpected error staging block: %s", err)
+	}
+
+	if requestPath != "/devstoreaccount1/test-container/test%20key" {
+		t.Errorf("unexpected path. have=%s", requestPath)
+	}
+	if requestQuery != "blockid=YmxvY2s%3D&comp=block" {
+		t.Errorf("unexpected query. have=%s", requestQuery)
+	}
+
+	mac := hmac.New(sha256.New, []byte("test-account-key"))
+	_, _ = mac.Write([]byte(stringToSign))
+	expectedAuthorization := "SharedKey devstoreaccount1:" + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	if authorization != expectedAuthorization {
+		t.Errorf("unexpected authorization header. want=%s have=%s", expectedAuthorization, authorization)
+	}
+}
+
+func TestAzureAPICommitBlockList(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := io.ReadAll(r.Body)
+		body = string(payload)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(server.Close)
+
+	api := testAzureAPI(t, server.URL)
+	if err := api.CommitBlockList(context.Background(), "test-container", "test-key", []string{"YQ==", "Yg=="}); err != nil {
+		t.Fatalf("unexpected error committing block list: %s", err)
+	}
+
+	expectedBody := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<BlockList><Latest>YQ==</Latest><Latest>Yg==</Latest></BlockList>`
+	if body != expectedBody {
+		t.Errorf("unexpected body. want=%s have=%s", expectedBody, body)
+	}
+}
+
+func TestAzureAPIListBlobs(t *testing.T) {
+	var requestQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestQuery = r.URL.RawQuery
+		_, _ = io.WriteString(w, `<?xml version="1.0" encoding="utf-8"?>
+<EnumerationResults ServiceEndpoint="http://localhost/" ContainerName="test-container">
+  <Prefix>upload-</Prefix>
+  <Blobs>
+    <Blob>
+      <Name>upload-1</Name>
+      <Properties><Last-Modified>Wed, 01 Jun 2022 10:00:00 GMT</Last-Modified></Properties>
+    </Blob>
+    <Blob>
+      <Name>upload-2</Name>
+      <Properties><Last-Modified>Thu, 02 Jun 2022 10:00:00 GMT</Last-Modified></Properties>
+    </Blob>
+  </Blobs>
+  <NextMarker>next</NextMarker>
+</EnumerationResults>`)
+	}))
+	t.Cleanup(server.Close)
+
+	api := testAzureAPI(t, server.URL)
+	page, err := api.ListBlobs(context.Background(), "test-container", "upload-", "marker")
+	if err != nil {
+		t.Fatalf("unexpected error listing blobs: %s", err)
+	}
+
+	if requestQuery != "comp=list&marker=marker&prefix=upload-&restype=container" {
+		t.Errorf("unexpected query. have=%s", requestQuery)
+	}
+
+	expected := azureBlobPage{
+		Blobs: []azureBlob{
+			{Name: "upload-1", LastModified: time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)},
+			{Name: "upload-2", LastModified: time.Date(2022, 6, 2, 10, 0, 0, 0, time.UTC)},
+		},
+		NextMarker: "next",
+	}
+	if diff := cmp.Diff(expected, page); diff != "" {
+		t.Errorf("unexpected page (-want, +got):\n%s", diff)
+	}
+}
+
+func TestAzureAPIErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-ms-error-code", "BlobNotFound")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	api := testAzureAPI(t, server.URL)
+	if _, err := api.GetBlob(context.Background(), "test-container", "test-key"); !isAzureError(err, http.StatusNotFound, "BlobNotFound") {
+		t.Errorf("unexpected error. have=%v", err)
+	}
+}
+
+func testAzureAPI(t *testing.T, endpoint string) *azureAPIShim {
+	api, err := newAzureAPIShim(http.DefaultClient, AzureConfig{
+		AccountName: "devstoreaccount1",
+		AccountKey:  testAzureAccountKey,
+		Endpoint:    endpoint,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating api: %s", err)
+	}
+
+	return api
+}
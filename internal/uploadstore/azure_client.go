@@ -0,0 +1,263 @@
+package uploadstore
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type azureStore struct {
+	container    string
+	manageBucket bool
+	client       azureAPI
+	operations   *Operations
+}
+
+var _ Store = &azureStore{}
+var _ Expirer = &azureStore{}
+
+type AzureConfig struct {
+	AccountName string
+	AccountKey  string
+	Endpoint    string
+}
+
+// newAzureFromConfig creates a new store backed by Azure Blob Storage. The configured bucket
+// is used as the name of the target container.
+func newAzureFromConfig(ctx context.Context, config Config, operations *Operations) (Store, error) {
+	client, err := azureClientFactory.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	api, err := newAzureAPIShim(client, config.Azure)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAzureWithClient(api, config.Bucket, config.ManageBucket, operations), nil
+}
+
+func newAzureWithClient(client azureAPI, container string, manageBucket bool, operations *Operations) *azureStore {
+	return &azureStore{
+		container:    container,
+		manageBucket: manageBucket,
+		client:       client,
+		operations:   operations,
+	}
+}
+
+// Init creates the target container. Lifecycle management policies are configured at the level
+// of the storage account and cannot be set by this store; objects are instead expired through
+// ExpireObjects.
+func (s *azureStore) Init(ctx context.Context) error {
+	if !s.manageBucket {
+		return nil
+	}
+
+	if err := s.client.CreateContainer(ctx, s.container); err != nil && !isAzureError(err, http.StatusConflict, "ContainerAlreadyExists") {
+		return errors.Wrap(err, "failed to create container")
+	}
+
+	return nil
+}
+
+func (s *azureStore) Get(ctx context.Context, key string) (_ io.ReadCloser, err error) {
+	ctx, _, endObservation := s.operations.Get.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	rc, err := s.client.GetBlob(ctx, s.container, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+
+	return rc, nil
+}
+
+func (s *azureStore) Upload(ctx context.Context, key string, r io.Reader) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Upload.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	var blockIDs []string
+	n, err := s.stageBlocks(ctx, key, r, &blockIDs)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+
+	if err := s.client.CommitBlockList(ctx, s.container, key, blockIDs); err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+
+	return n, nil
+}
+
+// Compose stages the content of each source object as blocks of the destination object. Blob
+// Storage can only copy blocks between blobs server-side when the source is readable without
+// shared key credentials, so the content of the sources is streamed through this store.
+func (s *azureStore) Compose(ctx context.Context, destination string, sources ...string) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Compose.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("destination", destination),
+		log.String("sources", strings.Join(sources, ", ")),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	defer func() {
+		if err == nil {
+			// Delete sources on success
+			if err := s.deleteSources(ctx, sources); err != nil {
+				log15.Error("Failed to delete source objects", "error", err)
+			}
+		}
+	}()
+
+	var n int64
+	var blockIDs []string
+	for _, source := range sources {
+		m, err := s.stageObject(ctx, destination, source, &blockIDs)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to compose objects")
+		}
+
+		n += m
+	}
+
+	if err := s.client.CommitBlockList(ctx, s.container, destination, blockIDs); err != nil {
+		return 0, errors.Wrap(err, "failed to compose objects")
+	}
+
+	return n, nil
+}
+
+func (s *azureStore) Delete(ctx context.Context, key string) (err error) {
+	ctx, _, endObservation := s.operations.Delete.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return errors.Wrap(s.deleteBlob(ctx, key), "failed to delete object")
+}
+
+func (s *azureStore) ExpireObjects(ctx context.Context, prefix string, maxAge time.Duration) (err error) {
+	ctx, trace, endObservation := s.operations.ExpireObjects.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("prefix", prefix),
+		log.String("maxAge", maxAge.String()),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	var keys []string
+	var marker string
+	expirationThreshold := time.Now().Add(-maxAge)
+
+	for {
+		page, err := s.client.ListBlobs(ctx, s.container, prefix, marker)
+		if err != nil {
+			return errors.Wrap(err, "failed to list objects")
+		}
+
+		for _, blob := range page.Blobs {
+			if blob.LastModified.Before(expirationThreshold) {
+				keys = append(keys, blob.Name)
+			}
+		}
+
+		if page.NextMarker == "" {
+			break
+		}
+		marker = page.NextMarker
+	}
+	trace.Log(log.Int("numExpiredObjects", len(keys)))
+
+	return goroutine.RunWorkersOverStrings(keys, func(index int, key string) error {
+		if err := s.deleteBlob(ctx, key); err != nil {
+			return errors.Wrap(err, "failed to delete expired object")
+		}
+
+		return nil
+	})
+}
+
+// azureBlockSize is the maximum size of a single block staged by the Azure store. This value
+// is replaced in unit tests.
+var azureBlockSize = 16 * 1024 * 1024
+
+// stageObject reads the object at the given source key and stages its content as blocks of
+// the given destination key.
+func (s *azureStore) stageObject(ctx context.Context, destination, source string, blockIDs *[]string) (int64, error) {
+	rc, err := s.client.GetBlob(ctx, s.container, source)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get source object")
+	}
+	defer rc.Close()
+
+	return s.stageBlocks(ctx, destination, rc, blockIDs)
+}
+
+// stageBlocks stages the content of the given reader as uncommitted blocks of the given key.
+// The identifiers of the staged blocks are appended to the given slice in order. The blocks
+// are not visible to readers until the block list is committed.
+func (s *azureStore) stageBlocks(ctx context.Context, key string, r io.Reader, blockIDs *[]string) (n int64, _ error) {
+	buf := make([]byte, azureBlockSize)
+
+	for {
+		m, err := io.ReadFull(r, buf)
+		if m > 0 {
+			blockID := azureBlockID(len(*blockIDs))
+
+			if err := s.client.StageBlock(ctx, s.container, key, blockID, buf[:m]); err != nil {
+				return 0, errors.Wrap(err, "failed to stage block")
+			}
+
+			*blockIDs = append(*blockIDs, blockID)
+			n += int64(m)
+		}
+
+		if err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return n, nil
+			}
+
+			return 0, err
+		}
+	}
+}
+
+func (s *azureStore) deleteSources(ctx context.Context, sources []string) error {
+	return goroutine.RunWorkersOverStrings(sources, func(index int, source string) error {
+		if err := s.deleteBlob(ctx, source); err != nil {
+			return errors.Wrap(err, "failed to delete source object")
+		}
+
+		return nil
+	})
+}
+
+// deleteBlob deletes the blob with the given key. Deleting a blob that does not exist is not
+// an error.
+func (s *azureStore) deleteBlob(ctx context.Context, key string) error {
+	if err := s.client.DeleteBlob(ctx, s.container, key); err != nil && !isAzureError(err, http.StatusNotFound, "") {
+		return err
+	}
+
+	return nil
+}
+
+// azureBlockID returns the identifier of the block with the given index. All blocks of
+// a blob must have identifiers of the same length.
+func azureBlockID(index int) string {
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("block-%010d", index)))
+}
@@ -0,0 +1,234 @@
+package uploadstore
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestAzureInit(t *testing.T) {
+	azureClient := NewMockAzureAPI()
+	client := testAzureClient(azureClient, true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	if calls := azureClient.CreateContainerFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of CreateContainer calls. want=%d have=%d", 1, len(calls))
+	} else if value := calls[0].Arg1; value != "test-container" {
+		t.Errorf("unexpected container argument. want=%s have=%s", "test-container", value)
+	}
+}
+
+func TestAzureInitContainerExists(t *testing.T) {
+	azureClient := NewMockAzureAPI()
+	azureClient.CreateContainerFunc.SetDefaultReturn(&azureResponseError{StatusCode: http.StatusConflict, Code: "ContainerAlreadyExists"})
+
+	client := testAzureClient(azureClient, true)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+}
+
+func TestAzureUnmanagedInit(t *testing.T) {
+	azureClient := NewMockAzureAPI()
+	client := testAzureClient(azureClient, false)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	if calls := azureClient.CreateContainerFunc.History(); len(calls) != 0 {
+		t.Fatalf("unexpected number of CreateContainer calls. want=%d have=%d", 0, len(calls))
+	}
+}
+
+func TestAzureGet(t *testing.T) {
+	azureClient := NewMockAzureAPI()
+	azureClient.GetBlobFunc.SetDefaultReturn(io.NopCloser(bytes.NewReader([]byte("TEST PAYLOAD"))), nil)
+
+	client := testAzureClient(azureClient, false)
+	rc, err := client.Get(context.Background(), "test-key")
+	if err != nil {
+		t.Fatalf("unexpected error getting key: %s", err)
+	}
+
+	defer rc.Close()
+	contents, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected error reading object: %s", err)
+	}
+
+	if string(contents) != "TEST PAYLOAD" {
+		t.Fatalf("unexpected contents. want=%s have=%s", "TEST PAYLOAD", contents)
+	}
+
+	if calls := azureClient.GetBlobFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of GetBlob calls. want=%d have=%d", 1, len(calls))
+	} else if value := calls[0].Arg1; value != "test-container" {
+		t.Errorf("unexpected container argument. want=%s have=%s", "test-container", value)
+	} else if value := calls[0].Arg2; value != "test-key" {
+		t.Errorf("unexpected key argument. want=%s have=%s", "test-key", value)
+	}
+}
+
+func TestAzureUpload(t *testing.T) {
+	setAzureBlockSize(t, 5)
+
+	azureClient := NewMockAzureAPI()
+	// The staged data buffer is reused between calls
+	var blocks []string
+	azureClient.StageBlockFunc.SetDefaultHook(func(ctx context.Context, container, name, blockID string, data []byte) error {
+		if name != "test-key" {
+			t.Errorf("unexpected key argument. want=%s have=%s", "test-key", name)
+		}
+		blocks = append(blocks, string(data))
+		return nil
+	})
+
+	client := testAzureClient(azureClient, false)
+
+	size, err := client.Upload(context.Background(), "test-key", bytes.NewReader([]byte("TEST PAYLOAD")))
+	if err != nil {
+		t.Fatalf("unexpected error uploading key: %s", err)
+	} else if size != 12 {
+		t.Errorf("unexpected size. want=%d have=%d", 12, size)
+	}
+
+	if diff := cmp.Diff([]string{"TEST ", "PAYLO", "AD"}, blocks); diff != "" {
+		t.Errorf("unexpected blocks (-want, +got):\n%s", diff)
+	}
+
+	if calls := azureClient.CommitBlockListFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of CommitBlockList calls. want=%d have=%d", 1, len(calls))
+	} else if diff := cmp.Diff([]string{azureBlockID(0), azureBlockID(1), azureBlockID(2)}, calls[0].Arg3); diff != "" {
+		t.Errorf("unexpected block ids (-want, +got):\n%s", diff)
+	}
+}
+
+func TestAzureCompose(t *testing.T) {
+	setAzureBlockSize(t, 5)
+
+	contents := map[string]string{
+		"test-src1": "TEST ",
+		"test-src2": "PAY",
+		"test-src3": "LOAD",
+	}
+
+	azureClient := NewMockAzureAPI()
+	azureClient.GetBlobFunc.SetDefaultHook(func(ctx context.Context, container, name string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader([]byte(contents[name]))), nil
+	})
+	// The staged data buffer is reused between calls
+	var blocks []string
+	azureClient.StageBlockFunc.SetDefaultHook(func(ctx context.Context, container, name, blockID string, data []byte) error {
+		if name != "test-key" {
+			t.Errorf("unexpected key argument. want=%s have=%s", "test-key", name)
+		}
+		blocks = append(blocks, string(data))
+		return nil
+	})
+
+	client := testAzureClient(azureClient, false)
+
+	size, err := client.Compose(context.Background(), "test-key", "test-src1", "test-src2", "test-src3")
+	if err != nil {
+		t.Fatalf("unexpected error composing objects: %s", err)
+	} else if size != 12 {
+		t.Errorf("unexpected size. want=%d have=%d", 12, size)
+	}
+
+	if diff := cmp.Diff([]string{"TEST ", "PAY", "LOAD"}, blocks); diff != "" {
+		t.Errorf("unexpected blocks (-want, +got):\n%s", diff)
+	}
+
+	if calls := azureClient.CommitBlockListFunc.History(); len(calls) != 1 {
+		t.Fatalf("unexpected number of CommitBlockList calls. want=%d have=%d", 1, len(calls))
+	} else if value := calls[0].Arg2; value != "test-key" {
+		t.Errorf("unexpected key argument. want=%s have=%s", "test-key", value)
+	}
+
+	var deletedKeys []string
+	for _, call := range azureClient.DeleteBlobFunc.History() {
+		deletedKeys = append(deletedKeys, call.Arg2)
+	}
+	sort.Strings(deletedKeys)
+	if diff := cmp.Diff([]string{"test-src1", "test-src2", "test-src3"}, deletedKeys); diff != "" {
+		t.Errorf("unexpected deleted keys (-want, +got):\n%s", diff)
+	}
+}
+
+func TestAzureDelete(t *testing.T) {
+	azureClient := NewMockAzureAPI()
+	azureClient.DeleteBlobFunc.PushReturn(nil)
+	azureClient.DeleteBlobFunc.PushReturn(&azureResponseError{StatusCode: http.StatusNotFound, Code: "BlobNotFound"})
+	azureClient.DeleteBlobFunc.PushReturn(&azureResponseError{StatusCode: http.StatusForbidden})
+
+	client := testAzureClient(azureClient, false)
+	if err := client.Delete(context.Background(), "test-key"); err != nil {
+		t.Fatalf("unexpected error deleting key: %s", err)
+	}
+	if err := client.Delete(context.Background(), "test-key"); err != nil {
+		t.Fatalf("unexpected error deleting missing key: %s", err)
+	}
+	if err := client.Delete(context.Background(), "test-key"); err == nil {
+		t.Fatalf("expected error deleting key")
+	}
+}
+
+func TestAzureExpireObjects(t *testing.T) {
+	now := time.Now()
+
+	azureClient := NewMockAzureAPI()
+	azureClient.ListBlobsFunc.PushReturn(azureBlobPage{
+		Blobs: []azureBlob{
+			{Name: "upload-1", LastModified: now.Add(-time.Hour * 48)},
+			{Name: "upload-2", LastModified: now},
+		},
+		NextMarker: "marker",
+	}, nil)
+	azureClient.ListBlobsFunc.PushReturn(azureBlobPage{
+		Blobs: []azureBlob{
+			{Name: "upload-3", LastModified: now.Add(-time.Hour * 72)},
+		},
+	}, nil)
+
+	client := testAzureClient(azureClient, false)
+	if err := client.(Expirer).ExpireObjects(context.Background(), "upload-", time.Hour*24); err != nil {
+		t.Fatalf("unexpected error expiring objects: %s", err)
+	}
+
+	if calls := azureClient.ListBlobsFunc.History(); len(calls) != 2 {
+		t.Fatalf("unexpected number of ListBlobs calls. want=%d have=%d", 2, len(calls))
+	} else if value := calls[0].Arg2; value != "upload-" {
+		t.Errorf("unexpected prefix argument. want=%s have=%s", "upload-", value)
+	} else if value := calls[1].Arg3; value != "marker" {
+		t.Errorf("unexpected marker argument. want=%s have=%s", "marker", value)
+	}
+
+	var deletedKeys []string
+	for _, call := range azureClient.DeleteBlobFunc.History() {
+		deletedKeys = append(deletedKeys, call.Arg2)
+	}
+	sort.Strings(deletedKeys)
+	if diff := cmp.Diff([]string{"upload-1", "upload-3"}, deletedKeys); diff != "" {
+		t.Errorf("unexpected deleted keys (-want, +got):\n%s", diff)
+	}
+}
+
+func testAzureClient(client azureAPI, manageBucket bool) Store {
+	return newLazyStore(newAzureWithClient(client, "test-container", manageBucket, NewOperations(&observation.TestContext, "test", "brittlestore")))
+}
+
+func setAzureBlockSize(t *testing.T, blockSize int) {
+	old := azureBlockSize
+	azureBlockSize = blockSize
+	t.Cleanup(func() { azureBlockSize = old })
+}
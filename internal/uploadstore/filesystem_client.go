@@ -0,0 +1,294 @@
+package uploadstore
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type filesystemStore struct {
+	dir        string
+	operations *Operations
+}
+
+var _ Store = &filesystemStore{}
+var _ Expirer = &filesystemStore{}
+
+type FilesystemConfig struct {
+	// Root is the directory under which a directory for each bucket is created. When
+	// the frontend and worker services run on different hosts, this directory must be
+	// on a volume shared between them.
+	Root string
+}
+
+// tempFilePrefix is the prefix of the files holding the content of in-progress writes. These
+// files are renamed to their final location once the write completes. Temporary files left by
+// interrupted writes are removed by ExpireObjects.
+const tempFilePrefix = ".uploadstore-tmp-"
+
+// newFilesystemFromConfig creates a new store backed by a directory of the local filesystem.
+func newFilesystemFromConfig(ctx context.Context, config Config, operations *Operations) (Store, error) {
+	if config.Filesystem.Root == "" {
+		return nil, errors.New("no filesystem root supplied")
+	}
+
+	return newFilesystemWithDir(filepath.Join(config.Filesystem.Root, config.Bucket), operations), nil
+}
+
+func newFilesystemWithDir(dir string, operations *Operations) *filesystemStore {
+	return &filesystemStore{
+		dir:        dir,
+		operations: operations,
+	}
+}
+
+// Init creates the target directory. The directory is created regardless of the value of
+// the ManageBucket configuration, as there is no provisioning or lifecycle configuration to
+// manage outside of the store.
+func (s *filesystemStore) Init(ctx context.Context) error {
+	if err := os.MkdirAll(s.dir, os.ModePerm); err != nil {
+		return errors.Wrap(err, "failed to create directory")
+	}
+
+	return nil
+}
+
+func (s *filesystemStore) Get(ctx context.Context, key string) (_ io.ReadCloser, err error) {
+	ctx, _, endObservation := s.operations.Get.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+
+	return f, nil
+}
+
+func (s *filesystemStore) Upload(ctx context.Context, key string, r io.Reader) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Upload.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	n, err := s.write(key, func(w io.Writer) (int64, error) {
+		return io.Copy(w, r)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) Compose(ctx context.Context, destination string, sources ...string) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Compose.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("destination", destination),
+		log.String("sources", strings.Join(sources, ", ")),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	defer func() {
+		if err == nil {
+			// Delete sources on success
+			if err := s.deleteSources(sources); err != nil {
+				log15.Error("Failed to delete source objects", "error", err)
+			}
+		}
+	}()
+
+	n, err := s.write(destination, func(w io.Writer) (n int64, _ error) {
+		for _, source := range sources {
+			m, err := s.copyObject(w, source)
+			if err != nil {
+				return 0, err
+			}
+
+			n += m
+		}
+
+		return n, nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to compose objects")
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) Delete(ctx context.Context, key string) (err error) {
+	ctx, _, endObservation := s.operations.Delete.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return errors.Wrap(s.remove(key), "failed to delete object")
+}
+
+func (s *filesystemStore) ExpireObjects(ctx context.Context, prefix string, maxAge time.Duration) (err error) {
+	ctx, trace, endObservation := s.operations.ExpireObjects.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("prefix", prefix),
+		log.String("maxAge", maxAge.String()),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	expirationThreshold := time.Now().Add(-maxAge)
+
+	numExpired := 0
+	if err := filepath.WalkDir(s.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(relativePath)
+		if !strings.HasPrefix(key, prefix) && !strings.HasPrefix(entry.Name(), tempFilePrefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+		if !info.ModTime().Before(expirationThreshold) {
+			return nil
+		}
+
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return errors.Wrap(err, "failed to delete expired object")
+		}
+
+		numExpired++
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "failed to expire objects")
+	}
+	trace.Log(log.Int("numExpiredObjects", numExpired))
+
+	return nil
+}
+
+// write invokes the given function with a writer to a temporary file, then moves the temporary
+// file to the location of the given key. The object is not visible to readers until the given
+// function returns successfully.
+func (s *filesystemStore) write(key string, fn func(w io.Writer) (int64, error)) (_ int64, err error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return 0, err
+	}
+
+	f, err := os.CreateTemp(dir, tempFilePrefix+"*")
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	n, err := fn(f)
+	if closeErr := f.Close(); closeErr != nil {
+		err = errors.Append(err, closeErr)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// copyObject writes the content of the object at the given key into the given writer.
+func (s *filesystemStore) copyObject(w io.Writer, key string) (int64, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return io.Copy(w, f)
+}
+
+func (s *filesystemStore) deleteSources(sources []string) error {
+	for _, source := range sources {
+		if err := s.remove(source); err != nil {
+			return errors.Wrap(err, "failed to delete source object")
+		}
+	}
+
+	return nil
+}
+
+// remove deletes the file holding the object at the given key. Removing an object that
+// does not exist is not an error.
+func (s *filesystemStore) remove(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// path returns the path of the file holding the object at the given key. An error is returned
+// if the key would refer to a file outside of the store's directory.
+func (s *filesystemStore) path(key string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(cleaned) || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", errors.Errorf("illegal object key %q", key)
+	}
+
+	return filepath.Join(s.dir, cleaned), nil
+}
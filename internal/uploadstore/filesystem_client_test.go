@@ -0,0 +1,189 @@
+package uploadstore
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestFilesystemInit(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "test-bucket")
+
+	client := testFilesystemClient(dir)
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	if info, err := os.Stat(dir); err != nil {
+		t.Fatalf("unexpected error statting directory: %s", err)
+	} else if !info.IsDir() {
+		t.Errorf("expected directory to be created")
+	}
+}
+
+func TestFilesystemUploadGet(t *testing.T) {
+	client := testFilesystemClient(t.TempDir())
+
+	size, err := client.Upload(context.Background(), "nested/test-key", bytes.NewReader([]byte("TEST PAYLOAD")))
+	if err != nil {
+		t.Fatalf("unexpected error uploading key: %s", err)
+	} else if size != 12 {
+		t.Errorf("unexpected size. want=%d have=%d", 12, size)
+	}
+
+	if contents := readFilesystemObject(t, client, "nested/test-key"); contents != "TEST PAYLOAD" {
+		t.Errorf("unexpected contents. want=%s have=%s", "TEST PAYLOAD", contents)
+	}
+
+	if _, err := client.Get(context.Background(), "missing-key"); err == nil {
+		t.Fatalf("expected error getting missing key")
+	}
+}
+
+func TestFilesystemIllegalKeys(t *testing.T) {
+	client := testFilesystemClient(t.TempDir())
+
+	for _, key := range []string{"", ".", "..", "../test-key", "nested/../../test-key", "/test-key"} {
+		if _, err := client.Upload(context.Background(), key, bytes.NewReader(nil)); err == nil {
+			t.Errorf("expected error uploading key %q", key)
+		}
+	}
+}
+
+func TestFilesystemCompose(t *testing.T) {
+	dir := t.TempDir()
+	client := testFilesystemClient(dir)
+
+	for key, contents := range map[string]string{
+		"test-src1": "TEST ",
+		"test-src2": "PAY",
+		"test-src3": "LOAD",
+	} {
+		if _, err := client.Upload(context.Background(), key, bytes.NewReader([]byte(contents))); err != nil {
+			t.Fatalf("unexpected error uploading key: %s", err)
+		}
+	}
+
+	size, err := client.Compose(context.Background(), "test-key", "test-src1", "test-src2", "test-src3")
+	if err != nil {
+		t.Fatalf("unexpected error composing objects: %s", err)
+	} else if size != 12 {
+		t.Errorf("unexpected size. want=%d have=%d", 12, size)
+	}
+
+	if contents := readFilesystemObject(t, client, "test-key"); contents != "TEST PAYLOAD" {
+		t.Errorf("unexpected contents. want=%s have=%s", "TEST PAYLOAD", contents)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error reading directory: %s", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "test-key" {
+		t.Errorf("expected source objects to be deleted")
+	}
+}
+
+func TestFilesystemComposeMissingSource(t *testing.T) {
+	dir := t.TempDir()
+	client := testFilesystemClient(dir)
+
+	if _, err := client.Upload(context.Background(), "test-src1", bytes.NewReader([]byte("TEST"))); err != nil {
+		t.Fatalf("unexpected error uploading key: %s", err)
+	}
+
+	if _, err := client.Compose(context.Background(), "test-key", "test-src1", "test-src2"); err == nil {
+		t.Fatalf("expected error composing objects")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error reading directory: %s", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "test-src1" {
+		t.Errorf("expected source objects to be retained and no partial destination to be written")
+	}
+}
+
+func TestFilesystemDelete(t *testing.T) {
+	client := testFilesystemClient(t.TempDir())
+
+	if _, err := client.Upload(context.Background(), "test-key", bytes.NewReader([]byte("TEST PAYLOAD"))); err != nil {
+		t.Fatalf("unexpected error uploading key: %s", err)
+	}
+
+	if err := client.Delete(context.Background(), "test-key"); err != nil {
+		t.Fatalf("unexpected error deleting key: %s", err)
+	}
+	if _, err := client.Get(context.Background(), "test-key"); err == nil {
+		t.Fatalf("expected error getting deleted key")
+	}
+
+	// Deleting a missing object is not an error
+	if err := client.Delete(context.Background(), "test-key"); err != nil {
+		t.Fatalf("unexpected error deleting missing key: %s", err)
+	}
+}
+
+func TestFilesystemExpireObjects(t *testing.T) {
+	dir := t.TempDir()
+	client := testFilesystemClient(dir)
+
+	for _, key := range []string{"upload-1", "upload-2", "other-1", "nested/upload-3"} {
+		if _, err := client.Upload(context.Background(), key, bytes.NewReader([]byte("TEST PAYLOAD"))); err != nil {
+			t.Fatalf("unexpected error uploading key: %s", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, tempFilePrefix+"123"), nil, os.ModePerm); err != nil {
+		t.Fatalf("unexpected error writing file: %s", err)
+	}
+
+	old := time.Now().Add(-time.Hour * 48)
+	for _, name := range []string{"upload-1", "other-1", "nested/upload-3", tempFilePrefix + "123"} {
+		if err := os.Chtimes(filepath.Join(dir, filepath.FromSlash(name)), old, old); err != nil {
+			t.Fatalf("unexpected error changing file times: %s", err)
+		}
+	}
+
+	if err := client.(Expirer).ExpireObjects(context.Background(), "upload-", time.Hour*24); err != nil {
+		t.Fatalf("unexpected error expiring objects: %s", err)
+	}
+
+	for name, expected := range map[string]bool{
+		"upload-1":             false,
+		"upload-2":             true,
+		"other-1":              true,
+		"nested/upload-3":      true,
+		tempFilePrefix + "123": false,
+	} {
+		_, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name)))
+		if exists := err == nil; exists != expected {
+			t.Errorf("unexpected existence of %s. want=%v have=%v", name, expected, exists)
+		}
+	}
+}
+
+func testFilesystemClient(dir string) Store {
+	return newLazyStore(newFilesystemWithDir(dir, NewOperations(&observation.TestContext, "test", "brittlestore")))
+}
+
+func readFilesystemObject(t *testing.T, client Store, key string) string {
+	rc, err := client.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("unexpected error getting key: %s", err)
+	}
+	defer rc.Close()
+
+	contents, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected error reading object: %s", err)
+	}
+
+	return string(contents)
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"io"
 	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 type lazyStore struct {
@@ -13,6 +16,7 @@ type lazyStore struct {
 }
 
 var _ Store = &gcsStore{}
+var _ Expirer = &lazyStore{}
 
 func newLazyStore(store Store) Store {
 	return &lazyStore{store: store}
@@ -54,6 +58,19 @@ func (s *lazyStore) Delete(ctx context.Context, key string) error {
 	return s.store.Delete(ctx, key)
 }
 
+func (s *lazyStore) ExpireObjects(ctx context.Context, prefix string, maxAge time.Duration) error {
+	if err := s.initOnce(ctx); err != nil {
+		return err
+	}
+
+	expirer, ok := s.store.(Expirer)
+	if !ok {
+		return errors.Errorf("upload store %T does not support expiring objects", s.store)
+	}
+
+	return expirer.ExpireObjects(ctx, prefix, maxAge)
+}
+
 // initOnce serializes access to the underlying store's Init method. If the
 // Init method completes successfully, all future calls to this function will
 // no-op.
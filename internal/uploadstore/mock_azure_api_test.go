@@ -0,0 +1,807 @@
+// Code generated by go-mockgen 1.3.1; DO NOT EDIT.
+//
+// This file was generated by running `sg generate` (or `go-mockgen`) at the root of
+// this repository. To add additional mocks to this or another package, add a new entry
+// to the metadata.yaml file in the root of this repository.
+
+package uploadstore
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// MockAzureAPI is a mock implementation of the azureAPI interface (from the
+// package github.com/sourcegraph/sourcegraph/internal/uploadstore) used for
+// unit testing.
+type MockAzureAPI struct {
+	// CommitBlockListFunc is an instance of a mock function object
+	// controlling the behavior of the method CommitBlockList.
+	CommitBlockListFunc *AzureAPICommitBlockListFunc
+	// CreateContainerFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateContainer.
+	CreateContainerFunc *AzureAPICreateContainerFunc
+	// DeleteBlobFunc is an instance of a mock function object controlling
+	// the behavior of the method DeleteBlob.
+	DeleteBlobFunc *AzureAPIDeleteBlobFunc
+	// GetBlobFunc is an instance of a mock function object controlling the
+	// behavior of the method GetBlob.
+	GetBlobFunc *AzureAPIGetBlobFunc
+	// ListBlobsFunc is an instance of a mock function object controlling
+	// the behavior of the method ListBlobs.
+	ListBlobsFunc *AzureAPIListBlobsFunc
+	// StageBlockFunc is an instance of a mock function object controlling
+	// the behavior of the method StageBlock.
+	StageBlockFunc *AzureAPIStageBlockFunc
+}
+
+// NewMockAzureAPI creates a new mock of the azureAPI interface. All methods
+// return zero values for all results, unless overwritten.
+func NewMockAzureAPI() *MockAzureAPI {
+	return &MockAzureAPI{
+		CommitBlockListFunc: &AzureAPICommitBlockListFunc{
+			defaultHook: func(context.Context, string, string, []string) (r0 error) {
+				return
+			},
+		},
+		CreateContainerFunc: &AzureAPICreateContainerFunc{
+			defaultHook: func(context.Context, string) (r0 error) {
+				return
+			},
+		},
+		DeleteBlobFunc: &AzureAPIDeleteBlobFunc{
+			defaultHook: func(context.Context, string, string) (r0 error) {
+				return
+			},
+		},
+		GetBlobFunc: &AzureAPIGetBlobFunc{
+			defaultHook: func(context.Context, string, string) (r0 io.ReadCloser, r1 error) {
+				return
+			},
+		},
+		ListBlobsFunc: &AzureAPIListBlobsFunc{
+			defaultHook: func(context.Context, string, string, string) (r0 azureBlobPage, r1 error) {
+				return
+			},
+		},
+		StageBlockFunc: &AzureAPIStageBlockFunc{
+			defaultHook: func(context.Context, string, string, string, []byte) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockAzureAPI creates a new mock of the azureAPI interface. All
+// methods panic on invocation, unless overwritten.
+func NewStrictMockAzureAPI() *MockAzureAPI {
+	return &MockAzureAPI{
+		CommitBlockListFunc: &AzureAPICommitBlockListFunc{
+			defaultHook: func(context.Context, string, string, []string) error {
+				panic("unexpected invocation of MockAzureAPI.CommitBlockList")
+			},
+		},
+		CreateContainerFunc: &AzureAPICreateContainerFunc{
+			defaultHook: func(context.Context, string) error {
+				panic("unexpected invocation of MockAzureAPI.CreateContainer")
+			},
+		},
+		DeleteBlobFunc: &AzureAPIDeleteBlobFunc{
+			defaultHook: func(context.Context, string, string) error {
+				panic("unexpected invocation of MockAzureAPI.DeleteBlob")
+			},
+		},
+		GetBlobFunc: &AzureAPIGetBlobFunc{
+			defaultHook: func(context.Context, string, string) (io.ReadCloser, error) {
+				panic("unexpected invocation of MockAzureAPI.GetBlob")
+			},
+		},
+		ListBlobsFunc: &AzureAPIListBlobsFunc{
+			defaultHook: func(context.Context, string, string, string) (azureBlobPage, error) {
+				panic("unexpected invocation of MockAzureAPI.ListBlobs")
+			},
+		},
+		StageBlockFunc: &AzureAPIStageBlockFunc{
+			defaultHook: func(context.Context, string, string, string, []byte) error {
+				panic("unexpected invocation of MockAzureAPI.StageBlock")
+			},
+		},
+	}
+}
+
+// surrogateMockAzureAPI is a copy of the azureAPI interface (from the
+// package github.com/sourcegraph/sourcegraph/internal/uploadstore). It is
+// redefined here as it is unexported in the source package.
+type surrogateMockAzureAPI interface {
+	CommitBlockList(context.Context, string, string, []string) error
+	CreateContainer(context.Context, string) error
+	DeleteBlob(context.Context, string, string) error
+	GetBlob(context.Context, string, string) (io.ReadCloser, error)
+	ListBlobs(context.Context, string, string, string) (azureBlobPage, error)
+	StageBlock(context.Context, string, string, string, []byte) error
+}
+
+// NewMockAzureAPIFrom creates a new mock of the MockAzureAPI interface. All
+// methods delegate to the given implementation, unless overwritten.
+func NewMockAzureAPIFrom(i surrogateMockAzureAPI) *MockAzureAPI {
+	return &MockAzureAPI{
+		CommitBlockListFunc: &AzureAPICommitBlockListFunc{
+			defaultHook: i.CommitBlockList,
+		},
+		CreateContainerFunc: &AzureAPICreateContainerFunc{
+			defaultHook: i.CreateContainer,
+		},
+		DeleteBlobFunc: &AzureAPIDeleteBlobFunc{
+			defaultHook: i.DeleteBlob,
+		},
+		GetBlobFunc: &AzureAPIGetBlobFunc{
+			defaultHook: i.GetBlob,
+		},
+		ListBlobsFunc: &AzureAPIListBlobsFunc{
+			defaultHook: i.ListBlobs,
+		},
+		StageBlockFunc: &AzureAPIStageBlockFunc{
+			defaultHook: i.StageBlock,
+		},
+	}
+}
+
+// AzureAPICommitBlockListFunc describes the behavior when the
+// CommitBlockList method of the parent MockAzureAPI instance is invoked.
+type AzureAPICommitBlockListFunc struct {
+	defaultHook func(context.Context, string, string, []string) error
+	hooks       []func(context.Context, string, string, []string) error
+	history     []AzureAPICommitBlockListFuncCall
+	mutex       sync.Mutex
+}
+
+// CommitBlockList delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAzureAPI) CommitBlockList(v0 context.Context, v1 string, v2 string, v3 []string) error {
+	r0 := m.CommitBlockListFunc.nextHook()(v0, v1, v2, v3)
+	m.CommitBlockListFunc.appendCall(AzureAPICommitBlockListFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the CommitBlockList
+// method of the parent MockAzureAPI instance is invoked and the hook queue
+// is empty.
+func (f *AzureAPICommitBlockListFunc) SetDefaultHook(hook func(context.Context, string, string, []string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CommitBlockList method of the parent MockAzureAPI instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *AzureAPICommitBlockListFunc) PushHook(hook func(context.Context, string, string, []string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureAPICommitBlockListFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, string, []string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureAPICommitBlockListFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, string, []string) error {
+		return r0
+	})
+}
+
+func (f *AzureAPICommitBlockListFunc) nextHook() func(context.Context, string, string, []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureAPICommitBlockListFunc) appendCall(r0 AzureAPICommitBlockListFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureAPICommitBlockListFuncCall objects
+// describing the invocations of this function.
+func (f *AzureAPICommitBlockListFunc) History() []AzureAPICommitBlockListFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureAPICommitBlockListFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureAPICommitBlockListFuncCall is an object that describes an invocation
+// of method CommitBlockList on an instance of MockAzureAPI.
+type AzureAPICommitBlockListFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureAPICommitBlockListFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureAPICommitBlockListFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// AzureAPICreateContainerFunc describes the behavior when the
+// CreateContainer method of the parent MockAzureAPI instance is invoked.
+type AzureAPICreateContainerFunc struct {
+	defaultHook func(context.Context, string) error
+	hooks       []func(context.Context, string) error
+	history     []AzureAPICreateContainerFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateContainer delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockAzureAPI) CreateContainer(v0 context.Context, v1 string) error {
+	r0 := m.CreateContainerFunc.nextHook()(v0, v1)
+	m.CreateContainerFunc.appendCall(AzureAPICreateContainerFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the CreateContainer
+// method of the parent MockAzureAPI instance is invoked and the hook queue
+// is empty.
+func (f *AzureAPICreateContainerFunc) SetDefaultHook(hook func(context.Context, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateContainer method of the parent MockAzureAPI instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *AzureAPICreateContainerFunc) PushHook(hook func(context.Context, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureAPICreateContainerFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureAPICreateContainerFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string) error {
+		return r0
+	})
+}
+
+func (f *AzureAPICreateContainerFunc) nextHook() func(context.Context, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureAPICreateContainerFunc) appendCall(r0 AzureAPICreateContainerFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureAPICreateContainerFuncCall objects
+// describing the invocations of this function.
+func (f *AzureAPICreateContainerFunc) History() []AzureAPICreateContainerFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureAPICreateContainerFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureAPICreateContainerFuncCall is an object that describes an invocation
+// of method CreateContainer on an instance of MockAzureAPI.
+type AzureAPICreateContainerFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureAPICreateContainerFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureAPICreateContainerFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// AzureAPIDeleteBlobFunc describes the behavior when the DeleteBlob method
+// of the parent MockAzureAPI instance is invoked.
+type AzureAPIDeleteBlobFunc struct {
+	defaultHook func(context.Context, string, string) error
+	hooks       []func(context.Context, string, string) error
+	history     []AzureAPIDeleteBlobFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteBlob delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockAzureAPI) DeleteBlob(v0 context.Context, v1 string, v2 string) error {
+	r0 := m.DeleteBlobFunc.nextHook()(v0, v1, v2)
+	m.DeleteBlobFunc.appendCall(AzureAPIDeleteBlobFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteBlob method of
+// the parent MockAzureAPI instance is invoked and the hook queue is empty.
+func (f *AzureAPIDeleteBlobFunc) SetDefaultHook(hook func(context.Context, string, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteBlob method of the parent MockAzureAPI instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *AzureAPIDeleteBlobFunc) PushHook(hook func(context.Context, string, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureAPIDeleteBlobFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureAPIDeleteBlobFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, string) error {
+		return r0
+	})
+}
+
+func (f *AzureAPIDeleteBlobFunc) nextHook() func(context.Context, string, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureAPIDeleteBlobFunc) appendCall(r0 AzureAPIDeleteBlobFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureAPIDeleteBlobFuncCall objects
+// describing the invocations of this function.
+func (f *AzureAPIDeleteBlobFunc) History() []AzureAPIDeleteBlobFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureAPIDeleteBlobFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureAPIDeleteBlobFuncCall is an object that describes an invocation of
+// method DeleteBlob on an instance of MockAzureAPI.
+type AzureAPIDeleteBlobFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureAPIDeleteBlobFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureAPIDeleteBlobFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// AzureAPIGetBlobFunc describes the behavior when the GetBlob method of the
+// parent MockAzureAPI instance is invoked.
+type AzureAPIGetBlobFunc struct {
+	defaultHook func(context.Context, string, string) (io.ReadCloser, error)
+	hooks       []func(context.Context, string, string) (io.ReadCloser, error)
+	history     []AzureAPIGetBlobFuncCall
+	mutex       sync.Mutex
+}
+
+// GetBlob delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockAzureAPI) GetBlob(v0 context.Context, v1 string, v2 string) (io.ReadCloser, error) {
+	r0, r1 := m.GetBlobFunc.nextHook()(v0, v1, v2)
+	m.GetBlobFunc.appendCall(AzureAPIGetBlobFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetBlob method of
+// the parent MockAzureAPI instance is invoked and the hook queue is empty.
+func (f *AzureAPIGetBlobFunc) SetDefaultHook(hook func(context.Context, string, string) (io.ReadCloser, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetBlob method of the parent MockAzureAPI instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *AzureAPIGetBlobFunc) PushHook(hook func(context.Context, string, string) (io.ReadCloser, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureAPIGetBlobFunc) SetDefaultReturn(r0 io.ReadCloser, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string) (io.ReadCloser, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureAPIGetBlobFunc) PushReturn(r0 io.ReadCloser, r1 error) {
+	f.PushHook(func(context.Context, string, string) (io.ReadCloser, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureAPIGetBlobFunc) nextHook() func(context.Context, string, string) (io.ReadCloser, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureAPIGetBlobFunc) appendCall(r0 AzureAPIGetBlobFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureAPIGetBlobFuncCall objects describing
+// the invocations of this function.
+func (f *AzureAPIGetBlobFunc) History() []AzureAPIGetBlobFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureAPIGetBlobFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureAPIGetBlobFuncCall is an object that describes an invocation of
+// method GetBlob on an instance of MockAzureAPI.
+type AzureAPIGetBlobFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 io.ReadCloser
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureAPIGetBlobFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureAPIGetBlobFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AzureAPIListBlobsFunc describes the behavior when the ListBlobs method of
+// the parent MockAzureAPI instance is invoked.
+type AzureAPIListBlobsFunc struct {
+	defaultHook func(context.Context, string, string, string) (azureBlobPage, error)
+	hooks       []func(context.Context, string, string, string) (azureBlobPage, error)
+	history     []AzureAPIListBlobsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListBlobs delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockAzureAPI) ListBlobs(v0 context.Context, v1 string, v2 string, v3 string) (azureBlobPage, error) {
+	r0, r1 := m.ListBlobsFunc.nextHook()(v0, v1, v2, v3)
+	m.ListBlobsFunc.appendCall(AzureAPIListBlobsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListBlobs method of
+// the parent MockAzureAPI instance is invoked and the hook queue is empty.
+func (f *AzureAPIListBlobsFunc) SetDefaultHook(hook func(context.Context, string, string, string) (azureBlobPage, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListBlobs method of the parent MockAzureAPI instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *AzureAPIListBlobsFunc) PushHook(hook func(context.Context, string, string, string) (azureBlobPage, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureAPIListBlobsFunc) SetDefaultReturn(r0 azureBlobPage, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string, string) (azureBlobPage, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureAPIListBlobsFunc) PushReturn(r0 azureBlobPage, r1 error) {
+	f.PushHook(func(context.Context, string, string, string) (azureBlobPage, error) {
+		return r0, r1
+	})
+}
+
+func (f *AzureAPIListBlobsFunc) nextHook() func(context.Context, string, string, string) (azureBlobPage, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureAPIListBlobsFunc) appendCall(r0 AzureAPIListBlobsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureAPIListBlobsFuncCall objects
+// describing the invocations of this function.
+func (f *AzureAPIListBlobsFunc) History() []AzureAPIListBlobsFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureAPIListBlobsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureAPIListBlobsFuncCall is an object that describes an invocation of
+// method ListBlobs on an instance of MockAzureAPI.
+type AzureAPIListBlobsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 azureBlobPage
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureAPIListBlobsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureAPIListBlobsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// AzureAPIStageBlockFunc describes the behavior when the StageBlock method
+// of the parent MockAzureAPI instance is invoked.
+type AzureAPIStageBlockFunc struct {
+	defaultHook func(context.Context, string, string, string, []byte) error
+	hooks       []func(context.Context, string, string, string, []byte) error
+	history     []AzureAPIStageBlockFuncCall
+	mutex       sync.Mutex
+}
+
+// StageBlock delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockAzureAPI) StageBlock(v0 context.Context, v1 string, v2 string, v3 string, v4 []byte) error {
+	r0 := m.StageBlockFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.StageBlockFunc.appendCall(AzureAPIStageBlockFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the StageBlock method of
+// the parent MockAzureAPI instance is invoked and the hook queue is empty.
+func (f *AzureAPIStageBlockFunc) SetDefaultHook(hook func(context.Context, string, string, string, []byte) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// StageBlock method of the parent MockAzureAPI instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *AzureAPIStageBlockFunc) PushHook(hook func(context.Context, string, string, string, []byte) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *AzureAPIStageBlockFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, string, string, []byte) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *AzureAPIStageBlockFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, string, string, []byte) error {
+		return r0
+	})
+}
+
+func (f *AzureAPIStageBlockFunc) nextHook() func(context.Context, string, string, string, []byte) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *AzureAPIStageBlockFunc) appendCall(r0 AzureAPIStageBlockFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of AzureAPIStageBlockFuncCall objects
+// describing the invocations of this function.
+func (f *AzureAPIStageBlockFunc) History() []AzureAPIStageBlockFuncCall {
+	f.mutex.Lock()
+	history := make([]AzureAPIStageBlockFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// AzureAPIStageBlockFuncCall is an object that describes an invocation of
+// method StageBlock on an instance of MockAzureAPI.
+type AzureAPIStageBlockFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 []byte
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c AzureAPIStageBlockFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c AzureAPIStageBlockFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
@@ -12,6 +12,8 @@ type Operations struct {
 	Upload  *observation.Operation
 	Compose *observation.Operation
 	Delete  *observation.Operation
+
+	ExpireObjects *observation.Operation
 }
 
 func NewOperations(observationContext *observation.Context, domain, storeName string) *Operations {
@@ -35,5 +37,7 @@ func NewOperations(observationContext *observation.Context, domain, storeName st
 		Upload:  op("Upload"),
 		Compose: op("Compose"),
 		Delete:  op("Delete"),
+
+		ExpireObjects: op("ExpireObjects"),
 	}
 }
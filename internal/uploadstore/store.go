@@ -3,6 +3,7 @@ package uploadstore
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -28,10 +29,21 @@ type Store interface {
 	Delete(ctx context.Context, key string) error
 }
 
+// Expirer is implemented by stores whose backend cannot be configured with native
+// lifecycle rules. Such stores rely on ExpireObjects being invoked periodically to
+// honor the configured TTL.
+type Expirer interface {
+	// ExpireObjects removes all objects with the given prefix that were last modified
+	// more than maxAge ago.
+	ExpireObjects(ctx context.Context, prefix string, maxAge time.Duration) error
+}
+
 var storeConstructors = map[string]func(ctx context.Context, config Config, operations *Operations) (Store, error){
-	"s3":    newS3FromConfig,
-	"minio": newS3FromConfig,
-	"gcs":   newGCSFromConfig,
+	"s3":         newS3FromConfig,
+	"minio":      newS3FromConfig,
+	"gcs":        newGCSFromConfig,
+	"azure":      newAzureFromConfig,
+	"filesystem": newFilesystemFromConfig,
 }
 
 // CreateLazy initialize a new store from the given configuration that is initialized
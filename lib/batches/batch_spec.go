@@ -1,8 +1,12 @@
 package batches
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/lib/batches/env"
 	"github.com/sourcegraph/sourcegraph/lib/batches/overridable"
@@ -40,11 +44,77 @@ type BatchSpec struct {
 }
 
 type ChangesetTemplate struct {
-	Title     string                       `json:"title,omitempty" yaml:"title"`
-	Body      string                       `json:"body,omitempty" yaml:"body"`
-	Branch    string                       `json:"branch,omitempty" yaml:"branch"`
-	Commit    ExpandedGitCommitDescription `json:"commit,omitempty" yaml:"commit"`
-	Published *overridable.BoolOrString    `json:"published" yaml:"published"`
+	Title           string                        `json:"title,omitempty" yaml:"title"`
+	Body            string                        `json:"body,omitempty" yaml:"body"`
+	Branch          string                        `json:"branch,omitempty" yaml:"branch"`
+	Commit          ExpandedGitCommitDescription  `json:"commit,omitempty" yaml:"commit"`
+	Published       *overridable.BoolOrString     `json:"published" yaml:"published"`
+	Reviewers       []string                      `json:"reviewers,omitempty" yaml:"reviewers"`
+	Labels          []string                      `json:"labels,omitempty" yaml:"labels"`
+	Assignees       []string                      `json:"assignees,omitempty" yaml:"assignees"`
+	AutoMerge       any                           `json:"autoMerge,omitempty" yaml:"autoMerge,omitempty"`
+	AutoReapplyDiff any                           `json:"autoReapplyDiff,omitempty" yaml:"autoReapplyDiff,omitempty"`
+	DependsOn       []ChangesetTemplateDependency `json:"dependsOn,omitempty" yaml:"dependsOn"`
+
+	ReviewReminder *ChangesetReviewReminder `json:"reviewReminder,omitempty" yaml:"reviewReminder,omitempty"`
+}
+
+// ChangesetReviewReminder is a comment that is posted on published changesets
+// whose review has been pending for AfterDays days. The reminder is repeated
+// every AfterDays days while the review is pending.
+type ChangesetReviewReminder struct {
+	AfterDays int `json:"afterDays" yaml:"afterDays"`
+	// Body is the body of the comment, which is rendered as a changeset
+	// comment template.
+	Body string `json:"body" yaml:"body"`
+}
+
+// After returns how long the review of a changeset must have been pending
+// before a reminder is posted.
+func (r *ChangesetReviewReminder) After() time.Duration {
+	return time.Duration(r.AfterDays) * 24 * time.Hour
+}
+
+// ChangesetTemplateDependency declares that changesets in the batch change
+// depend on another changeset in the same batch change, and will only be
+// published once that changeset has been merged.
+type ChangesetTemplateDependency struct {
+	// Repository is the name of the repository of the changeset that is
+	// depended on.
+	Repository string `json:"repository" yaml:"repository"`
+	// Branch is the branch of the changeset that is depended on. If empty, all
+	// changesets in the repository are depended on.
+	Branch string `json:"branch,omitempty" yaml:"branch"`
+	// Dependents is a glob pattern matched against the repository names of the
+	// changesets that have the dependency, optionally followed by "@" and a
+	// branch name. If empty, all other changesets have the dependency.
+	Dependents string `json:"dependents,omitempty" yaml:"dependents"`
+	// Gate is what the dependents wait for until the dependency has been
+	// merged: ChangesetDependencyGatePublish, the default, or
+	// ChangesetDependencyGateMerge.
+	Gate string `json:"gate,omitempty" yaml:"gate"`
+}
+
+const (
+	// ChangesetDependencyGatePublish holds back the publication of the
+	// dependents until the dependency has been merged.
+	ChangesetDependencyGatePublish = "publish"
+	// ChangesetDependencyGateMerge publishes the dependents as drafts, so that
+	// they can be reviewed but not merged, until the dependency has been
+	// merged.
+	ChangesetDependencyGateMerge = "merge"
+)
+
+// AutoMergeCondition returns the autoMerge field of the template as a string
+// that can be rendered as a template.
+func (t *ChangesetTemplate) AutoMergeCondition() string {
+	return conditionString(t.AutoMerge)
+}
+
+// AutoReapplyDiffCondition returns the autoReapplyDiff field of the template as a
+// string that can be rendered as a template.
+func (t *ChangesetTemplate) AutoReapplyDiffCondition() string {
+	return conditionString(t.AutoReapplyDiff)
 }
 
 type GitCommitAuthor struct {
@@ -58,14 +128,39 @@ type ExpandedGitCommitDescription struct {
 }
 
 type ImportChangeset struct {
-	Repository  string `json:"repository" yaml:"repository"`
-	ExternalIDs []any  `json:"externalIDs" yaml:"externalIDs"`
+	Repository  string `json:"repository,omitempty" yaml:"repository"`
+	ExternalIDs []any  `json:"externalIDs,omitempty" yaml:"externalIDs"`
+	// Query is a code host search query. The changesets matching it are
+	// imported periodically instead of when the batch spec is applied.
+	Query string `json:"query,omitempty" yaml:"query"`
+	// CodeHost is the URL of the code host Query runs on. If it's empty, the
+	// query runs on all code hosts that support it.
+	CodeHost string `json:"codeHost,omitempty" yaml:"codeHost"`
+}
+
+// IsQuery returns true if the changesets to import are found with a search
+// query on the code host.
+func (ic *ImportChangeset) IsQuery() bool {
+	return ic.Query != ""
+}
+
+// ImportChangesetQueries returns the importChangesets entries in the batch
+// spec that import changesets with a search query.
+func (spec *BatchSpec) ImportChangesetQueries() []ImportChangeset {
+	var queries []ImportChangeset
+	for _, ic := range spec.ImportChangesets {
+		if ic.IsQuery() {
+			queries = append(queries, ic)
+		}
+	}
+	return queries
 }
 
 type WorkspaceConfiguration struct {
 	RootAtLocationOf   string `json:"rootAtLocationOf,omitempty" yaml:"rootAtLocationOf"`
 	In                 string `json:"in,omitempty" yaml:"in"`
 	OnlyFetchWorkspace bool   `json:"onlyFetchWorkspace,omitempty" yaml:"onlyFetchWorkspace"`
+	SplitByCodeOwners  bool   `json:"splitByCodeOwners,omitempty" yaml:"splitByCodeOwners"`
 }
 
 type OnQueryOrRepository struct {
@@ -93,12 +188,19 @@ type Step struct {
 	Env       env.Environment   `json:"env,omitempty" yaml:"env"`
 	Files     map[string]string `json:"files,omitempty" yaml:"files,omitempty"`
 	Outputs   Outputs           `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	Artifacts Artifacts         `json:"artifacts,omitempty" yaml:"artifacts,omitempty"`
 
 	If any `json:"if,omitempty" yaml:"if,omitempty"`
 }
 
 func (s *Step) IfCondition() string {
-	switch v := s.If.(type) {
+	return conditionString(s.If)
+}
+
+// conditionString converts a field that can be either a boolean or a template
+// string into a string that can be rendered as a template.
+func conditionString(condition any) string {
+	switch v := condition.(type) {
 	case bool:
 		if v {
 			return "true"
@@ -118,6 +220,98 @@ type Output struct {
 	Format string `json:"format,omitempty" yaml:"format,omitempty"`
 }
 
+// Artifacts are the artifacts declared by a step, keyed by their name.
+type Artifacts map[string]Artifact
+
+// Artifact is a value produced by a step that is collected into the artifact
+// report of the batch change. Exactly one of Path and Value is set.
+type Artifact struct {
+	// Path is the path of a file in the workspace whose content is the value
+	// of the artifact.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Value is a template string that is rendered like the value of an
+	// Output.
+	Value  string `json:"value,omitempty" yaml:"value,omitempty"`
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+}
+
+// Parse converts the raw content of the artifact into its value according to
+// the Format of the artifact.
+func (a Artifact) Parse(raw string) (any, error) {
+	switch a.Format {
+	case "", "text":
+		return raw, nil
+	case "json":
+		var value any
+		if err := json.Unmarshal([]byte(raw), &value); err != nil {
+			return nil, errors.Wrap(err, "parsing artifact as JSON")
+		}
+		return value, nil
+	default:
+		return nil, errors.Errorf("unknown artifact format %q", a.Format)
+	}
+}
+
+// CollectArtifacts collects the artifacts declared by the step after it ran.
+// Artifacts with a Path are read with readFile, which receives the path
+// relative to the workspace root, and artifacts with a Value are rendered with
+// stepCtx. The values are added to a copy of previous, the artifacts collected
+// from the previous steps, so that the last step declaring an artifact wins.
+// The result is meant to be stored in execution.AfterStepResult.Artifacts and
+// execution.Result.Artifacts.
+func (s *Step) CollectArtifacts(previous map[string]any, stepCtx *template.StepContext, readFile func(path string) ([]byte, error)) (map[string]any, error) {
+	if len(s.Artifacts) == 0 {
+		return previous, nil
+	}
+
+	artifacts := make(map[string]any, len(previous)+len(s.Artifacts))
+	for name, value := range previous {
+		artifacts[name] = value
+	}
+
+	for name, a := range s.Artifacts {
+		var raw string
+		if a.Path != "" {
+			content, err := readFile(a.Path)
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading artifact %q", name)
+			}
+			raw = string(content)
+		} else {
+			var out bytes.Buffer
+			if err := template.RenderStepTemplate("artifacts-"+name, a.Value, &out, stepCtx); err != nil {
+				return nil, errors.Wrapf(err, "rendering artifact %q", name)
+			}
+			raw = out.String()
+		}
+
+		value, err := a.Parse(raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "artifact %q", name)
+		}
+		artifacts[name] = value
+	}
+	return artifacts, nil
+}
+
+// ArtifactNames returns the names of the artifacts declared by the steps of the
+// batch spec, sorted alphabetically.
+func (spec *BatchSpec) ArtifactNames() []string {
+	seen := make(map[string]struct{})
+	var names []string
+	for _, step := range spec.Steps {
+		for name := range step.Artifacts {
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 type TransformChanges struct {
 	Group []Group `json:"group,omitempty" yaml:"group"`
 }
@@ -132,6 +326,7 @@ type ParseBatchSpecOptions struct {
 	AllowArrayEnvironments bool
 	AllowTransformChanges  bool
 	AllowConditionalExec   bool
+	AllowArtifacts         bool
 }
 
 func ParseBatchSpec(data []byte, opts ParseBatchSpecOptions) (*BatchSpec, error) {
@@ -170,10 +365,18 @@ func parseBatchSpec(schema string, data []byte, opts ParseBatchSpecOptions) (*Ba
 		}
 	}
 
-	if len(spec.Steps) != 0 && spec.ChangesetTemplate == nil {
+	// Batch specs that only collect artifacts, such as read-only audits, don't
+	// need to create changesets.
+	if len(spec.Steps) != 0 && spec.ChangesetTemplate == nil && len(spec.ArtifactNames()) == 0 {
 		errs = errors.Append(errs, NewValidationError(errors.New("batch spec includes steps but no changesetTemplate")))
 	}
 
+	if spec.ChangesetTemplate != nil && spec.ChangesetTemplate.ReviewReminder != nil {
+		if err := template.ParseChangesetCommentTemplate(spec.ChangesetTemplate.ReviewReminder.Body); err != nil {
+			errs = errors.Append(errs, NewValidationError(errors.Wrap(err, "parsing changesetTemplate.reviewReminder.body")))
+		}
+	}
+
 	if spec.TransformChanges != nil && !opts.AllowTransformChanges {
 		errs = errors.Append(errs, NewValidationError(errors.New("batch spec includes transformChanges, which is not supported in this Sourcegraph version")))
 	}
@@ -193,6 +396,17 @@ func parseBatchSpec(schema string, data []byte, opts ParseBatchSpecOptions) (*Ba
 		}
 	}
 
+	if !opts.AllowArtifacts {
+		for i, step := range spec.Steps {
+			if len(step.Artifacts) != 0 {
+				errs = errors.Append(errs, NewValidationError(errors.Newf(
+					"step %d in batch spec declares artifacts, which are not supported in this Sourcegraph version",
+					i+1,
+				)))
+			}
+		}
+	}
+
 	return &spec, errs
 }
 
@@ -1,12 +1,18 @@
 package batches
 
 import (
+	"bytes"
 	"fmt"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func TestParseBatchSpec(t *testing.T) {
@@ -34,6 +40,226 @@ changesetTemplate:
 		}
 	})
 
+	t.Run("valid with reviewers, labels, assignees and autoMerge", func(t *testing.T) {
+		const spec = `
+name: hello-world
+description: Add Hello World to READMEs
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  published: false
+  reviewers:
+    - alice
+    - ${{ outputs.owners }}
+  labels: [automated]
+  assignees: [bob]
+  autoMerge: ${{ matches repository.name "github.com/my-org/*" }}
+  autoReapplyDiff: true
+`
+
+		have, err := ParseBatchSpec([]byte(spec), ParseBatchSpecOptions{})
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+
+		want := `${{ matches repository.name "github.com/my-org/*" }}`
+		if cond := have.ChangesetTemplate.AutoMergeCondition(); cond != want {
+			t.Fatalf("wrong autoMerge condition. want=%q, have=%q", want, cond)
+		}
+		if cond := have.ChangesetTemplate.AutoReapplyDiffCondition(); cond != "true" {
+			t.Fatalf("wrong autoReapplyDiff condition. want=%q, have=%q", "true", cond)
+		}
+	})
+
+	t.Run("review reminder", func(t *testing.T) {
+		const specFmt = `
+name: hello-world
+on:
+  - repositoriesMatchingQuery: file:README.md
+steps:
+  - run: echo Hello World | tee -a $(find -name README.md)
+    container: alpine:3
+changesetTemplate:
+  title: Hello World
+  body: My first batch change!
+  branch: hello-world
+  commit:
+    message: Append Hello World to all README.md files
+  reviewReminder:
+    afterDays: %d
+    body: %q
+`
+
+		have, err := ParseBatchSpec([]byte(fmt.Sprintf(specFmt, 7, "${{ changeset.url }} is waiting for review")), ParseBatchSpecOptions{})
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+		if after := have.ChangesetTemplate.ReviewReminder.After(); after != 7*24*time.Hour {
+			t.Fatalf("wrong reminder duration: %s", after)
+		}
+
+		if _, err := ParseBatchSpec([]byte(fmt.Sprintf(specFmt, 0, "Please review")), ParseBatchSpecOptions{}); err == nil {
+			t.Fatal("no error returned for afterDays below minimum")
+		}
+		if _, err := ParseBatchSpec([]byte(fmt.Sprintf(specFmt, 7, "${{ steps.modified_files }}")), ParseBatchSpecOptions{}); err == nil {
+			t.Fatal("no error returned for invalid body template")
+		}
+	})
+
+	t.Run("valid with importChangesets query", func(t *testing.T) {
+		const spec = `
+name: renovate-security
+importChangesets:
+  - repository: github.com/sourcegraph/sourcegraph
+    externalIDs: [120]
+  - query: is:pr author:renovate label:security
+  - query: author:renovate label:security
+    codeHost: https://gitlab.com/
+`
+
+		have, err := ParseBatchSpec([]byte(spec), ParseBatchSpecOptions{})
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+
+		want := []ImportChangeset{
+			{Query: "is:pr author:renovate label:security"},
+			{Query: "author:renovate label:security", CodeHost: "https://gitlab.com/"},
+		}
+		if diff := cmp.Diff(want, have.ImportChangesetQueries()); diff != "" {
+			t.Fatalf("wrong import queries (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("importChangesets with query and externalIDs", func(t *testing.T) {
+		const spec = `
+name: renovate-security
+importChangesets:
+  - repository: github.com/sourcegraph/sourcegraph
+    externalIDs: [120]
+    query: is:pr author:renovate
+`
+
+		if _, err := ParseBatchSpec([]byte(spec), ParseBatchSpecOptions{}); err == nil {
+			t.Fatal("no error returned")
+		}
+	})
+
+	t.Run("valid with artifacts", func(t *testing.T) {
+		const spec = `
+name: deprecated-api-audit
+on:
+  - repositoriesMatchingQuery: lang:go
+steps:
+  - run: ./audit.sh > audit.json
+    container: alpine:3
+    artifacts:
+      audit:
+        path: audit.json
+        format: json
+  - run: grep -c deprecated . || true
+    container: alpine:3
+    artifacts:
+      deprecatedCount:
+        value: ${{ step.stdout }}
+`
+
+		have, err := ParseBatchSpec([]byte(spec), ParseBatchSpecOptions{AllowArtifacts: true})
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+
+		want := []string{"audit", "deprecatedCount"}
+		if diff := cmp.Diff(want, have.ArtifactNames()); diff != "" {
+			t.Fatalf("wrong artifact names (-want +have):\n%s", diff)
+		}
+
+		if _, err := ParseBatchSpec([]byte(spec), ParseBatchSpecOptions{}); err == nil {
+			t.Fatal("no error returned for artifacts that are not allowed")
+		}
+	})
+
+	t.Run("artifact with path and value", func(t *testing.T) {
+		const spec = `
+name: deprecated-api-audit
+on:
+  - repositoriesMatchingQuery: lang:go
+steps:
+  - run: ./audit.sh > audit.json
+    container: alpine:3
+    artifacts:
+      audit:
+        path: audit.json
+        value: ${{ step.stdout }}
+`
+
+		if _, err := ParseBatchSpec([]byte(spec), ParseBatchSpecOptions{AllowArtifacts: true}); err == nil {
+			t.Fatal("no error returned")
+		}
+	})
+
+	t.Run("valid with workspaces split by code owners", func(t *testing.T) {
+		const spec = `
+name: migrate-logger
+on:
+  - repositoriesMatchingQuery: lang:go log.Printf
+workspaces:
+  - in: github.com/sourcegraph/*
+    splitByCodeOwners: true
+steps:
+  - run: ./migrate.sh ${{ join repository.search_result_paths " " }}
+    container: alpine:3
+changesetTemplate:
+  title: Migrate logger
+  body: Migrate the logger in the files owned by ${{ workspace.owner }}
+  branch: migrate-logger-${{ replace workspace.owner "@" "" }}
+  commit:
+    message: Migrate logger
+`
+
+		have, err := ParseBatchSpec([]byte(spec), ParseBatchSpecOptions{AllowTransformChanges: true})
+		if err != nil {
+			t.Fatalf("parsing valid spec returned error: %s", err)
+		}
+
+		want := []WorkspaceConfiguration{{In: "github.com/sourcegraph/*", SplitByCodeOwners: true}}
+		if diff := cmp.Diff(want, have.Workspaces); diff != "" {
+			t.Fatalf("wrong workspaces (-want +have):\n%s", diff)
+		}
+	})
+
+	t.Run("workspaces without rootAtLocationOf or splitByCodeOwners", func(t *testing.T) {
+		const spec = `
+name: migrate-logger
+on:
+  - repositoriesMatchingQuery: lang:go log.Printf
+workspaces:
+  - in: github.com/sourcegraph/*
+    splitByCodeOwners: false
+steps:
+  - run: ./migrate.sh
+    container: alpine:3
+changesetTemplate:
+  title: Migrate logger
+  body: Migrate the logger
+  branch: migrate-logger
+  commit:
+    message: Migrate logger
+`
+
+		if _, err := ParseBatchSpec([]byte(spec), ParseBatchSpecOptions{AllowTransformChanges: true}); err == nil {
+			t.Fatal("no error returned")
+		}
+	})
+
 	t.Run("missing changesetTemplate", func(t *testing.T) {
 		const spec = `
 name: hello-world
@@ -201,6 +427,87 @@ changesetTemplate:
 	})
 }
 
+func TestArtifact_Parse(t *testing.T) {
+	for name, tc := range map[string]struct {
+		artifact Artifact
+		raw      string
+		want     any
+		wantErr  bool
+	}{
+		"text": {
+			artifact: Artifact{Value: "${{ step.stdout }}"},
+			raw:      "deprecated API used\n",
+			want:     "deprecated API used\n",
+		},
+		"json": {
+			artifact: Artifact{Path: "audit.json", Format: "json"},
+			raw:      `{"warnings": 2}`,
+			want:     map[string]any{"warnings": float64(2)},
+		},
+		"invalid json": {
+			artifact: Artifact{Path: "audit.json", Format: "json"},
+			raw:      `{"warnings":`,
+			wantErr:  true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			have, err := tc.artifact.Parse(tc.raw)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("no error returned")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("wrong value (-want +have):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStep_CollectArtifacts(t *testing.T) {
+	step := &Step{
+		Artifacts: Artifacts{
+			"audit":  {Path: "audit.json", Format: "json"},
+			"stdout": {Value: "${{ step.stdout }}"},
+		},
+	}
+	stepCtx := &template.StepContext{
+		Step: execution.StepResult{Stdout: bytes.NewBufferString("3")},
+	}
+	readFile := func(path string) ([]byte, error) {
+		if path != "audit.json" {
+			return nil, errors.Newf("unexpected path %q", path)
+		}
+		return []byte(`{"deprecated": 3}`), nil
+	}
+
+	previous := map[string]any{"audit": "old", "count": "1"}
+	have, err := step.CollectArtifacts(previous, stepCtx, readFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{
+		"audit":  map[string]any{"deprecated": float64(3)},
+		"count":  "1",
+		"stdout": "3",
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("wrong artifacts (-want +have):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]any{"audit": "old", "count": "1"}, previous); diff != "" {
+		t.Fatalf("previous artifacts modified (-want +have):\n%s", diff)
+	}
+
+	step.Artifacts["broken"] = Artifact{Value: "not json", Format: "json"}
+	if _, err := step.CollectArtifacts(nil, stepCtx, readFile); err == nil {
+		t.Fatal("no error returned for invalid JSON artifact")
+	}
+}
+
 func TestOnQueryOrRepository_Branches(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		for name, tc := range map[string]struct {
@@ -80,6 +80,38 @@ type ChangesetSpec struct {
 	Commits []GitCommitDescription `json:"commits,omitempty"`
 
 	Published PublishedValue `json:"published,omitempty"`
+
+	// Reviewers, Labels and Assignees are added to the changeset on the code
+	// host, if the code host supports them.
+	Reviewers []string `json:"reviewers,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+
+	// AutoMerge enables merging the changeset on the code host once all of its
+	// requirements are met, if the code host supports it.
+	AutoMerge bool `json:"autoMerge,omitempty"`
+
+	// AutoReapplyDiff enables re-applying the changes of the changeset on top of
+	// its base branch whenever the base branch moves ahead.
+	AutoReapplyDiff bool `json:"autoReapplyDiff,omitempty"`
+
+	// DependsOn lists the changesets in the same batch change that must be
+	// merged before this changeset is published.
+	DependsOn []ChangesetSpecDependency `json:"dependsOn,omitempty"`
+
+	// Outputs are the outputs of the steps that produced the changes. They are
+	// available as template variables in comments on the changeset.
+	Outputs map[string]any `json:"outputs,omitempty"`
+}
+
+// ChangesetSpecDependency references a changeset in the same batch change by
+// its repository name and, optionally, its head ref. Gate is one of
+// ChangesetDependencyGatePublish and ChangesetDependencyGateMerge; empty means
+// ChangesetDependencyGatePublish.
+type ChangesetSpecDependency struct {
+	Repository string `json:"repository"`
+	HeadRef    string `json:"headRef,omitempty"`
+	Gate       string `json:"gate,omitempty"`
 }
 
 // MarshalJSON overwrites the default behavior of the json lib while unmarshalling
@@ -92,26 +124,38 @@ type ChangesetSpec struct {
 // See https://github.com/sourcegraph/sourcegraph/issues/25968.
 func (c *ChangesetSpec) MarshalJSON() ([]byte, error) {
 	v := struct {
-		BaseRepository string                 `json:"baseRepository,omitempty"`
-		ExternalID     string                 `json:"externalID,omitempty"`
-		BaseRev        string                 `json:"baseRev,omitempty"`
-		BaseRef        string                 `json:"baseRef,omitempty"`
-		HeadRepository string                 `json:"headRepository,omitempty"`
-		HeadRef        string                 `json:"headRef,omitempty"`
-		Title          string                 `json:"title,omitempty"`
-		Body           string                 `json:"body,omitempty"`
-		Commits        []GitCommitDescription `json:"commits,omitempty"`
-		Published      *PublishedValue        `json:"published,omitempty"`
+		BaseRepository  string                    `json:"baseRepository,omitempty"`
+		ExternalID      string                    `json:"externalID,omitempty"`
+		BaseRev         string                    `json:"baseRev,omitempty"`
+		BaseRef         string                    `json:"baseRef,omitempty"`
+		HeadRepository  string                    `json:"headRepository,omitempty"`
+		HeadRef         string                    `json:"headRef,omitempty"`
+		Title           string                    `json:"title,omitempty"`
+		Body            string                    `json:"body,omitempty"`
+		Commits         []GitCommitDescription    `json:"commits,omitempty"`
+		Published       *PublishedValue           `json:"published,omitempty"`
+		Reviewers       []string                  `json:"reviewers,omitempty"`
+		Labels          []string                  `json:"labels,omitempty"`
+		Assignees       []string                  `json:"assignees,omitempty"`
+		AutoMerge       bool                      `json:"autoMerge,omitempty"`
+		AutoReapplyDiff bool                      `json:"autoReapplyDiff,omitempty"`
+		DependsOn       []ChangesetSpecDependency `json:"dependsOn,omitempty"`
 	}{
-		BaseRepository: c.BaseRepository,
-		ExternalID:     c.ExternalID,
-		BaseRev:        c.BaseRev,
-		BaseRef:        c.BaseRef,
-		HeadRepository: c.HeadRepository,
-		HeadRef:        c.HeadRef,
-		Title:          c.Title,
-		Body:           c.Body,
-		Commits:        c.Commits,
+		BaseRepository:  c.BaseRepository,
+		ExternalID:      c.ExternalID,
+		BaseRev:         c.BaseRev,
+		BaseRef:         c.BaseRef,
+		HeadRepository:  c.HeadRepository,
+		HeadRef:         c.HeadRef,
+		Title:           c.Title,
+		Body:            c.Body,
+		Commits:         c.Commits,
+		Reviewers:       c.Reviewers,
+		Labels:          c.Labels,
+		Assignees:       c.Assignees,
+		AutoMerge:       c.AutoMerge,
+		AutoReapplyDiff: c.AutoReapplyDiff,
+		DependsOn:       c.DependsOn,
 	}
 	if !c.Published.Nil() {
 		v.Published = &c.Published
@@ -4,8 +4,10 @@ import (
 	"context"
 	"strings"
 
+	"github.com/gobwas/glob"
 	"github.com/sourcegraph/go-diff/diff"
 
+	"github.com/sourcegraph/sourcegraph/lib/batches/codeowners"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
 	"github.com/sourcegraph/sourcegraph/lib/batches/git"
 	"github.com/sourcegraph/sourcegraph/lib/batches/template"
@@ -33,6 +35,12 @@ type ChangesetSpecInput struct {
 	Template              *ChangesetTemplate              `json:"-"`
 	TransformChanges      *TransformChanges               `json:"-"`
 
+	// Owner is the code owner the workspace was split off for. If set, only
+	// the changes to the files that CodeOwners assigns to Owner are included
+	// in the changeset specs.
+	Owner      string              `json:"-"`
+	CodeOwners *codeowners.Ruleset `json:"-"`
+
 	Result execution.Result
 }
 
@@ -54,6 +62,20 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, features ChangesetSpecFeatur
 			Branch:      strings.TrimPrefix(input.Repository.BaseRef, "refs/heads/"),
 			FileMatches: input.Repository.FileMatches,
 		},
+		Owner: input.Owner,
+	}
+
+	completeDiff := input.Result.Diff
+	if input.Owner != "" {
+		var err error
+		completeDiff, err = filterFileDiffsByOwner(completeDiff, input.Owner, input.CodeOwners)
+		if err != nil {
+			return nil, errors.Wrap(err, "filtering diffs by owner failed")
+		}
+		// None of the changes were made to files of the owner.
+		if completeDiff == "" {
+			return []*ChangesetSpec{}, nil
+		}
 	}
 
 	var authorName string
@@ -92,6 +114,31 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, features ChangesetSpecFeatur
 		return nil, err
 	}
 
+	reviewers, err := template.RenderChangesetTemplateList("reviewers", input.Template.Reviewers, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, err := template.RenderChangesetTemplateList("labels", input.Template.Labels, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	assignees, err := template.RenderChangesetTemplateList("assignees", input.Template.Assignees, tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	autoMerge, err := template.EvalChangesetTemplateCondition("autoMerge", input.Template.AutoMergeCondition(), tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	autoReapplyDiff, err := template.EvalChangesetTemplateCondition("autoReapplyDiff", input.Template.AutoReapplyDiffCondition(), tmplCtx)
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO: As a next step, we should extend the ChangesetTemplateContext to also include
 	// TransformChanges.Group and then change validateGroups and groupFileDiffs to, for each group,
 	// render the branch name *before* grouping the diffs.
@@ -100,6 +147,12 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, features ChangesetSpecFeatur
 		return nil, err
 	}
 
+	// Only include the outputs if there are any, to keep the specs small.
+	var outputs map[string]any
+	if len(input.Result.Outputs) > 0 {
+		outputs = input.Result.Outputs
+	}
+
 	newSpec := func(branch, diff string) (*ChangesetSpec, error) {
 		var published any = nil
 		if input.Template.Published != nil {
@@ -115,6 +168,11 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, features ChangesetSpecFeatur
 			return nil, errOptionalPublishedUnsupported
 		}
 
+		dependsOn, err := renderChangesetDependencies(input.Template.DependsOn, input.Repository.Name, branch, tmplCtx)
+		if err != nil {
+			return nil, err
+		}
+
 		return &ChangesetSpec{
 			BaseRepository: input.Repository.ID,
 			HeadRepository: input.Repository.ID,
@@ -132,7 +190,14 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, features ChangesetSpecFeatur
 					Diff:        diff,
 				},
 			},
-			Published: PublishedValue{Val: published},
+			Published:       PublishedValue{Val: published},
+			Reviewers:       reviewers,
+			Labels:          labels,
+			Assignees:       assignees,
+			AutoMerge:       autoMerge,
+			AutoReapplyDiff: autoReapplyDiff,
+			DependsOn:       dependsOn,
+			Outputs:         outputs,
 		}, nil
 	}
 
@@ -146,7 +211,7 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, features ChangesetSpecFeatur
 		}
 
 		// TODO: Regarding 'defaultBranch', see comment above
-		diffsByBranch, err := groupFileDiffs(input.Result.Diff, defaultBranch, groups)
+		diffsByBranch, err := groupFileDiffs(completeDiff, defaultBranch, groups)
 		if err != nil {
 			return specs, errors.Wrap(err, "grouping diffs failed")
 		}
@@ -159,7 +224,7 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, features ChangesetSpecFeatur
 			specs = append(specs, spec)
 		}
 	} else {
-		spec, err := newSpec(defaultBranch, input.Result.Diff)
+		spec, err := newSpec(defaultBranch, completeDiff)
 		if err != nil {
 			return nil, err
 		}
@@ -169,6 +234,51 @@ func BuildChangesetSpecs(input *ChangesetSpecInput, features ChangesetSpecFeatur
 	return specs, nil
 }
 
+// renderChangesetDependencies returns the dependencies of the changeset in the
+// given repository and branch. A changeset never depends on itself.
+func renderChangesetDependencies(deps []ChangesetTemplateDependency, repoName, branch string, tmplCtx *template.ChangesetTemplateContext) ([]ChangesetSpecDependency, error) {
+	var specDeps []ChangesetSpecDependency
+	for i, dep := range deps {
+		if dep.Dependents != "" {
+			pattern, suffix, _ := strings.Cut(dep.Dependents, "@")
+			g, err := glob.Compile(pattern)
+			if err != nil {
+				return nil, errors.Wrapf(err, "compiling dependents pattern of dependency %d", i)
+			}
+			if !g.Match(repoName) || (suffix != "" && suffix != branch) {
+				continue
+			}
+		}
+
+		depRepo, err := template.RenderChangesetTemplateField("dependsOn.repository", dep.Repository, tmplCtx)
+		if err != nil {
+			return nil, err
+		}
+		depBranch, err := template.RenderChangesetTemplateField("dependsOn.branch", dep.Branch, tmplCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		if depRepo == repoName && (depBranch == "" || depBranch == branch) {
+			continue
+		}
+
+		specDep := ChangesetSpecDependency{Repository: depRepo}
+		switch dep.Gate {
+		case "", ChangesetDependencyGatePublish:
+		case ChangesetDependencyGateMerge:
+			specDep.Gate = ChangesetDependencyGateMerge
+		default:
+			return nil, errors.Errorf("invalid gate %q of dependency %d: must be %q or %q", dep.Gate, i, ChangesetDependencyGatePublish, ChangesetDependencyGateMerge)
+		}
+		if depBranch != "" {
+			specDep.HeadRef = git.EnsureRefPrefix(depBranch)
+		}
+		specDeps = append(specDeps, specDep)
+	}
+	return specDeps, nil
+}
+
 type RepoFetcher func(context.Context, []string) (map[string]string, error)
 
 func BuildImportChangesetSpecs(ctx context.Context, importChangesets []ImportChangeset, repoFetcher RepoFetcher) (specs []*ChangesetSpec, errs error) {
@@ -178,6 +288,11 @@ func BuildImportChangesetSpecs(ctx context.Context, importChangesets []ImportCha
 
 	var repoNames []string
 	for _, ic := range importChangesets {
+		// Changesets matching a query are imported periodically by Sourcegraph
+		// and don't have changeset specs.
+		if ic.IsQuery() {
+			continue
+		}
 		repoNames = append(repoNames, ic.Repository)
 	}
 
@@ -187,6 +302,9 @@ func BuildImportChangesetSpecs(ctx context.Context, importChangesets []ImportCha
 	}
 
 	for _, ic := range importChangesets {
+		if ic.IsQuery() {
+			continue
+		}
 		repoID, ok := repoNameIDs[ic.Repository]
 		if !ok {
 			errs = errors.Append(errs, errors.Newf("repository %q not found", ic.Repository))
@@ -307,3 +425,37 @@ func groupFileDiffs(completeDiff, defaultBranch string, groups []Group) (map[str
 	}
 	return finalDiffsByBranch, nil
 }
+
+// filterFileDiffsByOwner returns the diffs of the files in completeDiff that
+// are owned by owner. An empty string is returned if there are none.
+func filterFileDiffsByOwner(completeDiff, owner string, rules *codeowners.Ruleset) (string, error) {
+	if rules == nil {
+		return "", errors.Newf("no CODEOWNERS rules to determine the files of %q", owner)
+	}
+
+	fileDiffs, err := diff.ParseMultiFileDiff([]byte(completeDiff))
+	if err != nil {
+		return "", err
+	}
+
+	var owned []*diff.FileDiff
+	for _, f := range fileDiffs {
+		name := f.NewName
+		if name == "/dev/null" {
+			name = f.OrigName
+		}
+
+		if rules.Owner(name) == owner {
+			owned = append(owned, f)
+		}
+	}
+	if len(owned) == 0 {
+		return "", nil
+	}
+
+	printed, err := diff.PrintMultiFileDiff(owned)
+	if err != nil {
+		return "", errors.Wrap(err, "printing multi file diff failed")
+	}
+	return string(printed), nil
+}
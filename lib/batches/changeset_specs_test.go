@@ -7,6 +7,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/mitchellh/copystructure"
 
+	"github.com/sourcegraph/sourcegraph/lib/batches/codeowners"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
 	"github.com/sourcegraph/sourcegraph/lib/batches/git"
 	"github.com/sourcegraph/sourcegraph/lib/batches/overridable"
@@ -181,6 +182,97 @@ func TestCreateChangesetSpecs(t *testing.T) {
 			want:     nil,
 			wantErr:  errOptionalPublishedUnsupported.Error(),
 		},
+		{
+			name: "reviewers, labels, assignees and auto-merge",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Published = parsePublishedFieldString(t, "false")
+				input.Template.Reviewers = []string{"alice", "${{ outputs.owners }}"}
+				input.Template.Labels = []string{"automated", `${{ if eq repository.branch "my-cool-base-ref" }}base${{ end }}`}
+				input.Template.Assignees = []string{"${{ outputs.missing }}"}
+				input.Template.AutoMerge = `${{ matches repository.name "github.com/sourcegraph/*" }}`
+				input.Result.Outputs = map[string]any{"owners": "bob,my-org/team"}
+			}),
+			features: featuresAllEnabled,
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.Reviewers = []string{"alice", "bob", "my-org/team"}
+					s.Labels = []string{"automated", "base"}
+					s.AutoMerge = true
+					s.Outputs = map[string]any{"owners": "bob,my-org/team"}
+				}),
+			},
+			wantErr: "",
+		},
+		{
+			name: "auto-reapply-diff",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Published = parsePublishedFieldString(t, "false")
+				input.Template.AutoReapplyDiff = true
+			}),
+			features: featuresAllEnabled,
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.AutoReapplyDiff = true
+				}),
+			},
+			wantErr: "",
+		},
+		{
+			name: "dependencies",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Published = parsePublishedFieldString(t, "false")
+				input.Template.DependsOn = []ChangesetTemplateDependency{
+					{Repository: "github.com/sourcegraph/lib"},
+					{Repository: "github.com/sourcegraph/api", Branch: "${{ outputs.branch }}", Dependents: "github.com/sourcegraph/src-*"},
+					{Repository: "github.com/sourcegraph/web", Dependents: "github.com/sourcegraph/*@other-branch"},
+					{Repository: "github.com/sourcegraph/docs", Dependents: "github.com/other/*"},
+					{Repository: "github.com/sourcegraph/src-cli", Branch: "my-branch"},
+					{Repository: "github.com/sourcegraph/src-cli", Branch: "other-branch", Gate: "merge"},
+				}
+				input.Result.Outputs = map[string]any{"branch": "bump-lib"}
+			}),
+			features: featuresAllEnabled,
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.DependsOn = []ChangesetSpecDependency{
+						{Repository: "github.com/sourcegraph/lib"},
+						{Repository: "github.com/sourcegraph/api", HeadRef: "refs/heads/bump-lib"},
+						{Repository: "github.com/sourcegraph/src-cli", HeadRef: "refs/heads/other-branch", Gate: "merge"},
+					}
+					s.Outputs = map[string]any{"branch": "bump-lib"}
+				}),
+			},
+			wantErr: "",
+		},
+		{
+			name: "split by code owners",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Template.Published = parsePublishedFieldString(t, "false")
+				input.Template.Branch = `my-branch-${{ replace workspace.owner "@my-org/" "" }}`
+				input.Owner = "@my-org/frontend"
+				input.CodeOwners = parseCodeOwners(t, "*.go @my-org/backend\n*.js @my-org/frontend\n")
+				input.Result.Diff = ownedDiffGo + ownedDiffJS
+			}),
+			features: featuresAllEnabled,
+			want: []*ChangesetSpec{
+				specWith(defaultChangesetSpec, func(s *ChangesetSpec) {
+					s.HeadRef = "refs/heads/my-branch-frontend"
+					s.Commits[0].Diff = ownedDiffJS
+				}),
+			},
+			wantErr: "",
+		},
+		{
+			name: "split by code owners without owned changes",
+			input: inputWith(defaultInput, func(input *ChangesetSpecInput) {
+				input.Owner = "@my-org/docs"
+				input.CodeOwners = parseCodeOwners(t, "*.go @my-org/backend\n*.md @my-org/docs\n")
+				input.Result.Diff = ownedDiffGo + ownedDiffJS
+			}),
+			features: featuresAllEnabled,
+			want:     []*ChangesetSpec{},
+			wantErr:  "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -204,6 +296,34 @@ func TestCreateChangesetSpecs(t *testing.T) {
 	}
 }
 
+const ownedDiffGo = `diff --git cmd/main.go cmd/main.go
+index 19d6416..c825d65 100644
+--- cmd/main.go
++++ cmd/main.go
+@@ -1,1 +1,1 @@
+-package foo
++package main
+`
+
+const ownedDiffJS = `diff --git web/index.js web/index.js
+new file mode 100644
+index 0000000..1bd79fb
+--- /dev/null
++++ web/index.js
+@@ -0,0 +1,1 @@
++console.log("hello")
+`
+
+func parseCodeOwners(t *testing.T, content string) *codeowners.Ruleset {
+	t.Helper()
+
+	rs, err := codeowners.Parse(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rs
+}
+
 func TestGroupFileDiffs(t *testing.T) {
 	diff1 := `diff --git 1/1.txt 1/1.txt
 new file mode 100644
@@ -0,0 +1,141 @@
+// Package codeowners parses CODEOWNERS files and determines which owners
+// files in a repository belong to.
+package codeowners
+
+import (
+	"bufio"
+	"strings"
+
+	"github.com/grafana/regexp"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Paths are the locations in a repository, in order of precedence, at which
+// code hosts look for a CODEOWNERS file.
+var Paths = []string{
+	".github/CODEOWNERS",
+	"CODEOWNERS",
+	"docs/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+}
+
+// Rule assigns the files matching Pattern to Owners.
+type Rule struct {
+	Pattern string
+	Owners  []string
+
+	re *regexp.Regexp
+}
+
+// Ruleset is a parsed CODEOWNERS file.
+type Ruleset struct {
+	Rules []*Rule
+}
+
+// Parse parses the content of a CODEOWNERS file. Sections, as supported by
+// GitLab, are ignored and their rules are treated like all others.
+func Parse(content string) (*Ruleset, error) {
+	rs := &Ruleset{}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		var owners []string
+		for _, f := range fields[1:] {
+			if strings.HasPrefix(f, "#") {
+				break
+			}
+			owners = append(owners, f)
+		}
+
+		re, err := compilePattern(fields[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d: invalid pattern %q", lineNumber, fields[0])
+		}
+		rs.Rules = append(rs.Rules, &Rule{Pattern: fields[0], Owners: owners, re: re})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rs, nil
+}
+
+// Match returns the rule that determines the owners of the file at the given
+// path, which is relative to the root of the repository. As on the code hosts,
+// the last matching rule wins. Nil is returned if no rule matches.
+func (rs *Ruleset) Match(path string) *Rule {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(rs.Rules) - 1; i >= 0; i-- {
+		if rs.Rules[i].re.MatchString(path) {
+			return rs.Rules[i]
+		}
+	}
+	return nil
+}
+
+// Owner returns the owners of the file at the given path, separated by spaces,
+// or an empty string if the file isn't owned by anyone.
+func (rs *Ruleset) Owner(path string) string {
+	r := rs.Match(path)
+	if r == nil {
+		return ""
+	}
+	return strings.Join(r.Owners, " ")
+}
+
+// compilePattern translates a CODEOWNERS pattern, which follows the rules of
+// .gitignore files, into a regular expression matching the paths of the files
+// it applies to.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	// Patterns that contain a slash anywhere but at the end are relative to
+	// the root of the repository, all others can match at any depth.
+	trimmed := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(trimmed, "/")
+	trimmed = strings.TrimPrefix(trimmed, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(.*/)?")
+	}
+
+	for i := 0; i < len(trimmed); i++ {
+		switch c := trimmed[i]; {
+		case strings.HasPrefix(trimmed[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(trimmed[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	switch {
+	case strings.HasSuffix(pattern, "/"):
+		// Patterns ending in a slash only match directories, so only the
+		// files inside of them are owned.
+		b.WriteString("/.*")
+	case strings.HasSuffix(pattern, "/*"):
+		// "docs/*" only owns the files directly in docs, not the ones in
+		// nested directories.
+	default:
+		// A pattern matching a directory owns everything inside it.
+		b.WriteString("(/.*)?")
+	}
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
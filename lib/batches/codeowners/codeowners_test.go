@@ -0,0 +1,68 @@
+package codeowners
+
+import (
+	"testing"
+)
+
+func TestRuleset_Owner(t *testing.T) {
+	const input = `# Default owners.
+*       @sourcegraph/everyone
+
+*.js    @sourcegraph/frontend # inline comment
+**/logs @sourcegraph/logging
+/build/logs/ @sourcegraph/devops
+docs/*  docs@example.com
+apps/   @sourcegraph/apps
+/scripts/**/*.sh @sourcegraph/devops @sourcegraph/security
+
+[Generated]
+/generated/
+
+/dev/nested?.go @sourcegraph/dev
+`
+
+	rs, err := Parse(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"README.md":                         "@sourcegraph/everyone",
+		"web/src/index.js":                  "@sourcegraph/frontend",
+		"index.js":                          "@sourcegraph/frontend",
+		"build/logs/output.txt":             "@sourcegraph/devops",
+		"build/logs/nested/output.txt":      "@sourcegraph/devops",
+		"other/build/logs/output.txt":       "@sourcegraph/logging",
+		"docs/getting-started.md":           "docs@example.com",
+		"docs/build-app/troubleshooting.md": "@sourcegraph/everyone",
+		"apps/main.go":                      "@sourcegraph/apps",
+		"cmd/apps/main.go":                  "@sourcegraph/apps",
+		"apps.go":                           "@sourcegraph/everyone",
+		"deep/down/logs/today.log":          "@sourcegraph/logging",
+		"scripts/deploy.sh":                 "@sourcegraph/devops @sourcegraph/security",
+		"scripts/ci/deploy.sh":              "@sourcegraph/devops @sourcegraph/security",
+		"other/scripts/deploy.sh":           "@sourcegraph/everyone",
+		"generated/schema.go":               "",
+		"/dev/nested1.go":                   "@sourcegraph/dev",
+		"dev/nested12.go":                   "@sourcegraph/everyone",
+	}
+	for path, want := range tests {
+		if have := rs.Owner(path); have != want {
+			t.Errorf("wrong owner for %q: have=%q want=%q", path, have, want)
+		}
+	}
+}
+
+func TestRuleset_OwnerNoMatch(t *testing.T) {
+	rs, err := Parse("/docs/ @sourcegraph/docs\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if have := rs.Owner("README.md"); have != "" {
+		t.Fatalf("unexpected owner %q", have)
+	}
+	if have := rs.Match("README.md"); have != nil {
+		t.Fatalf("unexpected rule %+v", have)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"sort"
 
 	"github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/codeowners"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
 	"github.com/sourcegraph/sourcegraph/lib/batches/template"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -183,8 +184,13 @@ func KeyForWorkspace(batchChangeAttributes *template.BatchChangeAttributes, r ba
 	return executionKey
 }
 
-func ChangesetSpecsFromCache(spec *batches.BatchSpec, r batches.Repository, result execution.Result) ([]*batches.ChangesetSpec, error) {
-	if result.Diff == "" {
+// ChangesetSpecsFromCache builds the changeset specs for the given execution
+// result. If the workspace was split off for a code owner, owner and
+// codeOwners are used to only include the changes to the owner's files.
+func ChangesetSpecsFromCache(spec *batches.BatchSpec, r batches.Repository, result execution.Result, owner string, codeOwners *codeowners.Ruleset) ([]*batches.ChangesetSpec, error) {
+	// Batch specs without a changesetTemplate only collect artifacts, so we
+	// don't create changesets for their diffs.
+	if result.Diff == "" || spec.ChangesetTemplate == nil {
 		return []*batches.ChangesetSpec{}, nil
 	}
 
@@ -198,6 +204,8 @@ func ChangesetSpecsFromCache(spec *batches.BatchSpec, r batches.Repository, resu
 		},
 		Template:         spec.ChangesetTemplate,
 		TransformChanges: spec.TransformChanges,
+		Owner:            owner,
+		CodeOwners:       codeOwners,
 		Result:           result,
 	}
 
@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
+)
+
+// GlobalStepsCacheKey implements the Keyer interface for the result of
+// executing the Steps up to and including the step with index StepIndex in a
+// repository workspace. Unlike StepsCacheKey, the key is content-addressed:
+// it doesn't depend on the batch spec, batch change or user the steps are
+// executed for, so that identical steps in different batch changes share
+// their results.
+//
+// Only the repository, commit, workspace and the steps themselves make up the
+// key. The attributes of the batch change, the base branch and the search
+// result paths are only included if the steps reference them in templates.
+type GlobalStepsCacheKey struct {
+	*ExecutionKey
+	StepIndex int
+}
+
+// Cacheable returns true if the result of the steps can be shared across batch
+// changes and users. That is only the case if the containers of all steps are
+// pinned by digest, since a tag may resolve to a different image for
+// different executions. Tags are not resolved to digests, so steps using them
+// are never shared.
+func (key GlobalStepsCacheKey) Cacheable() bool {
+	if key.StepIndex < 0 || key.StepIndex >= len(key.ExecutionKey.Steps) {
+		return false
+	}
+	for _, step := range key.ExecutionKey.Steps[0 : key.StepIndex+1] {
+		if !isPinnedByDigest(step.Container) {
+			return false
+		}
+	}
+	return true
+}
+
+// Key converts the key into a string form that can be used to uniquely identify
+// the cache key in a more concise form than the entire Task.
+func (key GlobalStepsCacheKey) Key() (string, error) {
+	steps := key.ExecutionKey.Steps[0 : key.StepIndex+1]
+
+	envs, err := resolveStepsEnvironment([]string{}, steps)
+	if err != nil {
+		return "", err
+	}
+
+	rawSteps, err := json.Marshal(steps)
+	if err != nil {
+		return "", err
+	}
+	references := func(name string) bool {
+		return strings.Contains(string(rawSteps), name)
+	}
+
+	globalKey := struct {
+		Repository         string
+		Commit             string
+		Path               string
+		OnlyFetchWorkspace bool
+		Steps              []batches.Step
+		Environments       []map[string]string
+
+		BaseRef               string                          `json:",omitempty"`
+		FileMatches           []string                        `json:",omitempty"`
+		BatchChangeAttributes *template.BatchChangeAttributes `json:",omitempty"`
+	}{
+		Repository:         key.ExecutionKey.Repository.Name,
+		Commit:             key.ExecutionKey.Repository.BaseRev,
+		Path:               key.ExecutionKey.Path,
+		OnlyFetchWorkspace: key.ExecutionKey.OnlyFetchWorkspace,
+		Steps:              steps,
+		Environments:       envs,
+	}
+	if references("branch") {
+		globalKey.BaseRef = key.ExecutionKey.Repository.BaseRef
+	}
+	if references("search_result_paths") {
+		globalKey.FileMatches = key.ExecutionKey.Repository.FileMatches
+	}
+	if references("batch_change") {
+		globalKey.BatchChangeAttributes = key.ExecutionKey.BatchChangeAttributes
+	}
+
+	raw, err := json.Marshal(globalKey)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(raw)
+	return fmt.Sprintf("global-%s-step-%d", base64.RawURLEncoding.EncodeToString(hash[:16]), key.StepIndex), nil
+}
+
+func (key GlobalStepsCacheKey) Slug() string {
+	return SlugForRepo(key.Repository.Name, key.Repository.BaseRev)
+}
+
+func isPinnedByDigest(container string) bool {
+	return strings.Contains(container, "@sha256:")
+}
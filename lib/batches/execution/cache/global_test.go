@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
+)
+
+func TestGlobalStepsCacheKey(t *testing.T) {
+	parseSteps := func(t *testing.T, raw string) []batches.Step {
+		t.Helper()
+		var steps []batches.Step
+		if err := yaml.Unmarshal([]byte(raw), &steps); err != nil {
+			t.Fatal(err)
+		}
+		return steps
+	}
+
+	pinned := parseSteps(t, `
+- run: comby -in-place 'fmt.Sprintf(":[a]")' ':[a]' .go
+  container: comby/comby@sha256:b47ce282778bfea7f80d45f5ef0cc546ba0d6347baccebaf171a7866143b2593
+- run: gofmt -w .
+  container: golang@sha256:4a3e1d7b2e8c2f1f1b1e7d0f7b6b8c7e8d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a
+`)
+
+	newKey := func(steps []batches.Step, batchChange string) *ExecutionKey {
+		return &ExecutionKey{
+			Repository: batches.Repository{
+				ID:          "graphql-id",
+				Name:        "github.com/sourcegraph/src-cli",
+				BaseRef:     "refs/heads/main",
+				BaseRev:     "c0mmit",
+				FileMatches: []string{"a.go"},
+			},
+			Steps: steps,
+			BatchChangeAttributes: &template.BatchChangeAttributes{
+				Name: batchChange,
+			},
+		}
+	}
+
+	mustKey := func(t *testing.T, key GlobalStepsCacheKey) string {
+		t.Helper()
+		k, err := key.Key()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return k
+	}
+
+	t.Run("shared across batch changes", func(t *testing.T) {
+		a := GlobalStepsCacheKey{ExecutionKey: newKey(pinned, "first"), StepIndex: 1}
+		b := GlobalStepsCacheKey{ExecutionKey: newKey(pinned, "second"), StepIndex: 1}
+		if !a.Cacheable() {
+			t.Fatal("steps with pinned containers are not cacheable")
+		}
+		if mustKey(t, a) != mustKey(t, b) {
+			t.Fatal("keys differ across batch changes")
+		}
+
+		// The key only depends on the steps up to StepIndex.
+		first := GlobalStepsCacheKey{ExecutionKey: newKey(pinned, "first"), StepIndex: 0}
+		if mustKey(t, first) == mustKey(t, a) {
+			t.Fatal("keys for different step indexes are equal")
+		}
+	})
+
+	t.Run("commit changes key", func(t *testing.T) {
+		a := newKey(pinned, "first")
+		b := newKey(pinned, "first")
+		b.Repository.BaseRev = "0ther"
+		if mustKey(t, GlobalStepsCacheKey{ExecutionKey: a, StepIndex: 1}) == mustKey(t, GlobalStepsCacheKey{ExecutionKey: b, StepIndex: 1}) {
+			t.Fatal("keys are equal for different commits")
+		}
+	})
+
+	t.Run("referenced batch change attributes", func(t *testing.T) {
+		steps := parseSteps(t, `
+- run: echo ${{ batch_change.name }} > name.txt
+  container: alpine@sha256:4ed1812024ed78962a34727137627e8854a3b414d19e2c35a1dc727a47e16fba
+`)
+		a := GlobalStepsCacheKey{ExecutionKey: newKey(steps, "first"), StepIndex: 0}
+		b := GlobalStepsCacheKey{ExecutionKey: newKey(steps, "second"), StepIndex: 0}
+		if mustKey(t, a) == mustKey(t, b) {
+			t.Fatal("keys are equal although the steps reference the batch change")
+		}
+	})
+
+	t.Run("unpinned container", func(t *testing.T) {
+		steps := parseSteps(t, `
+- run: echo hello
+  container: alpine@sha256:4ed1812024ed78962a34727137627e8854a3b414d19e2c35a1dc727a47e16fba
+- run: echo world
+  container: alpine:3
+`)
+		if !(GlobalStepsCacheKey{ExecutionKey: newKey(steps, "first"), StepIndex: 0}).Cacheable() {
+			t.Fatal("pinned first step is not cacheable")
+		}
+		if (GlobalStepsCacheKey{ExecutionKey: newKey(steps, "first"), StepIndex: 1}).Cacheable() {
+			t.Fatal("unpinned step is cacheable")
+		}
+	})
+}
@@ -57,6 +57,9 @@ type AfterStepResult struct {
 	Diff string `json:"diff"`
 	// Outputs is a copy of the Outputs after executing the Step.
 	Outputs map[string]any `json:"outputs"`
+	// Artifacts are the artifacts produced by the Step and all previous
+	// steps, as collected by (*batches.Step).CollectArtifacts.
+	Artifacts map[string]any `json:"artifacts,omitempty"`
 	// PreviousStepResult is the StepResult of the step before Step, if
 	// StepIndex != 0.
 	PreviousStepResult StepResult `json:"previousStepResult"`
@@ -73,6 +76,11 @@ type Result struct {
 	// Outputs are the outputs produced by all steps.
 	Outputs map[string]any `json:"outputs"`
 
+	// Artifacts are the artifacts produced by all steps, keyed by their
+	// name, as collected by (*batches.Step).CollectArtifacts. If multiple
+	// steps declare the same artifact, the value of the last step wins.
+	Artifacts map[string]any `json:"artifacts,omitempty"`
+
 	// Path relative to the repository's root directory in which the steps
 	// have been executed.
 	// No leading slashes. Root directory is blank string.
@@ -95,7 +95,10 @@ const BatchSpecJSON = `{
         "type": "object",
         "description": "Configuration for how to setup workspaces in repositories",
         "additionalProperties": false,
-        "required": ["rootAtLocationOf"],
+        "anyOf": [
+          { "required": ["rootAtLocationOf"] },
+          { "required": ["splitByCodeOwners"], "properties": { "splitByCodeOwners": { "const": true } } }
+        ],
         "properties": {
           "rootAtLocationOf": {
             "type": "string",
@@ -111,6 +114,11 @@ const BatchSpecJSON = `{
             "type": "boolean",
             "description": "If this is true only the files in the workspace (and additional .gitignore) are downloaded instead of an archive of the full repository.",
             "default": false
+          },
+          "splitByCodeOwners": {
+            "type": "boolean",
+            "description": "If this is true, the workspaces are split up by the owners of the files matched by the repositoriesMatchingQuery search, as defined in the CODEOWNERS file of the repository. Each owner gets a workspace, and thus a changeset, that only contains the changes to the files they own.",
+            "default": false
           }
         }
       }
@@ -155,6 +163,33 @@ const BatchSpecJSON = `{
               }
             }
           },
+          "artifacts": {
+            "type": ["object", "null"],
+            "description": "Artifacts of this step that are collected from every workspace into a report of the batch change, also for workspaces that produce no changes.",
+            "additionalProperties": {
+              "title": "ArtifactDefinition",
+              "type": "object",
+              "additionalProperties": false,
+              "oneOf": [{ "required": ["path"] }, { "required": ["value"] }],
+              "properties": {
+                "path": {
+                  "type": "string",
+                  "description": "The path of a file in the workspace, relative to the workspace root, whose content after executing the step is the value of the artifact.",
+                  "examples": ["report.json", "audit/warnings.txt"]
+                },
+                "value": {
+                  "type": "string",
+                  "description": "The value of the artifact, which can be a template string.",
+                  "examples": ["${{ step.stdout }}", "${{ outputs.warnings }}"]
+                },
+                "format": {
+                  "type": "string",
+                  "description": "The format of the artifact. If set to 'json', the value is parsed as JSON. If not set, 'text' is assumed to be the format.",
+                  "enum": ["json", "text"]
+                }
+              }
+            }
+          },
           "env": {
             "description": "Environment variables to set in the step environment.",
             "oneOf": [
@@ -258,31 +293,57 @@ const BatchSpecJSON = `{
       "type": ["array", "null"],
       "description": "Import existing changesets on code hosts.",
       "items": {
-        "type": "object",
-        "additionalProperties": false,
-        "required": ["repository", "externalIDs"],
-        "properties": {
-          "repository": {
-            "type": "string",
-            "description": "The repository name as configured on your Sourcegraph instance."
-          },
-          "externalIDs": {
-            "type": ["array", "null"],
-            "description": "The changesets to import from the code host. For GitHub this is the PR number, for GitLab this is the MR number, for Bitbucket Server this is the PR number.",
-            "uniqueItems": true,
-            "items": {
-              "oneOf": [
-                {
-                  "type": "string"
+        "title": "ImportChangeset",
+        "oneOf": [
+          {
+            "title": "ImportChangesetsByID",
+            "type": "object",
+            "description": "Specific changesets in a repository that are imported.",
+            "additionalProperties": false,
+            "required": ["repository", "externalIDs"],
+            "properties": {
+              "repository": {
+                "type": "string",
+                "description": "The repository name as configured on your Sourcegraph instance."
+              },
+              "externalIDs": {
+                "type": ["array", "null"],
+                "description": "The changesets to import from the code host. For GitHub this is the PR number, for GitLab this is the MR number, for Bitbucket Server this is the PR number.",
+                "uniqueItems": true,
+                "items": {
+                  "oneOf": [
+                    {
+                      "type": "string"
+                    },
+                    {
+                      "type": "integer"
+                    }
+                  ]
                 },
-                {
-                  "type": "integer"
-                }
-              ]
-            },
-            "examples": [120, "120"]
+                "examples": [120, "120"]
+              }
+            }
+          },
+          {
+            "title": "ImportChangesetsByQuery",
+            "type": "object",
+            "description": "A code host search query. The pull requests and merge requests matching it on GitHub and GitLab are periodically imported, and detached again once they no longer match.",
+            "additionalProperties": false,
+            "required": ["query"],
+            "properties": {
+              "query": {
+                "type": "string",
+                "description": "The search query, using the pull request search syntax of GitHub. On GitLab, the qualifiers author, assignee, label, is:open, is:closed, is:merged and free text are supported.",
+                "examples": ["is:pr author:renovate label:security"]
+              },
+              "codeHost": {
+                "type": "string",
+                "description": "The URL of the code host to run the query on. If omitted, the query runs on every GitHub and GitLab code host that supports it.",
+                "examples": ["https://github.com/", "https://gitlab.example.com/"]
+              }
+            }
           }
-        }
+        ]
       }
     },
     "changesetTemplate": {
@@ -334,6 +395,110 @@ const BatchSpecJSON = `{
             }
           }
         },
+        "reviewers": {
+          "type": "array",
+          "description": "The users (or teams, as \"org/team\" on GitHub) whose review is requested on the changeset. Each entry supports templating; entries that render to an empty string are ignored, and entries that render to a comma- or newline-separated list are split. Supported on GitHub, GitLab, Bitbucket Server and Bitbucket Cloud.",
+          "items": {
+            "type": "string"
+          },
+          "examples": [["alice", "my-org/reviewers"], ["${{ outputs.codeOwners }}"]]
+        },
+        "labels": {
+          "type": "array",
+          "description": "The labels to add to the changeset. Each entry supports templating, like reviewers. Supported on GitHub and GitLab.",
+          "items": {
+            "type": "string"
+          },
+          "examples": [["automated", "dependencies"]]
+        },
+        "assignees": {
+          "type": "array",
+          "description": "The users to assign to the changeset. Each entry supports templating, like reviewers. Supported on GitHub and GitLab.",
+          "items": {
+            "type": "string"
+          },
+          "examples": [["alice"]]
+        },
+        "autoMerge": {
+          "oneOf": [
+            {
+              "type": "boolean"
+            },
+            {
+              "type": "string"
+            },
+            {
+              "type": "null"
+            }
+          ],
+          "description": "Whether the changeset should be merged by the code host once all of its requirements, such as passing checks and approvals, are met. Supports templating. The value 'true' is interpreted as true. Supported on GitHub and GitLab.",
+          "examples": [true, "${{ matches repository.name \"github.com/my-org/*\" }}"]
+        },
+        "autoReapplyDiff": {
+          "oneOf": [
+            {
+              "type": "boolean"
+            },
+            {
+              "type": "string"
+            },
+            {
+              "type": "null"
+            }
+          ],
+          "description": "Whether the changes of the changeset should be re-applied on top of its base branch and pushed whenever the base branch moves ahead. Supports templating. The value 'true' is interpreted as true.",
+          "examples": [true, "${{ matches repository.name \"github.com/my-org/*\" }}"]
+        },
+        "reviewReminder": {
+          "type": "object",
+          "description": "A comment that is posted on published changesets whose review has been pending for the given number of days. The reminder is repeated every afterDays days while the review is pending.",
+          "properties": {
+            "afterDays": {
+              "type": "integer",
+              "description": "The number of days the review of a changeset must have been pending before a reminder is posted.",
+              "minimum": 1,
+              "examples": [7]
+            },
+            "body": {
+              "type": "string",
+              "description": "The body of the reminder comment. Supports templating with the variables of changeset comments.",
+              "examples": ["${{ changeset.url }} has been waiting for a review for ${{ changeset.days_open }} days."]
+            }
+          },
+          "required": ["afterDays", "body"],
+          "additionalProperties": false
+        },
+        "dependsOn": {
+          "type": "array",
+          "description": "Changesets in the batch change that other changesets depend on. A changeset is only published once all of the changesets it depends on have been merged.",
+          "items": {
+            "type": "object",
+            "properties": {
+              "repository": {
+                "type": "string",
+                "description": "The name of the repository of the changeset that is depended on. Supports templating.",
+                "examples": ["github.com/my-org/my-library"]
+              },
+              "branch": {
+                "type": "string",
+                "description": "The branch of the changeset that is depended on. If omitted, all changesets in the repository are depended on. Supports templating."
+              },
+              "dependents": {
+                "type": "string",
+                "description": "A glob pattern to match the repository names of the changesets that depend on this one, optionally followed by '@' and a branch name. If omitted, all other changesets in the batch change depend on it.",
+                "examples": ["github.com/my-org/*", "github.com/my-org/my-service@my-branch"]
+              },
+              "gate": {
+                "type": "string",
+                "description": "What the dependents wait for until the changeset that is depended on has been merged. With 'publish', they stay unpublished. With 'merge', they are published as drafts, so that they can be reviewed but not merged, and are undrafted once the dependency has been merged. On code hosts without draft support, 'merge' behaves like 'publish'.",
+                "enum": ["publish", "merge"],
+                "default": "publish"
+              }
+            },
+            "required": ["repository"],
+            "additionalProperties": false
+          }
+        },
         "published": {
           "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host. If omitted, the publication state is controlled from the Batch Changes UI.",
           "oneOf": [
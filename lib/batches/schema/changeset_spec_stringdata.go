@@ -92,6 +92,57 @@ const ChangesetSpecJSON = `{
             }
           }
         },
+        "reviewers": {
+          "type": "array",
+          "description": "The users (or teams) whose review is requested on the changeset on the code host.",
+          "items": { "type": "string" }
+        },
+        "labels": {
+          "type": "array",
+          "description": "The labels to add to the changeset on the code host.",
+          "items": { "type": "string" }
+        },
+        "assignees": {
+          "type": "array",
+          "description": "The users to assign to the changeset on the code host.",
+          "items": { "type": "string" }
+        },
+        "autoMerge": {
+          "type": "boolean",
+          "description": "Whether the changeset should be merged by the code host once all of its requirements are met."
+        },
+        "autoReapplyDiff": {
+          "type": "boolean",
+          "description": "Whether the changes of the changeset should be re-applied on top of its base branch whenever the base branch moves ahead."
+        },
+        "dependsOn": {
+          "type": "array",
+          "description": "The changesets in the same batch change that must be merged before this changeset is published.",
+          "items": {
+            "type": "object",
+            "properties": {
+              "repository": {
+                "type": "string",
+                "description": "The name of the repository of the changeset that is depended on."
+              },
+              "headRef": {
+                "type": "string",
+                "description": "The head ref of the changeset that is depended on. If omitted, all changesets in the repository are depended on."
+              },
+              "gate": {
+                "type": "string",
+                "description": "Whether this changeset stays unpublished ('publish') or is published as a draft ('merge') until the changeset that is depended on has been merged.",
+                "enum": ["publish", "merge"]
+              }
+            },
+            "required": ["repository"],
+            "additionalProperties": false
+          }
+        },
+        "outputs": {
+          "type": "object",
+          "description": "The outputs of the steps that produced the changes. They are available as template variables in comments on the changeset."
+        },
         "published": {
           "oneOf": [{ "type": "boolean" }, { "type": "string", "pattern": "^draft$" }, { "type": "null" }],
           "description": "Whether to publish the changeset. An unpublished changeset can be previewed on Sourcegraph by any person who can view the batch change, but its commit, branch, and pull request aren't created on the code host. A published changeset results in a commit, branch, and pull request being created on the code host."
@@ -208,12 +208,21 @@ type ChangesetTemplateContext struct {
 
 	// Repository is the repository in which the steps were executed.
 	Repository Repository
+
+	// Owner is the code owner the workspace was split off for, if the
+	// workspace configuration splits workspaces by CODEOWNERS.
+	Owner string
 }
 
 // ToFuncMap returns a template.FuncMap to access fields on the StepContext in a
 // text/template.
 func (tmplCtx *ChangesetTemplateContext) ToFuncMap() template.FuncMap {
 	return template.FuncMap{
+		"workspace": func() map[string]any {
+			return map[string]any{
+				"owner": tmplCtx.Owner,
+			}
+		},
 		"repository": func() map[string]any {
 			return map[string]any{
 				"search_result_paths": tmplCtx.Repository.SearchResultPaths(),
@@ -260,3 +269,141 @@ func RenderChangesetTemplateField(name, tmpl string, tmplCtx *ChangesetTemplateC
 
 	return strings.TrimSpace(out.String()), nil
 }
+
+// RenderChangesetTemplateList renders each of the given templates and returns
+// the resulting values. Templates that render to an empty string are dropped,
+// and templates that render to a comma- or newline-separated list are split
+// into their elements, so that a single entry can produce a variable number of
+// values. Duplicate values are only returned once.
+func RenderChangesetTemplateList(name string, tmpls []string, tmplCtx *ChangesetTemplateContext) ([]string, error) {
+	var values []string
+	seen := map[string]struct{}{}
+
+	for _, tmpl := range tmpls {
+		out, err := RenderChangesetTemplateField(name, tmpl, tmplCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, value := range strings.FieldsFunc(out, func(r rune) bool { return r == ',' || r == '\n' }) {
+			// Missing outputs render as "<no value>", which is never a valid
+			// reviewer, label or assignee.
+			value = strings.TrimSpace(value)
+			if value == "" || value == "<no value>" {
+				continue
+			}
+			if _, ok := seen[value]; ok {
+				continue
+			}
+			seen[value] = struct{}{}
+			values = append(values, value)
+		}
+	}
+
+	return values, nil
+}
+
+// EvalChangesetTemplateCondition renders the given template and returns whether
+// it evaluated to "true". An empty template evaluates to false.
+func EvalChangesetTemplateCondition(name, condition string, tmplCtx *ChangesetTemplateContext) (bool, error) {
+	if condition == "" {
+		return false, nil
+	}
+
+	out, err := RenderChangesetTemplateField(name, condition, tmplCtx)
+	if err != nil {
+		return false, err
+	}
+
+	return out == "true", nil
+}
+
+// ChangesetAttributes are the attributes of a changeset that are available
+// when rendering a comment on the changeset.
+type ChangesetAttributes struct {
+	Title string
+	URL   string
+	// Branch is the head branch of the changeset, without the refs/heads/
+	// prefix.
+	Branch      string
+	State       string
+	ReviewState string
+	CheckState  string
+	// DaysOpen is the number of full days since the changeset was opened on
+	// the code host.
+	DaysOpen int
+}
+
+// ChangesetCommentContext represents the contextual information available
+// when rendering a comment on a changeset as a template.
+type ChangesetCommentContext struct {
+	// BatchChangeAttributes are the attributes of the BatchChange that the
+	// comment is posted from.
+	BatchChangeAttributes BatchChangeAttributes
+
+	// Repository is the repository of the changeset.
+	Repository Repository
+
+	// Changeset is the changeset that is commented on.
+	Changeset ChangesetAttributes
+
+	// Outputs are the outputs of the steps that produced the changeset. They
+	// are empty for changesets that weren't created by executing steps.
+	Outputs map[string]any
+}
+
+// ToFuncMap returns a template.FuncMap to access fields on the
+// ChangesetCommentContext in a text/template.
+func (tmplCtx *ChangesetCommentContext) ToFuncMap() template.FuncMap {
+	return template.FuncMap{
+		"repository": func() map[string]any {
+			return map[string]any{
+				"name":   tmplCtx.Repository.Name,
+				"branch": tmplCtx.Repository.Branch,
+			}
+		},
+		"batch_change": func() map[string]any {
+			return map[string]any{
+				"name":        tmplCtx.BatchChangeAttributes.Name,
+				"description": tmplCtx.BatchChangeAttributes.Description,
+			}
+		},
+		"changeset": func() map[string]any {
+			return map[string]any{
+				"title":        tmplCtx.Changeset.Title,
+				"url":          tmplCtx.Changeset.URL,
+				"branch":       tmplCtx.Changeset.Branch,
+				"state":        tmplCtx.Changeset.State,
+				"review_state": tmplCtx.Changeset.ReviewState,
+				"check_state":  tmplCtx.Changeset.CheckState,
+				"days_open":    tmplCtx.Changeset.DaysOpen,
+			}
+		},
+		"outputs": func() map[string]any {
+			return tmplCtx.Outputs
+		},
+	}
+}
+
+// ParseChangesetCommentTemplate returns an error if the given comment
+// template can't be parsed.
+func ParseChangesetCommentTemplate(tmpl string) error {
+	_, err := template.New("comment").Delims(startDelim, endDelim).Funcs(builtins).Funcs((&ChangesetCommentContext{}).ToFuncMap()).Parse(tmpl)
+	return err
+}
+
+// RenderChangesetComment renders the body of a comment on a changeset.
+func RenderChangesetComment(tmpl string, tmplCtx *ChangesetCommentContext) (string, error) {
+	var out bytes.Buffer
+
+	t, err := template.New("comment").Delims(startDelim, endDelim).Funcs(builtins).Funcs(tmplCtx.ToFuncMap()).Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	if err := t.Execute(&out, tmplCtx); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
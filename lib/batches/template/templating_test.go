@@ -292,6 +292,7 @@ func TestRenderChangesetTemplateField(t *testing.T) {
 			},
 			Path: "infrastructure/sub-project",
 		},
+		Owner: "@sourcegraph/batchers",
 	}
 
 	tests := []struct {
@@ -314,6 +315,7 @@ ${{ steps.added_files }}
 ${{ steps.deleted_files }}
 ${{ steps.renamed_files }}
 ${{ steps.path }}
+${{ workspace.owner }}
 `,
 			want: `README.md main.go
 github.com/sourcegraph/src-cli
@@ -325,7 +327,8 @@ CGO_ENABLED=0
 [added-file.txt]
 [deleted-file.txt]
 [renamed-file.txt]
-infrastructure/sub-project`,
+infrastructure/sub-project
+@sourcegraph/batchers`,
 		},
 		{
 			name:    "empty context",
@@ -361,3 +364,119 @@ ${{ steps.renamed_files }}
 		})
 	}
 }
+
+func TestRenderChangesetTemplateList(t *testing.T) {
+	tmplCtx := &ChangesetTemplateContext{
+		Outputs: map[string]any{
+			"owners": "alice, my-org/backend\nbob",
+			"empty":  "",
+		},
+		Repository: *testRepo1,
+	}
+
+	tmpls := []string{
+		"carol",
+		"${{ outputs.owners }}",
+		"${{ outputs.empty }}",
+		"${{ outputs.missing }}",
+		`${{ if matches repository.name "github.com/sourcegraph/*" }}alice${{ end }}`,
+	}
+
+	have, err := RenderChangesetTemplateList("reviewers", tmpls, tmplCtx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"carol", "alice", "my-org/backend", "bob"}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("wrong output (-want +got):\n%s", diff)
+	}
+}
+
+func TestEvalChangesetTemplateCondition(t *testing.T) {
+	tmplCtx := &ChangesetTemplateContext{Repository: *testRepo1}
+
+	tests := []struct {
+		condition string
+		want      bool
+	}{
+		{condition: "", want: false},
+		{condition: "true", want: true},
+		{condition: "false", want: false},
+		{condition: `${{ matches repository.name "github.com/sourcegraph/*" }}`, want: true},
+		{condition: `${{ matches repository.name "github.com/other/*" }}`, want: false},
+	}
+
+	for _, tc := range tests {
+		have, err := EvalChangesetTemplateCondition("autoMerge", tc.condition, tmplCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have != tc.want {
+			t.Errorf("wrong result for %q. want=%t have=%t", tc.condition, tc.want, have)
+		}
+	}
+}
+
+func TestRenderChangesetComment(t *testing.T) {
+	tmplCtx := &ChangesetCommentContext{
+		BatchChangeAttributes: BatchChangeAttributes{Name: "bump-lib"},
+		Repository:            Repository{Name: "github.com/sourcegraph/src-cli", Branch: "main"},
+		Changeset: ChangesetAttributes{
+			Title:       "Bump lib",
+			URL:         "https://github.com/sourcegraph/src-cli/pull/1",
+			Branch:      "bump-lib",
+			State:       "OPEN",
+			ReviewState: "PENDING",
+			CheckState:  "FAILED",
+			DaysOpen:    9,
+		},
+		Outputs: map[string]any{"owner": "@sourcegraph/batchers"},
+	}
+
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+	}{
+		{
+			name: "literal",
+			tmpl: "Please take a look!\n",
+			want: "Please take a look!",
+		},
+		{
+			name: "variables",
+			tmpl: `${{ outputs.owner }}: ${{ changeset.url }} (${{ changeset.branch }} into ${{ repository.branch }} of ${{ repository.name }}) from ${{ batch_change.name }} has been open for ${{ changeset.days_open }} days.`,
+			want: "@sourcegraph/batchers: https://github.com/sourcegraph/src-cli/pull/1 (bump-lib into main of github.com/sourcegraph/src-cli) from bump-lib has been open for 9 days.",
+		},
+		{
+			name: "conditionals",
+			tmpl: `${{ if eq changeset.check_state "FAILED" }}Checks are failing.${{ end }} Review is ${{ changeset.review_state }}.`,
+			want: "Checks are failing. Review is PENDING.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := RenderChangesetComment(tc.tmpl, tmplCtx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tc.want {
+				t.Errorf("wrong output:\nwant=%q\nhave=%q", tc.want, have)
+			}
+		})
+	}
+}
+
+func TestParseChangesetCommentTemplate(t *testing.T) {
+	if err := ParseChangesetCommentTemplate(`${{ changeset.url }} ${{ outputs.owner }}`); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := ParseChangesetCommentTemplate(`${{ steps.modified_files }}`); err == nil {
+		t.Fatal("expected error for unknown function, got nil")
+	}
+	if err := ParseChangesetCommentTemplate(`${{ if true }}`); err == nil {
+		t.Fatal("expected error for unterminated action, got nil")
+	}
+}
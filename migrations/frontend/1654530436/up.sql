@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS repo_redirects (
+    id SERIAL PRIMARY KEY,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+    name citext NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS repo_redirects_name_unique ON repo_redirects(name);
+CREATE INDEX IF NOT EXISTS repo_redirects_repo_id_idx ON repo_redirects(repo_id);
+
+COMMENT ON TABLE repo_redirects IS 'Previous names of repositories that were renamed or transferred on their code host.';
+COMMENT ON COLUMN repo_redirects.name IS 'The name the repository had before it was renamed.';
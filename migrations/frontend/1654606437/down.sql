@@ -0,0 +1 @@
+DROP TABLE IF EXISTS codeintel_lockfile_edges;
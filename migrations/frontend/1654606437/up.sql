@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS codeintel_lockfile_edges (
+    codeintel_lockfile_id integer NOT NULL REFERENCES codeintel_lockfiles(id) ON DELETE CASCADE,
+    source_reference_id integer REFERENCES codeintel_lockfile_references(id) ON DELETE CASCADE,
+    target_reference_id integer NOT NULL REFERENCES codeintel_lockfile_references(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS codeintel_lockfile_edges_codeintel_lockfile_id ON codeintel_lockfile_edges(codeintel_lockfile_id);
+
+COMMENT ON TABLE codeintel_lockfile_edges IS 'Edges of the dependency graph described by the lockfiles of a repository-commit pair. Lockfiles results without edges are treated as if all of their references were direct dependencies.';
+COMMENT ON COLUMN codeintel_lockfile_edges.source_reference_id IS 'The lockfile reference depending on the target reference. Null if the target reference is a direct dependency of the repository.';
+COMMENT ON COLUMN codeintel_lockfile_edges.target_reference_id IS 'The lockfile reference being depended on.';
@@ -0,0 +1,36 @@
+ALTER TABLE lsif_uploads ADD COLUMN IF NOT EXISTS base_upload_id integer;
+ALTER TABLE lsif_uploads ADD COLUMN IF NOT EXISTS changed_paths text[];
+
+COMMENT ON COLUMN lsif_uploads.base_upload_id IS 'The identifier of the upload whose data is carried forward for all documents not replaced by this upload. Null for uploads that contain the complete index.';
+COMMENT ON COLUMN lsif_uploads.changed_paths IS 'The repository-relative paths of the documents that this upload replaces in its base upload. Only set when base_upload_id is set.';
+
+DROP VIEW IF EXISTS lsif_uploads_with_repository_name;
+
+CREATE VIEW lsif_uploads_with_repository_name AS
+SELECT u.id,
+    u.commit,
+    u.root,
+    u.queued_at,
+    u.uploaded_at,
+    u.state,
+    u.failure_message,
+    u.started_at,
+    u.finished_at,
+    u.repository_id,
+    u.indexer,
+    u.indexer_version,
+    u.num_parts,
+    u.uploaded_parts,
+    u.process_after,
+    u.num_resets,
+    u.upload_size,
+    u.num_failures,
+    u.associated_index_id,
+    u.expired,
+    u.last_retention_scan_at,
+    u.base_upload_id,
+    u.changed_paths,
+    r.name AS repository_name
+FROM lsif_uploads u
+JOIN repo r ON r.id = u.repository_id
+WHERE r.deleted_at IS NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS codeintel_diagnostic_counts;
+DROP TABLE IF EXISTS codeintel_diagnostic_snapshots;
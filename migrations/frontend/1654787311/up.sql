@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS codeintel_diagnostic_snapshots (
+    id serial PRIMARY KEY,
+    repository_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    upload_ids integer[] NOT NULL,
+    recorded_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS codeintel_diagnostic_snapshots_repository_id_recorded_at ON codeintel_diagnostic_snapshots(repository_id, recorded_at);
+CREATE INDEX IF NOT EXISTS codeintel_diagnostic_snapshots_recorded_at ON codeintel_diagnostic_snapshots(recorded_at);
+
+COMMENT ON TABLE codeintel_diagnostic_snapshots IS 'A point-in-time record of the diagnostics reported by the precise code intelligence indexes visible at the tip of the default branch of a repository.';
+COMMENT ON COLUMN codeintel_diagnostic_snapshots.upload_ids IS 'The identifiers of the uploads visible at the tip of the default branch at the time the snapshot was recorded.';
+COMMENT ON COLUMN codeintel_diagnostic_snapshots.recorded_at IS 'The time the snapshot was recorded.';
+
+CREATE TABLE IF NOT EXISTS codeintel_diagnostic_counts (
+    snapshot_id integer NOT NULL REFERENCES codeintel_diagnostic_snapshots(id) ON DELETE CASCADE,
+    severity integer NOT NULL,
+    source text NOT NULL,
+    code text NOT NULL,
+    count integer NOT NULL,
+    PRIMARY KEY (snapshot_id, severity, source, code)
+);
+
+COMMENT ON TABLE codeintel_diagnostic_counts IS 'The number of diagnostics in a snapshot with the same severity, source, and code.';
+COMMENT ON COLUMN codeintel_diagnostic_counts.severity IS 'The LSP diagnostic severity: 1 (error), 2 (warning), 3 (information), or 4 (hint).';
+COMMENT ON COLUMN codeintel_diagnostic_counts.source IS 'The tool that produced the diagnostic (e.g., a compiler or linter). Empty if unspecified by the indexer.';
+COMMENT ON COLUMN codeintel_diagnostic_counts.code IS 'The tool-specific diagnostic code. Empty if unspecified by the indexer.';
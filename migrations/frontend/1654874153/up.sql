@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS changeset_dependencies (
+    batch_change_id bigint NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    changeset_id bigint NOT NULL REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+    depends_on_changeset_id bigint NOT NULL REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+    PRIMARY KEY (batch_change_id, changeset_id, depends_on_changeset_id)
+);
+
+CREATE INDEX IF NOT EXISTS changeset_dependencies_changeset_id ON changeset_dependencies(changeset_id);
+CREATE INDEX IF NOT EXISTS changeset_dependencies_depends_on_changeset_id ON changeset_dependencies(depends_on_changeset_id);
+
+COMMENT ON TABLE changeset_dependencies IS 'The changesets of a batch change that must be merged before another changeset of the batch change is published.';
+COMMENT ON COLUMN changeset_dependencies.changeset_id IS 'The changeset that is held back until the changeset it depends on is merged.';
+COMMENT ON COLUMN changeset_dependencies.depends_on_changeset_id IS 'The changeset that must be merged first.';
@@ -0,0 +1,63 @@
+ALTER TABLE changesets ADD COLUMN IF NOT EXISTS conflict_state text;
+ALTER TABLE changesets ADD COLUMN IF NOT EXISTS rebase_base_rev text;
+
+COMMENT ON COLUMN changesets.conflict_state IS 'Whether the changeset branch is up to date with, behind, or conflicting with its base branch, as computed by the rebaser.';
+COMMENT ON COLUMN changesets.rebase_base_rev IS 'The base branch commit the reconciler should rebase the changeset onto.';
+
+--
+-- Recreate the reconciler view with the new columns
+
+DROP VIEW IF EXISTS reconciler_changesets;
+
+CREATE VIEW reconciler_changesets AS
+SELECT c.id,
+    c.batch_change_ids,
+    c.repo_id,
+    c.queued_at,
+    c.created_at,
+    c.updated_at,
+    c.metadata,
+    c.external_id,
+    c.external_service_type,
+    c.external_deleted_at,
+    c.external_branch,
+    c.external_updated_at,
+    c.external_state,
+    c.external_review_state,
+    c.external_check_state,
+    c.diff_stat_added,
+    c.diff_stat_changed,
+    c.diff_stat_deleted,
+    c.sync_state,
+    c.current_spec_id,
+    c.previous_spec_id,
+    c.publication_state,
+    c.owned_by_batch_change_id,
+    c.reconciler_state,
+    c.failure_message,
+    c.started_at,
+    c.finished_at,
+    c.process_after,
+    c.num_resets,
+    c.closing,
+    c.num_failures,
+    c.log_contents,
+    c.execution_logs,
+    c.syncer_error,
+    c.external_title,
+    c.worker_hostname,
+    c.ui_publication_state,
+    c.last_heartbeat_at,
+    c.external_fork_namespace,
+    c.external_checks,
+    c.conflict_state,
+    c.rebase_base_rev
+FROM changesets c
+JOIN repo r ON r.id = c.repo_id
+WHERE r.deleted_at IS NULL AND EXISTS (
+    SELECT 1
+    FROM batch_changes
+    LEFT JOIN users namespace_user ON batch_changes.namespace_user_id = namespace_user.id
+    LEFT JOIN orgs namespace_org ON batch_changes.namespace_org_id = namespace_org.id
+    WHERE c.batch_change_ids ? batch_changes.id::text AND namespace_user.deleted_at IS NULL AND namespace_org.deleted_at IS NULL
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS batch_change_merge_train_changesets;
+DROP TABLE IF EXISTS batch_change_merge_trains;
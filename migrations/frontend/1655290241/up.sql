@@ -0,0 +1,39 @@
+CREATE TABLE IF NOT EXISTS batch_change_merge_trains (
+    id bigserial PRIMARY KEY,
+    batch_change_id bigint NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    state text NOT NULL DEFAULT 'RUNNING',
+    changesets_per_wave integer NOT NULL,
+    wave_interval_seconds integer NOT NULL,
+    squash boolean NOT NULL DEFAULT false,
+    health_check_query text,
+    health_check_url text,
+    paused_reason text,
+    last_wave_at timestamp with time zone,
+    resumed_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS batch_change_merge_trains_active_unique ON batch_change_merge_trains(batch_change_id) WHERE state IN ('RUNNING', 'PAUSED');
+CREATE INDEX IF NOT EXISTS batch_change_merge_trains_state ON batch_change_merge_trains(state);
+
+COMMENT ON TABLE batch_change_merge_trains IS 'Merge trains merge the changesets of a batch change in waves, pausing when a health check fails.';
+COMMENT ON COLUMN batch_change_merge_trains.changesets_per_wave IS 'The maximum number of changesets merged per code host in each wave.';
+COMMENT ON COLUMN batch_change_merge_trains.health_check_query IS 'A search query that must return no results for the next wave to start.';
+COMMENT ON COLUMN batch_change_merge_trains.health_check_url IS 'A URL that must respond with 200 OK for the next wave to start.';
+COMMENT ON COLUMN batch_change_merge_trains.resumed_at IS 'When the merge train was last resumed after being paused. Failures of waves started before this time are ignored.';
+
+CREATE TABLE IF NOT EXISTS batch_change_merge_train_changesets (
+    merge_train_id bigint NOT NULL REFERENCES batch_change_merge_trains(id) ON DELETE CASCADE DEFERRABLE,
+    changeset_id bigint NOT NULL REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+    wave integer,
+    bulk_group text,
+    PRIMARY KEY (merge_train_id, changeset_id)
+);
+
+CREATE INDEX IF NOT EXISTS batch_change_merge_train_changesets_changeset_id ON batch_change_merge_train_changesets(changeset_id);
+
+COMMENT ON TABLE batch_change_merge_train_changesets IS 'The changesets merged by a merge train, and the wave in which they were merged.';
+COMMENT ON COLUMN batch_change_merge_train_changesets.wave IS 'The wave in which the changeset was merged. NULL if the changeset is still waiting to be merged.';
+COMMENT ON COLUMN batch_change_merge_train_changesets.bulk_group IS 'The bulk group of the changeset jobs created for the wave.';
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS batch_step_cache_entries (
+    id bigserial PRIMARY KEY,
+    key text NOT NULL,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+    value text NOT NULL,
+    version integer NOT NULL,
+    last_used_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT batch_step_cache_entries_key_unique UNIQUE (key)
+);
+
+CREATE INDEX IF NOT EXISTS batch_step_cache_entries_repo_id ON batch_step_cache_entries(repo_id);
+
+COMMENT ON TABLE batch_step_cache_entries IS 'Content-addressed results of batch spec steps, shared across batch changes and users.';
+COMMENT ON COLUMN batch_step_cache_entries.key IS 'Hash of the repository, commit, step containers pinned by digest, commands, environment and files.';
+COMMENT ON COLUMN batch_step_cache_entries.repo_id IS 'The repository the steps were executed in. Entries are only returned to users that can access it.';
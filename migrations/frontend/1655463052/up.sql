@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS changeset_query_imports (
+    batch_change_id bigint NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    changeset_id bigint NOT NULL REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (batch_change_id, changeset_id)
+);
+
+CREATE INDEX IF NOT EXISTS changeset_query_imports_changeset_id ON changeset_query_imports(changeset_id);
+
+COMMENT ON TABLE changeset_query_imports IS 'The changesets that are tracked by a batch change because they matched one of the importChangesets queries of its batch spec.';
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS batch_spec_workspace_artifacts (
+    id bigserial PRIMARY KEY,
+    batch_spec_workspace_id bigint NOT NULL REFERENCES batch_spec_workspaces(id) ON DELETE CASCADE DEFERRABLE,
+    name text NOT NULL,
+    value jsonb NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT batch_spec_workspace_artifacts_name_unique UNIQUE (batch_spec_workspace_id, name)
+);
+
+COMMENT ON TABLE batch_spec_workspace_artifacts IS 'The artifacts declared by the steps of a batch spec, as produced by executing them in a workspace.';
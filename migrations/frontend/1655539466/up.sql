@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS changeset_review_reminders (
+    batch_change_id bigint NOT NULL REFERENCES batch_changes(id) ON DELETE CASCADE DEFERRABLE,
+    changeset_id bigint NOT NULL REFERENCES changesets(id) ON DELETE CASCADE DEFERRABLE,
+    reminded_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (batch_change_id, changeset_id)
+);
+
+CREATE INDEX IF NOT EXISTS changeset_review_reminders_changeset_id ON changeset_review_reminders(changeset_id);
+
+COMMENT ON TABLE changeset_review_reminders IS 'When a batch change last posted a review reminder comment on a changeset.';
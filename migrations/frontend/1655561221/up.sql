@@ -0,0 +1 @@
+ALTER TABLE batch_spec_workspaces ADD COLUMN IF NOT EXISTS owner text NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE changeset_dependencies DROP COLUMN IF EXISTS gate;
@@ -0,0 +1,3 @@
+ALTER TABLE changeset_dependencies ADD COLUMN IF NOT EXISTS gate text NOT NULL DEFAULT 'publish';
+
+COMMENT ON COLUMN changeset_dependencies.gate IS 'What the changeset waits for until the changeset it depends on is merged: ''publish'' keeps it unpublished, ''merge'' publishes it as a draft.';
@@ -10,7 +10,7 @@ import (
 
 // AWSCodeCommitConnection description: Configuration for a connection to AWS CodeCommit.
 type AWSCodeCommitConnection struct {
-	// AccessKeyID description: The AWS access key ID to use when listing and updating repositories from AWS CodeCommit. Must have the AWSCodeCommitReadOnly IAM policy.
+	// AccessKeyID description: The AWS access key ID to use when listing and updating repositories from AWS CodeCommit. Must have the AWSCodeCommitReadOnly IAM policy, or the AWSCodeCommitPowerUser IAM policy to create pull requests with Batch Changes.
 	AccessKeyID string `json:"accessKeyID"`
 	// Exclude description: A list of repositories to never mirror from AWS CodeCommit.
 	//
@@ -67,6 +67,14 @@ type ApiRatelimit struct {
 }
 
 // AuthAccessTokens description: Settings for access tokens, which enable external tools to access the Sourcegraph API with the privileges of the user.
+type ArtifactDefinition struct {
+	// Format description: The format of the artifact. If set to 'json', the value is parsed as JSON. If not set, 'text' is assumed to be the format.
+	Format string `json:"format,omitempty"`
+	// Path description: The path of a file in the workspace, relative to the workspace root, whose content after executing the step is the value of the artifact.
+	Path string `json:"path,omitempty"`
+	// Value description: The value of the artifact, which can be a template string.
+	Value string `json:"value,omitempty"`
+}
 type AuthAccessTokens struct {
 	// Allow description: Allow or restrict the use of access tokens. The default is "all-users-create", which enables all users to create access tokens. Use "none" to disable access tokens entirely. Use "site-admin-create" to restrict creation of new tokens to admin users (existing tokens will still work until revoked).
 	Allow string `json:"allow,omitempty"`
@@ -88,12 +96,13 @@ type AuthProviderCommon struct {
 	DisplayName string `json:"displayName,omitempty"`
 }
 type AuthProviders struct {
-	Builtin       *BuiltinAuthProvider
-	Saml          *SAMLAuthProvider
-	Openidconnect *OpenIDConnectAuthProvider
-	HttpHeader    *HTTPHeaderAuthProvider
-	Github        *GitHubAuthProvider
-	Gitlab        *GitLabAuthProvider
+	Builtin        *BuiltinAuthProvider
+	Saml           *SAMLAuthProvider
+	Openidconnect  *OpenIDConnectAuthProvider
+	HttpHeader     *HTTPHeaderAuthProvider
+	Github         *GitHubAuthProvider
+	Gitlab         *GitLabAuthProvider
+	Bitbucketcloud *BitbucketCloudAuthProvider
 }
 
 func (v AuthProviders) MarshalJSON() ([]byte, error) {
@@ -115,6 +124,9 @@ func (v AuthProviders) MarshalJSON() ([]byte, error) {
 	if v.Gitlab != nil {
 		return json.Marshal(v.Gitlab)
 	}
+	if v.Bitbucketcloud != nil {
+		return json.Marshal(v.Bitbucketcloud)
+	}
 	return nil, errors.New("tagged union type must have exactly 1 non-nil field value")
 }
 func (v *AuthProviders) UnmarshalJSON(data []byte) error {
@@ -125,6 +137,8 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	switch d.DiscriminantProperty {
+	case "bitbucketcloud":
+		return json.Unmarshal(data, &v.Bitbucketcloud)
 	case "builtin":
 		return json.Unmarshal(data, &v.Builtin)
 	case "github":
@@ -138,7 +152,7 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 	case "saml":
 		return json.Unmarshal(data, &v.Saml)
 	}
-	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab"})
+	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab", "bitbucketcloud"})
 }
 
 type BackendInsight struct {
@@ -176,7 +190,7 @@ type BatchSpec struct {
 	// Description description: The description of the batch change.
 	Description string `json:"description,omitempty"`
 	// ImportChangesets description: Import existing changesets on code hosts.
-	ImportChangesets []*ImportChangesets `json:"importChangesets,omitempty"`
+	ImportChangesets []interface{} `json:"importChangesets,omitempty"`
 	// Name description: The name of the batch change, which is unique among all batch changes in the namespace. A batch change's name is case-preserving.
 	Name string `json:"name"`
 	// On description: The set of repositories (and branches) to run the batch change on, specified as a list of search queries (that match repositories) and/or specific repositories.
@@ -189,15 +203,37 @@ type BatchSpec struct {
 	Workspaces []*WorkspaceConfiguration `json:"workspaces,omitempty"`
 }
 
+// BitbucketCloudAuthProvider description: Configures the Bitbucket Cloud OAuth authentication provider for SSO. In addition to specifying this configuration object, you must also create a OAuth consumer in the settings of your Bitbucket Cloud workspace: https://support.atlassian.com/bitbucket-cloud/docs/use-oauth-on-bitbucket-cloud/. The consumer should have the `Account: Read` and `Repositories: Read` permissions and the callback URL set to the concatenation of your Sourcegraph instance URL and "/.auth/bitbucketcloud/callback".
+type BitbucketCloudAuthProvider struct {
+	// AllowSignup description: Allows new visitors to sign up for accounts via Bitbucket Cloud authentication. If false, users signing in via Bitbucket Cloud must have an existing Sourcegraph account, which will be linked to their Bitbucket Cloud identity after sign-in.
+	AllowSignup bool `json:"allowSignup,omitempty"`
+	// ClientKey description: The Key of the Bitbucket OAuth consumer, accessible from the OAuth consumers section of the workspace settings.
+	ClientKey string `json:"clientKey"`
+	// ClientSecret description: The Secret of the Bitbucket OAuth consumer, accessible from the OAuth consumers section of the workspace settings.
+	ClientSecret string `json:"clientSecret"`
+	DisplayName  string `json:"displayName,omitempty"`
+	Type         string `json:"type"`
+	// Url description: URL of the Bitbucket Cloud instance.
+	Url string `json:"url,omitempty"`
+}
+
+// BitbucketCloudAuthorization description: If non-null, enforces Bitbucket Cloud repository permissions. Permissions are computed for Sourcegraph users that have connected their Bitbucket Cloud account through OAuth with the same `url` as specified in this `BitbucketCloudConnection`.
+type BitbucketCloudAuthorization struct {
+}
+
 // BitbucketCloudConnection description: Configuration for a connection to Bitbucket Cloud.
 type BitbucketCloudConnection struct {
 	// ApiURL description: The API URL of Bitbucket Cloud, such as https://api.bitbucket.org. Generally, admin should not modify the value of this option because Bitbucket Cloud is a public hosting platform.
 	ApiURL string `json:"apiURL,omitempty"`
 	// AppPassword description: The app password to use when authenticating to the Bitbucket Cloud. Also set the corresponding "username" field.
 	AppPassword string `json:"appPassword"`
+	// Authorization description: If non-null, enforces Bitbucket Cloud repository permissions. Permissions are computed for Sourcegraph users that have connected their Bitbucket Cloud account through OAuth with the same `url` as specified in this `BitbucketCloudConnection`.
+	Authorization *BitbucketCloudAuthorization `json:"authorization,omitempty"`
 	// Exclude description: A list of repositories to never mirror from Bitbucket Cloud. Takes precedence over "teams" configuration.
 	//
 	// Supports excluding by name ({"name": "myorg/myrepo"}) or by UUID ({"uuid": "{fceb73c7-cef6-4abe-956d-e471281126bd}"}).
+	//
+	// Also supports excluding by metadata reported by Bitbucket Cloud: size ({"sizeOverMB": 5000}), last push ({"pushedBefore": "2019-01-01"}) and primary language ({"languages": ["COBOL"]}). All fields of an item that sets metadata fields, including the name, ID and pattern, must match for a repository to be excluded.
 	Exclude []*ExcludedBitbucketCloudRepo `json:"exclude,omitempty"`
 	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this Bitbucket Cloud.
 	//
@@ -205,6 +241,8 @@ type BitbucketCloudConnection struct {
 	//
 	// If "ssh", Sourcegraph will access Bitbucket Cloud repositories using Git URLs of the form git@bitbucket.org:myteam/myproject.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
 	GitURLType string `json:"gitURLType,omitempty"`
+	// Include description: A list of metadata rules restricting which repositories are mirrored from Bitbucket Cloud. If set, only repositories matching at least one of the rules are mirrored. All fields of a single rule must match. "exclude" takes precedence over "include".
+	Include []*IncludedBitbucketCloudRepo `json:"include,omitempty"`
 	// RateLimit description: Rate limit applied when making background API requests to Bitbucket Cloud.
 	RateLimit *BitbucketCloudRateLimit `json:"rateLimit,omitempty"`
 	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Bitbucket Cloud repository.
@@ -527,10 +565,16 @@ type ExcludedAWSCodeCommitRepo struct {
 	Name string `json:"name,omitempty"`
 }
 type ExcludedBitbucketCloudRepo struct {
+	// Languages description: Matches repositories whose primary language, as detected by Bitbucket Cloud, is one of the given languages (case-insensitive).
+	Languages []string `json:"languages,omitempty"`
 	// Name description: The name of a Bitbucket Cloud repo ("myorg/myrepo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 	// Pattern description: Regular expression which matches against the name of a Bitbucket Cloud repo.
 	Pattern string `json:"pattern,omitempty"`
+	// PushedBefore description: Matches repositories whose most recent commit, on any branch, was before the given date ("YYYY-MM-DD").
+	PushedBefore string `json:"pushedBefore,omitempty"`
+	// SizeOverMB description: Matches repositories whose size on Bitbucket Cloud is larger than the given number of megabytes.
+	SizeOverMB float64 `json:"sizeOverMB,omitempty"`
 	// Uuid description: The UUID of a Bitbucket Cloud repo (as returned by the Bitbucket Cloud's API) to exclude from mirroring.
 	Uuid string `json:"uuid,omitempty"`
 }
@@ -549,16 +593,30 @@ type ExcludedGitHubRepo struct {
 	Forks bool `json:"forks,omitempty"`
 	// Id description: The node ID of a GitHub repository (as returned by the GitHub instance's API) to exclude from mirroring. Use this to exclude the repository, even if renamed. Note: This is the GraphQL ID, not the GitHub database ID. eg: "curl https://api.github.com/repos/vuejs/vue | jq .node_id"
 	Id string `json:"id,omitempty"`
+	// Languages description: Matches repositories whose primary language, as detected by GitHub, is one of the given languages (case-insensitive).
+	Languages []string `json:"languages,omitempty"`
 	// Name description: The name of a GitHub repository ("owner/name") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 	// Pattern description: Regular expression which matches against the name of a GitHub repository ("owner/name").
 	Pattern string `json:"pattern,omitempty"`
+	// PushedBefore description: Matches repositories last pushed to before the given date ("YYYY-MM-DD").
+	PushedBefore string `json:"pushedBefore,omitempty"`
+	// SizeOverMB description: Matches repositories whose size on GitHub is larger than the given number of megabytes.
+	SizeOverMB float64 `json:"sizeOverMB,omitempty"`
+	// Topics description: Matches repositories that have at least one of the given GitHub topics.
+	Topics []string `json:"topics,omitempty"`
 }
 type ExcludedGitLabProject struct {
 	// Id description: The ID of a GitLab project (as returned by the GitLab instance's API) to exclude from mirroring.
 	Id int `json:"id,omitempty"`
 	// Name description: The name of a GitLab project ("group/name") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
+	// PushedBefore description: Matches repositories whose most recent commit, on any branch, was before the given date ("YYYY-MM-DD").
+	PushedBefore string `json:"pushedBefore,omitempty"`
+	// SizeOverMB description: Matches repositories whose size on GitLab (as reported by the project statistics, which requires at least Reporter access) is larger than the given number of megabytes.
+	SizeOverMB float64 `json:"sizeOverMB,omitempty"`
+	// Topics description: Matches repositories that have at least one of the given GitLab topics.
+	Topics []string `json:"topics,omitempty"`
 }
 type ExcludedGitoliteRepo struct {
 	// Name description: The name of a Gitolite repo ("my-repo") to exclude from mirroring.
@@ -566,6 +624,12 @@ type ExcludedGitoliteRepo struct {
 	// Pattern description: Regular expression which matches against the name of a Gitolite repo to exclude from mirroring.
 	Pattern string `json:"pattern,omitempty"`
 }
+type ExcludedSourcegraphRepo struct {
+	// Name description: The name of a repository on the remote instance to exclude, such as "github.com/myorg/myrepo".
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of a repository on the remote instance.
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExistingChangesetSpec struct {
 	// BaseRepository description: The GraphQL ID of the repository that contains the existing changeset on the code host.
 	BaseRepository string `json:"baseRepository"`
@@ -631,6 +695,8 @@ type ExperimentalFeatures struct {
 	SearchIndexRevisions []*SearchIndexRevisionsRule `json:"search.index.revisions,omitempty"`
 	// SearchMultipleRevisionsPerRepository description: DEPRECATED. Always on. Will be removed in 3.19.
 	SearchMultipleRevisionsPerRepository *bool `json:"searchMultipleRevisionsPerRepository,omitempty"`
+	// SourcegraphFederation description: Allow adding code host connections to other Sourcegraph instances
+	SourcegraphFederation string `json:"sourcegraphFederation,omitempty"`
 	// StructuralSearch description: Enables structural search.
 	StructuralSearch   string              `json:"structuralSearch,omitempty"`
 	SubRepoPermissions *SubRepoPermissions `json:"subRepoPermissions,omitempty"`
@@ -762,6 +828,8 @@ type GitHubConnection struct {
 	// Supports excluding by name ({"name": "owner/name"}) or by ID ({"id": "MDEwOlJlcG9zaXRvcnkxMTczMDM0Mg=="}).
 	//
 	// Note: ID is the GitHub GraphQL ID, not the GitHub database ID. eg: "curl https://api.github.com/repos/vuejs/vue | jq .node_id"
+	//
+	// Also supports excluding by metadata reported by GitHub: size ({"sizeOverMB": 5000}), last push ({"pushedBefore": "2019-01-01"}), topics ({"topics": ["deprecated"]}) and primary language ({"languages": ["COBOL"]}). All fields of an item that sets metadata fields, including the name, ID and pattern, must match for a repository to be excluded.
 	Exclude []*ExcludedGitHubRepo `json:"exclude,omitempty"`
 	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this GitHub instance.
 	//
@@ -771,6 +839,8 @@ type GitHubConnection struct {
 	GitURLType string `json:"gitURLType,omitempty"`
 	// GithubAppInstallationID description: The installation ID of the GitHub App.
 	GithubAppInstallationID string `json:"githubAppInstallationID,omitempty"`
+	// Include description: A list of metadata rules restricting which repositories are mirrored from GitHub. If set, only repositories matching at least one of the rules are mirrored. All fields of a single rule must match. "exclude" takes precedence over "include".
+	Include []*IncludedGitHubRepo `json:"include,omitempty"`
 	// InitialRepositoryEnablement description: Deprecated and ignored field which will be removed entirely in the next release. GitHub repositories can no longer be enabled or disabled explicitly. Configure repositories to be mirrored via "repos", "exclude" and "repositoryQuery" instead.
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
 	// Orgs description: An array of organization names identifying GitHub organizations whose repositories should be mirrored on Sourcegraph.
@@ -861,6 +931,8 @@ type GitLabConnection struct {
 	// CloudGlobal description: When set to true, this external service will be chosen as our 'Global' GitLab service. Only valid on Sourcegraph.com. Only one service can have this flag set.
 	CloudGlobal bool `json:"cloudGlobal,omitempty"`
 	// Exclude description: A list of projects to never mirror from this GitLab instance. Takes precedence over "projects" and "projectQuery" configuration. Supports excluding by name ({"name": "group/name"}) or by ID ({"id": 42}).
+	//
+	// Also supports excluding by metadata reported by GitLab: size ({"sizeOverMB": 5000}), last push ({"pushedBefore": "2019-01-01"}) and topics ({"topics": ["deprecated"]}). All fields of an item that sets metadata fields, including the name, ID and pattern, must match for a repository to be excluded.
 	Exclude []*ExcludedGitLabProject `json:"exclude,omitempty"`
 	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this GitLab instance.
 	//
@@ -868,6 +940,8 @@ type GitLabConnection struct {
 	//
 	// If "ssh", Sourcegraph will access GitLab repositories using Git URLs of the form git@example.gitlab.com:myteam/myproject.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
 	GitURLType string `json:"gitURLType,omitempty"`
+	// Include description: A list of metadata rules restricting which projects are mirrored from GitLab. If set, only projects matching at least one of the rules are mirrored. All fields of a single rule must match. "exclude" takes precedence over "include".
+	Include []*IncludedGitLabProject `json:"include,omitempty"`
 	// InitialRepositoryEnablement description: Deprecated and ignored field which will be removed entirely in the next release. GitLab repositories can no longer be enabled or disabled explicitly.
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
 	// NameTransformations description: An array of transformations will apply to the repository name. Currently, only regex replacement is supported. All transformations happen after "repositoryPathPattern" is processed.
@@ -1020,12 +1094,47 @@ func (v *IdentityProvider) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"oauth", "username", "external"})
 }
 
-type ImportChangesets struct {
+// ImportChangesetsByID description: Specific changesets in a repository that are imported.
+type ImportChangesetsByID struct {
 	// ExternalIDs description: The changesets to import from the code host. For GitHub this is the PR number, for GitLab this is the MR number, for Bitbucket Server this is the PR number.
 	ExternalIDs []interface{} `json:"externalIDs"`
 	// Repository description: The repository name as configured on your Sourcegraph instance.
 	Repository string `json:"repository"`
 }
+
+// ImportChangesetsByQuery description: A code host search query. The pull requests and merge requests matching it on GitHub and GitLab are periodically imported, and detached again once they no longer match.
+type ImportChangesetsByQuery struct {
+	// CodeHost description: The URL of the code host to run the query on. If omitted, the query runs on every GitHub and GitLab code host that supports it.
+	CodeHost string `json:"codeHost,omitempty"`
+	// Query description: The search query, using the pull request search syntax of GitHub. On GitLab, the qualifiers author, assignee, label, is:open, is:closed, is:merged and free text are supported.
+	Query string `json:"query"`
+}
+type IncludedBitbucketCloudRepo struct {
+	// Languages description: Matches repositories whose primary language, as detected by Bitbucket Cloud, is one of the given languages (case-insensitive).
+	Languages []string `json:"languages,omitempty"`
+	// PushedBefore description: Matches repositories whose most recent commit, on any branch, was before the given date ("YYYY-MM-DD").
+	PushedBefore string `json:"pushedBefore,omitempty"`
+	// SizeOverMB description: Matches repositories whose size on Bitbucket Cloud is larger than the given number of megabytes.
+	SizeOverMB float64 `json:"sizeOverMB,omitempty"`
+}
+type IncludedGitHubRepo struct {
+	// Languages description: Matches repositories whose primary language, as detected by GitHub, is one of the given languages (case-insensitive).
+	Languages []string `json:"languages,omitempty"`
+	// PushedBefore description: Matches repositories last pushed to before the given date ("YYYY-MM-DD").
+	PushedBefore string `json:"pushedBefore,omitempty"`
+	// SizeOverMB description: Matches repositories whose size on GitHub is larger than the given number of megabytes.
+	SizeOverMB float64 `json:"sizeOverMB,omitempty"`
+	// Topics description: Matches repositories that have at least one of the given GitHub topics.
+	Topics []string `json:"topics,omitempty"`
+}
+type IncludedGitLabProject struct {
+	// PushedBefore description: Matches repositories whose most recent commit, on any branch, was before the given date ("YYYY-MM-DD").
+	PushedBefore string `json:"pushedBefore,omitempty"`
+	// SizeOverMB description: Matches repositories whose size on GitLab (as reported by the project statistics, which requires at least Reporter access) is larger than the given number of megabytes.
+	SizeOverMB float64 `json:"sizeOverMB,omitempty"`
+	// Topics description: Matches repositories that have at least one of the given GitLab topics.
+	Topics []string `json:"topics,omitempty"`
+}
 type Insight struct {
 	// Description description: The description of this insight
 	Description string `json:"description"`
@@ -1341,6 +1450,22 @@ type Overrides struct {
 	Limit interface{} `json:"limit,omitempty"`
 }
 
+// PHPPackagesConnection description: Configuration for a connection to PHP packages hosted on Packagist
+type PHPPackagesConnection struct {
+	// Dependencies description: An array of strings specifying Composer packages to mirror in Sourcegraph.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to the configured PHP repository APIs.
+	RateLimit *PHPRateLimit `json:"rateLimit,omitempty"`
+}
+
+// PHPRateLimit description: Rate limit applied when making background API requests to the configured PHP repository APIs.
+type PHPRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // PagureConnection description: Configuration for a connection to Pagure.
 type PagureConnection struct {
 	// Forks description: If true, it includes forks in the returned projects.
@@ -1508,6 +1633,22 @@ type Responders struct {
 	Username string `json:"username,omitempty"`
 }
 
+// RubyPackagesConnection description: Configuration for a connection to Ruby packages hosted on rubygems.org
+type RubyPackagesConnection struct {
+	// Dependencies description: An array of strings specifying Ruby gems to mirror in Sourcegraph.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to the configured Ruby repository APIs.
+	RateLimit *RubyRateLimit `json:"rateLimit,omitempty"`
+}
+
+// RubyRateLimit description: Rate limit applied when making background API requests to the configured Ruby repository APIs.
+type RubyRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // RustPackagesConnection description: Configuration for a connection to Rust packages
 type RustPackagesConnection struct {
 	// Dependencies description: An array of strings specifying Rust packages to mirror in Sourcegraph.
@@ -1823,6 +1964,8 @@ type SiteConfiguration struct {
 	BatchChangesDisableWebhooksWarning bool `json:"batchChanges.disableWebhooksWarning,omitempty"`
 	// BatchChangesEnabled description: Enables/disables the Batch Changes feature.
 	BatchChangesEnabled *bool `json:"batchChanges.enabled,omitempty"`
+	// BatchChangesEnableArtifacts description: Experimental: Allows the steps of batch specs to declare artifacts. Only enable this if the executors run a src-cli version that collects artifacts, since they are not collected otherwise.
+	BatchChangesEnableArtifacts bool `json:"batchChanges.enableArtifacts,omitempty"`
 	// BatchChangesEnforceForks description: When enabled, all branches created by batch changes will be pushed to forks of the original repository.
 	BatchChangesEnforceForks bool `json:"batchChanges.enforceForks,omitempty"`
 	// BatchChangesRestrictToAdmins description: When enabled, only site admins can create and apply batch changes.
@@ -1989,8 +2132,42 @@ type SiteConfiguration struct {
 	WebhookLogging *WebhookLogging `json:"webhook.logging,omitempty"`
 }
 
+// SourcegraphConnection description: Configuration for a connection to another Sourcegraph instance, whose repositories are mirrored from it.
+type SourcegraphConnection struct {
+	// Exclude description: A list of repositories to never mirror from the remote instance. Takes precedence over "repositoryQuery".
+	Exclude []*ExcludedSourcegraphRepo `json:"exclude,omitempty"`
+	// GitURL description: Base URL of the git smart-HTTP endpoint of the remote Sourcegraph instance that the gitservers of this instance clone from. This is either the internal API of the remote frontend (`/.internal/git`) or the `/git` endpoint of a remote gitserver. Neither endpoint is authenticated, so they must only be reachable from a private network.
+	GitURL string `json:"gitURL"`
+	// RateLimit description: Rate limit applied when making API requests to the remote Sourcegraph instance.
+	RateLimit *SourcegraphRateLimit `json:"rateLimit,omitempty"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for the repositories of the remote instance. In the pattern, the variable "{host}" is replaced with the host of the remote instance's URL, and "{name}" is replaced with the repository's name on the remote instance.
+	//
+	// For example, if the remote instance is https://sourcegraph.example.com, a repositoryPathPattern of "{host}/{name}" would mean that the remote repository github.com/myorg/myrepo is available on this instance as sourcegraph.example.com/github.com/myorg/myrepo.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// RepositoryQuery description: An array of repository search queries, in the search query syntax of the remote Sourcegraph instance, that select the repositories to mirror. The union of the matched repositories is mirrored. Queries may only use filters that apply to repositories, such as `repo:`, `fork:`, `archived:`, `visibility:` and `repo:has.file()`.
+	//
+	// If empty, all repositories on the remote instance are mirrored.
+	RepositoryQuery []string `json:"repositoryQuery,omitempty"`
+	// Token description: An access token of a user on the remote Sourcegraph instance. Only the repositories visible to this user are mirrored. If empty, the GraphQL API is accessed anonymously.
+	Token string `json:"token,omitempty"`
+	// Url description: URL of the remote Sourcegraph instance, such as https://sourcegraph.example.com. Repositories are listed through its GraphQL API.
+	Url string `json:"url"`
+}
+
+// SourcegraphRateLimit description: Rate limit applied when making API requests to the remote Sourcegraph instance.
+type SourcegraphRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 500, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 500 requests immediately.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
+
 // Step description: A command to run (as part of a sequence) in a repository branch to produce the required changes.
 type Step struct {
+	// Artifacts description: Artifacts of this step that are collected from every workspace into a report of the batch change, also for workspaces that produce no changes.
+	Artifacts map[string]ArtifactDefinition `json:"artifacts,omitempty"`
 	// Container description: The Docker image used to launch the Docker container in which the shell command is run.
 	Container string `json:"container"`
 	// Env description: Environment variables to set in the step environment.
@@ -2108,5 +2285,7 @@ type WorkspaceConfiguration struct {
 	// OnlyFetchWorkspace description: If this is true only the files in the workspace (and additional .gitignore) are downloaded instead of an archive of the full repository.
 	OnlyFetchWorkspace bool `json:"onlyFetchWorkspace,omitempty"`
 	// RootAtLocationOf description: The name of the file that sits at the root of the desired workspace.
-	RootAtLocationOf string `json:"rootAtLocationOf"`
+	RootAtLocationOf string `json:"rootAtLocationOf,omitempty"`
+	// SplitByCodeOwners description: If this is true, the workspaces are split up by the owners of the files matched by the repositoriesMatchingQuery search, as defined in the CODEOWNERS file of the repository. Each owner gets a workspace, and thus a changeset, that only contains the changes to the files they own.
+	SplitByCodeOwners bool `json:"splitByCodeOwners,omitempty"`
 }
@@ -58,6 +58,14 @@ var PythonPackagesSchemaJSON string
 //go:embed rust-packages.schema.json
 var RustPackagesSchemaJSON string
 
+// RubyPackagesSchemaJSON is the content of the file "ruby-packages.schema.json".
+//go:embed ruby-packages.schema.json
+var RubyPackagesSchemaJSON string
+
+// PHPPackagesSchemaJSON is the content of the file "php-packages.schema.json".
+//go:embed php-packages.schema.json
+var PHPPackagesSchemaJSON string
+
 // OtherExternalServiceSchemaJSON is the content of the file "other_external_service.schema.json".
 //go:embed other_external_service.schema.json
 var OtherExternalServiceSchemaJSON string
@@ -74,6 +82,10 @@ var PhabricatorSchemaJSON string
 //go:embed pagure.schema.json
 var PagureSchemaJSON string
 
+// SourcegraphSchemaJSON is the content of the file "sourcegraph.schema.json".
+//go:embed sourcegraph.schema.json
+var SourcegraphSchemaJSON string
+
 // SettingsSchemaJSON is the content of the file "settings.schema.json".
 //go:embed settings.schema.json
 var SettingsSchemaJSON string